@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/middleware"
+	"Aicon-assignment/internal/usecase"
+)
+
+// API サーバーを介して操作する
+type apiClient struct {
+	baseURL    string
+	actor      string
+	httpClient *http.Client
+}
+
+func newAPIClient(baseURL, actor string, timeout time.Duration) *apiClient {
+	return &apiClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		actor:      actor,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// API のエラーレスポンス
+type apiError struct {
+	Status  int
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Details []string `json:"details"`
+}
+
+func (e *apiError) Error() string {
+	message := fmt.Sprintf("%s (HTTP %d)", e.Message, e.Status)
+	if e.Code != "" {
+		message = fmt.Sprintf("%s (HTTP %d %s)", e.Message, e.Status, e.Code)
+	}
+	if len(e.Details) > 0 {
+		message += ": " + strings.Join(e.Details, ", ")
+	}
+	return message
+}
+
+func (c *apiClient) List(ctx context.Context, keyword string, input usecase.ListItemsInput) (*usecase.ItemList, error) {
+	query := url.Values{}
+	setQuery(query, "limit", strconv.Itoa(input.Limit), input.Limit != 0)
+	setQuery(query, "offset", strconv.Itoa(input.Offset), input.Offset != 0)
+	setQuery(query, "sort", input.Sort, input.Sort != "")
+	setQuery(query, "order", input.Order, input.Order != "")
+	setQuery(query, "category", input.Category, input.Category != "")
+	setQuery(query, "tag", input.Tag, input.Tag != "")
+	setQuery(query, "uncategorized", "true", input.Uncategorized)
+
+	path := "/items"
+	if keyword != "" {
+		path = "/items/search"
+		query.Set("q", keyword)
+	}
+
+	var list usecase.ItemList
+	if err := c.do(ctx, http.MethodGet, path, query, nil, "", &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (c *apiClient) Get(ctx context.Context, id int64) (*entity.Item, error) {
+	var item entity.Item
+	if err := c.do(ctx, http.MethodGet, itemPath(id), nil, nil, "", &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (c *apiClient) Create(ctx context.Context, input usecase.CreateItemInput) (*usecase.ItemResult, error) {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	setQuery(query, "strict", "true", input.Strict)
+
+	var result usecase.ItemResult
+	if err := c.do(ctx, http.MethodPost, "/items", query, bytes.NewReader(body), "application/json", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *apiClient) Update(ctx context.Context, id int64, input usecase.UpdateItemInput) (*usecase.ItemResult, error) {
+	body, err := json.Marshal(updateRequestBody(input))
+	if err != nil {
+		return nil, err
+	}
+
+	var result usecase.ItemResult
+	if err := c.do(ctx, http.MethodPatch, itemPath(id), nil, bytes.NewReader(body), "application/json", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 部分更新のリクエストボディ（ブランド・購入した国・地域・税額は指定した場合のみ含め、空にする場合は null とする）
+func updateRequestBody(input usecase.UpdateItemInput) map[string]interface{} {
+	body := map[string]interface{}{}
+	if input.Name != nil {
+		body["name"] = *input.Name
+	}
+	if input.Brand.Set {
+		body["brand"] = input.Brand.Value
+	}
+	if input.Category != nil {
+		body["category"] = *input.Category
+	}
+	if input.PurchasePrice != nil {
+		body["purchase_price"] = *input.PurchasePrice
+	}
+	if input.PurchaseDate != nil {
+		body["purchase_date"] = *input.PurchaseDate
+	}
+	if input.Attributes != nil {
+		body["attributes"] = input.Attributes
+	}
+	if input.PurchaseCountry.Set {
+		body["purchase_country"] = input.PurchaseCountry.Value
+	}
+	if input.TaxAmount.Set {
+		body["tax_amount"] = input.TaxAmount.Value
+	}
+	if input.TaxIncluded != nil {
+		body["tax_included"] = *input.TaxIncluded
+	}
+	if input.TaxRate != nil {
+		body["tax_rate"] = *input.TaxRate
+	}
+	return body
+}
+
+func (c *apiClient) Delete(ctx context.Context, id int64) error {
+	return c.do(ctx, http.MethodDelete, itemPath(id), nil, nil, "", nil)
+}
+
+func (c *apiClient) Import(ctx context.Context, fileName, format string, file io.Reader) (*usecase.ImportResult, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if format != "" {
+		if err := writer.WriteField("format", format); err != nil {
+			return nil, err
+		}
+	}
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var result usecase.ImportResult
+	if err := c.do(ctx, http.MethodPost, "/items/import", nil, &body, writer.FormDataContentType(), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *apiClient) Export(ctx context.Context, w io.Writer, options exportOptions) error {
+	query := url.Values{}
+	setQuery(query, "format", options.Format, options.Format != "")
+	setQuery(query, "bom", "true", options.BOM)
+	setQuery(query, "locale", options.Locale, options.Locale != "")
+
+	return c.do(ctx, http.MethodGet, "/items/export", query, nil, "", w)
+}
+
+// リクエストを送信し、レスポンスを out に読み込む（io.Writer の場合はそのまま書き込む）
+func (c *apiClient) do(ctx context.Context, method, path string, query url.Values, body io.Reader, contentType string, out interface{}) error {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.actor != "" {
+		req.Header.Set(middleware.ActorHeader, c.actor)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &apiError{Status: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil || apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return apiErr
+	}
+
+	switch out := out.(type) {
+	case nil:
+		return nil
+	case io.Writer:
+		_, err := io.Copy(out, resp.Body)
+		return err
+	default:
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+}
+
+func itemPath(id int64) string {
+	return "/items/" + strconv.FormatInt(id, 10)
+}
+
+func setQuery(query url.Values, key, value string, ok bool) {
+	if ok {
+		query.Set(key, value)
+	}
+}
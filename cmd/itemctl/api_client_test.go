@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIClient_List(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/items/search", r.URL.Path)
+		assert.Equal(t, "rolex", r.URL.Query().Get("q"))
+		assert.Equal(t, "watch", r.URL.Query().Get("category"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		assert.Equal(t, "alice", r.Header.Get("X-User-ID"))
+		_ = json.NewEncoder(w).Encode(usecase.ItemList{
+			Items: []*entity.Item{{ID: 1, Name: "ロレックス"}},
+			Total: 1,
+			Limit: 10,
+		})
+	}))
+	defer server.Close()
+
+	client := newAPIClient(server.URL, "alice", 0)
+	list, err := client.List(context.Background(), "rolex", usecase.ListItemsInput{Limit: 10, Category: "watch"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Total)
+	assert.Equal(t, "ロレックス", list.Items[0].Name)
+}
+
+func TestAPIClient_Update(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/items/3", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_ = json.NewEncoder(w).Encode(usecase.ItemResult{Item: &entity.Item{ID: 3, Name: "新しい名前"}})
+	}))
+	defer server.Close()
+
+	name := "新しい名前"
+	client := newAPIClient(server.URL, "", 0)
+	result, err := client.Update(context.Background(), 3, usecase.UpdateItemInput{
+		Name:  &name,
+		Brand: usecase.NullableString{Set: true},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.ID)
+	// 指定した項目のみ送り、ブランドは null で空にする
+	assert.Equal(t, map[string]interface{}{"name": "新しい名前", "brand": nil}, body)
+}
+
+func TestAPIClient_Import(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/items/import", r.URL.Path)
+		assert.Equal(t, "csv", r.FormValue("format"))
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+		content, _ := io.ReadAll(file)
+		assert.Equal(t, "items.csv", header.Filename)
+		assert.Equal(t, "name\n", string(content))
+		_ = json.NewEncoder(w).Encode(usecase.ImportResult{Accepted: []usecase.ImportedRow{{Row: 2, ID: 5}}})
+	}))
+	defer server.Close()
+
+	client := newAPIClient(server.URL, "", 0)
+	result, err := client.Import(context.Background(), "items.csv", "csv", strings.NewReader("name\n"))
+
+	require.NoError(t, err)
+	assert.Len(t, result.Accepted, 1)
+}
+
+func TestAPIClient_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":"VALIDATION_FAILED","message":"validation failed","details":["name is required"]}`))
+	}))
+	defer server.Close()
+
+	client := newAPIClient(server.URL, "", 0)
+	_, err := client.Create(context.Background(), usecase.CreateItemInput{})
+
+	require.Error(t, err)
+	assert.Equal(t, "validation failed (HTTP 400 VALIDATION_FAILED): name is required", err.Error())
+}
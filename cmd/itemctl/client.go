@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"io"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// アイテムの操作先（API サーバー、またはデータベースを直接操作するユースケース）
+type itemClient interface {
+	List(ctx context.Context, keyword string, input usecase.ListItemsInput) (*usecase.ItemList, error)
+	Get(ctx context.Context, id int64) (*entity.Item, error)
+	Create(ctx context.Context, input usecase.CreateItemInput) (*usecase.ItemResult, error)
+	Update(ctx context.Context, id int64, input usecase.UpdateItemInput) (*usecase.ItemResult, error)
+	Delete(ctx context.Context, id int64) error
+	Import(ctx context.Context, fileName, format string, file io.Reader) (*usecase.ImportResult, error)
+	Export(ctx context.Context, w io.Writer, options exportOptions) error
+}
+
+// エクスポートの指定（API の /items/export のクエリパラメーターと同じ）
+type exportOptions struct {
+	Format string
+	BOM    bool
+	Locale string
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/config"
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/infrastructure/server"
+	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+// データベースを直接操作する（API サーバーを介さない）
+// アイテムのユースケースはサーバーと同じ設定で組み立てる（監査ログ・版・予算・利用上限・削除の方針・キャッシュの削除・検索・Webhook を含む）
+type dbClient struct {
+	itemUsecase usecase.ItemUsecase
+	close       func() error
+}
+
+func newDBClient() (client *dbClient, err error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	// 接続時のログを出力結果（JSON など）に混ぜないよう、組み立て中は標準出力を標準エラー出力に向ける
+	var dbHandler itemDatabase.SqlHandler
+	var items *server.ItemComponents
+	err = toStderr(func() error {
+		dbHandler = databaseInfra.NewSqlHandler(cfg)
+
+		ctx := context.Background()
+		imageStorage, err := server.NewImageStorage(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		items, err = server.NewItemComponents(ctx, cfg, server.ItemDependencies{
+			DBHandler:    dbHandler,
+			ImageStorage: imageStorage,
+			Clock:        entity.SystemClock,
+			ReadOnly:     usecase.NewReadOnlySwitch(cfg.ReadOnly),
+		})
+		return err
+	})
+	if err != nil {
+		if dbHandler != nil {
+			dbHandler.Close()
+		}
+		return nil, err
+	}
+
+	return &dbClient{
+		itemUsecase: items.Usecase,
+		close: func() error {
+			return errors.Join(items.Close(), dbHandler.Close())
+		},
+	}, nil
+}
+
+// fn の実行中は標準出力を標準エラー出力に向ける（接続に失敗した場合の panic はエラーとして返す）
+func toStderr(fn func() error) (err error) {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() {
+		os.Stdout = stdout
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	return fn()
+}
+
+func (c *dbClient) List(ctx context.Context, keyword string, input usecase.ListItemsInput) (*usecase.ItemList, error) {
+	if keyword != "" {
+		return c.itemUsecase.SearchItems(ctx, keyword, input)
+	}
+	return c.itemUsecase.GetAllItems(ctx, input)
+}
+
+func (c *dbClient) Get(ctx context.Context, id int64) (*entity.Item, error) {
+	return c.itemUsecase.GetItemByID(ctx, id)
+}
+
+func (c *dbClient) Create(ctx context.Context, input usecase.CreateItemInput) (*usecase.ItemResult, error) {
+	return c.itemUsecase.CreateItem(ctx, input)
+}
+
+func (c *dbClient) Update(ctx context.Context, id int64, input usecase.UpdateItemInput) (*usecase.ItemResult, error) {
+	return c.itemUsecase.UpdateItem(ctx, id, input)
+}
+
+func (c *dbClient) Delete(ctx context.Context, id int64) error {
+	return c.itemUsecase.DeleteItem(ctx, id)
+}
+
+func (c *dbClient) Import(ctx context.Context, fileName, format string, file io.Reader) (*usecase.ImportResult, error) {
+	return c.itemUsecase.ImportItems(ctx, usecase.ImportItemsInput{Format: format, File: file})
+}
+
+func (c *dbClient) Export(ctx context.Context, w io.Writer, options exportOptions) error {
+	input := usecase.ExportItemsInput{Format: options.Format, BOM: options.BOM}
+	if options.Locale != "" {
+		formatter, err := presenter.NewFormatter(options.Locale)
+		if err != nil {
+			return err
+		}
+		input.Formatter = formatter
+	}
+	return c.itemUsecase.ExportItems(ctx, w, input)
+}
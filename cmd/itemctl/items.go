@@ -0,0 +1,323 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/spf13/cobra"
+)
+
+func newListCommand(opts *rootOptions) *cobra.Command {
+	var (
+		keyword string
+		input   usecase.ListItemsInput
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "アイテムの一覧を表示する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd, func(ctx context.Context, client itemClient) error {
+				list, err := client.List(ctx, keyword, input)
+				if err != nil {
+					return err
+				}
+				if opts.output == outputJSON {
+					return writeJSON(cmd.OutOrStdout(), list)
+				}
+				if err := writeItemTable(cmd.OutOrStdout(), list.Items); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "\n%d of %d items\n", len(list.Items), list.Total)
+				return nil
+			})
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&keyword, "query", "q", "", "キーワードで検索する")
+	flags.IntVar(&input.Limit, "limit", 0, "取得件数")
+	flags.IntVar(&input.Offset, "offset", 0, "取得開始位置")
+	flags.StringVar(&input.Sort, "sort", "", "並び替えの項目")
+	flags.StringVar(&input.Order, "order", "", "並び順（asc または desc）")
+	flags.StringVar(&input.Category, "category", "", "カテゴリーで絞り込む")
+	flags.StringVar(&input.Tag, "tag", "", "タグで絞り込む")
+	flags.BoolVar(&input.Uncategorized, "uncategorized", false, "未分類のアイテムのみに絞り込む")
+	return cmd
+}
+
+func newGetCommand(opts *rootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "アイテムを表示する",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			return opts.run(cmd, func(ctx context.Context, client itemClient) error {
+				item, err := client.Get(ctx, id)
+				if err != nil {
+					return err
+				}
+				if opts.output == outputJSON {
+					return writeJSON(cmd.OutOrStdout(), item)
+				}
+				return writeItemTable(cmd.OutOrStdout(), []*entity.Item{item})
+			})
+		},
+	}
+}
+
+func newCreateCommand(opts *rootOptions) *cobra.Command {
+	var (
+		input      usecase.CreateItemInput
+		attributes map[string]string
+		tax        int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "アイテムを登録する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input.Attributes = attributes
+			if cmd.Flags().Changed("tax") {
+				input.TaxAmount = &tax
+			}
+			return opts.run(cmd, func(ctx context.Context, client itemClient) error {
+				result, err := client.Create(ctx, input)
+				if err != nil {
+					return err
+				}
+				return writeItem(cmd.OutOrStdout(), opts.output, result.Item, result.Warnings)
+			})
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&input.Name, "name", "", "名前")
+	flags.StringVar(&input.Category, "category", "", "カテゴリー")
+	flags.StringVar(&input.Brand, "brand", "", "ブランド")
+	flags.Int64Var(&input.PurchasePrice.Amount, "price", 0, "購入価格（通貨の最小単位）")
+	flags.StringVar(&input.PurchasePrice.Currency, "currency", entity.DefaultCurrency, "購入価格の通貨")
+	flags.StringVar(&input.PurchaseDate, "purchase-date", "", "購入日（YYYY-MM-DD）")
+	flags.StringToStringVar(&attributes, "attr", nil, "カテゴリー固有の属性（key=value）")
+	flags.StringVar(&input.PurchaseCountry, "country", "", "購入した国・地域（ISO 3166-1 alpha-2）")
+	flags.Int64Var(&tax, "tax", 0, "支払った税額（購入価格と同じ通貨の最小単位）")
+	flags.BoolVar(&input.TaxIncluded, "tax-included", false, "購入価格に税額を含む")
+	flags.StringVar(&input.TaxRate, "tax-rate", "", "税率（%）。購入価格から税額を計算する")
+	flags.BoolVar(&input.Draft, "draft", false, "下書きとして保存する")
+	flags.BoolVar(&input.Strict, "strict", false, "重複する可能性がある場合にエラーにする")
+	cmd.MarkFlagsMutuallyExclusive("tax", "tax-rate")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func newUpdateCommand(opts *rootOptions) *cobra.Command {
+	var (
+		name, brand, category, currency, purchaseDate, country, taxRate string
+		price, tax                                                      int64
+		clearBrand, clearCountry, taxIncluded, clearTax                 bool
+		attributes                                                      map[string]string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update ID",
+		Short: "アイテムを部分更新する（指定した項目のみ変更する）",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			var input usecase.UpdateItemInput
+			if flags.Changed("name") {
+				input.Name = &name
+			}
+			if flags.Changed("brand") {
+				input.Brand = usecase.NewNullableString(brand)
+			}
+			if clearBrand {
+				input.Brand = usecase.NullableString{Set: true}
+			}
+			if flags.Changed("category") {
+				input.Category = &category
+			}
+			if flags.Changed("price") || flags.Changed("currency") {
+				input.PurchasePrice = &entity.Money{Amount: price, Currency: currency}
+			}
+			if flags.Changed("purchase-date") {
+				input.PurchaseDate = &purchaseDate
+			}
+			if flags.Changed("attr") {
+				input.Attributes = attributes
+			}
+			if flags.Changed("country") {
+				input.PurchaseCountry = usecase.NewNullableString(country)
+			}
+			if clearCountry {
+				input.PurchaseCountry = usecase.NullableString{Set: true}
+			}
+			if flags.Changed("tax") {
+				input.TaxAmount = usecase.NewNullableInt64(tax)
+			}
+			if clearTax {
+				input.TaxAmount = usecase.NullableInt64{Set: true}
+			}
+			if flags.Changed("tax-included") {
+				input.TaxIncluded = &taxIncluded
+			}
+			if flags.Changed("tax-rate") {
+				input.TaxRate = &taxRate
+			}
+
+			return opts.run(cmd, func(ctx context.Context, client itemClient) error {
+				result, err := client.Update(ctx, id, input)
+				if err != nil {
+					return err
+				}
+				return writeItem(cmd.OutOrStdout(), opts.output, result.Item, result.Warnings)
+			})
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&name, "name", "", "名前")
+	flags.StringVar(&brand, "brand", "", "ブランド")
+	flags.BoolVar(&clearBrand, "clear-brand", false, "ブランドを空にする")
+	flags.StringVar(&category, "category", "", "カテゴリー")
+	flags.Int64Var(&price, "price", 0, "購入価格（通貨の最小単位）")
+	flags.StringVar(&currency, "currency", entity.DefaultCurrency, "購入価格の通貨")
+	flags.StringVar(&purchaseDate, "purchase-date", "", "購入日（YYYY-MM-DD）")
+	flags.StringToStringVar(&attributes, "attr", nil, "カテゴリー固有の属性（key=value）")
+	flags.StringVar(&country, "country", "", "購入した国・地域（ISO 3166-1 alpha-2）")
+	flags.BoolVar(&clearCountry, "clear-country", false, "購入した国・地域を未設定にする")
+	flags.Int64Var(&tax, "tax", 0, "支払った税額（購入価格と同じ通貨の最小単位）")
+	flags.BoolVar(&clearTax, "clear-tax", false, "税額を未記録にする")
+	flags.BoolVar(&taxIncluded, "tax-included", false, "購入価格に税額を含む")
+	flags.StringVar(&taxRate, "tax-rate", "", "税率（%）。変更後の購入価格から税額を計算する")
+	cmd.MarkFlagsMutuallyExclusive("brand", "clear-brand")
+	cmd.MarkFlagsMutuallyExclusive("country", "clear-country")
+	cmd.MarkFlagsMutuallyExclusive("tax", "clear-tax", "tax-rate")
+	return cmd
+}
+
+func newDeleteCommand(opts *rootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete ID",
+		Short: "アイテムを削除する",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			return opts.run(cmd, func(ctx context.Context, client itemClient) error {
+				if err := client.Delete(ctx, id); err != nil {
+					return err
+				}
+				if opts.output == outputJSON {
+					return writeJSON(cmd.OutOrStdout(), map[string]int64{"deleted": id})
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "deleted item %d\n", id)
+				return nil
+			})
+		},
+	}
+}
+
+func newImportCommand(opts *rootOptions) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "CSV/XLSX ファイルからアイテムを一括登録する",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			// 形式の指定がなければ拡張子から判断する（API と同じ）
+			if format == "" {
+				format = strings.TrimPrefix(strings.ToLower(filepath.Ext(args[0])), ".")
+			}
+
+			return opts.run(cmd, func(ctx context.Context, client itemClient) error {
+				result, err := client.Import(ctx, filepath.Base(args[0]), format, file)
+				if err != nil {
+					return err
+				}
+				if opts.output == outputJSON {
+					return writeJSON(cmd.OutOrStdout(), result)
+				}
+				out := cmd.OutOrStdout()
+				fmt.Fprintf(out, "accepted: %d, rejected: %d\n", len(result.Accepted), len(result.Rejected))
+				for _, row := range result.Rejected {
+					fmt.Fprintf(out, "  row %d: %s\n", row.Row, strings.Join(row.Errors, "; "))
+				}
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "", "ファイル形式（csv または xlsx、省略時は拡張子から判断）")
+	return cmd
+}
+
+func newExportCommand(opts *rootOptions) *cobra.Command {
+	var (
+		options  exportOptions
+		fileName string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "アイテムを CSV/XLSX で出力する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd, func(ctx context.Context, client itemClient) error {
+				var w io.Writer = cmd.OutOrStdout()
+				if fileName != "" {
+					file, err := os.Create(fileName)
+					if err != nil {
+						return err
+					}
+					defer file.Close()
+					w = file
+				}
+				return client.Export(ctx, w, options)
+			})
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.Format, "format", "csv", "ファイル形式（csv または xlsx）")
+	flags.BoolVar(&options.BOM, "bom", false, "CSV の先頭に BOM を付ける（Excel 向け）")
+	flags.StringVar(&options.Locale, "locale", "", "金額・日付の表記（例: ja-JP）")
+	flags.StringVarP(&fileName, "file", "f", "", "出力先のファイル（省略時は標準出力）")
+	return cmd
+}
+
+func parseID(s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid item ID: %s", s)
+	}
+	return id, nil
+}
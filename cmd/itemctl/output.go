@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// アイテムを表形式で出力する
+func writeItemTable(w io.Writer, items []*entity.Item) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tCATEGORY\tBRAND\tPRICE\tPURCHASE DATE\tTAGS\tSTATUS")
+	for _, item := range items {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			item.ID,
+			item.Name,
+			item.Category,
+			item.Brand,
+			item.PurchasePrice.String(),
+			item.PurchaseDate,
+			strings.Join(item.Tags, ","),
+			itemStatus(item),
+		)
+	}
+	return tw.Flush()
+}
+
+func writeItem(w io.Writer, output string, item *entity.Item, warnings []string) error {
+	if output == outputJSON {
+		return writeJSON(w, usecase.ItemResult{Item: item, Warnings: warnings})
+	}
+	if err := writeItemTable(w, []*entity.Item{item}); err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		fmt.Fprintln(w, "Warning:", warning)
+	}
+	return nil
+}
+
+func itemStatus(item *entity.Item) string {
+	var status []string
+	if item.Draft {
+		status = append(status, "draft")
+	}
+	if item.OnHold {
+		status = append(status, "on hold")
+	}
+	if item.DeletedAt != nil {
+		status = append(status, "deleted")
+	}
+	return strings.Join(status, ",")
+}
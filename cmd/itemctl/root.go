@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/spf13/cobra"
+)
+
+// 操作先
+const (
+	modeAPI = "api" // API サーバーを介して操作する
+	modeDB  = "db"  // データベースを直接操作する
+)
+
+// 出力形式
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// 全サブコマンド共通のオプション
+type rootOptions struct {
+	mode    string
+	apiURL  string
+	actor   string
+	output  string
+	timeout time.Duration
+}
+
+func newRootCommand() *cobra.Command {
+	opts := &rootOptions{}
+
+	cmd := &cobra.Command{
+		Use:           "itemctl",
+		Short:         "所持品（アイテム）を管理するコマンドラインツール",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.mode != modeAPI && opts.mode != modeDB {
+				return fmt.Errorf("invalid --mode: %s (must be %s or %s)", opts.mode, modeAPI, modeDB)
+			}
+			if opts.output != outputTable && opts.output != outputJSON {
+				return fmt.Errorf("invalid --output: %s (must be %s or %s)", opts.output, outputTable, outputJSON)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&opts.mode, "mode", envOrDefault("ITEMCTL_MODE", modeAPI), "操作先（api: API サーバー、db: データベースを直接操作）")
+	flags.StringVar(&opts.apiURL, "api-url", envOrDefault("ITEMCTL_API_URL", "http://localhost:8080"), "API サーバーの URL（--mode=api）")
+	flags.StringVar(&opts.actor, "actor", envOrDefault("ITEMCTL_ACTOR", ""), "操作者（監査ログに記録する）")
+	flags.StringVarP(&opts.output, "output", "o", outputTable, "出力形式（table または json）")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "API リクエストのタイムアウト（--mode=api）")
+
+	cmd.AddCommand(
+		newListCommand(opts),
+		newGetCommand(opts),
+		newCreateCommand(opts),
+		newUpdateCommand(opts),
+		newDeleteCommand(opts),
+		newImportCommand(opts),
+		newExportCommand(opts),
+	)
+	return cmd
+}
+
+// 操作先のクライアントを作成し、fn を実行する
+func (o *rootOptions) run(cmd *cobra.Command, fn func(ctx context.Context, client itemClient) error) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if o.mode == modeAPI {
+		return fn(ctx, newAPIClient(o.apiURL, o.actor, o.timeout))
+	}
+
+	client, err := newDBClient()
+	if err != nil {
+		return err
+	}
+	defer client.close()
+
+	if o.actor != "" {
+		ctx = usecase.WithActor(ctx, o.actor)
+	}
+	return fn(ctx, client)
+}
+
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
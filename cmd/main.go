@@ -2,17 +2,111 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
 
+	"Aicon-assignment/internal/infrastructure/config"
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
 	"Aicon-assignment/internal/infrastructure/server"
+	itemDatabase "Aicon-assignment/internal/interfaces/database"
 )
 
 func main() {
-	ctx := context.Background()
+	// 不正な設定はすべて表示して起動しない
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("❌ Invalid configuration:\n%v", err)
+	}
+
+	// SIGINT / SIGTERM を受け取るとコンテキストをキャンセルし、処理中のリクエストを待ってから終了する
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 2回目のシグナルでは待たずに終了する
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	// マイグレーションのみ実行する（例: go run cmd/main.go migrate status）
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, cfg, os.Args[2:]); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		return
+	}
 
-	server := server.NewServer()
+	server := server.NewServer(cfg)
 
 	if err := server.Run(ctx); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+		log.Fatalf("Server stopped with error: %v", err)
+	}
+}
+
+const migrateUsage = "usage: main migrate up | down [N] | status"
+
+// マイグレーションのサブコマンド（migrate up / migrate down [N] / migrate status）
+func runMigrate(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(migrateUsage)
+	}
+
+	conn, err := databaseInfra.OpenDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	dialect := itemDatabase.Dialect(cfg.DBDriver)
+	migrator, err := databaseInfra.NewMigrator(conn, dialect, databaseInfra.Migrations(dialect))
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Printf("✅ Applied migration %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("✅ Database is up to date")
+		}
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of migrations to revert: %s", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Printf("✅ Reverted migration %04d_%s\n", migration.Version, migration.Name)
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf(migrateUsage)
 	}
 }
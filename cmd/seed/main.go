@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"Aicon-assignment/internal/infrastructure/config"
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/infrastructure/seed"
+	itemDatabase "Aicon-assignment/internal/interfaces/database"
+)
+
+// デモ用データを生成してデータベースに登録する
+func main() {
+	count := flag.Int("n", 100, "number of items to generate")
+	seedValue := flag.Int64("seed", 1, "random seed (same seed generates the same dataset)")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("❌ Invalid configuration:\n%v", err)
+	}
+
+	dbHandler := databaseInfra.NewSqlHandler(cfg)
+	defer dbHandler.Close()
+
+	itemRepo := &itemDatabase.ItemRepository{
+		SqlHandler: dbHandler,
+	}
+
+	for _, item := range seed.NewGenerator(*seedValue).Items(*count) {
+		if _, err := itemRepo.Create(ctx, item); err != nil {
+			log.Fatalf("Failed to seed item: %v", err)
+		}
+	}
+
+	fmt.Printf("✅ Seeded %d items (seed=%d)\n", *count, *seedValue)
+}
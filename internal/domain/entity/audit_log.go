@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"reflect"
+	"time"
+)
+
+// 監査ログに記録する操作の種類
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditRestore AuditAction = "restore"
+	AuditHold    AuditAction = "hold"
+	AuditRevert  AuditAction = "revert"
+	AuditPublish AuditAction = "publish"
+	AuditSell    AuditAction = "sell"
+	AuditSplit   AuditAction = "split"
+
+	AuditSchedulePurge AuditAction = "schedule_purge"
+	AuditCancelPurge   AuditAction = "cancel_purge"
+	AuditPurge         AuditAction = "purge"
+)
+
+// 変更された項目の変更前後の値（登録時の変更前、削除時の変更後は null）
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// アイテムへの変更の記録（誰が・いつ・何を変更したか）
+type AuditLog struct {
+	ID        int64                  `json:"id"`
+	ItemID    int64                  `json:"item_id"`
+	Action    AuditAction            `json:"action"`
+	Actor     string                 `json:"actor"`
+	Changes   map[string]FieldChange `json:"changes"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+func NewAuditLog(itemID int64, action AuditAction, actor string, changes map[string]FieldChange, now time.Time) *AuditLog {
+	if changes == nil {
+		changes = map[string]FieldChange{}
+	}
+	return &AuditLog{
+		ItemID:    itemID,
+		Action:    action,
+		Actor:     actor,
+		Changes:   changes,
+		CreatedAt: now,
+	}
+}
+
+// 監査ログで比較するアイテムの項目の値（変更前の値を保持できるよう、属性はコピーする）
+// nil の場合は nil を返す（登録前・削除後）
+func ItemAuditFields(item *Item) map[string]interface{} {
+	if item == nil {
+		return nil
+	}
+
+	var attributes map[string]string
+	if len(item.Attributes) > 0 {
+		attributes = make(map[string]string, len(item.Attributes))
+		for key, value := range item.Attributes {
+			attributes[key] = value
+		}
+	}
+
+	return map[string]interface{}{
+		"name":             item.Name,
+		"category":         item.Category,
+		"brand":            item.Brand,
+		"purchase_price":   item.PurchasePrice,
+		"purchase_date":    item.PurchaseDate,
+		"attributes":       attributes,
+		"purchase_country": item.PurchaseCountry,
+		"tax_amount":       item.TaxAmountValue(),
+		"tax_included":     item.TaxIncluded,
+		"on_hold":          item.OnHold,
+		"hold_reason":      item.HoldReason,
+		"draft":            item.Draft,
+		"sold":             item.Sold,
+		"split_from":       item.SplitFrom,
+		"purge_at":         item.PurgeAt,
+	}
+}
+
+// 変更前後の項目の値を比較し、値が異なる項目を返す
+func DiffAuditFields(before, after map[string]interface{}) map[string]FieldChange {
+	changes := map[string]FieldChange{}
+
+	for field, newValue := range after {
+		oldValue, ok := before[field]
+		if ok && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		if !ok && isZeroAuditValue(newValue) {
+			continue
+		}
+		changes[field] = FieldChange{Old: oldValue, New: newValue}
+	}
+	for field, oldValue := range before {
+		if _, ok := after[field]; !ok && !isZeroAuditValue(oldValue) {
+			changes[field] = FieldChange{Old: oldValue, New: nil}
+		}
+	}
+
+	return changes
+}
+
+// 登録時・削除時は、空の項目を変更として扱わない
+func isZeroAuditValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Map {
+		return v.IsNil() || v.Len() == 0
+	}
+	return v.IsZero()
+}
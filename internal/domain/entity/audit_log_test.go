@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffAuditFields(t *testing.T) {
+	item := &Item{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: JPY(1500000),
+		PurchaseDate:  MustParseDate("2023-01-15"),
+		Attributes:    map[string]string{"reference_number": "116500LN"},
+	}
+
+	t.Run("正常系: 変更された項目のみ", func(t *testing.T) {
+		before := ItemAuditFields(item)
+		updated := *item
+		updated.PurchasePrice = JPY(1600000)
+		updated.Attributes = map[string]string{"reference_number": "126500LN"}
+
+		changes := DiffAuditFields(before, ItemAuditFields(&updated))
+
+		assert.Equal(t, map[string]FieldChange{
+			"purchase_price": {Old: JPY(1500000), New: JPY(1600000)},
+			"attributes": {
+				Old: map[string]string{"reference_number": "116500LN"},
+				New: map[string]string{"reference_number": "126500LN"},
+			},
+		}, changes)
+	})
+
+	t.Run("正常系: 変更前の値は後からの変更の影響を受けない", func(t *testing.T) {
+		copied := *item
+		copied.Attributes = map[string]string{"reference_number": "116500LN"}
+		before := ItemAuditFields(&copied)
+		copied.Attributes["reference_number"] = "changed"
+
+		assert.Equal(t, map[string]string{"reference_number": "116500LN"}, before["attributes"])
+	})
+
+	t.Run("正常系: 登録時は空でない項目を新しい値として記録", func(t *testing.T) {
+		changes := DiffAuditFields(nil, ItemAuditFields(item))
+
+		assert.Equal(t, FieldChange{Old: nil, New: "ROLEX"}, changes["brand"])
+		assert.Contains(t, changes, "attributes")
+		assert.NotContains(t, changes, "on_hold")
+		assert.NotContains(t, changes, "hold_reason")
+	})
+
+	t.Run("正常系: 削除時は変更後を null として記録", func(t *testing.T) {
+		changes := DiffAuditFields(ItemAuditFields(item), nil)
+
+		assert.Equal(t, FieldChange{Old: "ロレックス デイトナ", New: nil}, changes["name"])
+		assert.NotContains(t, changes, "on_hold")
+	})
+
+	t.Run("正常系: 変更がない場合は空", func(t *testing.T) {
+		assert.Empty(t, DiffAuditFields(ItemAuditFields(item), ItemAuditFields(item)))
+	})
+}
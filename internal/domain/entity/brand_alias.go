@@ -0,0 +1,128 @@
+package entity
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ブランドの別名（表記ゆれ）と正式な表記。登録・更新時にブランドを正式な表記に揃える
+// 管理者が登録した別名は組み込みの辞書より優先する
+type BrandAlias struct {
+	Alias     string     `json:"alias"`
+	Brand     string     `json:"brand"`
+	Builtin   bool       `json:"builtin"`              // 組み込みの辞書の別名
+	UpdatedAt *time.Time `json:"updated_at,omitempty"` // 管理者が登録した日時（組み込みの場合は nil）
+}
+
+// 組み込みのブランドの別名（正式な表記自体の表記ゆれは辞書の作成時に追加する）
+var builtinBrandAliases = map[string]string{
+	"ロレックス":         "ROLEX",
+	"オメガ":           "OMEGA",
+	"パテック フィリップ":    "Patek Philippe",
+	"アップル":          "Apple",
+	"エルメス":          "HERMÈS",
+	"Hermes":        "HERMÈS",
+	"シャネル":          "CHANEL",
+	"ルイ・ヴィトン":       "LOUIS VUITTON",
+	"ティファニー":        "Tiffany & Co.",
+	"Tiffany":       "Tiffany & Co.",
+	"カルティエ":         "Cartier",
+	"ヴァン クリーフ&アーペル": "Van Cleef & Arpels",
+	"ルブタン":          "Christian Louboutin",
+	"クリスチャン ルブタン":   "Christian Louboutin",
+	"Louboutin":     "Christian Louboutin",
+	"ジョンロブ":         "JOHN LOBB",
+	"ナイキ":           "NIKE",
+	"モンブラン":         "Montblanc",
+	"ライカ":           "Leica",
+}
+
+func NewBrandAlias(alias, brand string, now time.Time) (*BrandAlias, error) {
+	a := &BrandAlias{
+		Alias:     strings.TrimSpace(alias),
+		Brand:     strings.TrimSpace(brand),
+		UpdatedAt: &now,
+	}
+
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *BrandAlias) Validate() error {
+	var errs []string
+
+	if a.Key() == "" {
+		errs = append(errs, "alias must contain a letter or digit")
+	} else if len(a.Alias) > 100 {
+		errs = append(errs, "alias must be 100 characters or less")
+	}
+
+	if a.Brand == "" {
+		errs = append(errs, "brand is required")
+	} else if len(a.Brand) > 100 {
+		errs = append(errs, "brand must be 100 characters or less")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// 表記ゆれを吸収した比較用のキー（全角・半角、大文字・小文字、空白・記号の違いは同じ別名とみなす）
+func (a *BrandAlias) Key() string {
+	return NormalizeForComparison(a.Alias)
+}
+
+// 組み込みの辞書の別名（別名の順）
+func BuiltinBrandAliases() []*BrandAlias {
+	aliases := make([]*BrandAlias, 0, len(builtinBrandAliases))
+	for alias, brand := range builtinBrandAliases {
+		aliases = append(aliases, &BrandAlias{Alias: alias, Brand: brand, Builtin: true})
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Alias < aliases[j].Alias })
+	return aliases
+}
+
+// 別名から正式な表記を引く辞書
+type BrandDictionary struct {
+	brands map[string]string
+}
+
+// 組み込みの辞書に管理者が登録した別名を重ねた辞書を作成する
+// 正式な表記の表記ゆれ（"rolex" など）もその表記に揃える
+func NewBrandDictionary(overrides []*BrandAlias) *BrandDictionary {
+	d := &BrandDictionary{brands: make(map[string]string)}
+	for _, alias := range BuiltinBrandAliases() {
+		d.add(alias.Brand, alias.Brand)
+		d.add(alias.Alias, alias.Brand)
+	}
+	for _, alias := range overrides {
+		d.add(alias.Brand, alias.Brand)
+	}
+	for _, alias := range overrides {
+		d.add(alias.Alias, alias.Brand)
+	}
+	return d
+}
+
+func (d *BrandDictionary) add(alias, brand string) {
+	if key := NormalizeForComparison(alias); key != "" {
+		d.brands[key] = brand
+	}
+}
+
+// ブランドを正式な表記にする（辞書にない場合は前後の空白を除去したまま返す）
+func (d *BrandDictionary) Normalize(brand string) string {
+	brand = strings.TrimSpace(brand)
+	if canonical, ok := d.brands[NormalizeForComparison(brand)]; ok {
+		return canonical
+	}
+	return brand
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrandDictionary_Normalize(t *testing.T) {
+	custom, err := NewBrandAlias("ﾛﾚｯｸｽ", "Rolex", time.Now())
+	require.NoError(t, err)
+	added, err := NewBrandAlias("パネライ", "PANERAI", time.Now())
+	require.NoError(t, err)
+	dictionary := NewBrandDictionary([]*BrandAlias{custom, added})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "正常系: 組み込みの別名", input: "エルメス", expected: "HERMÈS"},
+		{name: "正常系: 正式な表記の表記ゆれ", input: "tiffany & co", expected: "Tiffany & Co."},
+		{name: "正常系: 全角・空白の違いを吸収する", input: " ＣＨＡＮＥＬ ", expected: "CHANEL"},
+		{name: "正常系: 管理者の別名は組み込みより優先する", input: "ロレックス", expected: "Rolex"},
+		{name: "正常系: 管理者が追加した別名", input: "パネライ", expected: "PANERAI"},
+		{name: "正常系: 辞書にないブランドは空白の除去のみ", input: " GRAND SEIKO ", expected: "GRAND SEIKO"},
+		{name: "正常系: 空のブランド", input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dictionary.Normalize(tt.input))
+		})
+	}
+}
+
+func TestNewBrandAlias(t *testing.T) {
+	alias, err := NewBrandAlias(" エルメス ", " HERMÈS ", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "エルメス", alias.Alias)
+	assert.Equal(t, "HERMÈS", alias.Brand)
+	assert.False(t, alias.Builtin)
+	assert.NotNil(t, alias.UpdatedAt)
+
+	_, err = NewBrandAlias("・", "HERMÈS", time.Now())
+	assert.ErrorContains(t, err, "alias must contain a letter or digit")
+
+	_, err = NewBrandAlias("エルメス", "", time.Now())
+	assert.ErrorContains(t, err, "brand is required")
+}
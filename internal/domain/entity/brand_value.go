@@ -0,0 +1,11 @@
+package entity
+
+// ブランド・通貨ごとの購入価格の集計値
+// 金額は通貨の最小単位の整数で保持する
+type BrandValueTotal struct {
+	Brand    string
+	Currency string
+	Count    int
+	Total    int64
+	TotalJPY int64 // 円換算額の合計（円換算額のないアイテムは含まない）
+}
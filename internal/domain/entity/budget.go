@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// カテゴリーごとの年間予算
+type CategoryBudget struct {
+	Category  string    `json:"category"`
+	Amount    Money     `json:"amount"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewCategoryBudget(category string, amount Money, now time.Time) (*CategoryBudget, error) {
+	budget := &CategoryBudget{
+		Category:  category,
+		Amount:    normalizeMoney(amount),
+		UpdatedAt: now,
+	}
+
+	if err := budget.Validate(); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+func (b *CategoryBudget) Validate() error {
+	var errs []string
+
+	if !IsValidCategory(b.Category) {
+		errs = append(errs, "category must be one of: "+strings.Join(GetValidCategories(), ", "))
+	}
+	if b.Amount.IsNegative() {
+		errs = append(errs, "amount must be 0 or greater")
+	}
+	if !IsSupportedCurrency(b.Amount.Currency) {
+		errs = append(errs, "amount currency must be one of: "+strings.Join(SupportedCurrencies(), ", "))
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
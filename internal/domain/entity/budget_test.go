@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCategoryBudget(t *testing.T) {
+	tests := []struct {
+		name        string
+		category    string
+		amount      Money
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:     "正常系: 有効な予算",
+			category: "時計",
+			amount:   JPY(3000000),
+		},
+		{
+			name:     "正常系: 通貨未指定は円建て",
+			category: "バッグ",
+			amount:   Money{Amount: 1000000},
+		},
+		{
+			name:        "異常系: 無効なカテゴリー",
+			category:    "衣服",
+			amount:      JPY(3000000),
+			wantErr:     true,
+			expectedErr: "category must be one of: 時計, バッグ, ジュエリー, 靴, その他",
+		},
+		{
+			name:        "異常系: 負の金額",
+			category:    "時計",
+			amount:      JPY(-1),
+			wantErr:     true,
+			expectedErr: "amount must be 0 or greater",
+		},
+		{
+			name:        "異常系: 未対応の通貨",
+			category:    "時計",
+			amount:      Money{Amount: 1000, Currency: "XYZ"},
+			wantErr:     true,
+			expectedErr: "amount currency must be one of:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget, err := NewCategoryBudget(tt.category, tt.amount, time.Now())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, budget)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.category, budget.Category)
+			assert.Equal(t, tt.amount.Amount, budget.Amount.Amount)
+			assert.Equal(t, DefaultCurrency, budget.Amount.Currency)
+		})
+	}
+}
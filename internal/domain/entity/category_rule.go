@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"fmt"
+	"sync"
+	"unicode/utf8"
+)
+
+// カテゴリー固有の属性キー
+const (
+	AttrReferenceNumber = "reference_number" // 時計の型番（リファレンスナンバー）
+	AttrMaterial        = "material"         // ジュエリーの素材
+)
+
+// カテゴリー固有のバリデーションルール（違反内容を返す）
+type CategoryRule func(item *Item) []FieldError
+
+var (
+	categoryRulesMu sync.RWMutex
+	categoryRules   = map[string][]CategoryRule{
+		"時計":    {MaxAttributeLength(AttrReferenceNumber, 50)},
+		"ジュエリー": {MaxAttributeLength(AttrMaterial, 50)},
+	}
+)
+
+// カテゴリーにバリデーションルールを追加する（起動時に設定から登録する想定）
+func RegisterCategoryRule(category string, rule CategoryRule) {
+	categoryRulesMu.Lock()
+	defer categoryRulesMu.Unlock()
+
+	categoryRules[category] = append(categoryRules[category], rule)
+}
+
+// 属性の指定を必須とするルール
+func RequireAttribute(key string) CategoryRule {
+	return func(item *Item) []FieldError {
+		if item.Attributes[key] == "" {
+			return []FieldError{{Field: "attributes." + key, Reason: "is required for category " + item.Category}}
+		}
+		return nil
+	}
+}
+
+// 属性の文字数上限を設けるルール
+func MaxAttributeLength(key string, max int) CategoryRule {
+	return func(item *Item) []FieldError {
+		if utf8.RuneCountInString(item.Attributes[key]) > max {
+			return []FieldError{{Field: "attributes." + key, Reason: fmt.Sprintf("must be %d characters or less", max)}}
+		}
+		return nil
+	}
+}
+
+// カテゴリーに登録されたルールを適用
+func validateCategoryRules(item *Item) []FieldError {
+	categoryRulesMu.RLock()
+	rules := categoryRules[item.Category]
+	categoryRulesMu.RUnlock()
+
+	var errs []FieldError
+	for _, rule := range rules {
+		errs = append(errs, rule(item)...)
+	}
+	return errs
+}
@@ -0,0 +1,131 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// テスト中に登録したルールを元に戻す
+func restoreCategoryRules(t *testing.T) {
+	categoryRulesMu.RLock()
+	saved := make(map[string][]CategoryRule, len(categoryRules))
+	for category, rules := range categoryRules {
+		saved[category] = append([]CategoryRule(nil), rules...)
+	}
+	categoryRulesMu.RUnlock()
+
+	t.Cleanup(func() {
+		categoryRulesMu.Lock()
+		categoryRules = saved
+		categoryRulesMu.Unlock()
+	})
+}
+
+func TestNewItem_CategoryRules(t *testing.T) {
+	restoreCategoryRules(t)
+	RegisterCategoryRule("時計", RequireAttribute(AttrReferenceNumber))
+	RegisterCategoryRule("ジュエリー", RequireAttribute(AttrMaterial))
+
+	tests := []struct {
+		name        string
+		category    string
+		attributes  map[string]string
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:       "正常系: 時計に型番を指定",
+			category:   "時計",
+			attributes: map[string]string{AttrReferenceNumber: "116500LN"},
+			wantErr:    false,
+		},
+		{
+			name:        "異常系: 時計の型番が未指定",
+			category:    "時計",
+			attributes:  nil,
+			wantErr:     true,
+			expectedErr: "attributes.reference_number is required for category 時計",
+		},
+		{
+			name:        "異常系: 時計の型番が空白のみ",
+			category:    "時計",
+			attributes:  map[string]string{AttrReferenceNumber: "   "},
+			wantErr:     true,
+			expectedErr: "attributes.reference_number is required for category 時計",
+		},
+		{
+			name:        "異常系: 時計の型番が50文字超過",
+			category:    "時計",
+			attributes:  map[string]string{AttrReferenceNumber: strings.Repeat("A", 51)},
+			wantErr:     true,
+			expectedErr: "attributes.reference_number must be 50 characters or less",
+		},
+		{
+			name:       "正常系: ジュエリーに素材を指定",
+			category:   "ジュエリー",
+			attributes: map[string]string{AttrMaterial: "K18 ピンクゴールド"},
+			wantErr:    false,
+		},
+		{
+			name:        "異常系: ジュエリーの素材が未指定",
+			category:    "ジュエリー",
+			attributes:  map[string]string{AttrReferenceNumber: "116500LN"},
+			wantErr:     true,
+			expectedErr: "attributes.material is required for category ジュエリー",
+		},
+		{
+			name:       "正常系: ルールのないカテゴリーは属性不要",
+			category:   "バッグ",
+			attributes: nil,
+			wantErr:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := NewItem("アイテム", tt.category, "ブランド", JPY(100000), MustParseDate("2023-01-15"), time.Now(), WithAttributes(tt.attributes))
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, item)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, item)
+		})
+	}
+}
+
+func TestItem_Update_CategoryRules(t *testing.T) {
+	restoreCategoryRules(t)
+
+	item, err := NewItem("アイテム", "バッグ", "ブランド", JPY(100000), MustParseDate("2023-01-15"), time.Now())
+	require.NoError(t, err)
+
+	RegisterCategoryRule("時計", RequireAttribute(AttrReferenceNumber))
+
+	// カテゴリーを時計に変更すると、時計のルールが適用される
+	err = item.Update(item.Name, "時計", item.Brand, item.PurchasePrice, item.PurchaseDate, time.Now())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "attributes.reference_number is required for category 時計")
+
+	item.SetAttributes(map[string]string{AttrReferenceNumber: "116500LN"})
+	err = item.Update(item.Name, "時計", item.Brand, item.PurchasePrice, item.PurchaseDate, time.Now())
+	assert.NoError(t, err)
+}
+
+func TestWithAttributes_Normalize(t *testing.T) {
+	item, err := NewItem("アイテム", "時計", "ブランド", JPY(100000), MustParseDate("2023-01-15"), time.Now(), WithAttributes(map[string]string{
+		" reference_number ": " 116500LN ",
+		"empty":              "",
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{AttrReferenceNumber: "116500LN"}, item.Attributes)
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// カテゴリーの変更の提案の確認状況
+type SuggestionStatus string
+
+const (
+	SuggestionPending  SuggestionStatus = "pending" // 確認待ち
+	SuggestionApproved SuggestionStatus = "approved"
+	SuggestionRejected SuggestionStatus = "rejected"
+)
+
+func (s SuggestionStatus) IsValid() bool {
+	return s == SuggestionPending || s == SuggestionApproved || s == SuggestionRejected
+}
+
+// 「その他」に分類されたアイテムのカテゴリーの変更の提案（LLM が推定し、人が承認・却下する）
+type CategorySuggestion struct {
+	ID     int64 `json:"id"`
+	ItemID int64 `json:"item_id"`
+
+	// 提案時のカテゴリーと、提案されたカテゴリー
+	FromCategory string `json:"from_category"`
+	Category     string `json:"category"`
+
+	// 推定の確からしさ（0〜1）と理由
+	Confidence float64 `json:"confidence"`
+	Reason     string  `json:"reason,omitempty"`
+
+	Status     SuggestionStatus `json:"status"`
+	ReviewedBy string           `json:"reviewed_by,omitempty"` // 承認・却下した操作者（X-User-ID）
+	ReviewedAt *time.Time       `json:"reviewed_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// 変更の提案の対象のカテゴリー
+const OtherCategory = "その他"
+
+// 理由の最大文字数（超える場合は切り詰める）
+const MaxSuggestionReasonLength = 255
+
+// 提案先のカテゴリー（「その他」以外の ValidCategories）
+func SuggestibleCategories() []string {
+	categories := make([]string, 0, len(ValidCategories))
+	for _, category := range ValidCategories {
+		if category != OtherCategory {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+func NewCategorySuggestion(item *Item, category string, confidence float64, reason string, now time.Time) (*CategorySuggestion, error) {
+	if !isValidCategory(category) {
+		return nil, fmt.Errorf("invalid category: %s", category)
+	}
+	if category == item.Category {
+		return nil, fmt.Errorf("category is unchanged: %s", category)
+	}
+	if confidence < 0 || confidence > 1 {
+		return nil, fmt.Errorf("confidence must be between 0 and 1, got %v", confidence)
+	}
+	if runes := []rune(reason); len(runes) > MaxSuggestionReasonLength {
+		reason = string(runes[:MaxSuggestionReasonLength])
+	}
+
+	return &CategorySuggestion{
+		ItemID:       item.ID,
+		FromCategory: item.Category,
+		Category:     category,
+		Confidence:   confidence,
+		Reason:       reason,
+		Status:       SuggestionPending,
+		CreatedAt:    now,
+	}, nil
+}
+
+// 承認・却下を記録する
+func (s *CategorySuggestion) Review(status SuggestionStatus, actor string, now time.Time) {
+	s.Status = status
+	s.ReviewedBy = actor
+	s.ReviewedAt = &now
+}
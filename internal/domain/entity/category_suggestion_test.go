@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCategorySuggestion(t *testing.T) {
+	now := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+	item := &Item{ID: 1, Name: "サブマリーナー", Category: "その他"}
+
+	tests := []struct {
+		name        string
+		category    string
+		confidence  float64
+		expectedErr string
+	}{
+		{name: "正常系: 提案を作成できる", category: "時計", confidence: 0.9},
+		{name: "異常系: 不正なカテゴリー", category: "家電", confidence: 0.9, expectedErr: "invalid category: 家電"},
+		{name: "異常系: 未分類には提案しない", category: UncategorizedCategory, confidence: 0.9, expectedErr: "invalid category: 未分類"},
+		{name: "異常系: 変更のないカテゴリー", category: "その他", confidence: 0.9, expectedErr: "category is unchanged: その他"},
+		{name: "異常系: 範囲外の確からしさ", category: "時計", confidence: 1.5, expectedErr: "confidence must be between 0 and 1, got 1.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestion, err := NewCategorySuggestion(item, tt.category, tt.confidence, "", now)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), suggestion.ItemID)
+			assert.Equal(t, "その他", suggestion.FromCategory)
+			assert.Equal(t, tt.category, suggestion.Category)
+			assert.Equal(t, SuggestionPending, suggestion.Status)
+			assert.Equal(t, now, suggestion.CreatedAt)
+		})
+	}
+
+	t.Run("正常系: 長い理由は切り詰める", func(t *testing.T) {
+		suggestion, err := NewCategorySuggestion(item, "時計", 0.9, strings.Repeat("あ", MaxSuggestionReasonLength+10), now)
+		require.NoError(t, err)
+		assert.Len(t, []rune(suggestion.Reason), MaxSuggestionReasonLength)
+	})
+}
+
+func TestSuggestibleCategories(t *testing.T) {
+	assert.Equal(t, []string{"時計", "バッグ", "ジュエリー", "靴"}, SuggestibleCategories())
+}
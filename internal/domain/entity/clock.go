@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// 現在時刻の取得元（テストでは固定した時刻に差し替える）
+// 作成日時・更新日時や「今日」の日付は、time.Now を直接使わずにこれから取得する
+type Clock interface {
+	Now() time.Time
+}
+
+// 関数を Clock として使う
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// システムの現在時刻
+var SystemClock Clock = ClockFunc(time.Now)
+
+// 常に同じ時刻を返す
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+// 日付（YYYY-MM-DD）の形式
+const DateLayout = "2006-01-02"
+
+// 現在時刻の日付（YYYY-MM-DD、ローカルタイムゾーン）
+func Today(clock Clock) string {
+	return clock.Now().Format(DateLayout)
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock(t *testing.T) {
+	now := time.Date(2024, 12, 31, 23, 59, 0, 0, time.Local)
+
+	t.Run("正常系: 固定した時刻を返す", func(t *testing.T) {
+		clock := FixedClock(now)
+		assert.Equal(t, now, clock.Now())
+		assert.Equal(t, "2024-12-31", Today(clock))
+	})
+
+	t.Run("正常系: 関数を Clock として使う", func(t *testing.T) {
+		current := now
+		clock := ClockFunc(func() time.Time { return current })
+		current = current.Add(time.Minute)
+		assert.Equal(t, "2025-01-01", Today(clock))
+	})
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "strings"
+
+const purchaseCountryReason = "must be a 2-letter country code (ISO 3166-1 alpha-2)"
+
+// 国・地域コードの前後の空白を除去し、大文字にする
+func NormalizeCountryCode(country string) string {
+	return strings.ToUpper(strings.TrimSpace(country))
+}
+
+// ISO 3166-1 alpha-2 の形式（英大文字2文字）か
+// 割り当ての有無までは確認しない（新しい国・地域コードにも対応できるようにするため）
+func IsValidCountryCode(country string) bool {
+	if len(country) != 2 {
+		return false
+	}
+	for _, r := range country {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
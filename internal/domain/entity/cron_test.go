@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronSchedule_Next(t *testing.T) {
+	// 2026-10-16 は金曜日
+	after := time.Date(2026, 10, 16, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{name: "正常系: 毎日3時", expr: "0 3 * * *", want: time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC)},
+		{name: "正常系: 15分ごと", expr: "*/15 * * * *", want: time.Date(2026, 10, 16, 10, 45, 0, 0, time.UTC)},
+		{name: "正常系: 平日の9時・18時", expr: "0 9,18 * * 1-5", want: time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)},
+		{name: "正常系: 日曜日（7）", expr: "0 0 * * 7", want: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "正常系: 毎月1日", expr: "@monthly", want: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "正常系: 日と曜日の両方を指定した場合はいずれかに一致する日", expr: "0 0 1 * 1", want: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{name: "正常系: 31日がない月は飛ばす", expr: "0 0 31 * *", want: time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "正常系: うるう年の2月29日", expr: "0 0 29 2 *", want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "正常系: 同じ時刻は含まない", expr: "30 10 * * *", want: time.Date(2026, 10, 17, 10, 30, 0, 0, time.UTC)},
+		{name: "正常系: 実行されない日付はゼロ値", expr: "0 0 30 2 *", want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(after))
+			assert.Equal(t, tt.expr, schedule.String())
+		})
+	}
+}
+
+func TestCronSchedule_Next_DST(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+
+	// 2026-03-08 2:00 に夏時間が始まり、2:30 は存在しない
+	schedule, err := ParseCronSchedule("30 2 * * *")
+	require.NoError(t, err)
+
+	next := schedule.Next(time.Date(2026, 3, 7, 12, 0, 0, 0, location))
+	assert.Equal(t, time.Date(2026, 3, 9, 2, 30, 0, 0, location), next)
+}
+
+func TestParseCronSchedule_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{name: "異常系: 項目が足りない", expr: "0 3 * *", wantErr: "5 fields"},
+		{name: "異常系: 範囲外の分", expr: "60 * * * *", wantErr: "minute"},
+		{name: "異常系: 範囲外の月", expr: "0 0 1 13 *", wantErr: "month"},
+		{name: "異常系: 逆順の範囲", expr: "0 5-1 * * *", wantErr: "hour"},
+		{name: "異常系: 不正な間隔", expr: "*/0 * * * *", wantErr: "invalid step"},
+		{name: "異常系: 数値でない値", expr: "0 0 * * mon", wantErr: "day-of-week"},
+		{name: "異常系: 未対応のマクロ", expr: "@yearly", wantErr: "5 fields"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCronSchedule(tt.expr)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package entity
+
+// 購入した国・地域、購入年、通貨ごとの購入の集計値（税関・税務申告用）
+// 金額は通貨の最小単位の整数で保持する
+type CustomsValueTotal struct {
+	Country  string // ISO 3166-1 alpha-2
+	Year     int
+	Currency string
+	Count    int
+	Total    int64
+	TotalJPY int64 // 円換算額の合計（円換算額のないアイテムは含まない）
+}
@@ -0,0 +1,65 @@
+package entity
+
+import "time"
+
+// 管理者向けの操作者の活動の集計の条件
+type ActivityQuery struct {
+	Since         time.Time // この日時以降の監査ログを集計する
+	ExcludeActors []string  // 集計しない操作者（system など）
+	Limit         int
+}
+
+// 期間内に活動した操作者全体の集計
+type ActivitySummary struct {
+	ActiveUsers  int `json:"active_users"`
+	Changes      int `json:"changes"`
+	ItemsCreated int `json:"items_created"`
+}
+
+// 操作者（X-User-ID）ごとの期間内の活動（監査ログの集計）
+type ActorActivity struct {
+	Actor        string    `json:"actor"`
+	Changes      int       `json:"changes"` // アイテムの変更の件数（登録を含む）
+	ItemsCreated int       `json:"items_created"`
+	LastActiveAt time.Time `json:"last_active_at"`
+}
+
+// カテゴリーごとの写真の件数と合計サイズ
+type CategoryStorage struct {
+	Category     string `json:"category"`
+	Images       int    `json:"images"`
+	Bytes        int64  `json:"bytes"`
+	DeletedBytes int64  `json:"deleted_bytes"` // 削除済みのアイテムの写真（完全削除で解放される）
+}
+
+// 送信先ごとの期間内の配信状況
+type WebhookHealth struct {
+	WebhookID int64  `json:"webhook_id"`
+	URL       string `json:"url"`
+	Owner     string `json:"owner"`
+
+	Deliveries int `json:"deliveries"`
+	Succeeded  int `json:"succeeded"`
+	Failed     int `json:"failed"`
+	Pending    int `json:"pending"`
+
+	// 送信を終えた配信のうち成功した割合（送信を終えた配信がない場合は省略）
+	SuccessRate *float64 `json:"success_rate,omitempty"`
+
+	// 期間内の最後の配信
+	LastStatus         WebhookDeliveryStatus `json:"last_status,omitempty"`
+	LastResponseStatus int                   `json:"last_response_status,omitempty"`
+	LastError          string                `json:"last_error,omitempty"`
+	LastDeliveredAt    *time.Time            `json:"last_delivered_at,omitempty"`
+}
+
+// 成功した割合を計算する
+func (h *WebhookHealth) ComputeSuccessRate() {
+	completed := h.Succeeded + h.Failed
+	if completed == 0 {
+		h.SuccessRate = nil
+		return
+	}
+	rate := float64(h.Succeeded) / float64(completed)
+	h.SuccessRate = &rate
+}
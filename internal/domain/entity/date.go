@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 時刻・タイムゾーンを持たない暦日（購入日など）
+// UTC の0時として保持し、サーバーやデータベースのタイムゾーンによって日付がずれないようにする
+// ゼロ値は未入力（下書きの購入日など）を表し、JSON では空文字になる
+type Date struct {
+	t time.Time
+}
+
+// 最も早く日付が変わるタイムゾーン（UTC+14）
+// 利用者のタイムゾーンはわからないため、どこかで既にその日になっていれば未来の日付としない
+var earliestZone = time.FixedZone("UTC+14", 14*60*60)
+
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{t: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// 時刻の、その時刻のタイムゾーンでの日付
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return NewDate(year, month, day)
+}
+
+// YYYY-MM-DD 形式の日付（空文字の場合はゼロ値）
+func ParseDate(value string) (Date, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Date{}, nil
+	}
+	t, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date: %q", value)
+	}
+	return Date{t: t}, nil
+}
+
+// YYYY-MM-DD 形式の日付（固定値の初期化用、不正な形式の場合は panic する）
+func MustParseDate(value string) Date {
+	d, err := ParseDate(value)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d Date) IsZero() bool {
+	return d.t.IsZero()
+}
+
+// YYYY-MM-DD 形式（ゼロ値の場合は空文字）
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.t.Format(DateLayout)
+}
+
+func (d Date) Year() int {
+	return d.t.Year()
+}
+
+// 日付の UTC の0時
+func (d Date) Time() time.Time {
+	return d.t
+}
+
+func (d Date) Before(other Date) bool {
+	return d.t.Before(other.t)
+}
+
+func (d Date) After(other Date) bool {
+	return d.t.After(other.t)
+}
+
+// now の時点でまだどのタイムゾーンでもその日になっていないか
+func (d Date) IsFutureAt(now time.Time) bool {
+	return d.After(DateOf(now.In(earliestZone)))
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// キャッシュ（gob）での保存用
+func (d Date) MarshalBinary() ([]byte, error) {
+	return d.MarshalText()
+}
+
+func (d *Date) UnmarshalBinary(data []byte) error {
+	return d.UnmarshalText(data)
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Date
+		wantErr bool
+	}{
+		{name: "正常系: YYYY-MM-DD", value: "2023-01-15", want: NewDate(2023, time.January, 15)},
+		{name: "正常系: 前後の空白を除く", value: " 2023-01-15 ", want: NewDate(2023, time.January, 15)},
+		{name: "正常系: 空文字は未入力", value: "", want: Date{}},
+		{name: "異常系: 形式が違う", value: "2023/01/15", wantErr: true},
+		{name: "異常系: 存在しない日付", value: "2023-02-30", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDate(tt.value)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDateOf(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+
+	// UTC では前日でも、時刻のタイムゾーンでの日付になる
+	assert.Equal(t, "2023-01-15", DateOf(time.Date(2023, 1, 15, 1, 0, 0, 0, jst)).String())
+	assert.Equal(t, "2023-01-14", DateOf(time.Date(2023, 1, 15, 1, 0, 0, 0, jst).UTC()).String())
+}
+
+func TestDate_IsFutureAt(t *testing.T) {
+	// UTC の 2023-01-15 12:00 は UTC+14 では 2023-01-16 02:00
+	now := time.Date(2023, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	assert.False(t, MustParseDate("2023-01-15").IsFutureAt(now))
+	assert.False(t, MustParseDate("2023-01-16").IsFutureAt(now))
+	assert.True(t, MustParseDate("2023-01-17").IsFutureAt(now))
+}
+
+func TestDate_JSON(t *testing.T) {
+	type payload struct {
+		PurchaseDate Date `json:"purchase_date"`
+	}
+
+	data, err := json.Marshal(payload{PurchaseDate: MustParseDate("2023-01-15")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"purchase_date": "2023-01-15"}`, string(data))
+
+	// 未入力は空文字
+	data, err = json.Marshal(payload{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"purchase_date": ""}`, string(data))
+
+	var decoded payload
+	require.NoError(t, json.Unmarshal([]byte(`{"purchase_date": "2023-02-20"}`), &decoded))
+	assert.Equal(t, NewDate(2023, time.February, 20), decoded.PurchaseDate)
+	assert.Error(t, json.Unmarshal([]byte(`{"purchase_date": "20230220"}`), &decoded))
+}
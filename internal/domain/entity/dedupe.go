@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// 表記ゆれを吸収した比較用の文字列にする
+// 全角・半角（NFKC）、大文字・小文字を揃え、空白・記号を取り除く（"ﾛﾚｯｸｽ　ﾃﾞｲﾄﾅ" → "ロレックスデイトナ"）
+func NormalizeForComparison(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(norm.NFKC.String(s)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// 名前とブランドを正規化した重複検出用のキー（長さを揃えるためハッシュ化する）
+func dedupeKey(name, brand string) string {
+	sum := sha256.Sum256([]byte(NormalizeForComparison(name) + "\x00" + NormalizeForComparison(brand)))
+	return hex.EncodeToString(sum[:])
+}
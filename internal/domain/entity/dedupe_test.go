@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeForComparison(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "正常系: 全角空白と半角カナ", input: "ﾛﾚｯｸｽ　ﾃﾞｲﾄﾅ", expected: "ロレックスデイトナ"},
+		{name: "正常系: 全角英数字と大文字", input: "ＲＯＬＥＸ １１６５００", expected: "rolex116500"},
+		{name: "正常系: 記号を除く", input: "Tiffany & Co.", expected: "tiffanyco"},
+		{name: "正常系: アクセント付きの文字は残す", input: "HERMÈS", expected: "hermès"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeForComparison(tt.input))
+		})
+	}
+}
+
+func TestItem_DedupeKey(t *testing.T) {
+	item, err := NewItem("ロレックス デイトナ", "時計", "ROLEX", JPY(1500000), MustParseDate("2023-01-15"), time.Now())
+	require.NoError(t, err)
+	same, err := NewItem("ﾛﾚｯｸｽ　ﾃﾞｲﾄﾅ", "時計", "Rolex", JPY(1800000), MustParseDate("2024-01-15"), time.Now())
+	require.NoError(t, err)
+	other, err := NewItem("ロレックス サブマリーナ", "時計", "ROLEX", JPY(1500000), MustParseDate("2023-01-15"), time.Now())
+	require.NoError(t, err)
+
+	assert.Len(t, item.DedupeKey, 64)
+	assert.Equal(t, item.DedupeKey, same.DedupeKey)
+	assert.NotEqual(t, item.DedupeKey, other.DedupeKey)
+
+	// 名前を変更するとキーも変わる
+	require.NoError(t, other.Update("ロレックス デイトナ", other.Category, other.Brand, other.PurchasePrice, other.PurchaseDate, time.Now()))
+	assert.Equal(t, item.DedupeKey, other.DedupeKey)
+}
@@ -0,0 +1,193 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 減価償却の方法
+type DepreciationMethod string
+
+const (
+	// 定額法（毎年同じ額を償却する）
+	DepreciationStraight DepreciationMethod = "straight"
+	// 200%定率法（期首の帳簿価額に 2/耐用年数 を掛けた額を償却し、定額法の額を下回る年からは残額を均等に償却する）
+	DepreciationDeclining DepreciationMethod = "declining"
+	// 年ごとの償却率（購入価格に対する%）を指定する
+	DepreciationCustom DepreciationMethod = "custom"
+)
+
+var ValidDepreciationMethods = []DepreciationMethod{DepreciationStraight, DepreciationDeclining, DepreciationCustom}
+
+func (m DepreciationMethod) IsValid() bool {
+	for _, valid := range ValidDepreciationMethods {
+		if m == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// 耐用年数の上限
+const MaxDepreciationYears = 100
+
+// 減価償却の1年分（購入日から1年ごとの期間）
+type DepreciationPeriod struct {
+	Year         int    `json:"year"`       // 1始まり
+	StartDate    string `json:"start_date"` // YYYY-MM-DD 形式
+	EndDate      string `json:"end_date"`   // 期間の最終日
+	Depreciation Money  `json:"depreciation"`
+	Accumulated  Money  `json:"accumulated"` // 期末までの償却額の累計
+	BookValue    Money  `json:"book_value"`  // 期末の帳簿価額
+}
+
+// 購入価格と購入日からの減価償却の予定
+type DepreciationSchedule struct {
+	Method  DepreciationMethod   `json:"method"`
+	Years   int                  `json:"years"`
+	Cost    Money                `json:"cost"`
+	Periods []DepreciationPeriod `json:"periods"`
+}
+
+// 減価償却の予定を求める。金額は通貨の最小単位で、端数は最終年で調整する
+// rates は custom の場合の年ごとの償却率（%）で、years は rates の数になる
+func NewDepreciationSchedule(cost Money, purchaseDate Date, method DepreciationMethod, years int, rates []int) (*DepreciationSchedule, error) {
+	cost = normalizeMoney(cost)
+	if purchaseDate.IsZero() {
+		return nil, errors.New("purchase_date is required")
+	}
+	start := purchaseDate.Time()
+	if cost.IsNegative() {
+		return nil, errors.New("purchase_price must be 0 or greater")
+	}
+
+	var amounts []int64
+	switch method {
+	case DepreciationStraight, DepreciationDeclining:
+		if years < 1 || years > MaxDepreciationYears {
+			return nil, fmt.Errorf("years must be between 1 and %d", MaxDepreciationYears)
+		}
+		if method == DepreciationStraight {
+			amounts = straightLineAmounts(cost.Amount, years)
+		} else {
+			amounts = decliningBalanceAmounts(cost.Amount, years)
+		}
+	case DepreciationCustom:
+		if err := validateDepreciationRates(rates); err != nil {
+			return nil, err
+		}
+		amounts = customRateAmounts(cost.Amount, rates)
+	default:
+		methods := make([]string, 0, len(ValidDepreciationMethods))
+		for _, m := range ValidDepreciationMethods {
+			methods = append(methods, string(m))
+		}
+		return nil, fmt.Errorf("method must be one of: %s", strings.Join(methods, ", "))
+	}
+
+	schedule := &DepreciationSchedule{
+		Method:  method,
+		Years:   len(amounts),
+		Cost:    cost,
+		Periods: make([]DepreciationPeriod, 0, len(amounts)),
+	}
+
+	var accumulated int64
+	for i, amount := range amounts {
+		accumulated += amount
+		periodStart := start.AddDate(i, 0, 0)
+		schedule.Periods = append(schedule.Periods, DepreciationPeriod{
+			Year:         i + 1,
+			StartDate:    periodStart.Format("2006-01-02"),
+			EndDate:      start.AddDate(i+1, 0, -1).Format("2006-01-02"),
+			Depreciation: Money{Amount: amount, Currency: cost.Currency},
+			Accumulated:  Money{Amount: accumulated, Currency: cost.Currency},
+			BookValue:    Money{Amount: cost.Amount - accumulated, Currency: cost.Currency},
+		})
+	}
+
+	return schedule, nil
+}
+
+// 指定日（YYYY-MM-DD）時点の帳簿価額（期末を迎えた期間の償却のみ反映する）
+func (s *DepreciationSchedule) BookValueAt(date string) Money {
+	bookValue := s.Cost
+	for _, period := range s.Periods {
+		if period.EndDate > date {
+			break
+		}
+		bookValue = period.BookValue
+	}
+	return bookValue
+}
+
+func straightLineAmounts(cost int64, years int) []int64 {
+	amounts := make([]int64, years)
+	for i := range amounts {
+		amounts[i] = roundDiv(cost, int64(years), RoundDown)
+	}
+	amounts[years-1] += cost % int64(years)
+	return amounts
+}
+
+func decliningBalanceAmounts(cost int64, years int) []int64 {
+	amounts := make([]int64, years)
+	bookValue := cost
+	for i := range amounts {
+		remaining := int64(years - i)
+		// 最小単位未満は四捨五入
+		declining := roundDiv(bookValue*2, int64(years), RoundHalfUp)
+		// 残りの年数で均等に償却する額（切り上げ）
+		straight := roundDiv(bookValue, remaining, RoundUp)
+
+		amount := max(declining, straight)
+		if i == years-1 || amount > bookValue {
+			amount = bookValue
+		}
+		amounts[i] = amount
+		bookValue -= amount
+	}
+	return amounts
+}
+
+func customRateAmounts(cost int64, rates []int) []int64 {
+	amounts := make([]int64, len(rates))
+	total := 0
+	for _, rate := range rates {
+		total += rate
+	}
+
+	bookValue := cost
+	for i, rate := range rates {
+		amount := roundDiv(cost*int64(rate), 100, RoundHalfUp)
+		// 償却率の合計が100%の場合は最終年で残額をすべて償却する
+		if (i == len(rates)-1 && total == 100) || amount > bookValue {
+			amount = bookValue
+		}
+		amounts[i] = amount
+		bookValue -= amount
+	}
+	return amounts
+}
+
+func validateDepreciationRates(rates []int) error {
+	if len(rates) == 0 {
+		return errors.New("rates is required for custom method")
+	}
+	if len(rates) > MaxDepreciationYears {
+		return fmt.Errorf("rates must contain %d years or less", MaxDepreciationYears)
+	}
+
+	total := 0
+	for _, rate := range rates {
+		if rate < 0 || rate > 100 {
+			return errors.New("each rate must be between 0 and 100")
+		}
+		total += rate
+	}
+	if total > 100 {
+		return errors.New("rates must add up to 100 or less")
+	}
+	return nil
+}
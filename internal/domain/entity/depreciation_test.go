@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 期間ごとの償却額を取り出す
+func depreciationAmounts(schedule *DepreciationSchedule) []int64 {
+	amounts := make([]int64, 0, len(schedule.Periods))
+	for _, period := range schedule.Periods {
+		amounts = append(amounts, period.Depreciation.Amount)
+	}
+	return amounts
+}
+
+func TestNewDepreciationSchedule(t *testing.T) {
+	tests := []struct {
+		name            string
+		cost            Money
+		method          DepreciationMethod
+		years           int
+		rates           []int
+		expectedAmounts []int64
+		expectedErr     string
+	}{
+		{
+			name:            "正常系: 定額法（端数は最終年）",
+			cost:            JPY(1000000),
+			method:          DepreciationStraight,
+			years:           3,
+			expectedAmounts: []int64{333333, 333333, 333334},
+		},
+		{
+			name:            "正常系: 200%定率法（残額が定額法を下回る年から均等償却）",
+			cost:            JPY(1000000),
+			method:          DepreciationDeclining,
+			years:           5,
+			expectedAmounts: []int64{400000, 240000, 144000, 108000, 108000},
+		},
+		{
+			name:            "正常系: 償却率を指定",
+			cost:            Money{Amount: 100001, Currency: "USD"},
+			method:          DepreciationCustom,
+			rates:           []int{50, 30, 20},
+			expectedAmounts: []int64{50001, 30000, 20000},
+		},
+		{
+			name:            "正常系: 償却率の合計が100%未満の場合は残額が残る",
+			cost:            JPY(1000000),
+			method:          DepreciationCustom,
+			rates:           []int{40, 30},
+			expectedAmounts: []int64{400000, 300000},
+		},
+		{
+			name:        "異常系: 耐用年数が範囲外",
+			cost:        JPY(1000000),
+			method:      DepreciationStraight,
+			years:       0,
+			expectedErr: "years must be between 1 and 100",
+		},
+		{
+			name:        "異常系: 償却率の合計が100%を超える",
+			cost:        JPY(1000000),
+			method:      DepreciationCustom,
+			rates:       []int{60, 50},
+			expectedErr: "rates must add up to 100 or less",
+		},
+		{
+			name:        "異常系: 償却率が未指定",
+			cost:        JPY(1000000),
+			method:      DepreciationCustom,
+			expectedErr: "rates is required for custom method",
+		},
+		{
+			name:        "異常系: 無効な方法",
+			cost:        JPY(1000000),
+			method:      "sum-of-years",
+			years:       5,
+			expectedErr: "method must be one of: straight, declining, custom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewDepreciationSchedule(tt.cost, MustParseDate("2023-01-15"), tt.method, tt.years, tt.rates)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, schedule)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAmounts, depreciationAmounts(schedule))
+			assert.Equal(t, len(tt.expectedAmounts), schedule.Years)
+
+			last := schedule.Periods[len(schedule.Periods)-1]
+			assert.Equal(t, tt.cost.Amount, last.Accumulated.Amount+last.BookValue.Amount)
+			assert.Equal(t, tt.cost.Currency, last.BookValue.Currency)
+		})
+	}
+}
+
+func TestDepreciationSchedule_BookValueAt(t *testing.T) {
+	schedule, err := NewDepreciationSchedule(JPY(1000000), MustParseDate("2023-01-15"), DepreciationStraight, 4, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2023-01-15", schedule.Periods[0].StartDate)
+	assert.Equal(t, "2024-01-14", schedule.Periods[0].EndDate)
+	assert.Equal(t, "2024-01-15", schedule.Periods[1].StartDate)
+
+	assert.Equal(t, JPY(1000000), schedule.BookValueAt("2022-12-31"))
+	assert.Equal(t, JPY(1000000), schedule.BookValueAt("2024-01-13"))
+	assert.Equal(t, JPY(750000), schedule.BookValueAt("2024-01-14"))
+	assert.Equal(t, JPY(0), schedule.BookValueAt("2030-01-01"))
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "strings"
+
+// 項目ごとのバリデーションエラー（例: {"field":"purchase_price","reason":"must be 0 or greater"}）
+// 特定の項目に紐づかないエラーは Field を空にする
+type FieldError struct {
+	Field  string `json:"field,omitempty"`
+	Reason string `json:"reason"`
+}
+
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Reason
+	}
+	return e.Field + " " + e.Reason
+}
+
+// 項目ごとのバリデーションエラーの一覧
+// Error() は従来と同じ「name is required, brand is required」の形式で返す
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fieldErr := range e {
+		msgs[i] = fieldErr.Error()
+	}
+	return strings.Join(msgs, ", ")
+}
+
+// 項目のエラーを追加する
+func (e *ValidationErrors) Add(field, reason string) {
+	*e = append(*e, FieldError{Field: field, Reason: reason})
+}
+
+// エラーがなければ nil を返す（nil のスライスを error として返さないため）
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewItem_FieldErrors(t *testing.T) {
+	_, err := NewItem("", "時計", "ROLEX", JPY(-1), MustParseDate("2099-01-15"), time.Now())
+
+	var fieldErrs ValidationErrors
+	require.True(t, errors.As(err, &fieldErrs))
+	assert.Equal(t, ValidationErrors{
+		{Field: "name", Reason: "is required"},
+		{Field: "purchase_price", Reason: "must be 0 or greater"},
+		{Field: "purchase_date", Reason: "must not be in the future"},
+	}, fieldErrs)
+	assert.Equal(t, "name is required, purchase_price must be 0 or greater, purchase_date must not be in the future", err.Error())
+}
+
+func TestValidationErrors_Err(t *testing.T) {
+	var errs ValidationErrors
+	assert.NoError(t, errs.Err())
+
+	errs.Add("brand", "is required")
+	errs = append(errs, FieldError{Reason: "at least one field must be provided"})
+	assert.EqualError(t, errs.Err(), "brand is required, at least one field must be provided")
+}
@@ -0,0 +1,254 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 保険会社に提出する明細の書式（明細の分け方と各明細の列）
+type InsuranceTemplate struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Schedules   []InsuranceSchedule `json:"schedules"`
+}
+
+// 明細（宝飾品明細・時計明細など）
+// アイテムは明細の順に、カテゴリーが一致する最初の明細に含める。Categories が空の明細は残りのすべてを含める
+type InsuranceSchedule struct {
+	Name       string            `json:"name"`
+	Title      string            `json:"title"`
+	Categories []string          `json:"categories,omitempty"`
+	Columns    []InsuranceColumn `json:"columns"`
+
+	// 保険会社が必須とする項目（値がないアイテムは不足として報告する）
+	Required []string `json:"required,omitempty"`
+}
+
+// 明細の列（見出しと、出力するアイテムの項目）
+type InsuranceColumn struct {
+	Header string `json:"header"`
+	Field  string `json:"field"`
+}
+
+// 明細に出力できる項目（attributes.{キー} でカテゴリー固有の属性も出力できる）
+// insured_value は最新の評価額（評価額がない場合は購入価格）
+var InsuranceFields = []string{
+	"id", "name", "brand", "category", "purchase_date", "purchase_price", "currency",
+	"purchase_price_jpy", "purchase_country", "insured_value", "insured_currency",
+	"valuation_date", "valuation_source",
+}
+
+// 属性を出力する項目の接頭辞
+const InsuranceAttributePrefix = "attributes."
+
+// 書式を指定しない場合の既定
+const DefaultInsuranceTemplate = "standard"
+
+// 既定の書式（INSURANCE_TEMPLATES_PATH で同じ名前の書式を置き換え、別の書式を追加できる）
+func DefaultInsuranceTemplates() []InsuranceTemplate {
+	return []InsuranceTemplate{
+		{
+			Name:        DefaultInsuranceTemplate,
+			Description: "宝飾品・時計・その他の3つの明細",
+			Schedules: []InsuranceSchedule{
+				{
+					Name:       "jewelry",
+					Title:      "宝飾品明細",
+					Categories: []string{"ジュエリー"},
+					Columns: []InsuranceColumn{
+						{Header: "管理番号", Field: "id"},
+						{Header: "品名", Field: "name"},
+						{Header: "ブランド", Field: "brand"},
+						{Header: "素材", Field: "attributes.material"},
+						{Header: "購入日", Field: "purchase_date"},
+						{Header: "購入価格", Field: "purchase_price"},
+						{Header: "通貨", Field: "currency"},
+						{Header: "評価額", Field: "insured_value"},
+						{Header: "評価日", Field: "valuation_date"},
+						{Header: "鑑定元", Field: "valuation_source"},
+					},
+					Required: []string{"brand", "attributes.material", "valuation_date"},
+				},
+				{
+					Name:       "watches",
+					Title:      "時計明細",
+					Categories: []string{"時計"},
+					Columns: []InsuranceColumn{
+						{Header: "管理番号", Field: "id"},
+						{Header: "品名", Field: "name"},
+						{Header: "ブランド", Field: "brand"},
+						{Header: "リファレンス", Field: "attributes.reference_number"},
+						{Header: "購入日", Field: "purchase_date"},
+						{Header: "購入価格", Field: "purchase_price"},
+						{Header: "通貨", Field: "currency"},
+						{Header: "評価額", Field: "insured_value"},
+						{Header: "評価日", Field: "valuation_date"},
+					},
+					Required: []string{"brand", "attributes.reference_number"},
+				},
+				{
+					Name:  "general",
+					Title: "その他明細",
+					Columns: []InsuranceColumn{
+						{Header: "管理番号", Field: "id"},
+						{Header: "品名", Field: "name"},
+						{Header: "カテゴリー", Field: "category"},
+						{Header: "ブランド", Field: "brand"},
+						{Header: "購入日", Field: "purchase_date"},
+						{Header: "購入価格", Field: "purchase_price"},
+						{Header: "通貨", Field: "currency"},
+						{Header: "評価額", Field: "insured_value"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// 書式のJSON（書式の配列）を読み込む
+func ParseInsuranceTemplates(data []byte) ([]InsuranceTemplate, error) {
+	var templates []InsuranceTemplate
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return nil, fmt.Errorf("invalid insurance templates: %w", err)
+	}
+	for _, template := range templates {
+		if err := template.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return templates, nil
+}
+
+// 既定の書式に追加の書式を重ねる（同じ名前の書式は置き換える）。名前順に返す
+func MergeInsuranceTemplates(base, overrides []InsuranceTemplate) []InsuranceTemplate {
+	byName := make(map[string]InsuranceTemplate, len(base)+len(overrides))
+	for _, template := range base {
+		byName[template.Name] = template
+	}
+	for _, template := range overrides {
+		byName[template.Name] = template
+	}
+
+	merged := make([]InsuranceTemplate, 0, len(byName))
+	for _, template := range byName {
+		merged = append(merged, template)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Name < merged[j].Name })
+	return merged
+}
+
+func (t InsuranceTemplate) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("insurance template name is required")
+	}
+	if len(t.Schedules) == 0 {
+		return fmt.Errorf("insurance template %q must have at least one schedule", t.Name)
+	}
+
+	seen := make(map[string]bool, len(t.Schedules))
+	for _, schedule := range t.Schedules {
+		if schedule.Name == "" {
+			return fmt.Errorf("insurance template %q: schedule name is required", t.Name)
+		}
+		if seen[schedule.Name] {
+			return fmt.Errorf("insurance template %q: duplicate schedule %q", t.Name, schedule.Name)
+		}
+		seen[schedule.Name] = true
+
+		if len(schedule.Columns) == 0 {
+			return fmt.Errorf("insurance template %q: schedule %q must have at least one column", t.Name, schedule.Name)
+		}
+		for _, column := range schedule.Columns {
+			if !IsInsuranceField(column.Field) {
+				return fmt.Errorf("insurance template %q: schedule %q: unknown field %q", t.Name, schedule.Name, column.Field)
+			}
+		}
+		for _, field := range schedule.Required {
+			if !IsInsuranceField(field) {
+				return fmt.Errorf("insurance template %q: schedule %q: unknown required field %q", t.Name, schedule.Name, field)
+			}
+		}
+	}
+	return nil
+}
+
+func IsInsuranceField(field string) bool {
+	if key, ok := strings.CutPrefix(field, InsuranceAttributePrefix); ok {
+		return key != ""
+	}
+	for _, f := range InsuranceFields {
+		if field == f {
+			return true
+		}
+	}
+	return false
+}
+
+// アイテムを含める明細（どの明細にも含めない場合は -1）
+func (t InsuranceTemplate) ScheduleIndex(category string) int {
+	for i, schedule := range t.Schedules {
+		if len(schedule.Categories) == 0 {
+			return i
+		}
+		for _, c := range schedule.Categories {
+			if c == category {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// 明細に載せる保険金額（最新の評価額、評価額がない場合は購入価格）
+func InsuredValue(item *Item) Money {
+	if item.LatestValuation != nil {
+		return item.LatestValuation.Value
+	}
+	return item.PurchasePrice
+}
+
+// アイテムの項目の値（金額は通貨の単位の10進表記、値がない場合は空文字）
+func InsuranceFieldValue(item *Item, field string) string {
+	if key, ok := strings.CutPrefix(field, InsuranceAttributePrefix); ok {
+		return item.Attributes[key]
+	}
+
+	switch field {
+	case "id":
+		return strconv.FormatInt(item.ID, 10)
+	case "name":
+		return item.Name
+	case "brand":
+		return item.Brand
+	case "category":
+		return item.Category
+	case "purchase_date":
+		return item.PurchaseDate.String()
+	case "purchase_price":
+		return item.PurchasePrice.DecimalString()
+	case "currency":
+		return item.PurchasePrice.Currency
+	case "purchase_price_jpy":
+		if item.PurchasePriceJPY != nil {
+			return item.PurchasePriceJPY.DecimalString()
+		}
+	case "purchase_country":
+		return item.PurchaseCountry
+	case "insured_value":
+		return InsuredValue(item).DecimalString()
+	case "insured_currency":
+		return InsuredValue(item).Currency
+	case "valuation_date":
+		if item.LatestValuation != nil {
+			return item.LatestValuation.ValuatedAt.String()
+		}
+	case "valuation_source":
+		if item.LatestValuation != nil {
+			return item.LatestValuation.Source
+		}
+	}
+	return ""
+}
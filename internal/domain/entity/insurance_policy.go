@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 保険の証券番号・保険会社名の最大文字数
+const (
+	MaxPolicyNumberLength = 100
+	MaxInsurerLength      = 100
+)
+
+// 満期が近い保険の既定の日数と、指定できる最大の日数
+const (
+	DefaultInsuranceExpiryWindowDays = 30
+	MaxInsuranceExpiryWindowDays     = 366
+)
+
+// アイテムに掛けた保険の契約（1つのアイテムに複数の保険を掛けられる）
+type InsurancePolicy struct {
+	ID           int64     `json:"id"`
+	ItemID       int64     `json:"item_id"`
+	PolicyNumber string    `json:"policy_number"` // 証券番号
+	Insurer      string    `json:"insurer"`       // 保険会社
+	Coverage     Money     `json:"coverage"`      // 保険金額
+	ExpiresOn    Date      `json:"expires_on"`    // 満期日（この日まで補償される）
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func NewInsurancePolicy(itemID int64, policyNumber, insurer string, coverage Money, expiresOn Date, now time.Time) (*InsurancePolicy, error) {
+	policy := &InsurancePolicy{
+		ItemID:    itemID,
+		CreatedAt: now,
+	}
+	if err := policy.Update(policyNumber, insurer, coverage, expiresOn, now); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// 保険の契約の項目を更新する
+func (p *InsurancePolicy) Update(policyNumber, insurer string, coverage Money, expiresOn Date, now time.Time) error {
+	p.PolicyNumber = strings.TrimSpace(policyNumber)
+	p.Insurer = strings.TrimSpace(insurer)
+	p.Coverage = normalizeMoney(coverage)
+	p.ExpiresOn = expiresOn
+	p.UpdatedAt = now
+
+	return p.Validate()
+}
+
+func (p *InsurancePolicy) Validate() error {
+	var errs ValidationErrors
+
+	if p.PolicyNumber == "" {
+		errs.Add("policy_number", "is required")
+	} else if utf8.RuneCountInString(p.PolicyNumber) > MaxPolicyNumberLength {
+		errs.Add("policy_number", "must be 100 characters or less")
+	}
+
+	if p.Insurer == "" {
+		errs.Add("insurer", "is required")
+	} else if utf8.RuneCountInString(p.Insurer) > MaxInsurerLength {
+		errs.Add("insurer", "must be 100 characters or less")
+	}
+
+	if p.Coverage.IsNegative() {
+		errs.Add("coverage", "must be 0 or greater")
+	}
+	if !IsSupportedCurrency(p.Coverage.Currency) {
+		errs.Add("coverage", "currency must be one of: "+strings.Join(SupportedCurrencies(), ", "))
+	}
+
+	if p.ExpiresOn.IsZero() {
+		errs.Add("expires_on", "is required")
+	}
+
+	return errs.Err()
+}
+
+// 満期日までの日数（満期日当日は0、満期を過ぎた場合は負）
+func (p *InsurancePolicy) DaysUntilExpiry(today Date) int {
+	return int(p.ExpiresOn.Time().Sub(today.Time()).Hours() / 24)
+}
+
+// 満期が近い保険の契約と、保険を掛けたアイテム
+type ExpiringInsurancePolicy struct {
+	*InsurancePolicy
+	ItemName string `json:"item_name"`
+	Category string `json:"category"`
+	DaysLeft int    `json:"days_until_expiry"` // 満期日までの日数
+}
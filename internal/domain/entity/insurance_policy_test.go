@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInsurancePolicy(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: 前後の空白を取り除く", func(t *testing.T) {
+		policy, err := NewInsurancePolicy(1, " AB-123 ", " 東京海上 ", JPY(2000000), MustParseDate("2027-03-31"), now)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), policy.ItemID)
+		assert.Equal(t, "AB-123", policy.PolicyNumber)
+		assert.Equal(t, "東京海上", policy.Insurer)
+		assert.Equal(t, JPY(2000000), policy.Coverage)
+		assert.Equal(t, now, policy.CreatedAt)
+		assert.Equal(t, now, policy.UpdatedAt)
+	})
+
+	t.Run("異常系: 項目ごとのエラー", func(t *testing.T) {
+		_, err := NewInsurancePolicy(1, "", strings.Repeat("あ", 101), Money{Amount: -1, Currency: "XYZ"}, Date{}, now)
+
+		assert.Equal(t, ValidationErrors{
+			{Field: "policy_number", Reason: "is required"},
+			{Field: "insurer", Reason: "must be 100 characters or less"},
+			{Field: "coverage", Reason: "must be 0 or greater"},
+			{Field: "coverage", Reason: "currency must be one of: " + strings.Join(SupportedCurrencies(), ", ")},
+			{Field: "expires_on", Reason: "is required"},
+		}, err)
+	})
+}
+
+func TestInsurancePolicy_DaysUntilExpiry(t *testing.T) {
+	policy := &InsurancePolicy{ExpiresOn: MustParseDate("2026-10-31")}
+
+	assert.Equal(t, 30, policy.DaysUntilExpiry(MustParseDate("2026-10-01")))
+	assert.Equal(t, 0, policy.DaysUntilExpiry(MustParseDate("2026-10-31")))
+	assert.Equal(t, -1, policy.DaysUntilExpiry(MustParseDate("2026-11-01")))
+}
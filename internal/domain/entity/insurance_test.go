@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInsuranceTemplates(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expectedErr string
+	}{
+		{
+			name: "正常系: 属性の項目を含む書式",
+			data: `[{"name": "acme", "schedules": [{"name": "jewelry", "categories": ["ジュエリー"], "columns": [{"header": "素材", "field": "attributes.material"}], "required": ["brand"]}]}]`,
+		},
+		{
+			name:        "異常系: JSONが不正",
+			data:        `{`,
+			expectedErr: "invalid insurance templates",
+		},
+		{
+			name:        "異常系: 明細がない",
+			data:        `[{"name": "acme", "schedules": []}]`,
+			expectedErr: "at least one schedule",
+		},
+		{
+			name:        "異常系: 明細の名前が重複",
+			data:        `[{"name": "acme", "schedules": [{"name": "a", "columns": [{"field": "id"}]}, {"name": "a", "columns": [{"field": "id"}]}]}]`,
+			expectedErr: "duplicate schedule",
+		},
+		{
+			name:        "異常系: 存在しない項目",
+			data:        `[{"name": "acme", "schedules": [{"name": "a", "columns": [{"field": "serial"}]}]}]`,
+			expectedErr: `unknown field "serial"`,
+		},
+		{
+			name:        "異常系: 属性のキーがない",
+			data:        `[{"name": "acme", "schedules": [{"name": "a", "columns": [{"field": "id"}], "required": ["attributes."]}]}]`,
+			expectedErr: "unknown required field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templates, err := ParseInsuranceTemplates([]byte(tt.data))
+
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, templates, 1)
+		})
+	}
+}
+
+func TestMergeInsuranceTemplates(t *testing.T) {
+	override := InsuranceTemplate{Name: DefaultInsuranceTemplate, Description: "上書き"}
+	added := InsuranceTemplate{Name: "acme"}
+
+	merged := MergeInsuranceTemplates(DefaultInsuranceTemplates(), []InsuranceTemplate{override, added})
+
+	require.Len(t, merged, 2)
+	assert.Equal(t, "acme", merged[0].Name)
+	assert.Equal(t, "上書き", merged[1].Description)
+}
+
+func TestInsuranceTemplate_ScheduleIndex(t *testing.T) {
+	template := DefaultInsuranceTemplates()[0]
+
+	assert.Equal(t, 0, template.ScheduleIndex("ジュエリー"))
+	assert.Equal(t, 1, template.ScheduleIndex("時計"))
+	assert.Equal(t, 2, template.ScheduleIndex("バッグ"))
+
+	template.Schedules = template.Schedules[:2]
+	assert.Equal(t, -1, template.ScheduleIndex("バッグ"))
+}
@@ -4,34 +4,143 @@ import (
 	"errors"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 type Item struct {
-	ID            int64     `json:"id"`
-	Name          string    `json:"name"`
-	Category      string    `json:"category"`
-	Brand         string    `json:"brand"`
-	PurchasePrice int       `json:"purchase_price"`
-	PurchaseDate  string    `json:"purchase_date"` // YYYY-MM-DD 形式
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            int64             `json:"id"`
+	Name          string            `json:"name"`
+	Category      string            `json:"category"`
+	Brand         string            `json:"brand"`
+	PurchasePrice Money             `json:"purchase_price"`
+	PurchaseDate  Date              `json:"purchase_date"`        // YYYY-MM-DD 形式（未入力の場合は空文字）
+	Attributes    map[string]string `json:"attributes,omitempty"` // カテゴリー固有の属性（時計の型番など）
+
+	// 購入した国・地域（ISO 3166-1 alpha-2 のコード、例: "US"）。税関への申告額の集計に使う
+	PurchaseCountry string `json:"purchase_country,omitempty"`
+
+	// 購入日時点の為替レート（外貨1単位あたりの円）と円換算額。レートは登録時に固定する
+	ExchangeRate     string `json:"exchange_rate,omitempty"`
+	PurchasePriceJPY *Money `json:"purchase_price_jpy,omitempty"`
+
+	// 購入時に支払った税額（購入価格と同じ通貨、未記録の場合はnil）と、購入価格が税込かどうか
+	// 税額の円換算額は購入価格と同じ為替レートで求める
+	TaxAmount    *Money `json:"tax_amount,omitempty"`
+	TaxIncluded  bool   `json:"tax_included"`
+	TaxAmountJPY *Money `json:"tax_amount_jpy,omitempty"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`            // 項目の最終更新日時（ユースケースの時計で設定する）
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`  // 論理削除日時（削除されていない場合はnil）
+	OnHold     bool       `json:"on_hold"`               // 保全中（保険請求・係争中など）は削除・変更できない
+	HoldReason string     `json:"hold_reason,omitempty"` // 保全の理由
+
+	// 完全削除の予定日時（売却の決済期間中など、予定日時まではそのまま扱える）。予定がない場合はnil
+	PurgeAt *time.Time `json:"purge_at,omitempty"`
+
+	// 変更の通し番号。アイテムの変更（削除・復元・保全・タグを含む）のたびに、全アイテムで共通のカウンターから大きい番号を採番する
+	// 同期するクライアントは、取得済みの最大の番号より大きいアイテムを取得すれば変更を順に漏れなく取得できる
+	ChangeSeq int64 `json:"change_seq"`
+
+	// 下書き（一部の項目が未入力のまま保存したもの）。集計・予算には含めない
+	Draft bool `json:"draft"`
+
+	// 売却済み（売却の記録は Sale）
+	Sold bool `json:"sold"`
+
+	// 分割元のアイテムのID（セットを分割して登録した構成品のみ、分割元は削除済み）
+	SplitFrom *int64 `json:"split_from,omitempty"`
+
+	// 付けられたタグ名（名前順）
+	Tags []string `json:"tags,omitempty"`
+
+	// 名前とブランドを正規化した重複検出用のキー（APIでは返さない）
+	DedupeKey string `json:"-"`
+
+	// 最新の評価額と、購入価格に対する含み損益（評価額から導出するため保存しない）
+	LatestValuation *Valuation `json:"latest_valuation,omitempty"`
+	UnrealizedGain  *Money     `json:"unrealized_gain,omitempty"`
 }
 
 // カテゴリー定義
 var ValidCategories = []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
 
-func NewItem(name, category, brand string, purchasePrice int, purchaseDate string) (*Item, error) {
+// カテゴリー未指定で登録されたアイテムの分類先（ValidCategoriesとは別に集計する）
+const UncategorizedCategory = "未分類"
+
+const (
+	taxAmountReason = "must be 0 or greater"
+	categoryReason  = "must be one of: 時計, バッグ, ジュエリー, 靴, その他"
+)
+
+// NewItemに任意項目を指定するオプション
+type ItemOption func(*Item)
+
+// カテゴリー固有の属性を指定
+func WithAttributes(attributes map[string]string) ItemOption {
+	return func(i *Item) {
+		i.Attributes = normalizeAttributes(attributes)
+	}
+}
+
+// 購入した国・地域を指定
+func WithPurchaseCountry(country string) ItemOption {
+	return func(i *Item) {
+		i.PurchaseCountry = NormalizeCountryCode(country)
+	}
+}
+
+// 税額（購入価格の通貨の最小単位、nil の場合は未記録）と、購入価格が税込かどうかを指定
+func WithTax(amount *int64, included bool) ItemOption {
+	return func(i *Item) {
+		i.setTax(amount, included)
+	}
+}
+
+func NewItem(name, category, brand string, purchasePrice Money, purchaseDate Date, now time.Time, opts ...ItemOption) (*Item, error) {
 	item := &Item{
 		Name:          strings.TrimSpace(name),
 		Category:      strings.TrimSpace(category),
 		Brand:         strings.TrimSpace(brand),
-		PurchasePrice: purchasePrice,
-		PurchaseDate:  strings.TrimSpace(purchaseDate),
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		PurchasePrice: normalizeMoney(purchasePrice),
+		PurchaseDate:  purchaseDate,
+		CreatedAt:     now,
+		UpdatedAt:     now,
 	}
 
-	if err := item.Validate(); err != nil {
+	for _, opt := range opts {
+		opt(item)
+	}
+	item.applyExchangeRate()
+	item.DedupeKey = dedupeKey(item.Name, item.Brand)
+
+	if err := item.validate(true); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// 下書きとしてアイテムを作成する（購入価格・購入日などの未入力を許す）
+func NewDraftItem(name, category, brand string, purchasePrice Money, purchaseDate Date, now time.Time, opts ...ItemOption) (*Item, error) {
+	item := &Item{
+		Name:          strings.TrimSpace(name),
+		Category:      strings.TrimSpace(category),
+		Brand:         strings.TrimSpace(brand),
+		PurchasePrice: normalizeMoney(purchasePrice),
+		PurchaseDate:  purchaseDate,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Draft:         true,
+	}
+
+	for _, opt := range opts {
+		opt(item)
+	}
+	item.applyExchangeRate()
+	item.DedupeKey = dedupeKey(item.Name, item.Brand)
+
+	if err := item.validateDraft(); err != nil {
 		return nil, err
 	}
 
@@ -39,56 +148,341 @@ func NewItem(name, category, brand string, purchasePrice int, purchaseDate strin
 }
 
 // アイテムフィールドのバリデーション
+// ブランドは登録時のみ必須とし、更新で空にすることはできる
 func (i *Item) Validate() error {
-	var errs []string
+	if i.Draft {
+		return i.validateDraft()
+	}
+	return i.validate(false)
+}
+
+// 下書きを公開する（登録時と同じバリデーションを行う）
+func (i *Item) Publish(now time.Time) error {
+	if !i.Draft {
+		return errors.New("item is not a draft")
+	}
+	if err := i.validate(true); err != nil {
+		return err
+	}
+
+	i.Draft = false
+	i.UpdatedAt = now
+	return nil
+}
+
+// 下書きのバリデーション（名前のみ必須とし、入力済みの項目の形式だけを確認する）
+func (i *Item) validateDraft() error {
+	var errs ValidationErrors
+
+	if i.Name == "" {
+		errs.Add("name", "is required")
+	} else if len(i.Name) > 100 {
+		errs.Add("name", "must be 100 characters or less")
+	}
+
+	if i.Category != "" && !IsValidCategory(i.Category) {
+		errs.Add("category", categoryReason)
+	}
+
+	if len(i.Brand) > 100 {
+		errs.Add("brand", "must be 100 characters or less")
+	}
+
+	i.validatePurchasePrice(&errs)
+
+	i.validatePurchaseDate(&errs)
+
+	if i.PurchaseCountry != "" && !IsValidCountryCode(i.PurchaseCountry) {
+		errs.Add("purchase_country", purchaseCountryReason)
+	}
+
+	if i.TaxAmount != nil && i.TaxAmount.IsNegative() {
+		errs.Add("tax_amount", taxAmountReason)
+	}
+
+	return errs.Err()
+}
+
+func (i *Item) validate(requireBrand bool) error {
+	var errs ValidationErrors
 
 	if i.Name == "" {
-		errs = append(errs, "name is required")
+		errs.Add("name", "is required")
 	} else if len(i.Name) > 100 {
-		errs = append(errs, "name must be 100 characters or less")
+		errs.Add("name", "must be 100 characters or less")
 	}
 
 	if i.Category == "" {
-		errs = append(errs, "category is required")
-	} else if !isValidCategory(i.Category) {
-		errs = append(errs, "category must be one of: 時計, バッグ, ジュエリー, 靴, その他")
+		errs.Add("category", "is required")
+	} else if !IsValidCategory(i.Category) {
+		errs.Add("category", categoryReason)
 	}
 
 	if i.Brand == "" {
-		errs = append(errs, "brand is required")
+		if requireBrand {
+			errs.Add("brand", "is required")
+		}
 	} else if len(i.Brand) > 100 {
-		errs = append(errs, "brand must be 100 characters or less")
+		errs.Add("brand", "must be 100 characters or less")
 	}
 
-	if i.PurchasePrice < 0 {
-		errs = append(errs, "purchase_price must be 0 or greater")
+	i.validatePurchasePrice(&errs)
+
+	if i.PurchaseDate.IsZero() {
+		errs.Add("purchase_date", "is required")
+	} else {
+		i.validatePurchaseDate(&errs)
 	}
 
-	if i.PurchaseDate == "" {
-		errs = append(errs, "purchase_date is required")
-	} else if !isValidDateFormat(i.PurchaseDate) {
-		errs = append(errs, "purchase_date must be in YYYY-MM-DD format")
+	if i.PurchaseCountry != "" && !IsValidCountryCode(i.PurchaseCountry) {
+		errs.Add("purchase_country", purchaseCountryReason)
 	}
 
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, ", "))
+	if i.TaxAmount != nil {
+		if i.TaxAmount.IsNegative() {
+			errs.Add("tax_amount", taxAmountReason)
+		} else if i.TaxIncluded && i.TaxAmount.Amount > i.PurchasePrice.Amount {
+			errs.Add("tax_amount", "must not exceed purchase_price when tax_included is true")
+		}
 	}
 
-	return nil
+	// カテゴリー固有のルール
+	if IsValidCategory(i.Category) {
+		errs = append(errs, validateCategoryRules(i)...)
+	}
+
+	return errs.Err()
+}
+
+func (i *Item) validatePurchasePrice(errs *ValidationErrors) {
+	if i.PurchasePrice.IsNegative() {
+		errs.Add("purchase_price", "must be 0 or greater")
+	}
+	if !IsSupportedCurrency(i.PurchasePrice.Currency) {
+		errs.Add("purchase_price", "currency must be one of: "+strings.Join(SupportedCurrencies(), ", "))
+	}
+}
+
+// 購入日は更新日時（登録時は作成日時）より未来にできない（更新日時が未設定の場合は確認しない）
+func (i *Item) validatePurchaseDate(errs *ValidationErrors) {
+	if !i.PurchaseDate.IsZero() && !i.UpdatedAt.IsZero() && i.PurchaseDate.IsFutureAt(i.UpdatedAt) {
+		errs.Add("purchase_date", "must not be in the future")
+	}
 }
 
 // アイテムフィールドのアップデート
-func (i *Item) Update(name, category, brand string, purchasePrice int, purchaseDate string) error {
+func (i *Item) Update(name, category, brand string, purchasePrice Money, purchaseDate Date, now time.Time) error {
+	purchasePrice = normalizeMoney(purchasePrice)
+
+	// 通貨や購入日が変わった場合、固定した為替レートは使えない
+	if purchasePrice.Currency != i.PurchasePrice.Currency || purchaseDate != i.PurchaseDate {
+		i.ExchangeRate = ""
+	}
+
 	i.Name = strings.TrimSpace(name)
 	i.Category = strings.TrimSpace(category)
 	i.Brand = strings.TrimSpace(brand)
 	i.PurchasePrice = purchasePrice
-	i.PurchaseDate = strings.TrimSpace(purchaseDate)
-	i.UpdatedAt = time.Now()
+	i.PurchaseDate = purchaseDate
+	i.UpdatedAt = now
+	i.applyExchangeRate()
+	i.DedupeKey = dedupeKey(i.Name, i.Brand)
 
 	return i.Validate()
 }
 
+// 購入日時点の為替レートを設定し、円換算額を求める
+func (i *Item) SetExchangeRate(rate string) error {
+	normalized, err := NormalizeExchangeRate(rate)
+	if err != nil {
+		return err
+	}
+
+	i.ExchangeRate = normalized
+	i.applyExchangeRate()
+	return nil
+}
+
+// 外貨建てで為替レートが未設定か（購入日が未入力の下書きはレートを決められない）
+func (i *Item) NeedsExchangeRate() bool {
+	return i.PurchasePrice.Currency != DefaultCurrency && i.ExchangeRate == "" && !i.PurchaseDate.IsZero()
+}
+
+// 円換算額を購入価格・税額と為替レートから求める（円建ての場合は購入価格・税額そのもの）
+// 税額の通貨は購入価格の通貨に合わせる
+func (i *Item) applyExchangeRate() {
+	if i.TaxAmount != nil {
+		tax := Money{Amount: i.TaxAmount.Amount, Currency: i.PurchasePrice.Currency}
+		i.TaxAmount = &tax
+	}
+	if i.PurchasePrice.Currency == DefaultCurrency {
+		i.ExchangeRate = ""
+	}
+	i.PurchasePriceJPY = i.convertToJPY(&i.PurchasePrice)
+	i.TaxAmountJPY = i.convertToJPY(i.TaxAmount)
+}
+
+// 購入価格の通貨の金額の円換算額（為替レートが未設定の場合はnil）
+func (i *Item) convertToJPY(m *Money) *Money {
+	if m == nil {
+		return nil
+	}
+	if m.Currency == DefaultCurrency {
+		jpy := *m
+		return &jpy
+	}
+	if i.ExchangeRate == "" {
+		return nil
+	}
+	jpy, err := m.ConvertToJPY(i.ExchangeRate)
+	if err != nil {
+		return nil
+	}
+	return &jpy
+}
+
+// 税額（nil の場合は未記録にする）と購入価格が税込かどうかのアップデート
+func (i *Item) SetTax(amount *int64, included bool) {
+	i.setTax(amount, included)
+	i.applyExchangeRate()
+}
+
+// 税率（%）と購入価格から税額を求めて設定する（最小単位未満は mode で丸める）
+func (i *Item) SetTaxRate(rate string, included bool, mode RoundingMode) error {
+	tax, err := i.PurchasePrice.Tax(rate, included, mode)
+	if err != nil {
+		return err
+	}
+	i.SetTax(&tax.Amount, included)
+	return nil
+}
+
+func (i *Item) setTax(amount *int64, included bool) {
+	i.TaxIncluded = included
+	i.TaxAmount = nil
+	if amount != nil {
+		i.TaxAmount = &Money{Amount: *amount, Currency: i.PurchasePrice.Currency}
+	}
+}
+
+// 税額（最小単位の整数、未記録の場合はnil）
+func (i *Item) TaxAmountValue() *int64 {
+	if i.TaxAmount == nil {
+		return nil
+	}
+	amount := i.TaxAmount.Amount
+	return &amount
+}
+
+// 最新の評価額を設定し、購入価格に対する含み損益を求める
+// 通貨が異なる場合は、評価額が円建てで円換算額がわかるときのみ円で比較する
+func (i *Item) SetLatestValuation(valuation *Valuation) {
+	i.LatestValuation = valuation
+	i.UnrealizedGain = nil
+	if valuation == nil {
+		return
+	}
+
+	cost := i.PurchasePrice
+	if valuation.Value.Currency != cost.Currency {
+		if valuation.Value.Currency != DefaultCurrency || i.PurchasePriceJPY == nil {
+			return
+		}
+		cost = *i.PurchasePriceJPY
+	}
+
+	gain := Money{Amount: valuation.Value.Amount - cost.Amount, Currency: cost.Currency}
+	i.UnrealizedGain = &gain
+}
+
+// 論理削除されているか
+func (i *Item) IsDeleted() bool {
+	return i.DeletedAt != nil
+}
+
+// 完全削除が予定されているか
+func (i *Item) IsPurgeScheduled() bool {
+	return i.PurgeAt != nil
+}
+
+// 完全削除を予定する（予定日時は現在より後であること）
+func (i *Item) SchedulePurge(purgeAt, now time.Time) error {
+	if !purgeAt.After(now) {
+		return errors.New("purge_at must be in the future")
+	}
+	i.PurgeAt = &purgeAt
+	i.UpdatedAt = now
+	return nil
+}
+
+// 完全削除の予定を取り消す
+func (i *Item) CancelPurge(now time.Time) {
+	i.PurgeAt = nil
+	i.UpdatedAt = now
+}
+
+// 売却済みにする
+func (i *Item) MarkSold(now time.Time) {
+	i.Sold = true
+	i.UpdatedAt = now
+}
+
+// 保全の理由の最大文字数
+const MaxHoldReasonLength = 255
+
+// 保全状態の設定（解除時は理由も消去する）
+func (i *Item) SetHold(onHold bool, reason string, now time.Time) error {
+	reason = strings.TrimSpace(reason)
+	if !onHold {
+		reason = ""
+	}
+	if utf8.RuneCountInString(reason) > MaxHoldReasonLength {
+		return errors.New("reason must be 255 characters or less")
+	}
+
+	i.OnHold = onHold
+	i.HoldReason = reason
+	i.UpdatedAt = now
+	return nil
+}
+
+// 属性のアップデート
+func (i *Item) SetAttributes(attributes map[string]string) {
+	i.Attributes = normalizeAttributes(attributes)
+}
+
+// 購入した国・地域のアップデート（空文字の場合は未設定にする）
+func (i *Item) SetPurchaseCountry(country string) {
+	i.PurchaseCountry = NormalizeCountryCode(country)
+}
+
+// 属性の前後の空白を除去し、空の値を取り除く
+func normalizeAttributes(attributes map[string]string) map[string]string {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	normalized := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if key == "" || value == "" {
+			continue
+		}
+		normalized[key] = value
+	}
+
+	if len(normalized) == 0 {
+		return nil
+	}
+	return normalized
+}
+
+// カテゴリーとして指定可能か（未分類を含む）
+func IsValidCategory(category string) bool {
+	return isValidCategory(category) || category == UncategorizedCategory
+}
+
 // カテゴリーのバリデーション
 func isValidCategory(category string) bool {
 	for _, valid := range ValidCategories {
@@ -99,12 +493,6 @@ func isValidCategory(category string) bool {
 	return false
 }
 
-// デート形式のバリデーション
-func isValidDateFormat(dateStr string) bool {
-	_, err := time.Parse("2006-01-02", dateStr)
-	return err == nil
-}
-
 // カテゴリーの取得
 func GetValidCategories() []string {
 	return ValidCategories
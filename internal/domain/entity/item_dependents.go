@@ -0,0 +1,13 @@
+package entity
+
+// アイテムに紐づくデータ（写真・評価額・タグ・保険の契約）の件数
+type ItemDependents struct {
+	Images            int `json:"images"`
+	Valuations        int `json:"valuations"`
+	Tags              int `json:"tags"`
+	InsurancePolicies int `json:"insurance_policies"`
+}
+
+func (d ItemDependents) Total() int {
+	return d.Images + d.Valuations + d.Tags + d.InsurancePolicies
+}
@@ -0,0 +1,73 @@
+package entity
+
+import "time"
+
+// アイテムに添付された写真
+type ItemImage struct {
+	ID          int64  `json:"id"`
+	ItemID      int64  `json:"item_id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+
+	// ストレージ上の保存先（外部には公開しない）
+	StorageKey string `json:"-"`
+	// 写真を取得するURL（ストレージから導出するため保存しない）
+	URL string `json:"url"`
+
+	// 下書きのアイテムに添付した場合の、撮影日時・撮影場所から推測した購入日・購入場所（登録時のレスポンスのみ）
+	Suggestions *PhotoSuggestions `json:"suggestions,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// 写真のメタデータ（EXIF）から読み取った撮影日時と撮影場所
+type ImageMetadata struct {
+	TakenAt  *time.Time // 撮影地の時刻（タイムゾーンは不明なため UTC として扱う）
+	Location *GeoLocation
+}
+
+// 緯度・経度（度）
+type GeoLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// 写真から推測したアイテムの項目の候補（アイテムには反映しない）
+type PhotoSuggestions struct {
+	PurchaseDate     string       `json:"purchase_date,omitempty"` // YYYY-MM-DD 形式
+	PurchaseLocation *GeoLocation `json:"purchase_location,omitempty"`
+}
+
+// 撮影日時・撮影場所から候補を作る（どちらもない場合は nil）
+func NewPhotoSuggestions(metadata *ImageMetadata) *PhotoSuggestions {
+	if metadata == nil || (metadata.TakenAt == nil && metadata.Location == nil) {
+		return nil
+	}
+
+	suggestions := &PhotoSuggestions{PurchaseLocation: metadata.Location}
+	if metadata.TakenAt != nil {
+		suggestions.PurchaseDate = DateOf(*metadata.TakenAt).String()
+	}
+	return suggestions
+}
+
+// 写真として受け付けるMIMEタイプと拡張子
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// 受け付けるMIMEタイプ
+var AllowedImageContentTypes = []string{"image/jpeg", "image/png", "image/webp"}
+
+func IsAllowedImageContentType(contentType string) bool {
+	_, ok := imageExtensions[contentType]
+	return ok
+}
+
+// MIMEタイプに対応する拡張子（未対応の場合は空文字）
+func ImageExtension(contentType string) string {
+	return imageExtensions[contentType]
+}
@@ -0,0 +1,68 @@
+package entity
+
+// 一覧の並び替えキー
+type ItemSortKey string
+
+const (
+	SortByPurchasePrice ItemSortKey = "purchase_price"
+	SortByPurchaseDate  ItemSortKey = "purchase_date"
+	SortByCreatedAt     ItemSortKey = "created_at"
+	SortByName          ItemSortKey = "name"
+	SortByChangeSeq     ItemSortKey = "change_seq"
+
+	// ID順（エクスポートなど内部処理用。APIからは指定できない）
+	SortByID ItemSortKey = "id"
+)
+
+// 並び順
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// 指定可能な並び替えキー（ホワイトリスト）
+var ValidItemSortKeys = []ItemSortKey{SortByPurchasePrice, SortByPurchaseDate, SortByCreatedAt, SortByName, SortByChangeSeq}
+
+// アイテム一覧の取得条件
+type ItemQuery struct {
+	Limit  int
+	Offset int
+	Sort   ItemSortKey
+	Order  SortOrder
+
+	// 名前・ブランドの部分一致検索キーワード（空の場合は絞り込まない）
+	Keyword string
+
+	// カテゴリーの完全一致（空の場合は絞り込まない）
+	Category string
+
+	// このタグが付いたアイテムのみ（空の場合は絞り込まない）
+	Tag string
+
+	// 論理削除されたアイテムも含める（管理者用）
+	IncludeDeleted bool
+
+	// カーソル: このIDより大きいアイテムのみ取得する（0の場合は絞り込まない。ID昇順と組み合わせて使う）
+	AfterID int64
+
+	// 変更の通し番号がこの値より大きいアイテムのみ（0の場合は絞り込まない。変更の通し番号の昇順と組み合わせて同期に使う）
+	ChangedAfter int64
+
+	// このIDのアイテムのみ（空の場合は絞り込まない。外部の検索エンジンの結果の取得に使う）
+	IDs []int64
+}
+
+func (k ItemSortKey) IsValid() bool {
+	for _, valid := range ValidItemSortKeys {
+		if k == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func (o SortOrder) IsValid() bool {
+	return o == SortAsc || o == SortDesc
+}
@@ -0,0 +1,104 @@
+package entity
+
+import "time"
+
+// ある時点のアイテムの状態（元に戻せる項目のみ）
+type ItemSnapshot struct {
+	Name          string            `json:"name"`
+	Category      string            `json:"category"`
+	Brand         string            `json:"brand"`
+	PurchasePrice Money             `json:"purchase_price"`
+	PurchaseDate  Date              `json:"purchase_date"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+	ExchangeRate  string            `json:"exchange_rate,omitempty"`
+
+	PurchaseCountry string `json:"purchase_country,omitempty"`
+
+	TaxAmount   *int64 `json:"tax_amount,omitempty"`
+	TaxIncluded bool   `json:"tax_included,omitempty"`
+}
+
+// アイテムの登録・更新ごとに保存する版（バージョンはアイテムごとに1から採番する）
+type ItemRevision struct {
+	ID        int64        `json:"id"`
+	ItemID    int64        `json:"item_id"`
+	Version   int          `json:"version"`
+	Snapshot  ItemSnapshot `json:"snapshot"`
+	Actor     string       `json:"actor"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// アイテムの現在の状態を版として作成する（バージョンは保存時に採番する）
+func NewItemRevision(item *Item, actor string, now time.Time) *ItemRevision {
+	return &ItemRevision{
+		ItemID:    item.ID,
+		Snapshot:  NewItemSnapshot(item),
+		Actor:     actor,
+		CreatedAt: now,
+	}
+}
+
+// アイテムの現在の状態（後からの変更の影響を受けないよう、属性はコピーする）
+func NewItemSnapshot(item *Item) ItemSnapshot {
+	var attributes map[string]string
+	if len(item.Attributes) > 0 {
+		attributes = make(map[string]string, len(item.Attributes))
+		for key, value := range item.Attributes {
+			attributes[key] = value
+		}
+	}
+
+	return ItemSnapshot{
+		Name:          item.Name,
+		Category:      item.Category,
+		Brand:         item.Brand,
+		PurchasePrice: item.PurchasePrice,
+		PurchaseDate:  item.PurchaseDate,
+		Attributes:    attributes,
+		ExchangeRate:  item.ExchangeRate,
+
+		PurchaseCountry: item.PurchaseCountry,
+
+		TaxAmount:   item.TaxAmountValue(),
+		TaxIncluded: item.TaxIncluded,
+	}
+}
+
+// アイテムをこの時点の状態に戻す
+// 現在のカテゴリー固有のルールで検証するため、ルールの変更後は戻せない場合がある
+func (s ItemSnapshot) ApplyTo(item *Item, now time.Time) error {
+	item.SetAttributes(s.Attributes)
+	item.SetPurchaseCountry(s.PurchaseCountry)
+	item.setTax(s.TaxAmount, s.TaxIncluded)
+	if err := item.Update(s.Name, s.Category, s.Brand, s.PurchasePrice, s.PurchaseDate, now); err != nil {
+		return err
+	}
+
+	// 為替レートは当時固定したものを使う
+	if s.ExchangeRate != "" {
+		return item.SetExchangeRate(s.ExchangeRate)
+	}
+	return nil
+}
+
+// この版の時点のアイテム（現在のアイテムに版の状態を反映した複製）
+// 当時の状態をそのまま返すため検証は行わない。版に含まれないタグ・評価額は含めない
+func (r *ItemRevision) ItemAt(current *Item) *Item {
+	item := *current
+	item.Name = r.Snapshot.Name
+	item.Category = r.Snapshot.Category
+	item.Brand = r.Snapshot.Brand
+	item.PurchasePrice = r.Snapshot.PurchasePrice
+	item.PurchaseDate = r.Snapshot.PurchaseDate
+	item.Attributes = normalizeAttributes(r.Snapshot.Attributes)
+	item.ExchangeRate = r.Snapshot.ExchangeRate
+	item.PurchaseCountry = r.Snapshot.PurchaseCountry
+	item.setTax(r.Snapshot.TaxAmount, r.Snapshot.TaxIncluded)
+	item.UpdatedAt = r.CreatedAt
+	item.DedupeKey = dedupeKey(item.Name, item.Brand)
+	item.Tags = nil
+	item.LatestValuation = nil
+	item.UnrealizedGain = nil
+	item.applyExchangeRate()
+	return &item
+}
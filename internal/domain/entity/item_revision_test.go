@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemSnapshot_ApplyTo(t *testing.T) {
+	t.Run("正常系: 保存した時点の状態に戻す", func(t *testing.T) {
+		item, err := NewItem("ロレックス デイトナ", "時計", "ROLEX", Money{Amount: 1000000, Currency: "USD"}, MustParseDate("2023-01-15"),
+			time.Now(),
+			WithAttributes(map[string]string{"reference_number": "116500LN"}))
+		require.NoError(t, err)
+		require.NoError(t, item.SetExchangeRate("130"))
+		snapshot := NewItemSnapshot(item)
+
+		// 通貨を変更すると固定した為替レートは外れる
+		item.SetAttributes(map[string]string{"reference_number": "126500LN"})
+		require.NoError(t, item.Update("デイトナ", "時計", "ROLEX", JPY(2000000), MustParseDate("2024-01-01"), time.Now()))
+
+		require.NoError(t, snapshot.ApplyTo(item, time.Now()))
+
+		assert.Equal(t, "ロレックス デイトナ", item.Name)
+		assert.Equal(t, Money{Amount: 1000000, Currency: "USD"}, item.PurchasePrice)
+		assert.Equal(t, "2023-01-15", item.PurchaseDate.String())
+		assert.Equal(t, map[string]string{"reference_number": "116500LN"}, item.Attributes)
+		assert.Equal(t, "130", item.ExchangeRate)
+		require.NotNil(t, item.PurchasePriceJPY)
+		assert.Equal(t, JPY(1300000), *item.PurchasePriceJPY)
+	})
+
+	t.Run("正常系: 保存後の属性の変更の影響を受けない", func(t *testing.T) {
+		item := &Item{Attributes: map[string]string{"material": "gold"}}
+		snapshot := NewItemSnapshot(item)
+		item.Attributes["material"] = "silver"
+
+		assert.Equal(t, map[string]string{"material": "gold"}, snapshot.Attributes)
+	})
+
+	t.Run("異常系: 現在のルールで不正な状態には戻せない", func(t *testing.T) {
+		item, err := NewItem("時計1", "時計", "ROLEX", JPY(1000), MustParseDate("2023-01-01"), time.Now())
+		require.NoError(t, err)
+
+		snapshot := ItemSnapshot{Name: "時計1", Category: "家具", Brand: "ROLEX", PurchasePrice: JPY(1000), PurchaseDate: MustParseDate("2023-01-01")}
+		assert.Error(t, snapshot.ApplyTo(item, time.Now()))
+	})
+}
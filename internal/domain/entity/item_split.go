@@ -0,0 +1,209 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 分割時の購入価格の按分方法
+type SplitAllocation string
+
+const (
+	SplitEqual    SplitAllocation = "equal"  // 均等に按分する
+	SplitByRatio  SplitAllocation = "ratio"  // 構成品ごとの比率（ratio）で按分する
+	SplitByAmount SplitAllocation = "amount" // 構成品ごとの購入価格を指定する（合計は分割元の購入価格）
+)
+
+// 1回の分割で登録できる構成品の数
+const (
+	MinSplitComponents = 2
+	MaxSplitComponents = 50
+)
+
+// 分割して登録する構成品（カテゴリー・ブランドは未指定の場合は分割元と同じ）
+type SplitComponent struct {
+	Name          string            `json:"name"`
+	Category      string            `json:"category,omitempty"`
+	Brand         string            `json:"brand,omitempty"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+	Ratio         int64             `json:"ratio,omitempty"`          // allocation が ratio の場合の比率
+	PurchasePrice *Money            `json:"purchase_price,omitempty"` // allocation が amount の場合の購入価格
+}
+
+// 分割の按分方法か
+func IsValidSplitAllocation(allocation SplitAllocation) bool {
+	switch allocation {
+	case SplitEqual, SplitByRatio, SplitByAmount:
+		return true
+	}
+	return false
+}
+
+// アイテムを構成品に分割する（分割元は変更しない）
+// 構成品は分割元の購入日・購入した国・地域・為替レート・税込かどうかを引き継ぎ、購入価格と税額は allocation で按分する
+// 税額は按分した購入価格の比で按分するため、構成品の合計は分割元と一致する
+func (i *Item) Split(components []SplitComponent, allocation SplitAllocation, now time.Time) ([]*Item, error) {
+	if i.Draft {
+		return nil, ValidationErrors{{Reason: "draft items cannot be split"}}
+	}
+
+	prices, err := i.allocateSplit(components, allocation)
+	if err != nil {
+		return nil, err
+	}
+
+	taxes := make([]*int64, len(components))
+	if i.TaxAmount != nil {
+		for n, tax := range i.TaxAmount.Allocate(splitWeights(prices)) {
+			amount := tax.Amount
+			taxes[n] = &amount
+		}
+	}
+
+	var errs ValidationErrors
+	items := make([]*Item, 0, len(components))
+	for n, component := range components {
+		category := strings.TrimSpace(component.Category)
+		if category == "" {
+			category = i.Category
+		}
+		brand := strings.TrimSpace(component.Brand)
+		if brand == "" {
+			brand = i.Brand
+		}
+
+		item, err := NewItem(component.Name, category, brand, prices[n], i.PurchaseDate, now,
+			WithAttributes(component.Attributes),
+			WithPurchaseCountry(i.PurchaseCountry),
+			WithTax(taxes[n], i.TaxIncluded),
+		)
+		if err != nil {
+			errs = append(errs, componentErrors(n, err)...)
+			continue
+		}
+		if i.ExchangeRate != "" {
+			if err := item.SetExchangeRate(i.ExchangeRate); err != nil {
+				return nil, err
+			}
+		}
+		splitFrom := i.ID
+		item.SplitFrom = &splitFrom
+		items = append(items, item)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// 構成品ごとの購入価格を按分する
+func (i *Item) allocateSplit(components []SplitComponent, allocation SplitAllocation) ([]Money, error) {
+	var errs ValidationErrors
+	if len(components) < MinSplitComponents || len(components) > MaxSplitComponents {
+		errs.Add("components", fmt.Sprintf("must have %d to %d items", MinSplitComponents, MaxSplitComponents))
+	}
+	if !IsValidSplitAllocation(allocation) {
+		errs.Add("allocation", "must be one of: equal, ratio, amount")
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	weights := make([]int64, len(components))
+	switch allocation {
+	case SplitEqual:
+		for n := range weights {
+			weights[n] = 1
+		}
+	case SplitByRatio:
+		for n, component := range components {
+			if component.Ratio <= 0 {
+				errs.Add(fmt.Sprintf("components[%d].ratio", n), "must be greater than 0")
+			}
+			weights[n] = component.Ratio
+		}
+	case SplitByAmount:
+		return i.splitAmounts(components)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	return i.PurchasePrice.Allocate(weights), nil
+}
+
+// 指定された構成品の購入価格を検証する（分割元と同じ通貨で、合計は分割元の購入価格）
+func (i *Item) splitAmounts(components []SplitComponent) ([]Money, error) {
+	var errs ValidationErrors
+	prices := make([]Money, len(components))
+	total := Money{Currency: i.PurchasePrice.Currency}
+	for n, component := range components {
+		field := fmt.Sprintf("components[%d].purchase_price", n)
+		if component.PurchasePrice == nil {
+			errs.Add(field, "is required")
+			continue
+		}
+
+		price := normalizeMoney(*component.PurchasePrice)
+		if price.Currency != i.PurchasePrice.Currency {
+			errs.Add(field, "currency must be the same as the item ("+i.PurchasePrice.Currency+")")
+			continue
+		}
+		if price.IsNegative() {
+			errs.Add(field, "must be 0 or greater")
+			continue
+		}
+
+		sum, err := total.Add(price)
+		if err != nil {
+			errs.Add(field, err.Error())
+			continue
+		}
+		total = sum
+		prices[n] = price
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	if total.Amount != i.PurchasePrice.Amount {
+		return nil, ValidationErrors{{Field: "components", Reason: fmt.Sprintf("purchase_price total must be %s (got %s)", i.PurchasePrice, total)}}
+	}
+	return prices, nil
+}
+
+// 按分した購入価格を税額の按分の重みにする（購入価格がすべて0の場合は均等）
+func splitWeights(prices []Money) []int64 {
+	weights := make([]int64, len(prices))
+	total := int64(0)
+	for n, price := range prices {
+		weights[n] = price.Amount
+		total += price.Amount
+	}
+	if total == 0 {
+		for n := range weights {
+			weights[n] = 1
+		}
+	}
+	return weights
+}
+
+// 構成品のバリデーションエラーの項目名に構成品の番号を付ける（例: components[1].name）
+func componentErrors(n int, err error) ValidationErrors {
+	fieldErrs, ok := err.(ValidationErrors)
+	if !ok {
+		return ValidationErrors{{Field: fmt.Sprintf("components[%d]", n), Reason: err.Error()}}
+	}
+
+	prefixed := make(ValidationErrors, len(fieldErrs))
+	for k, fieldErr := range fieldErrs {
+		field := fmt.Sprintf("components[%d]", n)
+		if fieldErr.Field != "" {
+			field += "." + fieldErr.Field
+		}
+		prefixed[k] = FieldError{Field: field, Reason: fieldErr.Reason}
+	}
+	return prefixed
+}
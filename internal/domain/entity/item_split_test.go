@@ -0,0 +1,153 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItem_Split(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	newSet := func() *Item {
+		tax := int64(10000)
+		item, err := NewItem("ティファニー ジュエリーセット", "ジュエリー", "Tiffany & Co.", JPY(100000), MustParseDate("2023-05-01"), now,
+			WithPurchaseCountry("US"), WithTax(&tax, true))
+		require.NoError(t, err)
+		item.ID = 10
+		return item
+	}
+
+	t.Run("正常系: 均等に按分し、端数は先頭の構成品に配分する", func(t *testing.T) {
+		components, err := newSet().Split([]SplitComponent{
+			{Name: "ネックレス", Attributes: map[string]string{AttrMaterial: "シルバー"}},
+			{Name: "ピアス"},
+			{Name: "ブレスレット", Brand: "Tiffany"},
+		}, SplitEqual, now)
+		require.NoError(t, err)
+		require.Len(t, components, 3)
+
+		assert.Equal(t, JPY(33334), components[0].PurchasePrice)
+		assert.Equal(t, JPY(33333), components[1].PurchasePrice)
+		assert.Equal(t, JPY(33333), components[2].PurchasePrice)
+		assert.Equal(t, int64(3334), *components[0].TaxAmountValue())
+		assert.Equal(t, int64(3333), *components[1].TaxAmountValue())
+
+		first := components[0]
+		assert.Equal(t, "ネックレス", first.Name)
+		assert.Equal(t, "ジュエリー", first.Category)
+		assert.Equal(t, "Tiffany & Co.", first.Brand)
+		assert.Equal(t, "シルバー", first.Attributes[AttrMaterial])
+		assert.Equal(t, MustParseDate("2023-05-01"), first.PurchaseDate)
+		assert.Equal(t, "US", first.PurchaseCountry)
+		assert.True(t, first.TaxIncluded)
+		assert.Equal(t, int64(10), *first.SplitFrom)
+		assert.Equal(t, now, first.CreatedAt)
+		assert.Equal(t, "Tiffany", components[2].Brand)
+	})
+
+	t.Run("正常系: 比率で按分し、税額は按分した購入価格の比", func(t *testing.T) {
+		components, err := newSet().Split([]SplitComponent{
+			{Name: "ネックレス", Ratio: 3},
+			{Name: "ピアス", Ratio: 1},
+		}, SplitByRatio, now)
+		require.NoError(t, err)
+
+		assert.Equal(t, JPY(75000), components[0].PurchasePrice)
+		assert.Equal(t, JPY(25000), components[1].PurchasePrice)
+		assert.Equal(t, int64(7500), *components[0].TaxAmountValue())
+		assert.Equal(t, int64(2500), *components[1].TaxAmountValue())
+	})
+
+	t.Run("正常系: 金額を指定し、外貨建ての為替レートを引き継ぐ", func(t *testing.T) {
+		item, err := NewItem("Cartier セット", "ジュエリー", "Cartier", Money{Amount: 100000, Currency: "USD"}, MustParseDate("2023-05-01"), now)
+		require.NoError(t, err)
+		require.NoError(t, item.SetExchangeRate("150"))
+
+		components, err := item.Split([]SplitComponent{
+			{Name: "リング", PurchasePrice: &Money{Amount: 80000, Currency: "USD"}},
+			{Name: "ケース", Category: "その他", PurchasePrice: &Money{Amount: 20000, Currency: "USD"}},
+		}, SplitByAmount, now)
+		require.NoError(t, err)
+
+		assert.Equal(t, "150", components[0].ExchangeRate)
+		assert.Equal(t, &Money{Amount: 120000, Currency: "JPY"}, components[0].PurchasePriceJPY)
+		assert.Equal(t, "その他", components[1].Category)
+		assert.Nil(t, components[1].TaxAmount)
+	})
+
+	t.Run("異常系: 入力の誤り", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			components []SplitComponent
+			allocation SplitAllocation
+			expected   ValidationErrors
+		}{
+			{
+				name:       "構成品が1つ",
+				components: []SplitComponent{{Name: "ネックレス"}},
+				allocation: SplitEqual,
+				expected:   ValidationErrors{{Field: "components", Reason: "must have 2 to 50 items"}},
+			},
+			{
+				name:       "未対応の按分方法",
+				components: []SplitComponent{{Name: "ネックレス"}, {Name: "ピアス"}},
+				allocation: "weight",
+				expected:   ValidationErrors{{Field: "allocation", Reason: "must be one of: equal, ratio, amount"}},
+			},
+			{
+				name:       "比率が0",
+				components: []SplitComponent{{Name: "ネックレス", Ratio: 1}, {Name: "ピアス"}},
+				allocation: SplitByRatio,
+				expected:   ValidationErrors{{Field: "components[1].ratio", Reason: "must be greater than 0"}},
+			},
+			{
+				name: "金額の合計が分割元の購入価格と異なる",
+				components: []SplitComponent{
+					{Name: "ネックレス", PurchasePrice: &Money{Amount: 60000, Currency: "JPY"}},
+					{Name: "ピアス", PurchasePrice: &Money{Amount: 30000, Currency: "JPY"}},
+				},
+				allocation: SplitByAmount,
+				expected:   ValidationErrors{{Field: "components", Reason: "purchase_price total must be 100000 JPY (got 90000 JPY)"}},
+			},
+			{
+				name: "金額の未指定と通貨の違い",
+				components: []SplitComponent{
+					{Name: "ネックレス"},
+					{Name: "ピアス", PurchasePrice: &Money{Amount: 100000, Currency: "USD"}},
+				},
+				allocation: SplitByAmount,
+				expected: ValidationErrors{
+					{Field: "components[0].purchase_price", Reason: "is required"},
+					{Field: "components[1].purchase_price", Reason: "currency must be the same as the item (JPY)"},
+				},
+			},
+			{
+				name:       "構成品の名前が未入力",
+				components: []SplitComponent{{Name: "ネックレス"}, {Name: " ", Category: "家具"}},
+				allocation: SplitEqual,
+				expected: ValidationErrors{
+					{Field: "components[1].name", Reason: "is required"},
+					{Field: "components[1].category", Reason: categoryReason},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				components, err := newSet().Split(tt.components, tt.allocation, now)
+				assert.Nil(t, components)
+				assert.Equal(t, tt.expected, err)
+			})
+		}
+	})
+
+	t.Run("異常系: 下書きは分割できない", func(t *testing.T) {
+		item := newSet()
+		item.Draft = true
+
+		_, err := item.Split([]SplitComponent{{Name: "ネックレス"}, {Name: "ピアス"}}, SplitEqual, now)
+		assert.EqualError(t, err, "draft items cannot be split")
+	})
+}
@@ -14,7 +14,7 @@ func TestNewItem(t *testing.T) {
 		itemName      string
 		category      string
 		brand         string
-		purchasePrice int
+		purchasePrice Money
 		purchaseDate  string
 		wantErr       bool
 		expectedErr   string
@@ -24,7 +24,7 @@ func TestNewItem(t *testing.T) {
 			itemName:      "ロレックス デイトナ",
 			category:      "時計",
 			brand:         "ROLEX",
-			purchasePrice: 1500000,
+			purchasePrice: JPY(1500000),
 			purchaseDate:  "2023-01-15",
 			wantErr:       false,
 		},
@@ -33,7 +33,7 @@ func TestNewItem(t *testing.T) {
 			itemName:      "",
 			category:      "時計",
 			brand:         "ROLEX",
-			purchasePrice: 1500000,
+			purchasePrice: JPY(1500000),
 			purchaseDate:  "2023-01-15",
 			wantErr:       true,
 			expectedErr:   "name is required",
@@ -43,7 +43,7 @@ func TestNewItem(t *testing.T) {
 			itemName:      "ロレックス デイトナ 16520 18K イエローゴールド ブラック文字盤 自動巻き クロノグラフ メンズ 腕時計 1988年製 ヴィンテージ 希少 コレクション アイテム",
 			category:      "時計",
 			brand:         "ROLEX",
-			purchasePrice: 1500000,
+			purchasePrice: JPY(1500000),
 			purchaseDate:  "2023-01-15",
 			wantErr:       true,
 			expectedErr:   "name must be 100 characters or less",
@@ -53,7 +53,7 @@ func TestNewItem(t *testing.T) {
 			itemName:      "ロレックス デイトナ",
 			category:      "",
 			brand:         "ROLEX",
-			purchasePrice: 1500000,
+			purchasePrice: JPY(1500000),
 			purchaseDate:  "2023-01-15",
 			wantErr:       true,
 			expectedErr:   "category is required",
@@ -63,7 +63,7 @@ func TestNewItem(t *testing.T) {
 			itemName:      "ロレックス デイトナ",
 			category:      "無効なカテゴリー",
 			brand:         "ROLEX",
-			purchasePrice: 1500000,
+			purchasePrice: JPY(1500000),
 			purchaseDate:  "2023-01-15",
 			wantErr:       true,
 			expectedErr:   "category must be one of: 時計, バッグ, ジュエリー, 靴, その他",
@@ -73,7 +73,7 @@ func TestNewItem(t *testing.T) {
 			itemName:      "ロレックス デイトナ",
 			category:      "時計",
 			brand:         "",
-			purchasePrice: 1500000,
+			purchasePrice: JPY(1500000),
 			purchaseDate:  "2023-01-15",
 			wantErr:       true,
 			expectedErr:   "brand is required",
@@ -83,7 +83,7 @@ func TestNewItem(t *testing.T) {
 			itemName:      "ロレックス デイトナ",
 			category:      "時計",
 			brand:         "ROLEX SA Geneva Switzerland Official Authorized Dealer Store Premium Collection Limited Edition Special",
-			purchasePrice: 1500000,
+			purchasePrice: JPY(1500000),
 			purchaseDate:  "2023-01-15",
 			wantErr:       true,
 			expectedErr:   "brand must be 100 characters or less",
@@ -93,7 +93,7 @@ func TestNewItem(t *testing.T) {
 			itemName:      "ロレックス デイトナ",
 			category:      "時計",
 			brand:         "ROLEX",
-			purchasePrice: -1,
+			purchasePrice: JPY(-1),
 			purchaseDate:  "2023-01-15",
 			wantErr:       true,
 			expectedErr:   "purchase_price must be 0 or greater",
@@ -103,7 +103,7 @@ func TestNewItem(t *testing.T) {
 			itemName:      "ロレックス デイトナ",
 			category:      "時計",
 			brand:         "ROLEX",
-			purchasePrice: 1500000,
+			purchasePrice: JPY(1500000),
 			purchaseDate:  "",
 			wantErr:       true,
 			expectedErr:   "purchase_date is required",
@@ -113,17 +113,36 @@ func TestNewItem(t *testing.T) {
 			itemName:      "ロレックス デイトナ",
 			category:      "時計",
 			brand:         "ROLEX",
-			purchasePrice: 1500000,
-			purchaseDate:  "2023/01/15",
+			purchasePrice: JPY(1500000),
+			purchaseDate:  "2099-01-15",
 			wantErr:       true,
-			expectedErr:   "purchase_date must be in YYYY-MM-DD format",
+			expectedErr:   "purchase_date must not be in the future",
+		},
+		{
+			name:          "正常系: 外貨建ての購入価格",
+			itemName:      "オメガ スピードマスター",
+			category:      "時計",
+			brand:         "OMEGA",
+			purchasePrice: Money{Amount: 750000, Currency: "USD"},
+			purchaseDate:  "2023-01-15",
+			wantErr:       false,
+		},
+		{
+			name:          "異常系: 未対応の通貨",
+			itemName:      "オメガ スピードマスター",
+			category:      "時計",
+			brand:         "OMEGA",
+			purchasePrice: Money{Amount: 750000, Currency: "XYZ"},
+			purchaseDate:  "2023-01-15",
+			wantErr:       true,
+			expectedErr:   "purchase_price currency must be one of: CHF, CNY, EUR, GBP, HKD, JPY, KRW, USD",
 		},
 		{
 			name:          "正常系: 購入価格が0",
 			itemName:      "ギフト品",
 			category:      "その他",
 			brand:         "不明",
-			purchasePrice: 0,
+			purchasePrice: JPY(0),
 			purchaseDate:  "2023-01-15",
 			wantErr:       false,
 		},
@@ -131,7 +150,8 @@ func TestNewItem(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			item, err := NewItem(tt.itemName, tt.category, tt.brand, tt.purchasePrice, tt.purchaseDate)
+			now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+			item, err := NewItem(tt.itemName, tt.category, tt.brand, tt.purchasePrice, MustParseDate(tt.purchaseDate), now)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -148,18 +168,18 @@ func TestNewItem(t *testing.T) {
 			assert.Equal(t, tt.category, item.Category)
 			assert.Equal(t, tt.brand, item.Brand)
 			assert.Equal(t, tt.purchasePrice, item.PurchasePrice)
-			assert.Equal(t, tt.purchaseDate, item.PurchaseDate)
+			assert.Equal(t, tt.purchaseDate, item.PurchaseDate.String())
 
-			// CreatedAt と UpdatedAt がセットされているかチェック
-			assert.False(t, item.CreatedAt.IsZero())
-			assert.False(t, item.UpdatedAt.IsZero())
+			// CreatedAt と UpdatedAt に指定した時刻がセットされているかチェック
+			assert.Equal(t, now, item.CreatedAt)
+			assert.Equal(t, now, item.UpdatedAt)
 		})
 	}
 }
 
 func TestItem_Update(t *testing.T) {
 	// 初期アイテムを作成
-	item, err := NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+	item, err := NewItem("初期アイテム", "時計", "初期ブランド", JPY(100000), MustParseDate("2023-01-01"), time.Now())
 	require.NoError(t, err)
 
 	originalUpdatedAt := item.UpdatedAt
@@ -170,7 +190,7 @@ func TestItem_Update(t *testing.T) {
 		newName     string
 		newCategory string
 		newBrand    string
-		newPrice    int
+		newPrice    Money
 		newDate     string
 		wantErr     bool
 		expectedErr string
@@ -180,7 +200,16 @@ func TestItem_Update(t *testing.T) {
 			newName:     "更新されたアイテム",
 			newCategory: "バッグ",
 			newBrand:    "更新されたブランド",
-			newPrice:    200000,
+			newPrice:    JPY(200000),
+			newDate:     "2023-12-31",
+			wantErr:     false,
+		},
+		{
+			name:        "正常系: ブランドを空にする",
+			newName:     "更新されたアイテム",
+			newCategory: "バッグ",
+			newBrand:    "",
+			newPrice:    JPY(200000),
 			newDate:     "2023-12-31",
 			wantErr:     false,
 		},
@@ -189,7 +218,7 @@ func TestItem_Update(t *testing.T) {
 			newName:     "更新されたアイテム",
 			newCategory: "無効なカテゴリー",
 			newBrand:    "更新されたブランド",
-			newPrice:    200000,
+			newPrice:    JPY(200000),
 			newDate:     "2023-12-31",
 			wantErr:     true,
 			expectedErr: "category must be one of: 時計, バッグ, ジュエリー, 靴, その他",
@@ -199,16 +228,26 @@ func TestItem_Update(t *testing.T) {
 			newName:     "更新されたアイテム",
 			newCategory: "バッグ",
 			newBrand:    "更新されたブランド",
-			newPrice:    -1,
+			newPrice:    JPY(-1),
 			newDate:     "2023-12-31",
 			wantErr:     true,
 			expectedErr: "purchase_price must be 0 or greater",
 		},
+		{
+			name:        "異常系: 未来の購入日",
+			newName:     "更新されたアイテム",
+			newCategory: "バッグ",
+			newBrand:    "更新されたブランド",
+			newPrice:    JPY(200000),
+			newDate:     "2099-12-31",
+			wantErr:     true,
+			expectedErr: "purchase_date must not be in the future",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := item.Update(tt.newName, tt.newCategory, tt.newBrand, tt.newPrice, tt.newDate)
+			err := item.Update(tt.newName, tt.newCategory, tt.newBrand, tt.newPrice, MustParseDate(tt.newDate), time.Now())
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -223,7 +262,7 @@ func TestItem_Update(t *testing.T) {
 			assert.Equal(t, tt.newCategory, item.Category)
 			assert.Equal(t, tt.newBrand, item.Brand)
 			assert.Equal(t, tt.newPrice, item.PurchasePrice)
-			assert.Equal(t, tt.newDate, item.PurchaseDate)
+			assert.Equal(t, tt.newDate, item.PurchaseDate.String())
 
 			// UpdatedAt が更新されているかチェック
 			assert.True(t, item.UpdatedAt.After(originalUpdatedAt))
@@ -244,8 +283,8 @@ func TestItem_Validate(t *testing.T) {
 				Name:          "ロレックス デイトナ",
 				Category:      "時計",
 				Brand:         "ROLEX",
-				PurchasePrice: 1500000,
-				PurchaseDate:  "2023-01-15",
+				PurchasePrice: JPY(1500000),
+				PurchaseDate:  MustParseDate("2023-01-15"),
 			},
 			wantErr: false,
 		},
@@ -255,11 +294,10 @@ func TestItem_Validate(t *testing.T) {
 				Name:          "",
 				Category:      "",
 				Brand:         "",
-				PurchasePrice: -1,
-				PurchaseDate:  "",
+				PurchasePrice: JPY(-1),
 			},
 			wantErr:     true,
-			expectedErr: "name is required, category is required, brand is required, purchase_price must be 0 or greater, purchase_date is required",
+			expectedErr: "name is required, category is required, purchase_price must be 0 or greater, purchase_date is required",
 		},
 	}
 
@@ -278,6 +316,61 @@ func TestItem_Validate(t *testing.T) {
 	}
 }
 
+func TestItem_IsDeleted(t *testing.T) {
+	item, err := NewItem("ロレックス デイトナ", "時計", "ROLEX", JPY(1500000), MustParseDate("2023-01-15"), time.Now())
+	require.NoError(t, err)
+	assert.False(t, item.IsDeleted())
+
+	deletedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	item.DeletedAt = &deletedAt
+	assert.True(t, item.IsDeleted())
+}
+
+func TestNewDraftItem(t *testing.T) {
+	t.Run("正常系: 購入価格・購入日・ブランドが未入力でも保存できる", func(t *testing.T) {
+		item, err := NewDraftItem("ロレックス デイトナ", "", "", Money{}, Date{}, time.Now())
+		require.NoError(t, err)
+		assert.True(t, item.Draft)
+		assert.Equal(t, JPY(0), item.PurchasePrice)
+		assert.False(t, item.NeedsExchangeRate())
+	})
+
+	t.Run("異常系: 名前は必須", func(t *testing.T) {
+		_, err := NewDraftItem("", "", "", Money{}, Date{}, time.Now())
+		assert.EqualError(t, err, "name is required")
+	})
+
+	t.Run("異常系: 入力済みの項目は形式を確認する", func(t *testing.T) {
+		_, err := NewDraftItem("時計", "無効なカテゴリー", "", Money{}, MustParseDate("2099-01-15"), time.Now())
+		assert.EqualError(t, err, "category must be one of: 時計, バッグ, ジュエリー, 靴, その他, purchase_date must not be in the future")
+	})
+}
+
+func TestItem_Publish(t *testing.T) {
+	t.Run("正常系: 必須項目が揃えば公開できる", func(t *testing.T) {
+		item, err := NewDraftItem("ロレックス デイトナ", "時計", "ROLEX", JPY(1500000), MustParseDate("2023-01-15"), time.Now())
+		require.NoError(t, err)
+
+		require.NoError(t, item.Publish(time.Now()))
+		assert.False(t, item.Draft)
+	})
+
+	t.Run("異常系: 未入力の項目があると公開できない", func(t *testing.T) {
+		item, err := NewDraftItem("ロレックス デイトナ", "時計", "", JPY(1500000), Date{}, time.Now())
+		require.NoError(t, err)
+
+		err = item.Publish(time.Now())
+		assert.EqualError(t, err, "brand is required, purchase_date is required")
+		assert.True(t, item.Draft)
+	})
+
+	t.Run("異常系: 公開済みのアイテム", func(t *testing.T) {
+		item, err := NewItem("ロレックス デイトナ", "時計", "ROLEX", JPY(1500000), MustParseDate("2023-01-15"), time.Now())
+		require.NoError(t, err)
+		assert.EqualError(t, item.Publish(time.Now()), "item is not a draft")
+	})
+}
+
 func TestIsValidCategory(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -302,32 +395,6 @@ func TestIsValidCategory(t *testing.T) {
 	}
 }
 
-func TestIsValidDateFormat(t *testing.T) {
-	tests := []struct {
-		name    string
-		dateStr string
-		want    bool
-	}{
-		{"有効な日付: 2023-01-15", "2023-01-15", true},
-		{"有効な日付: 2023-12-31", "2023-12-31", true},
-		{"有効な日付: 2024-02-29", "2024-02-29", true}, // うるう年
-		{"無効な日付: 2023/01/15", "2023/01/15", false},
-		{"無効な日付: 2023-1-15", "2023-1-15", false},
-		{"無効な日付: 15-01-2023", "15-01-2023", false},
-		{"無効な日付: 2023-13-01", "2023-13-01", false},
-		{"無効な日付: 2023-02-30", "2023-02-30", false},
-		{"無効な日付: 空文字", "", false},
-		{"無効な日付: 無効な形式", "invalid", false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := isValidDateFormat(tt.dateStr)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func TestGetValidCategories(t *testing.T) {
 	categories := GetValidCategories()
 	expected := []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
@@ -335,3 +402,88 @@ func TestGetValidCategories(t *testing.T) {
 	assert.Equal(t, expected, categories)
 	assert.Len(t, categories, 5)
 }
+
+func TestItem_ExchangeRate(t *testing.T) {
+	// 円建ては購入価格がそのまま円換算額になる
+	item, err := NewItem("ロレックス デイトナ", "時計", "ROLEX", JPY(1500000), MustParseDate("2023-01-15"), time.Now())
+	require.NoError(t, err)
+	assert.False(t, item.NeedsExchangeRate())
+	assert.Equal(t, JPY(1500000), *item.PurchasePriceJPY)
+
+	// 外貨建てはレートを設定するまで円換算額がない
+	item, err = NewItem("オメガ スピードマスター", "時計", "OMEGA", Money{Amount: 750000, Currency: "USD"}, MustParseDate("2023-01-15"), time.Now())
+	require.NoError(t, err)
+	assert.True(t, item.NeedsExchangeRate())
+	assert.Nil(t, item.PurchasePriceJPY)
+
+	require.NoError(t, item.SetExchangeRate("130.000000"))
+	assert.Equal(t, "130", item.ExchangeRate)
+	assert.Equal(t, JPY(975000), *item.PurchasePriceJPY)
+
+	// 同じ通貨の価格変更は固定したレートで換算し直す
+	require.NoError(t, item.Update(item.Name, item.Category, item.Brand, Money{Amount: 800000, Currency: "USD"}, item.PurchaseDate, time.Now()))
+	assert.Equal(t, "130", item.ExchangeRate)
+	assert.Equal(t, JPY(1040000), *item.PurchasePriceJPY)
+
+	// 通貨を変更するとレートは無効になる
+	require.NoError(t, item.Update(item.Name, item.Category, item.Brand, Money{Amount: 700000, Currency: "EUR"}, item.PurchaseDate, time.Now()))
+	assert.True(t, item.NeedsExchangeRate())
+	assert.Nil(t, item.PurchasePriceJPY)
+
+	assert.Error(t, item.SetExchangeRate("invalid"))
+}
+
+func TestItem_PurchaseCountry(t *testing.T) {
+	// 前後の空白を除去し、大文字にする
+	item, err := NewItem("エルメス バーキン", "バッグ", "HERMÈS", JPY(2000000), MustParseDate("2023-02-20"), time.Now(), WithPurchaseCountry(" fr "))
+	require.NoError(t, err)
+	assert.Equal(t, "FR", item.PurchaseCountry)
+
+	_, err = NewItem("エルメス バーキン", "バッグ", "HERMÈS", JPY(2000000), MustParseDate("2023-02-20"), time.Now(), WithPurchaseCountry("France"))
+	assert.ErrorContains(t, err, "purchase_country")
+
+	// 下書きでも形式は確認する
+	_, err = NewDraftItem("エルメス バーキン", "", "", Money{}, Date{}, time.Now(), WithPurchaseCountry("F1"))
+	assert.ErrorContains(t, err, "purchase_country")
+
+	// 空文字で未設定に戻せる
+	item.SetPurchaseCountry("")
+	assert.NoError(t, item.Validate())
+	assert.Empty(t, item.PurchaseCountry)
+}
+
+func TestItem_Tax(t *testing.T) {
+	tax := int64(10000)
+	item, err := NewItem("ロレックス デイトナ", "時計", "ROLEX", Money{Amount: 110000, Currency: "USD"}, MustParseDate("2023-06-15"), time.Now(), WithTax(&tax, true))
+	require.NoError(t, err)
+	assert.Equal(t, &Money{Amount: 10000, Currency: "USD"}, item.TaxAmount)
+	assert.Nil(t, item.TaxAmountJPY)
+
+	// 税額も購入価格と同じ為替レートで円換算する
+	require.NoError(t, item.SetExchangeRate("150"))
+	assert.Equal(t, JPY(15000), *item.TaxAmountJPY)
+
+	// 円建てに変えると税額の通貨も円になる
+	require.NoError(t, item.Update(item.Name, item.Category, item.Brand, JPY(1650000), item.PurchaseDate, time.Now()))
+	assert.Equal(t, JPY(10000), *item.TaxAmount)
+	assert.Equal(t, JPY(10000), *item.TaxAmountJPY)
+
+	// 税率から求める
+	require.NoError(t, item.SetTaxRate("10", true, RoundDown))
+	assert.Equal(t, JPY(150000), *item.TaxAmount)
+	assert.Error(t, item.SetTaxRate("abc", true, RoundDown))
+
+	// 税込の場合、税額は購入価格以下
+	tooLarge := int64(2000000)
+	item.SetTax(&tooLarge, true)
+	assert.ErrorContains(t, item.Validate(), "tax_amount")
+
+	negative := int64(-1)
+	_, err = NewDraftItem("ロレックス デイトナ", "", "", Money{}, Date{}, time.Now(), WithTax(&negative, false))
+	assert.ErrorContains(t, err, "tax_amount")
+
+	item.SetTax(nil, false)
+	assert.NoError(t, item.Validate())
+	assert.Nil(t, item.TaxAmount)
+	assert.Nil(t, item.TaxAmountJPY)
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// バックグラウンドジョブの状況
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"  // 実行待ち（再試行待ちを含む）
+	JobRunning   JobStatus = "running" // ワーカーが実行中
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed" // 再試行の上限に達した、または再試行しても成功しないエラー
+)
+
+// HTTPリクエストとは別に、ワーカーが実行する時間のかかる処理
+type Job struct {
+	ID      int64           `json:"id"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+	Status  JobStatus       `json:"status"`
+
+	// 実行した回数と上限、最後の実行のエラー
+	Attempts    int    `json:"attempts"`
+	MaxAttempts int    `json:"max_attempts"`
+	LastError   string `json:"last_error,omitempty"`
+
+	// 成功した場合の結果（ジョブの種類ごとの形式）
+	Result json.RawMessage `json:"result,omitempty"`
+
+	Owner string `json:"owner"` // 登録した操作者（X-User-ID）
+
+	// 次に実行する日時（実行待ちの場合）と、実行中のワーカーが応答しなくなったとみなす日時（実行中の場合）
+	RunAt       time.Time  `json:"run_at"`
+	LockedUntil *time.Time `json:"-"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+func NewJob(jobType string, payload json.RawMessage, owner string, maxAttempts int, now time.Time) *Job {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &Job{
+		Type:        jobType,
+		Payload:     payload,
+		Status:      JobQueued,
+		MaxAttempts: maxAttempts,
+		Owner:       owner,
+		RunAt:       now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// 成功・失敗のいずれかで終了したか
+func (j *Job) IsFinished() bool {
+	return j.Status == JobSucceeded || j.Status == JobFailed
+}
+
+func (j *Job) Succeed(result json.RawMessage, now time.Time) {
+	j.Status = JobSucceeded
+	j.Result = result
+	j.LastError = ""
+	j.LockedUntil = nil
+	j.UpdatedAt = now
+	j.FinishedAt = &now
+}
+
+// 失敗を記録する。retryAt が nil の場合、または上限に達した場合は失敗として終了する
+func (j *Job) Fail(message string, retryAt *time.Time, now time.Time) {
+	j.LastError = message
+	j.LockedUntil = nil
+	j.UpdatedAt = now
+	if retryAt == nil || j.Attempts >= j.MaxAttempts {
+		j.Status = JobFailed
+		j.FinishedAt = &now
+		return
+	}
+	j.Status = JobQueued
+	j.RunAt = *retryAt
+}
@@ -0,0 +1,84 @@
+package entity
+
+import "time"
+
+// 定期実行の1回の状況
+type JobRunStatus string
+
+const (
+	JobRunRunning   JobRunStatus = "running"
+	JobRunSucceeded JobRunStatus = "succeeded" // 最後まで実行した（個別のアイテムの失敗は Failed に数える）
+	JobRunFailed    JobRunStatus = "failed"    // 途中で中断した
+)
+
+// 記録する失敗したアイテムの最大件数
+const MaxJobRunErrors = 20
+
+// 定期実行の1回分の集計
+type JobRun struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`     // 定期実行の処理（例: valuation.refresh）
+	Schedule string `json:"schedule"` // 実行予定（cron 形式）
+
+	// 実行予定の日時。同じ処理・同じ予定の実行は1回のみ記録する（複数のサーバーで重複して実行しない）
+	ScheduledAt time.Time    `json:"scheduled_at"`
+	Status      JobRunStatus `json:"status"`
+
+	// 対象のアイテム数と、更新・対象外・失敗の件数
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Skipped   int `json:"skipped"`
+	Failed    int `json:"failed"`
+
+	// 失敗したアイテムと理由（先頭の MaxJobRunErrors 件）
+	Errors []JobRunError `json:"errors"`
+	// 中断した理由
+	Error string `json:"error,omitempty"`
+
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+type JobRunError struct {
+	ItemID int64  `json:"item_id"`
+	Error  string `json:"error"`
+}
+
+func NewJobRun(name, schedule string, scheduledAt, now time.Time) *JobRun {
+	return &JobRun{
+		Name:        name,
+		Schedule:    schedule,
+		ScheduledAt: scheduledAt,
+		Status:      JobRunRunning,
+		Errors:      []JobRunError{},
+		StartedAt:   now,
+	}
+}
+
+func (r *JobRun) RecordSuccess() {
+	r.Total++
+	r.Succeeded++
+}
+
+func (r *JobRun) RecordSkip() {
+	r.Total++
+	r.Skipped++
+}
+
+func (r *JobRun) RecordFailure(itemID int64, message string) {
+	r.Total++
+	r.Failed++
+	if len(r.Errors) < MaxJobRunErrors {
+		r.Errors = append(r.Errors, JobRunError{ItemID: itemID, Error: message})
+	}
+}
+
+// 終了を記録する。message が空でない場合は中断したものとする
+func (r *JobRun) Finish(message string, now time.Time) {
+	r.Status = JobRunSucceeded
+	if message != "" {
+		r.Status = JobRunFailed
+		r.Error = message
+	}
+	r.FinishedAt = &now
+}
@@ -0,0 +1,85 @@
+package entity
+
+import "sort"
+
+// ラベル用紙のレイアウト（寸法はmm）
+// 左上のラベルの位置を余白で、2枚目以降の位置をラベルの寸法と間隔で求める
+type LabelLayout struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	PageWidth   float64 `json:"page_width"`
+	PageHeight  float64 `json:"page_height"`
+	Columns     int     `json:"columns"`
+	Rows        int     `json:"rows"`
+	MarginTop   float64 `json:"margin_top"`
+	MarginLeft  float64 `json:"margin_left"`
+	LabelWidth  float64 `json:"label_width"`
+	LabelHeight float64 `json:"label_height"`
+	GapX        float64 `json:"gap_x"` // 横に隣り合うラベルの間隔
+	GapY        float64 `json:"gap_y"` // 縦に隣り合うラベルの間隔
+}
+
+// レイアウトを指定しない場合の既定
+const DefaultLabelLayout = "a4-21"
+
+// 市販のラベル用紙の主なレイアウト
+var labelLayouts = map[string]LabelLayout{
+	"a4-12": {
+		Name: "a4-12", Description: "A4 12面（2列×6段、86.4×42.3mm）",
+		PageWidth: 210, PageHeight: 297, Columns: 2, Rows: 6,
+		MarginTop: 21.5, MarginLeft: 18.6, LabelWidth: 86.4, LabelHeight: 42.3, GapX: 0, GapY: 0,
+	},
+	"a4-21": {
+		Name: "a4-21", Description: "A4 21面（3列×7段、63.5×38.1mm）",
+		PageWidth: 210, PageHeight: 297, Columns: 3, Rows: 7,
+		MarginTop: 15.15, MarginLeft: 7.2, LabelWidth: 63.5, LabelHeight: 38.1, GapX: 2.5, GapY: 0,
+	},
+	"a4-24": {
+		Name: "a4-24", Description: "A4 24面（3列×8段、70×33.9mm）",
+		PageWidth: 210, PageHeight: 297, Columns: 3, Rows: 8,
+		MarginTop: 12.9, MarginLeft: 0, LabelWidth: 70, LabelHeight: 33.9, GapX: 0, GapY: 0,
+	},
+	"letter-30": {
+		Name: "letter-30", Description: "Letter 30面（3列×10段、66.7×25.4mm）",
+		PageWidth: 215.9, PageHeight: 279.4, Columns: 3, Rows: 10,
+		MarginTop: 12.7, MarginLeft: 4.8, LabelWidth: 66.7, LabelHeight: 25.4, GapX: 3.2, GapY: 0,
+	},
+}
+
+// 名前からラベル用紙のレイアウトを取得する
+func FindLabelLayout(name string) (LabelLayout, bool) {
+	layout, ok := labelLayouts[name]
+	return layout, ok
+}
+
+// 定義済みのラベル用紙のレイアウト（名前順）
+func LabelLayouts() []LabelLayout {
+	layouts := make([]LabelLayout, 0, len(labelLayouts))
+	for _, layout := range labelLayouts {
+		layouts = append(layouts, layout)
+	}
+	sort.Slice(layouts, func(i, j int) bool { return layouts[i].Name < layouts[j].Name })
+	return layouts
+}
+
+// 1枚あたりのラベル数
+func (l LabelLayout) PerSheet() int {
+	return l.Columns * l.Rows
+}
+
+// シートの先頭から数えて index 番目（0始まり、左から右・上から下の順）のラベルの左上の位置
+func (l LabelLayout) Position(index int) (x, y float64) {
+	index %= l.PerSheet()
+	col, row := index%l.Columns, index/l.Columns
+	x = l.MarginLeft + float64(col)*(l.LabelWidth+l.GapX)
+	y = l.MarginTop + float64(row)*(l.LabelHeight+l.GapY)
+	return x, y
+}
+
+// ラベル1枚に印字する内容
+type ItemLabel struct {
+	ItemID int64
+	Name   string
+	Brand  string
+	QRCode string // QRコードにする文字列（アイテムのURLなど）
+}
@@ -0,0 +1,431 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// 基準通貨
+const DefaultCurrency = "JPY"
+
+// 対応通貨と補助単位の桁数（ISO 4217）
+var currencyMinorUnits = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"HKD": 2,
+}
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrAmountOverflow   = errors.New("amount out of range")
+)
+
+// 最小単位未満の端数の丸め方（金額の端数処理はすべてこの規則で行う）
+type RoundingMode string
+
+const (
+	RoundHalfUp RoundingMode = "half_up" // 四捨五入（0.5 は絶対値の大きい方へ）
+	RoundDown   RoundingMode = "down"    // 切り捨て（0 の方へ）
+	RoundUp     RoundingMode = "up"      // 切り上げ（絶対値の大きい方へ）
+)
+
+func (m RoundingMode) IsValid() bool {
+	switch m {
+	case RoundHalfUp, RoundDown, RoundUp:
+		return true
+	}
+	return false
+}
+
+// 金額を表す値オブジェクト
+// 金額は通貨の最小単位（JPYは円、USDはセント）の整数で保持し、浮動小数点による丸め誤差を防ぐ
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func NewMoney(amount int64, currency string) (Money, error) {
+	m := Money{Amount: amount, Currency: normalizeCurrency(currency)}
+	if !IsSupportedCurrency(m.Currency) {
+		return Money{}, fmt.Errorf("unsupported currency: %s", currency)
+	}
+	return m, nil
+}
+
+// 補助単位を考慮した小数点表記（例: "12.34" USD → 1234）を、誤差なく最小単位の整数にする
+// 通貨の補助単位より細かい端数や、最小単位で int64 に収まらない金額はエラーにする
+func ParseMoney(value, currency string) (Money, error) {
+	currency = normalizeCurrency(currency)
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	if !IsSupportedCurrency(currency) {
+		return Money{}, fmt.Errorf("unsupported currency: %s", currency)
+	}
+
+	value = strings.TrimSpace(value)
+	r, ok := new(big.Rat).SetString(value)
+	if !ok || strings.ContainsAny(value, "eE/") {
+		return Money{}, fmt.Errorf("invalid amount: %q", value)
+	}
+
+	m := Money{Currency: currency}
+	r.Mul(r, new(big.Rat).SetInt(minorUnitScale(m.MinorUnits())))
+	if !r.IsInt() {
+		return Money{}, fmt.Errorf("invalid amount: %q has more than %d decimal places for %s", value, m.MinorUnits(), currency)
+	}
+	if !r.Num().IsInt64() {
+		return Money{}, fmt.Errorf("%w: %s %s", ErrAmountOverflow, value, currency)
+	}
+	m.Amount = r.Num().Int64()
+	return m, nil
+}
+
+// 円建ての金額
+func JPY(amount int64) Money {
+	return Money{Amount: amount, Currency: DefaultCurrency}
+}
+
+// 対応通貨かどうか
+func IsSupportedCurrency(currency string) bool {
+	_, ok := currencyMinorUnits[currency]
+	return ok
+}
+
+// 対応通貨の一覧（アルファベット順）
+func SupportedCurrencies() []string {
+	currencies := make([]string, 0, len(currencyMinorUnits))
+	for currency := range currencyMinorUnits {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+// 補助単位の桁数
+func (m Money) MinorUnits() int {
+	return currencyMinorUnits[m.Currency]
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// 同一通貨の金額を加算
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrAmountOverflow, m, other)
+	}
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+// 同一通貨の金額を減算
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	diff := m.Amount - other.Amount
+	if (other.Amount > 0 && diff > m.Amount) || (other.Amount < 0 && diff < m.Amount) {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrAmountOverflow, m, other)
+	}
+	return Money{Amount: diff, Currency: m.Currency}, nil
+}
+
+// 金額を重みの比で按分する（重みは0以上で、合計は正）
+// 最小単位未満の端数は、切り捨てた端数の大きい順（同じ場合は先頭から）に1単位ずつ配分するため、合計は元の金額と一致する
+func (m Money) Allocate(weights []int64) []Money {
+	total := new(big.Int)
+	for _, w := range weights {
+		total.Add(total, big.NewInt(w))
+	}
+
+	shares := make([]Money, len(weights))
+	remainders := make([]*big.Int, len(weights))
+	allocated := int64(0)
+	for i, w := range weights {
+		q, rem := new(big.Int).QuoRem(new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(w)), total, new(big.Int))
+		shares[i] = Money{Amount: q.Int64(), Currency: m.Currency}
+		remainders[i] = rem.Abs(rem)
+		allocated += q.Int64()
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+
+	step := int64(1)
+	if m.Amount < 0 {
+		step = -1
+	}
+	for i := 0; allocated != m.Amount; i++ {
+		shares[order[i]].Amount += step
+		allocated += step
+	}
+	return shares
+}
+
+// 為替レート（外貨1単位あたりの円、例: "148.25"）で円に換算する
+// 円未満は四捨五入する
+func (m Money) ConvertToJPY(rate string) (Money, error) {
+	r, err := parseExchangeRate(rate)
+	if err != nil {
+		return Money{}, err
+	}
+
+	converted := new(big.Rat).SetInt64(m.Amount)
+	converted.Mul(converted, r)
+	converted.Quo(converted, new(big.Rat).SetInt(minorUnitScale(m.MinorUnits())))
+
+	amount, err := roundAmount(converted, RoundHalfUp)
+	if err != nil {
+		return Money{}, err
+	}
+	return JPY(amount), nil
+}
+
+// 割合（%）を掛けた金額（例: 110% で1割増し）。最小単位未満は mode で丸める
+func (m Money) MulPercent(percent *big.Rat, mode RoundingMode) (Money, error) {
+	r := new(big.Rat).SetInt64(m.Amount)
+	r.Mul(r, percent)
+	r.Quo(r, big.NewRat(100, 1))
+
+	amount, err := roundAmount(r, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// 税率（%、例: "10"）での消費税額。included の場合は m を税込の金額として、含まれる税額を求める
+// 最小単位未満は mode で丸める
+func (m Money) Tax(rate string, included bool, mode RoundingMode) (Money, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(rate))
+	if !ok || r.Sign() < 0 || r.Cmp(big.NewRat(100, 1)) > 0 {
+		return Money{}, fmt.Errorf("invalid tax rate: %q", rate)
+	}
+	if !included {
+		return m.MulPercent(r, mode)
+	}
+
+	// 税込の金額 × 税率 / (100 + 税率)
+	tax := new(big.Rat).SetInt64(m.Amount)
+	tax.Mul(tax, r)
+	tax.Quo(tax, new(big.Rat).Add(big.NewRat(100, 1), r))
+
+	amount, err := roundAmount(tax, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// 為替レートの文字列を検証し、正規化した表記を返す（例: "148.250000" → "148.25"）
+func NormalizeExchangeRate(rate string) (string, error) {
+	r, err := parseExchangeRate(rate)
+	if err != nil {
+		return "", err
+	}
+	s := strings.TrimRight(r.FloatString(10), "0")
+	return strings.TrimSuffix(s, "."), nil
+}
+
+func parseExchangeRate(rate string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(rate))
+	if !ok || r.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid exchange rate: %q", rate)
+	}
+	return r, nil
+}
+
+// 有理数を最小単位の金額に丸める（int64 に収まらない場合はエラー）
+func roundAmount(r *big.Rat, mode RoundingMode) (int64, error) {
+	q := roundRatInt(r, mode)
+	if !q.IsInt64() {
+		return 0, fmt.Errorf("%w: %s", ErrAmountOverflow, q.String())
+	}
+	return q.Int64(), nil
+}
+
+// 有理数を整数に丸める（符号によらず絶対値で丸めるため、正負で対称になる）
+func roundRat(r *big.Rat, mode RoundingMode) int64 {
+	return roundRatInt(r, mode).Int64()
+}
+
+func roundRatInt(r *big.Rat, mode RoundingMode) *big.Int {
+	num := new(big.Int).Abs(r.Num())
+	q, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if rem.Sign() != 0 {
+		switch mode {
+		case RoundUp:
+			q.Add(q, big.NewInt(1))
+		case RoundDown:
+		default:
+			if rem.Mul(rem, big.NewInt(2)).Cmp(r.Denom()) >= 0 {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+	}
+	if r.Sign() < 0 {
+		q.Neg(q)
+	}
+	return q
+}
+
+// 補助単位の桁数に応じた 10 の累乗
+func minorUnitScale(units int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(units)), nil)
+}
+
+// 整数の割り算を丸める（金額を按分・償却する場合）
+func roundDiv(n, d int64, mode RoundingMode) int64 {
+	return roundRat(big.NewRat(n, d), mode)
+}
+
+// 小数点表記の金額文字列（例: "1500000 JPY", "12.34 USD"）
+func (m Money) String() string {
+	return m.DecimalString() + " " + m.Currency
+}
+
+// 補助単位を考慮した小数点表記の金額（例: 1234 USD → "12.34"）
+func (m Money) DecimalString() string {
+	units := m.MinorUnits()
+	if units == 0 {
+		return fmt.Sprintf("%d", m.Amount)
+	}
+
+	// 最小値の符号を反転すると int64 に収まらないため big.Int で計算する
+	sign := ""
+	amount := big.NewInt(m.Amount)
+	if amount.Sign() < 0 {
+		sign = "-"
+		amount.Neg(amount)
+	}
+
+	whole, fraction := new(big.Int).QuoRem(amount, minorUnitScale(units), new(big.Int))
+	return fmt.Sprintf("%s%s.%0*s", sign, whole.String(), units, fraction.String())
+}
+
+// 最小単位の整数の amount に加えて、補助単位を考慮した小数点表記の value を返す
+// （JavaScript の数値では正確に扱えない大きな金額も、value の文字列で受け取れるようにする）
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   int64  `json:"amount"`
+		Currency string `json:"currency"`
+		Value    string `json:"value"`
+	}{m.Amount, m.Currency, m.DecimalString()})
+}
+
+const moneyJSONError = "money must be an integer amount or an object with amount (or value) and currency"
+
+// 金額単体の数値（従来形式）と、amount/currencyのオブジェクトの両方を受け付ける
+// オブジェクトの amount は最小単位の整数（数値または文字列）、value は補助単位を考慮した小数点表記の文字列（例: "12.34"）
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] != '{' {
+		amount, err := parseJSONAmount(data, false)
+		if err != nil {
+			return err
+		}
+		*m = JPY(amount)
+		return nil
+	}
+
+	var raw struct {
+		Amount   json.RawMessage `json:"amount"`
+		Currency string          `json:"currency"`
+		Value    *string         `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return errors.New(moneyJSONError)
+	}
+
+	currency := normalizeCurrency(raw.Currency)
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	var amount *int64
+	if len(raw.Amount) > 0 && string(raw.Amount) != "null" {
+		parsed, err := parseJSONAmount(raw.Amount, true)
+		if err != nil {
+			return err
+		}
+		amount = &parsed
+	}
+
+	if raw.Value == nil {
+		*m = Money{Currency: currency}
+		if amount != nil {
+			m.Amount = *amount
+		}
+		return nil
+	}
+
+	// 未対応の通貨は補助単位の桁数が分からないため、value では受け付けない
+	parsed, err := ParseMoney(*raw.Value, currency)
+	if err != nil {
+		return err
+	}
+	if amount != nil && *amount != parsed.Amount {
+		return fmt.Errorf("money amount %d does not match value %q", *amount, *raw.Value)
+	}
+	*m = parsed
+	return nil
+}
+
+// 最小単位の整数。allowString の場合は、大きな金額を正確に送るための文字列（例: "9007199254740993"）も受け付ける
+func parseJSONAmount(data []byte, allowString bool) (int64, error) {
+	if allowString && len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return 0, errors.New(moneyJSONError)
+		}
+		data = []byte(strings.TrimSpace(s))
+	}
+
+	var amount int64
+	if err := json.Unmarshal(data, &amount); err != nil {
+		// 整数だが int64 に収まらない
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Value == "number" && !bytes.ContainsAny(data, ".eE") {
+			return 0, fmt.Errorf("%w: %s", ErrAmountOverflow, data)
+		}
+		return 0, errors.New(moneyJSONError)
+	}
+	return amount, nil
+}
+
+func normalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+// 通貨コードを正規化し、未指定の場合は基準通貨とする
+func normalizeMoney(m Money) Money {
+	m.Currency = normalizeCurrency(m.Currency)
+	if m.Currency == "" {
+		m.Currency = DefaultCurrency
+	}
+	return m
+}
@@ -0,0 +1,264 @@
+package entity
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		currency string
+		want     Money
+		wantErr  bool
+	}{
+		{"正常系: 円", 1500000, "JPY", Money{Amount: 1500000, Currency: "JPY"}, false},
+		{"正常系: 小文字の通貨コード", 1234, "usd", Money{Amount: 1234, Currency: "USD"}, false},
+		{"異常系: 未対応の通貨", 100, "XYZ", Money{}, true},
+		{"異常系: 通貨が空", 100, "", Money{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMoney(tt.amount, tt.currency)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoney_Add(t *testing.T) {
+	sum, err := JPY(1000).Add(JPY(500))
+	require.NoError(t, err)
+	assert.Equal(t, JPY(1500), sum)
+
+	_, err = JPY(1000).Add(Money{Amount: 500, Currency: "USD"})
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = JPY(math.MaxInt64).Add(JPY(1))
+	assert.ErrorIs(t, err, ErrAmountOverflow)
+	_, err = JPY(math.MinInt64).Add(JPY(-1))
+	assert.ErrorIs(t, err, ErrAmountOverflow)
+}
+
+func TestMoney_Sub(t *testing.T) {
+	diff, err := JPY(1000).Sub(JPY(1500))
+	require.NoError(t, err)
+	assert.Equal(t, JPY(-500), diff)
+
+	_, err = JPY(1000).Sub(Money{Amount: 500, Currency: "USD"})
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = JPY(math.MinInt64).Sub(JPY(1))
+	assert.ErrorIs(t, err, ErrAmountOverflow)
+	_, err = JPY(math.MaxInt64).Sub(JPY(-1))
+	assert.ErrorIs(t, err, ErrAmountOverflow)
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	// 端数は先頭から1単位ずつ
+	assert.Equal(t, []Money{JPY(334), JPY(333), JPY(333)}, JPY(1000).Allocate([]int64{1, 1, 1}))
+	// 切り捨てた端数の大きい構成品に配分する
+	assert.Equal(t, []Money{JPY(33), JPY(67)}, JPY(100).Allocate([]int64{1, 2}))
+	assert.Equal(t, []Money{{Amount: 0, Currency: "USD"}, {Amount: 999, Currency: "USD"}}, Money{Amount: 999, Currency: "USD"}.Allocate([]int64{0, 5}))
+	// 重みの積が int64 を超えても按分できる
+	assert.Equal(t, []Money{JPY(math.MaxInt64/2 + 1), JPY(math.MaxInt64 / 2)}, JPY(math.MaxInt64).Allocate([]int64{math.MaxInt64, math.MaxInt64}))
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		want  string
+	}{
+		{"円は小数点なし", JPY(1500000), "1500000 JPY"},
+		{"ドルはセントを小数点以下に表示", Money{Amount: 1234, Currency: "USD"}, "12.34 USD"},
+		{"1セント未満の桁を0埋め", Money{Amount: 5, Currency: "EUR"}, "0.05 EUR"},
+		{"負の金額", Money{Amount: -1234, Currency: "USD"}, "-12.34 USD"},
+		{"int64 の最小値", Money{Amount: math.MinInt64, Currency: "USD"}, "-92233720368547758.08 USD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.money.String())
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{"正常系: 数値のみ（円として扱う）", `1500000`, JPY(1500000), false},
+		{"正常系: オブジェクト", `{"amount": 1234, "currency": "usd"}`, Money{Amount: 1234, Currency: "USD"}, false},
+		{"正常系: 通貨省略時は円", `{"amount": 1000}`, JPY(1000), false},
+		{"正常系: 小数点表記の value", `{"value": "12.34", "currency": "USD"}`, Money{Amount: 1234, Currency: "USD"}, false},
+		{"正常系: amount と value が一致", `{"amount": 1234, "currency": "USD", "value": "12.34"}`, Money{Amount: 1234, Currency: "USD"}, false},
+		{"正常系: 大きな金額は文字列の amount", `{"amount": "9007199254740993", "currency": "JPY"}`, JPY(9007199254740993), false},
+		{"異常系: 小数", `12.5`, Money{}, true},
+		{"異常系: 文字列", `"1000"`, Money{}, true},
+		{"異常系: 小数の amount", `{"amount": 12.34, "currency": "USD"}`, Money{}, true},
+		{"異常系: 補助単位より細かい value", `{"value": "12.345", "currency": "USD"}`, Money{}, true},
+		{"異常系: amount と value が不一致", `{"amount": 1200, "currency": "USD", "value": "12.34"}`, Money{}, true},
+		{"異常系: int64 に収まらない金額", `{"amount": 9223372036854775808}`, Money{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	b, err := json.Marshal(Money{Amount: 1234, Currency: "USD"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount": 1234, "currency": "USD", "value": "12.34"}`, string(b))
+
+	// 出力した JSON を読み込むと元の金額に戻る
+	original := Money{Amount: math.MinInt64, Currency: "EUR"}
+	b, err = json.Marshal(original)
+	require.NoError(t, err)
+	var decoded Money
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, original, decoded)
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		currency string
+		want     Money
+		wantErr  bool
+	}{
+		{"正常系: ドル", "12.34", "usd", Money{Amount: 1234, Currency: "USD"}, false},
+		{"正常系: 補助単位の桁数未満の小数", "0.5", "EUR", Money{Amount: 50, Currency: "EUR"}, false},
+		{"正常系: 整数", "1500000", "JPY", JPY(1500000), false},
+		{"正常系: 通貨省略時は円", "1000", "", JPY(1000), false},
+		{"正常系: 負の金額", "-0.01", "USD", Money{Amount: -1, Currency: "USD"}, false},
+		{"正常系: int64 の上限", "92233720368547758.07", "USD", Money{Amount: math.MaxInt64, Currency: "USD"}, false},
+		{"異常系: 補助単位より細かい端数", "12.345", "USD", Money{}, true},
+		{"異常系: 円の小数", "1000.5", "JPY", Money{}, true},
+		{"異常系: 指数表記", "1e3", "JPY", Money{}, true},
+		{"異常系: 数値でない", "abc", "JPY", Money{}, true},
+		{"異常系: 未対応の通貨", "1", "XYZ", Money{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.value, tt.currency)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := ParseMoney("92233720368547758.08", "USD")
+	assert.ErrorIs(t, err, ErrAmountOverflow)
+}
+
+func TestMoney_ConvertToJPY(t *testing.T) {
+	tests := []struct {
+		name    string
+		money   Money
+		rate    string
+		want    Money
+		wantErr bool
+	}{
+		{"ドル（セント単位）", Money{Amount: 750000, Currency: "USD"}, "148.25", JPY(1111875), false},
+		{"円未満は四捨五入", Money{Amount: 1, Currency: "USD"}, "148.5", JPY(1), false},
+		{"0.5円は切り上げ", Money{Amount: 1, Currency: "EUR"}, "150", JPY(2), false},
+		{"補助単位のない通貨", Money{Amount: 100000, Currency: "KRW"}, "0.1105", JPY(11050), false},
+		{"異常系: 数値でないレート", Money{Amount: 100, Currency: "USD"}, "abc", Money{}, true},
+		{"異常系: 0以下のレート", Money{Amount: 100, Currency: "USD"}, "0", Money{}, true},
+		{"異常系: 換算後の金額が範囲外", Money{Amount: math.MaxInt64, Currency: "KRW"}, "2", Money{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.money.ConvertToJPY(tt.rate)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoney_Tax(t *testing.T) {
+	tests := []struct {
+		name     string
+		money    Money
+		rate     string
+		included bool
+		mode     RoundingMode
+		want     Money
+		wantErr  bool
+	}{
+		{"税抜の金額の税額", JPY(1234), "10", false, RoundDown, JPY(123), false},
+		{"税込の金額に含まれる税額（1円未満切り捨て）", JPY(1100), "10", true, RoundDown, JPY(100), false},
+		{"軽減税率・切り上げ", JPY(1001), "8", false, RoundUp, JPY(81), false},
+		{"四捨五入", JPY(1005), "10", false, RoundHalfUp, JPY(101), false},
+		{"ドルはセント単位で丸める", Money{Amount: 1999, Currency: "USD"}, "8.875", false, RoundHalfUp, Money{Amount: 177, Currency: "USD"}, false},
+		{"異常系: 100%を超える税率", JPY(1000), "101", false, RoundDown, Money{}, true},
+		{"異常系: 数値でない税率", JPY(1000), "ten", false, RoundDown, Money{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.money.Tax(tt.rate, tt.included, tt.mode)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRoundDiv(t *testing.T) {
+	// 正負で対称に丸める
+	assert.Equal(t, int64(3), roundDiv(5, 2, RoundHalfUp))
+	assert.Equal(t, int64(-3), roundDiv(-5, 2, RoundHalfUp))
+	assert.Equal(t, int64(1), roundDiv(5, 3, RoundDown))
+	assert.Equal(t, int64(-1), roundDiv(-5, 3, RoundDown))
+	assert.Equal(t, int64(2), roundDiv(5, 3, RoundUp))
+	assert.Equal(t, int64(2), roundDiv(6, 3, RoundUp))
+}
+
+func TestNormalizeExchangeRate(t *testing.T) {
+	rate, err := NormalizeExchangeRate("148.250000")
+	require.NoError(t, err)
+	assert.Equal(t, "148.25", rate)
+
+	rate, err = NormalizeExchangeRate("150.000000")
+	require.NoError(t, err)
+	assert.Equal(t, "150", rate)
+
+	_, err = NormalizeExchangeRate("-1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,11 @@
+package entity
+
+// 購入月・通貨ごとの購入の集計値
+// 金額は通貨の最小単位の整数で保持する
+type MonthlyPurchaseTotal struct {
+	Month    string // YYYY-MM 形式
+	Currency string
+	Count    int
+	Total    int64
+	TotalJPY int64 // 円換算額の合計（円換算額のないアイテムは含まない）
+}
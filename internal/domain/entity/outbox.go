@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// 変更と同じトランザクションで記録した、未送信のアイテムの変更イベント（トランザクショナル・アウトボックス）
+// 受け取り手（Webhook・検索インデックス）にすべて渡せた時点で送信済みにする
+type OutboxEvent struct {
+	ID     int64       `json:"id"`
+	ItemID int64       `json:"item_id"`
+	Action AuditAction `json:"action"`
+	Actor  string      `json:"actor"`
+
+	// 送信に失敗した回数と最後のエラー、次に送信する時刻
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"last_error,omitempty"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func NewOutboxEvent(itemID int64, action AuditAction, actor string, now time.Time) *OutboxEvent {
+	return &OutboxEvent{
+		ItemID:        itemID,
+		Action:        action,
+		Actor:         actor,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
@@ -0,0 +1,21 @@
+package entity
+
+// 実現損益の集計期間の単位
+type ProfitLossPeriod string
+
+const (
+	ProfitLossMonthly ProfitLossPeriod = "month"
+	ProfitLossYearly  ProfitLossPeriod = "year"
+)
+
+// 売却日の期間・カテゴリーごとの実現損益の集計値
+// 円換算額は円換算できた売却のみの合計
+type ProfitLossTotal struct {
+	Period          string // 年月（YYYY-MM）または年（YYYY）
+	Category        string
+	Count           int   // 売却の件数
+	Unconverted     int   // 実現損益を円換算できず、円換算額の合計に含めない件数
+	ProceedsJPY     int64 // 手取り額の円換算額の合計
+	CostBasisJPY    int64 // 取得費の円換算額の合計
+	RealizedGainJPY int64 // 実現損益の円換算額の合計
+}
@@ -0,0 +1,17 @@
+package entity
+
+// 利用できるアイテム数と写真の合計サイズの上限（0 の場合は無制限）
+type Quota struct {
+	MaxItems        int   `json:"max_items"`
+	MaxStorageBytes int64 `json:"max_storage_bytes"`
+}
+
+// 現在の件数に追加した場合にアイテム数の上限を超えるか
+func (q Quota) ExceedsItems(current, adding int) bool {
+	return q.MaxItems > 0 && current+adding > q.MaxItems
+}
+
+// 現在のサイズに追加した場合に写真の合計サイズの上限を超えるか
+func (q Quota) ExceedsStorage(current, adding int64) bool {
+	return q.MaxStorageBytes > 0 && current+adding > q.MaxStorageBytes
+}
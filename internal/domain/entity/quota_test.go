@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuota_Exceeds(t *testing.T) {
+	quota := Quota{MaxItems: 10, MaxStorageBytes: 1000}
+
+	assert.False(t, quota.ExceedsItems(9, 1))
+	assert.True(t, quota.ExceedsItems(9, 2))
+	assert.False(t, quota.ExceedsStorage(900, 100))
+	assert.True(t, quota.ExceedsStorage(900, 101))
+
+	// 0 の場合は無制限
+	unlimited := Quota{}
+	assert.False(t, unlimited.ExceedsItems(1000000, 1))
+	assert.False(t, unlimited.ExceedsStorage(1<<40, 1))
+}
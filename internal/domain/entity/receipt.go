@@ -0,0 +1,178 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// 領収書の写真から OCR で読み取った購入価格・購入日・店名の候補（アイテムには反映しない）
+// 写真ごとに最後に読み取った結果を保存する
+type ReceiptScan struct {
+	ImageID int64 `json:"image_id"`
+	ItemID  int64 `json:"item_id"`
+
+	// 読み取れなかった項目は省略する
+	PurchasePrice *Money `json:"purchase_price,omitempty"`
+	PurchaseDate  string `json:"purchase_date,omitempty"` // YYYY-MM-DD 形式
+	StoreName     string `json:"store_name,omitempty"`
+
+	// OCR で読み取った全文（候補が正しいかの確認用）
+	Text string `json:"text"`
+
+	ScannedAt time.Time `json:"scanned_at"`
+}
+
+// 店名の最大文字数（超える場合は切り詰める）
+const MaxReceiptStoreNameLength = 100
+
+// 読み取った全文の最大文字数（超える場合は切り詰める）
+const MaxReceiptTextLength = 10000
+
+// 合計金額の行の目印（優先する順。小計の行は除く）
+var receiptTotalKeywords = []string{"総合計", "合計", "お買上", "お支払", "TOTAL", "AMOUNT DUE"}
+
+// 店名として扱わない見出しの行
+var receiptTitles = []string{"領収書", "領収証", "レシート", "RECEIPT"}
+
+var (
+	receiptDatePattern   = regexp.MustCompile(`(\d{4})\s*[-/.年]\s*(\d{1,2})\s*[-/.月]\s*(\d{1,2})`)
+	receiptAmountPattern = regexp.MustCompile(`([¥$€£])?\s*(\d{1,3}(?:,\d{3})+|\d+)(?:\.(\d{1,2}))?\s*(円)?`)
+)
+
+// 金額の記号に対応する通貨
+var receiptCurrencySymbols = map[string]string{
+	"¥": "JPY",
+	"円": "JPY",
+	"$": "USD",
+	"€": "EUR",
+	"£": "GBP",
+}
+
+// OCR の全文から候補を読み取る
+// 金額に通貨の記号がない場合は currency（アイテムの購入価格の通貨）とみなす
+func NewReceiptScan(image *ItemImage, text, currency string, now time.Time) *ReceiptScan {
+	// 全角の数字・記号・空白を半角にそろえる
+	lines := receiptLines(norm.NFKC.String(text))
+
+	scan := &ReceiptScan{
+		ImageID:       image.ID,
+		ItemID:        image.ItemID,
+		PurchasePrice: receiptTotal(lines, currency),
+		StoreName:     receiptStoreName(lines),
+		Text:          truncateRunes(strings.TrimSpace(text), MaxReceiptTextLength),
+		ScannedAt:     now,
+	}
+	if date, ok := receiptDate(lines); ok {
+		scan.PurchaseDate = date.String()
+	}
+	return scan
+}
+
+// 空行を除いた行
+func receiptLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// 最初に見つかった実在する日付
+func receiptDate(lines []string) (Date, bool) {
+	for _, line := range lines {
+		for _, match := range receiptDatePattern.FindAllStringSubmatch(line, -1) {
+			if date, err := ParseDate(match[1] + "-" + zeroPad(match[2]) + "-" + zeroPad(match[3])); err == nil {
+				return date, true
+			}
+		}
+	}
+	return Date{}, false
+}
+
+func zeroPad(value string) string {
+	if len(value) == 1 {
+		return "0" + value
+	}
+	return value
+}
+
+// 合計金額の行の金額（金額が次の行にある場合はその行から読む）
+func receiptTotal(lines []string, currency string) *Money {
+	for _, keyword := range receiptTotalKeywords {
+		for i, line := range lines {
+			upper := strings.ToUpper(line)
+			if !strings.Contains(upper, keyword) || strings.Contains(upper, "小計") || strings.Contains(upper, "SUBTOTAL") {
+				continue
+			}
+			if amount := receiptAmount(line, currency); amount != nil {
+				return amount
+			}
+			if i+1 < len(lines) {
+				if amount := receiptAmount(lines[i+1], currency); amount != nil {
+					return amount
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// 行の最後の金額
+func receiptAmount(line, currency string) *Money {
+	matches := receiptAmountPattern.FindAllStringSubmatch(line, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	match := matches[len(matches)-1]
+
+	switch {
+	case match[1] != "":
+		currency = receiptCurrencySymbols[match[1]]
+	case match[4] != "":
+		currency = receiptCurrencySymbols[match[4]]
+	}
+	value := strings.ReplaceAll(match[2], ",", "")
+	if match[3] != "" {
+		value += "." + match[3]
+	}
+	amount, err := ParseMoney(value, currency)
+	if err != nil || amount.IsZero() {
+		return nil
+	}
+	return &amount
+}
+
+// 最初の見出し・日付・金額以外の、文字を含む行
+func receiptStoreName(lines []string) string {
+	for _, line := range lines {
+		if isReceiptTitle(line) || receiptDatePattern.MatchString(line) || !strings.ContainsFunc(line, unicode.IsLetter) {
+			continue
+		}
+		return truncateRunes(line, MaxReceiptStoreNameLength)
+	}
+	return ""
+}
+
+func isReceiptTitle(line string) bool {
+	upper := strings.ToUpper(strings.ReplaceAll(line, " ", ""))
+	for _, title := range receiptTitles {
+		if upper == title {
+			return true
+		}
+	}
+	return false
+}
+
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
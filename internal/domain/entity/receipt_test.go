@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReceiptScan(t *testing.T) {
+	image := &ItemImage{ID: 3, ItemID: 1}
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		text      string
+		currency  string
+		wantPrice *Money
+		wantDate  string
+		wantStore string
+	}{
+		{
+			name:      "正常系: 日本のレシート（見出しと小計を除く）",
+			text:      "領収書\nロレックス銀座店\n2023年1月15日 14:32\n小計 ¥1,363,637\n消費税 ¥136,363\n合計 ¥1,500,000\nお預り ¥1,500,000",
+			wantPrice: &Money{Amount: 1500000, Currency: "JPY"},
+			wantDate:  "2023-01-15",
+			wantStore: "ロレックス銀座店",
+		},
+		{
+			name:      "正常系: 全角の数字・記号と、次の行の金額",
+			text:      "ＨＥＲＭＥＳ 表参道\n２０２３／０２／２０\n合計（税込）\n￥２，０００，０００",
+			wantPrice: &Money{Amount: 2000000, Currency: "JPY"},
+			wantDate:  "2023-02-20",
+			wantStore: "HERMES 表参道",
+		},
+		{
+			name:      "正常系: 記号の通貨と小数点の金額",
+			text:      "RECEIPT\nNike SoHo\n2023-03-01\nSUBTOTAL $110.00\nTOTAL $120.50",
+			currency:  "JPY",
+			wantPrice: &Money{Amount: 12050, Currency: "USD"},
+			wantDate:  "2023-03-01",
+			wantStore: "Nike SoHo",
+		},
+		{
+			name:      "正常系: 記号がない金額はアイテムの通貨とみなす",
+			text:      "Boutique Paris\nTOTAL 950.00\n",
+			currency:  "EUR",
+			wantPrice: &Money{Amount: 95000, Currency: "EUR"},
+			wantStore: "Boutique Paris",
+		},
+		{
+			name: "正常系: 読み取れない項目は省略する",
+			text: "12345\n2023/13/40\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scan := NewReceiptScan(image, tt.text, tt.currency, now)
+
+			assert.Equal(t, int64(3), scan.ImageID)
+			assert.Equal(t, int64(1), scan.ItemID)
+			assert.Equal(t, tt.wantPrice, scan.PurchasePrice)
+			assert.Equal(t, tt.wantDate, scan.PurchaseDate)
+			assert.Equal(t, tt.wantStore, scan.StoreName)
+			assert.Equal(t, now, scan.ScannedAt)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// アイテムの売却の記録（1つのアイテムにつき1件）
+// 取得費・カテゴリーは売却時のアイテムの値を保存し、売却後にアイテムを変更・削除しても損益は変わらない
+type Sale struct {
+	ID       int64  `json:"id"`
+	ItemID   int64  `json:"item_id"`
+	Category string `json:"category"`
+
+	SalePrice Money `json:"sale_price"`
+	SaleDate  Date  `json:"sale_date"`
+	Fees      Money `json:"fees"` // 売却時の手数料（売却価格と同じ通貨）
+
+	// 売却日時点の為替レート（外貨1単位あたりの円）。売却価格が円の場合は空
+	ExchangeRate string `json:"exchange_rate,omitempty"`
+
+	// 取得費（売却時のアイテムの購入価格）とその円換算額
+	CostBasis    Money  `json:"cost_basis"`
+	CostBasisJPY *Money `json:"cost_basis_jpy,omitempty"`
+
+	// 手取り額（売却価格 - 手数料）の円換算額
+	ProceedsJPY *Money `json:"proceeds_jpy,omitempty"`
+
+	// 実現損益（手取り額 - 取得費）。売却価格と取得費の通貨が異なる場合は円換算額のみ、円換算できない場合は nil
+	RealizedGain    *Money `json:"realized_gain,omitempty"`
+	RealizedGainJPY *Money `json:"realized_gain_jpy,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// アイテムの売却を記録する（fees が nil の場合は手数料なし）
+// 外貨建ての売却の円換算額は SetExchangeRate で為替レートを設定して求める
+func NewSale(item *Item, salePrice Money, saleDate Date, fees *Money, now time.Time) (*Sale, error) {
+	salePrice = normalizeMoney(salePrice)
+	sale := &Sale{
+		ItemID:       item.ID,
+		Category:     item.Category,
+		SalePrice:    salePrice,
+		SaleDate:     saleDate,
+		Fees:         Money{Currency: salePrice.Currency},
+		CostBasis:    item.PurchasePrice,
+		CostBasisJPY: copyMoney(item.PurchasePriceJPY),
+		CreatedAt:    now,
+	}
+	if fees != nil {
+		sale.Fees = normalizeMoney(*fees)
+	}
+
+	var errs []string
+	if item.Draft {
+		errs = append(errs, "draft items cannot be sold")
+	}
+	if sale.SaleDate.IsZero() {
+		errs = append(errs, "sale_date is required")
+	} else if sale.SaleDate.IsFutureAt(now) {
+		errs = append(errs, "sale_date must not be in the future")
+	} else if sale.SaleDate.Before(item.PurchaseDate) {
+		errs = append(errs, "sale_date must be on or after purchase_date")
+	}
+	if sale.SalePrice.IsNegative() {
+		errs = append(errs, "sale_price must be 0 or greater")
+	}
+	if !IsSupportedCurrency(sale.SalePrice.Currency) {
+		errs = append(errs, "sale_price currency must be one of: "+strings.Join(SupportedCurrencies(), ", "))
+	}
+	if sale.Fees.IsNegative() {
+		errs = append(errs, "fees must be 0 or greater")
+	}
+	if sale.Fees.Currency != sale.SalePrice.Currency {
+		errs = append(errs, "fees currency must be the same as sale_price")
+	}
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, ", "))
+	}
+
+	if err := sale.applyExchangeRate(); err != nil {
+		return nil, err
+	}
+	return sale, nil
+}
+
+// 外貨建ての売却で為替レートが未設定か
+func (s *Sale) NeedsExchangeRate() bool {
+	return s.SalePrice.Currency != DefaultCurrency && s.ExchangeRate == ""
+}
+
+// 売却日時点の為替レートを設定し、手取り額・実現損益の円換算額を求める
+func (s *Sale) SetExchangeRate(rate string) error {
+	normalized, err := NormalizeExchangeRate(rate)
+	if err != nil {
+		return err
+	}
+
+	s.ExchangeRate = normalized
+	return s.applyExchangeRate()
+}
+
+// 手取り額と実現損益を売却価格・手数料・取得費と為替レートから求める
+func (s *Sale) applyExchangeRate() error {
+	if s.SalePrice.Currency == DefaultCurrency {
+		s.ExchangeRate = ""
+	}
+
+	proceeds, err := s.SalePrice.Sub(s.Fees)
+	if err != nil {
+		return err
+	}
+
+	s.RealizedGain = nil
+	if proceeds.Currency == s.CostBasis.Currency {
+		gain, err := proceeds.Sub(s.CostBasis)
+		if err != nil {
+			return err
+		}
+		s.RealizedGain = &gain
+	}
+
+	s.ProceedsJPY = nil
+	switch {
+	case proceeds.Currency == DefaultCurrency:
+		s.ProceedsJPY = &proceeds
+	case s.ExchangeRate != "":
+		jpy, err := proceeds.ConvertToJPY(s.ExchangeRate)
+		if err != nil {
+			return err
+		}
+		s.ProceedsJPY = &jpy
+	}
+
+	s.RealizedGainJPY = nil
+	if s.ProceedsJPY != nil && s.CostBasisJPY != nil {
+		gain, err := s.ProceedsJPY.Sub(*s.CostBasisJPY)
+		if err != nil {
+			return err
+		}
+		s.RealizedGainJPY = &gain
+	}
+	return nil
+}
+
+func copyMoney(m *Money) *Money {
+	if m == nil {
+		return nil
+	}
+	copied := *m
+	return &copied
+}
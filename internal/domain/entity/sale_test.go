@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSale(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	jpyItem := &Item{ID: 1, Category: "時計", PurchasePrice: JPY(1500000), PurchaseDate: MustParseDate("2023-01-15")}
+	jpyItem.applyExchangeRate()
+	usdItem := &Item{ID: 2, Category: "バッグ", PurchasePrice: Money{Amount: 500000, Currency: "USD"}, PurchaseDate: MustParseDate("2023-01-15"), ExchangeRate: "130"}
+	usdItem.applyExchangeRate()
+
+	t.Run("正常系: 手数料を引いた手取り額と取得費の差が実現損益", func(t *testing.T) {
+		fees := JPY(100000)
+		sale, err := NewSale(jpyItem, JPY(2000000), MustParseDate("2026-09-01"), &fees, now)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), sale.ItemID)
+		assert.Equal(t, "時計", sale.Category)
+		assert.Equal(t, JPY(1500000), sale.CostBasis)
+		assert.Equal(t, &Money{Amount: 1900000, Currency: "JPY"}, sale.ProceedsJPY)
+		assert.Equal(t, &Money{Amount: 400000, Currency: "JPY"}, sale.RealizedGain)
+		assert.Equal(t, &Money{Amount: 400000, Currency: "JPY"}, sale.RealizedGainJPY)
+		assert.Empty(t, sale.ExchangeRate)
+	})
+
+	t.Run("正常系: 手数料を省略すると売却価格の通貨の0", func(t *testing.T) {
+		sale, err := NewSale(jpyItem, JPY(1000000), MustParseDate("2026-09-01"), nil, now)
+		require.NoError(t, err)
+
+		assert.Equal(t, JPY(0), sale.Fees)
+		assert.Equal(t, &Money{Amount: -500000, Currency: "JPY"}, sale.RealizedGainJPY)
+	})
+
+	t.Run("正常系: 外貨建てで購入したアイテムを円で売却すると円換算額のみ", func(t *testing.T) {
+		sale, err := NewSale(usdItem, JPY(800000), MustParseDate("2026-09-01"), nil, now)
+		require.NoError(t, err)
+
+		assert.Equal(t, &Money{Amount: 650000, Currency: "JPY"}, sale.CostBasisJPY)
+		assert.Nil(t, sale.RealizedGain)
+		assert.Equal(t, &Money{Amount: 150000, Currency: "JPY"}, sale.RealizedGainJPY)
+	})
+
+	t.Run("正常系: 外貨建ての売却は為替レートを設定して円換算する", func(t *testing.T) {
+		fees := Money{Amount: 10000, Currency: "USD"}
+		sale, err := NewSale(usdItem, Money{Amount: 610000, Currency: "USD"}, MustParseDate("2026-09-01"), &fees, now)
+		require.NoError(t, err)
+
+		assert.True(t, sale.NeedsExchangeRate())
+		assert.Equal(t, &Money{Amount: 100000, Currency: "USD"}, sale.RealizedGain)
+		assert.Nil(t, sale.ProceedsJPY)
+		assert.Nil(t, sale.RealizedGainJPY)
+
+		require.NoError(t, sale.SetExchangeRate("150.00"))
+		assert.Equal(t, "150", sale.ExchangeRate)
+		assert.Equal(t, &Money{Amount: 900000, Currency: "JPY"}, sale.ProceedsJPY)
+		assert.Equal(t, &Money{Amount: 250000, Currency: "JPY"}, sale.RealizedGainJPY)
+	})
+
+	tests := []struct {
+		name      string
+		item      *Item
+		salePrice Money
+		saleDate  Date
+		fees      *Money
+		wantErr   string
+	}{
+		{"異常系: 売却日が未入力", jpyItem, JPY(1000), Date{}, nil, "sale_date is required"},
+		{"異常系: 売却日が未来", jpyItem, JPY(1000), MustParseDate("2026-10-02"), nil, "sale_date must not be in the future"},
+		{"異常系: 売却日が購入日より前", jpyItem, JPY(1000), MustParseDate("2023-01-14"), nil, "sale_date must be on or after purchase_date"},
+		{"異常系: 売却価格が負", jpyItem, JPY(-1), MustParseDate("2026-09-01"), nil, "sale_price must be 0 or greater"},
+		{"異常系: 手数料の通貨が売却価格と異なる", jpyItem, JPY(1000), MustParseDate("2026-09-01"), &Money{Amount: 100, Currency: "USD"}, "fees currency must be the same as sale_price"},
+		{"異常系: 下書き", &Item{ID: 3, Draft: true}, JPY(1000), MustParseDate("2026-09-01"), nil, "draft items cannot be sold"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSale(tt.item, tt.salePrice, tt.saleDate, tt.fees, now)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
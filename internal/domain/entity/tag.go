@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// タグ名の最大文字数
+const MaxTagLength = 50
+
+// アイテムに付けるタグ（"limited-edition" など）。1つのタグを複数のアイテムで共有する
+type Tag struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewTag(name string, now time.Time) (*Tag, error) {
+	normalized, err := NormalizeTagName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tag{
+		Name:      normalized,
+		CreatedAt: now,
+	}, nil
+}
+
+// タグ名を小文字に揃えて検証する（空白は含められない）
+func NormalizeTagName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if name == "" {
+		return "", errors.New("tag is required")
+	}
+	if utf8.RuneCountInString(name) > MaxTagLength {
+		return "", errors.New("tag must be 50 characters or less")
+	}
+	if strings.IndexFunc(name, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) >= 0 {
+		return "", errors.New("tag must not contain spaces or commas")
+	}
+
+	return name, nil
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTag(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expectedName string
+		wantErr      bool
+		expectedErr  string
+	}{
+		{
+			name:         "正常系: 小文字に揃える",
+			input:        "  Limited-Edition ",
+			expectedName: "limited-edition",
+		},
+		{
+			name:         "正常系: 日本語のタグ",
+			input:        "限定品",
+			expectedName: "限定品",
+		},
+		{
+			name:        "異常系: 空のタグ",
+			input:       "   ",
+			wantErr:     true,
+			expectedErr: "tag is required",
+		},
+		{
+			name:        "異常系: 空白を含む",
+			input:       "limited edition",
+			wantErr:     true,
+			expectedErr: "tag must not contain spaces or commas",
+		},
+		{
+			name:        "異常系: カンマを含む",
+			input:       "a,b",
+			wantErr:     true,
+			expectedErr: "tag must not contain spaces or commas",
+		},
+		{
+			name:        "異常系: 長すぎる",
+			input:       strings.Repeat("a", MaxTagLength+1),
+			wantErr:     true,
+			expectedErr: "tag must be 50 characters or less",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, err := NewTag(tt.input, time.Now())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, tag)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, tag.Name)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package entity
+
+// 購入年、通貨ごとの支払った税額の集計値
+// 金額は通貨の最小単位の整数で保持する
+type TaxTotal struct {
+	Year     int
+	Currency string
+	Count    int   // 税額を記録したアイテムの件数
+	Total    int64 // 税額の合計
+	TotalJPY int64 // 税額の円換算額の合計（円換算額のないアイテムは含まない）
+}
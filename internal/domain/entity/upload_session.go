@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 分割アップロードのファイル名の最大文字数
+const MaxUploadFileNameLength = 255
+
+// 大きな写真・レシートを分割して送るためのアップロードセッション
+// 受信済みのバイト数（offset）から再開でき、全体を受信すると写真として登録する
+type UploadSession struct {
+	ID        string    `json:"id"`
+	ItemID    int64     `json:"item_id"`
+	FileName  string    `json:"file_name"`
+	Size      int64     `json:"size"`   // ファイル全体のサイズ（バイト）
+	Offset    int64     `json:"offset"` // 受信済みのバイト数
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"` // 期限を過ぎたセッションはクリーンアップで削除する
+}
+
+func NewUploadSession(id string, itemID int64, fileName string, size int64, now time.Time, ttl time.Duration) (*UploadSession, error) {
+	session := &UploadSession{
+		ID:        id,
+		ItemID:    itemID,
+		FileName:  strings.TrimSpace(fileName),
+		Size:      size,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	if session.Size <= 0 {
+		return nil, errors.New("size must be greater than 0")
+	}
+	if utf8.RuneCountInString(session.FileName) > MaxUploadFileNameLength {
+		return nil, errors.New("file_name must be 255 characters or less")
+	}
+
+	return session, nil
+}
+
+// 全体を受信したか
+func (s *UploadSession) IsComplete() bool {
+	return s.Offset >= s.Size
+}
+
+// 期限切れか
+func (s *UploadSession) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
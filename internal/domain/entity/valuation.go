@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 評価額の取得元・記録理由の最大文字数
+const (
+	MaxValuationSourceLength = 100
+	MaxValuationReasonLength = 255
+)
+
+// アイテムのある時点の評価額（鑑定・相場など）
+type Valuation struct {
+	ID         int64     `json:"id"`
+	ItemID     int64     `json:"item_id"`
+	ValuatedAt Date      `json:"valuated_at"`
+	Value      Money     `json:"value"`
+	Source     string    `json:"source"`           // 評価額の取得元（鑑定業者名、相場サイトなど）
+	Reason     string    `json:"reason,omitempty"` // 記録した理由（一括調整の理由など）
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func NewValuation(itemID int64, valuatedAt Date, value Money, source string, now time.Time) (*Valuation, error) {
+	valuation := &Valuation{
+		ItemID:     itemID,
+		ValuatedAt: valuatedAt,
+		Value:      normalizeMoney(value),
+		Source:     strings.TrimSpace(source),
+		CreatedAt:  now,
+	}
+
+	if err := valuation.Validate(); err != nil {
+		return nil, err
+	}
+
+	return valuation, nil
+}
+
+func (v *Valuation) Validate() error {
+	var errs []string
+
+	if v.ValuatedAt.IsZero() {
+		errs = append(errs, "valuated_at is required")
+	}
+
+	if v.Value.IsNegative() {
+		errs = append(errs, "value must be 0 or greater")
+	}
+	if !IsSupportedCurrency(v.Value.Currency) {
+		errs = append(errs, "value currency must be one of: "+strings.Join(SupportedCurrencies(), ", "))
+	}
+
+	if v.Source == "" {
+		errs = append(errs, "source is required")
+	} else if utf8.RuneCountInString(v.Source) > MaxValuationSourceLength {
+		errs = append(errs, "source must be 100 characters or less")
+	}
+
+	if utf8.RuneCountInString(v.Reason) > MaxValuationReasonLength {
+		errs = append(errs, "reason must be 255 characters or less")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
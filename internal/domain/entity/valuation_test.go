@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewValuation(t *testing.T) {
+	tests := []struct {
+		name        string
+		valuatedAt  Date
+		value       Money
+		source      string
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:       "正常系: 有効な評価額",
+			valuatedAt: MustParseDate("2024-06-01"),
+			value:      JPY(2000000),
+			source:     "鑑定",
+		},
+		{
+			name:        "異常系: 評価日が未入力",
+			value:       JPY(2000000),
+			source:      "鑑定",
+			wantErr:     true,
+			expectedErr: "valuated_at is required",
+		},
+		{
+			name:        "異常系: 負の評価額",
+			valuatedAt:  MustParseDate("2024-06-01"),
+			value:       JPY(-1),
+			source:      "鑑定",
+			wantErr:     true,
+			expectedErr: "value must be 0 or greater",
+		},
+		{
+			name:        "異常系: 取得元が空",
+			valuatedAt:  MustParseDate("2024-06-01"),
+			value:       JPY(2000000),
+			source:      "  ",
+			wantErr:     true,
+			expectedErr: "source is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valuation, err := NewValuation(1, tt.valuatedAt, tt.value, tt.source, time.Now())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, valuation)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), valuation.ItemID)
+			assert.Equal(t, tt.value, valuation.Value)
+		})
+	}
+}
+
+func TestItem_SetLatestValuation(t *testing.T) {
+	// 同じ通貨は購入価格と比較する
+	item, err := NewItem("ロレックス デイトナ", "時計", "ROLEX", JPY(1500000), MustParseDate("2023-01-15"), time.Now())
+	require.NoError(t, err)
+	item.SetLatestValuation(&Valuation{Value: JPY(1200000)})
+	assert.Equal(t, JPY(-300000), *item.UnrealizedGain)
+
+	// 外貨建てのアイテムは円換算額と円建ての評価額を比較する
+	item, err = NewItem("オメガ スピードマスター", "時計", "OMEGA", Money{Amount: 750000, Currency: "USD"}, MustParseDate("2023-01-15"), time.Now())
+	require.NoError(t, err)
+	item.SetLatestValuation(&Valuation{Value: JPY(1000000)})
+	assert.Nil(t, item.UnrealizedGain)
+
+	require.NoError(t, item.SetExchangeRate("130"))
+	item.SetLatestValuation(&Valuation{Value: JPY(1000000)})
+	assert.Equal(t, JPY(25000), *item.UnrealizedGain)
+
+	item.SetLatestValuation(&Valuation{Value: Money{Amount: 800000, Currency: "USD"}})
+	assert.Equal(t, Money{Amount: 50000, Currency: "USD"}, *item.UnrealizedGain)
+
+	// 通貨が異なり円換算できない場合は求めない
+	item.SetLatestValuation(&Valuation{Value: Money{Amount: 700000, Currency: "EUR"}})
+	assert.Nil(t, item.UnrealizedGain)
+
+	item.SetLatestValuation(nil)
+	assert.Nil(t, item.LatestValuation)
+	assert.Nil(t, item.UnrealizedGain)
+}
@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Webhook で通知するイベント
+type WebhookEvent string
+
+const (
+	WebhookItemCreated WebhookEvent = "item.created"
+	WebhookItemUpdated WebhookEvent = "item.updated"
+	WebhookItemDeleted WebhookEvent = "item.deleted" // 論理削除と完全削除（data.action で区別する）
+)
+
+// 登録できるイベント（登録時に指定しない場合はすべてを通知する）
+var WebhookEvents = []WebhookEvent{WebhookItemCreated, WebhookItemUpdated, WebhookItemDeleted}
+
+func (e WebhookEvent) IsValid() bool {
+	for _, event := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// 監査ログの操作に対応するイベント（登録・削除以外の変更はすべて更新として通知する）
+func WebhookEventOf(action AuditAction) WebhookEvent {
+	switch action {
+	case AuditCreate:
+		return WebhookItemCreated
+	case AuditDelete, AuditPurge:
+		return WebhookItemDeleted
+	}
+	return WebhookItemUpdated
+}
+
+// 送信先のURLの最大文字数
+const MaxWebhookURLLength = 2048
+
+// アイテムの変更を通知する送信先
+type Webhook struct {
+	ID     int64          `json:"id"`
+	URL    string         `json:"url"`
+	Events []WebhookEvent `json:"events"`
+
+	// 署名の鍵（登録時のレスポンスでのみ返す）
+	Secret string `json:"secret,omitempty"`
+
+	Owner     string    `json:"owner"` // 登録した操作者（X-User-ID）
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewWebhook(rawURL string, events []WebhookEvent, owner, secret string, now time.Time) (*Webhook, error) {
+	webhook := &Webhook{
+		URL:       strings.TrimSpace(rawURL),
+		Owner:     owner,
+		Secret:    secret,
+		CreatedAt: now,
+	}
+
+	var errs ValidationErrors
+	if webhook.URL == "" {
+		errs.Add("url", "is required")
+	} else if len(webhook.URL) > MaxWebhookURLLength {
+		errs.Add("url", "must be "+strconv.Itoa(MaxWebhookURLLength)+" characters or less")
+	} else if u, err := url.Parse(webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs.Add("url", "must be an absolute http or https URL")
+	}
+
+	if len(events) == 0 {
+		events = WebhookEvents
+	}
+	seen := make(map[WebhookEvent]bool, len(events))
+	for _, event := range events {
+		if !event.IsValid() {
+			errs.Add("events", "must be one of: item.created, item.updated, item.deleted")
+			break
+		}
+		if !seen[event] {
+			seen[event] = true
+			webhook.Events = append(webhook.Events, event)
+		}
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// イベントを通知する送信先か
+func (w *Webhook) Subscribes(event WebhookEvent) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// 署名の鍵を除いた複製（一覧などで返す場合）
+func (w *Webhook) Redacted() *Webhook {
+	redacted := *w
+	redacted.Secret = ""
+	return &redacted
+}
+
+// 送信する本文の署名（X-Webhook-Signature ヘッダーの値）
+// 受信側は "{X-Webhook-Timestamp}.{本文}" の HMAC-SHA256 を署名の鍵で求めて比較する
+func (w *Webhook) Sign(timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(w.Secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Webhook の送信状況
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending" // 送信待ち（再送待ちを含む）
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed" // 再送の上限に達した
+)
+
+// Webhook の送信（イベント1件・送信先1件ごと。再送しても同じ ID と本文を使う）
+type WebhookDelivery struct {
+	ID        int64                 `json:"id"`
+	WebhookID int64                 `json:"webhook_id"`
+	Event     WebhookEvent          `json:"event"`
+	Payload   json.RawMessage       `json:"payload"`
+	Status    WebhookDeliveryStatus `json:"status"`
+	Attempts  int                   `json:"attempts"`
+
+	// 最後の送信の HTTP ステータス（接続できなかった場合は 0）とエラー
+	ResponseStatus int    `json:"response_status,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"` // 次に送信する日時（送信待ちの場合のみ）
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebhook(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		events         []WebhookEvent
+		expectedEvents []WebhookEvent
+		expectedFields []string
+	}{
+		{
+			name:           "正常系: イベントを指定しない場合はすべてを通知する",
+			url:            " https://example.com/hooks ",
+			expectedEvents: WebhookEvents,
+		},
+		{
+			name:           "正常系: 重複したイベントは1つにする",
+			url:            "http://localhost:8080/hooks",
+			events:         []WebhookEvent{WebhookItemDeleted, WebhookItemDeleted},
+			expectedEvents: []WebhookEvent{WebhookItemDeleted},
+		},
+		{
+			name:           "異常系: URLがない",
+			url:            "",
+			expectedFields: []string{"url"},
+		},
+		{
+			name:           "異常系: http / https 以外",
+			url:            "ftp://example.com/hooks",
+			expectedFields: []string{"url"},
+		},
+		{
+			name:           "異常系: 相対URL",
+			url:            "/hooks",
+			expectedFields: []string{"url"},
+		},
+		{
+			name:           "異常系: URLが長すぎる",
+			url:            "https://example.com/" + strings.Repeat("a", MaxWebhookURLLength),
+			expectedFields: []string{"url"},
+		},
+		{
+			name:           "異常系: 未定義のイベントとURLの誤り",
+			url:            "example.com",
+			events:         []WebhookEvent{"item.archived"},
+			expectedFields: []string{"url", "events"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook, err := NewWebhook(tt.url, tt.events, "alice", "whsec_test", time.Now())
+
+			if tt.expectedFields != nil {
+				require.Error(t, err)
+				var errs ValidationErrors
+				require.ErrorAs(t, err, &errs)
+				var fields []string
+				for _, fieldErr := range errs {
+					fields = append(fields, fieldErr.Field)
+				}
+				assert.Equal(t, tt.expectedFields, fields)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, strings.TrimSpace(tt.url), webhook.URL)
+			assert.Equal(t, tt.expectedEvents, webhook.Events)
+			assert.Equal(t, "alice", webhook.Owner)
+		})
+	}
+}
+
+func TestWebhookEventOf(t *testing.T) {
+	assert.Equal(t, WebhookItemCreated, WebhookEventOf(AuditCreate))
+	assert.Equal(t, WebhookItemDeleted, WebhookEventOf(AuditDelete))
+	assert.Equal(t, WebhookItemDeleted, WebhookEventOf(AuditPurge))
+	assert.Equal(t, WebhookItemUpdated, WebhookEventOf(AuditUpdate))
+	assert.Equal(t, WebhookItemUpdated, WebhookEventOf(AuditRestore))
+}
+
+func TestWebhook_Sign(t *testing.T) {
+	webhook := &Webhook{Secret: "whsec_test"}
+	body := []byte(`{"event":"item.created"}`)
+
+	// 受信側と同じ手順で求めた署名と一致する
+	mac := hmac.New(sha256.New, []byte("whsec_test"))
+	mac.Write([]byte("1700000000." + string(body)))
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), webhook.Sign(1700000000, body))
+
+	// 時刻が異なれば署名も異なる（再送された古いリクエストと区別できる）
+	assert.NotEqual(t, webhook.Sign(1700000000, body), webhook.Sign(1700000001, body))
+	assert.Empty(t, webhook.Redacted().Secret)
+	assert.Equal(t, "whsec_test", webhook.Secret)
+}
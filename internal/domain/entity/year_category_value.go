@@ -0,0 +1,12 @@
+package entity
+
+// 購入年・カテゴリー・通貨ごとの購入価格の集計値
+// 金額は通貨の最小単位の整数で保持する
+type YearCategoryValueTotal struct {
+	Year     int
+	Category string
+	Currency string
+	Count    int
+	Total    int64
+	TotalJPY int64 // 円換算額の合計（円換算額のないアイテムは含まない）
+}
@@ -7,6 +7,63 @@ var (
 	ErrInvalidInput   = errors.New("invalid input")
 	ErrDatabaseError  = errors.New("database error")
 	ErrDuplicateEntry = errors.New("duplicate entry")
+	ErrReadOnly       = errors.New("service is in read-only mode")
+	ErrBudgetExceeded = errors.New("category budget exceeded")
+	ErrItemOnHold     = errors.New("item is on hold")
+
+	// アイテムは売却済み（売却は1回のみ記録できる）
+	ErrItemSold = errors.New("item already sold")
+
+	// 名前・ブランド・購入日が同じアイテムが登録済み（重複を拒否する登録の場合）
+	ErrDuplicateItem = errors.New("duplicate item")
+
+	// 指定したバージョンのアイテムの変更履歴がない
+	ErrRevisionNotFound = errors.New("revision not found")
+
+	// 写真・評価額などの紐づくデータがあるため削除できない
+	ErrItemHasDependents = errors.New("item has dependent data")
+
+	// アイテム数・写真の合計サイズの利用上限を超える
+	ErrQuotaExceeded = errors.New("quota exceeded")
+
+	// 外部の相場APIから市場価格を取得できない（未設定、該当なし、接続エラーなど）
+	ErrMarketPriceUnavailable = errors.New("market price unavailable")
+
+	// 分割アップロードのセッションがない（期限切れで削除された場合を含む）
+	ErrUploadNotFound = errors.New("upload session not found")
+
+	// 分割アップロードの送信位置が受信済みのバイト数と一致しない（クライアントは受信済みの位置から再開する）
+	ErrUploadOffsetMismatch = errors.New("upload offset mismatch")
+
+	// Webhook の送信先がない（他の操作者が登録したものを含む）
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	// バックグラウンドジョブがない（他の操作者が登録したものを含む）
+	ErrJobNotFound = errors.New("job not found")
+
+	// カテゴリーの変更の提案がない
+	ErrSuggestionNotFound = errors.New("suggestion not found")
+
+	// カテゴリーの変更の提案が確認済み、または提案後にアイテムのカテゴリーが変わった
+	ErrSuggestionOutdated = errors.New("suggestion is no longer pending")
+
+	// 写真の領収書の読み取り結果がない（まだ読み取っていない）
+	ErrReceiptNotFound = errors.New("receipt scan not found")
+
+	// アイテムの保険の契約がない（他のアイテムの契約を含む）
+	ErrInsurancePolicyNotFound = errors.New("insurance policy not found")
+
+	// 同じ保険会社・証券番号の契約がアイテムに登録済み
+	ErrDuplicateInsurancePolicy = errors.New("insurance policy already registered")
+
+	// 外部の OCR サービスで写真の文字を読み取れない（接続エラー、タイムアウトなど）
+	ErrOCRUnavailable = errors.New("ocr unavailable")
+
+	// 再試行で成功しうる一時的なエラー（デッドロック、接続断など）。ErrDatabaseError とあわせて付与される
+	ErrTransient = errors.New("transient error")
+
+	// 依存先（読み取りキャッシュ・検索エンジンなど）の障害が続いているため、呼び出さずに失敗とした
+	ErrCircuitOpen = errors.New("circuit breaker is open")
 )
 
 func IsNotFoundError(err error) bool {
@@ -20,3 +77,83 @@ func IsDatabaseError(err error) bool {
 func IsValidationError(err error) bool {
 	return errors.Is(err, ErrInvalidInput)
 }
+
+func IsReadOnlyError(err error) bool {
+	return errors.Is(err, ErrReadOnly)
+}
+
+func IsBudgetExceededError(err error) bool {
+	return errors.Is(err, ErrBudgetExceeded)
+}
+
+func IsOnHoldError(err error) bool {
+	return errors.Is(err, ErrItemOnHold)
+}
+
+func IsItemSoldError(err error) bool {
+	return errors.Is(err, ErrItemSold)
+}
+
+func IsDuplicateItemError(err error) bool {
+	return errors.Is(err, ErrDuplicateItem)
+}
+
+func IsRevisionNotFoundError(err error) bool {
+	return errors.Is(err, ErrRevisionNotFound)
+}
+
+func IsHasDependentsError(err error) bool {
+	return errors.Is(err, ErrItemHasDependents)
+}
+
+func IsQuotaExceededError(err error) bool {
+	return errors.Is(err, ErrQuotaExceeded)
+}
+
+func IsMarketPriceUnavailableError(err error) bool {
+	return errors.Is(err, ErrMarketPriceUnavailable)
+}
+
+func IsUploadNotFoundError(err error) bool {
+	return errors.Is(err, ErrUploadNotFound)
+}
+
+func IsUploadOffsetMismatchError(err error) bool {
+	return errors.Is(err, ErrUploadOffsetMismatch)
+}
+
+func IsWebhookNotFoundError(err error) bool {
+	return errors.Is(err, ErrWebhookNotFound)
+}
+
+func IsJobNotFoundError(err error) bool {
+	return errors.Is(err, ErrJobNotFound)
+}
+
+func IsSuggestionNotFoundError(err error) bool {
+	return errors.Is(err, ErrSuggestionNotFound)
+}
+
+func IsSuggestionOutdatedError(err error) bool {
+	return errors.Is(err, ErrSuggestionOutdated)
+}
+
+func IsReceiptNotFoundError(err error) bool {
+	return errors.Is(err, ErrReceiptNotFound)
+}
+
+func IsInsurancePolicyNotFoundError(err error) bool {
+	return errors.Is(err, ErrInsurancePolicyNotFound)
+}
+
+func IsDuplicateInsurancePolicyError(err error) bool {
+	return errors.Is(err, ErrDuplicateInsurancePolicy)
+}
+
+func IsOCRUnavailableError(err error) bool {
+	return errors.Is(err, ErrOCRUnavailable)
+}
+
+func IsTransientError(err error) bool {
+	return errors.Is(err, ErrTransient)
+}
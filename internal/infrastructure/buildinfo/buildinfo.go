@@ -0,0 +1,54 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// ビルド時に -ldflags で埋め込まれる値
+//
+//	go build -ldflags "-X Aicon-assignment/internal/infrastructure/buildinfo.Version=v1.2.0 \
+//	  -X Aicon-assignment/internal/infrastructure/buildinfo.Commit=$(git rev-parse HEAD) \
+//	  -X Aicon-assignment/internal/infrastructure/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+// ビルド情報
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+// ビルド情報を返す（ldflags未指定の場合はGoのビルド情報に含まれるVCS情報で補完する）
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "unknown" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "unknown" {
+					info.BuildTime = setting.Value
+				}
+			}
+		}
+	}
+
+	return info
+}
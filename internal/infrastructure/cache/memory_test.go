@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 上限を超えると最も長く使われていない値から削除する", func(t *testing.T) {
+		c := NewMemoryCache(2)
+		require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+		require.NoError(t, c.Set(ctx, "b", []byte("2"), time.Minute))
+		_, _, _ = c.Get(ctx, "a")
+		require.NoError(t, c.Set(ctx, "c", []byte("3"), time.Minute))
+
+		_, ok, _ := c.Get(ctx, "b")
+		assert.False(t, ok)
+		value, ok, _ := c.Get(ctx, "a")
+		assert.True(t, ok)
+		assert.Equal(t, []byte("1"), value)
+	})
+
+	t.Run("正常系: 有効期間を過ぎた値は返さない", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		c := NewMemoryCache(10)
+		c.now = func() time.Time { return now }
+		require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+
+		now = now.Add(time.Minute)
+		_, ok, _ := c.Get(ctx, "a")
+		assert.False(t, ok)
+	})
+
+	t.Run("正常系: カウンターは上限の件数に含めず削除しない", func(t *testing.T) {
+		c := NewMemoryCache(1)
+		_, _ = c.Incr(ctx, "generation")
+		require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+		require.NoError(t, c.Set(ctx, "b", []byte("2"), time.Minute))
+
+		generation, err := c.Counter(ctx, "generation")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), generation)
+	})
+}
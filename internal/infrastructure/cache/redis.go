@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis のキャッシュ（複数台のサーバーでキャッシュと世代を共有する）
+// カウンターには有効期限を付けないため、maxmemory-policy は volatile-lru などの有効期限付きのキーのみを削除する設定にする
+type RedisCache struct {
+	client *redis.Client
+}
+
+// url は redis://[:password@]host:port/db の形式（タイムアウトは ?read_timeout=500ms などで指定する）
+func NewRedisCache(url string) (*RedisCache, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+	return &RedisCache{client: redis.NewClient(options)}, nil
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
+
+func (c *RedisCache) Counter(ctx context.Context, key string) (int64, error) {
+	value, err := c.client.Get(ctx, key).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return value, nil
+}
+
+func (c *RedisCache) Incr(ctx context.Context, key string) (int64, error) {
+	return c.client.Incr(ctx, key).Result()
+}
+
+// ロックが解放されるまでの確認の間隔
+const lockRetryInterval = 50 * time.Millisecond
+
+// 自分が取得したロックのみ解放する（ttl の経過後に他のサーバーが取得したロックは解放しない）
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// 複数台のサーバーで共有する排他ロックを取得するまで待ち、解放する関数を返す
+func (c *RedisCache) Lock(ctx context.Context, key string, ttl time.Duration) (func(), error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(buf)
+
+	for {
+		ok, err := c.client.SetNX(ctx, key, token, ttl).Result()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+
+	return func() {
+		_ = unlockScript.Run(context.WithoutCancel(ctx), c.client, []string{key}, token).Err()
+	}, nil
+}
+
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,71 @@
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	cloudflareEndpoint = "https://api.cloudflare.com/client/v4"
+
+	// 1リクエストで削除できるキャッシュタグの上限
+	cloudflareMaxTags = 30
+)
+
+// Cloudflare のキャッシュタグ単位でキャッシュを削除する
+// POST /zones/{zone_id}/purge_cache に {"tags": [...]} を渡す
+type CloudflarePurger struct {
+	zoneID   string
+	apiToken string
+	client   *http.Client
+}
+
+func NewCloudflarePurger(zoneID, apiToken string, timeout time.Duration) *CloudflarePurger {
+	return &CloudflarePurger{
+		zoneID:   zoneID,
+		apiToken: apiToken,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *CloudflarePurger) Purge(ctx context.Context, keys []string) error {
+	for _, chunk := range chunkKeys(keys, cloudflareMaxTags) {
+		body, err := json.Marshal(map[string][]string{"tags": chunk})
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf("%s/zones/%s/purge_cache", cloudflareEndpoint, p.zoneID)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+p.apiToken)
+		req.Header.Set("Content-Type", "application/json")
+
+		if err := p.do(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *CloudflarePurger) do(req *http.Request) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to purge cloudflare cache: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil || resp.StatusCode != http.StatusOK || !result.Success {
+		return fmt.Errorf("failed to purge cloudflare cache: status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package cdn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	fastlyEndpoint = "https://api.fastly.com"
+
+	// 1リクエストで削除できるサロゲートキーの上限
+	fastlyMaxKeys = 256
+)
+
+// Fastly のサロゲートキー単位でキャッシュを削除する
+// POST /service/{service_id}/purge に Surrogate-Key ヘッダー（スペース区切り）でキーを渡す
+type FastlyPurger struct {
+	serviceID string
+	apiToken  string
+	client    *http.Client
+}
+
+func NewFastlyPurger(serviceID, apiToken string, timeout time.Duration) *FastlyPurger {
+	return &FastlyPurger{
+		serviceID: serviceID,
+		apiToken:  apiToken,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *FastlyPurger) Purge(ctx context.Context, keys []string) error {
+	for _, chunk := range chunkKeys(keys, fastlyMaxKeys) {
+		endpoint := fmt.Sprintf("%s/service/%s/purge", fastlyEndpoint, p.serviceID)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Fastly-Key", p.apiToken)
+		req.Header.Set("Surrogate-Key", strings.Join(chunk, " "))
+		req.Header.Set("Accept", "application/json")
+
+		if err := p.do(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *FastlyPurger) do(req *http.Request) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to purge fastly cache: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to purge fastly cache: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// キーを上限ごとに分割する
+func chunkKeys(keys []string, size int) [][]string {
+	var chunks [][]string
+	for len(keys) > size {
+		chunks = append(chunks, keys[:size])
+		keys = keys[size:]
+	}
+	if len(keys) > 0 {
+		chunks = append(chunks, keys)
+	}
+	return chunks
+}
@@ -4,35 +4,432 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
-var (
+// アプリケーションの設定
+// 環境変数と設定ファイル（CONFIG_FILE）から読み込み、環境変数を優先する
+type Config struct {
+	// HTTPサーバーのポートとログレベル（debug / info / warn / error / off）
+	Port     int
+	LogLevel string
+
+	// データベースの種類（mysql / sqlite）と、sqlite の場合のファイルのパス
+	DBDriver string
+	DBPath   string
+
 	DBUser     string
 	DBPassword string
 	DBHost     string
 	DBName     string
 	DBPort     string
-)
 
-func init() {
-	err := godotenv.Load()
-	if err != nil {
+	// DB接続プールの最大接続数・最大アイドル接続数と、接続を使い回す最大時間（0 の場合は無制限）
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
+
+	// 起動時に未適用のマイグレーションを適用する
+	AutoMigrate bool
+
+	AppEnv string
+
+	// 終了時（SIGINT / SIGTERM）に処理中のリクエストの完了を待つ時間
+	ShutdownTimeout time.Duration
+
+	// 管理者用エンドポイントの共有トークン（未設定の場合は管理者用エンドポイントを無効化）
+	AdminToken string
+
+	// 起動時に読み取り専用モードにする
+	ReadOnly bool
+
+	// キーワード検索にFULLTEXTインデックスを使用する
+	SearchFullText bool
+
+	// キーワード検索に使う外部の検索エンジン（空: 使わない / meilisearch）と設定
+	SearchProvider string
+	SearchURL      string
+	SearchAPIKey   string
+	SearchIndex    string
+	SearchTimeout  time.Duration
+
+	// アイテムの読み取りキャッシュ（空: 使わない / memory / redis）と設定
+	// 有効期間は FindByID・一覧・カテゴリー別件数の順（0 の場合はその読み取りをキャッシュしない）
+	ItemCache           string
+	ItemCacheSize       int
+	ItemCacheRedisURL   string
+	ItemCacheTTL        time.Duration
+	ItemCacheListTTL    time.Duration
+	ItemCacheSummaryTTL time.Duration
+	// Redis の1回の呼び出しのタイムアウト
+	ItemCacheTimeout time.Duration
+
+	// 任意の依存先（Redis の読み取りキャッシュ・レート制限、検索エンジン）のサーキットブレーカー
+	// 連続した失敗がこの回数に達したら呼び出しを止め（0 の場合は止めない）、CircuitBreakerCooldown 後に試しに呼び出す
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  time.Duration
+
+	// アイテムの取得のレスポンスを ITEM_CACHE に保存する有効期間（0 の場合は保存せず、ETag のみ付ける）
+	ItemResponseCacheTTL time.Duration
+
+	// カテゴリー未指定で登録されたアイテムの分類先
+	DefaultCategory string
+
+	// 登録・更新時にブランドを別名の辞書で正式な表記に揃える（エルメス → HERMÈS など）
+	BrandNormalization bool
+
+	// カテゴリー予算を超える購入の扱い（warn: 警告のみ, block: 拒否）
+	BudgetEnforcement string
+
+	// アイテム削除時の写真・評価額・タグ・保険の契約の扱い（cascade / orphan / block）
+	DeletePolicy string
+
+	// orphan の場合に、削除から紐づくデータを保持する期間と、クリーンアップを実行する間隔（0 の場合は自動実行しない）
+	OrphanRetention       time.Duration
+	OrphanCleanupInterval time.Duration
+
+	// 予定日時を過ぎたアイテムの完全削除を実行する間隔（0 の場合は自動実行しない）
+	PurgeInterval time.Duration
+
+	// アイテム数と写真の合計サイズ（バイト）の上限（0 の場合は無制限）と、操作者（X-User-ID）ごとの上書き
+	// 上書きの例: "user-1:1000:1073741824,premium:0:0"（操作者:アイテム数:写真の合計サイズ）
+	QuotaMaxItems   int
+	QuotaMaxStorage int64
+	QuotaOverrides  map[string]QuotaLimit
+
+	// カテゴリーごとの必須属性（例: "時計:reference_number,ジュエリー:material"）
+	CategoryRequiredAttributes map[string][]string
+
+	// 公開統計（GET /public/stats）のキャッシュ期間と、クライアントIPごとの1分あたりのリクエスト上限
+	PublicStatsTTL  time.Duration
+	PublicRateLimit int
+
+	// X-Forwarded-For を信頼するリバースプロキシのアドレス範囲（CIDR）
+	// 未設定の場合は接続元のアドレスをクライアントIP（レート制限の単位）とする
+	TrustedProxies []string
+
+	// 税率から税額を求めるときの端数処理（down: 切り捨て / half_up: 四捨五入 / up: 切り上げ）
+	TaxRounding string
+
+	// 外貨建ての購入価格の円換算に使う為替API（Frankfurter 互換）のURLとタイムアウト
+	FXAPIURL  string
+	FXTimeout time.Duration
+
+	// 評価額の更新に使う相場APIのURL・APIキー、1回あたりのタイムアウトと最大試行回数
+	PriceAPIURL         string
+	PriceAPIKey         string
+	PriceAPITimeout     time.Duration
+	PriceAPIMaxAttempts int
+
+	// 変更時にキャッシュを削除するCDN（空: 削除しない / fastly / cloudflare）と設定
+	CDNProvider      string
+	CDNAPIToken      string
+	CDNTimeout       time.Duration
+	FastlyServiceID  string
+	CloudflareZoneID string
+
+	// 写真の保存先（local / s3）と設定
+	ImageStorage  string
+	ImageLocalDir string
+	ImageBaseURL  string
+	ImageS3Bucket string
+	ImageS3Region string
+	ImageMaxSize  int
+
+	// 写真の一括エクスポートのダウンロードURLの有効期限
+	ImageExportURLExpiry time.Duration
+
+	// 写真の直接アップロード（s3 のみ）の署名付きURLの有効期限
+	ImageUploadURLExpiry time.Duration
+
+	// ラベルシートの印字に使う TrueType フォント（未指定の場合は標準フォントで、日本語は印字できない）と、
+	// QRコードにするアイテムのURLのベース（例: https://example.com）
+	LabelFontPath  string
+	LabelQRBaseURL string
+
+	// 保険の明細の書式を追加・置き換える JSON ファイル（未指定の場合は既定の書式のみ）
+	InsuranceTemplatesPath string
+
+	// Webhook の1回あたりのタイムアウト、最大送信回数、再送の間隔（最初の間隔と上限）、
+	// 送信待ちを確認する間隔（0 の場合は自動で送信しない）
+	WebhookTimeout          time.Duration
+	WebhookMaxAttempts      int
+	WebhookRetryBaseDelay   time.Duration
+	WebhookRetryMaxDelay    time.Duration
+	WebhookDeliveryInterval time.Duration
+
+	// アイテムの変更イベントを変更と同じトランザクションでアウトボックスに記録するか（false の場合は変更の確定後にすぐに発行する）、
+	// 記録したイベントを送信する間隔、送信に失敗したイベントの再送の間隔（最初の間隔と上限）、送信済みのイベントを残す期間（0 の場合は削除しない）
+	OutboxEnabled        bool
+	OutboxRelayInterval  time.Duration
+	OutboxRetryBaseDelay time.Duration
+	OutboxRetryMaxDelay  time.Duration
+	OutboxRetention      time.Duration
+
+	// バックグラウンドジョブを実行するワーカーの数（0 の場合はこのプロセスでは実行しない）、実行待ちを確認する間隔、
+	// 最大実行回数、再試行の間隔（最初の間隔と上限）、実行中のジョブの期限（1回の実行のタイムアウト。過ぎた場合は再度実行する）
+	JobWorkers        int
+	JobPollInterval   time.Duration
+	JobMaxAttempts    int
+	JobRetryBaseDelay time.Duration
+	JobRetryMaxDelay  time.Duration
+	JobLease          time.Duration
+
+	// 全アイテムの評価額を市場価格で更新する実行予定（cron 形式、サーバーのタイムゾーン。空の場合は定期実行しない）
+	ValuationRefreshCron string
+
+	// 「その他」のアイテムのカテゴリーの推定に使う LLM（OpenAI 互換の Chat Completions API）のURL・APIキー・モデル、
+	// 1回あたりのタイムアウトと、提案として登録する確からしさの下限（URLが空の場合はカテゴリーの変更の提案を使わない）
+	LLMAPIURL                 string
+	LLMAPIKey                 string
+	LLMModel                  string
+	LLMTimeout                time.Duration
+	RecategorizeMinConfidence float64
+
+	// 領収書の写真の文字を読み取る OCR（空: 読み取らない / http: 汎用の OCR API / google-vision: Google Cloud Vision API）と
+	// http の送信先URL、APIキー、1回あたりのタイムアウト
+	OCRProvider string
+	OCRAPIURL   string
+	OCRAPIKey   string
+	OCRTimeout  time.Duration
+
+	// 非同期のエクスポート（POST /exports）のダウンロードURLの有効期限（ファイルは写真の保存先に保存する）
+	ExportURLExpiry time.Duration
+
+	// 分割アップロードの受信途中のデータの保存先、上限サイズ、セッションの有効期間、
+	// 期限切れのセッションを削除する間隔（0 の場合は自動実行しない）、クライアントIPごとの1分あたりのリクエスト数の上限
+	UploadDir             string
+	UploadMaxSize         int
+	UploadTTL             time.Duration
+	UploadCleanupInterval time.Duration
+	UploadRateLimit       int
+
+	// 書き込みのAPIの1分あたりのリクエスト数の上限（クライアントIPごと / 操作者ごと、0 の場合は制限しない）
+	// 上限の保存先（memory: サーバーごと / redis: 複数台で共有する）
+	WriteRateLimitPerIP   int
+	WriteRateLimitPerUser int
+	RateLimitStore        string
+	RateLimitRedisURL     string
+	RateLimitTimeout      time.Duration // Redis の1回の呼び出しのタイムアウト
+
+	// リクエストあたりのSQL実行回数の上限（0 の場合は数えない）と、超えた場合の動作（log: ログに出す / block: エラーにする）
+	StatementBudget     int
+	StatementBudgetMode string
+
+	// 実行計画（EXPLAIN）を確認して全件走査をログに出す SELECT の割合（0〜1、0 の場合は確認しない）
+	ExplainSampleRate float64
+
+	// フォールトインジェクション（カオステスト）設定
+	ChaosEnabled     bool
+	ChaosLatencyRate float64
+	ChaosLatency     time.Duration
+	ChaosErrorRate   float64
+	ChaosErrorStatus int
+	ChaosDropRate    float64
+
+	// 依存先（データベース・写真の保存先・Webhook の送信）に指定したエラーを返させる管理者用APIを有効にする（本番環境では無効）
+	FaultInjectionEnabled bool
+
+	// 連携先の動作確認用のサンドボックス（データベースを毎日 SandboxResetAt（HH:MM）にサンプルデータの状態に戻す）
+	Sandbox        bool
+	SandboxResetAt string
+}
+
+// 操作者ごとの利用上限
+type QuotaLimit struct {
+	MaxItems   int
+	MaxStorage int64
+}
+
+// .env・設定ファイル（CONFIG_FILE）・環境変数から設定を読み込み、検証する
+// 不正な値はすべてまとめてエラーとして返す
+func Load() (*Config, error) {
+	if err := godotenv.Load(); err != nil {
 		log.Println("⚠️  .envファイルが見つかりませんでした。")
 	}
+	return load(os.Getenv("CONFIG_FILE"), os.LookupEnv)
+}
+
+func load(path string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	s, err := newSource(path, lookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Config{
+		Port:     s.int("PORT", 8080),
+		LogLevel: s.string("LOG_LEVEL", "info"),
+
+		DBDriver:          s.string("DB_DRIVER", "mysql"),
+		DBPath:            s.string("DB_PATH", "./data/items.db"),
+		DBUser:            s.string("DB_USER", ""),
+		DBPassword:        s.string("DB_PASSWORD", ""),
+		DBHost:            s.string("DB_HOST", ""),
+		DBPort:            s.string("DB_PORT", "3306"),
+		DBName:            s.string("DB_NAME", ""),
+		DBMaxOpenConns:    s.int("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns:    s.int("DB_MAX_IDLE_CONNS", 10),
+		DBConnMaxLifetime: s.duration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+		AutoMigrate:       s.bool("AUTO_MIGRATE", true),
+
+		AppEnv:          s.string("APP_ENV", "development"),
+		ShutdownTimeout: s.duration("SHUTDOWN_TIMEOUT", 10*time.Second),
+		AdminToken:      s.string("ADMIN_TOKEN", ""),
+		ReadOnly:        s.bool("READ_ONLY", false),
+		SearchFullText:  s.bool("SEARCH_FULLTEXT", false),
+
+		SearchProvider: s.string("SEARCH_PROVIDER", ""),
+		SearchURL:      s.string("SEARCH_URL", ""),
+		SearchAPIKey:   s.string("SEARCH_API_KEY", ""),
+		SearchIndex:    s.string("SEARCH_INDEX", "items"),
+		SearchTimeout:  s.duration("SEARCH_TIMEOUT", 2*time.Second),
+
+		ItemCache:           s.string("ITEM_CACHE", ""),
+		ItemCacheSize:       s.int("ITEM_CACHE_SIZE", 10000),
+		ItemCacheRedisURL:   s.string("ITEM_CACHE_REDIS_URL", ""),
+		ItemCacheTTL:        s.duration("ITEM_CACHE_TTL", time.Minute),
+		ItemCacheListTTL:    s.duration("ITEM_CACHE_LIST_TTL", 30*time.Second),
+		ItemCacheSummaryTTL: s.duration("ITEM_CACHE_SUMMARY_TTL", time.Minute),
+		ItemCacheTimeout:    s.duration("ITEM_CACHE_TIMEOUT", 200*time.Millisecond),
+
+		CircuitBreakerThreshold: s.int("CIRCUIT_BREAKER_THRESHOLD", 5),
+		CircuitBreakerCooldown:  s.duration("CIRCUIT_BREAKER_COOLDOWN", 30*time.Second),
+
+		ItemResponseCacheTTL: s.duration("ITEM_RESPONSE_CACHE_TTL", 5*time.Minute),
+
+		DefaultCategory:            s.string("DEFAULT_CATEGORY", "未分類"),
+		CategoryRequiredAttributes: s.categoryAttributes("CATEGORY_REQUIRED_ATTRIBUTES"),
+		BrandNormalization:         s.bool("BRAND_NORMALIZATION", true),
+		BudgetEnforcement:          s.string("BUDGET_ENFORCEMENT", "warn"),
+
+		QuotaMaxItems:   s.int("QUOTA_MAX_ITEMS", 0),
+		QuotaMaxStorage: s.int64("QUOTA_MAX_STORAGE", 0),
+		QuotaOverrides:  s.quotaOverrides("QUOTA_OVERRIDES"),
+
+		DeletePolicy:          s.string("DELETE_POLICY", "orphan"),
+		OrphanRetention:       s.duration("ORPHAN_RETENTION", 30*24*time.Hour),
+		OrphanCleanupInterval: s.duration("ORPHAN_CLEANUP_INTERVAL", 24*time.Hour),
+		PurgeInterval:         s.duration("PURGE_INTERVAL", time.Hour),
+
+		PublicStatsTTL:  s.duration("PUBLIC_STATS_TTL", 5*time.Minute),
+		PublicRateLimit: s.int("PUBLIC_RATE_LIMIT", 60),
+		TrustedProxies:  s.list("TRUSTED_PROXIES", nil),
+
+		TaxRounding: s.string("TAX_ROUNDING", "down"),
+
+		FXAPIURL:  s.string("FX_API_URL", ""),
+		FXTimeout: s.duration("FX_TIMEOUT", 5*time.Second),
+
+		PriceAPIURL:         s.string("PRICE_API_URL", ""),
+		PriceAPIKey:         s.string("PRICE_API_KEY", ""),
+		PriceAPITimeout:     s.duration("PRICE_API_TIMEOUT", 10*time.Second),
+		PriceAPIMaxAttempts: s.int("PRICE_API_MAX_ATTEMPTS", 3),
+
+		CDNProvider:      s.string("CDN_PROVIDER", ""),
+		CDNAPIToken:      s.string("CDN_API_TOKEN", ""),
+		CDNTimeout:       s.duration("CDN_TIMEOUT", 5*time.Second),
+		FastlyServiceID:  s.string("FASTLY_SERVICE_ID", ""),
+		CloudflareZoneID: s.string("CLOUDFLARE_ZONE_ID", ""),
+
+		ImageStorage:         s.string("IMAGE_STORAGE", "local"),
+		ImageLocalDir:        s.string("IMAGE_LOCAL_DIR", "./uploads"),
+		ImageBaseURL:         s.string("IMAGE_BASE_URL", ""),
+		ImageS3Bucket:        s.string("IMAGE_S3_BUCKET", ""),
+		ImageS3Region:        s.string("IMAGE_S3_REGION", "ap-northeast-1"),
+		ImageMaxSize:         s.int("IMAGE_MAX_SIZE", 5<<20),
+		ImageExportURLExpiry: s.duration("IMAGE_EXPORT_URL_EXPIRY", time.Hour),
+		ImageUploadURLExpiry: s.duration("IMAGE_UPLOAD_URL_EXPIRY", 15*time.Minute),
+
+		LabelFontPath:  s.string("LABEL_FONT_PATH", ""),
+		LabelQRBaseURL: s.string("LABEL_QR_BASE_URL", ""),
+
+		InsuranceTemplatesPath: s.string("INSURANCE_TEMPLATES_PATH", ""),
+
+		WebhookTimeout:          s.duration("WEBHOOK_TIMEOUT", 10*time.Second),
+		WebhookMaxAttempts:      s.int("WEBHOOK_MAX_ATTEMPTS", 8),
+		WebhookRetryBaseDelay:   s.duration("WEBHOOK_RETRY_BASE_DELAY", 30*time.Second),
+		WebhookRetryMaxDelay:    s.duration("WEBHOOK_RETRY_MAX_DELAY", time.Hour),
+		WebhookDeliveryInterval: s.duration("WEBHOOK_DELIVERY_INTERVAL", 10*time.Second),
+
+		OutboxEnabled:        s.bool("OUTBOX_ENABLED", true),
+		OutboxRelayInterval:  s.duration("OUTBOX_RELAY_INTERVAL", time.Second),
+		OutboxRetryBaseDelay: s.duration("OUTBOX_RETRY_BASE_DELAY", 5*time.Second),
+		OutboxRetryMaxDelay:  s.duration("OUTBOX_RETRY_MAX_DELAY", 10*time.Minute),
+		OutboxRetention:      s.duration("OUTBOX_RETENTION", 7*24*time.Hour),
+
+		JobWorkers:        s.int("JOB_WORKERS", 4),
+		JobPollInterval:   s.duration("JOB_POLL_INTERVAL", time.Second),
+		JobMaxAttempts:    s.int("JOB_MAX_ATTEMPTS", 5),
+		JobRetryBaseDelay: s.duration("JOB_RETRY_BASE_DELAY", 10*time.Second),
+		JobRetryMaxDelay:  s.duration("JOB_RETRY_MAX_DELAY", 10*time.Minute),
+		JobLease:          s.duration("JOB_LEASE", 5*time.Minute),
+
+		ValuationRefreshCron: s.string("VALUATION_REFRESH_CRON", ""),
+
+		LLMAPIURL:                 s.string("LLM_API_URL", ""),
+		LLMAPIKey:                 s.string("LLM_API_KEY", ""),
+		LLMModel:                  s.string("LLM_MODEL", "gpt-4o-mini"),
+		LLMTimeout:                s.duration("LLM_TIMEOUT", 30*time.Second),
+		RecategorizeMinConfidence: s.float("RECATEGORIZE_MIN_CONFIDENCE", 0.5),
+
+		OCRProvider: s.string("OCR_PROVIDER", ""),
+		OCRAPIURL:   s.string("OCR_API_URL", ""),
+		OCRAPIKey:   s.string("OCR_API_KEY", ""),
+		OCRTimeout:  s.duration("OCR_TIMEOUT", 30*time.Second),
+
+		ExportURLExpiry: s.duration("EXPORT_URL_EXPIRY", time.Hour),
+
+		UploadDir:             s.string("UPLOAD_DIR", "./uploads-tmp"),
+		UploadMaxSize:         s.int("UPLOAD_MAX_SIZE", 50<<20),
+		UploadTTL:             s.duration("UPLOAD_TTL", 24*time.Hour),
+		UploadCleanupInterval: s.duration("UPLOAD_CLEANUP_INTERVAL", time.Hour),
+		UploadRateLimit:       s.int("UPLOAD_RATE_LIMIT", 120),
+
+		WriteRateLimitPerIP:   s.int("WRITE_RATE_LIMIT_PER_IP", 60),
+		WriteRateLimitPerUser: s.int("WRITE_RATE_LIMIT_PER_USER", 120),
+		RateLimitStore:        s.string("RATE_LIMIT_STORE", "memory"),
+		RateLimitRedisURL:     s.string("RATE_LIMIT_REDIS_URL", ""),
+		RateLimitTimeout:      s.duration("RATE_LIMIT_TIMEOUT", 200*time.Millisecond),
+
+		StatementBudget:     s.int("STATEMENT_BUDGET", 0),
+		StatementBudgetMode: s.string("STATEMENT_BUDGET_MODE", "log"),
+
+		ExplainSampleRate: s.float("EXPLAIN_SAMPLE_RATE", 0),
+
+		ChaosEnabled:     s.bool("CHAOS_ENABLED", false),
+		ChaosLatencyRate: s.float("CHAOS_LATENCY_RATE", 0),
+		ChaosLatency:     s.duration("CHAOS_LATENCY", 2*time.Second),
+		ChaosErrorRate:   s.float("CHAOS_ERROR_RATE", 0),
+		ChaosErrorStatus: s.int("CHAOS_ERROR_STATUS", 503),
+		ChaosDropRate:    s.float("CHAOS_DROP_RATE", 0),
+
+		FaultInjectionEnabled: s.bool("FAULT_INJECTION_ENABLED", false),
+
+		Sandbox:        s.bool("SANDBOX", false),
+		SandboxResetAt: s.string("SANDBOX_RESET_AT", "03:00"),
+	}
 
-	DBUser = os.Getenv("DB_USER")
-	DBPassword = os.Getenv("DB_PASSWORD")
-	DBHost = os.Getenv("DB_HOST")
-	DBPort = os.Getenv("DB_PORT")
-	DBName = os.Getenv("DB_NAME")
+	if err := s.err(); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
 }
 
-// DB接続文字列を返す
-func GetDSN() string {
+// DB接続文字列を返す（mysql の場合）
+func (c *Config) DSN() string {
 	return fmt.Sprintf(
 		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&collation=utf8mb4_unicode_ci&parseTime=true&loc=Local&sql_mode=TRADITIONAL",
-		DBUser, DBPassword, DBHost, DBPort, DBName,
+		c.DBUser, c.DBPassword, c.DBHost, c.DBPort, c.DBName,
 	)
 }
+
+// 本番環境かどうか
+func (c *Config) IsProduction() bool {
+	return c.AppEnv == "production"
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envOf(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func requiredEnv() map[string]string {
+	return map[string]string{"DB_USER": "root", "DB_HOST": "db", "DB_NAME": "items"}
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("正常系: 未設定の項目は既定値になる", func(t *testing.T) {
+		cfg, err := load("", envOf(requiredEnv()))
+		require.NoError(t, err)
+
+		assert.Equal(t, 8080, cfg.Port)
+		assert.Equal(t, "3306", cfg.DBPort)
+		assert.Equal(t, 10*time.Second, cfg.ShutdownTimeout)
+		assert.Equal(t, "未分類", cfg.DefaultCategory)
+		assert.True(t, cfg.BrandNormalization)
+	})
+
+	t.Run("正常系: 環境変数は設定ファイルより優先する", func(t *testing.T) {
+		path := writeConfigFile(t, "port: 9000\ndb_host: file-db\ndb_max_open_conns: 50\nshutdown_timeout: 30s\nread_only: true\n")
+		env := requiredEnv()
+		env["DB_HOST"] = "env-db"
+		env["SHUTDOWN_TIMEOUT"] = ""
+
+		cfg, err := load(path, envOf(env))
+		require.NoError(t, err)
+
+		assert.Equal(t, 9000, cfg.Port)
+		assert.Equal(t, "env-db", cfg.DBHost)
+		assert.Equal(t, 50, cfg.DBMaxOpenConns)
+		assert.Equal(t, 30*time.Second, cfg.ShutdownTimeout)
+		assert.True(t, cfg.ReadOnly)
+	})
+
+	t.Run("正常系: カテゴリーの必須属性と利用上限の上書き", func(t *testing.T) {
+		env := requiredEnv()
+		env["CATEGORY_REQUIRED_ATTRIBUTES"] = "時計:reference_number, 時計:material"
+		env["QUOTA_OVERRIDES"] = "user-1:1000:1073741824,premium:0:0"
+
+		cfg, err := load("", envOf(env))
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string][]string{"時計": {"reference_number", "material"}}, cfg.CategoryRequiredAttributes)
+		assert.Equal(t, QuotaLimit{MaxItems: 1000, MaxStorage: 1073741824}, cfg.QuotaOverrides["user-1"])
+		assert.Equal(t, QuotaLimit{}, cfg.QuotaOverrides["premium"])
+	})
+
+	t.Run("異常系: 読み取れない値と不正な値をまとめて返す", func(t *testing.T) {
+		env := requiredEnv()
+		env["PORT"] = "http"
+		env["FX_TIMEOUT"] = "5"
+		env["BUDGET_ENFORCEMENT"] = "strict"
+		env["QUOTA_OVERRIDES"] = "user-1:many:0"
+
+		_, err := load("", envOf(env))
+		require.Error(t, err)
+
+		assert.Contains(t, err.Error(), `PORT: "http" is not a valid integer`)
+		assert.Contains(t, err.Error(), `FX_TIMEOUT: "5" is not a valid duration`)
+		assert.Contains(t, err.Error(), `QUOTA_OVERRIDES: "user-1:many:0"`)
+	})
+
+	t.Run("異常系: 検証エラー", func(t *testing.T) {
+		env := requiredEnv()
+		delete(env, "DB_HOST")
+		env["BUDGET_ENFORCEMENT"] = "strict"
+		env["IMAGE_STORAGE"] = "s3"
+		env["CHAOS_DROP_RATE"] = "1.5"
+		env["RATE_LIMIT_STORE"] = "redis"
+		env["VALUATION_REFRESH_CRON"] = "0 25 * * *"
+		env["CIRCUIT_BREAKER_THRESHOLD"] = "-1"
+		env["ITEM_CACHE_TIMEOUT"] = "0s"
+		env["RECATEGORIZE_MIN_CONFIDENCE"] = "80"
+		env["OCR_PROVIDER"] = "google-vision"
+		env["TRUSTED_PROXIES"] = "10.0.0.0/8,proxy"
+
+		_, err := load("", envOf(env))
+		require.Error(t, err)
+
+		assert.Contains(t, err.Error(), "DB_HOST: is required")
+		assert.Contains(t, err.Error(), `BUDGET_ENFORCEMENT: must be warn or block, got "strict"`)
+		assert.Contains(t, err.Error(), "IMAGE_S3_BUCKET is required when IMAGE_STORAGE=s3")
+		assert.Contains(t, err.Error(), "CHAOS_DROP_RATE: must be between 0 and 1")
+		assert.Contains(t, err.Error(), "RATE_LIMIT_REDIS_URL is required when RATE_LIMIT_STORE=redis")
+		assert.Contains(t, err.Error(), `VALUATION_REFRESH_CRON: hour: value 25 out of range 0-23, got "0 25 * * *"`)
+		assert.Contains(t, err.Error(), "PRICE_API_URL is required when VALUATION_REFRESH_CRON is set")
+		assert.Contains(t, err.Error(), "CIRCUIT_BREAKER_THRESHOLD: must not be negative, got -1")
+		assert.Contains(t, err.Error(), "ITEM_CACHE_TIMEOUT: must be positive, got 0s")
+		assert.Contains(t, err.Error(), "RECATEGORIZE_MIN_CONFIDENCE: must be between 0 and 1, got 80")
+		assert.Contains(t, err.Error(), "OCR_API_KEY is required when OCR_PROVIDER=google-vision")
+		assert.Contains(t, err.Error(), `TRUSTED_PROXIES: "proxy" is not a valid CIDR`)
+	})
+
+	t.Run("正常系: SQLite の場合は MySQL の接続先は不要", func(t *testing.T) {
+		cfg, err := load("", envOf(map[string]string{"DB_DRIVER": "sqlite"}))
+		require.NoError(t, err)
+
+		assert.Equal(t, "sqlite", cfg.DBDriver)
+		assert.Equal(t, "./data/items.db", cfg.DBPath)
+	})
+
+	t.Run("異常系: 不明なデータベースの種類", func(t *testing.T) {
+		env := requiredEnv()
+		env["DB_DRIVER"] = "postgres"
+
+		_, err := load("", envOf(env))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `DB_DRIVER: must be mysql or sqlite, got "postgres"`)
+	})
+
+	t.Run("異常系: 本番環境・外部のキャッシュと併用するサンドボックス", func(t *testing.T) {
+		env := requiredEnv()
+		env["SANDBOX"] = "true"
+		env["APP_ENV"] = "production"
+		env["ITEM_CACHE"] = "memory"
+		env["SANDBOX_RESET_AT"] = "3am"
+
+		_, err := load("", envOf(env))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SANDBOX: must not be enabled when APP_ENV=production")
+		assert.Contains(t, err.Error(), `ITEM_CACHE: must be empty when SANDBOX=true, got "memory"`)
+		assert.Contains(t, err.Error(), `SANDBOX_RESET_AT: must be a time of day (HH:MM), got "3am"`)
+	})
+
+	t.Run("異常系: 設定ファイルの未知のキー", func(t *testing.T) {
+		path := writeConfigFile(t, "db_hots: db\n")
+
+		_, err := load(path, envOf(requiredEnv()))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown key db_hots")
+	})
+
+	t.Run("異常系: 設定ファイルが存在しない", func(t *testing.T) {
+		_, err := load(filepath.Join(t.TempDir(), "missing.yaml"), envOf(requiredEnv()))
+		assert.Error(t, err)
+	})
+}
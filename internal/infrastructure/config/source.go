@@ -0,0 +1,223 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 設定値の読み込み元（環境変数を優先し、なければ設定ファイルの値を使う）
+// 読み取れない値はエラーとして記録し、最後にまとめて返す
+type source struct {
+	lookupEnv func(string) (string, bool)
+	file      map[string]string
+	path      string
+	used      map[string]bool
+	errs      []error
+}
+
+// 設定ファイル（YAML）のキーは環境変数名の小文字（db_host など）とする
+func newSource(path string, lookupEnv func(string) (string, bool)) (*source, error) {
+	s := &source{
+		lookupEnv: lookupEnv,
+		file:      make(map[string]string),
+		path:      path,
+		used:      make(map[string]bool),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	for key, value := range values {
+		switch value.(type) {
+		case nil:
+			continue
+		case map[string]interface{}, []interface{}:
+			return nil, fmt.Errorf("config file %s: %s must be a single value", path, key)
+		}
+		s.file[strings.ToUpper(key)] = fmt.Sprint(value)
+	}
+
+	return s, nil
+}
+
+// 値を返す（空の場合は未設定とみなす）
+func (s *source) lookup(key string) (string, bool) {
+	s.used[key] = true
+	if value, ok := s.lookupEnv(key); ok && value != "" {
+		return value, true
+	}
+	if value, ok := s.file[key]; ok && value != "" {
+		return value, true
+	}
+	return "", false
+}
+
+func (s *source) invalid(key, value, expected string) {
+	s.errs = append(s.errs, fmt.Errorf("%s: %q is not a valid %s", key, value, expected))
+}
+
+func (s *source) string(key, defaultValue string) string {
+	if value, ok := s.lookup(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+func (s *source) bool(key string, defaultValue bool) bool {
+	value, ok := s.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		s.invalid(key, value, "boolean")
+		return defaultValue
+	}
+	return parsed
+}
+
+func (s *source) int(key string, defaultValue int) int {
+	value, ok := s.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		s.invalid(key, value, "integer")
+		return defaultValue
+	}
+	return parsed
+}
+
+func (s *source) int64(key string, defaultValue int64) int64 {
+	value, ok := s.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		s.invalid(key, value, "integer")
+		return defaultValue
+	}
+	return parsed
+}
+
+func (s *source) float(key string, defaultValue float64) float64 {
+	value, ok := s.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		s.invalid(key, value, "number")
+		return defaultValue
+	}
+	return parsed
+}
+
+func (s *source) duration(key string, defaultValue time.Duration) time.Duration {
+	value, ok := s.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		s.invalid(key, value, "duration (e.g. 30s, 5m)")
+		return defaultValue
+	}
+	return parsed
+}
+
+// カンマ区切りの設定を読み取る（未設定の場合は defaultValue）
+func (s *source) list(key string, defaultValue []string) []string {
+	value, ok := s.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+	var result []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// "カテゴリー:属性,カテゴリー:属性" 形式の設定を読み取る
+func (s *source) categoryAttributes(key string) map[string][]string {
+	result := make(map[string][]string)
+	value, _ := s.lookup(key)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		category, attribute, ok := strings.Cut(pair, ":")
+		if !ok || category == "" || attribute == "" {
+			s.invalid(key, pair, "category:attribute entry")
+			continue
+		}
+		result[category] = append(result[category], attribute)
+	}
+	return result
+}
+
+// "操作者:アイテム数:写真の合計サイズ,..." 形式の設定を読み取る
+func (s *source) quotaOverrides(key string) map[string]QuotaLimit {
+	result := make(map[string]QuotaLimit)
+	value, _ := s.lookup(key)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 || parts[0] == "" {
+			s.invalid(key, entry, "actor:items:bytes entry")
+			continue
+		}
+		maxItems, err := strconv.Atoi(parts[1])
+		if err != nil || maxItems < 0 {
+			s.invalid(key, entry, "actor:items:bytes entry")
+			continue
+		}
+		maxStorage, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil || maxStorage < 0 {
+			s.invalid(key, entry, "actor:items:bytes entry")
+			continue
+		}
+		result[parts[0]] = QuotaLimit{MaxItems: maxItems, MaxStorage: maxStorage}
+	}
+	return result
+}
+
+// 読み取れなかった値と、設定ファイルの未知のキー（書き間違い）をまとめたエラー
+func (s *source) err() error {
+	errs := s.errs
+	var unknown []string
+	for key := range s.file {
+		if !s.used[key] {
+			unknown = append(unknown, strings.ToLower(key))
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		errs = append(errs, fmt.Errorf("config file %s: unknown key %s", s.path, key))
+	}
+	return errors.Join(errs...)
+}
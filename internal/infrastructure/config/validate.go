@@ -0,0 +1,302 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+var logLevels = []string{"debug", "info", "warn", "error", "off"}
+
+// 設定の組み合わせと範囲を検証する（起動時に不正な設定を検出するため、問題をすべてまとめて返す）
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if c.Port < 1 || c.Port > 65535 {
+		add("PORT: must be between 1 and 65535, got %d", c.Port)
+	}
+	if !contains(logLevels, c.LogLevel) {
+		add("LOG_LEVEL: must be one of %v, got %q", logLevels, c.LogLevel)
+	}
+
+	switch c.DBDriver {
+	case "mysql":
+		for key, value := range map[string]string{"DB_USER": c.DBUser, "DB_HOST": c.DBHost, "DB_NAME": c.DBName} {
+			if value == "" {
+				add("%s: is required", key)
+			}
+		}
+	case "sqlite":
+		if c.DBPath == "" {
+			add("DB_PATH: is required when DB_DRIVER=sqlite")
+		}
+	default:
+		add("DB_DRIVER: must be mysql or sqlite, got %q", c.DBDriver)
+	}
+	if c.DBMaxOpenConns < 0 {
+		add("DB_MAX_OPEN_CONNS: must not be negative, got %d", c.DBMaxOpenConns)
+	}
+	if c.DBMaxIdleConns < 0 {
+		add("DB_MAX_IDLE_CONNS: must not be negative, got %d", c.DBMaxIdleConns)
+	} else if c.DBMaxOpenConns > 0 && c.DBMaxIdleConns > c.DBMaxOpenConns {
+		add("DB_MAX_IDLE_CONNS: must not exceed DB_MAX_OPEN_CONNS (%d), got %d", c.DBMaxOpenConns, c.DBMaxIdleConns)
+	}
+
+	if !entity.IsValidCategory(c.DefaultCategory) {
+		add("DEFAULT_CATEGORY: %q is not a valid category", c.DefaultCategory)
+	}
+	for category := range c.CategoryRequiredAttributes {
+		if !entity.IsValidCategory(category) {
+			add("CATEGORY_REQUIRED_ATTRIBUTES: %q is not a valid category", category)
+		}
+	}
+	for _, cidr := range c.TrustedProxies {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			add("TRUSTED_PROXIES: %q is not a valid CIDR", cidr)
+		}
+	}
+	if !usecase.BudgetEnforcement(c.BudgetEnforcement).IsValid() {
+		add("BUDGET_ENFORCEMENT: must be warn or block, got %q", c.BudgetEnforcement)
+	}
+	if !usecase.DeletePolicy(c.DeletePolicy).IsValid() {
+		add("DELETE_POLICY: must be cascade, orphan or block, got %q", c.DeletePolicy)
+	}
+	if !entity.RoundingMode(c.TaxRounding).IsValid() {
+		add("TAX_ROUNDING: must be down, half_up or up, got %q", c.TaxRounding)
+	}
+	if c.QuotaMaxItems < 0 {
+		add("QUOTA_MAX_ITEMS: must not be negative, got %d", c.QuotaMaxItems)
+	}
+	if c.QuotaMaxStorage < 0 {
+		add("QUOTA_MAX_STORAGE: must not be negative, got %d", c.QuotaMaxStorage)
+	}
+
+	// 0 を指定できない（0 で処理が止まる・すべてが期限切れになる）時間
+	for key, value := range map[string]time.Duration{
+		"SHUTDOWN_TIMEOUT":         c.ShutdownTimeout,
+		"FX_TIMEOUT":               c.FXTimeout,
+		"PRICE_API_TIMEOUT":        c.PriceAPITimeout,
+		"LLM_TIMEOUT":              c.LLMTimeout,
+		"OCR_TIMEOUT":              c.OCRTimeout,
+		"CDN_TIMEOUT":              c.CDNTimeout,
+		"SEARCH_TIMEOUT":           c.SearchTimeout,
+		"ITEM_CACHE_TIMEOUT":       c.ItemCacheTimeout,
+		"RATE_LIMIT_TIMEOUT":       c.RateLimitTimeout,
+		"CIRCUIT_BREAKER_COOLDOWN": c.CircuitBreakerCooldown,
+		"PUBLIC_STATS_TTL":         c.PublicStatsTTL,
+		"IMAGE_EXPORT_URL_EXPIRY":  c.ImageExportURLExpiry,
+		"IMAGE_UPLOAD_URL_EXPIRY":  c.ImageUploadURLExpiry,
+		"UPLOAD_TTL":               c.UploadTTL,
+		"WEBHOOK_TIMEOUT":          c.WebhookTimeout,
+		"WEBHOOK_RETRY_BASE_DELAY": c.WebhookRetryBaseDelay,
+		"WEBHOOK_RETRY_MAX_DELAY":  c.WebhookRetryMaxDelay,
+		"OUTBOX_RELAY_INTERVAL":    c.OutboxRelayInterval,
+		"OUTBOX_RETRY_BASE_DELAY":  c.OutboxRetryBaseDelay,
+		"OUTBOX_RETRY_MAX_DELAY":   c.OutboxRetryMaxDelay,
+		"JOB_POLL_INTERVAL":        c.JobPollInterval,
+		"JOB_RETRY_BASE_DELAY":     c.JobRetryBaseDelay,
+		"JOB_RETRY_MAX_DELAY":      c.JobRetryMaxDelay,
+		"JOB_LEASE":                c.JobLease,
+		"EXPORT_URL_EXPIRY":        c.ExportURLExpiry,
+	} {
+		if value <= 0 {
+			add("%s: must be positive, got %s", key, value)
+		}
+	}
+	// 0 で無効・無制限になる時間
+	for key, value := range map[string]time.Duration{
+		"DB_CONN_MAX_LIFETIME":      c.DBConnMaxLifetime,
+		"ORPHAN_RETENTION":          c.OrphanRetention,
+		"ORPHAN_CLEANUP_INTERVAL":   c.OrphanCleanupInterval,
+		"PURGE_INTERVAL":            c.PurgeInterval,
+		"UPLOAD_CLEANUP_INTERVAL":   c.UploadCleanupInterval,
+		"WEBHOOK_DELIVERY_INTERVAL": c.WebhookDeliveryInterval,
+		"OUTBOX_RETENTION":          c.OutboxRetention,
+		"CHAOS_LATENCY":             c.ChaosLatency,
+		"ITEM_CACHE_TTL":            c.ItemCacheTTL,
+		"ITEM_CACHE_LIST_TTL":       c.ItemCacheListTTL,
+		"ITEM_CACHE_SUMMARY_TTL":    c.ItemCacheSummaryTTL,
+		"ITEM_RESPONSE_CACHE_TTL":   c.ItemResponseCacheTTL,
+	} {
+		if value < 0 {
+			add("%s: must not be negative, got %s", key, value)
+		}
+	}
+
+	if c.PublicRateLimit <= 0 {
+		add("PUBLIC_RATE_LIMIT: must be positive, got %d", c.PublicRateLimit)
+	}
+	if c.UploadRateLimit < 0 {
+		add("UPLOAD_RATE_LIMIT: must not be negative, got %d", c.UploadRateLimit)
+	}
+	if c.WriteRateLimitPerIP < 0 {
+		add("WRITE_RATE_LIMIT_PER_IP: must not be negative, got %d", c.WriteRateLimitPerIP)
+	}
+	if c.WriteRateLimitPerUser < 0 {
+		add("WRITE_RATE_LIMIT_PER_USER: must not be negative, got %d", c.WriteRateLimitPerUser)
+	}
+	if c.PriceAPIMaxAttempts < 1 {
+		add("PRICE_API_MAX_ATTEMPTS: must be at least 1, got %d", c.PriceAPIMaxAttempts)
+	}
+	if c.WebhookMaxAttempts < 1 {
+		add("WEBHOOK_MAX_ATTEMPTS: must be at least 1, got %d", c.WebhookMaxAttempts)
+	}
+	if c.CircuitBreakerThreshold < 0 {
+		add("CIRCUIT_BREAKER_THRESHOLD: must not be negative, got %d", c.CircuitBreakerThreshold)
+	}
+	if c.JobWorkers < 0 {
+		add("JOB_WORKERS: must not be negative, got %d", c.JobWorkers)
+	}
+	if c.JobMaxAttempts < 1 {
+		add("JOB_MAX_ATTEMPTS: must be at least 1, got %d", c.JobMaxAttempts)
+	}
+	if c.ValuationRefreshCron != "" {
+		if _, err := entity.ParseCronSchedule(c.ValuationRefreshCron); err != nil {
+			add("VALUATION_REFRESH_CRON: %s, got %q", err.Error(), c.ValuationRefreshCron)
+		}
+		if c.PriceAPIURL == "" {
+			add("VALUATION_REFRESH_CRON: PRICE_API_URL is required when VALUATION_REFRESH_CRON is set")
+		}
+	}
+
+	if c.LLMAPIURL != "" && c.LLMModel == "" {
+		add("LLM_MODEL: must not be empty when LLM_API_URL is set")
+	}
+
+	switch c.OCRProvider {
+	case "":
+	case "http":
+		if c.OCRAPIURL == "" {
+			add("OCR_PROVIDER: OCR_API_URL is required when OCR_PROVIDER=http")
+		}
+	case "google-vision":
+		if c.OCRAPIKey == "" {
+			add("OCR_PROVIDER: OCR_API_KEY is required when OCR_PROVIDER=google-vision")
+		}
+	default:
+		add("OCR_PROVIDER: must be empty, http or google-vision, got %q", c.OCRProvider)
+	}
+
+	switch c.CDNProvider {
+	case "":
+	case "fastly":
+		if c.FastlyServiceID == "" || c.CDNAPIToken == "" {
+			add("CDN_PROVIDER: FASTLY_SERVICE_ID and CDN_API_TOKEN are required when CDN_PROVIDER=fastly")
+		}
+	case "cloudflare":
+		if c.CloudflareZoneID == "" || c.CDNAPIToken == "" {
+			add("CDN_PROVIDER: CLOUDFLARE_ZONE_ID and CDN_API_TOKEN are required when CDN_PROVIDER=cloudflare")
+		}
+	default:
+		add("CDN_PROVIDER: must be empty, fastly or cloudflare, got %q", c.CDNProvider)
+	}
+
+	switch c.SearchProvider {
+	case "":
+	case "meilisearch":
+		if c.SearchURL == "" || c.SearchIndex == "" {
+			add("SEARCH_PROVIDER: SEARCH_URL and SEARCH_INDEX are required when SEARCH_PROVIDER=meilisearch")
+		}
+	default:
+		add("SEARCH_PROVIDER: must be empty or meilisearch, got %q", c.SearchProvider)
+	}
+
+	switch c.ItemCache {
+	case "":
+	case "memory":
+		if c.ItemCacheSize <= 0 {
+			add("ITEM_CACHE_SIZE: must be positive, got %d", c.ItemCacheSize)
+		}
+	case "redis":
+		if c.ItemCacheRedisURL == "" {
+			add("ITEM_CACHE: ITEM_CACHE_REDIS_URL is required when ITEM_CACHE=redis")
+		}
+	default:
+		add("ITEM_CACHE: must be empty, memory or redis, got %q", c.ItemCache)
+	}
+
+	switch c.RateLimitStore {
+	case "memory":
+	case "redis":
+		if c.RateLimitRedisURL == "" {
+			add("RATE_LIMIT_STORE: RATE_LIMIT_REDIS_URL is required when RATE_LIMIT_STORE=redis")
+		}
+	default:
+		add("RATE_LIMIT_STORE: must be memory or redis, got %q", c.RateLimitStore)
+	}
+
+	switch c.ImageStorage {
+	case "local":
+	case "s3":
+		if c.ImageS3Bucket == "" {
+			add("IMAGE_STORAGE: IMAGE_S3_BUCKET is required when IMAGE_STORAGE=s3")
+		}
+	default:
+		add("IMAGE_STORAGE: must be local or s3, got %q", c.ImageStorage)
+	}
+	if c.ImageMaxSize <= 0 {
+		add("IMAGE_MAX_SIZE: must be positive, got %d", c.ImageMaxSize)
+	}
+	if c.UploadMaxSize <= 0 {
+		add("UPLOAD_MAX_SIZE: must be positive, got %d", c.UploadMaxSize)
+	}
+
+	if c.StatementBudget < 0 {
+		add("STATEMENT_BUDGET: must not be negative, got %d", c.StatementBudget)
+	}
+	if c.StatementBudgetMode != "log" && c.StatementBudgetMode != "block" {
+		add("STATEMENT_BUDGET_MODE: must be log or block, got %q", c.StatementBudgetMode)
+	}
+
+	for key, value := range map[string]float64{
+		"CHAOS_LATENCY_RATE":          c.ChaosLatencyRate,
+		"CHAOS_ERROR_RATE":            c.ChaosErrorRate,
+		"CHAOS_DROP_RATE":             c.ChaosDropRate,
+		"EXPLAIN_SAMPLE_RATE":         c.ExplainSampleRate,
+		"RECATEGORIZE_MIN_CONFIDENCE": c.RecategorizeMinConfidence,
+	} {
+		if value < 0 || value > 1 {
+			add("%s: must be between 0 and 1, got %g", key, value)
+		}
+	}
+	if c.ChaosErrorStatus < 400 || c.ChaosErrorStatus > 599 {
+		add("CHAOS_ERROR_STATUS: must be an HTTP error status (400-599), got %d", c.ChaosErrorStatus)
+	}
+
+	// サンドボックスのリセットは本番のデータを消すため、本番環境では起動しない
+	// リセットで消えたアイテムがキャッシュ・CDN・検索エンジンに残らないよう、外部の保存先は使えない
+	if c.Sandbox {
+		if c.IsProduction() {
+			add("SANDBOX: must not be enabled when APP_ENV=production")
+		}
+		for key, value := range map[string]string{"ITEM_CACHE": c.ItemCache, "CDN_PROVIDER": c.CDNProvider, "SEARCH_PROVIDER": c.SearchProvider} {
+			if value != "" {
+				add("%s: must be empty when SANDBOX=true, got %q", key, value)
+			}
+		}
+		if _, err := time.Parse("15:04", c.SandboxResetAt); err != nil {
+			add("SANDBOX_RESET_AT: must be a time of day (HH:MM), got %q", c.SandboxResetAt)
+		}
+	}
+
+	// 項目の順に並べる（map の走査順に依存しないように）
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errors.Join(errs...)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
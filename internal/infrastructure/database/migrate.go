@@ -0,0 +1,278 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// 適用済みのマイグレーションを記録するテーブル
+const migrationsTable = "schema_migrations"
+
+// 複数のサーバーが同時に起動した場合に、マイグレーションを1つずつ実行するためのロック名と待ち時間
+const (
+	migrationLockName    = "schema_migrations"
+	migrationLockTimeout = 60 // 秒
+)
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// スキーマの変更1つ分（バージョンの順に適用する）
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// マイグレーションと適用日時（未適用の場合は nil）
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// {バージョン}_{名前}.up.sql / .down.sql 形式のファイルを読み込む（バージョンの順）
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		matches := migrationFileName.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(matches[1])
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		} else if m.Name != matches[2] {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, m.Name, matches[2])
+		}
+		if matches[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" {
+			return nil, fmt.Errorf("migration %04d_%s has no up migration", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// マイグレーションを実行する
+// MySQL の DDL はトランザクションで戻せないため、失敗したマイグレーションは記録せず、
+// 再実行できるよう各ファイルは IF NOT EXISTS などで冪等に書く
+type Migrator struct {
+	conn       *sql.DB
+	dialect    database.Dialect
+	migrations []Migration
+}
+
+func NewMigrator(conn *sql.DB, dialect database.Dialect, fsys fs.FS) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{conn: conn, dialect: dialect, migrations: migrations}, nil
+}
+
+// 未適用のマイグレーションをすべて適用し、適用したマイグレーションを返す
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			if err := execMigration(ctx, conn, migration.Up); err != nil {
+				return fmt.Errorf("migration %04d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			if _, err := conn.ExecContext(ctx, "INSERT INTO "+migrationsTable+" (version, name) VALUES (?, ?)", migration.Version, migration.Name); err != nil {
+				return fmt.Errorf("failed to record migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// 適用済みのマイグレーションを新しい順に steps 個戻し、戻したマイグレーションを返す
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if strings.TrimSpace(migration.Down) == "" {
+				return fmt.Errorf("migration %04d_%s cannot be reverted (no down migration)", migration.Version, migration.Name)
+			}
+			if err := execMigration(ctx, conn, migration.Down); err != nil {
+				return fmt.Errorf("reverting migration %04d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			if _, err := conn.ExecContext(ctx, "DELETE FROM "+migrationsTable+" WHERE version = ?", migration.Version); err != nil {
+				return fmt.Errorf("failed to record reverting migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// 適用済みのマイグレーションをすべて戻してから適用し直す（サンドボックスのリセット用、すべてのデータが消える）
+func (m *Migrator) Reset(ctx context.Context) error {
+	if _, err := m.Down(ctx, len(m.migrations)); err != nil {
+		return err
+	}
+	_, err := m.Up(ctx)
+	return err
+}
+
+// すべてのマイグレーションと適用状況（バージョンの順）
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Migration: migration}
+			if appliedAt, ok := done[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// 1つの接続でロックを取得して fn を実行する（GET_LOCK は接続ごとのため）
+// SQLite は1つのプロセスから使うローカル用のため、ロックを取らない
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.conn.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer conn.Close()
+
+	if m.dialect == database.SQLite {
+		return fn(conn)
+	}
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", migrationLockName, migrationLockTimeout).Scan(&locked); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	if locked.Int64 != 1 {
+		return errors.New("failed to acquire migration lock: another migration is running")
+	}
+	defer func() {
+		if _, releaseErr := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", migrationLockName); releaseErr != nil && err == nil {
+			err = fmt.Errorf("failed to release migration lock: %w", releaseErr)
+		}
+	}()
+
+	return fn(conn)
+}
+
+// 適用済みのバージョンと適用日時（記録用のテーブルがなければ作成する）
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]time.Time, error) {
+	statement := `CREATE TABLE IF NOT EXISTS ` + migrationsTable + ` (
+    version INT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+	if m.dialect != database.SQLite {
+		statement += ` ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Applied schema migrations'`
+	}
+	if _, err := conn.ExecContext(ctx, statement); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", migrationsTable, err)
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM "+migrationsTable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", migrationsTable, err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", migrationsTable, err)
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+func execMigration(ctx context.Context, conn *sql.Conn, script string) error {
+	for _, statement := range splitStatements(script) {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SQLファイルを文に分割する（行末の ; を文の区切りとし、-- で始まる行は除く）
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSuffix(strings.TrimSpace(current.String()), ";"))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
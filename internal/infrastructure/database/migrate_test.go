@@ -0,0 +1,135 @@
+package databaseInfra
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/infrastructure/config"
+	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/sql/migrations"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("正常系: バージョンの順に up と down を組にする", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0002_add_tags.up.sql":     {Data: []byte("CREATE TABLE tags (id INT);")},
+			"0002_add_tags.down.sql":   {Data: []byte("DROP TABLE tags;")},
+			"0001_create_items.up.sql": {Data: []byte("CREATE TABLE items (id INT);")},
+			"migrations.go":            {Data: []byte("package migrations")},
+		}
+
+		loaded, err := LoadMigrations(fsys)
+		require.NoError(t, err)
+
+		require.Len(t, loaded, 2)
+		assert.Equal(t, Migration{Version: 1, Name: "create_items", Up: "CREATE TABLE items (id INT);"}, loaded[0])
+		assert.Equal(t, Migration{Version: 2, Name: "add_tags", Up: "CREATE TABLE tags (id INT);", Down: "DROP TABLE tags;"}, loaded[1])
+	})
+
+	t.Run("異常系: 同じバージョンの別のマイグレーション", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0001_create_items.up.sql": {Data: []byte("CREATE TABLE items (id INT);")},
+			"0001_create_tags.up.sql":  {Data: []byte("CREATE TABLE tags (id INT);")},
+		}
+
+		_, err := LoadMigrations(fsys)
+		assert.Error(t, err)
+	})
+
+	t.Run("異常系: up のないマイグレーション", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0001_create_items.down.sql": {Data: []byte("DROP TABLE items;")},
+		}
+
+		_, err := LoadMigrations(fsys)
+		assert.Error(t, err)
+	})
+
+	t.Run("正常系: 埋め込んだマイグレーションは連番で、すべて戻せる", func(t *testing.T) {
+		loaded, err := LoadMigrations(migrations.FS)
+		require.NoError(t, err)
+
+		require.NotEmpty(t, loaded)
+		for i, migration := range loaded {
+			assert.Equal(t, i+1, migration.Version)
+			assert.NotEmpty(t, splitStatements(migration.Up), migration.Name)
+			assert.NotEmpty(t, splitStatements(migration.Down), migration.Name)
+		}
+	})
+
+	t.Run("正常系: SQLite 用のマイグレーションは MySQL 用と同じバージョン", func(t *testing.T) {
+		mysql, err := LoadMigrations(migrations.FS)
+		require.NoError(t, err)
+		sqlite, err := LoadMigrations(migrations.SQLiteFS)
+		require.NoError(t, err)
+
+		require.Len(t, sqlite, len(mysql))
+		for i := range mysql {
+			assert.Equal(t, mysql[i].Version, sqlite[i].Version)
+			assert.Equal(t, mysql[i].Name, sqlite[i].Name)
+		}
+	})
+}
+
+func TestMigrator_SQLite(t *testing.T) {
+	ctx := context.Background()
+	conn, err := OpenDB(&config.Config{DBDriver: "sqlite", DBPath: filepath.Join(t.TempDir(), "items.db"), DBMaxOpenConns: 1})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	migrator, err := NewMigrator(conn, database.SQLite, Migrations(database.SQLite))
+	require.NoError(t, err)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(migrator.migrations))
+
+	var count int
+	require.NoError(t, conn.QueryRow("SELECT COUNT(*) FROM items").Scan(&count))
+	assert.Equal(t, 5, count)
+
+	// 適用済みの場合は何もしない
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	// リセットで登録したデータが消え、サンプルデータの状態に戻る
+	_, err = conn.Exec("INSERT INTO items (name, category, brand, purchase_price, purchase_date) VALUES ('追加', '時計', 'ROLEX', 1000, '2024-01-01')")
+	require.NoError(t, err)
+	require.NoError(t, migrator.Reset(ctx))
+	require.NoError(t, conn.QueryRow("SELECT COUNT(*) FROM items").Scan(&count))
+	assert.Equal(t, 5, count)
+
+	reverted, err := migrator.Down(ctx, len(migrator.migrations))
+	require.NoError(t, err)
+	assert.Len(t, reverted, len(migrator.migrations))
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.Nil(t, status.AppliedAt, status.Name)
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- コメント
+SET NAMES utf8mb4;
+
+CREATE TABLE items (
+    id BIGINT PRIMARY KEY,
+    -- 列のコメント
+    name VARCHAR(100) NOT NULL COMMENT 'a;b'
+);
+INSERT INTO items (id, name) VALUES (1, 'x')`
+
+	assert.Equal(t, []string{
+		"SET NAMES utf8mb4",
+		"CREATE TABLE items (\n    id BIGINT PRIMARY KEY,\n    name VARCHAR(100) NOT NULL COMMENT 'a;b'\n)",
+		"INSERT INTO items (id, name) VALUES (1, 'x')",
+	}, splitStatements(script))
+}
@@ -4,110 +4,230 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"io/fs"
+	"net/url"
 	"os"
+	"path/filepath"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+	_ "modernc.org/sqlite"
 
 	"Aicon-assignment/internal/infrastructure/config"
 	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/sql/migrations"
 )
 
-type MySqlHandler struct {
-	Conn *sql.DB
+// database/sql の接続で SqlHandler を実装する（MySQL / SQLite）
+type DBHandler struct {
+	Conn    *sql.DB
+	dialect database.Dialect
 }
 
-func NewSqlHandler() database.SqlHandler {
-	dsn := config.GetDSN()
-	conn, err := sql.Open("mysql", dsn)
+// トランザクションをコンテキストに保持するためのキー
+type txKey struct{}
+
+// SQLを実行する対象（トランザクション内であればトランザクション）
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// SQLite に保存する日時の形式（CURRENT_TIMESTAMP と同じ UTC の形式にして、文字列のまま比較できるようにする）
+const sqliteTimeFormat = "2006-01-02 15:04:05"
+
+func NewSqlHandler(cfg *config.Config) database.SqlHandler {
+	conn, err := OpenDB(cfg)
 	if err != nil {
-		panic(fmt.Sprintf("❌ Failed to connect to database: %v", err))
+		panic(fmt.Sprintf("❌ %v", err))
+	}
+
+	fmt.Println("✅ Successfully connected to the database!")
+
+	dialect := database.Dialect(cfg.DBDriver)
+
+	// 起動時に未適用のマイグレーションを適用する（スキーマとコードの不一致で動かさないよう、失敗した場合は起動しない）
+	if cfg.AutoMigrate {
+		migrator, err := NewMigrator(conn, dialect, Migrations(dialect))
+		if err != nil {
+			panic(fmt.Sprintf("❌ Failed to load migrations: %v", err))
+		}
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			panic(fmt.Sprintf("❌ Failed to migrate database: %v", err))
+		}
+		for _, migration := range applied {
+			fmt.Printf("✅ Applied migration %04d_%s\n", migration.Version, migration.Name)
+		}
 	}
 
+	return &DBHandler{Conn: conn, dialect: dialect}
+}
+
+// DBに接続し、接続プールを設定する
+func OpenDB(cfg *config.Config) (*sql.DB, error) {
+	var conn *sql.DB
+	var err error
+	switch database.Dialect(cfg.DBDriver) {
+	case database.SQLite:
+		conn, err = openSQLite(cfg.DBPath)
+	default:
+		conn, err = sql.Open("mysql", cfg.DSN())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	conn.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	conn.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	conn.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+
 	// DB接続が確立できているかを確認
 	if err := conn.Ping(); err != nil {
-		panic(fmt.Sprintf("❌ Failed to ping database: %v", err))
+		conn.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	fmt.Println("✅ Successfully connected to the database!")
+	return conn, nil
+}
 
-	sqlBytes, err := os.ReadFile("sql/init.sql")
-	if err != nil {
-		fmt.Printf("❌ Failed to read init.sql: %v\n", err)
-	} else {
-		if _, err := conn.Exec(string(sqlBytes)); err != nil {
-			fmt.Printf("❌ Failed to execute init.sql: %v\n", err)
-		} else {
-			fmt.Println("✅ Successfully initialized database from init.sql")
-		}
+// SQLite のファイルを開く（ファイルがなければ作成する）
+// 外部キー制約を有効にし、書き込みの競合はロックの解放を待ってから（トランザクションは開始時に）書き込みのロックを取る
+func openSQLite(path string) (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
 	}
+	query := url.Values{
+		"_pragma": {"foreign_keys(1)", "busy_timeout(5000)", "journal_mode(WAL)"},
+		"_txlock": {"immediate"},
+	}
+	return sql.Open("sqlite", "file:"+path+"?"+query.Encode())
+}
+
+// 方言に対応するマイグレーション
+func Migrations(dialect database.Dialect) fs.FS {
+	if dialect == database.SQLite {
+		return migrations.SQLiteFS
+	}
+	return migrations.FS
+}
+
+func (h *DBHandler) Dialect() database.Dialect {
+	return h.dialect
+}
 
-	return &MySqlHandler{Conn: conn}
+func (h *DBHandler) executor(ctx context.Context) executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return h.Conn
 }
 
-func (h *MySqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
-	result, err := h.Conn.ExecContext(ctx, statement, args...)
+func (h *DBHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	result, err := h.executor(ctx).ExecContext(ctx, statement, h.args(args)...)
 	if err != nil {
 		return nil, err
 	}
-	return &mysqlResult{result: result}, nil
+	return &sqlResult{result: result}, nil
 }
 
-func (h *MySqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
-	rows, err := h.Conn.QueryContext(ctx, statement, args...)
+func (h *DBHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	rows, err := h.executor(ctx).QueryContext(ctx, statement, h.args(args)...)
 	if err != nil {
 		return nil, err
 	}
-	return &mysqlRows{rows: rows}, nil
+	return &sqlRows{rows: rows}, nil
+}
+
+func (h *DBHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	row := h.executor(ctx).QueryRowContext(ctx, statement, h.args(args)...)
+	return &sqlRow{row: row}
 }
 
-func (h *MySqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
-	row := h.Conn.QueryRowContext(ctx, statement, args...)
-	return &mysqlRow{row: row}
+// SQLite では日時を CURRENT_TIMESTAMP と同じ形式の文字列で渡す
+func (h *DBHandler) args(args []interface{}) []interface{} {
+	if h.dialect != database.SQLite {
+		return args
+	}
+	converted := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case time.Time:
+			converted[i] = v.UTC().Format(sqliteTimeFormat)
+		case *time.Time:
+			if v != nil {
+				converted[i] = v.UTC().Format(sqliteTimeFormat)
+			}
+		default:
+			converted[i] = arg
+		}
+	}
+	return converted
+}
+
+func (h *DBHandler) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := h.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func (h *MySqlHandler) Close() error {
+func (h *DBHandler) Close() error {
 	if h.Conn != nil {
 		return h.Conn.Close()
 	}
 	return nil
 }
 
-type mysqlResult struct {
+type sqlResult struct {
 	result sql.Result
 }
 
-func (r *mysqlResult) LastInsertId() (int64, error) {
+func (r *sqlResult) LastInsertId() (int64, error) {
 	return r.result.LastInsertId()
 }
 
-func (r *mysqlResult) RowsAffected() (int64, error) {
+func (r *sqlResult) RowsAffected() (int64, error) {
 	return r.result.RowsAffected()
 }
 
-type mysqlRows struct {
+type sqlRows struct {
 	rows *sql.Rows
 }
 
-func (r *mysqlRows) Next() bool {
+func (r *sqlRows) Next() bool {
 	return r.rows.Next()
 }
 
-func (r *mysqlRows) Scan(dest ...interface{}) error {
+func (r *sqlRows) Scan(dest ...interface{}) error {
 	return r.rows.Scan(dest...)
 }
 
-func (r *mysqlRows) Close() error {
+func (r *sqlRows) Close() error {
 	return r.rows.Close()
 }
 
-func (r *mysqlRows) Err() error {
+func (r *sqlRows) Err() error {
 	return r.rows.Err()
 }
 
-type mysqlRow struct {
+type sqlRow struct {
 	row *sql.Row
 }
 
-func (r *mysqlRow) Scan(dest ...interface{}) error {
+func (r *sqlRow) Scan(dest ...interface{}) error {
 	return r.row.Scan(dest...)
 }
@@ -0,0 +1,64 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// Frankfurter 互換の為替API（GET {baseURL}/{date}?from={currency}&to=JPY）からレートを取得する
+type HTTPProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewHTTPProvider(baseURL string, timeout time.Duration) *HTTPProvider {
+	return &HTTPProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// 外貨1単位あたりの円を小数点表記の文字列で返す（丸め誤差を避けるため数値のまま扱わない）
+func (p *HTTPProvider) Rate(ctx context.Context, currency string, date entity.Date) (string, error) {
+	endpoint := fmt.Sprintf("%s/%s?%s", p.baseURL, url.PathEscape(date.String()), url.Values{
+		"from": {currency},
+		"to":   {"JPY"},
+	}.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch exchange rate: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch exchange rate: status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Rates map[string]json.Number `json:"rates"`
+	}
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode exchange rate: %w", err)
+	}
+
+	rate, ok := body.Rates["JPY"]
+	if !ok {
+		return "", fmt.Errorf("exchange rate for %s is not available", currency)
+	}
+	return rate.String(), nil
+}
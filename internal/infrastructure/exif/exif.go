@@ -0,0 +1,236 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// JPEG の EXIF（APP1 セグメント）から撮影日時と撮影場所（GPS）を読み取る
+// PNG・WebP の EXIF は読み取らない
+type Reader struct{}
+
+func NewReader() *Reader {
+	return &Reader{}
+}
+
+// EXIF のタグ
+const (
+	tagDateTime         = 0x0132
+	tagExifIFD          = 0x8769
+	tagGPSIFD           = 0x8825
+	tagDateTimeOriginal = 0x9003
+
+	tagGPSLatitudeRef  = 0x0001
+	tagGPSLatitude     = 0x0002
+	tagGPSLongitudeRef = 0x0003
+	tagGPSLongitude    = 0x0004
+)
+
+// EXIF の値の型
+const (
+	typeASCII    = 2
+	typeRational = 5
+)
+
+// EXIF の日時の形式（タイムゾーンを含まない撮影地の時刻）
+const dateTimeLayout = "2006:01:02 15:04:05"
+
+var errInvalidExif = errors.New("invalid exif")
+
+// data はファイルの先頭部分（EXIF を含む範囲）。EXIF がない場合は空のメタデータを返す
+func (r *Reader) ReadMetadata(data []byte) (*entity.ImageMetadata, error) {
+	tiff, err := findJPEGExif(data)
+	if err != nil || tiff == nil {
+		return &entity.ImageMetadata{}, err
+	}
+
+	var order binary.ByteOrder
+	switch {
+	case bytes.HasPrefix(tiff, []byte("II*\x00")):
+		order = binary.LittleEndian
+	case bytes.HasPrefix(tiff, []byte("MM\x00*")):
+		order = binary.BigEndian
+	default:
+		return &entity.ImageMetadata{}, errInvalidExif
+	}
+	t := &tiffReader{data: tiff, order: order}
+
+	ifd0, err := t.readIFD(t.uint32(4))
+	if err != nil {
+		return &entity.ImageMetadata{}, err
+	}
+
+	metadata := &entity.ImageMetadata{}
+
+	// 撮影日時（なければ更新日時）
+	dateTime := t.ascii(ifd0[tagDateTime])
+	if entry, ok := ifd0[tagExifIFD]; ok {
+		if exifIFD, err := t.readIFD(entry.offset(order)); err == nil {
+			if original := t.ascii(exifIFD[tagDateTimeOriginal]); original != "" {
+				dateTime = original
+			}
+		}
+	}
+	if takenAt, err := time.Parse(dateTimeLayout, dateTime); err == nil {
+		metadata.TakenAt = &takenAt
+	}
+
+	if entry, ok := ifd0[tagGPSIFD]; ok {
+		if gpsIFD, err := t.readIFD(entry.offset(order)); err == nil {
+			metadata.Location = t.location(gpsIFD)
+		}
+	}
+
+	return metadata, nil
+}
+
+// JPEG のセグメントをたどり、EXIF の TIFF 部分を返す（JPEG でない場合・EXIF がない場合は nil）
+func findJPEGExif(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte{0xFF, 0xD8}) {
+		return nil, nil
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil, fmt.Errorf("%w: unexpected jpeg marker", errInvalidExif)
+		}
+		marker := data[pos+1]
+		// 画像データの開始・終了以降に EXIF はない
+		if marker == 0xDA || marker == 0xD9 {
+			return nil, nil
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 {
+			return nil, fmt.Errorf("%w: invalid jpeg segment", errInvalidExif)
+		}
+		end := pos + 2 + length
+		if end > len(data) {
+			// 読み取った範囲の外にある
+			return nil, nil
+		}
+		segment := data[pos+4 : end]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:], nil
+		}
+		pos = end
+	}
+	return nil, nil
+}
+
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+// IFD のエントリー（値が4バイト以下の場合は value に値そのものを持つ）
+type ifdEntry struct {
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+func (e ifdEntry) offset(order binary.ByteOrder) uint32 {
+	return order.Uint32(e.value)
+}
+
+func (t *tiffReader) uint32(pos int) uint32 {
+	if pos < 0 || pos+4 > len(t.data) {
+		return 0
+	}
+	return t.order.Uint32(t.data[pos:])
+}
+
+func (t *tiffReader) readIFD(offset uint32) (map[uint16]ifdEntry, error) {
+	pos := int(offset)
+	if pos <= 0 || pos+2 > len(t.data) {
+		return nil, fmt.Errorf("%w: ifd out of range", errInvalidExif)
+	}
+	count := int(t.order.Uint16(t.data[pos:]))
+	pos += 2
+	if pos+count*12 > len(t.data) {
+		return nil, fmt.Errorf("%w: ifd out of range", errInvalidExif)
+	}
+
+	entries := make(map[uint16]ifdEntry, count)
+	for i := 0; i < count; i++ {
+		raw := t.data[pos+i*12 : pos+(i+1)*12]
+		entries[t.order.Uint16(raw)] = ifdEntry{
+			typ:   t.order.Uint16(raw[2:]),
+			count: t.order.Uint32(raw[4:]),
+			value: raw[8:12],
+		}
+	}
+	return entries, nil
+}
+
+// 値のバイト列（4バイトを超える場合は value が指す位置から読む）
+func (t *tiffReader) bytes(entry ifdEntry, size int) []byte {
+	total := int(entry.count) * size
+	if total <= 0 {
+		return nil
+	}
+	if total <= 4 {
+		return entry.value[:total]
+	}
+	pos := int(entry.offset(t.order))
+	if pos < 0 || pos+total > len(t.data) {
+		return nil
+	}
+	return t.data[pos : pos+total]
+}
+
+func (t *tiffReader) ascii(entry ifdEntry) string {
+	if entry.typ != typeASCII {
+		return ""
+	}
+	return string(bytes.TrimRight(t.bytes(entry, 1), "\x00 "))
+}
+
+// 度・分・秒の3つの RATIONAL を度に変換する
+func (t *tiffReader) degrees(entry ifdEntry) (float64, bool) {
+	if entry.typ != typeRational || entry.count != 3 {
+		return 0, false
+	}
+	raw := t.bytes(entry, 8)
+	if raw == nil {
+		return 0, false
+	}
+
+	var parts [3]float64
+	for i := range parts {
+		numerator := t.order.Uint32(raw[i*8:])
+		denominator := t.order.Uint32(raw[i*8+4:])
+		if denominator == 0 {
+			return 0, false
+		}
+		parts[i] = float64(numerator) / float64(denominator)
+	}
+	return parts[0] + parts[1]/60 + parts[2]/3600, true
+}
+
+func (t *tiffReader) location(gps map[uint16]ifdEntry) *entity.GeoLocation {
+	latitude, ok := t.degrees(gps[tagGPSLatitude])
+	if !ok {
+		return nil
+	}
+	longitude, ok := t.degrees(gps[tagGPSLongitude])
+	if !ok {
+		return nil
+	}
+	if t.ascii(gps[tagGPSLatitudeRef]) == "S" {
+		latitude = -latitude
+	}
+	if t.ascii(gps[tagGPSLongitudeRef]) == "W" {
+		longitude = -longitude
+	}
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return nil
+	}
+	return &entity.GeoLocation{Latitude: latitude, Longitude: longitude}
+}
@@ -0,0 +1,146 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// テスト用の IFD のエントリー（value が4バイトを超える場合は IFD の後ろに置く）
+type testEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+// TIFF 内の位置 offset に置く IFD（子の IFD の位置は pointers で後から埋める）
+func buildIFD(order binary.ByteOrder, offset int, entries []testEntry) []byte {
+	head := make([]byte, 2+len(entries)*12+4)
+	order.PutUint16(head, uint16(len(entries)))
+	var extra []byte
+	dataOffset := offset + len(head)
+	for i, entry := range entries {
+		raw := head[2+i*12:]
+		order.PutUint16(raw, entry.tag)
+		order.PutUint16(raw[2:], entry.typ)
+		order.PutUint32(raw[4:], entry.count)
+		if len(entry.value) <= 4 {
+			copy(raw[8:12], entry.value)
+			continue
+		}
+		order.PutUint32(raw[8:], uint32(dataOffset+len(extra)))
+		extra = append(extra, entry.value...)
+	}
+	return append(head, extra...)
+}
+
+func rationals(order binary.ByteOrder, values ...uint32) []byte {
+	b := make([]byte, len(values)*4)
+	for i, v := range values {
+		order.PutUint32(b[i*4:], v)
+	}
+	return b
+}
+
+func long(order binary.ByteOrder, v uint32) []byte {
+	b := make([]byte, 4)
+	order.PutUint32(b, v)
+	return b
+}
+
+// 撮影日時と撮影場所（東京・銀座）を持つ JPEG
+func buildJPEG(order binary.ByteOrder) []byte {
+	var tiff []byte
+	if order == binary.LittleEndian {
+		tiff = []byte("II*\x00")
+	} else {
+		tiff = []byte("MM\x00*")
+	}
+	tiff = append(tiff, long(order, 8)...)
+
+	// IFD0 の大きさは子の IFD の位置によらないため、位置を仮に決めてから作り直す
+	ifd0Entries := func(exifOffset, gpsOffset uint32) []testEntry {
+		return []testEntry{
+			{tag: tagDateTime, typ: typeASCII, count: 20, value: []byte("2024:05:01 10:00:00\x00")},
+			{tag: tagExifIFD, typ: 4, count: 1, value: long(order, exifOffset)},
+			{tag: tagGPSIFD, typ: 4, count: 1, value: long(order, gpsOffset)},
+		}
+	}
+	size := len(buildIFD(order, 8, ifd0Entries(0, 0)))
+	exifIFD := buildIFD(order, 8+size, []testEntry{
+		{tag: tagDateTimeOriginal, typ: typeASCII, count: 20, value: []byte("2023:01:15 14:30:00\x00")},
+	})
+	gpsOffset := 8 + size + len(exifIFD)
+	gpsIFD := buildIFD(order, gpsOffset, []testEntry{
+		{tag: tagGPSLatitudeRef, typ: typeASCII, count: 2, value: []byte("N\x00")},
+		{tag: tagGPSLatitude, typ: typeRational, count: 3, value: rationals(order, 35, 1, 40, 1, 18, 1)},
+		{tag: tagGPSLongitudeRef, typ: typeASCII, count: 2, value: []byte("E\x00")},
+		{tag: tagGPSLongitude, typ: typeRational, count: 3, value: rationals(order, 139, 1, 45, 1, 54, 1)},
+	})
+	tiff = append(tiff, buildIFD(order, 8, ifd0Entries(uint32(8+size), uint32(gpsOffset)))...)
+	tiff = append(tiff, exifIFD...)
+	tiff = append(tiff, gpsIFD...)
+
+	app1 := append([]byte("Exif\x00\x00"), tiff...)
+	jpeg := []byte{0xFF, 0xD8}
+	// EXIF の前に他のセグメント（JFIF）がある場合も読み飛ばす
+	jpeg = append(jpeg, 0xFF, 0xE0, 0x00, 0x07)
+	jpeg = append(jpeg, []byte("JFIF\x00")...)
+	jpeg = append(jpeg, 0xFF, 0xE1)
+	jpeg = binary.BigEndian.AppendUint16(jpeg, uint16(len(app1)+2))
+	jpeg = append(jpeg, app1...)
+	jpeg = append(jpeg, 0xFF, 0xDA, 0x00, 0x02)
+	return jpeg
+}
+
+func TestReader_ReadMetadata(t *testing.T) {
+	takenAt := time.Date(2023, 1, 15, 14, 30, 0, 0, time.UTC)
+
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		t.Run("正常系: 撮影日時と撮影場所を読み取る（"+order.String()+"）", func(t *testing.T) {
+			metadata, err := NewReader().ReadMetadata(buildJPEG(order))
+
+			require.NoError(t, err)
+			require.NotNil(t, metadata.TakenAt)
+			assert.Equal(t, takenAt, *metadata.TakenAt)
+			require.NotNil(t, metadata.Location)
+			assert.InDelta(t, 35.6717, metadata.Location.Latitude, 0.0001)
+			assert.InDelta(t, 139.765, metadata.Location.Longitude, 0.0001)
+		})
+	}
+
+	t.Run("正常系: EXIF のない画像は空のメタデータ", func(t *testing.T) {
+		png := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 100)...)
+
+		metadata, err := NewReader().ReadMetadata(png)
+
+		require.NoError(t, err)
+		assert.Equal(t, &entity.ImageMetadata{}, metadata)
+	})
+
+	t.Run("正常系: 読み取った範囲の外にある EXIF は読まない", func(t *testing.T) {
+		jpeg := buildJPEG(binary.LittleEndian)
+
+		metadata, err := NewReader().ReadMetadata(jpeg[:40])
+
+		require.NoError(t, err)
+		assert.Equal(t, &entity.ImageMetadata{}, metadata)
+	})
+
+	t.Run("異常系: 壊れた EXIF", func(t *testing.T) {
+		jpeg := buildJPEG(binary.LittleEndian)
+		// TIFF のヘッダーを壊す
+		copy(jpeg[bytes.Index(jpeg, []byte("Exif\x00\x00"))+6:], "XX")
+
+		_, err := NewReader().ReadMetadata(jpeg)
+
+		assert.ErrorIs(t, err, errInvalidExif)
+	})
+}
@@ -0,0 +1,179 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/skip2/go-qrcode"
+)
+
+// ラベル内の余白（mm）
+const labelPadding = 2.0
+
+// 1ポイントあたりのmm
+const mmPerPoint = 25.4 / 72
+
+// 埋め込むフォントのファミリー名（標準フォントを使う場合は Helvetica）
+const (
+	embeddedFamily = "label"
+	coreFamily     = "Helvetica"
+)
+
+// ラベルシートをPDFで描画する
+// 標準フォントは日本語などASCII以外の文字を含まないため、名前をそのまま印字するには
+// Noto Sans JP などの UTF-8 の TrueType フォントを指定する
+type PDFRenderer struct {
+	font []byte // TrueType フォント（nil の場合は標準フォントを使い、ASCII以外の文字は "?" で印字する）
+}
+
+// fontPath が空の場合は標準フォントを使う
+func NewPDFRenderer(fontPath string) (*PDFRenderer, error) {
+	if fontPath == "" {
+		return &PDFRenderer{}, nil
+	}
+
+	font, err := os.ReadFile(fontPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read label font: %w", err)
+	}
+	return &PDFRenderer{font: font}, nil
+}
+
+func (r *PDFRenderer) RenderLabels(w io.Writer, layout entity.LabelLayout, skip int, labels []entity.ItemLabel) error {
+	pdf := fpdf.NewCustom(&fpdf.InitType{
+		OrientationStr: "P",
+		UnitStr:        "mm",
+		Size:           fpdf.SizeType{Wd: layout.PageWidth, Ht: layout.PageHeight},
+	})
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetCellMargin(0)
+
+	family := coreFamily
+	if r.font != nil {
+		pdf.AddUTF8FontFromBytes(embeddedFamily, "", r.font)
+		family = embeddedFamily
+	}
+
+	for i, label := range labels {
+		index := skip + i
+		if index%layout.PerSheet() == 0 || i == 0 {
+			pdf.AddPage()
+		}
+
+		x, y := layout.Position(index)
+		if err := r.drawLabel(pdf, family, layout, x, y, label); err != nil {
+			return err
+		}
+	}
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
+}
+
+// QRコードを右側に、ID・名前・ブランドを左側に印字する
+func (r *PDFRenderer) drawLabel(pdf *fpdf.Fpdf, family string, layout entity.LabelLayout, x, y float64, label entity.ItemLabel) error {
+	qrSize := layout.LabelHeight - 2*labelPadding
+	png, err := qrcode.Encode(label.QRCode, qrcode.Medium, 256)
+	if err != nil {
+		return fmt.Errorf("failed to encode QR code for item %d: %w", label.ItemID, err)
+	}
+	imageName := fmt.Sprintf("qr-%d", label.ItemID)
+	options := fpdf.ImageOptions{ImageType: "PNG"}
+	pdf.RegisterImageOptionsReader(imageName, options, bytes.NewReader(png))
+	pdf.ImageOptions(imageName, x+layout.LabelWidth-labelPadding-qrSize, y+labelPadding, qrSize, qrSize, false, options, 0, "")
+
+	textX := x + labelPadding
+	textWidth := layout.LabelWidth - qrSize - 3*labelPadding
+	textBottom := y + layout.LabelHeight - labelPadding
+
+	// ラベルの高さに応じて文字の大きさを決める（25.4mm のラベルで ID が 10pt 程度）
+	idSize := clamp(layout.LabelHeight*0.4, 8, 14)
+	nameSize := idSize * 0.85
+	brandSize := idSize * 0.7
+
+	cursor := y + labelPadding
+	cursor = r.writeLines(pdf, family, idSize, textX, cursor, textWidth, textBottom, fmt.Sprintf("#%06d", label.ItemID), 1)
+
+	// ブランドの行を残して、名前を入るだけ折り返す
+	brandHeight := 0.0
+	if label.Brand != "" {
+		brandHeight = lineHeight(brandSize)
+	}
+	cursor = r.writeLines(pdf, family, nameSize, textX, cursor, textWidth, textBottom-brandHeight, label.Name, 0)
+	if label.Brand != "" {
+		r.writeLines(pdf, family, brandSize, textX, cursor, textWidth, textBottom, label.Brand, 1)
+	}
+
+	return pdf.Error()
+}
+
+// テキストを幅に合わせて折り返し、bottom までに入る行（maxLines が 0 より大きい場合はその行数まで）を印字する
+// 入りきらない場合は最後の行の末尾を "…" にする。次の行の位置を返す
+func (r *PDFRenderer) writeLines(pdf *fpdf.Fpdf, family string, size, x, y, width, bottom float64, text string, maxLines int) float64 {
+	pdf.SetFont(family, "", size)
+	if family == coreFamily {
+		text = asciiOnly(text)
+	}
+
+	height := lineHeight(size)
+	lines := pdf.SplitText(text, width)
+	fit := int((bottom - y) / height)
+	if maxLines > 0 && fit > maxLines {
+		fit = maxLines
+	}
+	if fit <= 0 {
+		return y
+	}
+	if len(lines) > fit {
+		ellipsis := "..."
+		if family != coreFamily {
+			ellipsis = "…"
+		}
+		last := []rune(lines[fit-1])
+		for len(last) > 0 && pdf.GetStringWidth(string(last)+ellipsis) > width {
+			last = last[:len(last)-1]
+		}
+		lines = append(lines[:fit-1], string(last)+ellipsis)
+	}
+
+	for _, line := range lines {
+		pdf.SetXY(x, y)
+		pdf.CellFormat(width, height, line, "", 0, "L", false, 0, "")
+		y += height
+	}
+	return y
+}
+
+// 行の高さ（文字の大きさの1.2倍）
+func lineHeight(size float64) float64 {
+	return size * mmPerPoint * 1.2
+}
+
+func clamp(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// 標準フォントで印字できない文字を "?" にする
+func asciiOnly(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e {
+			return '?'
+		}
+		return r
+	}, text)
+}
@@ -0,0 +1,42 @@
+package label
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func TestPDFRenderer_RenderLabels(t *testing.T) {
+	layout, ok := entity.FindLabelLayout("a4-12")
+	require.True(t, ok)
+
+	t.Run("正常系: 1枚に入りきらない場合は次のページに続ける", func(t *testing.T) {
+		labels := make([]entity.ItemLabel, 0, 12)
+		for i := int64(1); i <= 12; i++ {
+			labels = append(labels, entity.ItemLabel{ItemID: i, Name: "Submariner Date 126610LN", Brand: "ROLEX", QRCode: "items/1"})
+		}
+
+		renderer, err := NewPDFRenderer("")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, renderer.RenderLabels(&buf, layout, 3, labels))
+
+		assert.True(t, strings.HasPrefix(buf.String(), "%PDF"))
+		assert.Equal(t, 2, strings.Count(buf.String(), "/Type /Page\n"))
+	})
+
+	t.Run("正常系: 標準フォントでは ASCII 以外の文字を ? にする", func(t *testing.T) {
+		assert.Equal(t, "?? Daytona", asciiOnly("時計 Daytona"))
+	})
+
+	t.Run("異常系: フォントが読み込めない", func(t *testing.T) {
+		_, err := NewPDFRenderer("/nonexistent/font.ttf")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,139 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// OpenAI 互換の Chat Completions API（POST {baseURL}/chat/completions）でアイテムのカテゴリーを推定する
+// 回答は {"category": "時計", "confidence": 0.9, "reason": "..."} の JSON で求める
+type HTTPClassifier struct {
+	baseURL string
+	apiKey  string
+	model   string
+	client  *http.Client
+}
+
+func NewHTTPClassifier(baseURL, apiKey, model string, timeout time.Duration) *HTTPClassifier {
+	return &HTTPClassifier{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+const classifierInstruction = `You classify items of a luxury goods inventory into one of the given categories.
+Answer with a JSON object {"category": string, "confidence": number, "reason": string}.
+"category" must be exactly one of the given categories, or an empty string if none of them fits.
+"confidence" is between 0 and 1. "reason" is one short sentence in Japanese.`
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+func (c *HTTPClassifier) SuggestCategory(ctx context.Context, item *entity.Item, candidates []string) (*usecase.CategoryGuess, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"model": c.model,
+		"messages": []chatMessage{
+			{Role: "system", Content: classifierInstruction},
+			{Role: "user", Content: itemDescription(item, candidates)},
+		},
+		"response_format": map[string]string{"type": "json_object"},
+		"temperature":     0,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		// 接続エラー・タイムアウトは再試行で成功しうる
+		return nil, fmt.Errorf("%w: failed to request category: %w", domainErrors.ErrTransient, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return nil, fmt.Errorf("%w: failed to request category: status %d", domainErrors.ErrTransient, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to request category: status %d", resp.StatusCode)
+	}
+
+	var completion struct {
+		Choices []struct {
+			Message chatMessage `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return nil, fmt.Errorf("failed to decode completion: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return nil, fmt.Errorf("completion has no choices")
+	}
+
+	var answer struct {
+		Category   string  `json:"category"`
+		Confidence float64 `json:"confidence"`
+		Reason     string  `json:"reason"`
+	}
+	if err := json.Unmarshal([]byte(completion.Choices[0].Message.Content), &answer); err != nil {
+		return nil, fmt.Errorf("failed to decode category answer: %w", err)
+	}
+
+	// 候補にないカテゴリーは当てはまるものがないとみなす
+	category := strings.TrimSpace(answer.Category)
+	if !containsString(candidates, category) {
+		category = ""
+	}
+	return &usecase.CategoryGuess{Category: category, Confidence: answer.Confidence, Reason: answer.Reason}, nil
+}
+
+// 推定に使うアイテムの属性（価格・日付などの個人的な情報は送らない）
+func itemDescription(item *entity.Item, candidates []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Categories: %s\n", strings.Join(candidates, ", "))
+	fmt.Fprintf(&b, "Name: %s\n", item.Name)
+	if item.Brand != "" {
+		fmt.Fprintf(&b, "Brand: %s\n", item.Brand)
+	}
+
+	keys := make([]string, 0, len(item.Attributes))
+	for key := range item.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s: %s\n", key, item.Attributes[key])
+	}
+	return b.String()
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package marketprice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 相場API（GET {endpoint}?brand=&model=&category=&currency=）から市場価格を取得する
+// レスポンスは {"price": {"amount": 1800000, "currency": "JPY"}, "source": "..."} の形式
+type HTTPProvider struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+func NewHTTPProvider(endpoint, apiKey string, timeout time.Duration) *HTTPProvider {
+	return &HTTPProvider{
+		endpoint: strings.TrimRight(endpoint, "/"),
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *HTTPProvider) Quote(ctx context.Context, query usecase.PriceQuery) (*usecase.MarketPrice, error) {
+	endpoint := p.endpoint + "?" + url.Values{
+		"brand":    {query.Brand},
+		"model":    {query.Model},
+		"category": {query.Category},
+		"currency": {query.Currency},
+	}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		// 接続エラー・タイムアウトは再試行で成功しうる
+		return nil, fmt.Errorf("%w: failed to fetch market price: %w", domainErrors.ErrTransient, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("no market price for %s %s", query.Brand, query.Model)
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return nil, fmt.Errorf("%w: failed to fetch market price: status %d", domainErrors.ErrTransient, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to fetch market price: status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Price  *entity.Money `json:"price"`
+		Source string        `json:"source"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode market price: %w", err)
+	}
+	if body.Price == nil {
+		return nil, fmt.Errorf("no market price for %s %s", query.Brand, query.Model)
+	}
+
+	return &usecase.MarketPrice{Value: *body.Price, Source: body.Source}, nil
+}
@@ -0,0 +1,69 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 汎用の OCR API（POST {endpoint} に写真をそのまま送る）で写真の文字を読み取る
+// レスポンスは {"text": "..."}（行ごとに改行で区切った全文）の形式
+type HTTPReader struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+func NewHTTPReader(endpoint, apiKey string, timeout time.Duration) *HTTPReader {
+	return &HTTPReader{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (r *HTTPReader) ReadText(ctx context.Context, image []byte, contentType string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(image))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if r.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+r.apiKey)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		// 接続エラー・タイムアウトは再試行で成功しうる
+		return "", fmt.Errorf("%w: failed to request ocr: %w", domainErrors.ErrTransient, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return "", err
+	}
+
+	var body struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode ocr result: %w", err)
+	}
+	return body.Text, nil
+}
+
+// 混雑・サーバーエラーは再試行で成功しうる
+func checkStatus(resp *http.Response) error {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("%w: failed to request ocr: status %d", domainErrors.ErrTransient, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("failed to request ocr: status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const visionEndpoint = "https://vision.googleapis.com/v1/images:annotate"
+
+// Google Cloud Vision API の文書の文字検出（DOCUMENT_TEXT_DETECTION）で写真の文字を読み取る
+// POST /v1/images:annotate?key={apiKey} に写真を base64 で送り、fullTextAnnotation.text を全文とする
+type VisionReader struct {
+	apiKey string
+	client *http.Client
+}
+
+func NewVisionReader(apiKey string, timeout time.Duration) *VisionReader {
+	return &VisionReader{
+		apiKey: apiKey,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+type visionRequest struct {
+	Image struct {
+		Content string `json:"content"`
+	} `json:"image"`
+	Features     []visionFeature `json:"features"`
+	ImageContext struct {
+		LanguageHints []string `json:"languageHints"`
+	} `json:"imageContext"`
+}
+
+type visionFeature struct {
+	Type string `json:"type"`
+}
+
+func (r *VisionReader) ReadText(ctx context.Context, image []byte, contentType string) (string, error) {
+	var request visionRequest
+	request.Image.Content = base64.StdEncoding.EncodeToString(image)
+	request.Features = []visionFeature{{Type: "DOCUMENT_TEXT_DETECTION"}}
+	request.ImageContext.LanguageHints = []string{"ja", "en"}
+
+	body, err := json.Marshal(map[string][]visionRequest{"requests": {request}})
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := visionEndpoint + "?" + url.Values{"key": {r.apiKey}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		// 接続エラー・タイムアウトは再試行で成功しうる（URL に API キーを含むため、エラーの URL は出力しない）
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+		return "", fmt.Errorf("%w: failed to request ocr: %w", domainErrors.ErrTransient, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Responses []struct {
+			FullTextAnnotation *struct {
+				Text string `json:"text"`
+			} `json:"fullTextAnnotation"`
+			Error *struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		} `json:"responses"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode ocr result: %w", err)
+	}
+	if len(result.Responses) == 0 {
+		return "", fmt.Errorf("ocr result has no responses")
+	}
+
+	response := result.Responses[0]
+	if response.Error != nil {
+		return "", fmt.Errorf("failed to read text: %s (code %d)", response.Error.Message, response.Error.Code)
+	}
+	// 文字が見つからない場合は fullTextAnnotation がない
+	if response.FullTextAnnotation == nil {
+		return "", nil
+	}
+	return response.FullTextAnnotation.Text, nil
+}
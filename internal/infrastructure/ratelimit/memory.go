@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// 掃除を始めるバケットの数
+const maxMemoryBuckets = 10000
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// プロセス内のトークンバケット（サーバーごとに独立して数える）
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit int, per time.Duration) (bool, time.Duration, error) {
+	now := s.now()
+	rate := float64(limit) / float64(per) // 1ナノ秒あたりの補充数
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 満杯まで補充されたバケットは新しく作った場合と同じため、削除してメモリ使用量を抑える
+	if len(s.buckets) > maxMemoryBuckets {
+		for k, b := range s.buckets {
+			if now.Sub(b.updatedAt) >= per {
+				delete(s.buckets, k)
+			}
+		}
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit), updatedAt: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(limit), b.tokens+float64(now.Sub(b.updatedAt))*rate)
+	b.updatedAt = now
+
+	if b.tokens < 1 {
+		return false, time.Duration(math.Ceil((1 - b.tokens) / rate)), nil
+	}
+	b.tokens--
+	return true, 0, nil
+}
@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 容量まで続けて取り出せ、超えると補充までの時間を返す", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		s := NewMemoryStore()
+		s.now = func() time.Time { return now }
+
+		for i := 0; i < 3; i++ {
+			ok, _, err := s.Take(ctx, "ip:192.0.2.1", 3, time.Minute)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		}
+		ok, wait, err := s.Take(ctx, "ip:192.0.2.1", 3, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, 20*time.Second, wait)
+
+		// 他のキーは別に数える
+		ok, _, _ = s.Take(ctx, "ip:192.0.2.2", 3, time.Minute)
+		assert.True(t, ok)
+	})
+
+	t.Run("正常系: 経過時間に応じてトークンを補充する", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		s := NewMemoryStore()
+		s.now = func() time.Time { return now }
+
+		for i := 0; i < 2; i++ {
+			ok, _, _ := s.Take(ctx, "user:alice", 2, time.Minute)
+			require.True(t, ok)
+		}
+		now = now.Add(30 * time.Second)
+		ok, _, _ := s.Take(ctx, "user:alice", 2, time.Minute)
+		assert.True(t, ok)
+		ok, wait, _ := s.Take(ctx, "user:alice", 2, time.Minute)
+		assert.False(t, ok)
+		assert.Equal(t, 30*time.Second, wait)
+	})
+}
@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis のキーの接頭辞
+const redisKeyPrefix = "ratelimit:"
+
+// トークンの補充と取り出しを1回の実行で行う（複数台のサーバーから同時に呼ばれても数え漏れがない）
+// KEYS[1]: バケット, ARGV: 容量, 1ミリ秒あたりの補充数, 現在時刻（ミリ秒）
+// 戻り値: {取り出せた場合は 1, 次のトークンが補充されるまでのミリ秒}
+var takeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or capacity
+local ts = tonumber(state[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+local allowed, wait = 0, 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait = math.ceil((1 - tokens) / rate)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity / rate))
+return {allowed, wait}
+`)
+
+// Redis のトークンバケット（複数台のサーバーで上限を共有する）
+type RedisStore struct {
+	client *redis.Client
+	now    func() time.Time
+}
+
+// url は redis://[:password@]host:port/db の形式
+func NewRedisStore(url string) (*RedisStore, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+	return &RedisStore{client: redis.NewClient(options), now: time.Now}, nil
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, limit int, per time.Duration) (bool, time.Duration, error) {
+	rate := float64(limit) / float64(per.Milliseconds())
+	result, err := takeScript.Run(ctx, s.client, []string{redisKeyPrefix + key}, limit, rate, s.now().UnixMilli()).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
+
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
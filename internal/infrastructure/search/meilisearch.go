@@ -0,0 +1,162 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// Meilisearch のインデックスでアイテムを検索する
+// ドキュメントにはIDと検索・絞り込み・並べ替えに使う項目だけを登録し、アイテムはDBから取得する
+type MeilisearchIndex struct {
+	baseURL string
+	apiKey  string
+	index   string
+	client  *http.Client
+}
+
+func NewMeilisearchIndex(baseURL, apiKey, index string, timeout time.Duration) *MeilisearchIndex {
+	return &MeilisearchIndex{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		index:   index,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// インデックスに登録するドキュメント
+type meilisearchDocument struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	Brand         string `json:"brand"`
+	Category      string `json:"category"`
+	Currency      string `json:"currency"`
+	PurchasePrice int64  `json:"purchase_price"`
+	PurchaseDate  string `json:"purchase_date"`
+	CreatedAt     int64  `json:"created_at"` // 並べ替えのため UNIX 時間で登録する
+}
+
+// 検索・絞り込み・並べ替えに使う項目を設定する（起動時に実行する）
+func (m *MeilisearchIndex) Configure(ctx context.Context) error {
+	settings := map[string][]string{
+		"searchableAttributes": {"name", "brand"},
+		"filterableAttributes": usecase.SearchFacets,
+		"sortableAttributes":   {"id", "name", "purchase_price", "purchase_date", "created_at"},
+	}
+	return m.do(ctx, http.MethodPatch, "/settings", settings, nil)
+}
+
+func (m *MeilisearchIndex) Search(ctx context.Context, query usecase.SearchIndexQuery) (*usecase.SearchIndexResult, error) {
+	request := map[string]interface{}{
+		"q":                    query.Keyword,
+		"limit":                query.Limit,
+		"offset":               query.Offset,
+		"attributesToRetrieve": []string{"id"},
+	}
+	if query.Category != "" {
+		request["filter"] = "category = " + strconv.Quote(query.Category)
+	}
+	if len(query.Facets) > 0 {
+		request["facets"] = query.Facets
+	}
+	if query.Sort != "" {
+		// 同じ値の場合はIDの順（SQLでの検索と同じ並び）
+		request["sort"] = []string{
+			fmt.Sprintf("%s:%s", query.Sort, query.Order),
+			fmt.Sprintf("id:%s", query.Order),
+		}
+	}
+
+	var response struct {
+		Hits []struct {
+			ID int64 `json:"id"`
+		} `json:"hits"`
+		EstimatedTotalHits int                       `json:"estimatedTotalHits"`
+		FacetDistribution  map[string]map[string]int `json:"facetDistribution"`
+	}
+	if err := m.do(ctx, http.MethodPost, "/search", request, &response); err != nil {
+		return nil, err
+	}
+
+	result := &usecase.SearchIndexResult{
+		IDs:    make([]int64, 0, len(response.Hits)),
+		Total:  response.EstimatedTotalHits,
+		Facets: response.FacetDistribution,
+	}
+	for _, hit := range response.Hits {
+		result.IDs = append(result.IDs, hit.ID)
+	}
+	return result, nil
+}
+
+func (m *MeilisearchIndex) Upsert(ctx context.Context, items []*entity.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+	documents := make([]meilisearchDocument, 0, len(items))
+	for _, item := range items {
+		documents = append(documents, meilisearchDocument{
+			ID:            item.ID,
+			Name:          item.Name,
+			Brand:         item.Brand,
+			Category:      item.Category,
+			Currency:      item.PurchasePrice.Currency,
+			PurchasePrice: item.PurchasePrice.Amount,
+			PurchaseDate:  item.PurchaseDate.String(),
+			CreatedAt:     item.CreatedAt.Unix(),
+		})
+	}
+	return m.do(ctx, http.MethodPost, "/documents?"+url.Values{"primaryKey": {"id"}}.Encode(), documents, nil)
+}
+
+func (m *MeilisearchIndex) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return m.do(ctx, http.MethodPost, "/documents/delete-batch", ids, nil)
+}
+
+// インデックスのAPI（/indexes/{index}{path}）を呼び出す
+// ドキュメントの登録・削除は非同期のタスクとして受け付けられる（202）ため、反映を待たない
+func (m *MeilisearchIndex) do(ctx context.Context, method, path string, body, result interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/indexes/%s%s", m.baseURL, url.PathEscape(m.index), path)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if m.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call meilisearch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("failed to call meilisearch: %s %s: status %d", method, path, resp.StatusCode)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode meilisearch response: %w", err)
+	}
+	return nil
+}
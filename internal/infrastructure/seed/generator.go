@@ -0,0 +1,113 @@
+package seed
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// ブランドごとのモデル名と価格帯
+type brandProfile struct {
+	Brand    string
+	NameJa   string
+	Models   []string
+	MinPrice int
+	MaxPrice int
+}
+
+// カテゴリーごとのブランドカタログ
+var catalog = map[string][]brandProfile{
+	"時計": {
+		{Brand: "ROLEX", NameJa: "ロレックス", Models: []string{"デイトナ", "サブマリーナ", "GMTマスターII", "エクスプローラーI", "デイトジャスト"}, MinPrice: 800000, MaxPrice: 4500000},
+		{Brand: "OMEGA", NameJa: "オメガ", Models: []string{"スピードマスター", "シーマスター", "コンステレーション"}, MinPrice: 400000, MaxPrice: 1200000},
+		{Brand: "Patek Philippe", NameJa: "パテック フィリップ", Models: []string{"ノーチラス", "カラトラバ", "アクアノート"}, MinPrice: 3000000, MaxPrice: 15000000},
+		{Brand: "Apple", NameJa: "アップル", Models: []string{"Apple Watch Ultra", "Apple Watch Series 9"}, MinPrice: 50000, MaxPrice: 130000},
+	},
+	"バッグ": {
+		{Brand: "HERMÈS", NameJa: "エルメス", Models: []string{"バーキン 30", "ケリー 28", "ピコタン ロック"}, MinPrice: 400000, MaxPrice: 3500000},
+		{Brand: "CHANEL", NameJa: "シャネル", Models: []string{"マトラッセ", "ボーイシャネル", "2.55"}, MinPrice: 500000, MaxPrice: 1800000},
+		{Brand: "LOUIS VUITTON", NameJa: "ルイ・ヴィトン", Models: []string{"スピーディ 30", "ネヴァーフル MM", "アルマ BB"}, MinPrice: 150000, MaxPrice: 450000},
+	},
+	"ジュエリー": {
+		{Brand: "Tiffany & Co.", NameJa: "ティファニー", Models: []string{"ネックレス", "T ワイヤー ブレスレット", "ソリティア リング"}, MinPrice: 50000, MaxPrice: 800000},
+		{Brand: "Cartier", NameJa: "カルティエ", Models: []string{"LOVE ブレスレット", "ジュスト アン クル", "トリニティ リング"}, MinPrice: 200000, MaxPrice: 1500000},
+		{Brand: "Van Cleef & Arpels", NameJa: "ヴァン クリーフ&アーペル", Models: []string{"アルハンブラ ネックレス", "ペルレ リング"}, MinPrice: 300000, MaxPrice: 1200000},
+	},
+	"靴": {
+		{Brand: "Christian Louboutin", NameJa: "ルブタン", Models: []string{"パンプス", "ソー ケイト", "ルイス スニーカー"}, MinPrice: 90000, MaxPrice: 250000},
+		{Brand: "JOHN LOBB", NameJa: "ジョンロブ", Models: []string{"シティII", "フィリップII", "ウィリアム"}, MinPrice: 200000, MaxPrice: 450000},
+		{Brand: "NIKE", NameJa: "ナイキ", Models: []string{"エアジョーダン 1", "ダンク ロー"}, MinPrice: 15000, MaxPrice: 300000},
+	},
+	"その他": {
+		{Brand: "Apple", NameJa: "アップル", Models: []string{"MacBook Pro", "iPad Pro", "AirPods Max"}, MinPrice: 80000, MaxPrice: 500000},
+		{Brand: "Montblanc", NameJa: "モンブラン", Models: []string{"マイスターシュテュック 149", "スターウォーカー"}, MinPrice: 60000, MaxPrice: 150000},
+		{Brand: "Leica", NameJa: "ライカ", Models: []string{"M11", "Q3"}, MinPrice: 700000, MaxPrice: 1300000},
+	},
+}
+
+// 購入日の生成範囲（シードが同じなら常に同じデータになるよう固定）
+var (
+	purchaseDateFrom = time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	purchaseDateTo   = time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// デモ用アイテムの生成器
+type Generator struct {
+	rnd   *rand.Rand
+	clock entity.Clock
+}
+
+// シード値を指定して生成器を作成する（同じシードなら同じ順序で同じアイテムを返す）
+func NewGenerator(seed int64) *Generator {
+	return &Generator{
+		rnd:   rand.New(rand.NewSource(seed)),
+		clock: entity.SystemClock,
+	}
+}
+
+// アイテムを1件生成
+func (g *Generator) Item() *entity.Item {
+	categories := entity.GetValidCategories()
+	category := categories[g.rnd.Intn(len(categories))]
+
+	profiles := catalog[category]
+	profile := profiles[g.rnd.Intn(len(profiles))]
+	model := profile.Models[g.rnd.Intn(len(profile.Models))]
+
+	item, err := entity.NewItem(
+		fmt.Sprintf("%s %s", profile.NameJa, model),
+		category,
+		profile.Brand,
+		entity.JPY(g.price(profile)),
+		g.purchaseDate(),
+		g.clock.Now(),
+	)
+	if err != nil {
+		// カタログの内容はバリデーションを満たすように定義しているため到達しない
+		panic(fmt.Sprintf("seed: generated invalid item: %v", err))
+	}
+
+	return item
+}
+
+// アイテムをn件生成
+func (g *Generator) Items(n int) []*entity.Item {
+	items := make([]*entity.Item, 0, n)
+	for i := 0; i < n; i++ {
+		items = append(items, g.Item())
+	}
+	return items
+}
+
+// 価格帯の中からランダムに選び、1,000円単位に丸める
+func (g *Generator) price(profile brandProfile) int64 {
+	price := profile.MinPrice + g.rnd.Intn(profile.MaxPrice-profile.MinPrice+1)
+	return int64(price / 1000 * 1000)
+}
+
+func (g *Generator) purchaseDate() entity.Date {
+	days := int(purchaseDateTo.Sub(purchaseDateFrom).Hours() / 24)
+	return entity.DateOf(purchaseDateFrom.AddDate(0, 0, g.rnd.Intn(days+1)))
+}
@@ -0,0 +1,51 @@
+package seed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_Items(t *testing.T) {
+	items := NewGenerator(42).Items(200)
+	require.Len(t, items, 200)
+
+	for _, item := range items {
+		require.NoError(t, item.Validate())
+
+		// ブランドがカテゴリーのカタログに含まれ、価格が価格帯に収まっているかチェック
+		profile, ok := findProfile(item.Category, item.Brand)
+		require.True(t, ok, "unexpected brand %s for category %s", item.Brand, item.Category)
+		assert.Equal(t, "JPY", item.PurchasePrice.Currency)
+		assert.GreaterOrEqual(t, item.PurchasePrice.Amount, int64(profile.MinPrice/1000*1000))
+		assert.LessOrEqual(t, item.PurchasePrice.Amount, int64(profile.MaxPrice))
+	}
+}
+
+func TestGenerator_Deterministic(t *testing.T) {
+	first := NewGenerator(7).Items(20)
+	second := NewGenerator(7).Items(20)
+
+	for i := range first {
+		assert.Equal(t, first[i].Name, second[i].Name)
+		assert.Equal(t, first[i].PurchasePrice, second[i].PurchasePrice)
+		assert.Equal(t, first[i].PurchaseDate, second[i].PurchaseDate)
+	}
+}
+
+func BenchmarkGenerator_Items(b *testing.B) {
+	g := NewGenerator(1)
+	for i := 0; i < b.N; i++ {
+		g.Items(100)
+	}
+}
+
+func findProfile(category, brand string) (brandProfile, bool) {
+	for _, profile := range catalog[category] {
+		if profile.Brand == brand {
+			return profile, true
+		}
+	}
+	return brandProfile{}, false
+}
@@ -0,0 +1,224 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/config"
+	"Aicon-assignment/internal/infrastructure/exchangerate"
+	"Aicon-assignment/internal/infrastructure/storage"
+	"Aicon-assignment/internal/infrastructure/webhook"
+	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+// アイテムのユースケースを組み立てるときに使う、他のユースケースと共有する依存
+type ItemDependencies struct {
+	DBHandler    itemDatabase.SqlHandler
+	ImageStorage usecase.ImageStorage
+	Clock        entity.Clock
+	ReadOnly     *usecase.ReadOnlySwitch
+
+	// 障害の注入が無効の場合は nil
+	FaultInjector *usecase.FaultInjector
+}
+
+// アイテムのユースケースと、組み立てる途中で作った他のユースケースと共有するもの
+// API サーバーと itemctl が同じ設定（削除の方針・利用上限・読み取りキャッシュ・検索・Webhook など）で書き込むよう、ここでまとめて組み立てる
+type ItemComponents struct {
+	Usecase usecase.ItemUsecase
+	Repo    usecase.ItemRepository // 読み取りキャッシュが有効な場合はキャッシュを介する
+
+	ImageRepo       usecase.ItemImageRepository
+	Quota           *usecase.QuotaChecker
+	BrandAliasRepo  usecase.BrandAliasRepository
+	BrandNormalizer *usecase.BrandNormalizer
+	CachePurger     usecase.CachePurger        // CDN・読み取りキャッシュがない場合は nil
+	ResponseCache   *usecase.ItemResponseCache // 未設定の場合は nil
+	SearchIndexer   *usecase.SearchIndexer     // 検索エンジンが未設定の場合は nil
+	Webhooks        usecase.WebhookUsecase
+	OutboxRelay     *usecase.OutboxRelay  // OUTBOX_ENABLED でない場合は nil
+	SummaryCache    *usecase.SummaryCache // 公開統計のカテゴリー別集計のキャッシュ
+
+	closers []io.Closer
+}
+
+func NewItemComponents(ctx context.Context, cfg *config.Config, deps ItemDependencies) (*ItemComponents, error) {
+	s := NewServer(cfg)
+	dbHandler := deps.DBHandler
+	components := &ItemComponents{}
+
+	var itemRepo usecase.ItemRepository = &itemDatabase.ItemRepository{
+		SqlHandler:  dbHandler,
+		UseFullText: cfg.SearchFullText,
+	}
+	// 読み取りキャッシュが未設定の場合は毎回DBから読む
+	var cachedItemRepo *usecase.CachedItemRepository
+	var summaryOpts []usecase.SummaryCacheOption
+	itemCache, err := s.newItemCache()
+	if err != nil {
+		return nil, err
+	}
+	if itemCache != nil {
+		if closer, ok := itemCache.(io.Closer); ok {
+			components.closers = append(components.closers, closer)
+		}
+		// 読み取りキャッシュを共有するサーバー間で、公開統計の再集計を順に実行する
+		if locker, ok := itemCache.(usecase.Locker); ok {
+			summaryOpts = append(summaryOpts, usecase.WithSummaryLocker(locker))
+		}
+		// Redis の障害中は待たずにDBから読む（レスポンスのキャッシュも同じ）
+		if cfg.ItemCache == "redis" {
+			itemCache = usecase.NewResilientKeyValueCache(itemCache, s.newCircuitBreaker("item cache", cfg.ItemCacheTimeout))
+			// 公開統計と再集計の進み具合もサーバー間で共有する
+			summaryOpts = append(summaryOpts, usecase.WithSummaryStore(itemCache))
+		}
+		cachedItemRepo = usecase.NewCachedItemRepository(itemRepo, itemCache, usecase.ItemCacheTTL{
+			Item:    cfg.ItemCacheTTL,
+			List:    cfg.ItemCacheListTTL,
+			Summary: cfg.ItemCacheSummaryTTL,
+		})
+		itemRepo = cachedItemRepo
+		summaryOpts = append(summaryOpts, usecase.WithSummaryItemCache(cachedItemRepo))
+	}
+	components.Repo = itemRepo
+
+	cachePurger, err := s.newCachePurger()
+	if err != nil {
+		components.Close()
+		return nil, err
+	}
+	// アイテムの取得のレスポンスは読み取りキャッシュと同じ保存先に保存し、CDNと同じ契機で古い版を捨てる
+	if itemCache != nil && cfg.ItemResponseCacheTTL > 0 {
+		components.ResponseCache = usecase.NewItemResponseCache(itemCache, cfg.ItemResponseCacheTTL)
+		if cachePurger != nil {
+			cachePurger = usecase.CachePurgers{components.ResponseCache, cachePurger}
+		} else {
+			cachePurger = components.ResponseCache
+		}
+	}
+	// タグの変更など、アイテムのリポジトリを通らない変更でも読み取りキャッシュを削除する
+	if cachedItemRepo != nil {
+		if cachePurger != nil {
+			cachePurger = usecase.CachePurgers{cachedItemRepo.CachePurger(), cachePurger}
+		} else {
+			cachePurger = cachedItemRepo.CachePurger()
+		}
+	}
+	components.CachePurger = cachePurger
+
+	imageRepo := &itemDatabase.ItemImageRepository{SqlHandler: dbHandler}
+	quotaOverrides := make(map[string]entity.Quota, len(cfg.QuotaOverrides))
+	for actor, limit := range cfg.QuotaOverrides {
+		quotaOverrides[actor] = entity.Quota{MaxItems: limit.MaxItems, MaxStorageBytes: limit.MaxStorage}
+	}
+	quota := usecase.NewQuotaChecker(&usecase.StaticQuotaPolicy{
+		Default:   entity.Quota{MaxItems: cfg.QuotaMaxItems, MaxStorageBytes: cfg.QuotaMaxStorage},
+		Overrides: quotaOverrides,
+	}, itemRepo, imageRepo)
+	components.ImageRepo = imageRepo
+	components.Quota = quota
+
+	itemOpts := []usecase.ItemUsecaseOption{
+		usecase.WithClock(deps.Clock),
+		usecase.WithReadOnlySwitch(deps.ReadOnly),
+		usecase.WithDefaultCategory(cfg.DefaultCategory),
+		usecase.WithBudgetCheck(&itemDatabase.BudgetRepository{SqlHandler: dbHandler}, usecase.BudgetEnforcement(cfg.BudgetEnforcement)),
+		usecase.WithValuations(&itemDatabase.ValuationRepository{SqlHandler: dbHandler}),
+		usecase.WithTags(&itemDatabase.TagRepository{SqlHandler: dbHandler}),
+		usecase.WithSales(&itemDatabase.SaleRepository{SqlHandler: dbHandler}, dbHandler),
+		usecase.WithQuota(quota),
+		usecase.WithTaxRounding(entity.RoundingMode(cfg.TaxRounding)),
+		usecase.WithAuditLogger(&itemDatabase.AuditLogRepository{SqlHandler: dbHandler}),
+		usecase.WithRevisions(&itemDatabase.ItemRevisionRepository{SqlHandler: dbHandler}),
+		usecase.WithDeletePolicy(usecase.DeletePolicy(cfg.DeletePolicy), &itemDatabase.ItemDependentsRepository{SqlHandler: dbHandler}, dbHandler, deps.ImageStorage),
+	}
+	// ブランドの別名の辞書（無効の場合は入力のまま保存する）
+	components.BrandAliasRepo = &itemDatabase.BrandAliasRepository{SqlHandler: dbHandler}
+	components.BrandNormalizer = usecase.NewBrandNormalizer(components.BrandAliasRepo, usecase.DefaultBrandAliasTTL)
+	if cfg.BrandNormalization {
+		itemOpts = append(itemOpts, usecase.WithBrandNormalizer(components.BrandNormalizer))
+	}
+	// 為替APIが未設定の場合、外貨建ての購入価格は円換算しない
+	if cfg.FXAPIURL != "" {
+		itemOpts = append(itemOpts, usecase.WithExchangeRateProvider(exchangerate.NewHTTPProvider(cfg.FXAPIURL, cfg.FXTimeout)))
+	}
+	if cachePurger != nil {
+		itemOpts = append(itemOpts, usecase.WithCachePurger(cachePurger))
+	}
+	// 検索エンジンが未設定の場合、キーワード検索はSQLで行う
+	var eventHandlers []usecase.ItemEventHandler
+	if searchIndex := s.newSearchIndex(ctx); searchIndex != nil {
+		components.SearchIndexer = usecase.NewSearchIndexer(searchIndex, itemRepo)
+		itemOpts = append(itemOpts, usecase.WithSearchIndex(searchIndex))
+		eventHandlers = append(eventHandlers, components.SearchIndexer)
+	}
+	// アイテムの変更を登録された Webhook に通知する（送信は API サーバーの runWebhookDelivery で行う）
+	var webhookSender usecase.WebhookSender = webhook.NewHTTPSender(cfg.WebhookTimeout)
+	if deps.FaultInjector != nil {
+		webhookSender = usecase.NewFaultWebhookSender(webhookSender, deps.FaultInjector)
+	}
+	components.Webhooks = usecase.NewWebhookUsecase(
+		&itemDatabase.WebhookRepository{SqlHandler: dbHandler},
+		&itemDatabase.WebhookDeliveryRepository{SqlHandler: dbHandler},
+		itemRepo,
+		webhookSender,
+		usecase.WithWebhookClock(deps.Clock),
+		usecase.WithWebhookReadOnlySwitch(deps.ReadOnly),
+		usecase.WithWebhookRetryPolicy(usecase.RetryPolicy{
+			MaxAttempts: cfg.WebhookMaxAttempts,
+			BaseDelay:   cfg.WebhookRetryBaseDelay,
+			MaxDelay:    cfg.WebhookRetryMaxDelay,
+		}),
+	)
+	eventHandlers = append(eventHandlers, components.Webhooks)
+	// 変更イベントはアウトボックスに記録し、API サーバーの runOutboxRelay で受け取り手に渡す
+	if cfg.OutboxEnabled {
+		outboxRepo := &itemDatabase.OutboxRepository{SqlHandler: dbHandler}
+		components.OutboxRelay = usecase.NewOutboxRelay(outboxRepo, eventHandlers,
+			usecase.WithOutboxClock(deps.Clock),
+			usecase.WithOutboxRetention(cfg.OutboxRetention),
+			usecase.WithOutboxRetryPolicy(usecase.RetryPolicy{
+				BaseDelay: cfg.OutboxRetryBaseDelay,
+				MaxDelay:  cfg.OutboxRetryMaxDelay,
+			}),
+		)
+		itemOpts = append(itemOpts, usecase.WithOutbox(outboxRepo, dbHandler))
+	} else {
+		for _, handler := range eventHandlers {
+			itemOpts = append(itemOpts, usecase.WithItemEventHandler(handler))
+		}
+	}
+	components.Usecase = usecase.NewItemUsecase(itemRepo, itemOpts...)
+	components.SummaryCache = usecase.NewSummaryCache(components.Usecase, cfg.PublicStatsTTL, summaryOpts...)
+
+	return components, nil
+}
+
+// 読み取りキャッシュなどの接続を閉じる
+func (c *ItemComponents) Close() error {
+	var errs []error
+	for _, closer := range c.closers {
+		errs = append(errs, closer.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// 写真の保存先を作成する（local で IMAGE_BASE_URL が未設定の場合、配信は API サーバーの /images で行う）
+func NewImageStorage(ctx context.Context, cfg *config.Config) (usecase.ImageStorage, error) {
+	switch cfg.ImageStorage {
+	case "local":
+		baseURL := cfg.ImageBaseURL
+		if baseURL == "" {
+			baseURL = localImagePath
+		}
+		return storage.NewLocalStorage(cfg.ImageLocalDir, baseURL)
+	case "s3":
+		return storage.NewS3Storage(ctx, cfg.ImageS3Bucket, cfg.ImageS3Region, cfg.ImageBaseURL)
+	default:
+		return nil, fmt.Errorf("invalid IMAGE_STORAGE: %s", cfg.ImageStorage)
+	}
+}
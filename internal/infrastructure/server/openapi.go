@@ -0,0 +1,169 @@
+package server
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/buildinfo"
+	"Aicon-assignment/internal/interfaces/controller/brands"
+	"Aicon-assignment/internal/interfaces/controller/budgets"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/system"
+	"Aicon-assignment/internal/interfaces/controller/valuations"
+	"Aicon-assignment/internal/interfaces/openapi"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 一覧・検索で共通のクエリパラメーター
+var listItemsQuery = []openapi.Parameter{
+	{Name: "limit", Type: "integer", Description: "取得件数（1〜100、デフォルト: 20）"},
+	{Name: "offset", Type: "integer", Description: "取得開始位置"},
+	{Name: "sort", Description: "並び順のキー（created_at, purchase_date, purchase_price, name, id, change_seq）"},
+	{Name: "order", Description: "asc / desc"},
+	{Name: "category", Description: "カテゴリーで絞り込む"},
+	{Name: "tag", Description: "タグで絞り込む"},
+	{Name: "uncategorized", Type: "boolean", Description: "未分類のアイテムのみ"},
+	{Name: "changed_after", Type: "integer", Description: "変更の通し番号（change_seq）がこの値より大きいアイテムのみ"},
+}
+
+// 構造体から生成できない型のスキーマ
+func apiTypes() map[reflect.Type]*openapi3.Schema {
+	return map[reflect.Type]*openapi3.Schema{
+		// null を指定すると値を空にする文字列
+		reflect.TypeOf(usecase.NullableString{}): openapi3.NewStringSchema().WithNullable(),
+		// YYYY-MM-DD 形式の日付（未入力の場合は空文字）
+		reflect.TypeOf(entity.Date{}): openapi3.NewStringSchema(),
+	}
+}
+
+// OpenAPI ドキュメントに記載する操作（キーはルーターに登録したメソッドとパス）
+// ハンドラーが受け取る・返す型を指定し、スキーマは型から生成する
+func apiOperations() map[string]openapi.Operation {
+	// 分割アップロードのIDはランダムな文字列
+	uploadPath := []openapi.Parameter{{Name: "uploadId", Type: "string"}}
+
+	operations := map[string]openapi.Operation{
+		"GET /health":  {Summary: "ヘルスチェック", Tag: "system"},
+		"GET /version": {Summary: "バージョン・ビルド情報", Tag: "system", Response: buildinfo.Info{}},
+
+		"GET /users/me/usage": {Summary: "自身（X-User-ID）のAPIの利用状況", Tag: "usage", Response: usecase.APIUsage{}},
+		"GET /admin/usage":    {Summary: "操作者ごとのAPIの利用状況", Tag: "admin", Response: usecase.APIUsageRollup{}, Errors: []int{http.StatusUnauthorized, http.StatusForbidden}},
+
+		"GET /public/stats": {Summary: "公開統計", Tag: "public", Response: usecase.PublicStats{}, Errors: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable}},
+
+		"GET /admin/read-only":               {Summary: "読み取り専用モードの状態取得", Tag: "admin", Response: system.ReadOnlyStatus{}, Errors: []int{http.StatusUnauthorized, http.StatusForbidden}},
+		"PUT /admin/read-only":               {Summary: "読み取り専用モードの切り替え", Tag: "admin", Request: system.ReadOnlyStatus{}, Response: system.ReadOnlyStatus{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden}},
+		"GET /admin/items":                   {Summary: "削除済みを含むアイテム一覧", Tag: "admin", Query: append(listItemsQuery, openapi.Parameter{Name: "include_deleted", Type: "boolean"}), Response: usecase.ItemList{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden}},
+		"PUT /admin/items/:id/hold":          {Summary: "アイテムの保全", Tag: "admin", Request: usecase.SetItemHoldInput{}, Response: entity.Item{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound}},
+		"POST /admin/items/cleanup-orphans":  {Summary: "削除済みアイテムのデータの削除", Tag: "admin", Query: []openapi.Parameter{{Name: "retention", Description: "削除からの保持期間（例: 720h）"}}, Response: usecase.OrphanCleanupResult{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden}},
+		"POST /admin/valuations/adjust":      {Summary: "評価額の一括調整", Tag: "admin", Request: usecase.AdjustValuationsInput{}, Response: usecase.ValuationAdjustment{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden}},
+		"POST /admin/summaries/recompute":    {Summary: "公開統計の再集計", Tag: "admin", Response: usecase.SummaryRecomputeResult{}, Errors: []int{http.StatusUnauthorized, http.StatusForbidden}},
+		"GET /admin/summaries/recompute":     {Summary: "公開統計の再集計の進み具合", Tag: "admin", Response: usecase.SummaryRecomputeProgress{}, Errors: []int{http.StatusUnauthorized, http.StatusForbidden}},
+		"GET /admin/brand-aliases":           {Summary: "ブランドの別名の一覧", Tag: "admin", Response: []entity.BrandAlias{}, Errors: []int{http.StatusUnauthorized, http.StatusForbidden}},
+		"PUT /admin/brand-aliases/:alias":    {Summary: "ブランドの別名の登録", Tag: "admin", Request: brands.SetBrandAliasRequest{}, Response: entity.BrandAlias{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusServiceUnavailable}},
+		"DELETE /admin/brand-aliases/:alias": {Summary: "ブランドの別名の削除", Tag: "admin", Status: http.StatusNoContent, Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusServiceUnavailable}},
+		"POST /admin/brands/normalize":       {Summary: "登録済みのアイテムのブランドの表記の統一", Tag: "admin", Response: usecase.BrandNormalizationResult{}, Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusServiceUnavailable}},
+		"POST /admin/search/reindex":         {Summary: "検索インデックスの作り直し", Tag: "admin", Response: usecase.SearchReindexResult{}, Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusServiceUnavailable}},
+		"GET /admin/jobs/runs":               {Summary: "定期実行の履歴（VALUATION_REFRESH_CRON の評価額の更新など）", Tag: "admin", Query: []openapi.Parameter{{Name: "limit", Type: "integer", Description: "件数（省略時 20、最大 100）"}}, Response: []entity.JobRun{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden}},
+		"GET /admin/dashboard/activity":      {Summary: "期間内に活動した操作者と操作者ごとの変更・登録の件数", Tag: "admin", Query: []openapi.Parameter{{Name: "window", Description: "直近の集計期間（省略時 720h）"}, {Name: "limit", Type: "integer", Description: "操作者の件数（省略時 20、最大 100）"}}, Response: usecase.DashboardActivity{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden}},
+		"GET /admin/dashboard/storage":       {Summary: "カテゴリーごとの写真の保存容量", Tag: "admin", Response: usecase.DashboardStorage{}, Errors: []int{http.StatusUnauthorized, http.StatusForbidden}},
+		"GET /admin/dashboard/webhooks":      {Summary: "Webhookごとの期間内の配信状況", Tag: "admin", Query: []openapi.Parameter{{Name: "window", Description: "直近の集計期間（省略時 720h）"}}, Response: usecase.DashboardWebhooks{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden}},
+		"GET /admin/faults":                  {Summary: "注入中の障害（FAULT_INJECTION_ENABLED でない場合は 503）", Tag: "admin", Response: []usecase.Fault{}, Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusServiceUnavailable}},
+		"PUT /admin/faults/:target":          {Summary: "依存先への障害の注入（FAULT_INJECTION_ENABLED でない場合は 503）", Tag: "admin", Request: usecase.SetFaultInput{}, Response: usecase.Fault{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusServiceUnavailable}},
+		"DELETE /admin/faults/:target":       {Summary: "障害の解除（FAULT_INJECTION_ENABLED でない場合は 503）", Tag: "admin", Status: http.StatusNoContent, Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusServiceUnavailable}},
+
+		"POST /admin/recategorization/scan":                    {Summary: "「その他」のアイテムのカテゴリーの推定（非同期、LLM_API_URL が未設定の場合は 503）", Tag: "admin", Status: http.StatusAccepted, Response: entity.Job{}, Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusServiceUnavailable}},
+		"GET /admin/recategorization/suggestions":              {Summary: "カテゴリーの変更の提案（古い順）", Tag: "admin", Query: []openapi.Parameter{{Name: "status", Description: "pending（省略時）, approved, rejected"}, {Name: "limit", Type: "integer", Description: "件数（省略時 50、最大 100）"}, {Name: "offset", Type: "integer"}}, Response: []entity.CategorySuggestion{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusServiceUnavailable}},
+		"POST /admin/recategorization/suggestions/:id/approve": {Summary: "提案の承認（アイテムのカテゴリーを変更する）", Tag: "admin", Response: entity.CategorySuggestion{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusLocked, http.StatusServiceUnavailable}},
+		"POST /admin/recategorization/suggestions/:id/reject":  {Summary: "提案の却下", Tag: "admin", Response: entity.CategorySuggestion{}, Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable}},
+
+		"GET /items":                   {Summary: "アイテム一覧取得", Tag: "items", Query: listItemsQuery, Response: usecase.ItemList{}, Errors: []int{http.StatusBadRequest}},
+		"POST /items":                  {Summary: "アイテム登録", Tag: "items", Query: []openapi.Parameter{{Name: "strict", Type: "boolean", Description: "重複するアイテムを拒否する"}}, Request: usecase.CreateItemInput{}, Status: http.StatusCreated, Response: usecase.ItemResult{}, Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusServiceUnavailable}},
+		"POST /items/bulk":             {Summary: "アイテム一括登録", Tag: "items", Request: usecase.BulkCreateItemsInput{}, Status: http.StatusCreated, Response: usecase.BulkCreateResult{}, Errors: []int{http.StatusBadRequest, http.StatusForbidden}},
+		"POST /items/import":           {Summary: "CSV/XLSXファイルからのインポート", Tag: "items", RequestType: echo.MIMEMultipartForm, Response: usecase.ImportResult{}, Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusRequestEntityTooLarge}},
+		"GET /items/export":            {Summary: "アイテムのエクスポート（CSV / XLSX / JSON）", Tag: "items", Query: []openapi.Parameter{{Name: "format", Description: "csv（省略時）, xlsx, json"}, {Name: "bom", Type: "boolean", Description: "Excel 向けに BOM を付ける"}, {Name: "locale", Description: "金額・日付の表記（ja-JP / en-US）"}}, ResponseType: "text/csv", Errors: []int{http.StatusBadRequest}},
+		"GET /items/search":            {Summary: "キーワード検索", Tag: "items", Query: append([]openapi.Parameter{{Name: "q", Required: true}}, listItemsQuery...), Response: usecase.ItemList{}, Errors: []int{http.StatusBadRequest}},
+		"GET /items/:id":               {Summary: "アイテム取得（If-None-Match が ETag と一致する場合は 304）", Tag: "items", Query: []openapi.Parameter{{Name: "as_of", Description: "指定した日（YYYY-MM-DD）の時点の状態を返す"}}, Response: entity.Item{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		"PATCH /items/:id":             {Summary: "アイテムの部分更新", Tag: "items", Request: usecase.UpdateItemInput{}, Response: usecase.ItemResult{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusLocked, http.StatusUnprocessableEntity, http.StatusServiceUnavailable}},
+		"DELETE /items/:id":            {Summary: "アイテム削除（purge_at 指定時は完全削除の予定を登録し 202 を返す）", Tag: "items", Query: []openapi.Parameter{{Name: "purge_at", Description: "完全削除の予定日時（RFC 3339 または YYYY-MM-DD）"}}, Status: http.StatusNoContent, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusLocked, http.StatusServiceUnavailable}},
+		"POST /items/:id/restore":      {Summary: "アイテムの復元", Tag: "items", Response: entity.Item{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		"GET /items/:id/depreciation":  {Summary: "減価償却", Tag: "items", Query: []openapi.Parameter{{Name: "method", Description: "straight / declining / custom"}, {Name: "years", Type: "integer"}, {Name: "rates", Description: "custom の年ごとの償却率（カンマ区切り）"}, {Name: "as_of", Description: "帳簿価額の基準日（YYYY-MM-DD）"}}, Response: usecase.Depreciation{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		"GET /items/:id/history":       {Summary: "変更履歴", Tag: "items", Response: []entity.AuditLog{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		"GET /items/:id/revisions":     {Summary: "版の一覧", Tag: "items", Response: []entity.ItemRevision{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		"POST /items/:id/revert":       {Summary: "指定した版に戻す", Tag: "items", Query: []openapi.Parameter{{Name: "version", Type: "integer", Required: true}}, Response: usecase.ItemResult{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusLocked, http.StatusUnprocessableEntity, http.StatusServiceUnavailable}},
+		"POST /items/:id/cancel-purge": {Summary: "完全削除の予定の取り消し", Tag: "items", Response: entity.Item{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusServiceUnavailable}},
+		"POST /items/:id/publish":      {Summary: "下書きの公開", Tag: "items", Response: usecase.ItemResult{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusLocked, http.StatusServiceUnavailable}},
+		"POST /items/:id/sell":         {Summary: "売却の記録（アイテムを売却済みにする）", Tag: "items", Request: usecase.SellItemInput{}, Status: http.StatusCreated, Response: usecase.SaleResult{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusLocked, http.StatusServiceUnavailable}},
+		"POST /items/:id/split":        {Summary: "構成品への分割（分割元は削除する）", Tag: "items", Request: usecase.SplitItemInput{}, Status: http.StatusCreated, Response: usecase.SplitResult{}, Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusLocked, http.StatusServiceUnavailable}},
+		"POST /items/:id/tags":         {Summary: "タグの追加", Tag: "items", Request: itemController.AddItemTagRequest{}, Response: entity.Item{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		"DELETE /items/:id/tags/:tag":  {Summary: "タグの削除", Tag: "items", Status: http.StatusNoContent, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		"GET /items/summary":           {Summary: "カテゴリー別集計", Tag: "summary", Response: usecase.CategorySummary{}},
+		"GET /items/summary/brands":    {Summary: "ブランド別集計", Tag: "summary", Response: usecase.BrandSummary{}},
+		"GET /items/summary/years":     {Summary: "購入年×カテゴリーの集計", Tag: "summary", Response: usecase.YearCategorySummary{}},
+
+		"POST /items/:id/uploads":                        {Summary: "分割アップロードの開始", Tag: "images", Request: usecase.CreateUploadInput{}, Status: http.StatusCreated, Response: entity.UploadSession{}, Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusTooManyRequests, http.StatusServiceUnavailable}},
+		"GET /items/:id/uploads/:uploadId":               {Summary: "分割アップロードの受信状況", Tag: "images", Path: uploadPath, Response: entity.UploadSession{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusTooManyRequests}},
+		"PATCH /items/:id/uploads/:uploadId":             {Summary: "分割アップロードの送信（Upload-Offset ヘッダーの位置から。全体を受信すると写真を登録して 201 を返す）", Tag: "images", Path: uploadPath, RequestType: "application/offset+octet-stream", Response: usecase.UploadChunkResult{}, Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusTooManyRequests, http.StatusServiceUnavailable}},
+		"DELETE /items/:id/uploads/:uploadId":            {Summary: "分割アップロードの中止", Tag: "images", Path: uploadPath, Status: http.StatusNoContent, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusTooManyRequests}},
+		"POST /items/:id/images":                         {Summary: "写真のアップロード", Tag: "images", RequestType: echo.MIMEMultipartForm, Status: http.StatusCreated, Response: entity.ItemImage{}, Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound}},
+		"POST /items/:id/images/direct-uploads":          {Summary: "写真の直接アップロード用の署名付きURLの発行（s3 のみ）", Tag: "images", Request: usecase.CreateDirectUploadInput{}, Status: http.StatusCreated, Response: usecase.DirectUpload{}, Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusServiceUnavailable}},
+		"POST /items/:id/images/direct-uploads/complete": {Summary: "直接アップロードした写真の登録（内容とサイズを確認する）", Tag: "images", Request: usecase.CompleteDirectUploadInput{}, Status: http.StatusCreated, Response: entity.ItemImage{}, Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusServiceUnavailable}},
+		"GET /items/:id/images":                          {Summary: "写真の一覧", Tag: "images", Response: []entity.ItemImage{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		"DELETE /items/:id/images/:imageId":              {Summary: "写真の削除", Tag: "images", Status: http.StatusNoContent, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		"POST /items/:id/images/:imageId/receipt":        {Summary: "領収書の写真の読み取り（購入価格・購入日・店名の候補、OCR_PROVIDER が未設定の場合は 503）", Tag: "images", Response: entity.ReceiptScan{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusBadGateway, http.StatusServiceUnavailable}},
+		"GET /items/:id/images/:imageId/receipt":         {Summary: "領収書の写真の読み取り結果", Tag: "images", Response: entity.ReceiptScan{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusServiceUnavailable}},
+		"POST /items/images/export":                      {Summary: "写真のzipエクスポート", Tag: "images", Request: usecase.ExportImagesInput{}, Response: usecase.ImageExport{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+
+		"GET /items/labels/layouts": {Summary: "ラベル用紙のレイアウトの一覧", Tag: "labels", Response: []entity.LabelLayout{}},
+		"POST /items/labels":        {Summary: "ラベルシート（PDF）", Tag: "labels", Request: usecase.GenerateLabelsInput{}, ResponseType: "application/pdf", Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+
+		"GET /items/insurance-schedules":           {Summary: "保険会社に提出する明細一式（format=xlsx の場合は明細ごとのシートのブック）", Tag: "insurance", Query: []openapi.Parameter{{Name: "template", Description: "書式の名前（省略時は standard）"}, {Name: "format", Description: "json / xlsx"}}, Response: usecase.InsuranceBundle{}, Errors: []int{http.StatusBadRequest}},
+		"GET /items/insurance-schedules/templates": {Summary: "保険の明細の書式の一覧", Tag: "insurance", Response: []entity.InsuranceTemplate{}},
+		"GET /items/insurance/expiring":            {Summary: "満期が近い保険の契約（満期日が今日から within 以内）", Tag: "insurance", Query: []openapi.Parameter{{Name: "within", Description: "満期までの日数（例: 30d、省略時は 30d、最大 366d）"}}, Response: usecase.ExpiringInsuranceReport{}, Errors: []int{http.StatusBadRequest}},
+		"GET /items/:id/insurance":                 {Summary: "アイテムの保険の契約（満期日の順）", Tag: "insurance", Response: []entity.InsurancePolicy{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		"POST /items/:id/insurance":                {Summary: "保険の契約の登録", Tag: "insurance", Request: usecase.InsurancePolicyInput{}, Status: http.StatusCreated, Response: entity.InsurancePolicy{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable}},
+		"PATCH /items/:id/insurance/:policyId":     {Summary: "保険の契約の更新（指定した項目のみ）", Tag: "insurance", Request: usecase.UpdateInsurancePolicyInput{}, Response: entity.InsurancePolicy{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable}},
+		"DELETE /items/:id/insurance/:policyId":    {Summary: "保険の契約の削除", Tag: "insurance", Status: http.StatusNoContent, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusServiceUnavailable}},
+
+		"POST /items/:id/valuations":         {Summary: "評価額の記録", Tag: "valuations", Request: valuations.RecordValuationRequest{}, Status: http.StatusCreated, Response: entity.Valuation{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		"GET /items/:id/valuations":          {Summary: "評価額の履歴", Tag: "valuations", Response: []entity.Valuation{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		"POST /items/:id/valuations/refresh": {Summary: "市場価格による評価額の記録", Tag: "valuations", Query: []openapi.Parameter{{Name: "async", Type: "boolean", Description: "ジョブとして登録して 202 とジョブ（Location: /jobs/{id}）を返す"}}, Status: http.StatusCreated, Response: entity.Valuation{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusBadGateway}},
+
+		"GET /budgets":              {Summary: "カテゴリー予算の一覧", Tag: "budgets", Response: []entity.CategoryBudget{}},
+		"PUT /budgets/:category":    {Summary: "カテゴリー予算の設定", Tag: "budgets", Request: budgets.SetBudgetRequest{}, Response: entity.CategoryBudget{}, Errors: []int{http.StatusBadRequest}},
+		"DELETE /budgets/:category": {Summary: "カテゴリー予算の削除", Tag: "budgets", Status: http.StatusNoContent, Errors: []int{http.StatusNotFound}},
+
+		"GET /reports/purchases/monthly": {Summary: "月別の購入推移", Tag: "reports", Query: []openapi.Parameter{{Name: "from", Description: "YYYY-MM"}, {Name: "to", Description: "YYYY-MM"}}, Response: usecase.MonthlyPurchaseReport{}, Errors: []int{http.StatusBadRequest}},
+		"GET /reports/tax":               {Summary: "購入年ごとの支払った税額", Tag: "reports", Query: []openapi.Parameter{{Name: "from", Description: "YYYY"}, {Name: "to", Description: "YYYY"}}, Response: usecase.TaxPaidReport{}, Errors: []int{http.StatusBadRequest}},
+		"GET /reports/pnl":               {Summary: "売却日の期間・カテゴリーごとの実現損益", Tag: "reports", Query: []openapi.Parameter{{Name: "period", Description: "month または year（省略時 month）"}, {Name: "from", Description: "YYYY-MM（period=year は YYYY）"}, {Name: "to", Description: "YYYY-MM（period=year は YYYY）"}}, Response: usecase.ProfitAndLossReport{}, Errors: []int{http.StatusBadRequest}},
+		"GET /reports/customs":           {Summary: "購入した国・地域と年ごとの申告額", Tag: "reports", Query: []openapi.Parameter{{Name: "from", Description: "YYYY"}, {Name: "to", Description: "YYYY"}, {Name: "country", Description: "ISO 3166-1 alpha-2"}}, Response: usecase.CustomsValueReport{}, Errors: []int{http.StatusBadRequest}},
+
+		"GET /webhooks":                {Summary: "登録したWebhookの一覧", Tag: "webhooks", Response: []entity.Webhook{}},
+		"POST /webhooks":               {Summary: "Webhookの登録（署名の鍵はこのレスポンスでのみ返す）", Tag: "webhooks", Request: usecase.CreateWebhookInput{}, Status: http.StatusCreated, Response: entity.Webhook{}, Errors: []int{http.StatusBadRequest}},
+		"DELETE /webhooks/:id":         {Summary: "Webhookの削除", Tag: "webhooks", Status: http.StatusNoContent, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		"GET /webhooks/:id/deliveries": {Summary: "Webhookの送信ログ（新しい順）", Tag: "webhooks", Response: []entity.WebhookDelivery{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+
+		"POST /exports":    {Summary: "全アイテムのエクスポートの作成（非同期）", Tag: "exports", Request: usecase.CreateExportInput{}, Status: http.StatusAccepted, Response: usecase.ItemExport{}, Errors: []int{http.StatusBadRequest, http.StatusServiceUnavailable}},
+		"GET /exports/:id": {Summary: "エクスポートの状況とダウンロードURL", Tag: "exports", Response: usecase.ItemExport{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+
+		"GET /jobs/:id": {Summary: "バックグラウンドジョブの状況と結果", Tag: "jobs", Response: entity.Job{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+
+		"GET /graphql":  {Summary: "GraphQL（クエリ）", Tag: "graphql", Query: []openapi.Parameter{{Name: "query", Required: true}}},
+		"POST /graphql": {Summary: "GraphQL", Tag: "graphql"},
+	}
+
+	// 書き込みのAPIはすべてレート制限の対象（WRITE_RATE_LIMIT_PER_IP など）
+	for key, operation := range operations {
+		if !strings.HasPrefix(key, "GET ") && !slices.Contains(operation.Errors, http.StatusTooManyRequests) {
+			operation.Errors = append(operation.Errors, http.StatusTooManyRequests)
+			operations[key] = operation
+		}
+	}
+	return operations
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/infrastructure/config"
+	"Aicon-assignment/internal/interfaces/openapi"
+	"Aicon-assignment/internal/interfaces/presenter"
+)
+
+func TestAPIOperations(t *testing.T) {
+	operations := apiOperations()
+
+	// 定義したすべての操作のスキーマを生成でき、ドキュメントとして妥当か
+	var routes []*echo.Route
+	for key := range operations {
+		method, path, ok := strings.Cut(key, " ")
+		require.True(t, ok, key)
+		routes = append(routes, &echo.Route{Method: method, Path: path})
+	}
+
+	spec, err := openapi.Build(openapi.Document{Title: "test", Version: "dev", Error: presenter.ErrorResponse{}, Types: apiTypes()}, routes, operations)
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(context.Background()))
+
+	item := spec.Paths.Find("/items/{id}").Get
+	require.NotNil(t, item)
+	schema := item.Responses.Status(200).Value.Content.Get("application/json").Schema.Value
+	assert.Contains(t, schema.Properties, "purchase_price")
+	assert.Contains(t, schema.Properties, "draft")
+	assert.NotContains(t, schema.Properties, "DedupeKey")
+}
+
+func TestRegisterRoutes(t *testing.T) {
+	// 任意の機能（検索エンジン・LLM・OCR・障害の注入）が未設定の既定の構成で登録したルートから API仕様を生成できるか
+	e := echo.New()
+	NewServer(&config.Config{}).registerRoutes(e, routeHandlers{})
+
+	spec, err := buildAPISpec(e.Routes(), "test")
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(context.Background()))
+
+	for _, path := range []string{"/admin/search/reindex", "/admin/recategorization/scan", "/admin/faults", "/items/{id}/images/{imageId}/receipt"} {
+		assert.NotNil(t, spec.Paths.Find(path), path)
+	}
+}
@@ -2,92 +2,1022 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"os"
-	"os/signal"
+	"sync"
 	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"golang.org/x/sync/errgroup"
 
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/infrastructure/buildinfo"
+	"Aicon-assignment/internal/infrastructure/cache"
+	"Aicon-assignment/internal/infrastructure/cdn"
+	"Aicon-assignment/internal/infrastructure/config"
 	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/infrastructure/exif"
+	"Aicon-assignment/internal/infrastructure/label"
+	"Aicon-assignment/internal/infrastructure/llm"
+	"Aicon-assignment/internal/infrastructure/marketprice"
+	"Aicon-assignment/internal/infrastructure/ocr"
+	"Aicon-assignment/internal/infrastructure/ratelimit"
+	searchInfra "Aicon-assignment/internal/infrastructure/search"
+	"Aicon-assignment/internal/infrastructure/storage"
+	"Aicon-assignment/internal/interfaces/controller/brands"
+	"Aicon-assignment/internal/interfaces/controller/budgets"
+	"Aicon-assignment/internal/interfaces/controller/dashboard"
+	"Aicon-assignment/internal/interfaces/controller/exports"
+	"Aicon-assignment/internal/interfaces/controller/faults"
+	"Aicon-assignment/internal/interfaces/controller/images"
+	"Aicon-assignment/internal/interfaces/controller/insurance"
 	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/jobs"
+	"Aicon-assignment/internal/interfaces/controller/labels"
+	"Aicon-assignment/internal/interfaces/controller/public"
+	"Aicon-assignment/internal/interfaces/controller/recategorization"
+	"Aicon-assignment/internal/interfaces/controller/receipts"
+	"Aicon-assignment/internal/interfaces/controller/reports"
+	"Aicon-assignment/internal/interfaces/controller/search"
 	"Aicon-assignment/internal/interfaces/controller/system"
+	"Aicon-assignment/internal/interfaces/controller/usage"
+	"Aicon-assignment/internal/interfaces/controller/valuations"
+	"Aicon-assignment/internal/interfaces/controller/webhooks"
 	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/interfaces/graph"
+	appMiddleware "Aicon-assignment/internal/interfaces/middleware"
+	"Aicon-assignment/internal/interfaces/openapi"
+	"Aicon-assignment/internal/interfaces/presenter"
 	"Aicon-assignment/internal/usecase"
 )
 
 // サーバー用の構造体
-type Server struct{}
+type Server struct {
+	config *config.Config
+}
 
-func NewServer() *Server {
-	return &Server{}
+func NewServer(cfg *config.Config) *Server {
+	return &Server{config: cfg}
 }
 
 // サーバー起動
 func (s *Server) Run(ctx context.Context) error {
 	e := echo.New()
+	e.Logger.SetLevel(echoLogLevel(s.config.LogLevel))
+	e.HTTPErrorHandler = presenter.HTTPErrorHandler
+	e.IPExtractor = ipExtractor(s.config.TrustedProxies)
+
+	info := buildinfo.Get()
+	fmt.Printf("📦 Version %s (commit %s, built %s, %s %s)\n", info.Version, info.Commit, info.BuildTime, info.GoVersion, info.Platform)
+
+	// リクエスト単位のアイテムキャッシュ
+	e.Use(appMiddleware.RequestCache())
+	e.Use(appMiddleware.Actor())
+
+	// 操作者ごとのAPIの利用状況
+	usageTracker := usecase.NewUsageTracker(usecase.DefaultMaxUsageActors)
+	e.Use(appMiddleware.Usage(usageTracker))
+
+	// 書き込みのAPIのレート制限（分割アップロードは UPLOAD_RATE_LIMIT で別に制限する）
+	if s.config.WriteRateLimitPerIP > 0 || s.config.WriteRateLimitPerUser > 0 {
+		rateLimitStore, err := s.newRateLimitStore()
+		if err != nil {
+			return err
+		}
+		if closer, ok := rateLimitStore.(io.Closer); ok {
+			defer closer.Close()
+		}
+		if s.config.RateLimitStore == "redis" {
+			rateLimitStore = appMiddleware.ResilientRateLimitStore(rateLimitStore, s.newCircuitBreaker("rate limit store", s.config.RateLimitTimeout))
+		}
+		e.Use(appMiddleware.WriteRateLimit(appMiddleware.WriteRateLimitConfig{
+			Store:     rateLimitStore,
+			PerIP:     s.config.WriteRateLimitPerIP,
+			PerUser:   s.config.WriteRateLimitPerUser,
+			Per:       time.Minute,
+			SkipPaths: []string{"/items/:id/uploads", "/items/:id/uploads/:uploadId"},
+		}))
+	}
+
+	// リクエストあたりのSQL実行回数の上限（N+1 の検出用）
+	if s.config.StatementBudget > 0 {
+		e.Use(appMiddleware.StatementBudget(s.config.StatementBudget, s.config.StatementBudgetMode == "block"))
+	}
+
+	// フォールトインジェクション（本番環境では無効）
+	if s.config.ChaosEnabled {
+		if s.config.IsProduction() {
+			fmt.Println("⚠️  CHAOS_ENABLED is ignored in production")
+		} else {
+			fmt.Println("⚠️  Chaos middleware enabled")
+			e.Use(appMiddleware.Chaos(appMiddleware.ChaosConfig{
+				LatencyRate: s.config.ChaosLatencyRate,
+				Latency:     s.config.ChaosLatency,
+				ErrorRate:   s.config.ChaosErrorRate,
+				ErrorStatus: s.config.ChaosErrorStatus,
+				DropRate:    s.config.ChaosDropRate,
+				SkipPaths:   []string{"/health"},
+			}))
+		}
+	}
+
+	// 依存先の障害の注入（本番環境では無効）
+	var faultInjector *usecase.FaultInjector
+	if s.config.FaultInjectionEnabled {
+		if s.config.IsProduction() {
+			fmt.Println("⚠️  FAULT_INJECTION_ENABLED is ignored in production")
+		} else {
+			fmt.Println("⚠️  Fault injection enabled (PUT /admin/faults/{target})")
+			faultInjector = usecase.NewFaultInjector()
+		}
+	}
+
+	// サンドボックス（データは毎日リセットされる）
+	if s.config.Sandbox {
+		fmt.Printf("⚠️  Sandbox mode: the database is reset daily at %s\n", s.config.SandboxResetAt)
+		e.Use(appMiddleware.Sandbox())
+	}
+
+	// カテゴリー固有の必須属性を登録
+	for category, keys := range s.config.CategoryRequiredAttributes {
+		for _, key := range keys {
+			entity.RegisterCategoryRule(category, entity.RequireAttribute(key))
+		}
+	}
 
 	// 依存性注入
-	dbHandler := databaseInfra.NewSqlHandler()
+	dbHandler := databaseInfra.NewSqlHandler(s.config)
 	defer dbHandler.Close()
+	// 実行計画の確認は SQL の実行回数に含めない
+	if s.config.ExplainSampleRate > 0 {
+		dbHandler = itemDatabase.NewExplainSqlHandler(dbHandler, s.config.ExplainSampleRate)
+	}
+	if s.config.StatementBudget > 0 {
+		dbHandler = itemDatabase.NewBudgetedSqlHandler(dbHandler)
+	}
+	if faultInjector != nil {
+		dbHandler = itemDatabase.NewFaultSqlHandler(dbHandler, func() error { return faultInjector.Check(usecase.FaultRepository) })
+	}
+
+	budgetRepo := &itemDatabase.BudgetRepository{SqlHandler: dbHandler}
+	valuationRepo := &itemDatabase.ValuationRepository{SqlHandler: dbHandler}
+	saleRepo := &itemDatabase.SaleRepository{SqlHandler: dbHandler}
 
-	itemRepo := &itemDatabase.ItemRepository{
-		SqlHandler: dbHandler,
+	readOnly := usecase.NewReadOnlySwitch(s.config.ReadOnly)
+	if s.config.ReadOnly {
+		fmt.Println("⚠️  Starting in read-only mode")
 	}
 
-	itemUsecase := usecase.NewItemUsecase(itemRepo)
+	imageStorage, err := s.newImageStorage(ctx, e)
+	if err != nil {
+		return err
+	}
+	// 直接アップロードの署名付きURLの発行は障害の注入の対象外
+	directStorage, directUploads := imageStorage.(usecase.DirectUploadStorage)
+	if faultInjector != nil {
+		imageStorage = usecase.NewFaultImageStorage(imageStorage, faultInjector)
+	}
+
+	// 作成日時・有効期限・「今日」の日付などの基準にする現在時刻（ユースケース間で共有する）
+	clock := entity.SystemClock
+
+	// アイテムのユースケース（読み取りキャッシュ・CDN・検索・Webhook・アウトボックスを含む。itemctl と共通）
+	items, err := NewItemComponents(ctx, s.config, ItemDependencies{
+		DBHandler:     dbHandler,
+		ImageStorage:  imageStorage,
+		Clock:         clock,
+		ReadOnly:      readOnly,
+		FaultInjector: faultInjector,
+	})
+	if err != nil {
+		return err
+	}
+	defer items.Close()
+	itemUsecase := items.Usecase
+	itemRepo := items.Repo
+	imageRepo := items.ImageRepo
+	cachePurger := items.CachePurger
+	budgetUsecase := usecase.NewBudgetUsecase(budgetRepo, readOnly)
+
+	uploadStore, err := storage.NewLocalUploadStore(s.config.UploadDir)
+	if err != nil {
+		return err
+	}
+	imageOpts := []usecase.ImageUsecaseOption{
+		usecase.WithImageClock(clock),
+		usecase.WithImageReadOnlySwitch(readOnly),
+		usecase.WithMaxImageSize(int64(s.config.ImageMaxSize)),
+		usecase.WithExportURLExpiry(s.config.ImageExportURLExpiry),
+		usecase.WithImageQuota(items.Quota),
+		usecase.WithUploadSessions(uploadStore, int64(s.config.UploadMaxSize), s.config.UploadTTL),
+		usecase.WithImageMetadataReader(exif.NewReader()),
+	}
+	// 署名付きURLを発行できる保存先（s3）の場合のみ直接アップロードを受け付ける
+	if directUploads {
+		imageOpts = append(imageOpts, usecase.WithDirectUploads(directStorage, s.config.ImageUploadURLExpiry))
+	}
+	imageUsecase := usecase.NewImageUsecase(itemRepo, imageRepo, imageStorage, imageOpts...)
+	// OCR が未設定の場合、領収書の写真の読み取りは使わない
+	receiptReader, err := s.newReceiptReader()
+	if err != nil {
+		return err
+	}
+	var receiptUsecase usecase.ReceiptUsecase
+	if receiptReader != nil {
+		receiptUsecase = usecase.NewReceiptUsecase(itemRepo, imageRepo, imageStorage, &itemDatabase.ReceiptScanRepository{SqlHandler: dbHandler}, receiptReader,
+			usecase.WithReceiptClock(clock),
+			usecase.WithReceiptReadOnlySwitch(readOnly),
+			usecase.WithOCRTimeout(s.config.OCRTimeout),
+		)
+	}
+
+	systemHandler := system.NewSystemHandler(readOnly)
+	itemHandler := itemController.NewItemHandler(itemUsecase, s.config.OrphanRetention)
+	budgetHandler := budgets.NewBudgetHandler(budgetUsecase)
+	brandHandler := brands.NewBrandHandler(usecase.NewBrandAliasUsecase(items.BrandAliasRepo, items.BrandNormalizer, readOnly), itemUsecase)
+	reportHandler := reports.NewReportHandler(usecase.NewReportUsecase(itemRepo, saleRepo))
+	imageHandler := images.NewImageHandler(imageUsecase)
+	receiptHandler := receipts.NewReceiptHandler(receiptUsecase)
+	labelRenderer, err := label.NewPDFRenderer(s.config.LabelFontPath)
+	if err != nil {
+		return err
+	}
+	labelHandler := labels.NewLabelHandler(usecase.NewLabelUsecase(itemRepo, labelRenderer, s.config.LabelQRBaseURL))
+	insuranceTemplates, err := s.insuranceTemplates()
+	if err != nil {
+		return err
+	}
+	insuranceHandler := insurance.NewInsuranceHandler(usecase.NewInsuranceUsecase(itemRepo, valuationRepo, insuranceTemplates, clock))
+	insurancePolicyHandler := insurance.NewInsurancePolicyHandler(usecase.NewInsurancePolicyUsecase(itemRepo, &itemDatabase.InsurancePolicyRepository{SqlHandler: dbHandler}, readOnly, clock))
+	valuationOpts := []usecase.ValuationUsecaseOption{
+		usecase.WithValuationClock(clock),
+		usecase.WithValuationTransactor(dbHandler),
+		usecase.WithPriceTimeout(s.config.PriceAPITimeout),
+		usecase.WithPriceRetryPolicy(usecase.RetryPolicy{
+			MaxAttempts: s.config.PriceAPIMaxAttempts,
+			BaseDelay:   usecase.DefaultPriceRetryPolicy.BaseDelay,
+			MaxDelay:    usecase.DefaultPriceRetryPolicy.MaxDelay,
+		}),
+	}
+	// 相場APIが未設定の場合、市場価格による評価額の更新はできない
+	if s.config.PriceAPIURL != "" {
+		valuationOpts = append(valuationOpts, usecase.WithPriceProvider(marketprice.NewHTTPProvider(s.config.PriceAPIURL, s.config.PriceAPIKey, s.config.PriceAPITimeout)))
+	}
+	if cachePurger != nil {
+		valuationOpts = append(valuationOpts, usecase.WithValuationCachePurger(cachePurger))
+	}
+	valuationUsecase := usecase.NewValuationUsecase(itemRepo, valuationRepo, readOnly, valuationOpts...)
+	// 時間のかかる処理はジョブとして登録し、runJobWorkers のワーカーが実行する
+	jobOpts := []usecase.JobUsecaseOption{
+		usecase.WithJobClock(clock),
+		usecase.WithJobReadOnlySwitch(readOnly),
+		usecase.WithJobLease(s.config.JobLease),
+		usecase.WithJobRetryPolicy(usecase.RetryPolicy{
+			MaxAttempts: s.config.JobMaxAttempts,
+			BaseDelay:   s.config.JobRetryBaseDelay,
+			MaxDelay:    s.config.JobRetryMaxDelay,
+		}),
+		usecase.WithJobHandler(usecase.JobValuationRefresh, usecase.NewValuationRefreshJobHandler(valuationUsecase)),
+		usecase.WithJobHandler(usecase.JobItemsExport, usecase.NewItemExportJobHandler(itemUsecase, imageStorage)),
+	}
+	// LLM が未設定の場合、「その他」のアイテムのカテゴリーの変更の提案は使わない
+	var recategorizationUsecase usecase.RecategorizationUsecase
+	if s.config.LLMAPIURL != "" {
+		classifier := llm.NewHTTPClassifier(s.config.LLMAPIURL, s.config.LLMAPIKey, s.config.LLMModel, s.config.LLMTimeout)
+		recategorizationUsecase = usecase.NewRecategorizationUsecase(itemRepo, &itemDatabase.CategorySuggestionRepository{SqlHandler: dbHandler}, itemUsecase, classifier,
+			usecase.WithRecategorizationClock(clock),
+			usecase.WithRecategorizationReadOnlySwitch(readOnly),
+			usecase.WithClassifierTimeout(s.config.LLMTimeout),
+			usecase.WithMinSuggestionConfidence(s.config.RecategorizeMinConfidence),
+		)
+		jobOpts = append(jobOpts, usecase.WithJobHandler(usecase.JobItemsRecategorize, usecase.NewRecategorizationJobHandler(recategorizationUsecase)))
+	}
+	jobUsecase := usecase.NewJobUsecase(&itemDatabase.JobRepository{SqlHandler: dbHandler}, jobOpts...)
+	valuationHandler := valuations.NewValuationHandler(valuationUsecase, jobUsecase)
+	recategorizationHandler := recategorization.NewRecategorizationHandler(recategorizationUsecase, jobUsecase)
+	// VALUATION_REFRESH_CRON の予定に従って runValuationRefreshSchedule が実行する
+	scheduledJobUsecase := usecase.NewScheduledJobUsecase(itemRepo, &itemDatabase.JobRunRepository{SqlHandler: dbHandler}, valuationUsecase,
+		usecase.WithScheduledJobClock(clock),
+		usecase.WithScheduledJobReadOnlySwitch(readOnly),
+	)
+	jobHandler := jobs.NewJobHandler(jobUsecase, scheduledJobUsecase)
+	exportHandler := exports.NewExportHandler(usecase.NewExportUsecase(jobUsecase, imageStorage, s.config.ExportURLExpiry, clock))
+	usageHandler := usage.NewUsageHandler(usageTracker)
+	dashboardHandler := dashboard.NewDashboardHandler(usecase.NewDashboardUsecase(&itemDatabase.DashboardRepository{SqlHandler: dbHandler}, clock))
+	webhookHandler := webhooks.NewWebhookHandler(items.Webhooks)
+	searchHandler := search.NewSearchHandler(items.SearchIndexer)
+	publicHandler := public.NewPublicHandler(items.SummaryCache, s.config.PublicStatsTTL)
+
+	faultHandler := faults.NewFaultHandler(faultInjector)
+	s.registerRoutes(e, routeHandlers{
+		system:           systemHandler,
+		public:           publicHandler,
+		usage:            usageHandler,
+		item:             itemHandler,
+		valuation:        valuationHandler,
+		brand:            brandHandler,
+		search:           searchHandler,
+		job:              jobHandler,
+		dashboard:        dashboardHandler,
+		recategorization: recategorizationHandler,
+		fault:            faultHandler,
+		image:            imageHandler,
+		label:            labelHandler,
+		insurance:        insuranceHandler,
+		insurancePolicy:  insurancePolicyHandler,
+		receipt:          receiptHandler,
+		budget:           budgetHandler,
+		report:           reportHandler,
+		webhook:          webhookHandler,
+		export:           exportHandler,
+		graphql:          echo.WrapHandler(graph.NewHandler(itemUsecase, imageUsecase, valuationUsecase)),
+		responseCache:    items.ResponseCache,
+	})
+
+	// API仕様（登録済みのルートとハンドラーが受け取る・返す型から生成する）
+	spec, err := buildAPISpec(e.Routes(), info.Version)
+	if err != nil {
+		return fmt.Errorf("failed to build OpenAPI document: %w", err)
+	}
+	docsHandler, err := openapi.NewHandler(spec)
+	if err != nil {
+		return fmt.Errorf("failed to build OpenAPI document: %w", err)
+	}
+	e.GET("/openapi.json", docsHandler.Spec) // GET /openapi.json
+	e.GET("/docs", docsHandler.Docs)         // GET /docs (Swagger UI)
 
-	systemHandler := system.NewSystemHandler()
-	itemHandler := itemController.NewItemHandler(itemUsecase)
+	// サーバーと定期実行のジョブは、コンテキストのキャンセル（シグナル受信）かいずれかのエラーで終了する
+	// Run から戻るときにはすべて終了しているため、DB接続は処理中のリクエストを待ってから閉じられる
+	g, ctx := errgroup.WithContext(ctx)
 
+	// 削除から保持期間が経過したアイテムの紐づくデータを定期的に削除する
+	if s.config.OrphanCleanupInterval > 0 {
+		g.Go(func() error {
+			runOrphanCleanup(ctx, itemUsecase, s.config.OrphanRetention, s.config.OrphanCleanupInterval)
+			return nil
+		})
+	}
+
+	// 期限切れの分割アップロードを定期的に削除する
+	if s.config.UploadCleanupInterval > 0 {
+		g.Go(func() error {
+			runUploadCleanup(ctx, imageUsecase, s.config.UploadCleanupInterval)
+			return nil
+		})
+	}
+
+	// 予定日時を過ぎたアイテムを定期的に完全削除する
+	if s.config.PurgeInterval > 0 {
+		g.Go(func() error {
+			runScheduledPurge(ctx, itemUsecase, s.config.PurgeInterval)
+			return nil
+		})
+	}
+
+	// 送信待ちの Webhook を定期的に（新しいイベントがあった場合はすぐに）送信する
+	if s.config.WebhookDeliveryInterval > 0 {
+		g.Go(func() error {
+			runWebhookDelivery(ctx, items.Webhooks, s.config.WebhookDeliveryInterval)
+			return nil
+		})
+	}
+
+	// アウトボックスに記録した変更イベントを定期的に受け取り手に渡す
+	if items.OutboxRelay != nil {
+		g.Go(func() error {
+			runOutboxRelay(ctx, items.OutboxRelay, s.config.OutboxRelayInterval)
+			return nil
+		})
+	}
+
+	// 登録されたジョブをワーカーで並行して実行する
+	if s.config.JobWorkers > 0 {
+		g.Go(func() error {
+			runJobWorkers(ctx, jobUsecase, s.config.JobWorkers, s.config.JobPollInterval)
+			return nil
+		})
+	}
+
+	// 全アイテムの評価額を設定した予定に従って更新する
+	if s.config.ValuationRefreshCron != "" {
+		g.Go(func() error {
+			runValuationRefreshSchedule(ctx, scheduledJobUsecase, s.config.ValuationRefreshCron)
+			return nil
+		})
+	}
+
+	// サンドボックスのデータベースを毎日サンプルデータの状態に戻す
+	if s.config.Sandbox {
+		g.Go(func() error {
+			s.runSandboxReset(ctx)
+			return nil
+		})
+	}
+
+	s.serve(ctx, g, e)
+	return g.Wait()
+}
+
+// ルートに登録するハンドラー
+// 任意の機能（検索エンジン・LLM・OCR・障害の注入）が未設定の場合も、ハンドラーが 503 を返すためルートは常に登録する
+type routeHandlers struct {
+	system           *system.SystemHandler
+	public           *public.PublicHandler
+	usage            *usage.UsageHandler
+	item             *itemController.ItemHandler
+	valuation        *valuations.ValuationHandler
+	brand            *brands.BrandHandler
+	search           *search.SearchHandler
+	job              *jobs.JobHandler
+	dashboard        *dashboard.DashboardHandler
+	recategorization *recategorization.RecategorizationHandler
+	fault            *faults.FaultHandler
+	image            *images.ImageHandler
+	label            *labels.LabelHandler
+	insurance        *insurance.InsuranceHandler
+	insurancePolicy  *insurance.InsurancePolicyHandler
+	receipt          *receipts.ReceiptHandler
+	budget           *budgets.BudgetHandler
+	report           *reports.ReportHandler
+	webhook          *webhooks.WebhookHandler
+	export           *exports.ExportHandler
+	graphql          echo.HandlerFunc
+
+	// アイテムの取得の ETag に使うレスポンスのキャッシュ（未設定の場合は nil）
+	responseCache *usecase.ItemResponseCache
+}
+
+// API のルートを登録する（/openapi.json と /docs は、登録したルートから API仕様を生成してから登録する）
+func (s *Server) registerRoutes(e *echo.Echo, h routeHandlers) {
 	// ヘルスチェック
 	e.GET("/health", func(c echo.Context) error {
-		systemHandler.Health(c)
+		h.system.Health(c)
 		return nil
 	})
 
+	// バージョン情報
+	e.GET("/version", h.system.Version)
+
+	// 認証なしの公開エンドポイント（他のルートとは独立したレート制限をかける）
+	publicGroup := e.Group("/public", appMiddleware.RateLimit(s.config.PublicRateLimit, time.Minute))
+	{
+		publicGroup.GET("/stats", h.public.GetStats) // GET /public/stats
+	}
+
+	// 操作者（X-User-ID）自身の情報
+	e.GET("/users/me/usage", h.usage.GetMyUsage) // GET /users/me/usage
+
+	// 管理者用エンドポイント
+	adminGroup := e.Group("/admin", appMiddleware.AdminToken(s.config.AdminToken))
+	{
+		adminGroup.GET("/read-only", h.system.GetReadOnly)                   // GET /admin/read-only
+		adminGroup.PUT("/read-only", h.system.SetReadOnly)                   // PUT /admin/read-only
+		adminGroup.GET("/items", h.item.GetItemsForAdmin)                    // GET /admin/items?include_deleted=true
+		adminGroup.PUT("/items/:id/hold", h.item.SetItemHold)                // PUT /admin/items/:id/hold
+		adminGroup.POST("/items/cleanup-orphans", h.item.CleanupOrphans)     // POST /admin/items/cleanup-orphans?retention=720h
+		adminGroup.POST("/valuations/adjust", h.valuation.AdjustValuations)  // POST /admin/valuations/adjust
+		adminGroup.POST("/summaries/recompute", h.public.RecomputeStats)     // POST /admin/summaries/recompute
+		adminGroup.GET("/summaries/recompute", h.public.RecomputeProgress)   // GET /admin/summaries/recompute
+		adminGroup.GET("/usage", h.usage.GetUsage)                           // GET /admin/usage
+		adminGroup.GET("/brand-aliases", h.brand.GetBrandAliases)            // GET /admin/brand-aliases
+		adminGroup.PUT("/brand-aliases/:alias", h.brand.SetBrandAlias)       // PUT /admin/brand-aliases/{alias}
+		adminGroup.DELETE("/brand-aliases/:alias", h.brand.DeleteBrandAlias) // DELETE /admin/brand-aliases/{alias}
+		adminGroup.POST("/brands/normalize", h.brand.NormalizeBrands)        // POST /admin/brands/normalize
+		adminGroup.POST("/search/reindex", h.search.Reindex)                 // POST /admin/search/reindex
+		adminGroup.GET("/jobs/runs", h.job.GetJobRuns)                       // GET /admin/jobs/runs?limit=20
+		adminGroup.GET("/dashboard/activity", h.dashboard.GetActivity)       // GET /admin/dashboard/activity?window=720h&limit=20
+		adminGroup.GET("/dashboard/storage", h.dashboard.GetStorage)         // GET /admin/dashboard/storage
+		adminGroup.GET("/dashboard/webhooks", h.dashboard.GetWebhookHealth)  // GET /admin/dashboard/webhooks?window=720h
+	}
+	// カテゴリーの変更の提案（LLM_API_URL が未設定の場合は 503）
+	{
+		adminGroup.POST("/recategorization/scan", h.recategorization.Scan)                                 // POST /admin/recategorization/scan
+		adminGroup.GET("/recategorization/suggestions", h.recategorization.GetSuggestions)                 // GET /admin/recategorization/suggestions?status=pending
+		adminGroup.POST("/recategorization/suggestions/:id/approve", h.recategorization.ApproveSuggestion) // POST /admin/recategorization/suggestions/{id}/approve
+		adminGroup.POST("/recategorization/suggestions/:id/reject", h.recategorization.RejectSuggestion)   // POST /admin/recategorization/suggestions/{id}/reject
+	}
+	// 障害の注入（FAULT_INJECTION_ENABLED でない場合は 503）
+	{
+		adminGroup.GET("/faults", h.fault.GetFaults)             // GET /admin/faults
+		adminGroup.PUT("/faults/:target", h.fault.SetFault)      // PUT /admin/faults/{target}
+		adminGroup.DELETE("/faults/:target", h.fault.ClearFault) // DELETE /admin/faults/{target}
+	}
+
 	// アイテムに関するエンドポイント
 	itemsGroup := e.Group("/items")
+	// 分割アップロードは1ファイルで多数のリクエストになるため、他のAPIとは別に上限を設ける
+	var uploadRateLimit []echo.MiddlewareFunc
+	if s.config.UploadRateLimit > 0 {
+		uploadRateLimit = append(uploadRateLimit, appMiddleware.RateLimit(s.config.UploadRateLimit, time.Minute))
+	}
+	{
+		itemsGroup.GET("", h.item.GetItems)                                                   // GET /items
+		itemsGroup.POST("", h.item.CreateItem)                                                // POST /items
+		itemsGroup.POST("/bulk", h.item.CreateItems)                                          // POST /items/bulk
+		itemsGroup.POST("/import", h.item.ImportItems)                                        // POST /items/import (multipart)
+		itemsGroup.GET("/export", h.item.ExportItems)                                         // GET /items/export?format=csv
+		itemsGroup.GET("/search", h.item.SearchItems)                                         // GET /items/search?q=
+		itemsGroup.POST("/images/export", h.image.ExportImages)                               // POST /items/images/export
+		itemsGroup.GET("/labels/layouts", h.label.GetLabelLayouts)                            // GET /items/labels/layouts
+		itemsGroup.POST("/labels", h.label.GenerateLabels)                                    // POST /items/labels (PDF)
+		itemsGroup.GET("/insurance-schedules", h.insurance.GetSchedules)                      // GET /items/insurance-schedules?template=&format=xlsx
+		itemsGroup.GET("/insurance-schedules/templates", h.insurance.GetTemplates)            // GET /items/insurance-schedules/templates
+		itemsGroup.GET("/insurance/expiring", h.insurancePolicy.GetExpiringPolicies)          // GET /items/insurance/expiring?within=30d
+		itemsGroup.GET("/:id", h.item.GetItem, appMiddleware.ItemETag(h.responseCache))       // GET /items/{id} (ETag / If-None-Match)
+		itemsGroup.PATCH("/:id", h.item.UpdateItem)                                           // PATCH /items/{id}
+		itemsGroup.DELETE("/:id", h.item.DeleteItem)                                          // DELETE /items/{id}
+		itemsGroup.POST("/:id/restore", h.item.RestoreItem)                                   // POST /items/{id}/restore
+		itemsGroup.GET("/:id/depreciation", h.item.GetDepreciation)                           // GET /items/{id}/depreciation?method=straight&years=5
+		itemsGroup.GET("/:id/history", h.item.GetItemHistory)                                 // GET /items/{id}/history
+		itemsGroup.GET("/:id/revisions", h.item.GetItemRevisions)                             // GET /items/{id}/revisions
+		itemsGroup.POST("/:id/revert", h.item.RevertItem)                                     // POST /items/{id}/revert?version=N
+		itemsGroup.POST("/:id/publish", h.item.PublishItem)                                   // POST /items/{id}/publish
+		itemsGroup.POST("/:id/cancel-purge", h.item.CancelPurge)                              // POST /items/{id}/cancel-purge
+		itemsGroup.POST("/:id/sell", h.item.SellItem)                                         // POST /items/{id}/sell
+		itemsGroup.POST("/:id/split", h.item.SplitItem)                                       // POST /items/{id}/split
+		itemsGroup.POST("/:id/images", h.image.UploadImage)                                   // POST /items/{id}/images (multipart)
+		itemsGroup.POST("/:id/images/direct-uploads", h.image.CreateDirectUpload)             // POST /items/{id}/images/direct-uploads
+		itemsGroup.POST("/:id/images/direct-uploads/complete", h.image.CompleteDirectUpload)  // POST /items/{id}/images/direct-uploads/complete
+		itemsGroup.GET("/:id/images", h.image.GetImages)                                      // GET /items/{id}/images
+		itemsGroup.POST("/:id/uploads", h.image.CreateUpload, uploadRateLimit...)             // POST /items/{id}/uploads
+		itemsGroup.GET("/:id/uploads/:uploadId", h.image.GetUpload, uploadRateLimit...)       // GET /items/{id}/uploads/{uploadId}
+		itemsGroup.PATCH("/:id/uploads/:uploadId", h.image.AppendUpload, uploadRateLimit...)  // PATCH /items/{id}/uploads/{uploadId} (Upload-Offset)
+		itemsGroup.DELETE("/:id/uploads/:uploadId", h.image.CancelUpload, uploadRateLimit...) // DELETE /items/{id}/uploads/{uploadId}
+		itemsGroup.DELETE("/:id/images/:imageId", h.image.DeleteImage)                        // DELETE /items/{id}/images/{imageId}
+		itemsGroup.POST("/:id/valuations", h.valuation.RecordValuation)                       // POST /items/{id}/valuations
+		itemsGroup.GET("/:id/valuations", h.valuation.GetValuations)                          // GET /items/{id}/valuations
+		itemsGroup.POST("/:id/valuations/refresh", h.valuation.RefreshValuation)              // POST /items/{id}/valuations/refresh
+		itemsGroup.GET("/:id/insurance", h.insurancePolicy.GetPolicies)                       // GET /items/{id}/insurance
+		itemsGroup.POST("/:id/insurance", h.insurancePolicy.AddPolicy)                        // POST /items/{id}/insurance
+		itemsGroup.PATCH("/:id/insurance/:policyId", h.insurancePolicy.UpdatePolicy)          // PATCH /items/{id}/insurance/{policyId}
+		itemsGroup.DELETE("/:id/insurance/:policyId", h.insurancePolicy.DeletePolicy)         // DELETE /items/{id}/insurance/{policyId}
+		itemsGroup.POST("/:id/tags", h.item.AddItemTag)                                       // POST /items/{id}/tags
+		itemsGroup.DELETE("/:id/tags/:tag", h.item.RemoveItemTag)                             // DELETE /items/{id}/tags/{tag}
+		itemsGroup.GET("/summary", h.item.GetSummary)                                         // GET /items/summary (bonus)
+		itemsGroup.GET("/summary/brands", h.item.GetBrandSummary)                             // GET /items/summary/brands
+		itemsGroup.GET("/summary/years", h.item.GetYearCategorySummary)                       // GET /items/summary/years
+	}
+	// 領収書の写真の読み取り（OCR_PROVIDER が未設定の場合は 503）
 	{
-		itemsGroup.GET("", itemHandler.GetItems)           // GET /items
-		itemsGroup.POST("", itemHandler.CreateItem)        // POST /items
-		itemsGroup.GET("/:id", itemHandler.GetItem)        // GET /items/{id}
-		itemsGroup.PATCH("/:id", itemHandler.UpdateItem)   // PATCH /items/{id}
-		itemsGroup.DELETE("/:id", itemHandler.DeleteItem)  // DELETE /items/{id}
-		itemsGroup.GET("/summary", itemHandler.GetSummary) // GET /items/summary (bonus)
+		itemsGroup.POST("/:id/images/:imageId/receipt", h.receipt.ScanReceipt) // POST /items/{id}/images/{imageId}/receipt
+		itemsGroup.GET("/:id/images/:imageId/receipt", h.receipt.GetReceipt)   // GET /items/{id}/images/{imageId}/receipt
 	}
 
-	return s.startWithGracefulShutdown(ctx, e)
+	// カテゴリー予算に関するエンドポイント
+	budgetsGroup := e.Group("/budgets")
+	{
+		budgetsGroup.GET("", h.budget.GetBudgets)                // GET /budgets
+		budgetsGroup.PUT("/:category", h.budget.SetBudget)       // PUT /budgets/{category}
+		budgetsGroup.DELETE("/:category", h.budget.DeleteBudget) // DELETE /budgets/{category}
+	}
+
+	// レポートに関するエンドポイント
+	reportsGroup := e.Group("/reports")
+	{
+		reportsGroup.GET("/purchases/monthly", h.report.GetMonthlyPurchases) // GET /reports/purchases/monthly?from=&to=
+		reportsGroup.GET("/customs", h.report.GetCustomsValues)              // GET /reports/customs?from=&to=&country=
+		reportsGroup.GET("/tax", h.report.GetTaxPaid)                        // GET /reports/tax?from=&to=
+		reportsGroup.GET("/pnl", h.report.GetProfitAndLoss)                  // GET /reports/pnl?period=&from=&to=
+	}
+
+	// Webhook に関するエンドポイント（操作者（X-User-ID）ごとに登録する）
+	webhooksGroup := e.Group("/webhooks")
+	{
+		webhooksGroup.GET("", h.webhook.GetWebhooks)                  // GET /webhooks
+		webhooksGroup.POST("", h.webhook.CreateWebhook)               // POST /webhooks
+		webhooksGroup.DELETE("/:id", h.webhook.DeleteWebhook)         // DELETE /webhooks/{id}
+		webhooksGroup.GET("/:id/deliveries", h.webhook.GetDeliveries) // GET /webhooks/{id}/deliveries
+	}
+
+	// バックグラウンドジョブの状況（操作者（X-User-ID）が登録したもののみ）
+	e.GET("/jobs/:id", h.job.GetJob) // GET /jobs/{id}
+
+	// 全アイテムの非同期のエクスポート（操作者（X-User-ID）が作成したもののみ参照できる）
+	e.POST("/exports", h.export.CreateExport) // POST /exports
+	e.GET("/exports/:id", h.export.GetExport) // GET /exports/{id}
+
+	// GraphQL エンドポイント（REST API と同じユースケースを使う）
+	e.GET("/graphql", h.graphql)  // GET /graphql?query=
+	e.POST("/graphql", h.graphql) // POST /graphql
 }
 
-func (s *Server) startWithGracefulShutdown(ctx context.Context, e *echo.Echo) error {
-	go func() {
-		port := ":8080"
-		fmt.Printf("🚀 Server starting on port %s\n", port)
+// 登録済みのルートとハンドラーが受け取る・返す型から API仕様を生成する
+// apiOperations に記載した操作がルートに登録されていない場合はエラーになる
+func buildAPISpec(routes []*echo.Route, version string) (*openapi3.T, error) {
+	return openapi.Build(openapi.Document{
+		Title:   "所持品管理API",
+		Version: version,
+		Error:   presenter.ErrorResponse{},
+		Types:   apiTypes(),
+	}, routes, apiOperations())
+}
+
+func runOrphanCleanup(ctx context.Context, itemUsecase usecase.ItemUsecase, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-		if err := e.Start(port); err != nil && err != http.ErrServerClosed {
-			e.Logger.Fatal("Server startup failed:", err)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := itemUsecase.CleanupOrphans(ctx, retention)
+			if err != nil {
+				// 読み取り専用モード中は次回に持ち越す
+				if !domainErrors.IsReadOnlyError(err) {
+					fmt.Printf("❌ Orphan cleanup failed: %v\n", err)
+				}
+				continue
+			}
+			if result.Items > 0 {
+				fmt.Printf("🧹 Cleaned up data of %d deleted items (%d images)\n", result.Items, result.Images)
+			}
 		}
-	}()
+	}
+}
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
+func runScheduledPurge(ctx context.Context, itemUsecase usecase.ItemUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-	select {
-	case <-quit:
-		fmt.Println("\n🛑 Shutting down server...")
-	case <-ctx.Done():
-		fmt.Println("\n🛑 Context cancelled, shutting down server...")
+	ctx = usecase.WithActor(ctx, usecase.SystemActor)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := itemUsecase.PurgeDueItems(ctx)
+			if err != nil {
+				// 読み取り専用モード中は次回に持ち越す
+				if !domainErrors.IsReadOnlyError(err) {
+					fmt.Printf("❌ Scheduled purge failed: %v\n", err)
+				}
+				continue
+			}
+			if result.Items > 0 {
+				fmt.Printf("🗑️  Purged %d items scheduled for deletion (%d images)\n", result.Items, result.Images)
+			}
+		}
+	}
+}
+
+func runUploadCleanup(ctx context.Context, imageUsecase usecase.ImageUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := imageUsecase.CleanupUploads(ctx)
+			if err != nil {
+				fmt.Printf("❌ Upload cleanup failed: %v\n", err)
+				continue
+			}
+			if removed > 0 {
+				fmt.Printf("🧹 Removed %d expired uploads\n", removed)
+			}
+		}
+	}
+}
+
+func runWebhookDelivery(ctx context.Context, webhookUsecase usecase.WebhookUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-webhookUsecase.Pending():
+		}
+
+		result, err := webhookUsecase.DeliverDue(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				fmt.Printf("❌ Webhook delivery failed: %v\n", err)
+			}
+			continue
+		}
+		if result.Failed > 0 {
+			fmt.Printf("📮 Gave up %d webhook deliveries after reaching the retry limit\n", result.Failed)
+		}
 	}
+}
+
+func runOutboxRelay(ctx context.Context, relay *usecase.OutboxRelay, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 
-	if err := e.Shutdown(shutdownCtx); err != nil {
-		return fmt.Errorf("server forced to shutdown: %w", err)
+		result, err := relay.Relay(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				fmt.Printf("❌ Outbox relay failed: %v\n", err)
+			}
+			continue
+		}
+		if result.Retrying > 0 {
+			fmt.Printf("📮 Failed to publish %d item events, will retry\n", result.Retrying)
+		}
+	}
+}
+
+// workers 個のワーカーが、実行できるジョブがなくなるまで実行し、一定間隔または新しいジョブの登録まで待つ
+func runJobWorkers(ctx context.Context, jobUsecase usecase.JobUsecase, workers int, interval time.Duration) {
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runJobWorker(ctx, jobUsecase, interval)
+		}()
 	}
+	wg.Wait()
+}
+
+func runJobWorker(ctx context.Context, jobUsecase usecase.JobUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			ran, err := jobUsecase.RunNext(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					fmt.Printf("❌ Job worker failed: %v\n", err)
+				}
+				break
+			}
+			if !ran {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-jobUsecase.Pending():
+		}
+	}
+}
+
+// 実行予定の日時ごとに全アイテムの評価額を更新する（前回の実行が終わる前に過ぎた予定は実行しない）
+func runValuationRefreshSchedule(ctx context.Context, scheduledJobUsecase usecase.ScheduledJobUsecase, expr string) {
+	schedule, _ := entity.ParseCronSchedule(expr)
+	for {
+		next := schedule.Next(time.Now())
+		if next.IsZero() {
+			fmt.Printf("❌ Valuation refresh schedule %q has no next run\n", expr)
+			return
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			run, err := scheduledJobUsecase.RunValuationRefresh(ctx, expr, next)
+			if err != nil {
+				if ctx.Err() == nil {
+					fmt.Printf("❌ Scheduled valuation refresh failed: %v\n", err)
+				}
+				continue
+			}
+			// 他のサーバーが実行した
+			if run == nil {
+				continue
+			}
+			fmt.Printf("📈 Refreshed valuations: %d succeeded, %d skipped, %d failed\n", run.Succeeded, run.Skipped, run.Failed)
+		}
+	}
+}
+
+func (s *Server) runSandboxReset(ctx context.Context) {
+	resetAt, _ := time.Parse("15:04", s.config.SandboxResetAt)
+	for {
+		timer := time.NewTimer(time.Until(nextDailyRun(time.Now(), resetAt)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			if err := s.resetSandbox(ctx); err != nil {
+				fmt.Printf("❌ Sandbox reset failed: %v\n", err)
+				continue
+			}
+			fmt.Println("🔄 Reset the sandbox database")
+		}
+	}
+}
+
+// すべてのマイグレーションを戻して適用し直す（テーブルを作り直すため、リセット中のリクエストはエラーになることがある）
+func (s *Server) resetSandbox(ctx context.Context) error {
+	conn, err := databaseInfra.OpenDB(s.config)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	dialect := itemDatabase.Dialect(s.config.DBDriver)
+	migrator, err := databaseInfra.NewMigrator(conn, dialect, databaseInfra.Migrations(dialect))
+	if err != nil {
+		return err
+	}
+	return migrator.Reset(ctx)
+}
+
+// now より後で、時刻が at（時・分のみ使う）になる最初の日時
+func nextDailyRun(now, at time.Time) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), at.Hour(), at.Minute(), 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// ローカルディスクに保存した写真を配信するパス（IMAGE_BASE_URL が未設定の場合）
+const localImagePath = "/images"
 
-	fmt.Println("✅ Server exited gracefully")
-	return nil
+// 写真の保存先を作成する（ローカルディスクの場合は保存先を静的ファイルとして配信する）
+func (s *Server) newImageStorage(ctx context.Context, e *echo.Echo) (usecase.ImageStorage, error) {
+	if s.config.ImageStorage == "local" && s.config.ImageBaseURL == "" {
+		e.Static(localImagePath, s.config.ImageLocalDir)
+	}
+	return NewImageStorage(ctx, s.config)
+}
+
+// 変更時にキャッシュを削除するCDNのクライアントを作成する（未設定の場合は nil）
+func (s *Server) newCachePurger() (usecase.CachePurger, error) {
+	switch s.config.CDNProvider {
+	case "":
+		return nil, nil
+	case "fastly":
+		return cdn.NewFastlyPurger(s.config.FastlyServiceID, s.config.CDNAPIToken, s.config.CDNTimeout), nil
+	case "cloudflare":
+		return cdn.NewCloudflarePurger(s.config.CloudflareZoneID, s.config.CDNAPIToken, s.config.CDNTimeout), nil
+	default:
+		return nil, fmt.Errorf("invalid CDN_PROVIDER: %s", s.config.CDNProvider)
+	}
+}
+
+// 領収書の写真の文字を読み取る OCR のクライアントを作成する（未設定の場合は nil）
+func (s *Server) newReceiptReader() (usecase.ReceiptReader, error) {
+	switch s.config.OCRProvider {
+	case "":
+		return nil, nil
+	case "http":
+		return ocr.NewHTTPReader(s.config.OCRAPIURL, s.config.OCRAPIKey, s.config.OCRTimeout), nil
+	case "google-vision":
+		return ocr.NewVisionReader(s.config.OCRAPIKey, s.config.OCRTimeout), nil
+	default:
+		return nil, fmt.Errorf("invalid OCR_PROVIDER: %s", s.config.OCRProvider)
+	}
+}
+
+// キーワード検索に使う検索エンジンのクライアントを作成する（未設定の場合は nil）
+// インデックスの設定に失敗しても起動は続ける（検索に失敗した場合はSQLで検索する）
+func (s *Server) newSearchIndex(ctx context.Context) usecase.SearchIndex {
+	switch s.config.SearchProvider {
+	case "meilisearch":
+		index := searchInfra.NewMeilisearchIndex(s.config.SearchURL, s.config.SearchAPIKey, s.config.SearchIndex, s.config.SearchTimeout)
+		if err := index.Configure(ctx); err != nil {
+			fmt.Printf("⚠️  Failed to configure search index: %v\n", err)
+		}
+		// タイムアウトは検索エンジンのクライアントの SEARCH_TIMEOUT
+		return usecase.NewResilientSearchIndex(index, s.newCircuitBreaker("search index", 0))
+	default:
+		return nil
+	}
+}
+
+// 既定の保険の明細の書式に、INSURANCE_TEMPLATES_PATH の書式を重ねる
+func (s *Server) insuranceTemplates() ([]entity.InsuranceTemplate, error) {
+	defaults := entity.DefaultInsuranceTemplates()
+	if s.config.InsuranceTemplatesPath == "" {
+		return defaults, nil
+	}
+
+	data, err := os.ReadFile(s.config.InsuranceTemplatesPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read insurance templates: %w", err)
+	}
+	templates, err := entity.ParseInsuranceTemplates(data)
+	if err != nil {
+		return nil, err
+	}
+	return entity.MergeInsuranceTemplates(defaults, templates), nil
+}
+
+func (s *Server) newRateLimitStore() (appMiddleware.RateLimitStore, error) {
+	switch s.config.RateLimitStore {
+	case "redis":
+		return ratelimit.NewRedisStore(s.config.RateLimitRedisURL)
+	default:
+		return ratelimit.NewMemoryStore(), nil
+	}
+}
+
+// 任意の依存先のサーキットブレーカーを作成する（状態が変わったときにログを出す）
+func (s *Server) newCircuitBreaker(name string, timeout time.Duration) *usecase.CircuitBreaker {
+	return usecase.NewCircuitBreaker(name, usecase.CircuitBreakerSettings{
+		Threshold: s.config.CircuitBreakerThreshold,
+		Cooldown:  s.config.CircuitBreakerCooldown,
+		Timeout:   timeout,
+	}, usecase.WithCircuitStateChange(func(name string, from, to usecase.CircuitState) {
+		switch to {
+		case usecase.CircuitOpen:
+			fmt.Printf("⚠️  %s is unavailable, skipping calls for %s\n", name, s.config.CircuitBreakerCooldown)
+		case usecase.CircuitClosed:
+			fmt.Printf("✅ %s recovered\n", name)
+		}
+	}))
+}
+
+func (s *Server) newItemCache() (usecase.KeyValueCache, error) {
+	switch s.config.ItemCache {
+	case "memory":
+		return cache.NewMemoryCache(s.config.ItemCacheSize), nil
+	case "redis":
+		return cache.NewRedisCache(s.config.ItemCacheRedisURL)
+	default:
+		return nil, nil
+	}
+}
+
+// クライアントIP（レート制限の単位）の取得方法
+// X-Forwarded-For はクライアントが自由に指定できるため、信頼するプロキシを経由した場合のみ使う
+func ipExtractor(trustedProxies []string) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range trustedProxies {
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+			options = append(options, echo.TrustIPRange(ipNet))
+		}
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// LOG_LEVEL を Echo のログレベルに変換する
+func echoLogLevel(level string) log.Lvl {
+	switch level {
+	case "debug":
+		return log.DEBUG
+	case "warn":
+		return log.WARN
+	case "error":
+		return log.ERROR
+	case "off":
+		return log.OFF
+	default:
+		return log.INFO
+	}
+}
+
+// サーバーを起動し、コンテキストがキャンセルされたら新しい接続の受け付けを止めて処理中のリクエストを待つ
+// 待ち時間（SHUTDOWN_TIMEOUT）を過ぎた場合は接続を切断し、エラーを返す
+func (s *Server) serve(ctx context.Context, g *errgroup.Group, e *echo.Echo) {
+	g.Go(func() error {
+		port := fmt.Sprintf(":%d", s.config.Port)
+		fmt.Printf("🚀 Server starting on port %s\n", port)
+
+		if err := e.Start(port); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("server startup failed: %w", err)
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		<-ctx.Done()
+		fmt.Println("\n🛑 Shutting down server...")
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+		defer cancel()
+
+		if err := e.Shutdown(shutdownCtx); err != nil {
+			_ = e.Close()
+			return fmt.Errorf("server forced to shutdown: %w", err)
+		}
+
+		fmt.Println("✅ Server exited gracefully")
+		return nil
+	})
 }
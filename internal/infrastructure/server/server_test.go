@@ -0,0 +1,70 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/infrastructure/ratelimit"
+	appMiddleware "Aicon-assignment/internal/interfaces/middleware"
+)
+
+func TestNextDailyRun(t *testing.T) {
+	at := time.Date(0, 1, 1, 3, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: 当日の時刻の前は当日", func(t *testing.T) {
+		now := time.Date(2024, 1, 31, 2, 59, 0, 0, time.UTC)
+		assert.Equal(t, time.Date(2024, 1, 31, 3, 0, 0, 0, time.UTC), nextDailyRun(now, at))
+	})
+
+	t.Run("正常系: 当日の時刻ちょうど以降は翌日", func(t *testing.T) {
+		now := time.Date(2024, 1, 31, 3, 0, 0, 0, time.UTC)
+		assert.Equal(t, time.Date(2024, 2, 1, 3, 0, 0, 0, time.UTC), nextDailyRun(now, at))
+	})
+}
+
+func TestIPExtractor(t *testing.T) {
+	// 1分あたり1回までのAPIに、X-Forwarded-For を変えながら同じ接続元から2回リクエストする
+	publicStats := func(e *echo.Echo) *http.Request {
+		e.GET("/public/stats", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, appMiddleware.RateLimit(1, time.Minute))
+		return httptest.NewRequest(http.MethodGet, "/public/stats", nil)
+	}
+	createItem := func(e *echo.Echo) *http.Request {
+		e.Use(appMiddleware.WriteRateLimit(appMiddleware.WriteRateLimitConfig{Store: ratelimit.NewMemoryStore(), PerIP: 1, Per: time.Minute}))
+		e.POST("/items", func(c echo.Context) error { return c.NoContent(http.StatusCreated) })
+		return httptest.NewRequest(http.MethodPost, "/items", nil)
+	}
+	limited := func(trustedProxies []string, route func(*echo.Echo) *http.Request, remoteAddr string, forwardedFor ...string) []bool {
+		e := echo.New()
+		e.IPExtractor = ipExtractor(trustedProxies)
+		req := route(e)
+		var result []bool
+		for _, xff := range forwardedFor {
+			req := req.Clone(req.Context())
+			req.RemoteAddr = remoteAddr
+			req.Header.Set(echo.HeaderXForwardedFor, xff)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			result = append(result, rec.Code == http.StatusTooManyRequests)
+		}
+		return result
+	}
+
+	for name, route := range map[string]func(*echo.Echo) *http.Request{"公開統計": publicStats, "書き込み": createItem} {
+		t.Run("正常系: 信頼するプロキシが未設定の場合は X-Forwarded-For を無視する（"+name+"）", func(t *testing.T) {
+			assert.Equal(t, []bool{false, true}, limited(nil, route, "203.0.113.1:1234", "198.51.100.1", "198.51.100.2"))
+		})
+
+		t.Run("正常系: 信頼するプロキシからの X-Forwarded-For はクライアントIPとして使う（"+name+"）", func(t *testing.T) {
+			assert.Equal(t, []bool{false, false}, limited([]string{"10.0.0.0/8"}, route, "10.0.0.1:1234", "198.51.100.1", "198.51.100.2"))
+		})
+
+		t.Run("異常系: 信頼しない接続元からの X-Forwarded-For は無視する（"+name+"）", func(t *testing.T) {
+			assert.Equal(t, []bool{false, true}, limited([]string{"10.0.0.0/8"}, route, "203.0.113.1:1234", "198.51.100.1", "198.51.100.2"))
+		})
+	}
+}
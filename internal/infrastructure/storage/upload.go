@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 分割アップロードの受信途中のデータをローカルディスクに保存する
+// セッションごとにメタデータ（.json）と受信済みのデータ（.part）を置き、受信済みのバイト数はデータのサイズとする
+// 同じセッションへの書き込みはプロセス内で直列化する（単一サーバー向け）
+type LocalUploadStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// セッションIDとして受け付ける形式（ディレクトリの外を指さないよう英数字のみ）
+var uploadIDPattern = regexp.MustCompile(`^[0-9a-zA-Z]+$`)
+
+func NewLocalUploadStore(dir string) (*LocalUploadStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	return &LocalUploadStore{dir: dir}, nil
+}
+
+func (s *LocalUploadStore) Create(ctx context.Context, session *entity.UploadSession) error {
+	metaPath, dataPath, err := s.paths(session.ID)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	meta, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(dataPath, nil, 0o644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(metaPath, meta, 0o644); err != nil {
+		os.Remove(dataPath)
+		return err
+	}
+	return nil
+}
+
+func (s *LocalUploadStore) Find(ctx context.Context, id string) (*entity.UploadSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.find(id)
+}
+
+func (s *LocalUploadStore) find(id string) (*entity.UploadSession, error) {
+	metaPath, dataPath, err := s.paths(id)
+	if err != nil {
+		return nil, domainErrors.ErrUploadNotFound
+	}
+
+	meta, err := os.ReadFile(metaPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, domainErrors.ErrUploadNotFound
+		}
+		return nil, err
+	}
+	var session entity.UploadSession
+	if err := json.Unmarshal(meta, &session); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(dataPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, domainErrors.ErrUploadNotFound
+		}
+		return nil, err
+	}
+	session.Offset = info.Size()
+
+	return &session, nil
+}
+
+func (s *LocalUploadStore) Append(ctx context.Context, id string, offset int64, chunk io.Reader, limit int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, err := s.find(id)
+	if err != nil {
+		return 0, err
+	}
+	if offset != session.Offset {
+		return session.Offset, fmt.Errorf("%w: expected offset %d", domainErrors.ErrUploadOffsetMismatch, session.Offset)
+	}
+
+	_, dataPath, _ := s.paths(id)
+	file, err := os.OpenFile(dataPath, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return offset, err
+	}
+
+	// 上限を1バイト超えて読めた場合は申告したサイズを超えるため、書き込んだ分を取り消す
+	written, err := io.Copy(file, io.LimitReader(chunk, limit+1))
+	if err == nil && written > limit {
+		err = fmt.Errorf("%w: chunk exceeds the declared size", domainErrors.ErrInvalidInput)
+	}
+	if err != nil {
+		// 途中で切断された場合も、受信済みのバイト数からやり直せるよう送信前の状態に戻す
+		file.Truncate(offset)
+		file.Close()
+		return offset, err
+	}
+	if err := file.Close(); err != nil {
+		return offset, err
+	}
+
+	return offset + written, nil
+}
+
+func (s *LocalUploadStore) Open(ctx context.Context, id string) (io.ReadCloser, error) {
+	_, dataPath, err := s.paths(id)
+	if err != nil {
+		return nil, domainErrors.ErrUploadNotFound
+	}
+	return os.Open(dataPath)
+}
+
+func (s *LocalUploadStore) Delete(ctx context.Context, id string) error {
+	metaPath, dataPath, err := s.paths(id)
+	if err != nil {
+		return domainErrors.ErrUploadNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, path := range []string{metaPath, dataPath} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *LocalUploadStore) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok {
+			continue
+		}
+
+		// 読み込めないセッションは再開できないため、期限にかかわらず削除する
+		session, err := s.Find(ctx, id)
+		if err == nil && !session.IsExpired(now) {
+			continue
+		}
+		if err := s.Delete(ctx, id); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+func (s *LocalUploadStore) paths(id string) (string, string, error) {
+	if !uploadIDPattern.MatchString(id) {
+		return "", "", fmt.Errorf("invalid upload ID: %s", id)
+	}
+	base := filepath.Join(s.dir, id)
+	return base + ".json", base + ".part", nil
+}
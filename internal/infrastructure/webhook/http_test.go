@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestHTTPSender_Send(t *testing.T) {
+	t.Run("正常系: ヘッダーと本文をPOSTし、応答のステータスを返す", func(t *testing.T) {
+		var gotMethod, gotSignature, gotBody string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			gotMethod, gotSignature, gotBody = r.Method, r.Header.Get("X-Webhook-Signature"), string(body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		status, err := NewHTTPSender(time.Second).Send(context.Background(), usecase.WebhookRequest{
+			URL:    server.URL,
+			Header: map[string]string{"X-Webhook-Signature": "sha256=abc"},
+			Body:   []byte(`{"event":"item.created"}`),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, status)
+		assert.Equal(t, http.MethodPost, gotMethod)
+		assert.Equal(t, "sha256=abc", gotSignature)
+		assert.Equal(t, `{"event":"item.created"}`, gotBody)
+	})
+
+	t.Run("正常系: リダイレクトには従わない", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/elsewhere", http.StatusFound)
+		}))
+		defer server.Close()
+
+		status, err := NewHTTPSender(time.Second).Send(context.Background(), usecase.WebhookRequest{URL: server.URL})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusFound, status)
+	})
+
+	t.Run("異常系: 接続できない", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		status, err := NewHTTPSender(time.Second).Send(context.Background(), usecase.WebhookRequest{URL: server.URL})
+
+		assert.Error(t, err)
+		assert.Zero(t, status)
+	})
+}
@@ -0,0 +1,76 @@
+package brands
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type BrandHandler struct {
+	brandAliasUsecase usecase.BrandAliasUsecase
+	itemUsecase       usecase.ItemUsecase
+}
+
+func NewBrandHandler(brandAliasUsecase usecase.BrandAliasUsecase, itemUsecase usecase.ItemUsecase) *BrandHandler {
+	return &BrandHandler{
+		brandAliasUsecase: brandAliasUsecase,
+		itemUsecase:       itemUsecase,
+	}
+}
+
+// 別名の登録のリクエスト
+type SetBrandAliasRequest struct {
+	Brand string `json:"brand"`
+}
+
+// GET /admin/brand-aliases
+func (h *BrandHandler) GetBrandAliases(c echo.Context) error {
+	aliases, err := h.brandAliasUsecase.GetBrandAliases(c.Request().Context())
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve brand aliases")
+	}
+
+	return c.JSON(http.StatusOK, aliases)
+}
+
+// PUT /admin/brand-aliases/:alias
+func (h *BrandHandler) SetBrandAlias(c echo.Context) error {
+	var req SetBrandAliasRequest
+	if err := c.Bind(&req); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	alias, err := h.brandAliasUsecase.SetBrandAlias(c.Request().Context(), c.Param("alias"), req.Brand)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to save brand alias")
+	}
+
+	return c.JSON(http.StatusOK, alias)
+}
+
+// DELETE /admin/brand-aliases/:alias
+func (h *BrandHandler) DeleteBrandAlias(c echo.Context) error {
+	err := h.brandAliasUsecase.DeleteBrandAlias(c.Request().Context(), c.Param("alias"))
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return presenter.ErrorJSON(c, presenter.CodeNotFound, "brand alias not found")
+		}
+		return presenter.DomainErrorJSON(c, err, "failed to delete brand alias")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// POST /admin/brands/normalize
+func (h *BrandHandler) NormalizeBrands(c echo.Context) error {
+	result, err := h.itemUsecase.NormalizeBrands(c.Request().Context())
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to normalize brands")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,65 @@
+package budgets
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type BudgetHandler struct {
+	budgetUsecase usecase.BudgetUsecase
+}
+
+func NewBudgetHandler(budgetUsecase usecase.BudgetUsecase) *BudgetHandler {
+	return &BudgetHandler{
+		budgetUsecase: budgetUsecase,
+	}
+}
+
+// 予算設定のリクエスト
+type SetBudgetRequest struct {
+	Amount *entity.Money `json:"amount"`
+}
+
+func (h *BudgetHandler) GetBudgets(c echo.Context) error {
+	budgets, err := h.budgetUsecase.GetBudgets(c.Request().Context())
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve budgets")
+	}
+
+	return c.JSON(http.StatusOK, budgets)
+}
+
+func (h *BudgetHandler) SetBudget(c echo.Context) error {
+	var req SetBudgetRequest
+	if err := c.Bind(&req); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+	if req.Amount == nil {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", "amount is required")
+	}
+
+	budget, err := h.budgetUsecase.SetBudget(c.Request().Context(), c.Param("category"), *req.Amount)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to save budget")
+	}
+
+	return c.JSON(http.StatusOK, budget)
+}
+
+func (h *BudgetHandler) DeleteBudget(c echo.Context) error {
+	err := h.budgetUsecase.DeleteBudget(c.Request().Context(), c.Param("category"))
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return presenter.ErrorJSON(c, presenter.CodeNotFound, "budget not found")
+		}
+		return presenter.DomainErrorJSON(c, err, "failed to delete budget")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
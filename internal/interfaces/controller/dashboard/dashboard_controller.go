@@ -0,0 +1,82 @@
+package dashboard
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type DashboardHandler struct {
+	dashboardUsecase usecase.DashboardUsecase
+}
+
+func NewDashboardHandler(dashboardUsecase usecase.DashboardUsecase) *DashboardHandler {
+	return &DashboardHandler{dashboardUsecase: dashboardUsecase}
+}
+
+// 直近の期間（window、省略時は 720h）に活動した操作者と、操作者ごとの変更・登録の件数（?limit）
+func (h *DashboardHandler) GetActivity(c echo.Context) error {
+	var input usecase.DashboardActivityInput
+	window, err := parseWindow(c)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", err.Error())
+	}
+	input.Window = window
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "limit must be an integer")
+		}
+		input.Limit = parsed
+	}
+
+	activity, err := h.dashboardUsecase.GetActivity(c.Request().Context(), input)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve activity")
+	}
+
+	return c.JSON(http.StatusOK, activity)
+}
+
+func (h *DashboardHandler) GetStorage(c echo.Context) error {
+	storage, err := h.dashboardUsecase.GetStorage(c.Request().Context())
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve storage")
+	}
+
+	return c.JSON(http.StatusOK, storage)
+}
+
+// 直近の期間（window、省略時は 720h）の Webhook ごとの配信状況
+func (h *DashboardHandler) GetWebhookHealth(c echo.Context) error {
+	window, err := parseWindow(c)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", err.Error())
+	}
+
+	webhooks, err := h.dashboardUsecase.GetWebhookHealth(c.Request().Context(), window)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve webhook health")
+	}
+
+	return c.JSON(http.StatusOK, webhooks)
+}
+
+// 集計期間（未指定の場合は 0）
+func parseWindow(c echo.Context) (time.Duration, error) {
+	value := c.QueryParam("window")
+	if value == "" {
+		return 0, nil
+	}
+	window, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.New("window must be a duration (e.g. 720h)")
+	}
+	return window, nil
+}
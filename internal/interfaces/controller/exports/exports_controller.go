@@ -0,0 +1,53 @@
+package exports
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type ExportHandler struct {
+	exportUsecase usecase.ExportUsecase
+}
+
+func NewExportHandler(exportUsecase usecase.ExportUsecase) *ExportHandler {
+	return &ExportHandler{
+		exportUsecase: exportUsecase,
+	}
+}
+
+// 全アイテムのエクスポートを登録し、202 を返す（状況は Location の GET /exports/:id で確認する）
+func (h *ExportHandler) CreateExport(c echo.Context) error {
+	var input usecase.CreateExportInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	export, err := h.exportUsecase.CreateExport(c.Request().Context(), input)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to create export")
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/exports/%d", export.ID))
+	return c.JSON(http.StatusAccepted, export)
+}
+
+// 操作者（X-User-ID）が作成したエクスポートの状況（完了した場合はダウンロードURLを含む）
+func (h *ExportHandler) GetExport(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid export ID")
+	}
+
+	export, err := h.exportUsecase.GetExport(c.Request().Context(), id)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve export")
+	}
+
+	return c.JSON(http.StatusOK, export)
+}
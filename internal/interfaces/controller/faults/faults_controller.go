@@ -0,0 +1,67 @@
+package faults
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type FaultHandler struct {
+	faults *usecase.FaultInjector // FAULT_INJECTION_ENABLED でない場合は nil
+}
+
+func NewFaultHandler(faults *usecase.FaultInjector) *FaultHandler {
+	return &FaultHandler{
+		faults: faults,
+	}
+}
+
+// 注入中の障害
+func (h *FaultHandler) GetFaults(c echo.Context) error {
+	if h.faults == nil {
+		return notEnabled(c)
+	}
+
+	return c.JSON(http.StatusOK, h.faults.Faults())
+}
+
+// 依存先（repository / storage / notifier）に、指定したエラーを返させる
+func (h *FaultHandler) SetFault(c echo.Context) error {
+	if h.faults == nil {
+		return notEnabled(c)
+	}
+
+	var input usecase.SetFaultInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	fault, err := h.faults.Set(usecase.FaultTarget(c.Param("target")), input)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to set fault")
+	}
+
+	return c.JSON(http.StatusOK, fault)
+}
+
+func (h *FaultHandler) ClearFault(c echo.Context) error {
+	if h.faults == nil {
+		return notEnabled(c)
+	}
+
+	target := usecase.FaultTarget(c.Param("target"))
+	if !target.IsValid() {
+		return presenter.ErrorJSON(c, presenter.CodeNotFound, "unknown fault target")
+	}
+
+	h.faults.Clear(target)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// 障害の注入が無効（FAULT_INJECTION_ENABLED でない、または本番環境）の場合
+func notEnabled(c echo.Context) error {
+	return presenter.ErrorJSON(c, presenter.CodeServiceUnavailable, "fault injection is not enabled")
+}
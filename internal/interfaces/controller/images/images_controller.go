@@ -0,0 +1,230 @@
+package images
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type ImageHandler struct {
+	imageUsecase usecase.ImageUsecase
+}
+
+func NewImageHandler(imageUsecase usecase.ImageUsecase) *ImageHandler {
+	return &ImageHandler{
+		imageUsecase: imageUsecase,
+	}
+}
+
+// multipart/form-data の file で写真を受け取る
+func (h *ImageHandler) UploadImage(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", "file is required")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "failed to read file")
+	}
+	defer file.Close()
+
+	image, err := h.imageUsecase.UploadImage(c.Request().Context(), itemID, usecase.UploadImageInput{
+		FileName: fileHeader.Filename,
+		Size:     fileHeader.Size,
+		Body:     file,
+	})
+	if err != nil {
+		return errorResponse(c, err, "failed to upload image")
+	}
+
+	return c.JSON(http.StatusCreated, image)
+}
+
+func (h *ImageHandler) GetImages(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	images, err := h.imageUsecase.GetImages(c.Request().Context(), itemID)
+	if err != nil {
+		return errorResponse(c, err, "failed to retrieve images")
+	}
+
+	return c.JSON(http.StatusOK, images)
+}
+
+func (h *ImageHandler) DeleteImage(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+	imageID, err := strconv.ParseInt(c.Param("imageId"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid image ID")
+	}
+
+	if err := h.imageUsecase.DeleteImage(c.Request().Context(), itemID, imageID); err != nil {
+		return errorResponse(c, err, "failed to delete image")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// 指定したアイテムの写真をzipにまとめ、ダウンロードURLを返す
+func (h *ImageHandler) ExportImages(c echo.Context) error {
+	var input usecase.ExportImagesInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	export, err := h.imageUsecase.ExportImages(c.Request().Context(), input)
+	if err != nil {
+		return errorResponse(c, err, "failed to export images")
+	}
+
+	return c.JSON(http.StatusOK, export)
+}
+
+// 写真を保存先に直接アップロードするための署名付きURLを発行する（JSON で content_type と size を指定する）
+func (h *ImageHandler) CreateDirectUpload(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	var input usecase.CreateDirectUploadInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	upload, err := h.imageUsecase.CreateDirectUpload(c.Request().Context(), itemID, input)
+	if err != nil {
+		return errorResponse(c, err, "failed to create direct upload")
+	}
+
+	return c.JSON(http.StatusCreated, upload)
+}
+
+// 直接アップロードが完了した写真を登録する（JSON で発行時の key と file_name を指定する）
+func (h *ImageHandler) CompleteDirectUpload(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	var input usecase.CompleteDirectUploadInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	image, err := h.imageUsecase.CompleteDirectUpload(c.Request().Context(), itemID, input)
+	if err != nil {
+		return errorResponse(c, err, "failed to complete direct upload")
+	}
+
+	return c.JSON(http.StatusCreated, image)
+}
+
+// 分割アップロードの送信位置・受信済みのバイト数を示すヘッダー（tus と同じ名前）
+const UploadOffsetHeader = "Upload-Offset"
+
+// 分割アップロードを開始する（JSON で file_name と size を指定する）
+func (h *ImageHandler) CreateUpload(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	var input usecase.CreateUploadInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	session, err := h.imageUsecase.CreateUpload(c.Request().Context(), itemID, input)
+	if err != nil {
+		return errorResponse(c, err, "failed to create upload")
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/items/%d/uploads/%s", itemID, session.ID))
+	c.Response().Header().Set(UploadOffsetHeader, strconv.FormatInt(session.Offset, 10))
+	return c.JSON(http.StatusCreated, session)
+}
+
+// 分割アップロードの受信状況（中断後はこの offset から再開する）
+func (h *ImageHandler) GetUpload(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	session, err := h.imageUsecase.GetUpload(c.Request().Context(), itemID, c.Param("uploadId"))
+	if err != nil {
+		return errorResponse(c, err, "failed to retrieve upload")
+	}
+
+	c.Response().Header().Set(UploadOffsetHeader, strconv.FormatInt(session.Offset, 10))
+	return c.JSON(http.StatusOK, session)
+}
+
+// リクエストボディの内容を Upload-Offset の位置から受信する
+// 全体を受信した場合は写真を登録して 201、途中の場合は 200 を返す
+func (h *ImageHandler) AppendUpload(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+	offset, err := strconv.ParseInt(c.Request().Header.Get(UploadOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", "Upload-Offset header must be a non-negative integer")
+	}
+
+	result, err := h.imageUsecase.AppendUpload(c.Request().Context(), itemID, c.Param("uploadId"), offset, c.Request().Body)
+	if err != nil {
+		return errorResponse(c, err, "failed to upload")
+	}
+
+	c.Response().Header().Set(UploadOffsetHeader, strconv.FormatInt(result.Upload.Offset, 10))
+	if result.Image != nil {
+		return c.JSON(http.StatusCreated, result)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// 分割アップロードを中止する
+func (h *ImageHandler) CancelUpload(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	if err := h.imageUsecase.CancelUpload(c.Request().Context(), itemID, c.Param("uploadId")); err != nil {
+		return errorResponse(c, err, "failed to cancel upload")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func errorResponse(c echo.Context, err error, message string) error {
+	switch {
+	case domainErrors.IsUploadNotFoundError(err):
+		return presenter.ErrorJSON(c, presenter.CodeNotFound, "upload not found")
+	case domainErrors.IsUploadOffsetMismatchError(err):
+		return presenter.ErrorJSON(c, presenter.CodeConflict, "upload offset mismatch", err.Error())
+	case domainErrors.IsNotFoundError(err):
+		return presenter.ErrorJSON(c, presenter.CodeNotFound, "")
+	}
+	return presenter.DomainErrorJSON(c, err, message)
+}
@@ -0,0 +1,57 @@
+package insurance
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+type InsuranceHandler struct {
+	insuranceUsecase usecase.InsuranceUsecase
+}
+
+func NewInsuranceHandler(insuranceUsecase usecase.InsuranceUsecase) *InsuranceHandler {
+	return &InsuranceHandler{
+		insuranceUsecase: insuranceUsecase,
+	}
+}
+
+// GET /items/insurance-schedules/templates
+func (h *InsuranceHandler) GetTemplates(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.insuranceUsecase.GetTemplates())
+}
+
+// 書式（?template=、省略時は standard）の明細一式を返す
+// ?format=xlsx の場合は明細ごとのシートのブックを返す（生成してから書き込む）
+func (h *InsuranceHandler) GetSchedules(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = usecase.InsuranceFormatJSON
+	}
+	if format != usecase.InsuranceFormatJSON && format != usecase.InsuranceFormatXLSX {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", "format must be json or xlsx")
+	}
+
+	bundle, err := h.insuranceUsecase.GenerateSchedules(c.Request().Context(), c.QueryParam("template"))
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to generate insurance schedules")
+	}
+	if format == usecase.InsuranceFormatJSON {
+		return c.JSON(http.StatusOK, bundle)
+	}
+
+	var buf bytes.Buffer
+	if err := usecase.WriteInsuranceXLSX(&buf, bundle); err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to generate insurance schedules")
+	}
+	filename := fmt.Sprintf("insurance-%s-%s.xlsx", bundle.Template, bundle.GeneratedAt.Format("20060102"))
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	return c.Blob(http.StatusOK, xlsxContentType, buf.Bytes())
+}
@@ -0,0 +1,105 @@
+package insurance
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type InsurancePolicyHandler struct {
+	policyUsecase usecase.InsurancePolicyUsecase
+}
+
+func NewInsurancePolicyHandler(policyUsecase usecase.InsurancePolicyUsecase) *InsurancePolicyHandler {
+	return &InsurancePolicyHandler{
+		policyUsecase: policyUsecase,
+	}
+}
+
+// アイテムに掛けた保険の契約を満期日の順に返す
+func (h *InsurancePolicyHandler) GetPolicies(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	policies, err := h.policyUsecase.GetPolicies(c.Request().Context(), itemID)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve insurance policies")
+	}
+
+	return c.JSON(http.StatusOK, policies)
+}
+
+func (h *InsurancePolicyHandler) AddPolicy(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	var input usecase.InsurancePolicyInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	policy, err := h.policyUsecase.AddPolicy(c.Request().Context(), itemID, input)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to add insurance policy")
+	}
+
+	return c.JSON(http.StatusCreated, policy)
+}
+
+func (h *InsurancePolicyHandler) UpdatePolicy(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+	policyID, err := strconv.ParseInt(c.Param("policyId"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid insurance policy ID")
+	}
+
+	var input usecase.UpdateInsurancePolicyInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	policy, err := h.policyUsecase.UpdatePolicy(c.Request().Context(), itemID, policyID, input)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to update insurance policy")
+	}
+
+	return c.JSON(http.StatusOK, policy)
+}
+
+func (h *InsurancePolicyHandler) DeletePolicy(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+	policyID, err := strconv.ParseInt(c.Param("policyId"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid insurance policy ID")
+	}
+
+	if err := h.policyUsecase.DeletePolicy(c.Request().Context(), itemID, policyID); err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to delete insurance policy")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// 満期日が今日から ?within=（例: 30d、省略時は30日）以内の保険の契約を返す
+func (h *InsurancePolicyHandler) GetExpiringPolicies(c echo.Context) error {
+	report, err := h.policyUsecase.GetExpiringPolicies(c.Request().Context(), c.QueryParam("within"))
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve expiring insurance policies")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -1,10 +1,18 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
+	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/middleware"
+	"Aicon-assignment/internal/interfaces/presenter"
 	"Aicon-assignment/internal/usecase"
 
 	"github.com/labstack/echo/v4"
@@ -12,28 +20,45 @@ import (
 
 type ItemHandler struct {
 	itemUsecase usecase.ItemUsecase
+
+	// 孤立データのクリーンアップで、削除から紐づくデータを保持する期間の既定値
+	orphanRetention time.Duration
 }
 
-func NewItemHandler(itemUsecase usecase.ItemUsecase) *ItemHandler {
+func NewItemHandler(itemUsecase usecase.ItemUsecase, orphanRetention time.Duration) *ItemHandler {
 	return &ItemHandler{
-		itemUsecase: itemUsecase,
+		itemUsecase:     itemUsecase,
+		orphanRetention: orphanRetention,
 	}
 }
 
-// エラーレスポンスの形式
-type ErrorResponse struct {
-	Error   string   `json:"error"`
-	Details []string `json:"details,omitempty"`
+func (h *ItemHandler) GetItems(c echo.Context) error {
+	var input usecase.ListItemsInput
+	if errs := bindListItemsInput(c, &input); len(errs) > 0 {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", errs...)
+	}
+
+	items, err := h.itemUsecase.GetAllItems(c.Request().Context(), input)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve items")
+	}
+
+	middleware.SetSurrogateKeys(c, listSurrogateKeys(input, items)...)
+	return c.JSON(http.StatusOK, items)
 }
 
-func (h *ItemHandler) GetItems(c echo.Context) error {
-	items, err := h.itemUsecase.GetAllItems(c.Request().Context())
+func (h *ItemHandler) SearchItems(c echo.Context) error {
+	var input usecase.ListItemsInput
+	if errs := bindListItemsInput(c, &input); len(errs) > 0 {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", errs...)
+	}
+
+	items, err := h.itemUsecase.SearchItems(c.Request().Context(), c.QueryParam("q"), input)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve items",
-		})
+		return presenter.DomainErrorJSON(c, err, "failed to search items")
 	}
 
+	middleware.SetSurrogateKeys(c, listSurrogateKeys(input, items)...)
 	return c.JSON(http.StatusOK, items)
 }
 
@@ -41,99 +66,116 @@ func (h *ItemHandler) GetItem(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	// as_of=YYYY-MM-DD の場合は、その日の時点の状態（版）を返す
+	if asOf := c.QueryParam("as_of"); asOf != "" {
+		return h.getItemAsOf(c, id, asOf)
 	}
 
 	item, err := h.itemUsecase.GetItemByID(c.Request().Context(), id)
 	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve item")
+	}
+
+	middleware.SetSurrogateKeys(c, usecase.ItemSurrogateKeys(item)...)
+	return c.JSON(http.StatusOK, item)
+}
+
+func (h *ItemHandler) getItemAsOf(c echo.Context, id int64, asOf string) error {
+	item, err := h.itemUsecase.GetItemAsOf(c.Request().Context(), id, asOf)
+	if err != nil {
+		if domainErrors.IsRevisionNotFoundError(err) {
+			return presenter.ErrorJSON(c, presenter.CodeNotFound, "revision not found", err.Error())
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve item",
-		})
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve item")
 	}
 
+	middleware.SetSurrogateKeys(c, usecase.ItemSurrogateKeys(item)...)
 	return c.JSON(http.StatusOK, item)
 }
 
 func (h *ItemHandler) CreateItem(c echo.Context) error {
 	var input usecase.CreateItemInput
 	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request format",
-		})
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	// strict=true の場合、名前・ブランド・購入日が同じアイテムが登録済みであれば 409 を返す
+	if strict := c.QueryParam("strict"); strict != "" {
+		value, err := strconv.ParseBool(strict)
+		if err != nil {
+			return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", "strict must be a boolean")
+		}
+		input.Strict = value
 	}
 
 	// バリデーション
 	if validationErrors := validateCreateItemInput(input); len(validationErrors) > 0 {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation failed",
-			Details: validationErrors,
-		})
+		return presenter.FieldErrorsJSON(c, validationErrors)
 	}
 
 	item, err := h.itemUsecase.CreateItem(c.Request().Context(), input)
 	if err != nil {
-		if domainErrors.IsValidationError(err) {
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "validation failed",
-				Details: []string{err.Error()},
-			})
+		if domainErrors.IsDuplicateItemError(err) {
+			return presenter.ErrorJSON(c, presenter.CodeConflict, "duplicate item", err.Error())
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to create item",
-		})
+		return presenter.DomainErrorJSON(c, err, "failed to create item")
 	}
 
 	return c.JSON(http.StatusCreated, item)
 }
 
+func (h *ItemHandler) CreateItems(c echo.Context) error {
+	var input usecase.BulkCreateItemsInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	result, err := h.itemUsecase.CreateItems(c.Request().Context(), input)
+	if err != nil {
+		var bulkErr *usecase.BulkValidationError
+		switch {
+		case errors.As(err, &bulkErr):
+			var details []string
+			for _, failed := range bulkErr.Failed {
+				for _, msg := range failed.Errors {
+					details = append(details, fmt.Sprintf("items[%d]: %s", failed.Index, msg))
+				}
+			}
+			return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", details...)
+		}
+		return presenter.DomainErrorJSON(c, err, "failed to create items")
+	}
+
+	// partial モードで一部の項目が登録できなかった場合は 207 を返す
+	if len(result.Failed) > 0 {
+		return c.JSON(http.StatusMultiStatus, result)
+	}
+	return c.JSON(http.StatusCreated, result)
+}
+
 func (h *ItemHandler) UpdateItem(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
 	}
 
 	var input usecase.UpdateItemInput
 	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request format",
-		})
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
 	}
 
 	// バリデーション
 	if validationErrors := validateUpdateItemInput(input); len(validationErrors) > 0 {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation failed",
-			Details: validationErrors,
-		})
+		return presenter.FieldErrorsJSON(c, validationErrors)
 	}
 
 	item, err := h.itemUsecase.UpdateItem(c.Request().Context(), id, input)
 	if err != nil {
-		switch {
-		case domainErrors.IsValidationError(err):
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "validation failed",
-				Details: []string{err.Error()},
-			})
-		case domainErrors.IsNotFoundError(err):
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
-		default:
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error: "failed to update item",
-			})
-		}
+		return presenter.DomainErrorJSON(c, err, "failed to update item")
 	}
 
 	return c.JSON(http.StatusOK, item)
@@ -143,69 +185,545 @@ func (h *ItemHandler) DeleteItem(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	// purge_at を指定した場合は、その日時に完全削除するよう予定する
+	if purgeAt := c.QueryParam("purge_at"); purgeAt != "" {
+		return h.schedulePurge(c, id, purgeAt)
 	}
 
 	err = h.itemUsecase.DeleteItem(c.Request().Context(), id)
+	if err != nil {
+		if domainErrors.IsHasDependentsError(err) {
+			return presenter.ErrorJSON(c, presenter.CodeConflict, "item has dependent data", err.Error())
+		}
+		return presenter.DomainErrorJSON(c, err, "failed to delete item")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *ItemHandler) schedulePurge(c echo.Context, id int64, value string) error {
+	purgeAt, err := parsePurgeAt(value)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", "purge_at must be in RFC 3339 or YYYY-MM-DD format")
+	}
+
+	item, err := h.itemUsecase.SchedulePurge(c.Request().Context(), id, purgeAt)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to schedule item purge")
+	}
+
+	return c.JSON(http.StatusAccepted, item)
+}
+
+// 完全削除の予定日時（日付のみの場合はその日の0時）
+func parsePurgeAt(value string) (time.Time, error) {
+	if purgeAt, err := time.Parse(time.RFC3339, value); err == nil {
+		return purgeAt, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
+
+// 完全削除の予定を取り消す
+func (h *ItemHandler) CancelPurge(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	item, err := h.itemUsecase.CancelPurge(c.Request().Context(), id)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to cancel item purge")
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+func (h *ItemHandler) RestoreItem(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	item, err := h.itemUsecase.RestoreItem(c.Request().Context(), id)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to restore item")
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+// アイテムの変更履歴（登録・更新・削除などの記録順）
+func (h *ItemHandler) GetItemHistory(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	logs, err := h.itemUsecase.GetItemHistory(c.Request().Context(), id)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+		}
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve item history")
+	}
+
+	return c.JSON(http.StatusOK, logs)
+}
+
+// アイテムの版の一覧（古い順）
+func (h *ItemHandler) GetItemRevisions(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	revisions, err := h.itemUsecase.GetItemRevisions(c.Request().Context(), id)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+		}
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve item revisions")
+	}
+
+	return c.JSON(http.StatusOK, revisions)
+}
+
+// POST /items/{id}/revert?version=N
+// アイテムを指定した版の状態に戻す
+func (h *ItemHandler) RevertItem(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	version, err := strconv.Atoi(c.QueryParam("version"))
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", "version must be an integer")
+	}
+
+	item, err := h.itemUsecase.RevertItem(c.Request().Context(), id, version)
+	if err != nil {
+		if domainErrors.IsRevisionNotFoundError(err) {
+			return presenter.ErrorJSON(c, presenter.CodeNotFound, "revision not found")
+		}
+		return presenter.DomainErrorJSON(c, err, "failed to revert item")
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+func (h *ItemHandler) PublishItem(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	item, err := h.itemUsecase.PublishItem(c.Request().Context(), id)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to publish item")
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+type AddItemTagRequest struct {
+	Name string `json:"name"`
+}
+
+func (h *ItemHandler) AddItemTag(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	var req AddItemTagRequest
+	if err := c.Bind(&req); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	item, err := h.itemUsecase.AddItemTag(c.Request().Context(), id, req.Name)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to add tag")
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+func (h *ItemHandler) RemoveItemTag(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	err = h.itemUsecase.RemoveItemTag(c.Request().Context(), id, c.Param("tag"))
 	if err != nil {
 		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
+			return presenter.ErrorJSON(c, presenter.CodeNotFound, "item or tag not found", err.Error())
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to delete item",
-		})
+		return presenter.DomainErrorJSON(c, err, "failed to remove tag")
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+// 全アイテムをCSV（format=xlsx / json も可）でストリーミング出力する（bom=true で Excel 向けに BOM を付ける）
+// 件数が多い場合は POST /exports で非同期に作成する
+// locale=ja-JP / en-US を指定すると金額・日付をそのロケールの表記で出力する
+func (h *ItemHandler) ExportItems(c echo.Context) error {
+	input := usecase.ExportItemsInput{
+		Format: c.QueryParam("format"),
+	}
+	if bom := c.QueryParam("bom"); bom != "" {
+		value, err := strconv.ParseBool(bom)
+		if err != nil {
+			return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", "bom must be a boolean")
+		}
+		input.BOM = value
+	}
+	if locale := c.QueryParam("locale"); locale != "" {
+		formatter, err := presenter.NewFormatter(locale)
+		if err != nil {
+			return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", err.Error())
+		}
+		input.Formatter = formatter
+	}
+
+	fileType, ok := usecase.ExportFileTypeOf(input.Format)
+	if !ok {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", "format must be one of: csv, xlsx, json")
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, fileType.ContentType)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="items%s"`, fileType.Extension))
+
+	err := h.itemUsecase.ExportItems(c.Request().Context(), res, input)
+	if err != nil {
+		// 書き込み開始後はステータスを変更できないため、途中で打ち切る
+		if res.Committed {
+			return err
+		}
+		res.Header().Del(echo.HeaderContentType)
+		res.Header().Del(echo.HeaderContentDisposition)
+		return presenter.DomainErrorJSON(c, err, "failed to export items")
+	}
+
+	return nil
+}
+
+// インポートできるファイルの最大サイズ
+const maxImportFileSize = 10 << 20
+
+// CSV/XLSX ファイルからアイテムを一括登録する（エラーのある行は理由とともに返す）
+func (h *ItemHandler) ImportItems(c echo.Context) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", "file is required")
+	}
+	if fileHeader.Size > maxImportFileSize {
+		return presenter.ErrorJSON(c, presenter.CodePayloadTooLarge, fmt.Sprintf("file must be %d MB or smaller", maxImportFileSize>>20))
+	}
+
+	// 形式の指定がなければ拡張子から判断する
+	format := c.FormValue("format")
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "failed to read file")
+	}
+	defer file.Close()
+
+	result, err := h.itemUsecase.ImportItems(c.Request().Context(), usecase.ImportItemsInput{
+		Format: format,
+		File:   file,
+	})
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to import items")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// 管理者用のアイテム一覧（include_deleted=true で論理削除されたアイテムも含める）
+// 保全状態の設定（管理者用）
+func (h *ItemHandler) SetItemHold(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	var input usecase.SetItemHoldInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	item, err := h.itemUsecase.SetItemHold(c.Request().Context(), id, input)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to set item hold")
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+// POST /items/:id/sell
+func (h *ItemHandler) SellItem(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	var input usecase.SellItemInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	sale, err := h.itemUsecase.SellItem(c.Request().Context(), id, input)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to sell item")
+	}
+
+	return c.JSON(http.StatusCreated, sale)
+}
+
+// POST /items/:id/split
+func (h *ItemHandler) SplitItem(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	var input usecase.SplitItemInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	result, err := h.itemUsecase.SplitItem(c.Request().Context(), id, input)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to split item")
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+// 削除から保持期間（retention、省略時は設定値）が経過したアイテムの写真・評価額・タグ・保険の契約を削除する
+func (h *ItemHandler) CleanupOrphans(c echo.Context) error {
+	retention := h.orphanRetention
+	if value := c.QueryParam("retention"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", "retention must be a duration (e.g. 720h)")
+		}
+		retention = parsed
+	}
+
+	result, err := h.itemUsecase.CleanupOrphans(c.Request().Context(), retention)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to clean up orphaned data")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *ItemHandler) GetItemsForAdmin(c echo.Context) error {
+	var input usecase.ListItemsInput
+	errs := bindListItemsInput(c, &input)
+	if includeDeleted := c.QueryParam("include_deleted"); includeDeleted != "" {
+		value, err := strconv.ParseBool(includeDeleted)
+		if err != nil {
+			errs = append(errs, "include_deleted must be a boolean")
+		}
+		input.IncludeDeleted = value
+	}
+	if len(errs) > 0 {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", errs...)
+	}
+
+	items, err := h.itemUsecase.GetAllItems(c.Request().Context(), input)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve items")
+	}
+
+	return c.JSON(http.StatusOK, items)
+}
+
 func (h *ItemHandler) GetSummary(c echo.Context) error {
 	summary, err := h.itemUsecase.GetCategorySummary(c.Request().Context())
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve summary",
-		})
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve summary")
+	}
+
+	middleware.SetSurrogateKeys(c, usecase.ItemsSurrogateKey)
+	return c.JSON(http.StatusOK, summary)
+}
+
+func (h *ItemHandler) GetBrandSummary(c echo.Context) error {
+	summary, err := h.itemUsecase.GetBrandSummary(c.Request().Context())
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve brand summary")
+	}
+
+	middleware.SetSurrogateKeys(c, usecase.ItemsSurrogateKey)
+	return c.JSON(http.StatusOK, summary)
+}
+
+// GET /items/{id}/depreciation?method=straight&years=5
+// method=custom の場合は rates=40,30,20,10 のように年ごとの償却率（%）を指定する
+func (h *ItemHandler) GetDepreciation(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	input := usecase.DepreciationInput{
+		Method: c.QueryParam("method"),
+		AsOf:   c.QueryParam("as_of"),
+	}
+	var errs []string
+	if years := c.QueryParam("years"); years != "" {
+		value, err := strconv.Atoi(years)
+		if err != nil {
+			errs = append(errs, "years must be an integer")
+		}
+		input.Years = value
+	}
+	if rates := c.QueryParam("rates"); rates != "" {
+		for _, rate := range strings.Split(rates, ",") {
+			value, err := strconv.Atoi(strings.TrimSpace(rate))
+			if err != nil {
+				errs = append(errs, "rates must be comma-separated integers")
+				break
+			}
+			input.Rates = append(input.Rates, value)
+		}
+	}
+	if len(errs) > 0 {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", errs...)
+	}
+
+	depreciation, err := h.itemUsecase.GetDepreciation(c.Request().Context(), id, input)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to calculate depreciation")
 	}
 
+	return c.JSON(http.StatusOK, depreciation)
+}
+
+// 購入年×カテゴリーの集計表
+func (h *ItemHandler) GetYearCategorySummary(c echo.Context) error {
+	summary, err := h.itemUsecase.GetYearCategorySummary(c.Request().Context())
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve year category summary")
+	}
+
+	middleware.SetSurrogateKeys(c, usecase.ItemsSurrogateKey)
 	return c.JSON(http.StatusOK, summary)
 }
 
-func validateCreateItemInput(input usecase.CreateItemInput) []string {
+// 一覧レスポンスのサロゲートキー
+// 一覧に含まれないアイテムの追加でも内容が変わるため、全体のキーも付ける
+func listSurrogateKeys(input usecase.ListItemsInput, list *usecase.ItemList) []string {
+	keys := []string{usecase.ItemsSurrogateKey}
+	if input.Category != "" {
+		keys = append(keys, usecase.CategorySurrogateKey(input.Category))
+	}
+	for _, item := range list.Items {
+		keys = append(keys, usecase.ItemSurrogateKey(item.ID))
+	}
+	return keys
+}
+
+// クエリパラメータから一覧取得条件を読み取る
+func bindListItemsInput(c echo.Context, input *usecase.ListItemsInput) []string {
 	var errs []string
 
+	if limit := c.QueryParam("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			errs = append(errs, "limit must be an integer")
+		}
+		input.Limit = value
+	}
+	if offset := c.QueryParam("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil {
+			errs = append(errs, "offset must be an integer")
+		}
+		input.Offset = value
+	}
+	input.Sort = c.QueryParam("sort")
+	input.Order = c.QueryParam("order")
+	input.Category = c.QueryParam("category")
+	input.Tag = c.QueryParam("tag")
+	if changedAfter := c.QueryParam("changed_after"); changedAfter != "" {
+		value, err := strconv.ParseInt(changedAfter, 10, 64)
+		if err != nil {
+			errs = append(errs, "changed_after must be an integer")
+		}
+		input.ChangedAfter = value
+	}
+	if uncategorized := c.QueryParam("uncategorized"); uncategorized != "" {
+		value, err := strconv.ParseBool(uncategorized)
+		if err != nil {
+			errs = append(errs, "uncategorized must be a boolean")
+		}
+		input.Uncategorized = value
+	}
+
+	return errs
+}
+
+func validateCreateItemInput(input usecase.CreateItemInput) entity.ValidationErrors {
+	var errs entity.ValidationErrors
+
 	// Basic required field validation
 	if input.Name == "" {
-		errs = append(errs, "name is required")
+		errs.Add("name", "is required")
 	}
-	if input.Category == "" {
-		errs = append(errs, "category is required")
+	// 下書きはブランド・購入日の未入力を許す
+	if input.Brand == "" && !input.Draft {
+		errs.Add("brand", "is required")
 	}
-	if input.Brand == "" {
-		errs = append(errs, "brand is required")
+	if input.PurchaseDate == "" && !input.Draft {
+		errs.Add("purchase_date", "is required")
+	} else if _, err := entity.ParseDate(input.PurchaseDate); err != nil {
+		errs.Add("purchase_date", "must be in YYYY-MM-DD format")
 	}
-	if input.PurchaseDate == "" {
-		errs = append(errs, "purchase_date is required")
-	}
-	if input.PurchasePrice < 0 {
-		errs = append(errs, "purchase_price must be 0 or greater")
+	if input.PurchasePrice.IsNegative() {
+		errs.Add("purchase_price", "must be 0 or greater")
 	}
 
 	return errs
 }
 
-func validateUpdateItemInput(input usecase.UpdateItemInput) []string {
-	var errs []string
+func validateUpdateItemInput(input usecase.UpdateItemInput) entity.ValidationErrors {
+	var errs entity.ValidationErrors
 
-	if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil {
-		errs = append(errs, "at least one field must be provided")
+	if !input.HasChanges() {
+		// 特定の項目に紐づかないエラー
+		errs = append(errs, entity.FieldError{Reason: "at least one field must be provided"})
 	}
 
-	if input.PurchasePrice != nil && *input.PurchasePrice < 0 {
-		errs = append(errs, "purchase_price must be 0 or greater")
+	if input.PurchasePrice != nil && input.PurchasePrice.IsNegative() {
+		errs.Add("purchase_price", "must be 0 or greater")
+	}
+	if input.PurchaseDate != nil {
+		if _, err := entity.ParseDate(*input.PurchaseDate); err != nil {
+			errs.Add("purchase_date", "must be in YYYY-MM-DD format")
+		}
 	}
 
 	return errs
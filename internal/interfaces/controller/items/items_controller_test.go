@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// CreateItems のみを差し替えたユースケース（他のメソッドは呼ばれない）
+type stubItemUsecase struct {
+	usecase.ItemUsecase
+	createItems func(input usecase.BulkCreateItemsInput) (*usecase.BulkCreateResult, error)
+}
+
+func (s *stubItemUsecase) CreateItems(ctx context.Context, input usecase.BulkCreateItemsInput) (*usecase.BulkCreateResult, error) {
+	return s.createItems(input)
+}
+
+func TestItemHandler_CreateItems(t *testing.T) {
+	body := `{"mode": "partial", "items": [{"name": "時計1", "category": "時計", "brand": "ROLEX", "purchase_price": 1000000, "purchase_date": "2023-01-01"}]}`
+
+	tests := []struct {
+		name           string
+		body           string
+		result         *usecase.BulkCreateResult
+		err            error
+		expectedStatus int
+	}{
+		{
+			name:           "正常系: すべて登録できた場合は 201",
+			body:           body,
+			result:         &usecase.BulkCreateResult{Created: []*entity.Item{{ID: 1}}, Failed: []usecase.BulkItemError{}},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "正常系: 一部が登録できなかった場合は 207",
+			body:           body,
+			result:         &usecase.BulkCreateResult{Created: []*entity.Item{}, Failed: []usecase.BulkItemError{{Index: 0, Errors: []string{"name is required"}}}},
+			expectedStatus: http.StatusMultiStatus,
+		},
+		{
+			name:           "異常系: 項目のエラー",
+			body:           body,
+			err:            &usecase.BulkValidationError{Failed: []usecase.BulkItemError{{Index: 0, Errors: []string{"name is required"}}}},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 不正なJSON",
+			body:           `{"items": `,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemUsecase := &stubItemUsecase{createItems: func(input usecase.BulkCreateItemsInput) (*usecase.BulkCreateResult, error) {
+				assert.Len(t, input.Items, 1)
+				return tt.result, tt.err
+			}}
+			e := echo.New()
+			e.POST("/items/bulk", newTestItemHandler(itemUsecase).CreateItems)
+
+			req := httptest.NewRequest(http.MethodPost, "/items/bulk", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func newTestItemHandler(itemUsecase usecase.ItemUsecase) *ItemHandler {
+	return NewItemHandler(itemUsecase, 0)
+}
@@ -0,0 +1,57 @@
+package jobs
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type JobHandler struct {
+	jobUsecase          usecase.JobUsecase
+	scheduledJobUsecase usecase.ScheduledJobUsecase
+}
+
+func NewJobHandler(jobUsecase usecase.JobUsecase, scheduledJobUsecase usecase.ScheduledJobUsecase) *JobHandler {
+	return &JobHandler{
+		jobUsecase:          jobUsecase,
+		scheduledJobUsecase: scheduledJobUsecase,
+	}
+}
+
+// 操作者（X-User-ID）が登録したジョブの状況と結果
+func (h *JobHandler) GetJob(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid job ID")
+	}
+
+	job, err := h.jobUsecase.GetJob(c.Request().Context(), id)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve job")
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+// 定期実行の履歴（新しい順、?limit で件数を指定）
+func (h *JobHandler) GetJobRuns(c echo.Context) error {
+	limit := 0
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "limit must be an integer")
+		}
+		limit = parsed
+	}
+
+	runs, err := h.scheduledJobUsecase.ListRuns(c.Request().Context(), limit)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve job runs")
+	}
+
+	return c.JSON(http.StatusOK, runs)
+}
@@ -0,0 +1,43 @@
+package labels
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type LabelHandler struct {
+	labelUsecase usecase.LabelUsecase
+}
+
+func NewLabelHandler(labelUsecase usecase.LabelUsecase) *LabelHandler {
+	return &LabelHandler{
+		labelUsecase: labelUsecase,
+	}
+}
+
+// GET /items/labels/layouts
+func (h *LabelHandler) GetLabelLayouts(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.labelUsecase.GetLabelLayouts())
+}
+
+// 指定したアイテムのラベルシートをPDFで返す（JSON で item_ids・layout・skip を指定する）
+// 描画の途中で失敗した場合もエラーレスポンスを返せるよう、PDF 全体を生成してから書き込む
+func (h *LabelHandler) GenerateLabels(c echo.Context) error {
+	var input usecase.GenerateLabelsInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	var buf bytes.Buffer
+	if err := h.labelUsecase.GenerateLabels(c.Request().Context(), &buf, input); err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to generate labels")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="labels.pdf"`)
+	return c.Blob(http.StatusOK, "application/pdf", buf.Bytes())
+}
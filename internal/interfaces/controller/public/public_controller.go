@@ -0,0 +1,54 @@
+package public
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// 認証なしで公開するエンドポイント（マーケティングサイト向け）
+// 集計はキャッシュからのみ返し、データベースへの負荷を一定に抑える
+type PublicHandler struct {
+	summaryCache *usecase.SummaryCache
+	maxAge       time.Duration
+}
+
+func NewPublicHandler(summaryCache *usecase.SummaryCache, maxAge time.Duration) *PublicHandler {
+	return &PublicHandler{
+		summaryCache: summaryCache,
+		maxAge:       maxAge,
+	}
+}
+
+func (h *PublicHandler) GetStats(c echo.Context) error {
+	stats, err := h.summaryCache.GetPublicStats(c.Request().Context())
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeServiceUnavailable, "stats are temporarily unavailable")
+	}
+
+	c.Response().Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.maxAge.Seconds())))
+	return c.JSON(http.StatusOK, stats)
+}
+
+// POST /admin/summaries/recompute
+// 公開統計のキャッシュを期限によらず再集計する（管理者）
+func (h *PublicHandler) RecomputeStats(c echo.Context) error {
+	result, err := h.summaryCache.Recompute(c.Request().Context())
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to recompute summaries")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// GET /admin/summaries/recompute
+// 実行中または直前の再集計の進み具合を返す（管理者）
+func (h *PublicHandler) RecomputeProgress(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.summaryCache.RecomputeProgress(c.Request().Context()))
+}
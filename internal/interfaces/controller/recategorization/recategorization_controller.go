@@ -0,0 +1,111 @@
+package recategorization
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type RecategorizationHandler struct {
+	recategorizationUsecase usecase.RecategorizationUsecase // LLM が未設定の場合は nil
+	jobUsecase              usecase.JobUsecase
+}
+
+func NewRecategorizationHandler(recategorizationUsecase usecase.RecategorizationUsecase, jobUsecase usecase.JobUsecase) *RecategorizationHandler {
+	return &RecategorizationHandler{
+		recategorizationUsecase: recategorizationUsecase,
+		jobUsecase:              jobUsecase,
+	}
+}
+
+// 「その他」のアイテムのカテゴリーの推定をジョブとして登録する（結果は Location の GET /jobs/{id} で確認する）
+func (h *RecategorizationHandler) Scan(c echo.Context) error {
+	if h.recategorizationUsecase == nil {
+		return notConfigured(c)
+	}
+
+	job, err := h.jobUsecase.Enqueue(c.Request().Context(), usecase.JobItemsRecategorize, nil)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to enqueue recategorization")
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/jobs/%d", job.ID))
+	return c.JSON(http.StatusAccepted, job)
+}
+
+// 提案の一覧（?status=pending|approved|rejected、?limit、?offset）
+func (h *RecategorizationHandler) GetSuggestions(c echo.Context) error {
+	if h.recategorizationUsecase == nil {
+		return notConfigured(c)
+	}
+
+	input := usecase.ListSuggestionsInput{Status: c.QueryParam("status")}
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "limit must be an integer")
+		}
+		input.Limit = parsed
+	}
+	if value := c.QueryParam("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "offset must be an integer")
+		}
+		input.Offset = parsed
+	}
+
+	suggestions, err := h.recategorizationUsecase.ListSuggestions(c.Request().Context(), input)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve suggestions")
+	}
+
+	return c.JSON(http.StatusOK, suggestions)
+}
+
+// 提案のカテゴリーにアイテムを変更する
+func (h *RecategorizationHandler) ApproveSuggestion(c echo.Context) error {
+	if h.recategorizationUsecase == nil {
+		return notConfigured(c)
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid suggestion ID")
+	}
+
+	suggestion, err := h.recategorizationUsecase.ApproveSuggestion(c.Request().Context(), id)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to approve suggestion")
+	}
+
+	return c.JSON(http.StatusOK, suggestion)
+}
+
+func (h *RecategorizationHandler) RejectSuggestion(c echo.Context) error {
+	if h.recategorizationUsecase == nil {
+		return notConfigured(c)
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid suggestion ID")
+	}
+
+	suggestion, err := h.recategorizationUsecase.RejectSuggestion(c.Request().Context(), id)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to reject suggestion")
+	}
+
+	return c.JSON(http.StatusOK, suggestion)
+}
+
+// LLM（LLM_API_URL）が未設定の場合、提案の機能は使えない
+func notConfigured(c echo.Context) error {
+	return presenter.ErrorJSON(c, presenter.CodeServiceUnavailable, "recategorization is not configured")
+}
@@ -0,0 +1,78 @@
+package receipts
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type ReceiptHandler struct {
+	receiptUsecase usecase.ReceiptUsecase // OCR が未設定の場合は nil
+}
+
+func NewReceiptHandler(receiptUsecase usecase.ReceiptUsecase) *ReceiptHandler {
+	return &ReceiptHandler{receiptUsecase: receiptUsecase}
+}
+
+// 写真の文字を読み取り、購入価格・購入日・店名の候補を返す（アイテムには反映しない）
+func (h *ReceiptHandler) ScanReceipt(c echo.Context) error {
+	if h.receiptUsecase == nil {
+		return presenter.ErrorJSON(c, presenter.CodeServiceUnavailable, "receipt scanning is not configured")
+	}
+
+	itemID, imageID, ok := parseIDs(c)
+	if !ok {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID or image ID")
+	}
+
+	scan, err := h.receiptUsecase.ScanReceipt(c.Request().Context(), itemID, imageID)
+	if err != nil {
+		return errorResponse(c, err, "failed to scan receipt")
+	}
+
+	return c.JSON(http.StatusOK, scan)
+}
+
+func (h *ReceiptHandler) GetReceipt(c echo.Context) error {
+	if h.receiptUsecase == nil {
+		return presenter.ErrorJSON(c, presenter.CodeServiceUnavailable, "receipt scanning is not configured")
+	}
+
+	itemID, imageID, ok := parseIDs(c)
+	if !ok {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID or image ID")
+	}
+
+	scan, err := h.receiptUsecase.GetReceipt(c.Request().Context(), itemID, imageID)
+	if err != nil {
+		return errorResponse(c, err, "failed to retrieve receipt")
+	}
+
+	return c.JSON(http.StatusOK, scan)
+}
+
+// パスのアイテムIDと写真ID
+func parseIDs(c echo.Context) (int64, int64, bool) {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	imageID, err := strconv.ParseInt(c.Param("imageId"), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return itemID, imageID, true
+}
+
+// アイテム・写真がない場合は区別せずに返す
+func errorResponse(c echo.Context, err error, message string) error {
+	if domainErrors.IsNotFoundError(err) {
+		return presenter.ErrorJSON(c, presenter.CodeNotFound, "")
+	}
+	return presenter.DomainErrorJSON(c, err, message)
+}
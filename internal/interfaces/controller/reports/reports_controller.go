@@ -0,0 +1,74 @@
+package reports
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type ReportHandler struct {
+	reportUsecase usecase.ReportUsecase
+}
+
+func NewReportHandler(reportUsecase usecase.ReportUsecase) *ReportHandler {
+	return &ReportHandler{
+		reportUsecase: reportUsecase,
+	}
+}
+
+// GET /reports/purchases/monthly?from=YYYY-MM&to=YYYY-MM
+func (h *ReportHandler) GetMonthlyPurchases(c echo.Context) error {
+	report, err := h.reportUsecase.GetMonthlyPurchases(c.Request().Context(), usecase.MonthlyPurchasesInput{
+		From: c.QueryParam("from"),
+		To:   c.QueryParam("to"),
+	})
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve monthly purchases")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// GET /reports/customs?from=YYYY&to=YYYY&country=US
+func (h *ReportHandler) GetCustomsValues(c echo.Context) error {
+	report, err := h.reportUsecase.GetCustomsValues(c.Request().Context(), usecase.CustomsValuesInput{
+		From:    c.QueryParam("from"),
+		To:      c.QueryParam("to"),
+		Country: c.QueryParam("country"),
+	})
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve customs values")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// GET /reports/pnl?period=month|year&from=&to=
+func (h *ReportHandler) GetProfitAndLoss(c echo.Context) error {
+	report, err := h.reportUsecase.GetProfitAndLoss(c.Request().Context(), usecase.ProfitAndLossInput{
+		Period: c.QueryParam("period"),
+		From:   c.QueryParam("from"),
+		To:     c.QueryParam("to"),
+	})
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve profit and loss")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// GET /reports/tax?from=YYYY&to=YYYY
+func (h *ReportHandler) GetTaxPaid(c echo.Context) error {
+	report, err := h.reportUsecase.GetTaxPaid(c.Request().Context(), usecase.TaxPaidInput{
+		From: c.QueryParam("from"),
+		To:   c.QueryParam("to"),
+	})
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve tax paid")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,32 @@
+package search
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type SearchHandler struct {
+	indexer *usecase.SearchIndexer // 検索エンジンが未設定の場合は nil
+}
+
+func NewSearchHandler(indexer *usecase.SearchIndexer) *SearchHandler {
+	return &SearchHandler{indexer: indexer}
+}
+
+// POST /admin/search/reindex
+func (h *SearchHandler) Reindex(c echo.Context) error {
+	if h.indexer == nil {
+		return presenter.ErrorJSON(c, presenter.CodeServiceUnavailable, "search index is not configured")
+	}
+
+	result, err := h.indexer.Reindex(c.Request().Context())
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to rebuild search index")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
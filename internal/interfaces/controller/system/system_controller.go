@@ -3,15 +3,51 @@ package system
 import (
 	"net/http"
 
+	"Aicon-assignment/internal/interfaces/presenter"
+
 	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/infrastructure/buildinfo"
+	"Aicon-assignment/internal/usecase"
 )
 
-type SystemHandler struct{}
+type SystemHandler struct {
+	readOnly *usecase.ReadOnlySwitch
+}
+
+// 読み取り専用モードの状態
+type ReadOnlyStatus struct {
+	Enabled *bool `json:"enabled"`
+}
 
 func (handler *SystemHandler) Health(ctx echo.Context) {
 	ctx.NoContent(http.StatusOK)
 }
 
-func NewSystemHandler() *SystemHandler {
-	return &SystemHandler{}
+// デプロイされているバージョン情報を返す
+func (handler *SystemHandler) Version(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, buildinfo.Get())
+}
+
+// 読み取り専用モードの状態を返す
+func (handler *SystemHandler) GetReadOnly(ctx echo.Context) error {
+	enabled := handler.readOnly.Enabled()
+	return ctx.JSON(http.StatusOK, ReadOnlyStatus{Enabled: &enabled})
+}
+
+// 読み取り専用モードを切り替える
+func (handler *SystemHandler) SetReadOnly(ctx echo.Context) error {
+	var status ReadOnlyStatus
+	if err := ctx.Bind(&status); err != nil || status.Enabled == nil {
+		return presenter.ErrorJSON(ctx, presenter.CodeValidationFailed, "", "enabled must be a boolean")
+	}
+
+	handler.readOnly.Set(*status.Enabled)
+	return ctx.JSON(http.StatusOK, status)
+}
+
+func NewSystemHandler(readOnly *usecase.ReadOnlySwitch) *SystemHandler {
+	return &SystemHandler{
+		readOnly: readOnly,
+	}
 }
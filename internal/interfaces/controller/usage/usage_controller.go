@@ -0,0 +1,33 @@
+package usage
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// 操作者（X-User-ID）ごとのAPIの利用状況
+type UsageHandler struct {
+	tracker *usecase.UsageTracker
+}
+
+func NewUsageHandler(tracker *usecase.UsageTracker) *UsageHandler {
+	return &UsageHandler{
+		tracker: tracker,
+	}
+}
+
+// GET /users/me/usage
+// リクエストした操作者の利用状況（このリクエストは含まない）
+func (h *UsageHandler) GetMyUsage(c echo.Context) error {
+	actor := usecase.ActorFromContext(c.Request().Context())
+	return c.JSON(http.StatusOK, h.tracker.Get(actor))
+}
+
+// GET /admin/usage
+// 全操作者の利用状況（管理者）
+func (h *UsageHandler) GetUsage(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.tracker.Rollup())
+}
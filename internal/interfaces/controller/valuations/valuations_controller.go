@@ -0,0 +1,137 @@
+package valuations
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type ValuationHandler struct {
+	valuationUsecase usecase.ValuationUsecase
+	jobUsecase       usecase.JobUsecase
+}
+
+func NewValuationHandler(valuationUsecase usecase.ValuationUsecase, jobUsecase usecase.JobUsecase) *ValuationHandler {
+	return &ValuationHandler{
+		valuationUsecase: valuationUsecase,
+		jobUsecase:       jobUsecase,
+	}
+}
+
+type RecordValuationRequest struct {
+	ValuatedAt string        `json:"valuated_at"`
+	Value      *entity.Money `json:"value"`
+	Source     string        `json:"source"`
+	Reason     string        `json:"reason"`
+}
+
+func (h *ValuationHandler) RecordValuation(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	var req RecordValuationRequest
+	if err := c.Bind(&req); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+	if req.Value == nil {
+		return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", "value is required")
+	}
+
+	valuation, err := h.valuationUsecase.RecordValuation(c.Request().Context(), itemID, usecase.RecordValuationInput{
+		ValuatedAt: req.ValuatedAt,
+		Value:      *req.Value,
+		Source:     req.Source,
+		Reason:     req.Reason,
+	})
+	if err != nil {
+		return errorResponse(c, err, "failed to record valuation")
+	}
+
+	return c.JSON(http.StatusCreated, valuation)
+}
+
+// 相場APIから現在の市場価格を取得し、当日の評価額として記録する
+// async=true の場合はジョブとして登録して 202 を返す（状況は Location の GET /jobs/:id で確認する）
+func (h *ValuationHandler) RefreshValuation(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	if async := c.QueryParam("async"); async != "" {
+		value, err := strconv.ParseBool(async)
+		if err != nil {
+			return presenter.ErrorJSON(c, presenter.CodeValidationFailed, "", "async must be a boolean")
+		}
+		if value {
+			return h.enqueueRefresh(c, itemID)
+		}
+	}
+
+	valuation, err := h.valuationUsecase.RefreshItemValuation(c.Request().Context(), itemID)
+	if err != nil {
+		return errorResponse(c, err, "failed to refresh valuation")
+	}
+
+	return c.JSON(http.StatusCreated, valuation)
+}
+
+func (h *ValuationHandler) enqueueRefresh(c echo.Context, itemID int64) error {
+	if itemID <= 0 {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	job, err := h.jobUsecase.Enqueue(c.Request().Context(), usecase.JobValuationRefresh, usecase.ValuationRefreshPayload{ItemID: itemID})
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to enqueue valuation refresh")
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/jobs/%d", job.ID))
+	return c.JSON(http.StatusAccepted, job)
+}
+
+// 評価額の履歴を評価日の古い順に返す
+func (h *ValuationHandler) GetValuations(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid item ID")
+	}
+
+	valuations, err := h.valuationUsecase.GetValuations(c.Request().Context(), itemID)
+	if err != nil {
+		return errorResponse(c, err, "failed to retrieve valuations")
+	}
+
+	return c.JSON(http.StatusOK, valuations)
+}
+
+// 絞り込んだアイテムの評価額を一律の割合で調整する（管理者）
+func (h *ValuationHandler) AdjustValuations(c echo.Context) error {
+	var input usecase.AdjustValuationsInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	adjustment, err := h.valuationUsecase.AdjustValuations(c.Request().Context(), input)
+	if err != nil {
+		return errorResponse(c, err, "failed to adjust valuations")
+	}
+
+	return c.JSON(http.StatusOK, adjustment)
+}
+
+func errorResponse(c echo.Context, err error, message string) error {
+	if domainErrors.IsMarketPriceUnavailableError(err) {
+		return presenter.ErrorJSON(c, presenter.CodeUpstreamUnavailable, "market price unavailable", err.Error())
+	}
+	return presenter.DomainErrorJSON(c, err, message)
+}
@@ -0,0 +1,74 @@
+package webhooks
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+)
+
+type WebhookHandler struct {
+	webhookUsecase usecase.WebhookUsecase
+}
+
+func NewWebhookHandler(webhookUsecase usecase.WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUsecase: webhookUsecase,
+	}
+}
+
+// 操作者（X-User-ID）が登録した送信先（署名の鍵は含めない）
+func (h *WebhookHandler) GetWebhooks(c echo.Context) error {
+	webhooks, err := h.webhookUsecase.GetWebhooks(c.Request().Context())
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve webhooks")
+	}
+
+	return c.JSON(http.StatusOK, webhooks)
+}
+
+// 送信先を登録する（レスポンスの secret は署名の検証に使うため、このときに保存してもらう）
+func (h *WebhookHandler) CreateWebhook(c echo.Context) error {
+	var input usecase.CreateWebhookInput
+	if err := c.Bind(&input); err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid request format")
+	}
+
+	webhook, err := h.webhookUsecase.CreateWebhook(c.Request().Context(), input)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to create webhook")
+	}
+
+	return c.JSON(http.StatusCreated, webhook)
+}
+
+func (h *WebhookHandler) DeleteWebhook(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid webhook ID")
+	}
+
+	if err := h.webhookUsecase.DeleteWebhook(c.Request().Context(), id); err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to delete webhook")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// 送信ログ（新しい順）。送信先での受信を確認するときに使う
+func (h *WebhookHandler) GetDeliveries(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return presenter.ErrorJSON(c, presenter.CodeInvalidRequest, "invalid webhook ID")
+	}
+
+	deliveries, err := h.webhookUsecase.GetDeliveries(c.Request().Context(), id)
+	if err != nil {
+		return presenter.DomainErrorJSON(c, err, "failed to retrieve webhook deliveries")
+	}
+
+	return c.JSON(http.StatusOK, deliveries)
+}
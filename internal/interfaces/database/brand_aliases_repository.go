@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type BrandAliasRepository struct {
+	SqlHandler
+}
+
+func (r *BrandAliasRepository) FindAll(ctx context.Context) ([]*entity.BrandAlias, error) {
+	query := `
+        SELECT alias, brand, updated_at
+        FROM brand_aliases
+        ORDER BY alias
+    `
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var aliases []*entity.BrandAlias
+	for rows.Next() {
+		alias, err := scanBrandAlias(rows)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		aliases = append(aliases, alias)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return aliases, nil
+}
+
+func (r *BrandAliasRepository) Save(ctx context.Context, alias *entity.BrandAlias) (*entity.BrandAlias, error) {
+	query := `
+        INSERT INTO brand_aliases (alias_key, alias, brand)
+        VALUES (?, ?, ?)
+        ON DUPLICATE KEY UPDATE alias = VALUES(alias), brand = VALUES(brand)
+    `
+	if r.Dialect() == SQLite {
+		query = `
+            INSERT INTO brand_aliases (alias_key, alias, brand)
+            VALUES (?, ?, ?)
+            ON CONFLICT (alias_key) DO UPDATE SET alias = excluded.alias, brand = excluded.brand, updated_at = CURRENT_TIMESTAMP
+        `
+	}
+
+	if _, err := r.Execute(ctx, query, alias.Key(), alias.Alias, alias.Brand); err != nil {
+		return nil, classifyError(err)
+	}
+
+	query = `
+        SELECT alias, brand, updated_at
+        FROM brand_aliases
+        WHERE alias_key = ?
+    `
+
+	saved, err := scanBrandAlias(r.QueryRow(ctx, query, alias.Key()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: saved brand alias not found", domainErrors.ErrDatabaseError)
+		}
+		return nil, classifyError(err)
+	}
+
+	return saved, nil
+}
+
+func (r *BrandAliasRepository) Delete(ctx context.Context, key string) error {
+	query := `DELETE FROM brand_aliases WHERE alias_key = ?`
+
+	result, err := r.Execute(ctx, query, key)
+	if err != nil {
+		return classifyError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrItemNotFound
+	}
+
+	return nil
+}
+
+func scanBrandAlias(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.BrandAlias, error) {
+	var alias entity.BrandAlias
+	var updatedAt time.Time
+
+	err := scanner.Scan(
+		&alias.Alias,
+		&alias.Brand,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	alias.UpdatedAt = &updatedAt
+
+	return &alias, nil
+}
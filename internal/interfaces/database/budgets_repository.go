@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type BudgetRepository struct {
+	SqlHandler
+}
+
+func (r *BudgetRepository) FindAll(ctx context.Context) ([]*entity.CategoryBudget, error) {
+	query := `
+        SELECT category, amount, currency, updated_at
+        FROM category_budgets
+        ORDER BY category
+    `
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var budgets []*entity.CategoryBudget
+	for rows.Next() {
+		budget, err := scanBudget(rows)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		budgets = append(budgets, budget)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return budgets, nil
+}
+
+func (r *BudgetRepository) FindByCategory(ctx context.Context, category string) (*entity.CategoryBudget, error) {
+	query := `
+        SELECT category, amount, currency, updated_at
+        FROM category_budgets
+        WHERE category = ?
+    `
+
+	budget, err := scanBudget(r.QueryRow(ctx, query, category))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, classifyError(err)
+	}
+
+	return budget, nil
+}
+
+func (r *BudgetRepository) Save(ctx context.Context, budget *entity.CategoryBudget) (*entity.CategoryBudget, error) {
+	query := `
+        INSERT INTO category_budgets (category, amount, currency)
+        VALUES (?, ?, ?)
+        ON DUPLICATE KEY UPDATE amount = VALUES(amount), currency = VALUES(currency)
+    `
+	if r.Dialect() == SQLite {
+		query = `
+            INSERT INTO category_budgets (category, amount, currency)
+            VALUES (?, ?, ?)
+            ON CONFLICT (category) DO UPDATE SET amount = excluded.amount, currency = excluded.currency, updated_at = CURRENT_TIMESTAMP
+        `
+	}
+
+	if _, err := r.Execute(ctx, query, budget.Category, budget.Amount.Amount, budget.Amount.Currency); err != nil {
+		return nil, classifyError(err)
+	}
+
+	saved, err := r.FindByCategory(ctx, budget.Category)
+	if err != nil {
+		return nil, err
+	}
+	if saved == nil {
+		return nil, fmt.Errorf("%w: saved budget not found", domainErrors.ErrDatabaseError)
+	}
+
+	return saved, nil
+}
+
+func (r *BudgetRepository) Delete(ctx context.Context, category string) error {
+	query := `DELETE FROM category_budgets WHERE category = ?`
+
+	result, err := r.Execute(ctx, query, category)
+	if err != nil {
+		return classifyError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrItemNotFound
+	}
+
+	return nil
+}
+
+func scanBudget(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.CategoryBudget, error) {
+	var budget entity.CategoryBudget
+
+	err := scanner.Scan(
+		&budget.Category,
+		&budget.Amount.Amount,
+		&budget.Amount.Currency,
+		&budget.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &budget, nil
+}
@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type CategorySuggestionRepository struct {
+	SqlHandler
+}
+
+const categorySuggestionColumns = `id, item_id, from_category, category, confidence, reason, status, reviewed_by, reviewed_at, created_at`
+
+func (r *CategorySuggestionRepository) Create(ctx context.Context, suggestion *entity.CategorySuggestion) (*entity.CategorySuggestion, error) {
+	query := `
+        INSERT INTO category_suggestions (item_id, from_category, category, confidence, reason, status, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		suggestion.ItemID,
+		suggestion.FromCategory,
+		suggestion.Category,
+		suggestion.Confidence,
+		suggestion.Reason,
+		string(suggestion.Status),
+		suggestion.CreatedAt,
+	)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	created := *suggestion
+	created.ID = id
+	return &created, nil
+}
+
+func (r *CategorySuggestionRepository) FindByID(ctx context.Context, id int64) (*entity.CategorySuggestion, error) {
+	query := `
+        SELECT ` + categorySuggestionColumns + `
+        FROM category_suggestions
+        WHERE id = ?
+    `
+
+	suggestion, err := scanCategorySuggestion(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrSuggestionNotFound
+		}
+		return nil, classifyError(err)
+	}
+
+	return suggestion, nil
+}
+
+func (r *CategorySuggestionRepository) FindByStatus(ctx context.Context, status entity.SuggestionStatus, limit, offset int) ([]*entity.CategorySuggestion, error) {
+	query := `
+        SELECT ` + categorySuggestionColumns + `
+        FROM category_suggestions
+        WHERE status = ?
+        ORDER BY id
+        LIMIT ? OFFSET ?
+    `
+
+	rows, err := r.Query(ctx, query, string(status), limit, offset)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	suggestions := []*entity.CategorySuggestion{}
+	for rows.Next() {
+		suggestion, err := scanCategorySuggestion(rows)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return suggestions, nil
+}
+
+func (r *CategorySuggestionRepository) FindPendingItemIDs(ctx context.Context, itemIDs []int64) (map[int64]bool, error) {
+	pending := make(map[int64]bool, len(itemIDs))
+	if len(itemIDs) == 0 {
+		return pending, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(itemIDs)), ", ")
+	args := make([]interface{}, 0, len(itemIDs)+1)
+	args = append(args, string(entity.SuggestionPending))
+	for _, id := range itemIDs {
+		args = append(args, id)
+	}
+
+	query := `
+        SELECT DISTINCT item_id
+        FROM category_suggestions
+        WHERE status = ? AND item_id IN (` + placeholders + `)
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var itemID int64
+		if err := rows.Scan(&itemID); err != nil {
+			return nil, classifyError(err)
+		}
+		pending[itemID] = true
+	}
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return pending, nil
+}
+
+// 確認待ちの提案のみ更新する（同時に承認・却下された場合は、先に記録した方を残す）
+func (r *CategorySuggestionRepository) Update(ctx context.Context, suggestion *entity.CategorySuggestion) error {
+	query := `
+        UPDATE category_suggestions
+        SET status = ?, reviewed_by = ?, reviewed_at = ?
+        WHERE id = ? AND status = ?
+    `
+
+	result, err := r.Execute(ctx, query,
+		string(suggestion.Status),
+		suggestion.ReviewedBy,
+		suggestion.ReviewedAt,
+		suggestion.ID,
+		string(entity.SuggestionPending),
+	)
+	if err != nil {
+		return classifyError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrSuggestionOutdated
+	}
+
+	return nil
+}
+
+func scanCategorySuggestion(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.CategorySuggestion, error) {
+	var suggestion entity.CategorySuggestion
+	var status string
+	var reviewedAt sql.NullTime
+	err := scanner.Scan(
+		&suggestion.ID,
+		&suggestion.ItemID,
+		&suggestion.FromCategory,
+		&suggestion.Category,
+		&suggestion.Confidence,
+		&suggestion.Reason,
+		&status,
+		&suggestion.ReviewedBy,
+		&reviewedAt,
+		&suggestion.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestion.Status = entity.SuggestionStatus(status)
+	if reviewedAt.Valid {
+		suggestion.ReviewedAt = &reviewedAt.Time
+	}
+	return &suggestion, nil
+}
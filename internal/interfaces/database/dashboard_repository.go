@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 管理者ダッシュボードの集計（監査ログ・写真・Webhook の送信ログをまとめて集計する）
+type DashboardRepository struct {
+	SqlHandler
+}
+
+func (r *DashboardRepository) SummarizeActivity(ctx context.Context, query entity.ActivityQuery) (*entity.ActivitySummary, error) {
+	where, args := activityConditions(query)
+	statement := `
+        SELECT COUNT(DISTINCT actor), COUNT(*), COALESCE(SUM(CASE WHEN action = ? THEN 1 ELSE 0 END), 0)
+        FROM item_audit_logs
+        WHERE ` + where
+
+	var summary entity.ActivitySummary
+	err := r.QueryRow(ctx, statement, append([]interface{}{string(entity.AuditCreate)}, args...)...).
+		Scan(&summary.ActiveUsers, &summary.Changes, &summary.ItemsCreated)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	return &summary, nil
+}
+
+// 最後の活動の日時は集計した最大のIDの監査ログから読む（SQLite では集計した日時の型が失われるため）
+func (r *DashboardRepository) FindActorActivity(ctx context.Context, query entity.ActivityQuery) ([]*entity.ActorActivity, error) {
+	where, args := activityConditions(query)
+	statement := `
+        SELECT a.actor, a.changes, a.items_created, l.created_at
+        FROM (
+            SELECT actor, COUNT(*) AS changes, SUM(CASE WHEN action = ? THEN 1 ELSE 0 END) AS items_created, MAX(id) AS last_id
+            FROM item_audit_logs
+            WHERE ` + where + `
+            GROUP BY actor
+        ) a
+        JOIN item_audit_logs l ON l.id = a.last_id
+        ORDER BY a.changes DESC, a.actor
+        LIMIT ?
+    `
+	args = append([]interface{}{string(entity.AuditCreate)}, args...)
+	args = append(args, query.Limit)
+
+	rows, err := r.Query(ctx, statement, args...)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	activities := []*entity.ActorActivity{}
+	for rows.Next() {
+		var activity entity.ActorActivity
+		if err := rows.Scan(&activity.Actor, &activity.Changes, &activity.ItemsCreated, &activity.LastActiveAt); err != nil {
+			return nil, classifyError(err)
+		}
+		activities = append(activities, &activity)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return activities, nil
+}
+
+func (r *DashboardRepository) FindStorageByCategory(ctx context.Context) ([]*entity.CategoryStorage, error) {
+	query := `
+        SELECT i.category, COUNT(*), COALESCE(SUM(m.size), 0),
+            COALESCE(SUM(CASE WHEN i.deleted_at IS NOT NULL THEN m.size ELSE 0 END), 0)
+        FROM item_images m
+        JOIN items i ON i.id = m.item_id
+        GROUP BY i.category
+        ORDER BY COALESCE(SUM(m.size), 0) DESC, i.category
+    `
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	storages := []*entity.CategoryStorage{}
+	for rows.Next() {
+		var storage entity.CategoryStorage
+		if err := rows.Scan(&storage.Category, &storage.Images, &storage.Bytes, &storage.DeletedBytes); err != nil {
+			return nil, classifyError(err)
+		}
+		storages = append(storages, &storage)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return storages, nil
+}
+
+// 最後の配信は集計した最大のIDの送信ログから読む
+func (r *DashboardRepository) FindWebhookHealth(ctx context.Context, since time.Time) ([]*entity.WebhookHealth, error) {
+	query := `
+        SELECT w.id, w.url, w.owner,
+            COALESCE(d.deliveries, 0), COALESCE(d.succeeded, 0), COALESCE(d.failed, 0), COALESCE(d.pending, 0),
+            COALESCE(l.status, ''), COALESCE(l.response_status, 0), COALESCE(l.last_error, ''), l.updated_at
+        FROM webhooks w
+        LEFT JOIN (
+            SELECT webhook_id, COUNT(*) AS deliveries,
+                SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS succeeded,
+                SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS failed,
+                SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS pending,
+                MAX(id) AS last_id
+            FROM webhook_deliveries
+            WHERE created_at >= ?
+            GROUP BY webhook_id
+        ) d ON d.webhook_id = w.id
+        LEFT JOIN webhook_deliveries l ON l.id = d.last_id
+        ORDER BY w.id
+    `
+
+	rows, err := r.Query(ctx, query,
+		string(entity.WebhookDeliverySucceeded),
+		string(entity.WebhookDeliveryFailed),
+		string(entity.WebhookDeliveryPending),
+		since,
+	)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	healths := []*entity.WebhookHealth{}
+	for rows.Next() {
+		var health entity.WebhookHealth
+		var lastStatus string
+		var lastDeliveredAt sql.NullTime
+		err := rows.Scan(
+			&health.WebhookID,
+			&health.URL,
+			&health.Owner,
+			&health.Deliveries,
+			&health.Succeeded,
+			&health.Failed,
+			&health.Pending,
+			&lastStatus,
+			&health.LastResponseStatus,
+			&health.LastError,
+			&lastDeliveredAt,
+		)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		health.LastStatus = entity.WebhookDeliveryStatus(lastStatus)
+		if lastDeliveredAt.Valid {
+			health.LastDeliveredAt = &lastDeliveredAt.Time
+		}
+		healths = append(healths, &health)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return healths, nil
+}
+
+// 監査ログの集計の条件（期間と除外する操作者）
+func activityConditions(query entity.ActivityQuery) (string, []interface{}) {
+	where := "created_at >= ?"
+	args := []interface{}{query.Since}
+	if len(query.ExcludeActors) > 0 {
+		where += " AND actor NOT IN (" + strings.TrimSuffix(strings.Repeat("?, ", len(query.ExcludeActors)), ", ") + ")"
+		for _, actor := range query.ExcludeActors {
+			args = append(args, actor)
+		}
+	}
+	return where, args
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+
+	"github.com/go-sql-driver/mysql"
+	"modernc.org/sqlite"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MySQLのエラー番号
+const (
+	mysqlErrLockWaitTimeout = 1205
+	mysqlErrDeadlock        = 1213
+	mysqlErrDuplicateEntry  = 1062
+)
+
+// SQLiteの（拡張）エラーコード
+const (
+	sqliteErrBusy                 = 5
+	sqliteErrLocked               = 6
+	sqliteErrConstraintPrimaryKey = 1555
+	sqliteErrConstraintUnique     = 2067
+)
+
+// データベースのエラーをドメインエラーに変換する
+// デッドロックや接続断など再試行で成功しうるエラーには ErrTransient も付与し、ユースケース層で再試行できるようにする
+func classifyError(err error) error {
+	if isFaultError(err) {
+		return err
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case mysqlErrDeadlock, mysqlErrLockWaitTimeout:
+			return fmt.Errorf("%w: %w: %s", domainErrors.ErrDatabaseError, domainErrors.ErrTransient, err.Error())
+		case mysqlErrDuplicateEntry:
+			return fmt.Errorf("%w: %w: %s", domainErrors.ErrDatabaseError, domainErrors.ErrDuplicateEntry, err.Error())
+		}
+	}
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		// 拡張エラーコードの下位8ビットが基本のエラーコード
+		switch code := sqliteErr.Code(); {
+		case code&0xff == sqliteErrBusy, code&0xff == sqliteErrLocked:
+			return fmt.Errorf("%w: %w: %s", domainErrors.ErrDatabaseError, domainErrors.ErrTransient, err.Error())
+		case code == sqliteErrConstraintPrimaryKey, code == sqliteErrConstraintUnique:
+			return fmt.Errorf("%w: %w: %s", domainErrors.ErrDatabaseError, domainErrors.ErrDuplicateEntry, err.Error())
+		}
+	}
+
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: %w: %s", domainErrors.ErrDatabaseError, domainErrors.ErrTransient, err.Error())
+	}
+
+	return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+}
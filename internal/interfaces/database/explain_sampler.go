@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// SELECT の一部で実行計画（EXPLAIN）を確認し、全件走査を含む場合はログに出す SqlHandler
+// インデックスの追加漏れ・削除による性能の劣化を、本番に近い環境のデータ量で検出するために使う
+type explainSqlHandler struct {
+	SqlHandler
+	rate float64
+
+	// 乱数生成関数と全件走査の報告先（テスト用に差し替え可能）
+	random func() float64
+	report func(statement string, tables []string)
+}
+
+// rate は実行計画を確認する SELECT の割合（0〜1）
+func NewExplainSqlHandler(handler SqlHandler, rate float64) SqlHandler {
+	return &explainSqlHandler{SqlHandler: handler, rate: rate, random: rand.Float64, report: logFullScan}
+}
+
+func (h *explainSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	h.sample(ctx, statement, args)
+	return h.SqlHandler.Query(ctx, statement, args...)
+}
+
+func (h *explainSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	h.sample(ctx, statement, args)
+	return h.SqlHandler.QueryRow(ctx, statement, args...)
+}
+
+// 実行計画の確認に失敗しても元のクエリは実行する
+// 同じ接続で結果を読み終えてから元のクエリを実行するため、トランザクション内でも使える
+func (h *explainSqlHandler) sample(ctx context.Context, statement string, args []interface{}) {
+	if !isSelect(statement) || h.random() >= h.rate {
+		return
+	}
+	tables, err := h.fullScans(ctx, statement, args)
+	if err != nil {
+		fmt.Printf("⚠️  EXPLAIN failed: %v\n", err)
+		return
+	}
+	if len(tables) > 0 {
+		h.report(statement, tables)
+	}
+}
+
+// 全件走査するテーブル
+func (h *explainSqlHandler) fullScans(ctx context.Context, statement string, args []interface{}) ([]string, error) {
+	switch h.Dialect() {
+	case SQLite:
+		return h.sqliteFullScans(ctx, statement, args)
+	default:
+		return h.mysqlFullScans(ctx, statement, args)
+	}
+}
+
+// MySQL は JSON 形式の実行計画で access_type が ALL のテーブルを全件走査とみなす
+func (h *explainSqlHandler) mysqlFullScans(ctx context.Context, statement string, args []interface{}) ([]string, error) {
+	var plan string
+	if err := h.SqlHandler.QueryRow(ctx, "EXPLAIN FORMAT=JSON "+statement, args...).Scan(&plan); err != nil {
+		return nil, err
+	}
+	var root interface{}
+	if err := json.Unmarshal([]byte(plan), &root); err != nil {
+		return nil, err
+	}
+	var tables []string
+	collectFullScans(root, &tables)
+	return tables, nil
+}
+
+func collectFullScans(node interface{}, tables *[]string) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if v["access_type"] == "ALL" {
+			if name, ok := v["table_name"].(string); ok {
+				*tables = append(*tables, name)
+			}
+		}
+		for _, child := range v {
+			collectFullScans(child, tables)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectFullScans(child, tables)
+		}
+	}
+}
+
+// SQLite は「SCAN items」のようにインデックスを使わない走査を全件走査とみなす
+// （「SCAN items USING INDEX ...」はインデックスの順に読むため対象外）
+func (h *explainSqlHandler) sqliteFullScans(ctx context.Context, statement string, args []interface{}) ([]string, error) {
+	rows, err := h.SqlHandler.Query(ctx, "EXPLAIN QUERY PLAN "+statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return nil, err
+		}
+		if table, ok := sqliteScannedTable(detail); ok {
+			tables = append(tables, table)
+		}
+	}
+	return tables, rows.Err()
+}
+
+func sqliteScannedTable(detail string) (string, bool) {
+	fields := strings.Fields(detail)
+	if len(fields) < 2 || fields[0] != "SCAN" || strings.Contains(detail, " USING ") {
+		return "", false
+	}
+	// 古いバージョンの SQLite は「SCAN TABLE items」と出力する
+	if fields[1] == "TABLE" && len(fields) > 2 {
+		return fields[2], true
+	}
+	return fields[1], true
+}
+
+func isSelect(statement string) bool {
+	trimmed := strings.TrimSpace(statement)
+	return len(trimmed) >= 6 && strings.EqualFold(trimmed[:6], "SELECT")
+}
+
+func logFullScan(statement string, tables []string) {
+	fmt.Printf("⚠️  Full table scan on %s: %s\n", strings.Join(tables, ", "), strings.Join(strings.Fields(statement), " "))
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// EXPLAIN QUERY PLAN の結果を返す SQLite の SqlHandler
+type planSqlHandler struct {
+	SqlHandler
+	plan       []string
+	statements []string
+}
+
+func (h *planSqlHandler) Dialect() Dialect {
+	return SQLite
+}
+
+func (h *planSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	h.statements = append(h.statements, statement)
+	return &planRows{details: h.plan}, nil
+}
+
+type planRows struct {
+	details []string
+	next    int
+}
+
+func (r *planRows) Next() bool {
+	r.next++
+	return r.next <= len(r.details)
+}
+
+func (r *planRows) Scan(dest ...interface{}) error {
+	*dest[3].(*string) = r.details[r.next-1]
+	return nil
+}
+
+func (r *planRows) Close() error { return nil }
+func (r *planRows) Err() error   { return nil }
+
+func TestExplainSqlHandler(t *testing.T) {
+	newHandler := func(inner SqlHandler, random float64) (*explainSqlHandler, *[]string) {
+		var reported []string
+		handler := NewExplainSqlHandler(inner, 0.5).(*explainSqlHandler)
+		handler.random = func() float64 { return random }
+		handler.report = func(statement string, tables []string) { reported = append(reported, tables...) }
+		return handler, &reported
+	}
+
+	t.Run("正常系: 全件走査するテーブルを報告する", func(t *testing.T) {
+		inner := &planSqlHandler{plan: []string{"SCAN items", "SEARCH t USING INDEX idx_item_tags_item_id (item_id=?)", "SCAN i USING INDEX idx_items_created_at"}}
+		handler, reported := newHandler(inner, 0.1)
+
+		_, err := handler.Query(context.Background(), "SELECT * FROM items WHERE name LIKE ?", "%a%")
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{"EXPLAIN QUERY PLAN SELECT * FROM items WHERE name LIKE ?", "SELECT * FROM items WHERE name LIKE ?"}, inner.statements)
+		assert.Equal(t, []string{"items"}, *reported)
+	})
+
+	t.Run("正常系: 抽出されなかったクエリと SELECT 以外は実行計画を確認しない", func(t *testing.T) {
+		inner := &planSqlHandler{plan: []string{"SCAN items"}}
+		handler, reported := newHandler(inner, 0.9)
+		_, _ = handler.Query(context.Background(), "SELECT * FROM items")
+
+		handler.random = func() float64 { return 0 }
+		_, _ = handler.Query(context.Background(), "PRAGMA table_info(items)")
+
+		assert.Equal(t, []string{"SELECT * FROM items", "PRAGMA table_info(items)"}, inner.statements)
+		assert.Empty(t, *reported)
+	})
+}
+
+func TestCollectFullScans(t *testing.T) {
+	plan := map[string]interface{}{
+		"query_block": map[string]interface{}{
+			"nested_loop": []interface{}{
+				map[string]interface{}{"table": map[string]interface{}{"table_name": "items", "access_type": "ALL"}},
+				map[string]interface{}{"table": map[string]interface{}{"table_name": "item_tags", "access_type": "ref"}},
+			},
+		},
+	}
+
+	var tables []string
+	collectFullScans(plan, &tables)
+	assert.Equal(t, []string{"items"}, tables)
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"errors"
+)
+
+// 注入した障害のエラー（ドメインエラーをそのまま返すため、classifyError で変換しない）
+type faultError struct {
+	err error
+}
+
+func (e *faultError) Error() string {
+	return e.err.Error()
+}
+
+func (e *faultError) Unwrap() error {
+	return e.err
+}
+
+func isFaultError(err error) bool {
+	var fault *faultError
+	return errors.As(err, &fault)
+}
+
+// SQLの実行前に fault を呼び出し、エラーを返した場合は実行せずにそのエラーを返す SqlHandler
+// 結合環境でリポジトリの失敗を再現するために使う
+type faultSqlHandler struct {
+	SqlHandler
+	fault func() error
+}
+
+func NewFaultSqlHandler(handler SqlHandler, fault func() error) SqlHandler {
+	return &faultSqlHandler{SqlHandler: handler, fault: fault}
+}
+
+func (h *faultSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	if err := h.check(); err != nil {
+		return nil, err
+	}
+	return h.SqlHandler.Execute(ctx, statement, args...)
+}
+
+func (h *faultSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	if err := h.check(); err != nil {
+		return nil, err
+	}
+	return h.SqlHandler.Query(ctx, statement, args...)
+}
+
+func (h *faultSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	if err := h.check(); err != nil {
+		return errRow{err: err}
+	}
+	return h.SqlHandler.QueryRow(ctx, statement, args...)
+}
+
+func (h *faultSqlHandler) check() error {
+	if err := h.fault(); err != nil {
+		return &faultError{err: err}
+	}
+	return nil
+}
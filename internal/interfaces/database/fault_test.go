@@ -0,0 +1,35 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestFaultSqlHandler(t *testing.T) {
+	t.Run("正常系: 障害がなければそのまま実行する", func(t *testing.T) {
+		inner := &countingSqlHandler{}
+		handler := NewFaultSqlHandler(inner, func() error { return nil })
+
+		_, err := handler.Execute(context.Background(), "UPDATE items SET name = ?", "a")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, inner.executed)
+	})
+
+	t.Run("異常系: 注入したドメインエラーをリポジトリがそのまま返す", func(t *testing.T) {
+		inner := &countingSqlHandler{}
+		handler := NewFaultSqlHandler(inner, func() error { return domainErrors.ErrItemNotFound })
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.FindByID(context.Background(), 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.False(t, domainErrors.IsDatabaseError(err))
+
+		_, err = handler.Execute(context.Background(), "UPDATE items SET name = ?", "a")
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.Equal(t, 0, inner.executed)
+	})
+}
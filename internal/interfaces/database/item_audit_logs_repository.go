@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type AuditLogRepository struct {
+	SqlHandler
+}
+
+func (r *AuditLogRepository) Log(ctx context.Context, entry *entity.AuditLog) error {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return fmt.Errorf("%w: failed to encode changes: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	query := `
+        INSERT INTO item_audit_logs (item_id, action, actor, changes, created_at)
+        VALUES (?, ?, ?, ?, ?)
+    `
+
+	_, err = r.Execute(ctx, query,
+		entry.ItemID,
+		string(entry.Action),
+		entry.Actor,
+		string(changes),
+		entry.CreatedAt,
+	)
+	if err != nil {
+		return classifyError(err)
+	}
+
+	return nil
+}
+
+func (r *AuditLogRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.AuditLog, error) {
+	query := `
+        SELECT id, item_id, action, actor, changes, created_at
+        FROM item_audit_logs
+        WHERE item_id = ?
+        ORDER BY id
+    `
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var logs []*entity.AuditLog
+	for rows.Next() {
+		var log entity.AuditLog
+		var action string
+		var changes []byte
+		if err := rows.Scan(&log.ID, &log.ItemID, &action, &log.Actor, &changes, &log.CreatedAt); err != nil {
+			return nil, classifyError(err)
+		}
+		log.Action = entity.AuditAction(action)
+		if err := json.Unmarshal(changes, &log.Changes); err != nil {
+			return nil, fmt.Errorf("%w: failed to decode changes: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		logs = append(logs, &log)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return logs, nil
+}
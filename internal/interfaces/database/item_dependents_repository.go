@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+type ItemDependentsRepository struct {
+	SqlHandler
+}
+
+func (r *ItemDependentsRepository) CountByItemID(ctx context.Context, itemID int64) (*entity.ItemDependents, error) {
+	query := `
+        SELECT
+            (SELECT COUNT(*) FROM item_images WHERE item_id = ?),
+            (SELECT COUNT(*) FROM item_valuations WHERE item_id = ?),
+            (SELECT COUNT(*) FROM item_tags WHERE item_id = ?),
+            (SELECT COUNT(*) FROM item_insurance_policies WHERE item_id = ?)
+    `
+
+	var dependents entity.ItemDependents
+	err := r.QueryRow(ctx, query, itemID, itemID, itemID, itemID).Scan(
+		&dependents.Images,
+		&dependents.Valuations,
+		&dependents.Tags,
+		&dependents.InsurancePolicies,
+	)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	return &dependents, nil
+}
+
+func (r *ItemDependentsRepository) DeleteByItemID(ctx context.Context, itemID int64) ([]string, error) {
+	var keys []string
+	err := r.Transaction(ctx, func(ctx context.Context) error {
+		query := `SELECT storage_key FROM item_images WHERE item_id = ? FOR UPDATE`
+		if r.Dialect() == SQLite {
+			// SQLite は書き込みのトランザクションがデータベース全体をロックするため、行ロックはない
+			query = `SELECT storage_key FROM item_images WHERE item_id = ?`
+		}
+		rows, err := r.Query(ctx, query, itemID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var key string
+			if err := rows.Scan(&key); err != nil {
+				return err
+			}
+			keys = append(keys, key)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, statement := range []string{
+			`DELETE FROM item_images WHERE item_id = ?`,
+			`DELETE FROM item_valuations WHERE item_id = ?`,
+			`DELETE FROM item_tags WHERE item_id = ?`,
+			`DELETE FROM item_insurance_policies WHERE item_id = ?`,
+		} {
+			if _, err := r.Execute(ctx, statement, itemID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	return keys, nil
+}
+
+func (r *ItemDependentsRepository) FindOrphanedItemIDs(ctx context.Context, deletedBefore time.Time, limit int) ([]int64, error) {
+	query := `
+        SELECT i.id
+        FROM items i
+        WHERE i.deleted_at IS NOT NULL AND i.deleted_at < ?
+            AND (
+                EXISTS (SELECT 1 FROM item_images WHERE item_id = i.id)
+                OR EXISTS (SELECT 1 FROM item_valuations WHERE item_id = i.id)
+                OR EXISTS (SELECT 1 FROM item_tags WHERE item_id = i.id)
+                OR EXISTS (SELECT 1 FROM item_insurance_policies WHERE item_id = i.id)
+            )
+        ORDER BY i.id
+        LIMIT ?
+    `
+
+	rows, err := r.Query(ctx, query, deletedBefore, limit)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, classifyError(err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type OutboxRepository struct {
+	SqlHandler
+}
+
+const outboxColumns = `id, item_id, action, actor, attempts, last_error, next_attempt_at, published_at, created_at`
+
+// 変更と同じトランザクションで記録する（ctx のトランザクションに参加する）
+func (r *OutboxRepository) Create(ctx context.Context, event *entity.OutboxEvent) error {
+	query := `
+        INSERT INTO item_event_outbox (item_id, action, actor, attempts, last_error, next_attempt_at, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		event.ItemID,
+		string(event.Action),
+		event.Actor,
+		event.Attempts,
+		event.LastError,
+		event.NextAttemptAt,
+		event.CreatedAt,
+	)
+	if err != nil {
+		return classifyError(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	event.ID = id
+	return nil
+}
+
+func (r *OutboxRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.OutboxEvent, error) {
+	query := `
+        SELECT ` + outboxColumns + `
+        FROM item_event_outbox
+        WHERE published_at IS NULL AND next_attempt_at <= ?
+        ORDER BY id
+        LIMIT ?
+    `
+
+	rows, err := r.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var events []*entity.OutboxEvent
+	for rows.Next() {
+		var event entity.OutboxEvent
+		var action string
+		var publishedAt sql.NullTime
+		err := rows.Scan(
+			&event.ID,
+			&event.ItemID,
+			&action,
+			&event.Actor,
+			&event.Attempts,
+			&event.LastError,
+			&event.NextAttemptAt,
+			&publishedAt,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		event.Action = entity.AuditAction(action)
+		if publishedAt.Valid {
+			event.PublishedAt = &publishedAt.Time
+		}
+		events = append(events, &event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return events, nil
+}
+
+// 送信の結果（失敗した回数・最後のエラー・次の送信日時・送信済みの日時）を更新する
+func (r *OutboxRepository) Update(ctx context.Context, event *entity.OutboxEvent) error {
+	query := `
+        UPDATE item_event_outbox
+        SET attempts = ?, last_error = ?, next_attempt_at = ?, published_at = ?
+        WHERE id = ?
+    `
+
+	_, err := r.Execute(ctx, query,
+		event.Attempts,
+		event.LastError,
+		event.NextAttemptAt,
+		event.PublishedAt,
+		event.ID,
+	)
+	if err != nil {
+		return classifyError(err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+        DELETE FROM item_event_outbox
+        WHERE published_at IS NOT NULL AND published_at < ?
+    `
+
+	result, err := r.Execute(ctx, query, before)
+	if err != nil {
+		return 0, classifyError(err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return deleted, nil
+}
@@ -0,0 +1,142 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ItemImageRepository struct {
+	SqlHandler
+}
+
+// scanItemImageで読み取るカラム
+const itemImageColumns = `id, item_id, file_name, content_type, size, storage_key, created_at`
+
+func (r *ItemImageRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	query := `
+        SELECT ` + itemImageColumns + `
+        FROM item_images
+        WHERE item_id = ?
+        ORDER BY id
+    `
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var images []*entity.ItemImage
+	for rows.Next() {
+		image, err := scanItemImage(rows)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		images = append(images, image)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return images, nil
+}
+
+func (r *ItemImageRepository) FindByID(ctx context.Context, itemID, imageID int64) (*entity.ItemImage, error) {
+	query := `
+        SELECT ` + itemImageColumns + `
+        FROM item_images
+        WHERE id = ? AND item_id = ?
+    `
+
+	image, err := scanItemImage(r.QueryRow(ctx, query, imageID, itemID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, classifyError(err)
+	}
+
+	return image, nil
+}
+
+func (r *ItemImageRepository) Create(ctx context.Context, image *entity.ItemImage) (*entity.ItemImage, error) {
+	query := `
+        INSERT INTO item_images (item_id, file_name, content_type, size, storage_key)
+        VALUES (?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		image.ItemID,
+		image.FileName,
+		image.ContentType,
+		image.Size,
+		image.StorageKey,
+	)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, image.ItemID, id)
+}
+
+func (r *ItemImageRepository) Delete(ctx context.Context, itemID, imageID int64) error {
+	query := `DELETE FROM item_images WHERE id = ? AND item_id = ?`
+
+	result, err := r.Execute(ctx, query, imageID, itemID)
+	if err != nil {
+		return classifyError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrItemNotFound
+	}
+
+	return nil
+}
+
+func scanItemImage(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.ItemImage, error) {
+	var image entity.ItemImage
+
+	err := scanner.Scan(
+		&image.ID,
+		&image.ItemID,
+		&image.FileName,
+		&image.ContentType,
+		&image.Size,
+		&image.StorageKey,
+		&image.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &image, nil
+}
+
+func (r *ItemImageRepository) TotalSize(ctx context.Context) (int64, error) {
+	query := `SELECT COALESCE(SUM(size), 0) FROM item_images`
+
+	var total int64
+	if err := r.QueryRow(ctx, query).Scan(&total); err != nil {
+		return 0, classifyError(err)
+	}
+
+	return total, nil
+}
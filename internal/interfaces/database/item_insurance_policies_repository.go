@@ -0,0 +1,200 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type InsurancePolicyRepository struct {
+	SqlHandler
+}
+
+// scanInsurancePolicyで読み取るカラム
+const insurancePolicyColumns = `p.id, p.item_id, p.policy_number, p.insurer, p.coverage, p.currency, p.expires_on, p.created_at, p.updated_at`
+
+func (r *InsurancePolicyRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.InsurancePolicy, error) {
+	query := `
+        SELECT ` + insurancePolicyColumns + `
+        FROM item_insurance_policies p
+        WHERE p.item_id = ?
+        ORDER BY p.expires_on, p.id
+    `
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var policies []*entity.InsurancePolicy
+	for rows.Next() {
+		policy, err := scanInsurancePolicy(rows)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		policies = append(policies, policy)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return policies, nil
+}
+
+func (r *InsurancePolicyRepository) FindByID(ctx context.Context, id int64) (*entity.InsurancePolicy, error) {
+	query := `
+        SELECT ` + insurancePolicyColumns + `
+        FROM item_insurance_policies p
+        WHERE p.id = ?
+    `
+
+	policy, err := scanInsurancePolicy(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrInsurancePolicyNotFound
+		}
+		return nil, classifyError(err)
+	}
+
+	return policy, nil
+}
+
+// 削除済みのアイテムの契約は含めない
+func (r *InsurancePolicyRepository) FindExpiring(ctx context.Context, from, to entity.Date) ([]*entity.ExpiringInsurancePolicy, error) {
+	query := `
+        SELECT ` + insurancePolicyColumns + `, i.name, i.category
+        FROM item_insurance_policies p
+        JOIN items i ON i.id = p.item_id
+        WHERE p.expires_on >= ? AND p.expires_on <= ? AND i.deleted_at IS NULL
+        ORDER BY p.expires_on, p.id
+    `
+
+	rows, err := r.Query(ctx, query, from.String(), to.String())
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var policies []*entity.ExpiringInsurancePolicy
+	for rows.Next() {
+		var expiring entity.ExpiringInsurancePolicy
+		policy, err := scanInsurancePolicy(rows, &expiring.ItemName, &expiring.Category)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		expiring.InsurancePolicy = policy
+		policies = append(policies, &expiring)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return policies, nil
+}
+
+func (r *InsurancePolicyRepository) Create(ctx context.Context, policy *entity.InsurancePolicy) (*entity.InsurancePolicy, error) {
+	query := `
+        INSERT INTO item_insurance_policies (item_id, policy_number, insurer, coverage, currency, expires_on, created_at, updated_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		policy.ItemID,
+		policy.PolicyNumber,
+		policy.Insurer,
+		policy.Coverage.Amount,
+		policy.Coverage.Currency,
+		policy.ExpiresOn.String(),
+		policy.CreatedAt,
+		policy.UpdatedAt,
+	)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// 対象の契約がない場合は、更新後の取得でErrInsurancePolicyNotFoundを返す
+func (r *InsurancePolicyRepository) Update(ctx context.Context, policy *entity.InsurancePolicy) (*entity.InsurancePolicy, error) {
+	query := `
+        UPDATE item_insurance_policies
+        SET policy_number = ?, insurer = ?, coverage = ?, currency = ?, expires_on = ?, updated_at = ?
+        WHERE id = ?
+    `
+
+	_, err := r.Execute(ctx, query,
+		policy.PolicyNumber,
+		policy.Insurer,
+		policy.Coverage.Amount,
+		policy.Coverage.Currency,
+		policy.ExpiresOn.String(),
+		policy.UpdatedAt,
+		policy.ID,
+	)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	return r.FindByID(ctx, policy.ID)
+}
+
+func (r *InsurancePolicyRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM item_insurance_policies WHERE id = ?`, id)
+	if err != nil {
+		return classifyError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrInsurancePolicyNotFound
+	}
+
+	return nil
+}
+
+// extra には契約のカラムの後に読み取るカラムの格納先を指定する
+func scanInsurancePolicy(scanner interface {
+	Scan(dest ...interface{}) error
+}, extra ...interface{}) (*entity.InsurancePolicy, error) {
+	var policy entity.InsurancePolicy
+	var expiresOn string
+
+	dest := []interface{}{
+		&policy.ID,
+		&policy.ItemID,
+		&policy.PolicyNumber,
+		&policy.Insurer,
+		&policy.Coverage.Amount,
+		&policy.Coverage.Currency,
+		&expiresOn,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	}
+	if err := scanner.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	date, err := entity.ParseDate(formatDateColumn(expiresOn))
+	if err != nil {
+		return nil, err
+	}
+	policy.ExpiresOn = date
+
+	return &policy, nil
+}
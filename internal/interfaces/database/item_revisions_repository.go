@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ItemRevisionRepository struct {
+	SqlHandler
+}
+
+func (r *ItemRevisionRepository) Create(ctx context.Context, revision *entity.ItemRevision) (*entity.ItemRevision, error) {
+	snapshot, err := json.Marshal(revision.Snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode snapshot: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	// 同時に保存した場合は (item_id, version) の一意制約で片方がエラーになる
+	query := `
+        INSERT INTO item_revisions (item_id, version, snapshot, actor, created_at)
+        SELECT ?, COALESCE(MAX(version), 0) + 1, ?, ?, ?
+        FROM item_revisions
+        WHERE item_id = ?
+    `
+
+	result, err := r.Execute(ctx, query,
+		revision.ItemID,
+		string(snapshot),
+		revision.Actor,
+		revision.CreatedAt,
+		revision.ItemID,
+	)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	var version int
+	if err := r.QueryRow(ctx, `SELECT version FROM item_revisions WHERE id = ?`, id).Scan(&version); err != nil {
+		return nil, classifyError(err)
+	}
+
+	created := *revision
+	created.ID = id
+	created.Version = version
+	return &created, nil
+}
+
+func (r *ItemRevisionRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemRevision, error) {
+	query := `
+        SELECT id, item_id, version, snapshot, actor, created_at
+        FROM item_revisions
+        WHERE item_id = ?
+        ORDER BY version
+    `
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var revisions []*entity.ItemRevision
+	for rows.Next() {
+		revision, err := scanItemRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return revisions, nil
+}
+
+func (r *ItemRevisionRepository) FindByVersion(ctx context.Context, itemID int64, version int) (*entity.ItemRevision, error) {
+	query := `
+        SELECT id, item_id, version, snapshot, actor, created_at
+        FROM item_revisions
+        WHERE item_id = ? AND version = ?
+    `
+
+	revision, err := scanItemRevision(r.QueryRow(ctx, query, itemID, version))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// sql.ErrNoRows はそのまま返す
+func scanItemRevision(row Row) (*entity.ItemRevision, error) {
+	var revision entity.ItemRevision
+	var snapshot []byte
+	if err := row.Scan(&revision.ID, &revision.ItemID, &revision.Version, &snapshot, &revision.Actor, &revision.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, classifyError(err)
+	}
+	if err := json.Unmarshal(snapshot, &revision.Snapshot); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode snapshot: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return &revision, nil
+}
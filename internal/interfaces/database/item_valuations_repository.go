@@ -0,0 +1,164 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ValuationRepository struct {
+	SqlHandler
+}
+
+// scanValuationで読み取るカラム
+const valuationColumns = `id, item_id, valuated_at, value, currency, source, reason, created_at`
+
+func (r *ValuationRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.Valuation, error) {
+	query := `
+        SELECT ` + valuationColumns + `
+        FROM item_valuations
+        WHERE item_id = ?
+        ORDER BY valuated_at, id
+    `
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var valuations []*entity.Valuation
+	for rows.Next() {
+		valuation, err := scanValuation(rows)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		valuations = append(valuations, valuation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return valuations, nil
+}
+
+func (r *ValuationRepository) FindLatestByItemIDs(ctx context.Context, itemIDs []int64) (map[int64]*entity.Valuation, error) {
+	latest := make(map[int64]*entity.Valuation, len(itemIDs))
+	if len(itemIDs) == 0 {
+		return latest, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(itemIDs)), ", ")
+	args := make([]interface{}, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		args = append(args, id)
+	}
+
+	// 同じ日の評価額が複数ある場合は後から登録したものを最新とする
+	query := `
+        SELECT ` + valuationColumns + `
+        FROM item_valuations v
+        WHERE item_id IN (` + placeholders + `)
+          AND NOT EXISTS (
+            SELECT 1 FROM item_valuations newer
+            WHERE newer.item_id = v.item_id
+              AND (newer.valuated_at > v.valuated_at
+                OR (newer.valuated_at = v.valuated_at AND newer.id > v.id))
+          )
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		valuation, err := scanValuation(rows)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		latest[valuation.ItemID] = valuation
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return latest, nil
+}
+
+func (r *ValuationRepository) Create(ctx context.Context, valuation *entity.Valuation) (*entity.Valuation, error) {
+	query := `
+        INSERT INTO item_valuations (item_id, valuated_at, value, currency, source, reason)
+        VALUES (?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		valuation.ItemID,
+		valuation.ValuatedAt.String(),
+		valuation.Value.Amount,
+		valuation.Value.Currency,
+		valuation.Source,
+		valuation.Reason,
+	)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.findByID(ctx, id)
+}
+
+func (r *ValuationRepository) findByID(ctx context.Context, id int64) (*entity.Valuation, error) {
+	query := `
+        SELECT ` + valuationColumns + `
+        FROM item_valuations
+        WHERE id = ?
+    `
+
+	valuation, err := scanValuation(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, classifyError(err)
+	}
+
+	return valuation, nil
+}
+
+func scanValuation(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Valuation, error) {
+	var valuation entity.Valuation
+	var valuatedAt string
+
+	err := scanner.Scan(
+		&valuation.ID,
+		&valuation.ItemID,
+		&valuatedAt,
+		&valuation.Value.Amount,
+		&valuation.Value.Currency,
+		&valuation.Source,
+		&valuation.Reason,
+		&valuation.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if valuation.ValuatedAt, err = entity.ParseDate(formatDateColumn(valuatedAt)); err != nil {
+		return nil, err
+	}
+
+	return &valuation, nil
+}
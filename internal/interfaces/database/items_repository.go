@@ -3,7 +3,9 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"Aicon-assignment/internal/domain/entity"
@@ -12,18 +14,38 @@ import (
 
 type ItemRepository struct {
 	SqlHandler
+
+	// キーワード検索にFULLTEXTインデックス（ngramパーサー）を使用する
+	UseFullText bool
+}
+
+// 並び替えキーとカラムの対応（ユーザー入力をSQLに直接埋め込まないため、ここに定義したカラムのみ使用する）
+var itemSortColumns = map[entity.ItemSortKey]string{
+	entity.SortByPurchasePrice: "purchase_price",
+	entity.SortByPurchaseDate:  "purchase_date",
+	entity.SortByCreatedAt:     "created_at",
+	entity.SortByName:          "name",
+	entity.SortByChangeSeq:     "change_seq",
+	entity.SortByID:            "id",
 }
 
-func (r *ItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
+// scanItemで読み取るカラム
+const itemColumns = `id, name, category, brand, purchase_price, currency, purchase_date, attributes, purchase_country, exchange_rate, purchase_price_jpy, tax_amount, tax_included, tax_amount_jpy, created_at, updated_at, change_seq, deleted_at, on_hold, hold_reason, draft, sold, split_from, purge_at`
+
+func (r *ItemRepository) FindAll(ctx context.Context, itemQuery entity.ItemQuery) ([]*entity.Item, error) {
+	where, args := r.whereClause(itemQuery)
 	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
+        SELECT ` + itemColumns + `
         FROM items
-        ORDER BY created_at DESC
+        ` + where + `
+        ORDER BY ` + orderByClause(itemQuery) + `
+        LIMIT ? OFFSET ?
     `
 
-	rows, err := r.Query(ctx, query)
+	args = append(args, itemQuery.Limit, itemQuery.Offset)
+	rows, err := r.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, classifyError(err)
 	}
 	defer rows.Close()
 
@@ -31,23 +53,35 @@ func (r *ItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
 	for rows.Next() {
 		item, err := scanItem(rows)
 		if err != nil {
-			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+			return nil, classifyError(err)
 		}
 		items = append(items, item)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, classifyError(err)
 	}
 
 	return items, nil
 }
 
+func (r *ItemRepository) Count(ctx context.Context, itemQuery entity.ItemQuery) (int, error) {
+	where, args := r.whereClause(itemQuery)
+	query := `SELECT COUNT(*) FROM items ` + where
+
+	var count int
+	if err := r.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, classifyError(err)
+	}
+
+	return count, nil
+}
+
 func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
 	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
+        SELECT ` + itemColumns + `
         FROM items
-        WHERE id = ?
+        WHERE id = ? AND deleted_at IS NULL
     `
 
 	row := r.QueryRow(ctx, query, id)
@@ -57,72 +91,313 @@ func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item,
 		if err == sql.ErrNoRows {
 			return nil, domainErrors.ErrItemNotFound
 		}
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, classifyError(err)
 	}
 
 	return item, nil
 }
 
+func (r *ItemRepository) FindByDedupeKey(ctx context.Context, key string) ([]*entity.Item, error) {
+	query := `
+        SELECT ` + itemColumns + `
+        FROM items
+        WHERE dedupe_key = ? AND deleted_at IS NULL
+        ORDER BY id
+    `
+
+	rows, err := r.Query(ctx, query, key)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var items []*entity.Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return items, nil
+}
+
+func (r *ItemRepository) ExistsSimilar(ctx context.Context, dedupeKey string, purchaseDate entity.Date) (bool, error) {
+	query := `
+        SELECT EXISTS (
+            SELECT 1 FROM items
+            WHERE dedupe_key = ? AND purchase_date = ? AND deleted_at IS NULL
+        )
+    `
+
+	var exists bool
+	if err := r.QueryRow(ctx, query, dedupeKey, nullableDate(purchaseDate)).Scan(&exists); err != nil {
+		return false, classifyError(err)
+	}
+
+	return exists, nil
+}
+
 func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	var id int64
+	err := r.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		id, err = r.insert(ctx, item)
+		return err
+	})
+	if err != nil {
+		if domainErrors.IsDatabaseError(err) {
+			return nil, err
+		}
+		return nil, classifyError(err)
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *ItemRepository) CreateMany(ctx context.Context, items []*entity.Item) ([]*entity.Item, error) {
+	created := make([]*entity.Item, 0, len(items))
+
+	err := r.Transaction(ctx, func(ctx context.Context) error {
+		for _, item := range items {
+			id, err := r.insert(ctx, item)
+			if err != nil {
+				return err
+			}
+
+			createdItem, err := r.FindByID(ctx, id)
+			if err != nil {
+				return err
+			}
+			created = append(created, createdItem)
+		}
+		return nil
+	})
+	if err != nil {
+		if domainErrors.IsDatabaseError(err) {
+			return nil, err
+		}
+		return nil, classifyError(err)
+	}
+
+	return created, nil
+}
+
+// 分割元のアイテムを論理削除し、構成品を登録する（1つのトランザクションで行う）
+// 分割元が存在しない（削除済みを含む）場合はErrItemNotFoundを返す
+func (r *ItemRepository) Split(ctx context.Context, id int64, components []*entity.Item, now time.Time) ([]*entity.Item, error) {
+	created := make([]*entity.Item, 0, len(components))
+
+	err := r.Transaction(ctx, func(ctx context.Context) error {
+		if err := r.Delete(ctx, id, now); err != nil {
+			return err
+		}
+
+		for _, component := range components {
+			componentID, err := r.insert(ctx, component)
+			if err != nil {
+				return err
+			}
+
+			createdItem, err := r.FindByID(ctx, componentID)
+			if err != nil {
+				return err
+			}
+			created = append(created, createdItem)
+		}
+		return nil
+	})
+	if err != nil {
+		if domainErrors.IsDatabaseError(err) || domainErrors.IsNotFoundError(err) {
+			return nil, err
+		}
+		return nil, classifyError(err)
+	}
+
+	return created, nil
+}
+
+// アイテムを1件登録し、採番されたIDを返す（トランザクション内で呼ぶ）
+func (r *ItemRepository) insert(ctx context.Context, item *entity.Item) (int64, error) {
 	query := `
-        INSERT INTO items (name, category, brand, purchase_price, purchase_date)
-        VALUES (?, ?, ?, ?, ?)
+        INSERT INTO items (name, category, brand, purchase_price, currency, purchase_date, attributes, purchase_country, exchange_rate, purchase_price_jpy, tax_amount, tax_included, tax_amount_jpy, dedupe_key, draft, split_from, created_at, updated_at, change_seq)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
     `
 
+	attributes, err := marshalAttributes(item.Attributes)
+	if err != nil {
+		return 0, classifyError(err)
+	}
+
+	seq, err := nextItemChangeSeq(ctx, r.SqlHandler)
+	if err != nil {
+		return 0, err
+	}
+
 	result, err := r.Execute(ctx, query,
 		item.Name,
 		item.Category,
 		item.Brand,
-		item.PurchasePrice,
-		item.PurchaseDate,
+		item.PurchasePrice.Amount,
+		item.PurchasePrice.Currency,
+		nullableDate(item.PurchaseDate),
+		attributes,
+		nullableCountry(item.PurchaseCountry),
+		nullableExchangeRate(item.ExchangeRate),
+		nullableJPY(item.PurchasePriceJPY),
+		nullableJPY(item.TaxAmount),
+		item.TaxIncluded,
+		nullableJPY(item.TaxAmountJPY),
+		item.DedupeKey,
+		item.Draft,
+		item.SplitFrom,
+		item.CreatedAt,
+		item.UpdatedAt,
+		seq,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return 0, classifyError(err)
 	}
 
 	id, err := result.LastInsertId()
 	if err != nil {
-		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		return 0, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
-	return r.FindByID(ctx, id)
+	return id, nil
 }
 
+// 更新日時はエンティティの値（ユースケースの時計）で更新する
 func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
 	query := `
         UPDATE items
-        SET name = ?, brand = ?, purchase_price = ?, updated_at = CURRENT_TIMESTAMP
-        WHERE id = ?
+        SET change_seq = ?, name = ?, category = ?, brand = ?, purchase_price = ?, currency = ?, purchase_date = ?, attributes = ?, purchase_country = ?, exchange_rate = ?, purchase_price_jpy = ?, tax_amount = ?, tax_included = ?, tax_amount_jpy = ?, dedupe_key = ?, draft = ?, updated_at = ?
+        WHERE id = ? AND deleted_at IS NULL
     `
 
-	result, err := r.Execute(ctx, query,
+	attributes, err := marshalAttributes(item.Attributes)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	err = r.modifyItem(ctx, query,
 		item.Name,
+		item.Category,
 		item.Brand,
-		item.PurchasePrice,
+		item.PurchasePrice.Amount,
+		item.PurchasePrice.Currency,
+		nullableDate(item.PurchaseDate),
+		attributes,
+		nullableCountry(item.PurchaseCountry),
+		nullableExchangeRate(item.ExchangeRate),
+		nullableJPY(item.PurchasePriceJPY),
+		nullableJPY(item.TaxAmount),
+		item.TaxIncluded,
+		nullableJPY(item.TaxAmountJPY),
+		item.DedupeKey,
+		item.Draft,
+		item.UpdatedAt,
 		item.ID,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	return r.FindByID(ctx, item.ID)
+}
+
+func (r *ItemRepository) Delete(ctx context.Context, id int64, now time.Time) error {
+	query := `UPDATE items SET change_seq = ?, deleted_at = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL`
+
+	return r.modifyItem(ctx, query, now, now, id)
+}
+
+func (r *ItemRepository) Restore(ctx context.Context, id int64, now time.Time) error {
+	query := `UPDATE items SET change_seq = ?, deleted_at = NULL, updated_at = ? WHERE id = ? AND deleted_at IS NOT NULL`
+
+	return r.modifyItem(ctx, query, now, id)
+}
+
+func (r *ItemRepository) SetHold(ctx context.Context, id int64, onHold bool, reason string, now time.Time) error {
+	query := `UPDATE items SET change_seq = ?, on_hold = ?, hold_reason = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL`
+
+	return r.modifyItem(ctx, query, onHold, reason, now, id)
+}
+
+func (r *ItemRepository) SetSold(ctx context.Context, id int64, now time.Time) error {
+	query := `UPDATE items SET change_seq = ?, sold = TRUE, updated_at = ? WHERE id = ? AND deleted_at IS NULL`
+
+	return r.modifyItem(ctx, query, now, id)
+}
+
+func (r *ItemRepository) SchedulePurge(ctx context.Context, id int64, purgeAt *time.Time, now time.Time) error {
+	query := `UPDATE items SET change_seq = ?, purge_at = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL`
+
+	return r.modifyItem(ctx, query, purgeAt, now, id)
+}
+
+func (r *ItemRepository) FindDuePurges(ctx context.Context, now time.Time, limit int) ([]*entity.Item, error) {
+	query := `
+        SELECT ` + itemColumns + `
+        FROM items
+        WHERE purge_at <= ? AND on_hold = FALSE
+        ORDER BY purge_at, id
+        LIMIT ?
+    `
+
+	rows, err := r.Query(ctx, query, now, limit)
 	if err != nil {
-		return nil, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, classifyError(err)
 	}
+	defer rows.Close()
 
-	if rowsAffected == 0 {
-		return nil, domainErrors.ErrItemNotFound
+	var items []*entity.Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, classifyError(err)
 	}
 
-	return r.FindByID(ctx, item.ID)
+	return items, nil
 }
 
-func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
+func (r *ItemRepository) Purge(ctx context.Context, id int64) error {
+	// 写真・評価額・タグ・保険の契約は外部キーの ON DELETE CASCADE で削除される
 	query := `DELETE FROM items WHERE id = ?`
 
-	result, err := r.Execute(ctx, query, id)
+	return r.executeAffectingItem(ctx, query, id)
+}
+
+// アイテムの変更の通し番号を進めて、1件のアイテムを対象とする更新を実行する（query の最初のパラメーターが change_seq）
+func (r *ItemRepository) modifyItem(ctx context.Context, query string, args ...interface{}) error {
+	err := r.Transaction(ctx, func(ctx context.Context) error {
+		seq, err := nextItemChangeSeq(ctx, r.SqlHandler)
+		if err != nil {
+			return err
+		}
+		return r.executeAffectingItem(ctx, query, append([]interface{}{seq}, args...)...)
+	})
+	if err == nil || domainErrors.IsDatabaseError(err) || domainErrors.IsNotFoundError(err) {
+		return err
+	}
+	return classifyError(err)
+}
+
+// 1件のアイテムを対象とする更新を実行し、対象がなければErrItemNotFoundを返す
+func (r *ItemRepository) executeAffectingItem(ctx context.Context, query string, args ...interface{}) error {
+	result, err := r.Execute(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return classifyError(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -137,16 +412,38 @@ func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (r *ItemRepository) SumPurchasePrice(ctx context.Context, category, currency string, year int, excludeID int64) (int64, error) {
+	// purchase_date のインデックスを使えるよう、YEAR() ではなく日付の範囲で絞り込む
+	query := `
+        SELECT COALESCE(SUM(purchase_price), 0)
+        FROM items
+        WHERE category = ? AND currency = ?
+          AND purchase_date >= ? AND purchase_date < ?
+          AND id <> ? AND deleted_at IS NULL AND draft = FALSE
+    `
+
+	from := fmt.Sprintf("%04d-01-01", year)
+	to := fmt.Sprintf("%04d-01-01", year+1)
+
+	var total int64
+	if err := r.QueryRow(ctx, query, category, currency, from, to, excludeID).Scan(&total); err != nil {
+		return 0, classifyError(err)
+	}
+
+	return total, nil
+}
+
 func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
 	query := `
         SELECT category, COUNT(*) as count
         FROM items
+        WHERE deleted_at IS NULL AND draft = FALSE
         GROUP BY category
     `
 
 	rows, err := r.Query(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, classifyError(err)
 	}
 	defer rows.Close()
 
@@ -155,53 +452,516 @@ func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]i
 		var category string
 		var count int
 		if err := rows.Scan(&category, &count); err != nil {
-			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+			return nil, classifyError(err)
 		}
 		summary[category] = count
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, classifyError(err)
 	}
 
 	return summary, nil
 }
 
+func (r *ItemRepository) GetValueSummaryByCategory(ctx context.Context) ([]entity.CategoryValueTotal, error) {
+	query := `
+        SELECT category, currency, COUNT(*) as count, COALESCE(SUM(purchase_price), 0) as total,
+               COALESCE(SUM(purchase_price_jpy), 0) as total_jpy
+        FROM items
+        WHERE deleted_at IS NULL AND draft = FALSE
+        GROUP BY category, currency
+        ORDER BY category, currency
+    `
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var totals []entity.CategoryValueTotal
+	for rows.Next() {
+		var total entity.CategoryValueTotal
+		if err := rows.Scan(&total.Category, &total.Currency, &total.Count, &total.Total, &total.TotalJPY); err != nil {
+			return nil, classifyError(err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return totals, nil
+}
+
+func (r *ItemRepository) GetSummaryByBrand(ctx context.Context) ([]entity.BrandValueTotal, error) {
+	query := `
+        SELECT brand, currency, COUNT(*) as count, COALESCE(SUM(purchase_price), 0) as total,
+               COALESCE(SUM(purchase_price_jpy), 0) as total_jpy
+        FROM items
+        WHERE deleted_at IS NULL AND draft = FALSE
+        GROUP BY brand, currency
+        ORDER BY total_jpy DESC, total DESC, brand, currency
+    `
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var totals []entity.BrandValueTotal
+	for rows.Next() {
+		var total entity.BrandValueTotal
+		if err := rows.Scan(&total.Brand, &total.Currency, &total.Count, &total.Total, &total.TotalJPY); err != nil {
+			return nil, classifyError(err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return totals, nil
+}
+
+func (r *ItemRepository) GetSummaryByYearAndCategory(ctx context.Context) ([]entity.YearCategoryValueTotal, error) {
+	query := `
+        SELECT ` + r.yearOf("purchase_date") + ` as year, category, currency, COUNT(*) as count,
+               COALESCE(SUM(purchase_price), 0) as total, COALESCE(SUM(purchase_price_jpy), 0) as total_jpy
+        FROM items
+        WHERE deleted_at IS NULL AND draft = FALSE
+        GROUP BY year, category, currency
+        ORDER BY year, category, currency
+    `
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var totals []entity.YearCategoryValueTotal
+	for rows.Next() {
+		var total entity.YearCategoryValueTotal
+		if err := rows.Scan(&total.Year, &total.Category, &total.Currency, &total.Count, &total.Total, &total.TotalJPY); err != nil {
+			return nil, classifyError(err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return totals, nil
+}
+
+func (r *ItemRepository) GetMonthlyPurchaseTotals(ctx context.Context, from, to string) ([]entity.MonthlyPurchaseTotal, error) {
+	conditions := []string{"deleted_at IS NULL", "draft = FALSE"}
+	var args []interface{}
+	if from != "" {
+		conditions = append(conditions, "purchase_date >= ?")
+		args = append(args, from)
+	}
+	if to != "" {
+		conditions = append(conditions, "purchase_date < ?")
+		args = append(args, to)
+	}
+
+	query := `
+        SELECT ` + r.monthOf("purchase_date") + ` as month, currency, COUNT(*) as count,
+               COALESCE(SUM(purchase_price), 0) as total, COALESCE(SUM(purchase_price_jpy), 0) as total_jpy
+        FROM items
+        WHERE ` + strings.Join(conditions, " AND ") + `
+        GROUP BY month, currency
+        ORDER BY month, currency
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var totals []entity.MonthlyPurchaseTotal
+	for rows.Next() {
+		var total entity.MonthlyPurchaseTotal
+		if err := rows.Scan(&total.Month, &total.Currency, &total.Count, &total.Total, &total.TotalJPY); err != nil {
+			return nil, classifyError(err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return totals, nil
+}
+
+func (r *ItemRepository) GetCustomsValueTotals(ctx context.Context, from, to string) ([]entity.CustomsValueTotal, error) {
+	conditions := []string{"deleted_at IS NULL", "draft = FALSE", "purchase_country IS NOT NULL"}
+	var args []interface{}
+	if from != "" {
+		conditions = append(conditions, "purchase_date >= ?")
+		args = append(args, from)
+	}
+	if to != "" {
+		conditions = append(conditions, "purchase_date < ?")
+		args = append(args, to)
+	}
+
+	query := `
+        SELECT purchase_country, ` + r.yearOf("purchase_date") + ` as year, currency, COUNT(*) as count,
+               COALESCE(SUM(purchase_price), 0) as total, COALESCE(SUM(purchase_price_jpy), 0) as total_jpy
+        FROM items
+        WHERE ` + strings.Join(conditions, " AND ") + `
+        GROUP BY purchase_country, year, currency
+        ORDER BY purchase_country, year, currency
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var totals []entity.CustomsValueTotal
+	for rows.Next() {
+		var total entity.CustomsValueTotal
+		if err := rows.Scan(&total.Country, &total.Year, &total.Currency, &total.Count, &total.Total, &total.TotalJPY); err != nil {
+			return nil, classifyError(err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return totals, nil
+}
+
+func (r *ItemRepository) GetTaxTotals(ctx context.Context, from, to string) ([]entity.TaxTotal, error) {
+	conditions := []string{"deleted_at IS NULL", "draft = FALSE", "tax_amount IS NOT NULL"}
+	var args []interface{}
+	if from != "" {
+		conditions = append(conditions, "purchase_date >= ?")
+		args = append(args, from)
+	}
+	if to != "" {
+		conditions = append(conditions, "purchase_date < ?")
+		args = append(args, to)
+	}
+
+	query := `
+        SELECT ` + r.yearOf("purchase_date") + ` as year, currency, COUNT(*) as count,
+               COALESCE(SUM(tax_amount), 0) as total, COALESCE(SUM(tax_amount_jpy), 0) as total_jpy
+        FROM items
+        WHERE ` + strings.Join(conditions, " AND ") + `
+        GROUP BY year, currency
+        ORDER BY year, currency
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var totals []entity.TaxTotal
+	for rows.Next() {
+		var total entity.TaxTotal
+		if err := rows.Scan(&total.Year, &total.Currency, &total.Count, &total.Total, &total.TotalJPY); err != nil {
+			return nil, classifyError(err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return totals, nil
+}
+
+// アイテムの変更の通し番号を採番する（トランザクション内で呼ぶ）
+// カウンターの行はトランザクションの終了までロックされるため、変更は番号の順にコミットされる。
+// 同期するクライアントが取得済みの番号より小さい番号の変更を後から見つけることはない
+func nextItemChangeSeq(ctx context.Context, h SqlHandler) (int64, error) {
+	if h.Dialect() == SQLite {
+		var seq int64
+		err := h.QueryRow(ctx, `UPDATE item_change_sequence SET value = value + 1 WHERE id = 1 RETURNING value`).Scan(&seq)
+		if err != nil {
+			return 0, classifyError(err)
+		}
+		return seq, nil
+	}
+
+	result, err := h.Execute(ctx, `UPDATE item_change_sequence SET value = LAST_INSERT_ID(value + 1) WHERE id = 1`)
+	if err != nil {
+		return 0, classifyError(err)
+	}
+	seq, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to get change sequence: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return seq, nil
+}
+
+// 日付の列から年（整数）を取り出す式
+func (r *ItemRepository) yearOf(column string) string {
+	if r.Dialect() == SQLite {
+		return "CAST(strftime('%Y', " + column + ") AS INTEGER)"
+	}
+	return "YEAR(" + column + ")"
+}
+
+// 日付の列から年月（YYYY-MM）を取り出す式
+func (r *ItemRepository) monthOf(column string) string {
+	if r.Dialect() == SQLite {
+		return "strftime('%Y-%m', " + column + ")"
+	}
+	return "DATE_FORMAT(" + column + ", '%Y-%m')"
+}
+
+// 検索条件からWHERE句とパラメータを組み立てる
+func (r *ItemRepository) whereClause(itemQuery entity.ItemQuery) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if !itemQuery.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	if itemQuery.Keyword != "" {
+		switch {
+		case r.UseFullText && r.Dialect() == MySQL:
+			conditions = append(conditions, "MATCH(name, brand) AGAINST (? IN BOOLEAN MODE)")
+			args = append(args, itemQuery.Keyword)
+		case r.Dialect() == SQLite:
+			// SQLite の LIKE は ASCII の大文字・小文字のみを区別しない。エスケープ文字は既定で無いため指定する
+			pattern := "%" + escapeLike(itemQuery.Keyword) + "%"
+			conditions = append(conditions, `(name LIKE ? ESCAPE '\' OR brand LIKE ? ESCAPE '\')`)
+			args = append(args, pattern, pattern)
+		default:
+			// 照合順序 utf8mb4_unicode_ci により大文字・小文字を区別しない
+			pattern := "%" + escapeLike(itemQuery.Keyword) + "%"
+			conditions = append(conditions, "(name LIKE ? OR brand LIKE ?)")
+			args = append(args, pattern, pattern)
+		}
+	}
+
+	if itemQuery.Category != "" {
+		conditions = append(conditions, "category = ?")
+		args = append(args, itemQuery.Category)
+	}
+
+	if itemQuery.Tag != "" {
+		conditions = append(conditions, "id IN (SELECT it.item_id FROM item_tags it JOIN tags t ON t.id = it.tag_id WHERE t.name = ?)")
+		args = append(args, itemQuery.Tag)
+	}
+
+	if itemQuery.AfterID > 0 {
+		conditions = append(conditions, "id > ?")
+		args = append(args, itemQuery.AfterID)
+	}
+
+	if itemQuery.ChangedAfter > 0 {
+		conditions = append(conditions, "change_seq > ?")
+		args = append(args, itemQuery.ChangedAfter)
+	}
+
+	if len(itemQuery.IDs) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(itemQuery.IDs)), ", ")
+		conditions = append(conditions, "id IN ("+placeholders+")")
+		for _, id := range itemQuery.IDs {
+			args = append(args, id)
+		}
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// LIKE のワイルドカード文字をエスケープする
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// ORDER BY句を組み立てる（同値の場合もページ間で順序が安定するようIDを第2キーにする）
+func orderByClause(itemQuery entity.ItemQuery) string {
+	column, ok := itemSortColumns[itemQuery.Sort]
+	if !ok {
+		column = itemSortColumns[entity.SortByCreatedAt]
+	}
+
+	direction := "DESC"
+	if itemQuery.Order == entity.SortAsc {
+		direction = "ASC"
+	}
+
+	if column == "id" {
+		return "id " + direction
+	}
+	return fmt.Sprintf("%s %s, id %s", column, direction, direction)
+}
+
 func scanItem(scanner interface {
 	Scan(dest ...interface{}) error
 }) (*entity.Item, error) {
 	var item entity.Item
-	var purchaseDate string
+	var purchaseDate sql.NullString
+	var attributes sql.NullString
+	var purchaseCountry sql.NullString
+	var exchangeRate sql.NullString
+	var purchasePriceJPY sql.NullInt64
+	var taxAmount, taxAmountJPY sql.NullInt64
 	var createdAt, updatedAt time.Time
+	var deletedAt sql.NullTime
+	var splitFrom sql.NullInt64
+	var purgeAt sql.NullTime
 
 	err := scanner.Scan(
 		&item.ID,
 		&item.Name,
 		&item.Category,
 		&item.Brand,
-		&item.PurchasePrice,
+		&item.PurchasePrice.Amount,
+		&item.PurchasePrice.Currency,
 		&purchaseDate,
+		&attributes,
+		&purchaseCountry,
+		&exchangeRate,
+		&purchasePriceJPY,
+		&taxAmount,
+		&item.TaxIncluded,
+		&taxAmountJPY,
 		&createdAt,
 		&updatedAt,
+		&item.ChangeSeq,
+		&deletedAt,
+		&item.OnHold,
+		&item.HoldReason,
+		&item.Draft,
+		&item.Sold,
+		&splitFrom,
+		&purgeAt,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	if purchaseDate != "" {
-		if parsedDate, err := time.Parse("2006-01-02", purchaseDate); err == nil {
-			item.PurchaseDate = parsedDate.Format("2006-01-02")
-		} else if parsedDate, err := time.Parse(time.RFC3339, purchaseDate); err == nil {
-			item.PurchaseDate = parsedDate.Format("2006-01-02")
-		} else if len(purchaseDate) >= 10 {
-			item.PurchaseDate = purchaseDate[:10]
-		} else {
-			item.PurchaseDate = purchaseDate
+	if item.PurchaseDate, err = entity.ParseDate(formatDateColumn(purchaseDate.String)); err != nil {
+		return nil, err
+	}
+
+	if attributes.Valid && attributes.String != "" {
+		if err := json.Unmarshal([]byte(attributes.String), &item.Attributes); err != nil {
+			return nil, err
 		}
 	}
 
+	item.PurchaseCountry = purchaseCountry.String
+
+	if exchangeRate.Valid {
+		// DECIMAL カラムの末尾の0を取り除く
+		if rate, err := entity.NormalizeExchangeRate(exchangeRate.String); err == nil {
+			item.ExchangeRate = rate
+		}
+	}
+	if purchasePriceJPY.Valid {
+		jpy := entity.JPY(purchasePriceJPY.Int64)
+		item.PurchasePriceJPY = &jpy
+	}
+	if taxAmount.Valid {
+		item.TaxAmount = &entity.Money{Amount: taxAmount.Int64, Currency: item.PurchasePrice.Currency}
+	}
+	if taxAmountJPY.Valid {
+		jpy := entity.JPY(taxAmountJPY.Int64)
+		item.TaxAmountJPY = &jpy
+	}
+
 	item.CreatedAt = createdAt
 	item.UpdatedAt = updatedAt
+	if deletedAt.Valid {
+		item.DeletedAt = &deletedAt.Time
+	}
+	if splitFrom.Valid {
+		item.SplitFrom = &splitFrom.Int64
+	}
+	if purgeAt.Valid {
+		item.PurgeAt = &purgeAt.Time
+	}
 
 	return &item, nil
 }
+
+// 為替レートが未設定の場合はNULL
+func nullableExchangeRate(rate string) interface{} {
+	if rate == "" {
+		return nil
+	}
+	return rate
+}
+
+// 購入した国・地域が未設定の場合はNULL
+func nullableCountry(country string) interface{} {
+	if country == "" {
+		return nil
+	}
+	return country
+}
+
+// 購入日が未入力（下書き）の場合はNULL
+// 日付は YYYY-MM-DD の文字列で渡し、接続のタイムゾーンによって DATE カラムの日付がずれないようにする（未入力の場合はNULL）
+func nullableDate(date entity.Date) interface{} {
+	if date.IsZero() {
+		return nil
+	}
+	return date.String()
+}
+
+// 円換算額・税額が未設定の場合はNULL
+func nullableJPY(jpy *entity.Money) interface{} {
+	if jpy == nil {
+		return nil
+	}
+	return jpy.Amount
+}
+
+// 属性をJSONカラムに保存する形式へ変換（属性がない場合はNULL）
+func marshalAttributes(attributes map[string]string) (interface{}, error) {
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// DATE カラムの値を YYYY-MM-DD 形式にする（ドライバの設定により日時形式で返ることがある）
+func formatDateColumn(value string) string {
+	if value == "" {
+		return ""
+	}
+	if parsedDate, err := time.Parse("2006-01-02", value); err == nil {
+		return parsedDate.Format("2006-01-02")
+	}
+	if parsedDate, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsedDate.Format("2006-01-02")
+	}
+	if len(value) >= 10 {
+		return value[:10]
+	}
+	return value
+}
@@ -0,0 +1,144 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type JobRunRepository struct {
+	SqlHandler
+}
+
+const jobRunColumns = `id, name, schedule, scheduled_at, status, total, succeeded, skipped, failed, errors, last_error, started_at, finished_at`
+
+// 同じ処理・同じ実行予定の日時の実行がすでにある場合は ErrDuplicateEntry を返す（ユニークキーで重複を防ぐ）
+func (r *JobRunRepository) Create(ctx context.Context, run *entity.JobRun) (*entity.JobRun, error) {
+	query := `
+        INSERT INTO job_runs (name, schedule, scheduled_at, status, started_at)
+        VALUES (?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		run.Name,
+		run.Schedule,
+		run.ScheduledAt,
+		string(run.Status),
+		run.StartedAt,
+	)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	created := *run
+	created.ID = id
+	return &created, nil
+}
+
+// 実行の集計（状況・件数・失敗したアイテム・終了日時）を更新する
+func (r *JobRunRepository) Update(ctx context.Context, run *entity.JobRun) error {
+	query := `
+        UPDATE job_runs
+        SET status = ?, total = ?, succeeded = ?, skipped = ?, failed = ?, errors = ?, last_error = ?, finished_at = ?
+        WHERE id = ?
+    `
+
+	errs, err := json.Marshal(run.Errors)
+	if err != nil {
+		return fmt.Errorf("failed to encode job run errors: %w", err)
+	}
+
+	_, err = r.Execute(ctx, query,
+		string(run.Status),
+		run.Total,
+		run.Succeeded,
+		run.Skipped,
+		run.Failed,
+		string(errs),
+		run.Error,
+		run.FinishedAt,
+		run.ID,
+	)
+	if err != nil {
+		return classifyError(err)
+	}
+
+	return nil
+}
+
+func (r *JobRunRepository) FindRecent(ctx context.Context, limit int) ([]*entity.JobRun, error) {
+	query := `
+        SELECT ` + jobRunColumns + `
+        FROM job_runs
+        ORDER BY started_at DESC, id DESC
+        LIMIT ?
+    `
+
+	rows, err := r.Query(ctx, query, limit)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	runs := []*entity.JobRun{}
+	for rows.Next() {
+		run, err := scanJobRun(rows)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		runs = append(runs, run)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return runs, nil
+}
+
+func scanJobRun(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.JobRun, error) {
+	var run entity.JobRun
+	var status string
+	var errs []byte
+	var finishedAt sql.NullTime
+	err := scanner.Scan(
+		&run.ID,
+		&run.Name,
+		&run.Schedule,
+		&run.ScheduledAt,
+		&status,
+		&run.Total,
+		&run.Succeeded,
+		&run.Skipped,
+		&run.Failed,
+		&errs,
+		&run.Error,
+		&run.StartedAt,
+		&finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	run.Status = entity.JobRunStatus(status)
+	run.Errors = []entity.JobRunError{}
+	if len(errs) > 0 {
+		if err := json.Unmarshal(errs, &run.Errors); err != nil {
+			return nil, fmt.Errorf("invalid job run errors: %w", err)
+		}
+	}
+	if finishedAt.Valid {
+		run.FinishedAt = &finishedAt.Time
+	}
+	return &run, nil
+}
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type JobRepository struct {
+	SqlHandler
+}
+
+const jobColumns = `id, type, payload, status, attempts, max_attempts, last_error, result, owner, run_at, locked_until, created_at, updated_at, finished_at`
+
+// 他のワーカーと取り合いになった場合に、次に試す実行待ちのジョブの件数
+const jobClaimCandidates = 5
+
+func (r *JobRepository) Create(ctx context.Context, job *entity.Job) (*entity.Job, error) {
+	query := `
+        INSERT INTO jobs (type, payload, status, attempts, max_attempts, last_error, owner, run_at, created_at, updated_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		job.Type,
+		string(job.Payload),
+		string(job.Status),
+		job.Attempts,
+		job.MaxAttempts,
+		job.LastError,
+		job.Owner,
+		job.RunAt,
+		job.CreatedAt,
+		job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	created := *job
+	created.ID = id
+	return &created, nil
+}
+
+func (r *JobRepository) FindByID(ctx context.Context, id int64) (*entity.Job, error) {
+	query := `
+        SELECT ` + jobColumns + `
+        FROM jobs
+        WHERE id = ?
+    `
+
+	job, err := scanJob(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrJobNotFound
+		}
+		return nil, classifyError(err)
+	}
+
+	return job, nil
+}
+
+// 実行待ちで実行日時を過ぎたもの、または実行中で期限を過ぎたもの（ワーカーが応答しなくなったもの）を古い順に取得し、
+// 取得したときの状況のままであれば実行中にする（複数のワーカーが同じジョブを取得した場合は、1つだけが更新できる）
+func (r *JobRepository) Claim(ctx context.Context, now, leaseUntil time.Time) (*entity.Job, error) {
+	query := `
+        SELECT id, status
+        FROM jobs
+        WHERE (status = ? AND run_at <= ?) OR (status = ? AND locked_until <= ?)
+        ORDER BY run_at, id
+        LIMIT ?
+    `
+
+	rows, err := r.Query(ctx, query,
+		string(entity.JobQueued), now,
+		string(entity.JobRunning), now,
+		jobClaimCandidates,
+	)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	type candidate struct {
+		id     int64
+		status string
+	}
+	var candidates []candidate
+	for rows.Next() {
+		var c candidate
+		if err := rows.Scan(&c.id, &c.status); err != nil {
+			rows.Close()
+			return nil, classifyError(err)
+		}
+		candidates = append(candidates, c)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, classifyError(err)
+	}
+	rows.Close()
+
+	update := `
+        UPDATE jobs
+        SET status = ?, attempts = attempts + 1, locked_until = ?, updated_at = ?
+        WHERE id = ? AND status = ? AND ((status = ? AND run_at <= ?) OR (status = ? AND locked_until <= ?))
+    `
+	for _, c := range candidates {
+		result, err := r.Execute(ctx, update,
+			string(entity.JobRunning), leaseUntil, now,
+			c.id, c.status,
+			string(entity.JobQueued), now,
+			string(entity.JobRunning), now,
+		)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		if affected == 1 {
+			return r.FindByID(ctx, c.id)
+		}
+	}
+
+	return nil, nil
+}
+
+// 実行の結果（状況・最後のエラー・結果・次の実行日時）を更新する
+func (r *JobRepository) Update(ctx context.Context, job *entity.Job) error {
+	query := `
+        UPDATE jobs
+        SET status = ?, attempts = ?, last_error = ?, result = ?, run_at = ?, locked_until = ?, updated_at = ?, finished_at = ?
+        WHERE id = ?
+    `
+
+	var result interface{}
+	if job.Result != nil {
+		result = string(job.Result)
+	}
+
+	_, err := r.Execute(ctx, query,
+		string(job.Status),
+		job.Attempts,
+		job.LastError,
+		result,
+		job.RunAt,
+		job.LockedUntil,
+		job.UpdatedAt,
+		job.FinishedAt,
+		job.ID,
+	)
+	if err != nil {
+		return classifyError(err)
+	}
+
+	return nil
+}
+
+func scanJob(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Job, error) {
+	var job entity.Job
+	var status string
+	var payload, result []byte
+	var lockedUntil, finishedAt sql.NullTime
+	err := scanner.Scan(
+		&job.ID,
+		&job.Type,
+		&payload,
+		&status,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.LastError,
+		&result,
+		&job.Owner,
+		&job.RunAt,
+		&lockedUntil,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	job.Status = entity.JobStatus(status)
+	job.Payload = payload
+	job.Result = result
+	if lockedUntil.Valid {
+		job.LockedUntil = &lockedUntil.Time
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	return &job, nil
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ReceiptScanRepository struct {
+	SqlHandler
+}
+
+func (r *ReceiptScanRepository) Save(ctx context.Context, scan *entity.ReceiptScan) error {
+	query := `
+        INSERT INTO receipt_scans (image_id, item_id, purchase_price, currency, purchase_date, store_name, text, scanned_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE purchase_price = VALUES(purchase_price), currency = VALUES(currency), purchase_date = VALUES(purchase_date),
+            store_name = VALUES(store_name), text = VALUES(text), scanned_at = VALUES(scanned_at)
+    `
+	if r.Dialect() == SQLite {
+		query = `
+            INSERT INTO receipt_scans (image_id, item_id, purchase_price, currency, purchase_date, store_name, text, scanned_at)
+            VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+            ON CONFLICT (image_id) DO UPDATE SET purchase_price = excluded.purchase_price, currency = excluded.currency, purchase_date = excluded.purchase_date,
+                store_name = excluded.store_name, text = excluded.text, scanned_at = excluded.scanned_at
+        `
+	}
+
+	// 購入価格・購入日を読み取れなかった場合はNULL
+	var price, currency interface{} = nil, ""
+	if scan.PurchasePrice != nil {
+		price, currency = scan.PurchasePrice.Amount, scan.PurchasePrice.Currency
+	}
+	var purchaseDate interface{}
+	if scan.PurchaseDate != "" {
+		purchaseDate = scan.PurchaseDate
+	}
+
+	_, err := r.Execute(ctx, query,
+		scan.ImageID,
+		scan.ItemID,
+		price,
+		currency,
+		purchaseDate,
+		scan.StoreName,
+		scan.Text,
+		scan.ScannedAt,
+	)
+	if err != nil {
+		return classifyError(err)
+	}
+	return nil
+}
+
+func (r *ReceiptScanRepository) FindByImageID(ctx context.Context, itemID, imageID int64) (*entity.ReceiptScan, error) {
+	query := `
+        SELECT image_id, item_id, purchase_price, currency, purchase_date, store_name, text, scanned_at
+        FROM receipt_scans
+        WHERE image_id = ? AND item_id = ?
+    `
+
+	var scan entity.ReceiptScan
+	var price sql.NullInt64
+	var currency string
+	var purchaseDate sql.NullString
+	err := r.QueryRow(ctx, query, imageID, itemID).Scan(
+		&scan.ImageID,
+		&scan.ItemID,
+		&price,
+		&currency,
+		&purchaseDate,
+		&scan.StoreName,
+		&scan.Text,
+		&scan.ScannedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrReceiptNotFound
+		}
+		return nil, classifyError(err)
+	}
+
+	if price.Valid {
+		scan.PurchasePrice = &entity.Money{Amount: price.Int64, Currency: currency}
+	}
+	scan.PurchaseDate = formatDateColumn(purchaseDate.String)
+
+	return &scan, nil
+}
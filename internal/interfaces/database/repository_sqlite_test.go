@@ -0,0 +1,967 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/infrastructure/config"
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// マイグレーションを適用した空の SQLite データベース（テストごとに作成する）
+func newSQLiteHandler(t *testing.T) database.SqlHandler {
+	t.Helper()
+	handler := databaseInfra.NewSqlHandler(&config.Config{
+		DBDriver:       "sqlite",
+		DBPath:         filepath.Join(t.TempDir(), "items.db"),
+		DBMaxOpenConns: 4,
+		DBMaxIdleConns: 4,
+		AutoMigrate:    true,
+	})
+	t.Cleanup(func() { handler.Close() })
+
+	// サンプルデータを除く
+	_, err := handler.Execute(context.Background(), "DELETE FROM items")
+	require.NoError(t, err)
+	return handler
+}
+
+func createItem(t *testing.T, repo *database.ItemRepository, name, category, brand string, price entity.Money, purchaseDate string) *entity.Item {
+	t.Helper()
+	item, err := entity.NewItem(name, category, brand, price, entity.MustParseDate(purchaseDate), time.Now())
+	require.NoError(t, err)
+	created, err := repo.Create(context.Background(), item)
+	require.NoError(t, err)
+	return created
+}
+
+func TestItemRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 登録・取得・更新・論理削除・復元", func(t *testing.T) {
+		repo := &database.ItemRepository{SqlHandler: newSQLiteHandler(t)}
+
+		item, err := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), entity.MustParseDate("2023-01-15"), time.Now(), entity.WithAttributes(map[string]string{"reference_number": "116500LN"}))
+		require.NoError(t, err)
+		created, err := repo.Create(ctx, item)
+		require.NoError(t, err)
+		require.NotZero(t, created.ID)
+
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "ロレックス デイトナ", found.Name)
+		assert.Equal(t, "2023-01-15", found.PurchaseDate.String())
+		assert.Equal(t, entity.JPY(1500000), found.PurchasePrice)
+		assert.Equal(t, map[string]string{"reference_number": "116500LN"}, found.Attributes)
+		assert.False(t, found.CreatedAt.IsZero())
+
+		found.Name = "ロレックス デイトナ 白"
+		_, err = repo.Update(ctx, found)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(ctx, created.ID, time.Now()))
+		_, err = repo.FindByID(ctx, created.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, created.ID, time.Now()), domainErrors.ErrItemNotFound)
+
+		require.NoError(t, repo.Restore(ctx, created.ID, time.Now()))
+		found, err = repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "ロレックス デイトナ 白", found.Name)
+	})
+
+	t.Run("正常系: キーワード・カテゴリー・並び順・ページング", func(t *testing.T) {
+		repo := &database.ItemRepository{SqlHandler: newSQLiteHandler(t)}
+		daytona := createItem(t, repo, "ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), "2023-01-15")
+		submariner := createItem(t, repo, "ロレックス サブマリーナ", "時計", "ROLEX", entity.JPY(1200000), "2023-02-01")
+		createItem(t, repo, "エルメス バーキン", "バッグ", "HERMÈS", entity.JPY(2000000), "2023-02-20")
+		createItem(t, repo, "100%シルク スカーフ", "その他", "HERMÈS", entity.JPY(50000), "2023-03-01")
+
+		query := entity.ItemQuery{Keyword: "rolex", Limit: 10, Sort: entity.SortByPurchasePrice, Order: entity.SortAsc}
+		items, err := repo.FindAll(ctx, query)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, []int64{submariner.ID, daytona.ID}, []int64{items[0].ID, items[1].ID})
+
+		count, err := repo.Count(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		// LIKE の特殊文字はそのまま検索する
+		items, err = repo.FindAll(ctx, entity.ItemQuery{Keyword: "0%シ", Limit: 10, Sort: entity.SortByID, Order: entity.SortAsc})
+		require.NoError(t, err)
+		assert.Len(t, items, 1)
+
+		items, err = repo.FindAll(ctx, entity.ItemQuery{Category: "時計", Limit: 1, Offset: 1, Sort: entity.SortByID, Order: entity.SortAsc})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, submariner.ID, items[0].ID)
+
+		items, err = repo.FindAll(ctx, entity.ItemQuery{IDs: []int64{daytona.ID, submariner.ID}, Limit: 10, Sort: entity.SortByID})
+		require.NoError(t, err)
+		assert.Len(t, items, 2)
+	})
+
+	t.Run("正常系: 年・月ごとの集計", func(t *testing.T) {
+		repo := &database.ItemRepository{SqlHandler: newSQLiteHandler(t)}
+		createItem(t, repo, "時計1", "時計", "ROLEX", entity.JPY(1000000), "2022-12-01")
+		createItem(t, repo, "時計2", "時計", "ROLEX", entity.JPY(2000000), "2023-01-15")
+		createItem(t, repo, "時計3", "時計", "OMEGA", entity.JPY(500000), "2023-01-20")
+
+		byYear, err := repo.GetSummaryByYearAndCategory(ctx)
+		require.NoError(t, err)
+		require.Len(t, byYear, 2)
+		assert.Equal(t, entity.YearCategoryValueTotal{Year: 2023, Category: "時計", Currency: "JPY", Count: 2, Total: 2500000, TotalJPY: 2500000}, byYear[1])
+
+		byMonth, err := repo.GetMonthlyPurchaseTotals(ctx, "2023-01-01", "2024-01-01")
+		require.NoError(t, err)
+		require.Len(t, byMonth, 1)
+		assert.Equal(t, "2023-01", byMonth[0].Month)
+		assert.Equal(t, int64(2500000), byMonth[0].Total)
+
+		sum, err := repo.SumPurchasePrice(ctx, "時計", "JPY", 2023, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2500000), sum)
+	})
+
+	t.Run("正常系: 税額の保存と年ごとの集計", func(t *testing.T) {
+		repo := &database.ItemRepository{SqlHandler: newSQLiteHandler(t)}
+		tax := int64(100000)
+		item, err := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1100000), entity.MustParseDate("2023-01-15"), time.Now(), entity.WithTax(&tax, true))
+		require.NoError(t, err)
+		created, err := repo.Create(ctx, item)
+		require.NoError(t, err)
+		createItem(t, repo, "時計2", "時計", "ROLEX", entity.JPY(1000000), "2023-02-01")
+
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found.TaxAmount)
+		assert.Equal(t, entity.JPY(100000), *found.TaxAmount)
+		assert.True(t, found.TaxIncluded)
+
+		totals, err := repo.GetTaxTotals(ctx, "2023-01-01", "2024-01-01")
+		require.NoError(t, err)
+		assert.Equal(t, []entity.TaxTotal{{Year: 2023, Currency: "JPY", Count: 1, Total: 100000, TotalJPY: 100000}}, totals)
+	})
+
+	t.Run("正常系: 完全削除の予定日時を過ぎたアイテム", func(t *testing.T) {
+		repo := &database.ItemRepository{SqlHandler: newSQLiteHandler(t)}
+		due := createItem(t, repo, "時計1", "時計", "ROLEX", entity.JPY(1000000), "2023-01-01")
+		later := createItem(t, repo, "時計2", "時計", "ROLEX", entity.JPY(1000000), "2023-01-01")
+
+		now := time.Now()
+		past, future := now.Add(-time.Hour), now.Add(time.Hour)
+		require.NoError(t, repo.SchedulePurge(ctx, due.ID, &past, time.Now()))
+		require.NoError(t, repo.SchedulePurge(ctx, later.ID, &future, time.Now()))
+
+		items, err := repo.FindDuePurges(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, due.ID, items[0].ID)
+		require.NotNil(t, items[0].PurgeAt)
+		assert.WithinDuration(t, past, *items[0].PurgeAt, time.Second)
+	})
+}
+
+func TestItemRepository_ChangeSeq_SQLite(t *testing.T) {
+	ctx := context.Background()
+	handler := newSQLiteHandler(t)
+	items := &database.ItemRepository{SqlHandler: handler}
+	tags := &database.TagRepository{SqlHandler: handler}
+
+	// 登録日時・更新日時はエンティティの値で保存する
+	createdAt := time.Date(2020, 4, 1, 9, 0, 0, 0, time.UTC)
+	item, err := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2020-03-01"), createdAt)
+	require.NoError(t, err)
+	first, err := items.Create(ctx, item)
+	require.NoError(t, err)
+	assert.True(t, createdAt.Equal(first.CreatedAt))
+	assert.True(t, createdAt.Equal(first.UpdatedAt))
+
+	second := createItem(t, items, "時計2", "時計", "ROLEX", entity.JPY(1000000), "2023-01-01")
+	assert.Greater(t, second.ChangeSeq, first.ChangeSeq)
+
+	updatedAt := createdAt.Add(time.Hour)
+	require.NoError(t, first.Update("時計1 改", first.Category, first.Brand, first.PurchasePrice, first.PurchaseDate, updatedAt))
+	first, err = items.Update(ctx, first)
+	require.NoError(t, err)
+	assert.True(t, updatedAt.Equal(first.UpdatedAt))
+	assert.Greater(t, first.ChangeSeq, second.ChangeSeq)
+
+	// 保全・タグの変更でも番号が進み、変更の順に取得できる
+	require.NoError(t, items.SetHold(ctx, second.ID, true, "保険請求", time.Now()))
+	gift, err := entity.NewTag("gift", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, tags.AddToItem(ctx, first.ID, gift))
+
+	changed, err := items.FindAll(ctx, entity.ItemQuery{ChangedAfter: first.ChangeSeq - 1, Limit: 10, Sort: entity.SortByChangeSeq, Order: entity.SortAsc})
+	require.NoError(t, err)
+	require.Len(t, changed, 2)
+	assert.Equal(t, second.ID, changed[0].ID)
+	assert.Equal(t, first.ID, changed[1].ID)
+	assert.Greater(t, changed[1].ChangeSeq, changed[0].ChangeSeq)
+	assert.True(t, updatedAt.Equal(changed[1].UpdatedAt), "タグの変更では更新日時を変えない")
+}
+
+func TestItemRepository_UpdatedAt_SQLite(t *testing.T) {
+	ctx := context.Background()
+	items := &database.ItemRepository{SqlHandler: newSQLiteHandler(t)}
+	item := createItem(t, items, "時計1", "時計", "ROLEX", entity.JPY(1000000), "2023-01-01")
+
+	// 更新日時・削除日時は渡した現在時刻で保存する
+	at := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	updatedAt := func(id int64) time.Time {
+		t.Helper()
+		found, err := items.FindAll(ctx, entity.ItemQuery{IncludeDeleted: true, Limit: 10})
+		require.NoError(t, err)
+		for _, f := range found {
+			if f.ID == id {
+				return f.UpdatedAt
+			}
+		}
+		t.Fatalf("item %d not found", id)
+		return time.Time{}
+	}
+
+	require.NoError(t, items.SetHold(ctx, item.ID, true, "保険請求", at))
+	assert.True(t, at.Equal(updatedAt(item.ID)))
+	require.NoError(t, items.SetHold(ctx, item.ID, false, "", at.Add(time.Hour)))
+	assert.True(t, at.Add(time.Hour).Equal(updatedAt(item.ID)))
+
+	purgeAt := at.Add(30 * 24 * time.Hour)
+	require.NoError(t, items.SchedulePurge(ctx, item.ID, &purgeAt, at.Add(2*time.Hour)))
+	assert.True(t, at.Add(2*time.Hour).Equal(updatedAt(item.ID)))
+
+	require.NoError(t, items.SetSold(ctx, item.ID, at.Add(3*time.Hour)))
+	assert.True(t, at.Add(3*time.Hour).Equal(updatedAt(item.ID)))
+
+	require.NoError(t, items.Delete(ctx, item.ID, at.Add(4*time.Hour)))
+	assert.True(t, at.Add(4*time.Hour).Equal(updatedAt(item.ID)))
+	deleted, err := items.FindAll(ctx, entity.ItemQuery{IncludeDeleted: true, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	require.NotNil(t, deleted[0].DeletedAt)
+	assert.True(t, at.Add(4*time.Hour).Equal(*deleted[0].DeletedAt))
+
+	require.NoError(t, items.Restore(ctx, item.ID, at.Add(5*time.Hour)))
+	restored, err := items.FindByID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.True(t, at.Add(5*time.Hour).Equal(restored.UpdatedAt))
+	assert.Nil(t, restored.DeletedAt)
+}
+
+func TestItemRepository_Split_SQLite(t *testing.T) {
+	ctx := context.Background()
+	repo := &database.ItemRepository{SqlHandler: newSQLiteHandler(t)}
+	now := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+
+	set := createItem(t, repo, "ジュエリーセット", "ジュエリー", "Tiffany & Co.", entity.JPY(100000), "2023-05-01")
+	components, err := set.Split([]entity.SplitComponent{{Name: "ネックレス"}, {Name: "ピアス"}}, entity.SplitEqual, now)
+	require.NoError(t, err)
+
+	created, err := repo.Split(ctx, set.ID, components, time.Now())
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	for _, component := range created {
+		assert.NotZero(t, component.ID)
+		require.NotNil(t, component.SplitFrom)
+		assert.Equal(t, set.ID, *component.SplitFrom)
+		assert.Equal(t, entity.JPY(50000), component.PurchasePrice)
+		assert.Greater(t, component.ChangeSeq, set.ChangeSeq)
+	}
+
+	// 分割元は削除済み
+	_, err = repo.FindByID(ctx, set.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+
+	// 削除済みのアイテムは分割できず、構成品も登録しない
+	_, err = repo.Split(ctx, set.ID, components, time.Now())
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	count, err := repo.Count(ctx, entity.ItemQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestTagRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	handler := newSQLiteHandler(t)
+	items := &database.ItemRepository{SqlHandler: handler}
+	tags := &database.TagRepository{SqlHandler: handler}
+
+	first := createItem(t, items, "時計1", "時計", "ROLEX", entity.JPY(1000000), "2023-01-01")
+	second := createItem(t, items, "時計2", "時計", "ROLEX", entity.JPY(1000000), "2023-01-01")
+	gift, err := entity.NewTag("Gift", time.Now())
+	require.NoError(t, err)
+
+	// 既存のタグは同じIDで付け、同じタグを2回付けても1つ
+	require.NoError(t, tags.AddToItem(ctx, first.ID, gift))
+	require.NoError(t, tags.AddToItem(ctx, second.ID, gift))
+	require.NoError(t, tags.AddToItem(ctx, second.ID, gift))
+
+	names, err := tags.FindNamesByItemIDs(ctx, []int64{first.ID, second.ID})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64][]string{first.ID: {"gift"}, second.ID: {"gift"}}, names)
+
+	tagged, err := items.FindAll(ctx, entity.ItemQuery{Tag: "gift", Limit: 10, Sort: entity.SortByID})
+	require.NoError(t, err)
+	assert.Len(t, tagged, 2)
+
+	require.NoError(t, tags.RemoveFromItem(ctx, first.ID, "gift"))
+	assert.ErrorIs(t, tags.RemoveFromItem(ctx, first.ID, "gift"), domainErrors.ErrItemNotFound)
+}
+
+func TestUpsertRepositories_SQLite(t *testing.T) {
+	ctx := context.Background()
+	handler := newSQLiteHandler(t)
+
+	t.Run("正常系: カテゴリー予算の登録と上書き", func(t *testing.T) {
+		budgets := &database.BudgetRepository{SqlHandler: handler}
+		budget, err := entity.NewCategoryBudget("時計", entity.JPY(1000000), time.Now())
+		require.NoError(t, err)
+		_, err = budgets.Save(ctx, budget)
+		require.NoError(t, err)
+
+		budget.Amount = entity.JPY(3000000)
+		saved, err := budgets.Save(ctx, budget)
+		require.NoError(t, err)
+		assert.Equal(t, entity.JPY(3000000), saved.Amount)
+
+		all, err := budgets.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+	})
+
+	t.Run("正常系: ブランドの別名の登録と上書き", func(t *testing.T) {
+		aliases := &database.BrandAliasRepository{SqlHandler: handler}
+		alias, err := entity.NewBrandAlias("ロレックス", "ROLEX", time.Now())
+		require.NoError(t, err)
+		_, err = aliases.Save(ctx, alias)
+		require.NoError(t, err)
+
+		alias.Brand = "Rolex"
+		saved, err := aliases.Save(ctx, alias)
+		require.NoError(t, err)
+		assert.Equal(t, "Rolex", saved.Brand)
+	})
+}
+
+func TestWebhookRepositories_SQLite(t *testing.T) {
+	ctx := context.Background()
+	handler := newSQLiteHandler(t)
+	webhooks := &database.WebhookRepository{SqlHandler: handler}
+	deliveries := &database.WebhookDeliveryRepository{SqlHandler: handler}
+	now := time.Now().UTC().Truncate(time.Second)
+
+	webhook, err := entity.NewWebhook("https://example.com/hooks", []entity.WebhookEvent{entity.WebhookItemCreated, entity.WebhookItemDeleted}, "alice", "whsec_test", now)
+	require.NoError(t, err)
+	created, err := webhooks.Create(ctx, webhook)
+	require.NoError(t, err)
+
+	found, err := webhooks.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.WebhookEvent{entity.WebhookItemCreated, entity.WebhookItemDeleted}, found.Events)
+	assert.Equal(t, "whsec_test", found.Secret)
+
+	owned, err := webhooks.FindByOwner(ctx, "bob")
+	require.NoError(t, err)
+	assert.Empty(t, owned)
+
+	// 送信時刻を過ぎた送信待ちのみを取得する
+	later := now.Add(time.Hour)
+	due, err := deliveries.Create(ctx, &entity.WebhookDelivery{WebhookID: created.ID, Event: entity.WebhookItemCreated, Payload: []byte(`{"event":"item.created"}`), Status: entity.WebhookDeliveryPending, NextAttemptAt: &now, CreatedAt: now, UpdatedAt: now})
+	require.NoError(t, err)
+	_, err = deliveries.Create(ctx, &entity.WebhookDelivery{WebhookID: created.ID, Event: entity.WebhookItemDeleted, Payload: []byte(`{}`), Status: entity.WebhookDeliveryPending, NextAttemptAt: &later, CreatedAt: now, UpdatedAt: now})
+	require.NoError(t, err)
+
+	pending, err := deliveries.FindDue(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, due.ID, pending[0].ID)
+	assert.JSONEq(t, `{"event":"item.created"}`, string(pending[0].Payload))
+
+	pending[0].Status = entity.WebhookDeliverySucceeded
+	pending[0].Attempts = 1
+	pending[0].ResponseStatus = 204
+	pending[0].NextAttemptAt = nil
+	require.NoError(t, deliveries.Update(ctx, pending[0]))
+
+	pending, err = deliveries.FindDue(ctx, later, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, entity.WebhookItemDeleted, pending[0].Event)
+
+	// 送信ログは新しい順
+	log, err := deliveries.FindByWebhookID(ctx, created.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, log, 2)
+	assert.Equal(t, entity.WebhookDeliveryPending, log[0].Status)
+	assert.Equal(t, entity.WebhookDeliverySucceeded, log[1].Status)
+	assert.Equal(t, 204, log[1].ResponseStatus)
+	assert.Nil(t, log[1].NextAttemptAt)
+
+	// 削除すると送信ログも削除する
+	require.NoError(t, webhooks.Delete(ctx, created.ID))
+	assert.ErrorIs(t, webhooks.Delete(ctx, created.ID), domainErrors.ErrWebhookNotFound)
+	log, err = deliveries.FindByWebhookID(ctx, created.ID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, log)
+}
+
+func TestOutboxRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	handler := newSQLiteHandler(t)
+	outbox := &database.OutboxRepository{SqlHandler: handler}
+	now := time.Now().UTC().Truncate(time.Second)
+
+	// 変更と同じトランザクションで記録し、ロールバックした場合は残らない
+	err := handler.Transaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, outbox.Create(ctx, entity.NewOutboxEvent(1, entity.AuditCreate, "alice", now)))
+		return errors.New("mutation failed")
+	})
+	require.Error(t, err)
+	due, err := outbox.FindDue(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	first := entity.NewOutboxEvent(1, entity.AuditCreate, "alice", now)
+	second := entity.NewOutboxEvent(1, entity.AuditUpdate, "bob", now)
+	require.NoError(t, handler.Transaction(ctx, func(ctx context.Context) error {
+		if err := outbox.Create(ctx, first); err != nil {
+			return err
+		}
+		return outbox.Create(ctx, second)
+	}))
+
+	// 未送信のイベントを記録順に取得する
+	due, err = outbox.FindDue(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	assert.Equal(t, first.ID, due[0].ID)
+	assert.Equal(t, entity.AuditUpdate, due[1].Action)
+	assert.Equal(t, "bob", due[1].Actor)
+
+	// 送信済みのイベントと、次の送信時刻になっていないイベントは取得しない
+	due[0].PublishedAt = &now
+	require.NoError(t, outbox.Update(ctx, due[0]))
+	due[1].Attempts = 1
+	due[1].LastError = "connection refused"
+	due[1].NextAttemptAt = now.Add(time.Minute)
+	require.NoError(t, outbox.Update(ctx, due[1]))
+
+	due, err = outbox.FindDue(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+	due, err = outbox.FindDue(ctx, now.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, 1, due[0].Attempts)
+	assert.Equal(t, "connection refused", due[0].LastError)
+
+	// 送信済みのイベントのみ削除する
+	deleted, err := outbox.DeletePublishedBefore(ctx, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func TestJobRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	jobs := &database.JobRepository{SqlHandler: newSQLiteHandler(t)}
+	now := time.Now().UTC().Truncate(time.Second)
+
+	first, err := jobs.Create(ctx, entity.NewJob("valuation.refresh", []byte(`{"item_id":1}`), "alice", 3, now))
+	require.NoError(t, err)
+	second, err := jobs.Create(ctx, entity.NewJob("valuation.refresh", []byte(`{"item_id":2}`), "bob", 3, now.Add(time.Second)))
+	require.NoError(t, err)
+
+	_, err = jobs.FindByID(ctx, 999)
+	assert.ErrorIs(t, err, domainErrors.ErrJobNotFound)
+
+	// 実行日時を過ぎたジョブを古い順に実行中にし、実行回数を数える
+	claimed, err := jobs.Claim(ctx, now, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	assert.Equal(t, first.ID, claimed.ID)
+	assert.Equal(t, entity.JobRunning, claimed.Status)
+	assert.Equal(t, 1, claimed.Attempts)
+	assert.JSONEq(t, `{"item_id":1}`, string(claimed.Payload))
+
+	// 実行中のジョブと、実行日時になっていないジョブは取得しない
+	none, err := jobs.Claim(ctx, now, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Nil(t, none)
+
+	// 期限を過ぎた実行中のジョブはもう一度実行する
+	reclaimed, err := jobs.Claim(ctx, now.Add(time.Minute), now.Add(2*time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, reclaimed)
+	assert.Equal(t, first.ID, reclaimed.ID)
+	assert.Equal(t, 2, reclaimed.Attempts)
+
+	// 結果を記録する
+	reclaimed.Succeed([]byte(`{"value":100}`), now.Add(time.Minute))
+	require.NoError(t, jobs.Update(ctx, reclaimed))
+	found, err := jobs.FindByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.JobSucceeded, found.Status)
+	assert.JSONEq(t, `{"value":100}`, string(found.Result))
+	assert.Nil(t, found.LockedUntil)
+	require.NotNil(t, found.FinishedAt)
+
+	// 終了したジョブは取得しない
+	next, err := jobs.Claim(ctx, now.Add(time.Hour), now.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, second.ID, next.ID)
+}
+
+func TestJobRunRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	runs := &database.JobRunRepository{SqlHandler: newSQLiteHandler(t)}
+	scheduledAt := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+
+	first, err := runs.Create(ctx, entity.NewJobRun("valuation.refresh", "0 3 * * *", scheduledAt, scheduledAt))
+	require.NoError(t, err)
+
+	// 同じ処理・同じ実行予定の日時は重複エラー
+	_, err = runs.Create(ctx, entity.NewJobRun("valuation.refresh", "0 3 * * *", scheduledAt, scheduledAt))
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+
+	first.RecordSuccess()
+	first.RecordFailure(2, "market price unavailable")
+	first.Finish("", scheduledAt.Add(time.Minute))
+	require.NoError(t, runs.Update(ctx, first))
+
+	next := scheduledAt.AddDate(0, 0, 1)
+	second, err := runs.Create(ctx, entity.NewJobRun("valuation.refresh", "0 3 * * *", next, next))
+	require.NoError(t, err)
+
+	// 新しい順
+	found, err := runs.FindRecent(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, second.ID, found[0].ID)
+	assert.Equal(t, entity.JobRunRunning, found[0].Status)
+	assert.Empty(t, found[0].Errors)
+	assert.Nil(t, found[0].FinishedAt)
+
+	assert.Equal(t, entity.JobRunSucceeded, found[1].Status)
+	assert.Equal(t, 2, found[1].Total)
+	assert.Equal(t, 1, found[1].Succeeded)
+	assert.Equal(t, 1, found[1].Failed)
+	assert.Equal(t, []entity.JobRunError{{ItemID: 2, Error: "market price unavailable"}}, found[1].Errors)
+	require.NotNil(t, found[1].FinishedAt)
+
+	limited, err := runs.FindRecent(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+}
+
+func TestCategorySuggestionRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	handler := newSQLiteHandler(t)
+	items := &database.ItemRepository{SqlHandler: handler}
+	suggestions := &database.CategorySuggestionRepository{SqlHandler: handler}
+	now := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+
+	watch := createItem(t, items, "サブマリーナー", "その他", "ROLEX", entity.JPY(1200000), "2022-05-01")
+	ring := createItem(t, items, "ラブリング", "その他", "Cartier", entity.JPY(200000), "2021-03-10")
+
+	suggestion, err := entity.NewCategorySuggestion(watch, "時計", 0.92, "ダイバーズウォッチのモデル名", now)
+	require.NoError(t, err)
+	first, err := suggestions.Create(ctx, suggestion)
+	require.NoError(t, err)
+	require.NotZero(t, first.ID)
+
+	suggestion, err = entity.NewCategorySuggestion(ring, "ジュエリー", 0.8, "", now)
+	require.NoError(t, err)
+	second, err := suggestions.Create(ctx, suggestion)
+	require.NoError(t, err)
+
+	found, err := suggestions.FindByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, watch.ID, found.ItemID)
+	assert.Equal(t, "その他", found.FromCategory)
+	assert.Equal(t, "時計", found.Category)
+	assert.Equal(t, 0.92, found.Confidence)
+	assert.Equal(t, entity.SuggestionPending, found.Status)
+	assert.Nil(t, found.ReviewedAt)
+
+	_, err = suggestions.FindByID(ctx, 999)
+	assert.ErrorIs(t, err, domainErrors.ErrSuggestionNotFound)
+
+	// 承認した提案は確認待ちではなくなる
+	found.Review(entity.SuggestionApproved, "reviewer-1", now.Add(time.Hour))
+	require.NoError(t, suggestions.Update(ctx, found))
+	assert.ErrorIs(t, suggestions.Update(ctx, found), domainErrors.ErrSuggestionOutdated)
+
+	pending, err := suggestions.FindPendingItemIDs(ctx, []int64{watch.ID, ring.ID})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]bool{ring.ID: true}, pending)
+
+	listed, err := suggestions.FindByStatus(ctx, entity.SuggestionPending, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, second.ID, listed[0].ID)
+
+	approved, err := suggestions.FindByStatus(ctx, entity.SuggestionApproved, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, approved, 1)
+	assert.Equal(t, "reviewer-1", approved[0].ReviewedBy)
+	require.NotNil(t, approved[0].ReviewedAt)
+
+	rejected, err := suggestions.FindByStatus(ctx, entity.SuggestionRejected, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, rejected)
+	assert.NotNil(t, rejected)
+}
+
+func TestDashboardRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	handler := newSQLiteHandler(t)
+	repo := &database.DashboardRepository{SqlHandler: handler}
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("正常系: 期間内の監査ログを操作者ごとに集計する", func(t *testing.T) {
+		logs := &database.AuditLogRepository{SqlHandler: handler}
+		entries := []*entity.AuditLog{
+			entity.NewAuditLog(1, entity.AuditCreate, "alice", nil, now.Add(-48*time.Hour)), // 期間外
+			entity.NewAuditLog(2, entity.AuditCreate, "alice", nil, now.Add(-2*time.Hour)),
+			entity.NewAuditLog(2, entity.AuditUpdate, "alice", nil, now.Add(-time.Hour)),
+			entity.NewAuditLog(3, entity.AuditCreate, "bob", nil, now.Add(-3*time.Hour)),
+			entity.NewAuditLog(3, entity.AuditUpdate, "system", nil, now),
+		}
+		for _, entry := range entries {
+			require.NoError(t, logs.Log(ctx, entry))
+		}
+		query := entity.ActivityQuery{Since: now.Add(-24 * time.Hour), ExcludeActors: []string{"system"}, Limit: 10}
+
+		summary, err := repo.SummarizeActivity(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ActivitySummary{ActiveUsers: 2, Changes: 3, ItemsCreated: 2}, *summary)
+
+		actors, err := repo.FindActorActivity(ctx, query)
+		require.NoError(t, err)
+		require.Len(t, actors, 2)
+		assert.Equal(t, "alice", actors[0].Actor)
+		assert.Equal(t, 2, actors[0].Changes)
+		assert.Equal(t, 1, actors[0].ItemsCreated)
+		assert.True(t, actors[0].LastActiveAt.Equal(now.Add(-time.Hour)))
+		assert.Equal(t, "bob", actors[1].Actor)
+
+		query.Limit = 1
+		actors, err = repo.FindActorActivity(ctx, query)
+		require.NoError(t, err)
+		assert.Len(t, actors, 1)
+	})
+
+	t.Run("正常系: 写真の容量をカテゴリーごとに集計する", func(t *testing.T) {
+		items := &database.ItemRepository{SqlHandler: handler}
+		images := &database.ItemImageRepository{SqlHandler: handler}
+		watch := createItem(t, items, "ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), "2023-01-15")
+		bag := createItem(t, items, "バーキン", "バッグ", "HERMÈS", entity.JPY(2000000), "2023-02-01")
+		for i, image := range []*entity.ItemImage{
+			{ItemID: watch.ID, Size: 100},
+			{ItemID: watch.ID, Size: 200},
+			{ItemID: bag.ID, Size: 50},
+		} {
+			image.FileName = "photo.jpg"
+			image.ContentType = "image/jpeg"
+			image.StorageKey = fmt.Sprintf("items/%d/photo-%d.jpg", image.ItemID, i)
+			_, err := images.Create(ctx, image)
+			require.NoError(t, err)
+		}
+		require.NoError(t, items.Delete(ctx, bag.ID, time.Now()))
+
+		storages, err := repo.FindStorageByCategory(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.CategoryStorage{
+			{Category: "時計", Images: 2, Bytes: 300},
+			{Category: "バッグ", Images: 1, Bytes: 50, DeletedBytes: 50},
+		}, storages)
+	})
+
+	t.Run("正常系: 期間内の配信を Webhook ごとに集計する", func(t *testing.T) {
+		webhooks := &database.WebhookRepository{SqlHandler: handler}
+		deliveries := &database.WebhookDeliveryRepository{SqlHandler: handler}
+		var ids []int64
+		for _, owner := range []string{"alice", "bob"} {
+			webhook, err := entity.NewWebhook("https://example.com/"+owner, []entity.WebhookEvent{entity.WebhookItemCreated}, owner, "whsec_test", now)
+			require.NoError(t, err)
+			created, err := webhooks.Create(ctx, webhook)
+			require.NoError(t, err)
+			ids = append(ids, created.ID)
+		}
+		for _, d := range []struct {
+			status    entity.WebhookDeliveryStatus
+			createdAt time.Time
+		}{
+			{status: entity.WebhookDeliveryFailed, createdAt: now.Add(-48 * time.Hour)}, // 期間外
+			{status: entity.WebhookDeliverySucceeded, createdAt: now.Add(-2 * time.Hour)},
+			{status: entity.WebhookDeliveryFailed, createdAt: now.Add(-time.Hour)},
+			{status: entity.WebhookDeliveryPending, createdAt: now},
+		} {
+			_, err := deliveries.Create(ctx, &entity.WebhookDelivery{WebhookID: ids[0], Event: entity.WebhookItemCreated, Payload: []byte(`{}`), Status: d.status, CreatedAt: d.createdAt, UpdatedAt: d.createdAt})
+			require.NoError(t, err)
+		}
+
+		healths, err := repo.FindWebhookHealth(ctx, now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, healths, 2)
+		assert.Equal(t, ids[0], healths[0].WebhookID)
+		assert.Equal(t, "alice", healths[0].Owner)
+		assert.Equal(t, 3, healths[0].Deliveries)
+		assert.Equal(t, 1, healths[0].Succeeded)
+		assert.Equal(t, 1, healths[0].Failed)
+		assert.Equal(t, 1, healths[0].Pending)
+		assert.Equal(t, entity.WebhookDeliveryPending, healths[0].LastStatus)
+		require.NotNil(t, healths[0].LastDeliveredAt)
+		assert.True(t, healths[0].LastDeliveredAt.Equal(now))
+
+		// 配信のない Webhook も含める
+		assert.Equal(t, ids[1], healths[1].WebhookID)
+		assert.Zero(t, healths[1].Deliveries)
+		assert.Empty(t, healths[1].LastStatus)
+		assert.Nil(t, healths[1].LastDeliveredAt)
+	})
+}
+
+func TestReceiptScanRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	handler := newSQLiteHandler(t)
+	items := &database.ItemRepository{SqlHandler: handler}
+	images := &database.ItemImageRepository{SqlHandler: handler}
+	scans := &database.ReceiptScanRepository{SqlHandler: handler}
+	now := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+
+	watch := createItem(t, items, "ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), "2023-01-15")
+	image, err := images.Create(ctx, &entity.ItemImage{ItemID: watch.ID, FileName: "receipt.jpg", ContentType: "image/jpeg", StorageKey: "items/1/receipt.jpg", Size: 100})
+	require.NoError(t, err)
+
+	// 読み取れなかった項目は NULL で保存する
+	require.NoError(t, scans.Save(ctx, entity.NewReceiptScan(image, "読み取れない", "JPY", now)))
+	found, err := scans.FindByImageID(ctx, watch.ID, image.ID)
+	require.NoError(t, err)
+	assert.Nil(t, found.PurchasePrice)
+	assert.Empty(t, found.PurchaseDate)
+	assert.Equal(t, "読み取れない", found.Text)
+
+	// 読み取り直した結果で置き換える
+	require.NoError(t, scans.Save(ctx, entity.NewReceiptScan(image, "ロレックス銀座店\n2023年1月15日\n合計 ¥1,500,000", "JPY", now.Add(time.Hour))))
+	found, err = scans.FindByImageID(ctx, watch.ID, image.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &entity.Money{Amount: 1500000, Currency: "JPY"}, found.PurchasePrice)
+	assert.Equal(t, "2023-01-15", found.PurchaseDate)
+	assert.Equal(t, "ロレックス銀座店", found.StoreName)
+	assert.True(t, found.ScannedAt.Equal(now.Add(time.Hour)))
+
+	// 他のアイテムの写真としては見つからない
+	_, err = scans.FindByImageID(ctx, watch.ID+1, image.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrReceiptNotFound)
+
+	// 写真を削除すると読み取り結果も削除される
+	require.NoError(t, images.Delete(ctx, watch.ID, image.ID))
+	_, err = scans.FindByImageID(ctx, watch.ID, image.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrReceiptNotFound)
+}
+
+func TestSaleRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	handler := newSQLiteHandler(t)
+	items := &database.ItemRepository{SqlHandler: handler}
+	sales := &database.SaleRepository{SqlHandler: handler}
+	now := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+
+	watch := createItem(t, items, "ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), "2023-01-15")
+	bag := createItem(t, items, "バーキン", "バッグ", "HERMÈS", entity.JPY(2000000), "2023-02-01")
+	ring := createItem(t, items, "ラブリング", "ジュエリー", "Cartier", entity.JPY(200000), "2021-03-10")
+
+	for _, s := range []struct {
+		item      *entity.Item
+		salePrice entity.Money
+		saleDate  string
+	}{
+		{watch, entity.JPY(1900000), "2025-12-20"},
+		{bag, entity.JPY(2500000), "2026-08-10"},
+		{ring, entity.Money{Amount: 100000, Currency: "USD"}, "2026-08-31"}, // 為替レートなし
+	} {
+		sale, err := entity.NewSale(s.item, s.salePrice, entity.MustParseDate(s.saleDate), nil, now)
+		require.NoError(t, err)
+		created, err := sales.Create(ctx, sale)
+		require.NoError(t, err)
+		assert.NotZero(t, created.ID)
+		require.NoError(t, items.SetSold(ctx, s.item.ID, time.Now()))
+	}
+
+	// 売却は1つのアイテムにつき1件
+	sale, err := entity.NewSale(watch, entity.JPY(1), entity.MustParseDate("2026-01-01"), nil, now)
+	require.NoError(t, err)
+	_, err = sales.Create(ctx, sale)
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+
+	found, err := items.FindByID(ctx, watch.ID)
+	require.NoError(t, err)
+	assert.True(t, found.Sold)
+
+	monthly, err := sales.GetProfitLossTotals(ctx, entity.ProfitLossMonthly, "2026-01-01", "")
+	require.NoError(t, err)
+	assert.Equal(t, []entity.ProfitLossTotal{
+		{Period: "2026-08", Category: "ジュエリー", Count: 1, Unconverted: 1},
+		{Period: "2026-08", Category: "バッグ", Count: 1, ProceedsJPY: 2500000, CostBasisJPY: 2000000, RealizedGainJPY: 500000},
+	}, monthly)
+
+	yearly, err := sales.GetProfitLossTotals(ctx, entity.ProfitLossYearly, "", "")
+	require.NoError(t, err)
+	require.Len(t, yearly, 3)
+	assert.Equal(t, entity.ProfitLossTotal{Period: "2025", Category: "時計", Count: 1, ProceedsJPY: 1900000, CostBasisJPY: 1500000, RealizedGainJPY: 400000}, yearly[0])
+
+	// アイテムを完全削除しても売却の記録は残る
+	require.NoError(t, items.Purge(ctx, watch.ID))
+	yearly, err = sales.GetProfitLossTotals(ctx, entity.ProfitLossYearly, "2025-01-01", "2026-01-01")
+	require.NoError(t, err)
+	assert.Len(t, yearly, 1)
+}
+
+func TestInsurancePolicyRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	handler := newSQLiteHandler(t)
+	items := &database.ItemRepository{SqlHandler: handler}
+	policies := &database.InsurancePolicyRepository{SqlHandler: handler}
+	now := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+
+	watch := createItem(t, items, "ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), "2023-01-15")
+	bag := createItem(t, items, "バーキン", "バッグ", "HERMÈS", entity.JPY(2000000), "2023-02-01")
+
+	create := func(item *entity.Item, policyNumber string, coverage entity.Money, expiresOn string) *entity.InsurancePolicy {
+		policy, err := entity.NewInsurancePolicy(item.ID, policyNumber, "東京海上", coverage, entity.MustParseDate(expiresOn), now)
+		require.NoError(t, err)
+		created, err := policies.Create(ctx, policy)
+		require.NoError(t, err)
+		return created
+	}
+	later := create(watch, "W-2", entity.JPY(1500000), "2027-09-30")
+	soon := create(watch, "W-1", entity.JPY(1000000), "2026-10-20")
+	bagPolicy := create(bag, "B-1", entity.JPY(2000000), "2026-10-10")
+
+	assert.Equal(t, entity.MustParseDate("2026-10-20"), soon.ExpiresOn)
+
+	// 同じ保険会社の証券番号は1つのアイテムにつき1件
+	duplicate, err := entity.NewInsurancePolicy(watch.ID, "W-1", "東京海上", entity.JPY(1), entity.MustParseDate("2026-12-31"), now)
+	require.NoError(t, err)
+	_, err = policies.Create(ctx, duplicate)
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+
+	found, err := policies.FindByItemID(ctx, watch.ID)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, soon.ID, found[0].ID)
+	assert.Equal(t, later.ID, found[1].ID)
+
+	expiring, err := policies.FindExpiring(ctx, entity.MustParseDate("2026-10-01"), entity.MustParseDate("2026-10-31"))
+	require.NoError(t, err)
+	require.Len(t, expiring, 2)
+	assert.Equal(t, bagPolicy.ID, expiring[0].ID)
+	assert.Equal(t, "バーキン", expiring[0].ItemName)
+	assert.Equal(t, soon.ID, expiring[1].ID)
+	assert.Equal(t, "時計", expiring[1].Category)
+
+	require.NoError(t, soon.Update("W-1", "東京海上", entity.JPY(1200000), entity.MustParseDate("2027-10-20"), now))
+	updated, err := policies.Update(ctx, soon)
+	require.NoError(t, err)
+	assert.Equal(t, entity.JPY(1200000), updated.Coverage)
+	assert.Equal(t, entity.MustParseDate("2027-10-20"), updated.ExpiresOn)
+
+	// 削除済みのアイテムの契約は満期が近くても含めない
+	require.NoError(t, items.Delete(ctx, bag.ID, time.Now()))
+	expiring, err = policies.FindExpiring(ctx, entity.MustParseDate("2026-10-01"), entity.MustParseDate("2026-10-31"))
+	require.NoError(t, err)
+	assert.Empty(t, expiring)
+
+	require.NoError(t, policies.Delete(ctx, later.ID))
+	assert.ErrorIs(t, policies.Delete(ctx, later.ID), domainErrors.ErrInsurancePolicyNotFound)
+	_, err = policies.FindByID(ctx, later.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrInsurancePolicyNotFound)
+}
+
+func TestValuationRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	handler := newSQLiteHandler(t)
+	items := &database.ItemRepository{SqlHandler: handler}
+	valuations := &database.ValuationRepository{SqlHandler: handler}
+	now := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+
+	watch := createItem(t, items, "ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), "2023-01-15")
+
+	create := func(valuatedAt string, value entity.Money) *entity.Valuation {
+		valuation, err := entity.NewValuation(watch.ID, entity.MustParseDate(valuatedAt), value, "鑑定", now)
+		require.NoError(t, err)
+		created, err := valuations.Create(ctx, valuation)
+		require.NoError(t, err)
+		return created
+	}
+	latest := create("2026-09-30", entity.JPY(1800000))
+	create("2024-06-01", entity.JPY(1600000))
+
+	assert.Equal(t, entity.MustParseDate("2026-09-30"), latest.ValuatedAt)
+
+	// 評価日の古い順
+	found, err := valuations.FindByItemID(ctx, watch.ID)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, entity.MustParseDate("2024-06-01"), found[0].ValuatedAt)
+	assert.Equal(t, entity.MustParseDate("2026-09-30"), found[1].ValuatedAt)
+
+	byItem, err := valuations.FindLatestByItemIDs(ctx, []int64{watch.ID})
+	require.NoError(t, err)
+	require.Contains(t, byItem, watch.ID)
+	assert.Equal(t, latest.ID, byItem[watch.ID].ID)
+}
+
+func TestItemDependentsRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	handler := newSQLiteHandler(t)
+	items := &database.ItemRepository{SqlHandler: handler}
+	policies := &database.InsurancePolicyRepository{SqlHandler: handler}
+	dependents := &database.ItemDependentsRepository{SqlHandler: handler}
+	now := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+
+	// 保険の契約のみが紐づくアイテム
+	watch := createItem(t, items, "ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), "2023-01-15")
+	bag := createItem(t, items, "バーキン", "バッグ", "HERMÈS", entity.JPY(2000000), "2023-02-01")
+	policy, err := entity.NewInsurancePolicy(watch.ID, "W-1", "東京海上", entity.JPY(1000000), entity.MustParseDate("2027-09-30"), now)
+	require.NoError(t, err)
+	_, err = policies.Create(ctx, policy)
+	require.NoError(t, err)
+
+	counts, err := dependents.CountByItemID(ctx, watch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ItemDependents{InsurancePolicies: 1}, *counts)
+	assert.Equal(t, 1, counts.Total())
+
+	// 紐づくデータのない削除済みのアイテムは対象外
+	require.NoError(t, items.Delete(ctx, watch.ID, time.Now()))
+	require.NoError(t, items.Delete(ctx, bag.ID, time.Now()))
+	orphaned, err := dependents.FindOrphanedItemIDs(ctx, time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{watch.ID}, orphaned)
+
+	keys, err := dependents.DeleteByItemID(ctx, watch.ID)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	counts, err = dependents.CountByItemID(ctx, watch.ID)
+	require.NoError(t, err)
+	assert.Zero(t, counts.Total())
+	orphaned, err = dependents.FindOrphanedItemIDs(ctx, time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, orphaned)
+}
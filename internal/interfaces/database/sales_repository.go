@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type SaleRepository struct {
+	SqlHandler
+}
+
+func (r *SaleRepository) Create(ctx context.Context, sale *entity.Sale) (*entity.Sale, error) {
+	query := `
+        INSERT INTO sales (item_id, category, sale_price, currency, fees, sale_date, exchange_rate, cost_basis, cost_currency, cost_basis_jpy, proceeds_jpy, realized_gain_jpy, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		sale.ItemID,
+		sale.Category,
+		sale.SalePrice.Amount,
+		sale.SalePrice.Currency,
+		sale.Fees.Amount,
+		sale.SaleDate.String(),
+		nullableExchangeRate(sale.ExchangeRate),
+		sale.CostBasis.Amount,
+		sale.CostBasis.Currency,
+		nullableJPY(sale.CostBasisJPY),
+		nullableJPY(sale.ProceedsJPY),
+		nullableJPY(sale.RealizedGainJPY),
+		sale.CreatedAt,
+	)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	created := *sale
+	created.ID = id
+	return &created, nil
+}
+
+func (r *SaleRepository) GetProfitLossTotals(ctx context.Context, period entity.ProfitLossPeriod, from, to string) ([]entity.ProfitLossTotal, error) {
+	var conditions []string
+	var args []interface{}
+	if from != "" {
+		conditions = append(conditions, "sale_date >= ?")
+		args = append(args, from)
+	}
+	if to != "" {
+		conditions = append(conditions, "sale_date < ?")
+		args = append(args, to)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// 実現損益を円換算できない売却は件数のみ数える
+	query := `
+        SELECT ` + r.periodOf("sale_date", period) + ` as period, category, COUNT(*) as count,
+               SUM(CASE WHEN realized_gain_jpy IS NULL THEN 1 ELSE 0 END) as unconverted,
+               COALESCE(SUM(CASE WHEN realized_gain_jpy IS NOT NULL THEN proceeds_jpy END), 0) as proceeds_jpy,
+               COALESCE(SUM(CASE WHEN realized_gain_jpy IS NOT NULL THEN cost_basis_jpy END), 0) as cost_basis_jpy,
+               COALESCE(SUM(realized_gain_jpy), 0) as realized_gain_jpy
+        FROM sales
+        ` + where + `
+        GROUP BY period, category
+        ORDER BY period, category
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var totals []entity.ProfitLossTotal
+	for rows.Next() {
+		var total entity.ProfitLossTotal
+		if err := rows.Scan(&total.Period, &total.Category, &total.Count, &total.Unconverted, &total.ProceedsJPY, &total.CostBasisJPY, &total.RealizedGainJPY); err != nil {
+			return nil, classifyError(err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return totals, nil
+}
+
+// 日付の列から集計期間（年月 YYYY-MM または年 YYYY）を取り出す式
+func (r *SaleRepository) periodOf(column string, period entity.ProfitLossPeriod) string {
+	format := "%Y-%m"
+	if period == entity.ProfitLossYearly {
+		format = "%Y"
+	}
+	if r.Dialect() == SQLite {
+		return "strftime('" + format + "', " + column + ")"
+	}
+	return "DATE_FORMAT(" + column + ", '" + format + "')"
+}
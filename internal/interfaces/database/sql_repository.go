@@ -2,11 +2,23 @@ package database
 
 import "context"
 
+// SQLの方言（方言ごとに書き分けが必要なクエリで使う）
+type Dialect string
+
+const (
+	MySQL  Dialect = "mysql"
+	SQLite Dialect = "sqlite" // ローカル開発・テスト用
+)
+
 type SqlHandler interface {
 	Execute(ctx context.Context, statement string, args ...interface{}) (Result, error)
 	Query(ctx context.Context, statement string, args ...interface{}) (Rows, error)
 	QueryRow(ctx context.Context, statement string, args ...interface{}) Row
+	// fnをトランザクション内で実行する。fnに渡されるコンテキストを使った操作はすべて同じトランザクションで実行され、
+	// fnがエラーを返した場合はロールバックされる。既にトランザクション内の場合はそのトランザクションを使用する。
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 	Close() error
+	Dialect() Dialect
 }
 
 type Result interface {
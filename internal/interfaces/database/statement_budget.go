@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// リクエストあたりのSQL実行回数が上限を超えた場合のエラー
+var ErrStatementBudgetExceeded = errors.New("statement budget exceeded")
+
+// リクエスト単位のSQL実行回数のカウンター
+type StatementBudget struct {
+	mu    sync.Mutex
+	limit int
+	block bool
+	count int
+}
+
+type statementBudgetKey struct{}
+
+// コンテキストにSQL実行回数の上限を設定する。block が true の場合、上限を超えた実行はエラーにする
+func WithStatementBudget(ctx context.Context, limit int, block bool) (context.Context, *StatementBudget) {
+	budget := &StatementBudget{limit: limit, block: block}
+	return context.WithValue(ctx, statementBudgetKey{}, budget), budget
+}
+
+// コンテキストに設定されたカウンターを返す（未設定の場合は nil）
+func StatementBudgetFromContext(ctx context.Context) *StatementBudget {
+	budget, _ := ctx.Value(statementBudgetKey{}).(*StatementBudget)
+	return budget
+}
+
+// これまでの実行回数
+func (b *StatementBudget) Count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.count
+}
+
+// 上限
+func (b *StatementBudget) Limit() int {
+	return b.limit
+}
+
+// 上限を超えたかどうか
+func (b *StatementBudget) Exceeded() bool {
+	return b.Count() > b.limit
+}
+
+// 実行回数を数え、ブロックする場合は上限超過のエラーを返す
+func (b *StatementBudget) use() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.count++
+	if b.block && b.count > b.limit {
+		return fmt.Errorf("%w: %d statements (limit %d)", ErrStatementBudgetExceeded, b.count, b.limit)
+	}
+	return nil
+}
+
+// コンテキストのカウンターでSQLの実行回数を数える SqlHandler
+// カウンターが設定されていないコンテキスト（バッチ処理など）はそのまま実行する
+type budgetedSqlHandler struct {
+	SqlHandler
+}
+
+func NewBudgetedSqlHandler(handler SqlHandler) SqlHandler {
+	return &budgetedSqlHandler{SqlHandler: handler}
+}
+
+func (h *budgetedSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	if err := countStatement(ctx); err != nil {
+		return nil, err
+	}
+	return h.SqlHandler.Execute(ctx, statement, args...)
+}
+
+func (h *budgetedSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	if err := countStatement(ctx); err != nil {
+		return nil, err
+	}
+	return h.SqlHandler.Query(ctx, statement, args...)
+}
+
+func (h *budgetedSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	if err := countStatement(ctx); err != nil {
+		return errRow{err: err}
+	}
+	return h.SqlHandler.QueryRow(ctx, statement, args...)
+}
+
+func countStatement(ctx context.Context) error {
+	if budget := StatementBudgetFromContext(ctx); budget != nil {
+		return budget.use()
+	}
+	return nil
+}
+
+// Scan でエラーを返す Row
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(dest ...interface{}) error {
+	return r.err
+}
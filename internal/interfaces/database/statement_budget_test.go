@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingSqlHandler struct {
+	SqlHandler
+	executed int
+}
+
+func (h *countingSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	h.executed++
+	return nil, nil
+}
+
+func (h *countingSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	h.executed++
+	return errRow{}
+}
+
+func TestBudgetedSqlHandler(t *testing.T) {
+	t.Run("正常系: 上限を超えてもログモードでは実行する", func(t *testing.T) {
+		inner := &countingSqlHandler{}
+		handler := NewBudgetedSqlHandler(inner)
+		ctx, budget := WithStatementBudget(context.Background(), 1, false)
+
+		_, err := handler.Execute(ctx, "UPDATE items SET name = ?", "a")
+		assert.NoError(t, err)
+		assert.NoError(t, handler.QueryRow(ctx, "SELECT 1").Scan())
+
+		assert.Equal(t, 2, inner.executed)
+		assert.Equal(t, 2, budget.Count())
+		assert.True(t, budget.Exceeded())
+	})
+
+	t.Run("異常系: ブロックモードでは上限を超えた実行をエラーにする", func(t *testing.T) {
+		inner := &countingSqlHandler{}
+		handler := NewBudgetedSqlHandler(inner)
+		ctx, _ := WithStatementBudget(context.Background(), 1, true)
+
+		_, err := handler.Execute(ctx, "UPDATE items SET name = ?", "a")
+		assert.NoError(t, err)
+		_, err = handler.Execute(ctx, "UPDATE items SET name = ?", "b")
+		assert.ErrorIs(t, err, ErrStatementBudgetExceeded)
+		assert.ErrorIs(t, handler.QueryRow(ctx, "SELECT 1").Scan(), ErrStatementBudgetExceeded)
+
+		assert.Equal(t, 1, inner.executed)
+	})
+
+	t.Run("正常系: カウンターのないコンテキストは数えない", func(t *testing.T) {
+		inner := &countingSqlHandler{}
+		handler := NewBudgetedSqlHandler(inner)
+
+		for i := 0; i < 3; i++ {
+			_, err := handler.Execute(context.Background(), "DELETE FROM upload_sessions")
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 3, inner.executed)
+	})
+}
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type TagRepository struct {
+	SqlHandler
+}
+
+func (r *TagRepository) FindNamesByItemIDs(ctx context.Context, itemIDs []int64) (map[int64][]string, error) {
+	names := make(map[int64][]string, len(itemIDs))
+	if len(itemIDs) == 0 {
+		return names, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(itemIDs)), ", ")
+	args := make([]interface{}, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		args = append(args, id)
+	}
+
+	query := `
+        SELECT it.item_id, t.name
+        FROM item_tags it
+        JOIN tags t ON t.id = it.tag_id
+        WHERE it.item_id IN (` + placeholders + `)
+        ORDER BY it.item_id, t.name
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var itemID int64
+		var name string
+		if err := rows.Scan(&itemID, &name); err != nil {
+			return nil, classifyError(err)
+		}
+		names[itemID] = append(names[itemID], name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return names, nil
+}
+
+func (r *TagRepository) AddToItem(ctx context.Context, itemID int64, tag *entity.Tag) error {
+	err := r.Transaction(ctx, func(ctx context.Context) error {
+		if r.Dialect() == SQLite {
+			return r.addToItemSQLite(ctx, itemID, tag)
+		}
+
+		// 既存のタグの場合も LAST_INSERT_ID で既存のIDを取得できるようにする
+		result, err := r.Execute(ctx, `
+            INSERT INTO tags (name) VALUES (?)
+            ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id)
+        `, tag.Name)
+		if err != nil {
+			return err
+		}
+
+		tagID, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		if _, err := r.Execute(ctx, `INSERT IGNORE INTO item_tags (item_id, tag_id) VALUES (?, ?)`, itemID, tagID); err != nil {
+			return err
+		}
+		return r.touchItem(ctx, itemID)
+	})
+	if err != nil {
+		if domainErrors.IsDatabaseError(err) {
+			return err
+		}
+		return classifyError(err)
+	}
+
+	return nil
+}
+
+// SQLite では既存のタグのIDを LAST_INSERT_ID で取得できないため、登録後に名前で取得する
+func (r *TagRepository) addToItemSQLite(ctx context.Context, itemID int64, tag *entity.Tag) error {
+	if _, err := r.Execute(ctx, `INSERT INTO tags (name) VALUES (?) ON CONFLICT (name) DO NOTHING`, tag.Name); err != nil {
+		return err
+	}
+
+	var tagID int64
+	if err := r.QueryRow(ctx, `SELECT id FROM tags WHERE name = ?`, tag.Name).Scan(&tagID); err != nil {
+		return err
+	}
+
+	if _, err := r.Execute(ctx, `INSERT OR IGNORE INTO item_tags (item_id, tag_id) VALUES (?, ?)`, itemID, tagID); err != nil {
+		return err
+	}
+	return r.touchItem(ctx, itemID)
+}
+
+func (r *TagRepository) RemoveFromItem(ctx context.Context, itemID int64, name string) error {
+	query := `
+        DELETE FROM item_tags
+        WHERE item_id = ? AND tag_id IN (SELECT id FROM tags WHERE name = ?)
+    `
+
+	err := r.Transaction(ctx, func(ctx context.Context) error {
+		result, err := r.Execute(ctx, query, itemID, name)
+		if err != nil {
+			return classifyError(err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		if rowsAffected == 0 {
+			return domainErrors.ErrItemNotFound
+		}
+
+		return r.touchItem(ctx, itemID)
+	})
+	if err == nil || domainErrors.IsDatabaseError(err) || domainErrors.IsNotFoundError(err) {
+		return err
+	}
+	return classifyError(err)
+}
+
+// タグの変更をアイテムの変更として、アイテムの変更の通し番号を進める
+func (r *TagRepository) touchItem(ctx context.Context, itemID int64) error {
+	seq, err := nextItemChangeSeq(ctx, r.SqlHandler)
+	if err != nil {
+		return err
+	}
+	if _, err := r.Execute(ctx, `UPDATE items SET change_seq = ? WHERE id = ?`, seq, itemID); err != nil {
+		return classifyError(err)
+	}
+	return nil
+}
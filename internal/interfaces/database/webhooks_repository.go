@@ -0,0 +1,298 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type WebhookRepository struct {
+	SqlHandler
+}
+
+const webhookColumns = `id, url, events, secret, owner, created_at`
+
+func (r *WebhookRepository) FindAll(ctx context.Context) ([]*entity.Webhook, error) {
+	query := `
+        SELECT ` + webhookColumns + `
+        FROM webhooks
+        ORDER BY id
+    `
+	return r.findWebhooks(ctx, query)
+}
+
+func (r *WebhookRepository) FindByOwner(ctx context.Context, owner string) ([]*entity.Webhook, error) {
+	query := `
+        SELECT ` + webhookColumns + `
+        FROM webhooks
+        WHERE owner = ?
+        ORDER BY id
+    `
+	return r.findWebhooks(ctx, query, owner)
+}
+
+func (r *WebhookRepository) findWebhooks(ctx context.Context, query string, args ...interface{}) ([]*entity.Webhook, error) {
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var webhooks []*entity.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) FindByID(ctx context.Context, id int64) (*entity.Webhook, error) {
+	query := `
+        SELECT ` + webhookColumns + `
+        FROM webhooks
+        WHERE id = ?
+    `
+
+	webhook, err := scanWebhook(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrWebhookNotFound
+		}
+		return nil, classifyError(err)
+	}
+
+	return webhook, nil
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	query := `
+        INSERT INTO webhooks (url, events, secret, owner, created_at)
+        VALUES (?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		webhook.URL,
+		joinWebhookEvents(webhook.Events),
+		webhook.Secret,
+		webhook.Owner,
+		webhook.CreatedAt,
+	)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	created := *webhook
+	created.ID = id
+	return &created, nil
+}
+
+// 送信先と送信ログを削除する（SQLite は外部キーの ON DELETE CASCADE を有効にしていない場合があるため明示的に削除する）
+func (r *WebhookRepository) Delete(ctx context.Context, id int64) error {
+	if _, err := r.Execute(ctx, `DELETE FROM webhook_deliveries WHERE webhook_id = ?`, id); err != nil {
+		return classifyError(err)
+	}
+
+	result, err := r.Execute(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		return classifyError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func scanWebhook(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Webhook, error) {
+	var webhook entity.Webhook
+	var events string
+
+	err := scanner.Scan(
+		&webhook.ID,
+		&webhook.URL,
+		&events,
+		&webhook.Secret,
+		&webhook.Owner,
+		&webhook.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Events = splitWebhookEvents(events)
+
+	return &webhook, nil
+}
+
+// イベントはカンマ区切りで保存する
+func joinWebhookEvents(events []entity.WebhookEvent) string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = string(event)
+	}
+	return strings.Join(names, ",")
+}
+
+func splitWebhookEvents(events string) []entity.WebhookEvent {
+	var result []entity.WebhookEvent
+	for _, name := range strings.Split(events, ",") {
+		if name != "" {
+			result = append(result, entity.WebhookEvent(name))
+		}
+	}
+	return result
+}
+
+type WebhookDeliveryRepository struct {
+	SqlHandler
+}
+
+const webhookDeliveryColumns = `id, webhook_id, event, payload, status, attempts, response_status, last_error, next_attempt_at, created_at, updated_at`
+
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	query := `
+        INSERT INTO webhook_deliveries (webhook_id, event, payload, status, attempts, response_status, last_error, next_attempt_at, created_at, updated_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		delivery.WebhookID,
+		string(delivery.Event),
+		string(delivery.Payload),
+		string(delivery.Status),
+		delivery.Attempts,
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt,
+		delivery.UpdatedAt,
+	)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	created := *delivery
+	created.ID = id
+	return &created, nil
+}
+
+// 送信の結果（状況・送信回数・最後の応答・次の送信日時）を更新する
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	query := `
+        UPDATE webhook_deliveries
+        SET status = ?, attempts = ?, response_status = ?, last_error = ?, next_attempt_at = ?, updated_at = ?
+        WHERE id = ?
+    `
+
+	_, err := r.Execute(ctx, query,
+		string(delivery.Status),
+		delivery.Attempts,
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.UpdatedAt,
+		delivery.ID,
+	)
+	if err != nil {
+		return classifyError(err)
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	query := `
+        SELECT ` + webhookDeliveryColumns + `
+        FROM webhook_deliveries
+        WHERE status = ? AND next_attempt_at <= ?
+        ORDER BY next_attempt_at, id
+        LIMIT ?
+    `
+	return r.findDeliveries(ctx, query, string(entity.WebhookDeliveryPending), now, limit)
+}
+
+func (r *WebhookDeliveryRepository) FindByWebhookID(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error) {
+	query := `
+        SELECT ` + webhookDeliveryColumns + `
+        FROM webhook_deliveries
+        WHERE webhook_id = ?
+        ORDER BY id DESC
+        LIMIT ?
+    `
+	return r.findDeliveries(ctx, query, webhookID, limit)
+}
+
+func (r *WebhookDeliveryRepository) findDeliveries(ctx context.Context, query string, args ...interface{}) ([]*entity.WebhookDelivery, error) {
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+
+	var deliveries []*entity.WebhookDelivery
+	for rows.Next() {
+		var delivery entity.WebhookDelivery
+		var event, status string
+		var payload []byte
+		var nextAttemptAt sql.NullTime
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&event,
+			&payload,
+			&status,
+			&delivery.Attempts,
+			&delivery.ResponseStatus,
+			&delivery.LastError,
+			&nextAttemptAt,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+		)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		delivery.Event = entity.WebhookEvent(event)
+		delivery.Status = entity.WebhookDeliveryStatus(status)
+		delivery.Payload = payload
+		if nextAttemptAt.Valid {
+			delivery.NextAttemptAt = &nextAttemptAt.Time
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return deliveries, nil
+}
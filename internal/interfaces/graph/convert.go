@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/graph/model"
+	"Aicon-assignment/internal/usecase"
+)
+
+// GraphQL の引数を一覧取得の入力に変換する（未指定の項目はユースケースの既定値を使う）
+func toListItemsInput(limit, offset *int, sort, order *string, filter *model.ItemFilter) usecase.ListItemsInput {
+	input := usecase.ListItemsInput{
+		Limit:  valueOf(limit),
+		Offset: valueOf(offset),
+		Sort:   valueOf(sort),
+		Order:  valueOf(order),
+	}
+	if filter != nil {
+		input.Category = valueOf(filter.Category)
+		input.Tag = valueOf(filter.Tag)
+		input.Uncategorized = valueOf(filter.Uncategorized)
+	}
+	return input
+}
+
+func toCreateItemInput(input model.CreateItemInput) usecase.CreateItemInput {
+	return usecase.CreateItemInput{
+		Name:          input.Name,
+		Category:      valueOf(input.Category),
+		Brand:         input.Brand,
+		PurchasePrice: toMoney(input.PurchasePrice),
+		PurchaseDate:  input.PurchaseDate,
+		Attributes:    input.Attributes,
+
+		PurchaseCountry: valueOf(input.PurchaseCountry),
+
+		TaxAmount:   toInt64(input.TaxAmount),
+		TaxIncluded: valueOf(input.TaxIncluded),
+		TaxRate:     valueOf(input.TaxRate),
+	}
+}
+
+func toUpdateItemInput(input model.UpdateItemInput) usecase.UpdateItemInput {
+	result := usecase.UpdateItemInput{
+		Name:         input.Name,
+		Category:     input.Category,
+		PurchaseDate: input.PurchaseDate,
+		Attributes:   input.Attributes,
+		TaxIncluded:  input.TaxIncluded,
+		TaxRate:      input.TaxRate,
+	}
+	if input.Brand != nil {
+		result.Brand = usecase.NewNullableString(*input.Brand)
+	}
+	if input.PurchaseCountry != nil {
+		result.PurchaseCountry = usecase.NewNullableString(*input.PurchaseCountry)
+	}
+	if input.TaxAmount != nil {
+		result.TaxAmount = usecase.NewNullableInt64(int64(*input.TaxAmount))
+	}
+	if input.PurchasePrice != nil {
+		money := toMoney(input.PurchasePrice)
+		result.PurchasePrice = &money
+	}
+	return result
+}
+
+// 通貨の省略時の扱い（基準通貨とみなす）はエンティティ側で正規化される
+func toMoney(input *model.MoneyInput) entity.Money {
+	if input == nil {
+		return entity.Money{}
+	}
+	return entity.Money{Amount: int64(input.Amount), Currency: valueOf(input.Currency)}
+}
+
+func toInt64(p *int) *int64 {
+	if p == nil {
+		return nil
+	}
+	v := int64(*p)
+	return &v
+}
+
+func valueOf[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// カテゴリー固有の属性（GraphQL の Attributes スカラー）を文字列のキーと値のオブジェクトとして読み書きする
+
+func MarshalAttributes(attributes map[string]string) graphql.Marshaler {
+	return graphql.WriterFunc(func(w io.Writer) {
+		if attributes == nil {
+			_, _ = io.WriteString(w, "null")
+			return
+		}
+		b, err := json.Marshal(attributes)
+		if err != nil {
+			_, _ = io.WriteString(w, "null")
+			return
+		}
+		_, _ = w.Write(b)
+	})
+}
+
+func UnmarshalAttributes(v interface{}) (map[string]string, error) {
+	values, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("attributes must be an object")
+	}
+
+	attributes := make(map[string]string, len(values))
+	for key, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("attribute %s must be a string", key)
+		}
+		attributes[key] = s
+	}
+	return attributes, nil
+}
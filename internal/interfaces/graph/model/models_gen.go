@@ -0,0 +1,46 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package model
+
+type CreateItemInput struct {
+	Name            string            `json:"name"`
+	Category        *string           `json:"category,omitempty"`
+	Brand           string            `json:"brand"`
+	PurchasePrice   *MoneyInput       `json:"purchasePrice"`
+	PurchaseDate    string            `json:"purchaseDate"`
+	Attributes      map[string]string `json:"attributes,omitempty"`
+	PurchaseCountry *string           `json:"purchaseCountry,omitempty"`
+	TaxAmount       *int              `json:"taxAmount,omitempty"`
+	TaxIncluded     *bool             `json:"taxIncluded,omitempty"`
+	TaxRate         *string           `json:"taxRate,omitempty"`
+}
+
+type ItemFilter struct {
+	Category      *string `json:"category,omitempty"`
+	Tag           *string `json:"tag,omitempty"`
+	Uncategorized *bool   `json:"uncategorized,omitempty"`
+}
+
+type MoneyInput struct {
+	Amount   int     `json:"amount"`
+	Currency *string `json:"currency,omitempty"`
+}
+
+type Mutation struct {
+}
+
+type Query struct {
+}
+
+type UpdateItemInput struct {
+	Name            *string           `json:"name,omitempty"`
+	Brand           *string           `json:"brand,omitempty"`
+	Category        *string           `json:"category,omitempty"`
+	PurchasePrice   *MoneyInput       `json:"purchasePrice,omitempty"`
+	PurchaseDate    *string           `json:"purchaseDate,omitempty"`
+	Attributes      map[string]string `json:"attributes,omitempty"`
+	PurchaseCountry *string           `json:"purchaseCountry,omitempty"`
+	TaxAmount       *int              `json:"taxAmount,omitempty"`
+	TaxIncluded     *bool             `json:"taxIncluded,omitempty"`
+	TaxRate         *string           `json:"taxRate,omitempty"`
+}
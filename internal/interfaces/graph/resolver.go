@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/presenter"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+//go:generate go run github.com/99designs/gqlgen generate
+
+// GraphQL のリゾルバーが使うユースケース（REST API のハンドラーと共有する）
+type Resolver struct {
+	itemUsecase      usecase.ItemUsecase
+	imageUsecase     usecase.ImageUsecase
+	valuationUsecase usecase.ValuationUsecase
+}
+
+// GraphQL エンドポイントのハンドラー（GET・POST のクエリを受け付ける）
+func NewHandler(itemUsecase usecase.ItemUsecase, imageUsecase usecase.ImageUsecase, valuationUsecase usecase.ValuationUsecase) http.Handler {
+	srv := handler.New(NewExecutableSchema(Config{Resolvers: &Resolver{
+		itemUsecase:      itemUsecase,
+		imageUsecase:     imageUsecase,
+		valuationUsecase: valuationUsecase,
+	}}))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.SetErrorPresenter(presentError)
+	return srv
+}
+
+// ドメインエラーを extensions.code 付きの GraphQL エラーに変換する（内部エラーの詳細は返さない）
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) && gqlErr.Err == nil {
+		// クエリの構文・型のエラーなど gqlgen 自身が返すエラー
+		return gqlErr
+	}
+
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+	// REST API と同じエラーコードを返す
+	code := presenter.CodeOf(err)
+	if code == presenter.CodeInternal {
+		presented.Message = "internal server error"
+	}
+	if presented.Extensions == nil {
+		presented.Extensions = map[string]interface{}{}
+	}
+	presented.Extensions["code"] = string(code)
+
+	var fieldErrs entity.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		presented.Extensions["fields"] = fieldErrs
+	}
+	return presented
+}
@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// テストで使うメソッドだけを実装したユースケース
+type stubItemUsecase struct {
+	usecase.ItemUsecase
+	items   map[int64]*entity.Item
+	created usecase.CreateItemInput
+	updated usecase.UpdateItemInput
+}
+
+func (s *stubItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	item, ok := s.items[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: id %d", domainErrors.ErrItemNotFound, id)
+	}
+	return item, nil
+}
+
+func (s *stubItemUsecase) CreateItem(ctx context.Context, input usecase.CreateItemInput) (*usecase.ItemResult, error) {
+	s.created = input
+	if input.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", domainErrors.ErrInvalidInput)
+	}
+	return &usecase.ItemResult{Item: &entity.Item{ID: 10, Name: input.Name, PurchasePrice: input.PurchasePrice}}, nil
+}
+
+func (s *stubItemUsecase) UpdateItem(ctx context.Context, id int64, input usecase.UpdateItemInput) (*usecase.ItemResult, error) {
+	s.updated = input
+	return nil, fmt.Errorf("%w: connection refused", domainErrors.ErrDatabaseError)
+}
+
+type stubImageUsecase struct {
+	usecase.ImageUsecase
+}
+
+func (stubImageUsecase) GetImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	return []*entity.ItemImage{{ID: 1, ItemID: itemID}}, nil
+}
+
+type stubValuationUsecase struct {
+	usecase.ValuationUsecase
+}
+
+func (stubValuationUsecase) GetValuations(ctx context.Context, itemID int64) ([]*entity.Valuation, error) {
+	return []*entity.Valuation{{ID: 2, ItemID: itemID, Value: entity.JPY(900000)}}, nil
+}
+
+type graphqlResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func execute(t *testing.T, h http.Handler, query string) graphqlResponse {
+	t.Helper()
+	body, err := json.Marshal(map[string]string{"query": query})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var res graphqlResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return res
+}
+
+func TestHandler_Item(t *testing.T) {
+	items := &stubItemUsecase{items: map[int64]*entity.Item{
+		1: {ID: 1, Name: "ロレックス デイトナ", PurchasePrice: entity.JPY(1500000)},
+	}}
+	h := NewHandler(items, stubImageUsecase{}, stubValuationUsecase{})
+
+	t.Run("正常系: 写真と評価額をあわせて取得できる", func(t *testing.T) {
+		res := execute(t, h, `{ item(id: 1) { id name purchasePrice { amount currency } images { id } valuations { id value { amount } } } }`)
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, `{
+			"id": 1,
+			"name": "ロレックス デイトナ",
+			"purchasePrice": {"amount": 1500000, "currency": "JPY"},
+			"images": [{"id": 1}],
+			"valuations": [{"id": 2, "value": {"amount": 900000}}]
+		}`, string(res.Data["item"]))
+	})
+
+	t.Run("正常系: 存在しないアイテムは null", func(t *testing.T) {
+		res := execute(t, h, `{ item(id: 999) { id } }`)
+		require.Empty(t, res.Errors)
+		assert.Equal(t, "null", string(res.Data["item"]))
+	})
+}
+
+func TestHandler_Mutations(t *testing.T) {
+	items := &stubItemUsecase{}
+	h := NewHandler(items, stubImageUsecase{}, stubValuationUsecase{})
+
+	t.Run("正常系: 通貨を省略して登録できる", func(t *testing.T) {
+		res := execute(t, h, `mutation { createItem(input: {name: "時計", brand: "ROLEX", purchasePrice: {amount: 1000}, purchaseDate: "2023-01-15"}) { id name } }`)
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, `{"id": 10, "name": "時計"}`, string(res.Data["createItem"]))
+		assert.Equal(t, entity.Money{Amount: 1000}, items.created.PurchasePrice)
+	})
+
+	t.Run("異常系: 入力エラーは VALIDATION_FAILED", func(t *testing.T) {
+		res := execute(t, h, `mutation { createItem(input: {name: "", brand: "ROLEX", purchasePrice: {amount: 1000}, purchaseDate: "2023-01-15"}) { id } }`)
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, "VALIDATION_FAILED", res.Errors[0].Extensions["code"])
+	})
+
+	t.Run("異常系: 内部エラーの詳細は返さない", func(t *testing.T) {
+		res := execute(t, h, `mutation { updateItem(id: 1, input: {brand: "OMEGA"}) { id } }`)
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, "INTERNAL_ERROR", res.Errors[0].Extensions["code"])
+		assert.Equal(t, "internal server error", res.Errors[0].Message)
+		assert.Equal(t, usecase.NewNullableString("OMEGA"), items.updated.Brand)
+	})
+}
@@ -0,0 +1,81 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/graph/model"
+	"Aicon-assignment/internal/usecase"
+	"context"
+)
+
+// Images is the resolver for the images field.
+func (r *itemResolver) Images(ctx context.Context, obj *entity.Item) ([]*entity.ItemImage, error) {
+	return r.imageUsecase.GetImages(ctx, obj.ID)
+}
+
+// Valuations is the resolver for the valuations field.
+func (r *itemResolver) Valuations(ctx context.Context, obj *entity.Item) ([]*entity.Valuation, error) {
+	return r.valuationUsecase.GetValuations(ctx, obj.ID)
+}
+
+// CreateItem is the resolver for the createItem field.
+func (r *mutationResolver) CreateItem(ctx context.Context, input model.CreateItemInput) (*entity.Item, error) {
+	result, err := r.itemUsecase.CreateItem(ctx, toCreateItemInput(input))
+	if err != nil {
+		return nil, err
+	}
+	return result.Item, nil
+}
+
+// UpdateItem is the resolver for the updateItem field.
+func (r *mutationResolver) UpdateItem(ctx context.Context, id int64, input model.UpdateItemInput) (*entity.Item, error) {
+	result, err := r.itemUsecase.UpdateItem(ctx, id, toUpdateItemInput(input))
+	if err != nil {
+		return nil, err
+	}
+	return result.Item, nil
+}
+
+// DeleteItem is the resolver for the deleteItem field.
+func (r *mutationResolver) DeleteItem(ctx context.Context, id int64) (bool, error) {
+	if err := r.itemUsecase.DeleteItem(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Items is the resolver for the items field.
+func (r *queryResolver) Items(ctx context.Context, limit *int, offset *int, sort *string, order *string, filter *model.ItemFilter) (*usecase.ItemList, error) {
+	return r.itemUsecase.GetAllItems(ctx, toListItemsInput(limit, offset, sort, order, filter))
+}
+
+// SearchItems is the resolver for the searchItems field.
+func (r *queryResolver) SearchItems(ctx context.Context, q string, limit *int, offset *int, filter *model.ItemFilter) (*usecase.ItemList, error) {
+	return r.itemUsecase.SearchItems(ctx, q, toListItemsInput(limit, offset, nil, nil, filter))
+}
+
+// Item is the resolver for the item field.
+func (r *queryResolver) Item(ctx context.Context, id int64) (*entity.Item, error) {
+	item, err := r.itemUsecase.GetItemByID(ctx, id)
+	if domainErrors.IsNotFoundError(err) {
+		return nil, nil
+	}
+	return item, err
+}
+
+// Item returns ItemResolver implementation.
+func (r *Resolver) Item() ItemResolver { return &itemResolver{r} }
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type itemResolver struct{ *Resolver }
+type mutationResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// 操作者を指定するヘッダー名
+const ActorHeader = "X-User-ID"
+
+// 監査ログに記録する操作者の最大文字数
+const maxActorLength = 100
+
+// リクエストの操作者をコンテキストに設定するミドルウェア
+// ヘッダーがない場合は anonymous として記録する
+func Actor() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			actor := strings.TrimSpace(c.Request().Header.Get(ActorHeader))
+			if actor == "" {
+				return next(c)
+			}
+			if utf8.RuneCountInString(actor) > maxActorLength {
+				actor = string([]rune(actor)[:maxActorLength])
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(usecase.WithActor(req.Context(), actor)))
+			return next(c)
+		}
+	}
+}
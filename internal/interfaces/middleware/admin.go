@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 管理者用トークンのヘッダー名
+const AdminTokenHeader = "X-Admin-Token"
+
+// 管理者用エンドポイントを共有トークンで保護するミドルウェア
+// トークンが未設定の場合、管理者用エンドポイントは無効になる
+func AdminToken(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if token == "" {
+				return presenter.ErrorJSON(c, presenter.CodeForbidden, "admin API is disabled")
+			}
+
+			given := c.Request().Header.Get(AdminTokenHeader)
+			if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				return presenter.ErrorJSON(c, presenter.CodeUnauthorized, "invalid admin token")
+			}
+
+			return next(c)
+		}
+	}
+}
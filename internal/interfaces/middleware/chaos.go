@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+
+	"github.com/labstack/echo/v4"
+)
+
+// フォールトインジェクションの設定（各Rateは0.0〜1.0の確率）
+type ChaosConfig struct {
+	LatencyRate float64
+	Latency     time.Duration
+	ErrorRate   float64
+	ErrorStatus int
+	DropRate    float64
+
+	// 対象外とするパス（ヘルスチェックなど）
+	SkipPaths []string
+
+	// 乱数生成関数（テスト用に差し替え可能。未指定なら math/rand を使用）
+	Random func() float64
+}
+
+// 遅延・エラー・接続切断を設定された確率で発生させるミドルウェア
+// クライアントのリトライやタイムアウトの挙動をステージング環境で検証するために使用する
+func Chaos(config ChaosConfig) echo.MiddlewareFunc {
+	random := config.Random
+	if random == nil {
+		var mu sync.Mutex
+		rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+		random = func() float64 {
+			mu.Lock()
+			defer mu.Unlock()
+			return rnd.Float64()
+		}
+	}
+
+	errorStatus := config.ErrorStatus
+	if errorStatus == 0 {
+		errorStatus = http.StatusServiceUnavailable
+	}
+
+	skip := make(map[string]bool, len(config.SkipPaths))
+	for _, path := range config.SkipPaths {
+		skip[path] = true
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skip[c.Path()] {
+				return next(c)
+			}
+
+			// 接続切断
+			if config.DropRate > 0 && random() < config.DropRate {
+				conn, _, err := c.Response().Hijack()
+				if err == nil {
+					return conn.Close()
+				}
+			}
+
+			// 遅延
+			if config.LatencyRate > 0 && random() < config.LatencyRate {
+				select {
+				case <-time.After(config.Latency):
+				case <-c.Request().Context().Done():
+					return c.Request().Context().Err()
+				}
+			}
+
+			// エラー応答
+			if config.ErrorRate > 0 && random() < config.ErrorRate {
+				return c.JSON(errorStatus, presenter.ErrorResponse{
+					Code:    presenter.CodeOfStatus(errorStatus),
+					Message: "injected fault",
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// 条件付きリクエストのヘッダー名
+const (
+	ETagHeader        = "ETag"
+	IfNoneMatchHeader = "If-None-Match"
+)
+
+// キャッシュしたレスポンスで返すヘッダー
+var cachedResponseHeaders = []string{echo.HeaderContentType, SurrogateKeyHeader, CacheTagHeader}
+
+// アイテムの取得（GET /items/:id）のレスポンスに内容から求めた ETag を付け、
+// If-None-Match が一致する場合は本文を返さずに 304 を返す
+// cache を指定した場合はアイテムの版ごとにレスポンスを保存し、変更がなければハンドラーを呼ばずに返す
+// クエリパラメーター付きの取得（as_of など）は対象外
+func ItemETag(cache *usecase.ItemResponseCache) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+			if err != nil || c.Request().Method != http.MethodGet || c.QueryString() != "" {
+				return next(c)
+			}
+			ctx := c.Request().Context()
+
+			// キャッシュの障害時は毎回ハンドラーを呼ぶ
+			var version int64
+			cacheable := false
+			if cache != nil {
+				current, cached, err := cache.Get(ctx, id)
+				if err == nil {
+					if cached != nil {
+						return writeCachedResponse(c, cached)
+					}
+					version, cacheable = current, true
+				}
+			}
+
+			// ハンドラーのレスポンスを保持し、送信前の状態に戻してから送り直す
+			response := c.Response()
+			recorder := &responseRecorder{ResponseWriter: response.Writer}
+			response.Writer = recorder
+			err = next(c)
+			response.Writer = recorder.ResponseWriter
+			response.Committed, response.Size = false, 0
+			if err != nil || recorder.status == 0 {
+				return err
+			}
+
+			body := recorder.body.Bytes()
+			if recorder.status != http.StatusOK {
+				response.WriteHeader(recorder.status)
+				_, err := response.Write(body)
+				return err
+			}
+
+			result := &usecase.CachedResponse{ETag: computeETag(body), Header: map[string]string{}, Body: body}
+			for _, name := range cachedResponseHeaders {
+				if value := response.Header().Get(name); value != "" {
+					result.Header[name] = value
+				}
+			}
+			if cacheable {
+				_ = cache.Set(ctx, id, version, result)
+			}
+			return writeCachedResponse(c, result)
+		}
+	}
+}
+
+// 保存したレスポンスを返す（If-None-Match が一致する場合は 304）
+func writeCachedResponse(c echo.Context, cached *usecase.CachedResponse) error {
+	header := c.Response().Header()
+	for name, value := range cached.Header {
+		header.Set(name, value)
+	}
+	header.Set(ETagHeader, cached.ETag)
+
+	if etagMatches(c.Request().Header.Get(IfNoneMatchHeader), cached.ETag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.Blob(http.StatusOK, cached.Header[echo.HeaderContentType], cached.Body)
+}
+
+// 本文のハッシュから求める強い ETag
+func computeETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// If-None-Match のいずれかの ETag と一致するか（弱い比較のため W/ は無視する）
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ハンドラーのレスポンスを送らずに保持する
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(b)
+}
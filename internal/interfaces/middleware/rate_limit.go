@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/interfaces/presenter"
+
+	"github.com/labstack/echo/v4"
+)
+
+// クライアントIPごとの固定ウィンドウのカウンター
+type rateWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+// クライアントIPごとに window あたり limit 回までリクエストを許可するミドルウェア
+// 超過した場合は 429 と Retry-After を返す（カウンターはプロセス内のみで共有する簡易的な制限）
+func RateLimit(limit int, window time.Duration) echo.MiddlewareFunc {
+	var mu sync.Mutex
+	windows := make(map[string]*rateWindow)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			now := time.Now()
+			ip := c.RealIP()
+
+			mu.Lock()
+			// 期限切れのカウンターを掃除してメモリ使用量を抑える
+			if len(windows) > 10000 {
+				for key, w := range windows {
+					if now.After(w.resetAt) {
+						delete(windows, key)
+					}
+				}
+			}
+
+			w, ok := windows[ip]
+			if !ok || now.After(w.resetAt) {
+				w = &rateWindow{resetAt: now.Add(window)}
+				windows[ip] = w
+			}
+			w.count++
+			count, resetAt := w.count, w.resetAt
+			mu.Unlock()
+
+			if count > limit {
+				retryAfter := int(math.Ceil(resetAt.Sub(now).Seconds()))
+				c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				return presenter.ErrorJSON(c, presenter.CodeRateLimited, "")
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// リクエストごとにアイテムキャッシュを用意するミドルウェア
+func RequestCache() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(usecase.WithItemCache(req.Context())))
+			return next(c)
+		}
+	}
+}
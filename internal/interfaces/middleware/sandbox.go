@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// サンドボックスのレスポンスであることを示すヘッダー名
+const SandboxHeader = "X-Sandbox"
+
+// 連携先が本番と取り違えないよう、すべてのレスポンスにサンドボックスであることを示すヘッダーを付ける
+func Sandbox() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Set(SandboxHeader, "true")
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// リクエストごとのSQL実行回数を数え、上限を超えたリクエストをログに出すミドルウェア
+// block が true の場合、上限を超えた実行はエラーになる（N+1 の検出用）
+func StatementBudget(limit int, block bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx, budget := database.WithStatementBudget(req.Context(), limit, block)
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+			if budget.Exceeded() {
+				fmt.Printf("⚠️  %s %s executed %d statements (budget %d)\n", req.Method, c.Path(), budget.Count(), limit)
+			}
+			return err
+		}
+	}
+}
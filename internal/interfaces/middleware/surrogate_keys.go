@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// サロゲートキーのヘッダー名（Fastly はスペース区切り、Cloudflare はカンマ区切り）
+const (
+	SurrogateKeyHeader = "Surrogate-Key"
+	CacheTagHeader     = "Cache-Tag"
+)
+
+// CDNが変更時にキャッシュを削除できるよう、レスポンスにサロゲートキーを付ける
+func SetSurrogateKeys(c echo.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	header := c.Response().Header()
+	header.Set(SurrogateKeyHeader, strings.Join(keys, " "))
+	header.Set(CacheTagHeader, strings.Join(keys, ","))
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// 操作者ごとのリクエスト数・エラー数を集計するミドルウェア（Actor の後に登録する）
+func Usage(tracker *usecase.UsageTracker) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			tracker.Record(usecase.ActorFromContext(c.Request().Context()), responseStatus(c, err))
+			return err
+		}
+	}
+}
+
+// レスポンスのステータス。ハンドラーがレスポンスを返さずにエラーを返した場合は、エラーハンドラーが返すステータスとする
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/presenter"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// トークンバケットの保存先（プロセス内 / Redis）
+type RateLimitStore interface {
+	// key のバケットからトークンを1つ取り出す（容量は limit 個で、per ごとに limit 個を補充する）
+	// 取り出せない場合は false と、次のトークンが補充されるまでの時間を返す
+	Take(ctx context.Context, key string, limit int, per time.Duration) (bool, time.Duration, error)
+}
+
+// 保存先の呼び出しにタイムアウトとサーキットブレーカーを設ける（Redis の障害中は応答を待たずに制限せずに通す）
+func ResilientRateLimitStore(store RateLimitStore, breaker *usecase.CircuitBreaker) RateLimitStore {
+	return &resilientRateLimitStore{store: store, breaker: breaker}
+}
+
+type resilientRateLimitStore struct {
+	store   RateLimitStore
+	breaker *usecase.CircuitBreaker
+}
+
+func (s *resilientRateLimitStore) Take(ctx context.Context, key string, limit int, per time.Duration) (bool, time.Duration, error) {
+	var ok bool
+	var wait time.Duration
+	err := s.breaker.Do(ctx, func(ctx context.Context) error {
+		var err error
+		ok, wait, err = s.store.Take(ctx, key, limit, per)
+		return err
+	})
+	return ok, wait, err
+}
+
+type WriteRateLimitConfig struct {
+	Store RateLimitStore
+
+	// per あたりのリクエスト数の上限（0 の場合はその単位では制限しない）
+	PerIP   int // クライアントIPごと
+	PerUser int // 操作者（X-User-ID）ごと。ヘッダーのないリクエストはIPごとの上限のみ
+	Per     time.Duration
+
+	// 独自の上限を設けるルート（c.Path() と一致するもの）
+	SkipPaths []string
+}
+
+// 書き込み（GET・HEAD・OPTIONS 以外）のリクエストをトークンバケットで制限するミドルウェア（Actor の後に登録する）
+// 超過した場合は 429 と Retry-After を返す。保存先の障害時は制限せずに通す
+func WriteRateLimit(config WriteRateLimitConfig) echo.MiddlewareFunc {
+	skip := make(map[string]bool, len(config.SkipPaths))
+	for _, path := range config.SkipPaths {
+		skip[path] = true
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return next(c)
+			}
+			if skip[c.Path()] {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+			if config.PerIP > 0 {
+				if wait, limited := takeToken(ctx, config.Store, "ip:"+c.RealIP(), config.PerIP, config.Per); limited {
+					return tooManyRequests(c, wait)
+				}
+			}
+			if actor := usecase.ActorFromContext(ctx); config.PerUser > 0 && actor != usecase.AnonymousActor {
+				if wait, limited := takeToken(ctx, config.Store, "user:"+actor, config.PerUser, config.Per); limited {
+					return tooManyRequests(c, wait)
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func takeToken(ctx context.Context, store RateLimitStore, key string, limit int, per time.Duration) (time.Duration, bool) {
+	ok, wait, err := store.Take(ctx, key, limit, per)
+	if err != nil {
+		// 障害が続いている間（サーキットブレーカーが open の間）はリクエストごとには出さない
+		if !errors.Is(err, domainErrors.ErrCircuitOpen) {
+			fmt.Printf("⚠️  Rate limit store error: %v\n", err)
+		}
+		return 0, false
+	}
+	return wait, !ok
+}
+
+func tooManyRequests(c echo.Context, wait time.Duration) error {
+	retryAfter := int(math.Ceil(wait.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	return presenter.ErrorJSON(c, presenter.CodeRateLimited, "")
+}
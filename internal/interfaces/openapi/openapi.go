@@ -0,0 +1,235 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"github.com/labstack/echo/v4"
+)
+
+// API仕様に記載する操作。リクエスト・レスポンスのスキーマは Go の型から生成する
+type Operation struct {
+	Summary string
+	Tag     string
+	Query   []Parameter
+	Path    []Parameter // パスパラメーターの型・説明（指定がなければ名前から型を判断する）
+
+	Request     interface{} // JSON のリクエストボディの型（nil の場合はボディなし）
+	RequestType string      // JSON 以外のリクエストボディのメディアタイプ（multipart/form-data など）
+
+	Status       int         // 成功時のステータス（0 の場合は 200）
+	Response     interface{} // 成功時の JSON のレスポンスボディの型（nil の場合はボディなし）
+	ResponseType string      // JSON 以外のレスポンスボディのメディアタイプ（text/csv など）
+
+	Errors []int // エラー時のステータス（ボディは Document.Error の型）
+}
+
+// クエリパラメーター
+type Parameter struct {
+	Name        string
+	Description string
+	Type        string // string（省略時）, integer, boolean
+	Required    bool
+}
+
+// ドキュメント全体の情報
+type Document struct {
+	Title   string
+	Version string
+	Error   interface{} // エラーレスポンスの型
+
+	// 構造体から生成できない型（独自の JSON 形式の型）のスキーマ
+	Types map[reflect.Type]*openapi3.Schema
+}
+
+// ルーターに登録されたルートと操作の定義から OpenAPI 3 のドキュメントを作る
+// 定義のないルートは操作の情報なしで記載し、登録されていないルートの定義はエラーにする
+func Build(doc Document, routes []*echo.Route, operations map[string]Operation) (*openapi3.T, error) {
+	spec := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info:    &openapi3.Info{Title: doc.Title, Version: doc.Version},
+		Paths:   openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{},
+		},
+	}
+	generator := openapi3gen.NewGenerator(openapi3gen.SchemaCustomizer(func(name string, t reflect.Type, tag reflect.StructTag, schema *openapi3.Schema) error {
+		if override, ok := doc.Types[t]; ok {
+			*schema = *override
+		}
+		return nil
+	}))
+
+	var errorSchema *openapi3.SchemaRef
+	if doc.Error != nil {
+		ref, err := generator.NewSchemaRefForValue(doc.Error, spec.Components.Schemas)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate error schema: %w", err)
+		}
+		errorSchema = ref
+	}
+
+	documented := make(map[string]bool, len(operations))
+	for _, route := range sortedRoutes(routes) {
+		key := route.Method + " " + route.Path
+		op := operations[key]
+		documented[key] = true
+
+		operation, err := newOperation(generator, spec.Components.Schemas, route, op, errorSchema)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		spec.AddOperation(pathTemplate(route.Path), route.Method, operation)
+	}
+
+	for key := range operations {
+		if !documented[key] {
+			return nil, fmt.Errorf("operation %q is not registered in the router", key)
+		}
+	}
+
+	return spec, nil
+}
+
+// ドキュメントに記載するルート（メソッド・パス順）。ワイルドカードのルートや echo 内部のルートは除く
+func sortedRoutes(routes []*echo.Route) []*echo.Route {
+	var sorted []*echo.Route
+	seen := make(map[string]bool)
+	for _, route := range routes {
+		if !isDocumentedMethod(route.Method) || strings.Contains(route.Path, "*") {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		sorted = append(sorted, route)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+	return sorted
+}
+
+func isDocumentedMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func newOperation(generator *openapi3gen.Generator, schemas openapi3.Schemas, route *echo.Route, op Operation, errorSchema *openapi3.SchemaRef) (*openapi3.Operation, error) {
+	operation := openapi3.NewOperation()
+	operation.Summary = op.Summary
+	if op.Tag != "" {
+		operation.Tags = []string{op.Tag}
+	}
+
+	for _, name := range pathParams(route.Path) {
+		param := openapi3.NewPathParameter(name).WithSchema(paramSchema(pathParamType(name)))
+		for _, path := range op.Path {
+			if path.Name == name {
+				param.Schema = openapi3.NewSchemaRef("", paramSchema(path.Type))
+				param.Description = path.Description
+			}
+		}
+		operation.AddParameter(param)
+	}
+	for _, query := range op.Query {
+		param := openapi3.NewQueryParameter(query.Name).WithSchema(paramSchema(query.Type)).WithRequired(query.Required)
+		param.Description = query.Description
+		operation.AddParameter(param)
+	}
+
+	switch {
+	case op.Request != nil:
+		ref, err := generator.NewSchemaRefForValue(op.Request, schemas)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate request schema: %w", err)
+		}
+		operation.RequestBody = &openapi3.RequestBodyRef{
+			Value: openapi3.NewRequestBody().WithRequired(true).WithJSONSchemaRef(ref),
+		}
+	case op.RequestType != "":
+		operation.RequestBody = &openapi3.RequestBodyRef{
+			Value: openapi3.NewRequestBody().WithRequired(true).WithContent(openapi3.NewContentWithSchema(openapi3.NewStringSchema().WithFormat("binary"), []string{op.RequestType})),
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	response := openapi3.NewResponse().WithDescription(http.StatusText(status))
+	switch {
+	case op.Response != nil:
+		ref, err := generator.NewSchemaRefForValue(op.Response, schemas)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate response schema: %w", err)
+		}
+		response.WithJSONSchemaRef(ref)
+	case op.ResponseType != "":
+		response.WithContent(openapi3.NewContentWithSchema(openapi3.NewStringSchema().WithFormat("binary"), []string{op.ResponseType}))
+	}
+	operation.AddResponse(status, response)
+
+	for _, code := range op.Errors {
+		errorResponse := openapi3.NewResponse().WithDescription(http.StatusText(code))
+		if errorSchema != nil {
+			errorResponse.WithJSONSchemaRef(errorSchema)
+		}
+		operation.AddResponse(code, errorResponse)
+	}
+
+	return operation, nil
+}
+
+// echo のパス（/items/:id）を OpenAPI のパステンプレート（/items/{id}）にする
+func pathTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func pathParams(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") {
+			names = append(names, segment[1:])
+		}
+	}
+	return names
+}
+
+// ID のパスパラメーター（id, imageId）は整数、それ以外は文字列
+func pathParamType(name string) string {
+	if name == "id" || strings.HasSuffix(name, "Id") {
+		return "integer"
+	}
+	return "string"
+}
+
+func paramSchema(paramType string) *openapi3.Schema {
+	switch paramType {
+	case "integer":
+		return openapi3.NewInt64Schema()
+	case "boolean":
+		return openapi3.NewBoolSchema()
+	}
+	return openapi3.NewStringSchema()
+}
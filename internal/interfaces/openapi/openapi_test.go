@@ -0,0 +1,69 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRequest struct {
+	Name   string `json:"name"`
+	Secret string `json:"-"`
+}
+
+type testError struct {
+	Error string `json:"error"`
+}
+
+func TestBuild(t *testing.T) {
+	e := echo.New()
+	noop := func(c echo.Context) error { return nil }
+	e.POST("/items", noop)
+	e.GET("/items/:id/images/:imageId", noop)
+	e.DELETE("/budgets/:category", noop)
+	e.GET("/uploads/:uploadId", noop)
+	e.Static("/images", "images")
+
+	t.Run("正常系: ルートと型からドキュメントを作る", func(t *testing.T) {
+		spec, err := Build(Document{Title: "test", Version: "dev", Error: testError{}}, e.Routes(), map[string]Operation{
+			"POST /items":            {Summary: "登録", Request: testRequest{}, Status: http.StatusCreated, Response: testRequest{}, Errors: []int{http.StatusBadRequest}},
+			"GET /uploads/:uploadId": {Summary: "取得", Path: []Parameter{{Name: "uploadId", Type: "string", Description: "ランダムなID"}}},
+		})
+		require.NoError(t, err)
+		require.NoError(t, spec.Validate(context.Background()))
+		assert.Len(t, spec.Paths.Map(), 4)
+
+		create := spec.Paths.Find("/items").Post
+		require.NotNil(t, create)
+		request := create.RequestBody.Value.Content.Get("application/json").Schema.Value
+		assert.Contains(t, request.Properties, "name")
+		assert.NotContains(t, request.Properties, "Secret")
+		assert.NotNil(t, create.Responses.Status(http.StatusCreated))
+		errorSchema := create.Responses.Status(http.StatusBadRequest).Value.Content.Get("application/json").Schema.Value
+		assert.Contains(t, errorSchema.Properties, "error")
+
+		// 定義のないルートもパスパラメーター付きで記載する
+		images := spec.Paths.Find("/items/{id}/images/{imageId}").Get
+		require.NotNil(t, images)
+		require.Len(t, images.Parameters, 2)
+		assert.True(t, images.Parameters[1].Value.Schema.Value.Type.Is("integer"))
+		category := spec.Paths.Find("/budgets/{category}").Delete
+		assert.True(t, category.Parameters[0].Value.Schema.Value.Type.Is("string"))
+
+		// 名前から判断した型は Path で上書きできる
+		upload := spec.Paths.Find("/uploads/{uploadId}").Get
+		assert.True(t, upload.Parameters[0].Value.Schema.Value.Type.Is("string"))
+		assert.Equal(t, "ランダムなID", upload.Parameters[0].Value.Description)
+	})
+
+	t.Run("異常系: 登録されていないルートの定義", func(t *testing.T) {
+		_, err := Build(Document{Title: "test", Version: "dev"}, e.Routes(), map[string]Operation{
+			"GET /unknown": {Summary: "未登録"},
+		})
+		assert.EqualError(t, err, `operation "GET /unknown" is not registered in the router`)
+	})
+}
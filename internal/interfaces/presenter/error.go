@@ -0,0 +1,215 @@
+package presenter
+
+import (
+	"errors"
+	"net/http"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// クライアントが判別に使うエラーコード
+type ErrorCode string
+
+const (
+	CodeInvalidRequest      ErrorCode = "INVALID_REQUEST"   // リクエストの形式・パラメーターが不正
+	CodeValidationFailed    ErrorCode = "VALIDATION_FAILED" // 入力値のバリデーションエラー
+	CodeInvalidCategory     ErrorCode = "INVALID_CATEGORY"  // カテゴリーが定義済みのものでない
+	CodeUnauthorized        ErrorCode = "UNAUTHORIZED"
+	CodeForbidden           ErrorCode = "FORBIDDEN"
+	CodeItemNotFound        ErrorCode = "ITEM_NOT_FOUND"
+	CodeNotFound            ErrorCode = "NOT_FOUND" // アイテム以外（変更履歴・アップロード・Webhook・ジョブなど）やルートがない
+	CodeMethodNotAllowed    ErrorCode = "METHOD_NOT_ALLOWED"
+	CodePayloadTooLarge     ErrorCode = "PAYLOAD_TOO_LARGE"
+	CodeConflict            ErrorCode = "CONFLICT" // 重複・紐づくデータがある・アップロード位置の不一致
+	CodeBudgetExceeded      ErrorCode = "BUDGET_EXCEEDED"
+	CodeQuotaExceeded       ErrorCode = "QUOTA_EXCEEDED"
+	CodeItemOnHold          ErrorCode = "ITEM_ON_HOLD"
+	CodeRateLimited         ErrorCode = "RATE_LIMITED"
+	CodeInternal            ErrorCode = "INTERNAL_ERROR" // 詳細は返さない
+	CodeUpstreamUnavailable ErrorCode = "UPSTREAM_UNAVAILABLE"
+	CodeReadOnly            ErrorCode = "READ_ONLY"
+	CodeServiceUnavailable  ErrorCode = "SERVICE_UNAVAILABLE"
+	CodeDBUnavailable       ErrorCode = "DB_UNAVAILABLE" // デッドロック・接続断など一時的なデータベースエラー
+)
+
+// エラーコードごとの HTTP ステータスと既定のメッセージ
+var errorCatalog = map[ErrorCode]struct {
+	status  int
+	message string
+}{
+	CodeInvalidRequest:      {http.StatusBadRequest, "invalid request"},
+	CodeValidationFailed:    {http.StatusBadRequest, "validation failed"},
+	CodeInvalidCategory:     {http.StatusBadRequest, "invalid category"},
+	CodeUnauthorized:        {http.StatusUnauthorized, "unauthorized"},
+	CodeForbidden:           {http.StatusForbidden, "forbidden"},
+	CodeItemNotFound:        {http.StatusNotFound, "item not found"},
+	CodeNotFound:            {http.StatusNotFound, "not found"},
+	CodeMethodNotAllowed:    {http.StatusMethodNotAllowed, "method not allowed"},
+	CodePayloadTooLarge:     {http.StatusRequestEntityTooLarge, "payload too large"},
+	CodeConflict:            {http.StatusConflict, "conflict"},
+	CodeBudgetExceeded:      {http.StatusUnprocessableEntity, "category budget exceeded"},
+	CodeQuotaExceeded:       {http.StatusForbidden, "quota exceeded"},
+	CodeItemOnHold:          {http.StatusLocked, "item is on hold"},
+	CodeRateLimited:         {http.StatusTooManyRequests, "too many requests"},
+	CodeInternal:            {http.StatusInternalServerError, "internal server error"},
+	CodeUpstreamUnavailable: {http.StatusBadGateway, "upstream service unavailable"},
+	CodeReadOnly:            {http.StatusServiceUnavailable, "service is in read-only mode"},
+	CodeServiceUnavailable:  {http.StatusServiceUnavailable, "service unavailable"},
+	CodeDBUnavailable:       {http.StatusServiceUnavailable, "database unavailable"},
+}
+
+// エラーコードに対応する HTTP ステータス
+func (code ErrorCode) Status() int {
+	if entry, ok := errorCatalog[code]; ok {
+		return entry.status
+	}
+	return http.StatusInternalServerError
+}
+
+// エラーコードの既定のメッセージ
+func (code ErrorCode) Message() string {
+	return errorCatalog[code].message
+}
+
+// エラーレスポンスの形式（全エンドポイント共通）
+type ErrorResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+	Details []string  `json:"details,omitempty"`
+
+	// 項目ごとのバリデーションエラー（どの項目の何が不正かをクライアントが判別できるようにする）
+	Fields []entity.FieldError `json:"fields,omitempty"`
+}
+
+// ドメインエラーに対応するエラーコード（ドメインエラーとエラーコードの対応はここだけで定義する）
+func CodeOf(err error) ErrorCode {
+	switch {
+	case domainErrors.IsValidationError(err):
+		var fieldErrs entity.ValidationErrors
+		if errors.As(err, &fieldErrs) {
+			for _, fieldErr := range fieldErrs {
+				if fieldErr.Field == "category" {
+					return CodeInvalidCategory
+				}
+			}
+		}
+		return CodeValidationFailed
+	case domainErrors.IsNotFoundError(err):
+		return CodeItemNotFound
+	case domainErrors.IsRevisionNotFoundError(err), domainErrors.IsUploadNotFoundError(err), domainErrors.IsWebhookNotFoundError(err), domainErrors.IsJobNotFoundError(err), domainErrors.IsSuggestionNotFoundError(err), domainErrors.IsReceiptNotFoundError(err), domainErrors.IsInsurancePolicyNotFoundError(err):
+		return CodeNotFound
+	case domainErrors.IsDuplicateItemError(err), domainErrors.IsHasDependentsError(err), domainErrors.IsUploadOffsetMismatchError(err), domainErrors.IsSuggestionOutdatedError(err), domainErrors.IsItemSoldError(err), domainErrors.IsDuplicateInsurancePolicyError(err):
+		return CodeConflict
+	case domainErrors.IsBudgetExceededError(err):
+		return CodeBudgetExceeded
+	case domainErrors.IsQuotaExceededError(err):
+		return CodeQuotaExceeded
+	case domainErrors.IsOnHoldError(err):
+		return CodeItemOnHold
+	case domainErrors.IsReadOnlyError(err):
+		return CodeReadOnly
+	case domainErrors.IsMarketPriceUnavailableError(err), domainErrors.IsOCRUnavailableError(err):
+		return CodeUpstreamUnavailable
+	case domainErrors.IsDatabaseError(err) && domainErrors.IsTransientError(err):
+		return CodeDBUnavailable
+	}
+	return CodeInternal
+}
+
+// エラーコードを指定してエラーレスポンスを返す（message が空の場合は既定のメッセージ）
+func ErrorJSON(c echo.Context, code ErrorCode, message string, details ...string) error {
+	if message == "" {
+		message = code.Message()
+	}
+	return c.JSON(code.Status(), ErrorResponse{
+		Code:    code,
+		Message: message,
+		Details: details,
+	})
+}
+
+// ドメインエラーをエラーレスポンスとして返す
+// 対応するエラーコードがない場合は message で 500 を返し、内部のエラー内容は返さない
+func DomainErrorJSON(c echo.Context, err error, message string) error {
+	code := CodeOf(err)
+	switch code {
+	case CodeInternal:
+		return ErrorJSON(c, code, message)
+	case CodeDBUnavailable:
+		return ErrorJSON(c, code, "")
+	}
+
+	resp := ErrorResponse{Code: code, Message: code.Message()}
+	if detail := err.Error(); detail != resp.Message {
+		resp.Details = []string{detail}
+	}
+	var fieldErrs entity.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		resp.Fields = fieldErrs
+	}
+	return c.JSON(code.Status(), resp)
+}
+
+// 項目ごとのバリデーションエラーのレスポンス（入力の形式チェックで見つかったもの）
+func FieldErrorsJSON(c echo.Context, errs entity.ValidationErrors) error {
+	details := make([]string, len(errs))
+	for i, fieldErr := range errs {
+		details[i] = fieldErr.Error()
+	}
+	return c.JSON(CodeValidationFailed.Status(), ErrorResponse{
+		Code:    CodeValidationFailed,
+		Message: CodeValidationFailed.Message(),
+		Details: details,
+		Fields:  errs,
+	})
+}
+
+// ハンドラー・ミドルウェアが返した echo.HTTPError（ルートがない、ボディが大きすぎるなど）も共通の形式で返す
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	var httpErr *echo.HTTPError
+	if !errors.As(err, &httpErr) {
+		_ = DomainErrorJSON(c, err, "")
+		return
+	}
+
+	code := CodeOfStatus(httpErr.Code)
+	message := code.Message()
+	if msg, ok := httpErr.Message.(string); ok && httpErr.Code < http.StatusInternalServerError {
+		message = msg
+	}
+	if c.Request().Method == http.MethodHead {
+		_ = c.NoContent(httpErr.Code)
+		return
+	}
+	_ = c.JSON(httpErr.Code, ErrorResponse{Code: code, Message: message})
+}
+
+// HTTP ステータスに対応するエラーコード（echo.HTTPError など、ドメインエラーでないもの）
+func CodeOfStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnsupportedMediaType:
+		return CodeInvalidRequest
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	}
+	return CodeInternal
+}
@@ -0,0 +1,106 @@
+package presenter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{"入力エラー", fmt.Errorf("%w: name is required", domainErrors.ErrInvalidInput), CodeValidationFailed},
+		{"カテゴリーの入力エラー", fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, entity.ValidationErrors{{Field: "category", Reason: "is required"}}), CodeInvalidCategory},
+		{"アイテムがない", domainErrors.ErrItemNotFound, CodeItemNotFound},
+		{"変更履歴がない", domainErrors.ErrRevisionNotFound, CodeNotFound},
+		{"保険の契約がない", domainErrors.ErrInsurancePolicyNotFound, CodeNotFound},
+		{"重複", domainErrors.ErrDuplicateItem, CodeConflict},
+		{"売却済み", domainErrors.ErrItemSold, CodeConflict},
+		{"登録済みの保険の契約", domainErrors.ErrDuplicateInsurancePolicy, CodeConflict},
+		{"保全中", domainErrors.ErrItemOnHold, CodeItemOnHold},
+		{"OCR を利用できない", fmt.Errorf("%w: timeout", domainErrors.ErrOCRUnavailable), CodeUpstreamUnavailable},
+		{"一時的なデータベースエラー", fmt.Errorf("%w: %w: deadlock", domainErrors.ErrDatabaseError, domainErrors.ErrTransient), CodeDBUnavailable},
+		{"その他のデータベースエラー", fmt.Errorf("%w: syntax error", domainErrors.ErrDatabaseError), CodeInternal},
+		{"未知のエラー", errors.New("boom"), CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CodeOf(tt.err))
+		})
+	}
+}
+
+func TestDomainErrorJSON(t *testing.T) {
+	t.Run("異常系: 項目ごとのエラーを返す", func(t *testing.T) {
+		err := fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, entity.ValidationErrors{{Field: "purchase_price", Reason: "must be 0 or greater"}})
+
+		rec, resp := respond(t, func(c echo.Context) error { return DomainErrorJSON(c, err, "failed to create item") })
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, ErrorResponse{
+			Code:    CodeValidationFailed,
+			Message: "validation failed",
+			Details: []string{"invalid input: purchase_price must be 0 or greater"},
+			Fields:  []entity.FieldError{{Field: "purchase_price", Reason: "must be 0 or greater"}},
+		}, resp)
+	})
+
+	t.Run("異常系: 内部エラーは詳細を返さない", func(t *testing.T) {
+		rec, resp := respond(t, func(c echo.Context) error {
+			return DomainErrorJSON(c, errors.New("dial tcp: secret"), "failed to create item")
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, ErrorResponse{Code: CodeInternal, Message: "failed to create item"}, resp)
+	})
+
+	t.Run("異常系: 一時的なデータベースエラーは 503", func(t *testing.T) {
+		err := fmt.Errorf("%w: %w: connection reset", domainErrors.ErrDatabaseError, domainErrors.ErrTransient)
+
+		rec, resp := respond(t, func(c echo.Context) error { return DomainErrorJSON(c, err, "failed to retrieve items") })
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, ErrorResponse{Code: CodeDBUnavailable, Message: "database unavailable"}, resp)
+	})
+}
+
+func TestHTTPErrorHandler(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+
+	req := httptest.NewRequest(http.MethodGet, "/no-such-route", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, CodeNotFound, resp.Code)
+}
+
+func respond(t *testing.T, handler echo.HandlerFunc) (*httptest.ResponseRecorder, ErrorResponse) {
+	t.Helper()
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	require.NoError(t, handler(c))
+
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return rec, resp
+}
@@ -0,0 +1,108 @@
+package presenter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// レポートの表記に使うロケール
+const (
+	LocaleJaJP = "ja-JP"
+	LocaleEnUS = "en-US"
+)
+
+// ロケールごとの通貨記号（未定義の通貨は通貨コードを前に付ける）
+var currencySymbols = map[string]map[string]string{
+	LocaleJaJP: {"JPY": "￥", "KRW": "₩", "USD": "$", "EUR": "€", "GBP": "£", "CNY": "CN¥", "HKD": "HK$"},
+	LocaleEnUS: {"JPY": "¥", "KRW": "₩", "USD": "$", "EUR": "€", "GBP": "£", "CNY": "CN¥", "HKD": "HK$"},
+}
+
+// ロケールごとの日付・日時の表記
+var (
+	dateLayouts = map[string]string{
+		LocaleJaJP: "2006/01/02",
+		LocaleEnUS: "01/02/2006",
+	}
+	timeLayouts = map[string]string{
+		LocaleJaJP: "2006/01/02 15:04:05",
+		LocaleEnUS: "01/02/2006 3:04:05 PM",
+	}
+)
+
+// 金額・日付をロケールに応じて表記する（CSVなどのレポート出力で共通に使う）
+type Formatter struct {
+	locale string
+}
+
+// ロケールの表記を返す（未対応のロケールはエラー）
+func NewFormatter(locale string) (*Formatter, error) {
+	switch locale {
+	case LocaleJaJP, LocaleEnUS:
+		return &Formatter{locale: locale}, nil
+	default:
+		return nil, fmt.Errorf("locale must be one of: %s, %s", LocaleJaJP, LocaleEnUS)
+	}
+}
+
+func (f *Formatter) Locale() string {
+	return f.locale
+}
+
+// 通貨記号と桁区切りを付けた金額（例: ja-JP で 1500000 JPY → "￥1,500,000"、en-US で 123456 USD → "$1,234.56"）
+func (f *Formatter) FormatMoney(m entity.Money) string {
+	decimal := m.DecimalString()
+	sign := ""
+	if strings.HasPrefix(decimal, "-") {
+		sign = "-"
+		decimal = decimal[1:]
+	}
+
+	integer, fraction, hasFraction := strings.Cut(decimal, ".")
+	formatted := groupThousands(integer)
+	if hasFraction {
+		formatted += "." + fraction
+	}
+
+	symbol, ok := currencySymbols[f.locale][m.Currency]
+	if !ok {
+		symbol = m.Currency + " "
+	}
+	return sign + symbol + formatted
+}
+
+// 日付をロケールの表記にする（未入力の場合は空文字）
+func (f *Formatter) FormatDate(date entity.Date) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Time().Format(dateLayouts[f.locale])
+}
+
+// 日時をロケールの表記にする
+func (f *Formatter) FormatTime(t time.Time) string {
+	return t.Format(timeLayouts[f.locale])
+}
+
+// 3桁ごとにカンマで区切る
+func groupThousands(digits string) string {
+	if _, err := strconv.ParseUint(digits, 10, 64); err != nil || len(digits) <= 3 {
+		return digits
+	}
+
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
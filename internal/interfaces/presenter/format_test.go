@@ -0,0 +1,53 @@
+package presenter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func TestFormatter_FormatMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		locale   string
+		money    entity.Money
+		expected string
+	}{
+		{name: "正常系: ja-JP の円", locale: LocaleJaJP, money: entity.JPY(1500000), expected: "￥1,500,000"},
+		{name: "正常系: en-US の円", locale: LocaleEnUS, money: entity.JPY(1500000), expected: "¥1,500,000"},
+		{name: "正常系: 補助単位のある通貨", locale: LocaleEnUS, money: entity.Money{Amount: 123456789, Currency: "USD"}, expected: "$1,234,567.89"},
+		{name: "正常系: 負の金額", locale: LocaleJaJP, money: entity.Money{Amount: -150, Currency: "EUR"}, expected: "-€1.50"},
+		{name: "正常系: 3桁以下", locale: LocaleJaJP, money: entity.JPY(999), expected: "￥999"},
+		{name: "正常系: 記号のない通貨は通貨コードを付ける", locale: LocaleEnUS, money: entity.Money{Amount: 100000, Currency: "CHF"}, expected: "CHF 1,000.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter, err := NewFormatter(tt.locale)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, formatter.FormatMoney(tt.money))
+		})
+	}
+}
+
+func TestFormatter_FormatDate(t *testing.T) {
+	ja, _ := NewFormatter(LocaleJaJP)
+	en, _ := NewFormatter(LocaleEnUS)
+	at := time.Date(2023, 1, 15, 13, 5, 0, 0, time.UTC)
+
+	assert.Equal(t, "2023/01/15", ja.FormatDate(entity.NewDate(2023, time.January, 15)))
+	assert.Equal(t, "01/15/2023", en.FormatDate(entity.NewDate(2023, time.January, 15)))
+	assert.Equal(t, "2023/01/15 13:05:00", ja.FormatTime(at))
+	assert.Equal(t, "01/15/2023 1:05:00 PM", en.FormatTime(at))
+	// 未入力の日付は空
+	assert.Equal(t, "", ja.FormatDate(entity.Date{}))
+}
+
+func TestNewFormatter(t *testing.T) {
+	_, err := NewFormatter("fr-FR")
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 操作者が不明な場合と、定期実行の処理による操作の場合の操作者
+const (
+	AnonymousActor = "anonymous"
+	SystemActor    = "system"
+)
+
+type actorKey struct{}
+
+// 監査ログに記録する操作者を持つコンテキストを返す
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// コンテキストの操作者（未設定の場合は anonymous）
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+// アイテムの登録・更新・削除を監査ログに記録する
+func WithAuditLogger(logger AuditLogger) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.auditLogger = logger
+	}
+}
+
+// 変更前後の項目を比較して監査ログに記録し、変更イベントを発行する
+// 変更は完了しているため、監査ログの記録に失敗しても操作自体はエラーにしない
+// アウトボックスを使う場合は mutate の中で呼び出し、イベントを記録できなかった場合は変更ごと取り消す
+func (u *itemUsecase) audit(ctx context.Context, itemID int64, action entity.AuditAction, before, after map[string]interface{}) error {
+	now := u.clock.Now()
+	actor := ActorFromContext(ctx)
+
+	if u.auditLogger != nil {
+		entry := entity.NewAuditLog(itemID, action, actor, entity.DiffAuditFields(before, after), now)
+		_ = u.auditLogger.Log(ctx, entry)
+	}
+
+	return u.publish(ctx, ItemEvent{ItemID: itemID, Action: action, Actor: actor, OccurredAt: now})
+}
+
+// アイテムの変更履歴（記録順）
+func (u *itemUsecase) GetItemHistory(ctx context.Context, id int64) ([]*entity.AuditLog, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	logs := []*entity.AuditLog{}
+	if u.auditLogger != nil {
+		found, err := u.auditLogger.FindByItemID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve history: %w", err)
+		}
+		if found != nil {
+			logs = found
+		}
+	}
+
+	// 削除済みのアイテムも履歴は参照できるため、履歴がない場合のみ存在を確認する
+	if len(logs) == 0 {
+		if _, err := u.findItem(ctx, id); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return nil, domainErrors.ErrItemNotFound
+			}
+			return nil, fmt.Errorf("failed to retrieve item: %w", err)
+		}
+	}
+
+	return logs, nil
+}
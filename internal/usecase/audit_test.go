@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockAuditLogger は監査ログのモック
+type MockAuditLogger struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogger) Log(ctx context.Context, entry *entity.AuditLog) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogger) FindByItemID(ctx context.Context, itemID int64) ([]*entity.AuditLog, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AuditLog), args.Error(1)
+}
+
+func TestItemUsecase_Audit(t *testing.T) {
+	t.Run("正常系: 更新時に操作者と変更内容を記録", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		logger := new(MockAuditLogger)
+
+		existing, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+		existing.ID = 1
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+		// 更新後のアイテムは FindByID で返したものを更新したもの
+		mockRepo.On("Update", mock.Anything, existing).Return(existing, nil)
+
+		var logged *entity.AuditLog
+		logger.On("Log", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			logged = args.Get(1).(*entity.AuditLog)
+		}).Return(nil)
+
+		usecase := NewItemUsecase(mockRepo, WithAuditLogger(logger))
+		price := entity.JPY(1200000)
+		_, err := usecase.UpdateItem(WithActor(context.Background(), "user-42"), 1, UpdateItemInput{PurchasePrice: &price})
+
+		require.NoError(t, err)
+		require.NotNil(t, logged)
+		assert.Equal(t, int64(1), logged.ItemID)
+		assert.Equal(t, entity.AuditUpdate, logged.Action)
+		assert.Equal(t, "user-42", logged.Actor)
+		assert.Equal(t, map[string]entity.FieldChange{
+			"purchase_price": {Old: entity.JPY(1000000), New: entity.JPY(1200000)},
+		}, logged.Changes)
+	})
+
+	t.Run("正常系: 削除時は操作者が不明な場合 anonymous として記録", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		logger := new(MockAuditLogger)
+
+		existing := &entity.Item{ID: 2, Name: "バッグ1"}
+		mockRepo.On("FindByID", mock.Anything, int64(2)).Return(existing, nil)
+		mockRepo.On("Delete", mock.Anything, int64(2), mock.Anything).Return(nil)
+		logger.On("Log", mock.Anything, mock.MatchedBy(func(entry *entity.AuditLog) bool {
+			return entry.Action == entity.AuditDelete && entry.Actor == AnonymousActor &&
+				entry.Changes["name"] == entity.FieldChange{Old: "バッグ1", New: nil}
+		})).Return(nil)
+
+		usecase := NewItemUsecase(mockRepo, WithAuditLogger(logger))
+		err := usecase.DeleteItem(context.Background(), 2)
+
+		require.NoError(t, err)
+		logger.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 記録に失敗しても操作は成功", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		logger := new(MockAuditLogger)
+
+		mockRepo.On("FindByID", mock.Anything, int64(2)).Return(&entity.Item{ID: 2}, nil)
+		mockRepo.On("Delete", mock.Anything, int64(2), mock.Anything).Return(nil)
+		logger.On("Log", mock.Anything, mock.Anything).Return(domainErrors.ErrDatabaseError)
+
+		usecase := NewItemUsecase(mockRepo, WithAuditLogger(logger))
+		assert.NoError(t, usecase.DeleteItem(context.Background(), 2))
+	})
+}
+
+func TestItemUsecase_GetItemHistory(t *testing.T) {
+	logs := []*entity.AuditLog{
+		entity.NewAuditLog(1, entity.AuditCreate, "user-1", nil, time.Now()),
+		entity.NewAuditLog(1, entity.AuditDelete, "user-2", nil, time.Now()),
+	}
+
+	tests := []struct {
+		name        string
+		id          int64
+		setupMock   func(*MockItemRepository, *MockAuditLogger)
+		expected    []*entity.AuditLog
+		expectedErr error
+	}{
+		{
+			name: "正常系: 削除済みのアイテムの履歴も取得できる",
+			id:   1,
+			setupMock: func(_ *MockItemRepository, logger *MockAuditLogger) {
+				logger.On("FindByItemID", mock.Anything, int64(1)).Return(logs, nil)
+			},
+			expected: logs,
+		},
+		{
+			name: "正常系: 履歴のないアイテム",
+			id:   3,
+			setupMock: func(itemRepo *MockItemRepository, logger *MockAuditLogger) {
+				logger.On("FindByItemID", mock.Anything, int64(3)).Return(nil, nil)
+				itemRepo.On("FindByID", mock.Anything, int64(3)).Return(&entity.Item{ID: 3}, nil)
+			},
+			expected: []*entity.AuditLog{},
+		},
+		{
+			name: "異常系: 存在しないアイテム",
+			id:   999,
+			setupMock: func(itemRepo *MockItemRepository, logger *MockAuditLogger) {
+				logger.On("FindByItemID", mock.Anything, int64(999)).Return(nil, nil)
+				itemRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			logger := new(MockAuditLogger)
+			tt.setupMock(itemRepo, logger)
+
+			usecase := NewItemUsecase(itemRepo, WithAuditLogger(logger))
+			history, err := usecase.GetItemHistory(context.Background(), tt.id)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, history)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, history)
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 管理者が登録した別名を読み込み直す間隔（他のインスタンスでの変更はこの間隔で反映される）
+const DefaultBrandAliasTTL = time.Minute
+
+// ブランドの表記を揃えるときに一度に読み込むアイテム数
+const BrandNormalizationBatchSize = 500
+
+type BrandAliasUsecase interface {
+	GetBrandAliases(ctx context.Context) ([]*entity.BrandAlias, error)
+	SetBrandAlias(ctx context.Context, alias, brand string) (*entity.BrandAlias, error)
+	DeleteBrandAlias(ctx context.Context, alias string) error
+}
+
+// ブランドの別名の辞書のキャッシュ
+// 登録・更新のたびに別名を読み込まないよう、組み込みの辞書に管理者が登録した別名を重ねた辞書を保持する
+type BrandNormalizer struct {
+	aliasRepo BrandAliasRepository
+	ttl       time.Duration
+	clock     entity.Clock
+
+	mu         sync.Mutex
+	dictionary *entity.BrandDictionary
+	expiresAt  time.Time
+}
+
+func NewBrandNormalizer(aliasRepo BrandAliasRepository, ttl time.Duration) *BrandNormalizer {
+	return &BrandNormalizer{
+		aliasRepo: aliasRepo,
+		ttl:       ttl,
+		clock:     entity.SystemClock,
+	}
+}
+
+// 辞書を返す。期限切れの場合は読み込み直し、失敗した場合は古い辞書（初回は組み込みの辞書のみ）を返す
+// 登録・更新を止めないよう、読み込みの失敗はエラーにしない
+func (n *BrandNormalizer) Dictionary(ctx context.Context) *entity.BrandDictionary {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.dictionary != nil && n.clock.Now().Before(n.expiresAt) {
+		return n.dictionary
+	}
+
+	aliases, err := n.aliasRepo.FindAll(ctx)
+	if err != nil {
+		if n.dictionary != nil {
+			return n.dictionary
+		}
+		return entity.NewBrandDictionary(nil)
+	}
+
+	n.dictionary = entity.NewBrandDictionary(aliases)
+	n.expiresAt = n.clock.Now().Add(n.ttl)
+	return n.dictionary
+}
+
+// ブランドを正式な表記にする
+func (n *BrandNormalizer) Normalize(ctx context.Context, brand string) string {
+	return n.Dictionary(ctx).Normalize(brand)
+}
+
+// 次の呼び出しで辞書を読み込み直す
+func (n *BrandNormalizer) Invalidate() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.expiresAt = time.Time{}
+}
+
+type brandAliasUsecase struct {
+	aliasRepo  BrandAliasRepository
+	normalizer *BrandNormalizer
+	readOnly   *ReadOnlySwitch
+	clock      entity.Clock
+}
+
+func NewBrandAliasUsecase(aliasRepo BrandAliasRepository, normalizer *BrandNormalizer, readOnly *ReadOnlySwitch) BrandAliasUsecase {
+	if readOnly == nil {
+		readOnly = NewReadOnlySwitch(false)
+	}
+	return &brandAliasUsecase{
+		aliasRepo:  aliasRepo,
+		normalizer: normalizer,
+		readOnly:   readOnly,
+		clock:      entity.SystemClock,
+	}
+}
+
+// 管理者が登録した別名と、上書きされていない組み込みの別名（別名の順）
+func (u *brandAliasUsecase) GetBrandAliases(ctx context.Context) ([]*entity.BrandAlias, error) {
+	custom, err := u.aliasRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve brand aliases: %w", err)
+	}
+
+	overridden := make(map[string]bool, len(custom))
+	aliases := make([]*entity.BrandAlias, 0, len(custom))
+	for _, alias := range custom {
+		overridden[alias.Key()] = true
+		aliases = append(aliases, alias)
+	}
+	for _, alias := range entity.BuiltinBrandAliases() {
+		if !overridden[alias.Key()] {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.SliceStable(aliases, func(i, j int) bool { return aliases[i].Alias < aliases[j].Alias })
+
+	return aliases, nil
+}
+
+func (u *brandAliasUsecase) SetBrandAlias(ctx context.Context, alias, brand string) (*entity.BrandAlias, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+
+	brandAlias, err := entity.NewBrandAlias(alias, brand, u.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	saved, err := u.aliasRepo.Save(ctx, brandAlias)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save brand alias: %w", err)
+	}
+	u.invalidate()
+
+	return saved, nil
+}
+
+// 管理者が登録した別名を削除する（組み込みの別名がある場合はその別名に戻る）
+func (u *brandAliasUsecase) DeleteBrandAlias(ctx context.Context, alias string) error {
+	if u.readOnly.Enabled() {
+		return domainErrors.ErrReadOnly
+	}
+
+	key := entity.NormalizeForComparison(alias)
+	if key == "" {
+		return domainErrors.ErrItemNotFound
+	}
+
+	if err := u.aliasRepo.Delete(ctx, key); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemNotFound
+		}
+		return fmt.Errorf("failed to delete brand alias: %w", err)
+	}
+	u.invalidate()
+
+	return nil
+}
+
+func (u *brandAliasUsecase) invalidate() {
+	if u.normalizer != nil {
+		u.normalizer.Invalidate()
+	}
+}
+
+// 登録・更新時にブランドを別名の辞書で正式な表記に揃える
+func WithBrandNormalizer(normalizer *BrandNormalizer) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.brandNormalizer = normalizer
+	}
+}
+
+// ブランドを正式な表記にする（辞書が未指定の場合は入力のまま）
+func (u *itemUsecase) normalizeBrand(ctx context.Context, brand string) string {
+	if u.brandNormalizer == nil {
+		return brand
+	}
+	return u.brandNormalizer.Normalize(ctx, brand)
+}
+
+// 既存のアイテムのブランドを揃えた結果
+type BrandNormalizationResult struct {
+	Checked int `json:"checked"`
+	Updated int `json:"updated"`
+	// 保全中、または現在のルールで検証できないため変更しなかったアイテム数
+	Skipped int `json:"skipped"`
+}
+
+// 登録済みのアイテムのブランドを現在の辞書で正式な表記に揃える（別名を追加したあとの一括修正用）
+// 変更したアイテムは通常の更新と同じく監査ログと版を記録する
+func (u *itemUsecase) NormalizeBrands(ctx context.Context) (*BrandNormalizationResult, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	result := &BrandNormalizationResult{}
+	if u.brandNormalizer == nil {
+		return result, nil
+	}
+	// 途中で別名が変わっても同じ辞書で揃える
+	dictionary := u.brandNormalizer.Dictionary(ctx)
+
+	query := entity.ItemQuery{
+		Limit: BrandNormalizationBatchSize,
+		Sort:  entity.SortByID,
+		Order: entity.SortAsc,
+	}
+	for {
+		items, err := u.itemRepo.FindAll(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve items: %w", err)
+		}
+
+		for _, item := range items {
+			result.Checked++
+			brand := dictionary.Normalize(item.Brand)
+			if brand == item.Brand {
+				continue
+			}
+
+			updated, err := u.updateBrand(ctx, item, brand)
+			if err != nil {
+				return nil, err
+			}
+			if updated {
+				result.Updated++
+			} else {
+				result.Skipped++
+			}
+		}
+
+		if len(items) < BrandNormalizationBatchSize {
+			break
+		}
+		query.AfterID = items[len(items)-1].ID
+	}
+
+	return result, nil
+}
+
+// アイテムのブランドを変更する。保全中・検証エラー・削除済みの場合は変更せず false を返す
+func (u *itemUsecase) updateBrand(ctx context.Context, item *entity.Item, brand string) (bool, error) {
+	if item.OnHold {
+		return false, nil
+	}
+	before := entity.ItemAuditFields(item)
+
+	if err := item.Update(item.Name, item.Category, brand, item.PurchasePrice, item.PurchaseDate, u.clock.Now()); err != nil {
+		return false, nil
+	}
+
+	var updatedItem *entity.Item
+	err := u.mutate(ctx, func(ctx context.Context) error {
+		var err error
+		updatedItem, err = u.itemRepo.Update(ctx, item)
+		if err != nil {
+			return err
+		}
+		return u.audit(ctx, item.ID, entity.AuditUpdate, before, entity.ItemAuditFields(updatedItem))
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			u.evictItem(ctx, item.ID)
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to update item: %w", err)
+	}
+	u.cacheItem(ctx, updatedItem)
+	u.snapshot(ctx, updatedItem)
+	purgeItems(ctx, u.cachePurger, updatedItem)
+
+	return true, nil
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockBrandAliasRepository はブランドの別名のモックリポジトリ
+type MockBrandAliasRepository struct {
+	mock.Mock
+}
+
+func (m *MockBrandAliasRepository) FindAll(ctx context.Context) ([]*entity.BrandAlias, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.BrandAlias), args.Error(1)
+}
+
+func (m *MockBrandAliasRepository) Save(ctx context.Context, alias *entity.BrandAlias) (*entity.BrandAlias, error) {
+	args := m.Called(ctx, alias)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.BrandAlias), args.Error(1)
+}
+
+func (m *MockBrandAliasRepository) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func TestBrandNormalizer(t *testing.T) {
+	t.Run("正常系: 期限内は辞書を読み込み直さない", func(t *testing.T) {
+		aliasRepo := new(MockBrandAliasRepository)
+		aliasRepo.On("FindAll", mock.Anything).Return([]*entity.BrandAlias{{Alias: "ロレ", Brand: "ROLEX"}}, nil).Once()
+		normalizer := NewBrandNormalizer(aliasRepo, time.Minute)
+
+		assert.Equal(t, "ROLEX", normalizer.Normalize(context.Background(), "ロレ"))
+		assert.Equal(t, "HERMÈS", normalizer.Normalize(context.Background(), "エルメス"))
+		aliasRepo.AssertNumberOfCalls(t, "FindAll", 1)
+	})
+
+	t.Run("正常系: Invalidate の後は読み込み直す", func(t *testing.T) {
+		aliasRepo := new(MockBrandAliasRepository)
+		aliasRepo.On("FindAll", mock.Anything).Return([]*entity.BrandAlias{}, nil).Once()
+		aliasRepo.On("FindAll", mock.Anything).Return([]*entity.BrandAlias{{Alias: "ロレ", Brand: "ROLEX"}}, nil).Once()
+		normalizer := NewBrandNormalizer(aliasRepo, time.Minute)
+
+		assert.Equal(t, "ロレ", normalizer.Normalize(context.Background(), "ロレ"))
+		normalizer.Invalidate()
+		assert.Equal(t, "ROLEX", normalizer.Normalize(context.Background(), "ロレ"))
+	})
+
+	t.Run("正常系: 読み込みに失敗した場合は古い辞書を使う", func(t *testing.T) {
+		aliasRepo := new(MockBrandAliasRepository)
+		aliasRepo.On("FindAll", mock.Anything).Return([]*entity.BrandAlias{{Alias: "ロレ", Brand: "ROLEX"}}, nil).Once()
+		aliasRepo.On("FindAll", mock.Anything).Return(nil, errors.New("connection refused"))
+		normalizer := NewBrandNormalizer(aliasRepo, time.Minute)
+
+		assert.Equal(t, "ROLEX", normalizer.Normalize(context.Background(), "ロレ"))
+		normalizer.Invalidate()
+		assert.Equal(t, "ROLEX", normalizer.Normalize(context.Background(), "ロレ"))
+	})
+
+	t.Run("正常系: 初回の読み込みに失敗した場合は組み込みの辞書を使う", func(t *testing.T) {
+		aliasRepo := new(MockBrandAliasRepository)
+		aliasRepo.On("FindAll", mock.Anything).Return(nil, errors.New("connection refused"))
+		normalizer := NewBrandNormalizer(aliasRepo, time.Minute)
+
+		assert.Equal(t, "ROLEX", normalizer.Normalize(context.Background(), "ロレックス"))
+	})
+}
+
+func TestBrandAliasUsecase_GetBrandAliases(t *testing.T) {
+	aliasRepo := new(MockBrandAliasRepository)
+	aliasRepo.On("FindAll", mock.Anything).Return([]*entity.BrandAlias{{Alias: "エルメス", Brand: "Hermès"}}, nil)
+	usecase := NewBrandAliasUsecase(aliasRepo, nil, nil)
+
+	aliases, err := usecase.GetBrandAliases(context.Background())
+	require.NoError(t, err)
+
+	assert.Len(t, aliases, len(entity.BuiltinBrandAliases()))
+	for _, alias := range aliases {
+		if alias.Alias == "エルメス" {
+			assert.Equal(t, "Hermès", alias.Brand)
+			assert.False(t, alias.Builtin)
+		}
+	}
+}
+
+func TestBrandAliasUsecase_SetBrandAlias(t *testing.T) {
+	t.Run("正常系: 登録すると辞書を読み込み直す", func(t *testing.T) {
+		aliasRepo := new(MockBrandAliasRepository)
+		aliasRepo.On("FindAll", mock.Anything).Return([]*entity.BrandAlias{}, nil).Once()
+		aliasRepo.On("FindAll", mock.Anything).Return([]*entity.BrandAlias{{Alias: "ロレ", Brand: "ROLEX"}}, nil).Once()
+		aliasRepo.On("Save", mock.Anything, mock.MatchedBy(func(alias *entity.BrandAlias) bool {
+			return alias.Alias == "ロレ" && alias.Brand == "ROLEX"
+		})).Return(&entity.BrandAlias{Alias: "ロレ", Brand: "ROLEX"}, nil)
+		normalizer := NewBrandNormalizer(aliasRepo, time.Hour)
+		usecase := NewBrandAliasUsecase(aliasRepo, normalizer, nil)
+
+		assert.Equal(t, "ロレ", normalizer.Normalize(context.Background(), "ロレ"))
+		_, err := usecase.SetBrandAlias(context.Background(), " ロレ ", " ROLEX ")
+		require.NoError(t, err)
+		assert.Equal(t, "ROLEX", normalizer.Normalize(context.Background(), "ロレ"))
+	})
+
+	t.Run("異常系: ブランドが空", func(t *testing.T) {
+		usecase := NewBrandAliasUsecase(new(MockBrandAliasRepository), nil, nil)
+
+		_, err := usecase.SetBrandAlias(context.Background(), "ロレ", " ")
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 読み取り専用モード", func(t *testing.T) {
+		usecase := NewBrandAliasUsecase(new(MockBrandAliasRepository), nil, NewReadOnlySwitch(true))
+
+		_, err := usecase.SetBrandAlias(context.Background(), "ロレ", "ROLEX")
+		assert.ErrorIs(t, err, domainErrors.ErrReadOnly)
+	})
+}
+
+func TestBrandAliasUsecase_DeleteBrandAlias(t *testing.T) {
+	t.Run("正常系: 比較用のキーで削除", func(t *testing.T) {
+		aliasRepo := new(MockBrandAliasRepository)
+		aliasRepo.On("Delete", mock.Anything, entity.NormalizeForComparison("ＲＯＬＥ")).Return(nil)
+		usecase := NewBrandAliasUsecase(aliasRepo, nil, nil)
+
+		assert.NoError(t, usecase.DeleteBrandAlias(context.Background(), "ＲＯＬＥ"))
+		aliasRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 登録されていない別名", func(t *testing.T) {
+		aliasRepo := new(MockBrandAliasRepository)
+		aliasRepo.On("Delete", mock.Anything, mock.Anything).Return(domainErrors.ErrItemNotFound)
+		usecase := NewBrandAliasUsecase(aliasRepo, nil, nil)
+
+		assert.ErrorIs(t, usecase.DeleteBrandAlias(context.Background(), "ロレ"), domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestItemUsecase_CreateItem_NormalizesBrand(t *testing.T) {
+	aliasRepo := new(MockBrandAliasRepository)
+	aliasRepo.On("FindAll", mock.Anything).Return([]*entity.BrandAlias{}, nil)
+	createdItem, _ := entity.NewItem("バーキン", "バッグ", "HERMÈS", entity.JPY(2000000), entity.MustParseDate("2023-01-15"), time.Now())
+	createdItem.ID = 1
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+		return item.Brand == "HERMÈS"
+	})).Return(createdItem, nil)
+	mockRepo.On("FindByDedupeKey", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	usecase := NewItemUsecase(mockRepo, WithBrandNormalizer(NewBrandNormalizer(aliasRepo, time.Minute)))
+
+	item, err := usecase.CreateItem(context.Background(), CreateItemInput{
+		Name:          "バーキン",
+		Category:      "バッグ",
+		Brand:         "エルメス",
+		PurchasePrice: entity.JPY(2000000),
+		PurchaseDate:  "2023-01-15",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "HERMÈS", item.Brand)
+}
+
+func TestItemUsecase_NormalizeBrands(t *testing.T) {
+	newItem := func(id int64, brand string, onHold bool) *entity.Item {
+		item, _ := entity.NewItem("アイテム", "時計", brand, entity.JPY(100000), entity.MustParseDate("2023-01-01"), time.Now())
+		item.ID = id
+		item.OnHold = onHold
+		return item
+	}
+
+	aliasRepo := new(MockBrandAliasRepository)
+	aliasRepo.On("FindAll", mock.Anything).Return([]*entity.BrandAlias{}, nil)
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{
+		newItem(1, "ROLEX", false),
+		newItem(2, "ロレックス", false),
+		newItem(3, "rolex", true),
+	}, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+		return item.ID == 2 && item.Brand == "ROLEX"
+	})).Return(newItem(2, "ROLEX", false), nil)
+	usecase := NewItemUsecase(mockRepo, WithBrandNormalizer(NewBrandNormalizer(aliasRepo, time.Minute)))
+
+	result, err := usecase.NormalizeBrands(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, &BrandNormalizationResult{Checked: 3, Updated: 1, Skipped: 1}, result)
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 予算超過時の扱い
+type BudgetEnforcement string
+
+const (
+	// 登録・更新は行い、警告を返す
+	BudgetWarn BudgetEnforcement = "warn"
+	// 登録・更新を拒否する
+	BudgetBlock BudgetEnforcement = "block"
+)
+
+func (e BudgetEnforcement) IsValid() bool {
+	return e == BudgetWarn || e == BudgetBlock
+}
+
+type BudgetUsecase interface {
+	GetBudgets(ctx context.Context) ([]*entity.CategoryBudget, error)
+	SetBudget(ctx context.Context, category string, amount entity.Money) (*entity.CategoryBudget, error)
+	DeleteBudget(ctx context.Context, category string) error
+}
+
+type budgetUsecase struct {
+	budgetRepo BudgetRepository
+	readOnly   *ReadOnlySwitch
+	clock      entity.Clock
+}
+
+func NewBudgetUsecase(budgetRepo BudgetRepository, readOnly *ReadOnlySwitch) BudgetUsecase {
+	if readOnly == nil {
+		readOnly = NewReadOnlySwitch(false)
+	}
+	return &budgetUsecase{
+		budgetRepo: budgetRepo,
+		readOnly:   readOnly,
+		clock:      entity.SystemClock,
+	}
+}
+
+func (u *budgetUsecase) GetBudgets(ctx context.Context) ([]*entity.CategoryBudget, error) {
+	budgets, err := u.budgetRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve budgets: %w", err)
+	}
+
+	if budgets == nil {
+		budgets = []*entity.CategoryBudget{}
+	}
+
+	return budgets, nil
+}
+
+func (u *budgetUsecase) SetBudget(ctx context.Context, category string, amount entity.Money) (*entity.CategoryBudget, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+
+	budget, err := entity.NewCategoryBudget(category, amount, u.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	saved, err := u.budgetRepo.Save(ctx, budget)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save budget: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (u *budgetUsecase) DeleteBudget(ctx context.Context, category string) error {
+	if u.readOnly.Enabled() {
+		return domainErrors.ErrReadOnly
+	}
+
+	if err := u.budgetRepo.Delete(ctx, category); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemNotFound
+		}
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	return nil
+}
+
+// 購入によってカテゴリーの年間予算を超える場合、警告メッセージを返す（block の場合はエラー）
+func (u *itemUsecase) checkBudget(ctx context.Context, item *entity.Item) ([]string, error) {
+	return u.checkBatchBudget(ctx, item, nil)
+}
+
+// 一括登録・インポートで同時に登録するアイテムの購入額（まだ保存されていないため、予算の集計に加える）
+type batchSpend map[batchSpendKey]int64
+
+type batchSpendKey struct {
+	category string
+	currency string
+	year     int
+}
+
+func batchSpendKeyOf(item *entity.Item) batchSpendKey {
+	return batchSpendKey{category: item.Category, currency: item.PurchasePrice.Currency, year: item.PurchaseDate.Year()}
+}
+
+// 登録するアイテムの購入額を加える
+func (s batchSpend) add(item *entity.Item) {
+	if item.Draft {
+		return
+	}
+	s[batchSpendKeyOf(item)] += item.PurchasePrice.Amount
+}
+
+// checkBudget と同じだが、同じバッチで先に登録するアイテムの購入額（pending）も合計に含める
+func (u *itemUsecase) checkBatchBudget(ctx context.Context, item *entity.Item, pending batchSpend) ([]string, error) {
+	// 下書きは公開時にチェックする
+	if u.budgetRepo == nil || item.Draft {
+		return nil, nil
+	}
+
+	budget, err := u.budgetRepo.FindByCategory(ctx, item.Category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve budget: %w", err)
+	}
+	// 予算未設定、または予算と異なる通貨の購入は対象外
+	if budget == nil || budget.Amount.Currency != item.PurchasePrice.Currency {
+		return nil, nil
+	}
+
+	year := item.PurchaseDate.Year()
+
+	spent, err := u.itemRepo.SumPurchasePrice(ctx, item.Category, item.PurchasePrice.Currency, year, item.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate spending: %w", err)
+	}
+
+	total := entity.Money{Amount: spent + pending[batchSpendKeyOf(item)] + item.PurchasePrice.Amount, Currency: item.PurchasePrice.Currency}
+	if total.Amount <= budget.Amount.Amount {
+		return nil, nil
+	}
+
+	message := fmt.Sprintf("%d budget for %s exceeded: %s of %s", year, item.Category, total, budget.Amount)
+	if u.budgetEnforcement == BudgetBlock {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrBudgetExceeded, message)
+	}
+	return []string{message}, nil
+}
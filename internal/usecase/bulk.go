@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 一括登録でエラーがあった場合の扱い
+type BulkMode string
+
+const (
+	// 1件でもエラーがあれば何も登録しない
+	BulkModeAllOrNothing BulkMode = "all_or_nothing"
+	// エラーのない項目だけを登録し、エラーは項目ごとに返す
+	BulkModePartial BulkMode = "partial"
+)
+
+// 一括登録できる最大件数
+const MaxBulkItems = 100
+
+type BulkCreateItemsInput struct {
+	Mode  BulkMode          `json:"mode"`
+	Items []CreateItemInput `json:"items"`
+}
+
+// 一括登録で登録できなかった項目
+type BulkItemError struct {
+	Index  int      `json:"index"`
+	Errors []string `json:"errors"`
+}
+
+// 一括登録で登録できたが警告のある項目（予算の超過など）
+type BulkItemWarning struct {
+	Index    int      `json:"index"`
+	Warnings []string `json:"warnings"`
+}
+
+type BulkCreateResult struct {
+	Created  []*entity.Item    `json:"created"`
+	Failed   []BulkItemError   `json:"failed"`
+	Warnings []BulkItemWarning `json:"warnings,omitempty"`
+}
+
+// all_or_nothing モードで項目にエラーがあった場合のエラー
+type BulkValidationError struct {
+	Failed []BulkItemError
+}
+
+func (e *BulkValidationError) Error() string {
+	return fmt.Sprintf("%s: %d of the items are invalid", domainErrors.ErrInvalidInput, len(e.Failed))
+}
+
+func (e *BulkValidationError) Unwrap() error {
+	return domainErrors.ErrInvalidInput
+}
+
+func (u *itemUsecase) CreateItems(ctx context.Context, input BulkCreateItemsInput) (*BulkCreateResult, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	mode := input.Mode
+	if mode == "" {
+		mode = BulkModeAllOrNothing
+	}
+	if mode != BulkModeAllOrNothing && mode != BulkModePartial {
+		return nil, fmt.Errorf("%w: mode must be %s or %s", domainErrors.ErrInvalidInput, BulkModeAllOrNothing, BulkModePartial)
+	}
+
+	if len(input.Items) == 0 {
+		return nil, fmt.Errorf("%w: items must not be empty", domainErrors.ErrInvalidInput)
+	}
+	if len(input.Items) > MaxBulkItems {
+		return nil, fmt.Errorf("%w: items must be %d or fewer", domainErrors.ErrInvalidInput, MaxBulkItems)
+	}
+
+	// 全項目をバリデーションし、前の項目の購入額を含めて予算をチェックする
+	items := make([]*entity.Item, 0, len(input.Items))
+	failed := []BulkItemError{}
+	var warnings []BulkItemWarning
+	pending := batchSpend{}
+	for i, itemInput := range input.Items {
+		item, err := u.newItem(ctx, itemInput)
+		if err != nil {
+			failed = append(failed, BulkItemError{
+				Index:  i,
+				Errors: strings.Split(err.Error(), ", "),
+			})
+			continue
+		}
+		budgetWarnings, err := u.checkBatchBudget(ctx, item, pending)
+		if err != nil {
+			if !errors.Is(err, domainErrors.ErrBudgetExceeded) {
+				return nil, err
+			}
+			failed = append(failed, BulkItemError{Index: i, Errors: []string{err.Error()}})
+			continue
+		}
+		if len(budgetWarnings) > 0 {
+			warnings = append(warnings, BulkItemWarning{Index: i, Warnings: budgetWarnings})
+		}
+		pending.add(item)
+		items = append(items, item)
+	}
+
+	if mode == BulkModeAllOrNothing && len(failed) > 0 {
+		return nil, &BulkValidationError{Failed: failed}
+	}
+
+	if err := u.quota.CheckItems(ctx, len(items)); err != nil {
+		return nil, err
+	}
+
+	created := []*entity.Item{}
+	if len(items) > 0 {
+		// 為替レートを取得できなかったアイテムは円換算額なしで登録する
+		u.applyExchangeRates(ctx, items...)
+
+		err := u.mutate(ctx, func(ctx context.Context) error {
+			var err error
+			created, err = u.itemRepo.CreateMany(ctx, items)
+			if err != nil {
+				return fmt.Errorf("failed to create items: %w", err)
+			}
+			for _, item := range created {
+				if err := u.audit(ctx, item.ID, entity.AuditCreate, nil, entity.ItemAuditFields(item)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range created {
+			u.cacheItem(ctx, item)
+			u.snapshot(ctx, item)
+		}
+		purgeItems(ctx, u.cachePurger, created...)
+	}
+
+	return &BulkCreateResult{
+		Created:  created,
+		Failed:   failed,
+		Warnings: warnings,
+	}, nil
+}
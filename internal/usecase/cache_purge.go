@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// CDNのキャッシュをサロゲートキー（キャッシュタグ）単位で削除する
+type CachePurger interface {
+	// Purge invalidates every cached response tagged with any of the keys
+	Purge(ctx context.Context, keys []string) error
+}
+
+// 複数の削除先（CDN、アイテムのレスポンスのキャッシュなど）のキャッシュを順に削除する
+type CachePurgers []CachePurger
+
+func (p CachePurgers) Purge(ctx context.Context, keys []string) error {
+	var errs []error
+	for _, purger := range p {
+		if err := purger.Purge(ctx, keys); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// アイテムを含むすべてのレスポンス（一覧・検索・集計）に付けるサロゲートキー
+const ItemsSurrogateKey = "items"
+
+// アイテム単位のサロゲートキー
+func ItemSurrogateKey(id int64) string {
+	return itemSurrogateKeyPrefix + strconv.FormatInt(id, 10)
+}
+
+const itemSurrogateKeyPrefix = "item-"
+
+// カテゴリー単位のサロゲートキー（ヘッダーに含められるようカテゴリー名はURLエンコードする）
+func CategorySurrogateKey(category string) string {
+	return "category-" + url.QueryEscape(category)
+}
+
+// アイテムの変更時に削除するサロゲートキー
+func ItemSurrogateKeys(item *entity.Item) []string {
+	keys := []string{ItemsSurrogateKey, ItemSurrogateKey(item.ID)}
+	if item.Category != "" {
+		keys = append(keys, CategorySurrogateKey(item.Category))
+	}
+	return keys
+}
+
+// アイテムの変更時にCDNのキャッシュを削除する
+func WithCachePurger(purger CachePurger) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.cachePurger = purger
+	}
+}
+
+// 評価額の記録時にCDNのキャッシュを削除する（アイテムのレスポンスに最新の評価額を含むため）
+func WithValuationCachePurger(purger CachePurger) ValuationUsecaseOption {
+	return func(u *valuationUsecase) {
+		u.cachePurger = purger
+	}
+}
+
+// 変更されたアイテムのキャッシュを削除する
+// 変更は完了しているため、削除に失敗しても操作自体はエラーにしない（キャッシュは有効期限で更新される）
+func purgeItems(ctx context.Context, purger CachePurger, items ...*entity.Item) {
+	if purger == nil || len(items) == 0 {
+		return
+	}
+
+	seen := make(map[string]bool)
+	var keys []string
+	for _, item := range items {
+		for _, key := range ItemSurrogateKeys(item) {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	_ = purger.Purge(ctx, keys)
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// MockCachePurger はCDNのキャッシュ削除のモック
+type MockCachePurger struct {
+	mock.Mock
+}
+
+func (m *MockCachePurger) Purge(ctx context.Context, keys []string) error {
+	args := m.Called(ctx, keys)
+	return args.Error(0)
+}
+
+func TestItemSurrogateKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     *entity.Item
+		expected []string
+	}{
+		{
+			name:     "正常系: 全体・アイテム・カテゴリーのキー",
+			item:     &entity.Item{ID: 1, Category: "時計"},
+			expected: []string{"items", "item-1", "category-%E6%99%82%E8%A8%88"},
+		},
+		{
+			name:     "正常系: カテゴリーがない場合はカテゴリーのキーを含めない",
+			item:     &entity.Item{ID: 2},
+			expected: []string{"items", "item-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ItemSurrogateKeys(tt.item))
+		})
+	}
+}
+
+func TestItemUsecase_CachePurge(t *testing.T) {
+	t.Run("正常系: 更新時にアイテムとカテゴリーのキャッシュを削除", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		purger := new(MockCachePurger)
+
+		existing, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+		existing.ID = 1
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+		mockRepo.On("Update", mock.Anything, existing).Return(existing, nil)
+		purger.On("Purge", mock.Anything, []string{"items", "item-1", CategorySurrogateKey("時計")}).Return(nil)
+
+		usecase := NewItemUsecase(mockRepo, WithCachePurger(purger))
+		name := "時計2"
+		_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Name: &name})
+
+		require.NoError(t, err)
+		purger.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 削除に失敗しても操作は成功", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		purger := new(MockCachePurger)
+
+		mockRepo.On("FindByID", mock.Anything, int64(2)).Return(&entity.Item{ID: 2}, nil)
+		mockRepo.On("Delete", mock.Anything, int64(2), mock.Anything).Return(nil)
+		purger.On("Purge", mock.Anything, []string{"items", "item-2"}).Return(errors.New("cdn unavailable"))
+
+		usecase := NewItemUsecase(mockRepo, WithCachePurger(purger))
+		assert.NoError(t, usecase.DeleteItem(context.Background(), 2))
+		purger.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 変更に失敗した場合は削除しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		purger := new(MockCachePurger)
+
+		mockRepo.On("FindByID", mock.Anything, int64(3)).Return(&entity.Item{ID: 3}, nil)
+		mockRepo.On("Delete", mock.Anything, int64(3), mock.Anything).Return(errors.New("db error"))
+
+		usecase := NewItemUsecase(mockRepo, WithCachePurger(purger))
+		assert.Error(t, usecase.DeleteItem(context.Background(), 3))
+		purger.AssertNotCalled(t, "Purge", mock.Anything, mock.Anything)
+	})
+}
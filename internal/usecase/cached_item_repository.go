@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 読み取りキャッシュの保存先（プロセス内のLRU、Redis など）
+type KeyValueCache interface {
+	// Get returns the value stored under the key, or false if it does not exist or has expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores the value under the key until the TTL elapses
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the values stored under the keys
+	Delete(ctx context.Context, keys ...string) error
+
+	// Counter returns the value of the counter stored under the key (0 if it does not exist)
+	Counter(ctx context.Context, key string) (int64, error)
+
+	// Incr increments the counter stored under the key and returns the new value
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+// 読み取りの種類ごとのキャッシュの有効期間（0 の場合はキャッシュしない）
+type ItemCacheTTL struct {
+	Item    time.Duration // FindByID
+	List    time.Duration // FindAll
+	Summary time.Duration // GetSummaryByCategory
+}
+
+// キャッシュのキー（保存形式を変えた場合は版を上げる）
+const (
+	itemCachePrefix        = "items:v1:"
+	itemCacheGenerationKey = itemCachePrefix + "generation"
+)
+
+// ItemRepository の読み取りをキャッシュするデコレーター
+// FindByID はアイテムごと、FindAll・GetSummaryByCategory は世代（いずれかのアイテムの変更で進む）ごとにキャッシュする
+// キャッシュの障害時はリポジトリから読み、変更時の削除に失敗した場合は有効期間まで古い内容を返すことがある
+// トランザクション内の変更はコミット前に削除するため、コミットまでの間に読まれた内容も有効期間まで残ることがある
+type CachedItemRepository struct {
+	ItemRepository
+	cache KeyValueCache
+	ttl   ItemCacheTTL
+}
+
+func NewCachedItemRepository(repo ItemRepository, cache KeyValueCache, ttl ItemCacheTTL) *CachedItemRepository {
+	return &CachedItemRepository{
+		ItemRepository: repo,
+		cache:          cache,
+		ttl:            ttl,
+	}
+}
+
+func (r *CachedItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	if r.ttl.Item <= 0 {
+		return r.ItemRepository.FindByID(ctx, id)
+	}
+
+	// 存在しないアイテムはキャッシュしない（エラーはリポジトリの結果をそのまま返す）
+	var item *entity.Item
+	err := r.readThrough(ctx, cachedItemKey(id), r.ttl.Item, &item, func() (interface{}, error) {
+		return r.ItemRepository.FindByID(ctx, id)
+	})
+	return item, err
+}
+
+// タグで絞り込む一覧は、タグの付け外しで結果が変わる（アイテムの変更ではない）ためキャッシュしない
+func (r *CachedItemRepository) FindAll(ctx context.Context, query entity.ItemQuery) ([]*entity.Item, error) {
+	if r.ttl.List <= 0 || query.Tag != "" {
+		return r.ItemRepository.FindAll(ctx, query)
+	}
+
+	key, err := r.generationKey(ctx, "list:"+queryHash(query))
+	if err != nil {
+		return r.ItemRepository.FindAll(ctx, query)
+	}
+	var items []*entity.Item
+	err = r.readThrough(ctx, key, r.ttl.List, &items, func() (interface{}, error) {
+		return r.ItemRepository.FindAll(ctx, query)
+	})
+	return items, err
+}
+
+func (r *CachedItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
+	if r.ttl.Summary <= 0 {
+		return r.ItemRepository.GetSummaryByCategory(ctx)
+	}
+
+	key, err := r.generationKey(ctx, "summary")
+	if err != nil {
+		return r.ItemRepository.GetSummaryByCategory(ctx)
+	}
+	var summary map[string]int
+	err = r.readThrough(ctx, key, r.ttl.Summary, &summary, func() (interface{}, error) {
+		return r.ItemRepository.GetSummaryByCategory(ctx)
+	})
+	return summary, err
+}
+
+func (r *CachedItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	created, err := r.ItemRepository.Create(ctx, item)
+	if err == nil {
+		r.invalidate(ctx)
+	}
+	return created, err
+}
+
+func (r *CachedItemRepository) CreateMany(ctx context.Context, items []*entity.Item) ([]*entity.Item, error) {
+	created, err := r.ItemRepository.CreateMany(ctx, items)
+	if err == nil {
+		r.invalidate(ctx)
+	}
+	return created, err
+}
+
+func (r *CachedItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	updated, err := r.ItemRepository.Update(ctx, item)
+	if err == nil {
+		r.invalidate(ctx, item.ID)
+	}
+	return updated, err
+}
+
+func (r *CachedItemRepository) Delete(ctx context.Context, id int64, now time.Time) error {
+	err := r.ItemRepository.Delete(ctx, id, now)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return err
+}
+
+func (r *CachedItemRepository) Restore(ctx context.Context, id int64, now time.Time) error {
+	err := r.ItemRepository.Restore(ctx, id, now)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return err
+}
+
+func (r *CachedItemRepository) SetHold(ctx context.Context, id int64, onHold bool, reason string, now time.Time) error {
+	err := r.ItemRepository.SetHold(ctx, id, onHold, reason, now)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return err
+}
+
+func (r *CachedItemRepository) SetSold(ctx context.Context, id int64, now time.Time) error {
+	err := r.ItemRepository.SetSold(ctx, id, now)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return err
+}
+
+func (r *CachedItemRepository) Split(ctx context.Context, id int64, components []*entity.Item, now time.Time) ([]*entity.Item, error) {
+	created, err := r.ItemRepository.Split(ctx, id, components, now)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return created, err
+}
+
+func (r *CachedItemRepository) SchedulePurge(ctx context.Context, id int64, purgeAt *time.Time, now time.Time) error {
+	err := r.ItemRepository.SchedulePurge(ctx, id, purgeAt, now)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return err
+}
+
+func (r *CachedItemRepository) Purge(ctx context.Context, id int64) error {
+	err := r.ItemRepository.Purge(ctx, id)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return err
+}
+
+// キャッシュにあればそれを dest に読み込み、なければ load の結果をキャッシュする
+func (r *CachedItemRepository) readThrough(ctx context.Context, key string, ttl time.Duration, dest interface{}, load func() (interface{}, error)) error {
+	if cached, ok, err := r.cache.Get(ctx, key); err == nil && ok {
+		if err := gob.NewDecoder(bytes.NewReader(cached)).Decode(dest); err == nil {
+			return nil
+		}
+	}
+
+	value, err := load()
+	if err != nil {
+		return err
+	}
+
+	// 呼び出し側の変更がキャッシュに影響しないよう、保存した内容を読み直して返す
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		return fmt.Errorf("failed to encode cached value: %w", err)
+	}
+	_ = r.cache.Set(ctx, key, buf.Bytes(), ttl)
+	return gob.NewDecoder(&buf).Decode(dest)
+}
+
+// 現在の世代のキー
+func (r *CachedItemRepository) generationKey(ctx context.Context, name string) (string, error) {
+	generation, err := r.cache.Counter(ctx, itemCacheGenerationKey)
+	if err != nil {
+		return "", err
+	}
+	return itemCachePrefix + strconv.FormatInt(generation, 10) + ":" + name, nil
+}
+
+// アイテム単位のサロゲートキーのアイテムのキャッシュを削除する削除先
+// タグの追加・削除など、他のリポジトリでの変更でアイテムの内容（変更の通し番号）が変わる場合に使う
+func (r *CachedItemRepository) CachePurger() CachePurger {
+	return cachedItemPurger{repo: r}
+}
+
+type cachedItemPurger struct {
+	repo *CachedItemRepository
+}
+
+func (p cachedItemPurger) Purge(ctx context.Context, keys []string) error {
+	var ids []int64
+	for _, key := range keys {
+		value, ok := strings.CutPrefix(key, itemSurrogateKeyPrefix)
+		if !ok {
+			continue
+		}
+		if id, err := strconv.ParseInt(value, 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > 0 {
+		p.repo.invalidate(ctx, ids...)
+	}
+	return nil
+}
+
+// 一覧・集計の世代を進め、キャッシュした一覧・集計を返さないようにする（リポジトリを通らない変更の後に使う）
+func (r *CachedItemRepository) InvalidateAll(ctx context.Context) error {
+	_, err := r.cache.Incr(ctx, itemCacheGenerationKey)
+	return err
+}
+
+// 変更したアイテムのキャッシュを削除し、一覧・集計の世代を進める
+func (r *CachedItemRepository) invalidate(ctx context.Context, ids ...int64) {
+	if len(ids) > 0 {
+		keys := make([]string, 0, len(ids))
+		for _, id := range ids {
+			keys = append(keys, cachedItemKey(id))
+		}
+		_ = r.cache.Delete(ctx, keys...)
+	}
+	_, _ = r.cache.Incr(ctx, itemCacheGenerationKey)
+}
+
+func cachedItemKey(id int64) string {
+	return itemCachePrefix + "item:" + strconv.FormatInt(id, 10)
+}
+
+// 検索条件を表すキー
+func queryHash(query entity.ItemQuery) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%#v", query)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeKeyValueCache は有効期間を扱わないメモリ上のキャッシュ
+type fakeKeyValueCache struct {
+	values   map[string][]byte
+	counters map[string]int64
+	err      error // 設定した場合はすべての操作が失敗する
+}
+
+func newFakeKeyValueCache() *fakeKeyValueCache {
+	return &fakeKeyValueCache{values: map[string][]byte{}, counters: map[string]int64{}}
+}
+
+func (c *fakeKeyValueCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, ok := c.values[key]
+	return value, ok, c.err
+}
+
+func (c *fakeKeyValueCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if c.err == nil {
+		c.values[key] = value
+	}
+	return c.err
+}
+
+func (c *fakeKeyValueCache) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(c.values, key)
+	}
+	return c.err
+}
+
+func (c *fakeKeyValueCache) Counter(ctx context.Context, key string) (int64, error) {
+	return c.counters[key], c.err
+}
+
+func (c *fakeKeyValueCache) Incr(ctx context.Context, key string) (int64, error) {
+	c.counters[key]++
+	return c.counters[key], c.err
+}
+
+var testItemCacheTTL = ItemCacheTTL{Item: time.Minute, List: time.Minute, Summary: time.Minute}
+
+func TestCachedItemRepository_FindByID(t *testing.T) {
+	t.Run("正常系: 2回目はキャッシュから返し、呼び出し側の変更は影響しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "時計", Attributes: map[string]string{"reference_number": "116500LN"}}, nil).Once()
+
+		repo := NewCachedItemRepository(mockRepo, newFakeKeyValueCache(), testItemCacheTTL)
+		first, err := repo.FindByID(context.Background(), 1)
+		require.NoError(t, err)
+		first.Name = "変更"
+		first.Attributes["reference_number"] = "変更"
+
+		second, err := repo.FindByID(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, "時計", second.Name)
+		assert.Equal(t, "116500LN", second.Attributes["reference_number"])
+		mockRepo.AssertNumberOfCalls(t, "FindByID", 1)
+	})
+
+	t.Run("正常系: 更新・削除したアイテムはリポジトリから読み直す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		item := &entity.Item{ID: 1, Name: "時計"}
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil).Twice()
+		mockRepo.On("Update", mock.Anything, item).Return(item, nil)
+		mockRepo.On("Delete", mock.Anything, int64(1), mock.Anything).Return(nil)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+
+		repo := NewCachedItemRepository(mockRepo, newFakeKeyValueCache(), testItemCacheTTL)
+		ctx := context.Background()
+		_, _ = repo.FindByID(ctx, 1)
+		_, err := repo.Update(ctx, item)
+		require.NoError(t, err)
+		_, _ = repo.FindByID(ctx, 1)
+		require.NoError(t, repo.Delete(ctx, 1, time.Now()))
+
+		_, err = repo.FindByID(ctx, 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		mockRepo.AssertNumberOfCalls(t, "FindByID", 3)
+	})
+
+	t.Run("正常系: サロゲートキーでの削除（タグの変更など）の後はリポジトリから読み直す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, ChangeSeq: 10}, nil).Once()
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, ChangeSeq: 11}, nil).Once()
+
+		repo := NewCachedItemRepository(mockRepo, newFakeKeyValueCache(), testItemCacheTTL)
+		ctx := context.Background()
+		_, _ = repo.FindByID(ctx, 1)
+		require.NoError(t, repo.CachePurger().Purge(ctx, []string{ItemsSurrogateKey, ItemSurrogateKey(1), CategorySurrogateKey("時計")}))
+
+		item, err := repo.FindByID(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(11), item.ChangeSeq)
+		mockRepo.AssertNumberOfCalls(t, "FindByID", 2)
+	})
+
+	t.Run("正常系: キャッシュの障害時はリポジトリから読む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		cache := newFakeKeyValueCache()
+		cache.err = errors.New("connection refused")
+
+		repo := NewCachedItemRepository(mockRepo, cache, testItemCacheTTL)
+		item, err := repo.FindByID(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), item.ID)
+	})
+}
+
+func TestCachedItemRepository_FindAll(t *testing.T) {
+	query := entity.ItemQuery{Limit: 20, Sort: entity.SortByCreatedAt, Order: entity.SortDesc}
+
+	t.Run("正常系: アイテムの登録で一覧と集計のキャッシュを捨てる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, query).Return([]*entity.Item{{ID: 1}}, nil).Twice()
+		mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 1}, nil).Twice()
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 2}, nil)
+
+		repo := NewCachedItemRepository(mockRepo, newFakeKeyValueCache(), testItemCacheTTL)
+		ctx := context.Background()
+		for i := 0; i < 2; i++ {
+			items, err := repo.FindAll(ctx, query)
+			require.NoError(t, err)
+			assert.Len(t, items, 1)
+			summary, err := repo.GetSummaryByCategory(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]int{"時計": 1}, summary)
+		}
+		_, err := repo.Create(ctx, &entity.Item{})
+		require.NoError(t, err)
+		_, _ = repo.FindAll(ctx, query)
+		_, _ = repo.GetSummaryByCategory(ctx)
+
+		mockRepo.AssertNumberOfCalls(t, "FindAll", 2)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 2)
+	})
+
+	t.Run("正常系: 該当なしの結果もキャッシュする", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, query).Return([]*entity.Item(nil), nil).Once()
+
+		repo := NewCachedItemRepository(mockRepo, newFakeKeyValueCache(), testItemCacheTTL)
+		for i := 0; i < 2; i++ {
+			items, err := repo.FindAll(context.Background(), query)
+			require.NoError(t, err)
+			assert.Empty(t, items)
+		}
+		mockRepo.AssertNumberOfCalls(t, "FindAll", 1)
+	})
+
+	t.Run("正常系: タグで絞り込む一覧はキャッシュしない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		tagged := query
+		tagged.Tag = "gift"
+		mockRepo.On("FindAll", mock.Anything, tagged).Return([]*entity.Item{{ID: 1}}, nil)
+
+		repo := NewCachedItemRepository(mockRepo, newFakeKeyValueCache(), testItemCacheTTL)
+		_, _ = repo.FindAll(context.Background(), tagged)
+		_, _ = repo.FindAll(context.Background(), tagged)
+
+		mockRepo.AssertNumberOfCalls(t, "FindAll", 2)
+	})
+
+	t.Run("正常系: 有効期間が0の読み取りはキャッシュしない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, query).Return([]*entity.Item{{ID: 1}}, nil)
+
+		repo := NewCachedItemRepository(mockRepo, newFakeKeyValueCache(), ItemCacheTTL{Item: time.Minute})
+		_, _ = repo.FindAll(context.Background(), query)
+		_, _ = repo.FindAll(context.Background(), query)
+
+		mockRepo.AssertNumberOfCalls(t, "FindAll", 2)
+	})
+}
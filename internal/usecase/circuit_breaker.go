@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// サーキットブレーカーの状態
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // 通常どおり呼び出す
+	CircuitOpen     CircuitState = "open"      // 呼び出さずにすぐ失敗とする
+	CircuitHalfOpen CircuitState = "half_open" // 1回だけ試しに呼び出し、成功すれば closed に戻す
+)
+
+type CircuitBreakerSettings struct {
+	// 連続した失敗がこの回数に達したら open にする（0 の場合は open にしない）
+	Threshold int
+	// open にしてから試しに呼び出すまでの時間
+	Cooldown time.Duration
+	// 1回の呼び出しのタイムアウト（0 の場合は呼び出し元の期限のみ）
+	Timeout time.Duration
+}
+
+// 任意の依存先（読み取りキャッシュ・検索エンジンなど）の障害時に、応答を待たずに失敗とするサーキットブレーカー
+// 依存先が応答しない場合も、リクエストごとにタイムアウトまで待たずに代わりの処理（DBからの読み取りなど）に切り替えられる
+type CircuitBreaker struct {
+	name          string
+	settings      CircuitBreakerSettings
+	clock         entity.Clock
+	onStateChange func(name string, from, to CircuitState)
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// CircuitBreakerの任意の設定を指定するオプション
+type CircuitBreakerOption func(*CircuitBreaker)
+
+// 状態が変わったときに呼ぶ関数を指定（ログの出力など。ブレーカーのロック中に呼ぶため、ブレーカーを操作しないこと）
+func WithCircuitStateChange(fn func(name string, from, to CircuitState)) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.onStateChange = fn
+	}
+}
+
+// 現在時刻の取得元を指定（デフォルトはシステムの時刻）
+func WithCircuitBreakerClock(clock entity.Clock) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.clock = clock
+	}
+}
+
+// name は依存先の名前（エラーと状態の変化の通知に使う）
+func NewCircuitBreaker(name string, settings CircuitBreakerSettings, opts ...CircuitBreakerOption) *CircuitBreaker {
+	b := &CircuitBreaker{
+		name:     name,
+		settings: settings,
+		clock:    entity.SystemClock,
+		state:    CircuitClosed,
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// タイムアウトを設けて fn を呼び出し、結果を記録する。open の場合は呼び出さずに ErrCircuitOpen を返す
+// 呼び出し元の取り消し・期限切れによる失敗は、依存先の障害として数えない
+func (b *CircuitBreaker) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !b.allow() {
+		return fmt.Errorf("%w: %s", domainErrors.ErrCircuitOpen, b.name)
+	}
+
+	callCtx := ctx
+	if b.settings.Timeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, b.settings.Timeout)
+		defer cancel()
+	}
+
+	err := fn(callCtx)
+	if err != nil && ctx.Err() != nil {
+		b.release()
+		return err
+	}
+	b.record(err == nil)
+	return err
+}
+
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.clock.Now().Sub(b.openedAt) < b.settings.Cooldown {
+			return false
+		}
+		b.setState(CircuitHalfOpen)
+		b.probing = true
+		return true
+	case CircuitHalfOpen:
+		// 試しの呼び出しの結果が出るまでは他の呼び出しを止める
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// 試しの呼び出しが結果を出さずに終わった場合は、次の呼び出しで試す
+func (b *CircuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *CircuitBreaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if success {
+		b.failures = 0
+		b.setState(CircuitClosed)
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitHalfOpen || (b.settings.Threshold > 0 && b.failures >= b.settings.Threshold) {
+		b.openedAt = b.clock.Now()
+		b.setState(CircuitOpen)
+	}
+}
+
+func (b *CircuitBreaker) setState(state CircuitState) {
+	if b.state == state {
+		return
+	}
+	from := b.state
+	b.state = state
+	if b.onStateChange != nil {
+		b.onStateChange(b.name, from, state)
+	}
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	errBackend := errors.New("connection refused")
+	fail := func(ctx context.Context) error { return errBackend }
+	succeed := func(ctx context.Context) error { return nil }
+
+	newBreaker := func(now *time.Time, changes *[]CircuitState) *CircuitBreaker {
+		return NewCircuitBreaker("cache", CircuitBreakerSettings{Threshold: 2, Cooldown: time.Minute},
+			WithCircuitBreakerClock(entity.ClockFunc(func() time.Time { return *now })),
+			WithCircuitStateChange(func(name string, from, to CircuitState) { *changes = append(*changes, to) }),
+		)
+	}
+
+	t.Run("正常系: 連続した失敗で open にし、待機後の試しの呼び出しが成功すれば closed に戻す", func(t *testing.T) {
+		now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+		var changes []CircuitState
+		breaker := newBreaker(&now, &changes)
+
+		assert.ErrorIs(t, breaker.Do(context.Background(), fail), errBackend)
+		assert.Equal(t, CircuitClosed, breaker.State())
+		assert.ErrorIs(t, breaker.Do(context.Background(), fail), errBackend)
+		assert.Equal(t, CircuitOpen, breaker.State())
+
+		// open の間は呼び出さない
+		called := false
+		err := breaker.Do(context.Background(), func(ctx context.Context) error { called = true; return nil })
+		assert.ErrorIs(t, err, domainErrors.ErrCircuitOpen)
+		assert.False(t, called)
+
+		now = now.Add(time.Minute)
+		require.NoError(t, breaker.Do(context.Background(), succeed))
+		assert.Equal(t, CircuitClosed, breaker.State())
+		assert.Equal(t, []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed}, changes)
+	})
+
+	t.Run("正常系: 成功すると連続した失敗の回数を戻す", func(t *testing.T) {
+		now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+		var changes []CircuitState
+		breaker := newBreaker(&now, &changes)
+
+		_ = breaker.Do(context.Background(), fail)
+		_ = breaker.Do(context.Background(), succeed)
+		_ = breaker.Do(context.Background(), fail)
+
+		assert.Equal(t, CircuitClosed, breaker.State())
+	})
+
+	t.Run("異常系: 試しの呼び出しが失敗した場合は再び open にする", func(t *testing.T) {
+		now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+		var changes []CircuitState
+		breaker := newBreaker(&now, &changes)
+		_ = breaker.Do(context.Background(), fail)
+		_ = breaker.Do(context.Background(), fail)
+
+		now = now.Add(time.Minute)
+		assert.ErrorIs(t, breaker.Do(context.Background(), fail), errBackend)
+		assert.Equal(t, CircuitOpen, breaker.State())
+
+		// 待機はやり直し
+		now = now.Add(30 * time.Second)
+		assert.ErrorIs(t, breaker.Do(context.Background(), succeed), domainErrors.ErrCircuitOpen)
+	})
+
+	t.Run("正常系: 呼び出し元の取り消しは失敗として数えない", func(t *testing.T) {
+		now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+		var changes []CircuitState
+		breaker := newBreaker(&now, &changes)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		for i := 0; i < 3; i++ {
+			assert.ErrorIs(t, breaker.Do(ctx, func(ctx context.Context) error { return ctx.Err() }), context.Canceled)
+		}
+		assert.Equal(t, CircuitClosed, breaker.State())
+	})
+
+	t.Run("正常系: 応答しない依存先はタイムアウトで失敗とする", func(t *testing.T) {
+		breaker := NewCircuitBreaker("search", CircuitBreakerSettings{Threshold: 1, Cooldown: time.Minute, Timeout: 10 * time.Millisecond})
+
+		err := breaker.Do(context.Background(), func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, CircuitOpen, breaker.State())
+	})
+}
+
+// 応答しない読み取りキャッシュ（呼び出し回数を数える）
+type hangingKeyValueCache struct {
+	*fakeKeyValueCache
+	calls int
+}
+
+func (c *hangingKeyValueCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.calls++
+	<-ctx.Done()
+	return nil, false, ctx.Err()
+}
+
+func (c *hangingKeyValueCache) Counter(ctx context.Context, key string) (int64, error) {
+	c.calls++
+	<-ctx.Done()
+	return 0, ctx.Err()
+}
+
+func TestResilientKeyValueCache(t *testing.T) {
+	t.Run("正常系: キャッシュが応答しない場合はタイムアウト後にリポジトリから読み、以降は待たずに読む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "時計"}, nil)
+
+		cache := &hangingKeyValueCache{fakeKeyValueCache: newFakeKeyValueCache()}
+		breaker := NewCircuitBreaker("item cache", CircuitBreakerSettings{Threshold: 1, Cooldown: time.Minute, Timeout: 10 * time.Millisecond})
+		repo := NewCachedItemRepository(mockRepo, NewResilientKeyValueCache(cache, breaker), testItemCacheTTL)
+
+		for i := 0; i < 3; i++ {
+			item, err := repo.FindByID(context.Background(), 1)
+			require.NoError(t, err)
+			assert.Equal(t, "時計", item.Name)
+		}
+		assert.Equal(t, 1, cache.calls)
+		assert.Equal(t, CircuitOpen, breaker.State())
+		mockRepo.AssertNumberOfCalls(t, "FindByID", 3)
+	})
+}
+
+// 障害中の検索エンジン
+type failingSearchIndex struct {
+	calls int
+}
+
+func (s *failingSearchIndex) Search(ctx context.Context, query SearchIndexQuery) (*SearchIndexResult, error) {
+	s.calls++
+	return nil, errors.New("search engine unavailable")
+}
+
+func (s *failingSearchIndex) Upsert(ctx context.Context, items []*entity.Item) error {
+	s.calls++
+	return errors.New("search engine unavailable")
+}
+
+func (s *failingSearchIndex) Delete(ctx context.Context, ids []int64) error {
+	s.calls++
+	return errors.New("search engine unavailable")
+}
+
+func TestResilientSearchIndex(t *testing.T) {
+	t.Run("正常系: 障害が続く場合は検索エンジンを呼ばずにエラーを返す", func(t *testing.T) {
+		index := &failingSearchIndex{}
+		resilient := NewResilientSearchIndex(index, NewCircuitBreaker("search index", CircuitBreakerSettings{Threshold: 2, Cooldown: time.Minute}))
+
+		for i := 0; i < 5; i++ {
+			_, err := resilient.Search(context.Background(), SearchIndexQuery{Keyword: "rolex"})
+			assert.Error(t, err)
+		}
+		err := resilient.Upsert(context.Background(), []*entity.Item{{ID: 1}})
+
+		assert.ErrorIs(t, err, domainErrors.ErrCircuitOpen)
+		assert.Equal(t, 2, index.calls)
+	})
+}
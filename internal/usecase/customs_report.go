@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 集計期間（購入年、両端を含む）と購入した国・地域（ISO 3166-1 alpha-2）。未指定の場合はすべて
+type CustomsValuesInput struct {
+	From    string
+	To      string
+	Country string
+}
+
+// 購入した国・地域と購入年ごとの申告額（購入価格）の集計。税関・税務申告の確認に使う
+// 購入した国・地域が未設定のアイテムは含めない
+type CustomsValueReport struct {
+	Countries []CountryCustomsValue `json:"countries"`
+}
+
+type CountryCustomsValue struct {
+	Country  string             `json:"country"`
+	Count    int                `json:"count"`
+	TotalJPY int64              `json:"total_jpy"` // 円換算額の合計
+	Years    []YearCustomsValue `json:"years"`
+}
+
+type YearCustomsValue struct {
+	Year     int             `json:"year"`
+	Count    int             `json:"count"`
+	TotalJPY int64           `json:"total_jpy"` // 円換算額の合計
+	Totals   []CurrencyTotal `json:"totals"`    // 通貨ごとの購入価格の合計
+}
+
+func (u *reportUsecase) GetCustomsValues(ctx context.Context, input CustomsValuesInput) (*CustomsValueReport, error) {
+	from, to, country, err := parseCustomsValuesInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	fromDate, toDate := yearRangeDates(from, to)
+	totals, err := u.itemRepo.GetCustomsValueTotals(ctx, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customs values: %w", err)
+	}
+
+	// 集計結果は国・地域、年の順に並んでいる
+	report := &CustomsValueReport{Countries: []CountryCustomsValue{}}
+	for _, t := range totals {
+		if country != "" && t.Country != country {
+			continue
+		}
+
+		if n := len(report.Countries); n == 0 || report.Countries[n-1].Country != t.Country {
+			report.Countries = append(report.Countries, CountryCustomsValue{Country: t.Country, Years: []YearCustomsValue{}})
+		}
+		c := &report.Countries[len(report.Countries)-1]
+
+		if n := len(c.Years); n == 0 || c.Years[n-1].Year != t.Year {
+			c.Years = append(c.Years, YearCustomsValue{Year: t.Year, Totals: []CurrencyTotal{}})
+		}
+		y := &c.Years[len(c.Years)-1]
+
+		y.Count += t.Count
+		y.TotalJPY += t.TotalJPY
+		y.Totals = append(y.Totals, CurrencyTotal{Currency: t.Currency, Total: t.Total})
+		c.Count += t.Count
+		c.TotalJPY += t.TotalJPY
+	}
+
+	return report, nil
+}
+
+// 購入年（YYYY 形式）と国・地域コードを検証する（未指定の場合はゼロ値）
+func parseCustomsValuesInput(input CustomsValuesInput) (int, int, string, error) {
+	from, to, errs := parseYearRange(input.From, input.To)
+
+	country := entity.NormalizeCountryCode(input.Country)
+	if country != "" && !entity.IsValidCountryCode(country) {
+		errs = append(errs, "country must be a 2-letter country code (ISO 3166-1 alpha-2)")
+	}
+
+	if len(errs) > 0 {
+		return 0, 0, "", fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, strings.Join(errs, ", "))
+	}
+	return from, to, country, nil
+}
+
+// 集計期間の購入年（YYYY 形式、両端を含む）を検証する（未指定の場合はゼロ値）
+func parseYearRange(fromValue, toValue string) (int, int, []string) {
+	var errs []string
+	parseYear := func(name, value string) int {
+		if value == "" {
+			return 0
+		}
+		year, err := strconv.Atoi(value)
+		if err != nil || len(value) != 4 || year < 1 {
+			errs = append(errs, name+" must be in YYYY format")
+			return 0
+		}
+		return year
+	}
+
+	from := parseYear("from", fromValue)
+	to := parseYear("to", toValue)
+	if from != 0 && to != 0 && from > to {
+		errs = append(errs, "from must be before or equal to to")
+	}
+	return from, to, errs
+}
+
+// 購入年の期間を購入日の検索条件にする（終端は翌年の1月1日で、含まない）
+func yearRangeDates(from, to int) (string, string) {
+	var fromDate, toDate string
+	if from != 0 {
+		fromDate = fmt.Sprintf("%04d-01-01", from)
+	}
+	if to != 0 {
+		toDate = fmt.Sprintf("%04d-01-01", to+1)
+	}
+	return fromDate, toDate
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestReportUsecase_GetCustomsValues(t *testing.T) {
+	totals := []entity.CustomsValueTotal{
+		{Country: "FR", Year: 2023, Currency: "EUR", Count: 1, Total: 1200000, TotalJPY: 1740000},
+		{Country: "US", Year: 2023, Currency: "JPY", Count: 1, Total: 300000, TotalJPY: 300000},
+		{Country: "US", Year: 2023, Currency: "USD", Count: 2, Total: 750000, TotalJPY: 978750},
+		{Country: "US", Year: 2024, Currency: "USD", Count: 1, Total: 50000, TotalJPY: 75000},
+	}
+
+	tests := []struct {
+		name              string
+		input             CustomsValuesInput
+		setupMock         func(*MockItemRepository)
+		expectedCountries []CountryCustomsValue
+		expectedErr       error
+	}{
+		{
+			name:  "正常系: 国・地域と年ごとに集計する",
+			input: CustomsValuesInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetCustomsValueTotals", mock.Anything, "", "").Return(totals, nil)
+			},
+			expectedCountries: []CountryCustomsValue{
+				{Country: "FR", Count: 1, TotalJPY: 1740000, Years: []YearCustomsValue{
+					{Year: 2023, Count: 1, TotalJPY: 1740000, Totals: []CurrencyTotal{{Currency: "EUR", Total: 1200000}}},
+				}},
+				{Country: "US", Count: 4, TotalJPY: 1353750, Years: []YearCustomsValue{
+					{Year: 2023, Count: 3, TotalJPY: 1278750, Totals: []CurrencyTotal{{Currency: "JPY", Total: 300000}, {Currency: "USD", Total: 750000}}},
+					{Year: 2024, Count: 1, TotalJPY: 75000, Totals: []CurrencyTotal{{Currency: "USD", Total: 50000}}},
+				}},
+			},
+		},
+		{
+			name:  "正常系: 期間と国・地域を指定（終端は翌年1月1日の前まで、小文字のコードも受け付ける）",
+			input: CustomsValuesInput{From: "2023", To: "2023", Country: "us"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetCustomsValueTotals", mock.Anything, "2023-01-01", "2024-01-01").Return(totals[:3], nil)
+			},
+			expectedCountries: []CountryCustomsValue{
+				{Country: "US", Count: 3, TotalJPY: 1278750, Years: []YearCustomsValue{
+					{Year: 2023, Count: 3, TotalJPY: 1278750, Totals: []CurrencyTotal{{Currency: "JPY", Total: 300000}, {Currency: "USD", Total: 750000}}},
+				}},
+			},
+		},
+		{
+			name:  "正常系: データなし",
+			input: CustomsValuesInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetCustomsValueTotals", mock.Anything, "", "").Return(nil, nil)
+			},
+			expectedCountries: []CountryCustomsValue{},
+		},
+		{
+			name:        "異常系: 無効な形式",
+			input:       CustomsValuesInput{From: "23", To: "2023-01", Country: "USA"},
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: from が to より後",
+			input:       CustomsValuesInput{From: "2024", To: "2023"},
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			input: CustomsValuesInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetCustomsValueTotals", mock.Anything, "", "").Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewReportUsecase(mockRepo, nil)
+
+			report, err := usecase.GetCustomsValues(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, report)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCountries, report.Countries)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 管理者ダッシュボードの集計期間のデフォルトと上限
+const (
+	DefaultDashboardWindow = 30 * 24 * time.Hour
+	MaxDashboardWindow     = 366 * 24 * time.Hour
+)
+
+// 操作者ごとの活動で返す件数のデフォルトと上限
+const (
+	DefaultDashboardActorLimit = 20
+	MaxDashboardActorLimit     = 100
+)
+
+// 集計期間（直近の期間、省略時は30日）と操作者の件数
+type DashboardActivityInput struct {
+	Window time.Duration
+	Limit  int
+}
+
+// 期間内の操作者の活動（定期実行などの system による変更は含めない）
+type DashboardActivity struct {
+	Since time.Time `json:"since"`
+	entity.ActivitySummary
+	Actors []*entity.ActorActivity `json:"actors"` // 変更の多い順
+}
+
+// 写真の保存容量（カテゴリーごと）
+type DashboardStorage struct {
+	Images       int                       `json:"images"`
+	Bytes        int64                     `json:"bytes"`
+	DeletedBytes int64                     `json:"deleted_bytes"`
+	Categories   []*entity.CategoryStorage `json:"categories"` // 容量の大きい順
+}
+
+// 期間内の Webhook の配信状況
+type DashboardWebhooks struct {
+	Since    time.Time               `json:"since"`
+	Webhooks []*entity.WebhookHealth `json:"webhooks"`
+}
+
+// 複数の操作者で使う環境の管理者向けの集計
+type DashboardUsecase interface {
+	GetActivity(ctx context.Context, input DashboardActivityInput) (*DashboardActivity, error)
+	GetStorage(ctx context.Context) (*DashboardStorage, error)
+	// 送信の成功率が低い、または送信待ちが溜まっている送信先の確認に使う
+	GetWebhookHealth(ctx context.Context, window time.Duration) (*DashboardWebhooks, error)
+}
+
+type dashboardUsecase struct {
+	dashboardRepo DashboardRepository
+	clock         entity.Clock
+}
+
+func NewDashboardUsecase(dashboardRepo DashboardRepository, clock entity.Clock) DashboardUsecase {
+	return &dashboardUsecase{
+		dashboardRepo: dashboardRepo,
+		clock:         clock,
+	}
+}
+
+func (u *dashboardUsecase) GetActivity(ctx context.Context, input DashboardActivityInput) (*DashboardActivity, error) {
+	since, err := u.dashboardSince(input.Window)
+	if err != nil {
+		return nil, err
+	}
+	limit := input.Limit
+	if limit == 0 {
+		limit = DefaultDashboardActorLimit
+	}
+	if limit < 0 || limit > MaxDashboardActorLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domainErrors.ErrInvalidInput, MaxDashboardActorLimit)
+	}
+
+	query := entity.ActivityQuery{
+		Since:         since,
+		ExcludeActors: []string{SystemActor},
+		Limit:         limit,
+	}
+	summary, err := u.dashboardRepo.SummarizeActivity(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize activity: %w", err)
+	}
+	actors, err := u.dashboardRepo.FindActorActivity(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve actor activity: %w", err)
+	}
+
+	return &DashboardActivity{Since: since, ActivitySummary: *summary, Actors: actors}, nil
+}
+
+func (u *dashboardUsecase) GetStorage(ctx context.Context) (*DashboardStorage, error) {
+	categories, err := u.dashboardRepo.FindStorageByCategory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve storage: %w", err)
+	}
+
+	storage := &DashboardStorage{Categories: categories}
+	for _, c := range categories {
+		storage.Images += c.Images
+		storage.Bytes += c.Bytes
+		storage.DeletedBytes += c.DeletedBytes
+	}
+	return storage, nil
+}
+
+func (u *dashboardUsecase) GetWebhookHealth(ctx context.Context, window time.Duration) (*DashboardWebhooks, error) {
+	since, err := u.dashboardSince(window)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := u.dashboardRepo.FindWebhookHealth(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook health: %w", err)
+	}
+	for _, w := range webhooks {
+		w.ComputeSuccessRate()
+	}
+
+	return &DashboardWebhooks{Since: since, Webhooks: webhooks}, nil
+}
+
+// 集計期間の開始日時（0 の場合はデフォルトの期間）
+func (u *dashboardUsecase) dashboardSince(window time.Duration) (time.Time, error) {
+	if window == 0 {
+		window = DefaultDashboardWindow
+	}
+	if window < 0 || window > MaxDashboardWindow {
+		return time.Time{}, fmt.Errorf("%w: window must be between 1s and %s", domainErrors.ErrInvalidInput, MaxDashboardWindow)
+	}
+	return u.clock.Now().Add(-window), nil
+}
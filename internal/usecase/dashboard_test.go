@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 集計結果を返し、受け取った条件を記録する
+type fakeDashboardRepository struct {
+	summary  entity.ActivitySummary
+	actors   []*entity.ActorActivity
+	storages []*entity.CategoryStorage
+	webhooks []*entity.WebhookHealth
+
+	query entity.ActivityQuery
+	since time.Time
+}
+
+func (r *fakeDashboardRepository) SummarizeActivity(ctx context.Context, query entity.ActivityQuery) (*entity.ActivitySummary, error) {
+	r.query = query
+	summary := r.summary
+	return &summary, nil
+}
+
+func (r *fakeDashboardRepository) FindActorActivity(ctx context.Context, query entity.ActivityQuery) ([]*entity.ActorActivity, error) {
+	r.query = query
+	return r.actors, nil
+}
+
+func (r *fakeDashboardRepository) FindStorageByCategory(ctx context.Context) ([]*entity.CategoryStorage, error) {
+	return r.storages, nil
+}
+
+func (r *fakeDashboardRepository) FindWebhookHealth(ctx context.Context, since time.Time) ([]*entity.WebhookHealth, error) {
+	r.since = since
+	return r.webhooks, nil
+}
+
+func TestDashboardUsecase_GetActivity(t *testing.T) {
+	now := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		input     DashboardActivityInput
+		wantSince time.Time
+		wantLimit int
+		wantErr   bool
+	}{
+		{name: "正常系: 省略時は直近30日・デフォルトの件数", wantSince: now.AddDate(0, 0, -30), wantLimit: DefaultDashboardActorLimit},
+		{name: "正常系: 期間と件数を指定", input: DashboardActivityInput{Window: 24 * time.Hour, Limit: 5}, wantSince: now.AddDate(0, 0, -1), wantLimit: 5},
+		{name: "異常系: 上限を超える期間", input: DashboardActivityInput{Window: MaxDashboardWindow + time.Hour}, wantErr: true},
+		{name: "異常系: 負の期間", input: DashboardActivityInput{Window: -time.Hour}, wantErr: true},
+		{name: "異常系: 上限を超える件数", input: DashboardActivityInput{Limit: MaxDashboardActorLimit + 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeDashboardRepository{
+				summary: entity.ActivitySummary{ActiveUsers: 1, Changes: 3, ItemsCreated: 2},
+				actors:  []*entity.ActorActivity{{Actor: "alice", Changes: 3, ItemsCreated: 2, LastActiveAt: now}},
+			}
+			usecase := NewDashboardUsecase(repo, entity.FixedClock(now))
+
+			activity, err := usecase.GetActivity(context.Background(), tt.input)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSince, activity.Since)
+			assert.Equal(t, 1, activity.ActiveUsers)
+			assert.Len(t, activity.Actors, 1)
+			assert.Equal(t, tt.wantSince, repo.query.Since)
+			assert.Equal(t, tt.wantLimit, repo.query.Limit)
+			assert.Equal(t, []string{SystemActor}, repo.query.ExcludeActors)
+		})
+	}
+}
+
+func TestDashboardUsecase_GetStorage(t *testing.T) {
+	repo := &fakeDashboardRepository{storages: []*entity.CategoryStorage{
+		{Category: "時計", Images: 2, Bytes: 300},
+		{Category: "バッグ", Images: 1, Bytes: 50, DeletedBytes: 50},
+	}}
+	usecase := NewDashboardUsecase(repo, entity.SystemClock)
+
+	storage, err := usecase.GetStorage(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, storage.Images)
+	assert.Equal(t, int64(350), storage.Bytes)
+	assert.Equal(t, int64(50), storage.DeletedBytes)
+	assert.Len(t, storage.Categories, 2)
+}
+
+func TestDashboardUsecase_GetWebhookHealth(t *testing.T) {
+	now := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+	repo := &fakeDashboardRepository{webhooks: []*entity.WebhookHealth{
+		{WebhookID: 1, Deliveries: 5, Succeeded: 3, Failed: 1, Pending: 1},
+		{WebhookID: 2, Deliveries: 1, Pending: 1},
+	}}
+	usecase := NewDashboardUsecase(repo, entity.FixedClock(now))
+
+	health, err := usecase.GetWebhookHealth(context.Background(), 6*time.Hour)
+
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-6*time.Hour), repo.since)
+	require.Len(t, health.Webhooks, 2)
+	require.NotNil(t, health.Webhooks[0].SuccessRate)
+	assert.InDelta(t, 0.75, *health.Webhooks[0].SuccessRate, 1e-9)
+	// 送信を終えた配信がない場合は成功率を返さない
+	assert.Nil(t, health.Webhooks[1].SuccessRate)
+}
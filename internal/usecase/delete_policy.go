@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// アイテム削除時の、紐づくデータ（写真・評価額・タグ・保険の契約）の扱い
+type DeletePolicy string
+
+const (
+	// アイテムと同じトランザクションで紐づくデータも削除する
+	DeleteCascade DeletePolicy = "cascade"
+	// アイテムのみ削除し、紐づくデータは保持期間の経過後にクリーンアップで削除する（保持期間中は復元できる）
+	DeleteOrphan DeletePolicy = "orphan"
+	// 紐づくデータがある場合は削除を拒否する
+	DeleteBlock DeletePolicy = "block"
+)
+
+func (p DeletePolicy) IsValid() bool {
+	return p == DeleteCascade || p == DeleteOrphan || p == DeleteBlock
+}
+
+// クリーンアップで1回に取得するアイテム数
+const orphanCleanupBatchSize = 100
+
+// 孤立データのクリーンアップ結果
+type OrphanCleanupResult struct {
+	Items  int `json:"items"`  // 紐づくデータを削除したアイテム数
+	Images int `json:"images"` // 削除した写真のファイル数
+}
+
+// 削除時の紐づくデータの扱いを指定する
+// storage は削除した写真のファイルの削除に使う（nil の場合はファイルを残す）
+func WithDeletePolicy(policy DeletePolicy, dependentsRepo ItemDependentsRepository, transactor Transactor, storage ImageStorage) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.deletePolicy = policy
+		u.dependentsRepo = dependentsRepo
+		u.transactor = transactor
+		u.imageStorage = storage
+	}
+}
+
+// 削除ポリシーに従ってアイテムを削除する（紐づくデータの削除・確認とあわせて1つのトランザクションで行う）
+func (u *itemUsecase) deleteWithDependents(ctx context.Context, id int64) error {
+	now := u.clock.Now()
+	if u.dependentsRepo == nil || u.deletePolicy == DeleteOrphan {
+		return u.itemRepo.Delete(ctx, id, now)
+	}
+
+	var removedKeys []string
+	err := runInTransaction(ctx, u.transactor, func(ctx context.Context) error {
+		switch u.deletePolicy {
+		case DeleteBlock:
+			dependents, err := u.dependentsRepo.CountByItemID(ctx, id)
+			if err != nil {
+				return err
+			}
+			if dependents.Total() > 0 {
+				return fmt.Errorf("%w: %d images, %d valuations, %d tags, %d insurance policies", domainErrors.ErrItemHasDependents,
+					dependents.Images, dependents.Valuations, dependents.Tags, dependents.InsurancePolicies)
+			}
+		case DeleteCascade:
+			keys, err := u.dependentsRepo.DeleteByItemID(ctx, id)
+			if err != nil {
+				return err
+			}
+			removedKeys = keys
+		}
+
+		return u.itemRepo.Delete(ctx, id, now)
+	})
+	if err != nil {
+		return err
+	}
+
+	// コミット後にファイルを削除する（メタデータは削除済みのため、失敗しても参照されることはない）
+	u.deleteImageFiles(ctx, removedKeys)
+
+	return nil
+}
+
+// 削除から保持期間が経過したアイテムの、紐づくデータを削除する
+func (u *itemUsecase) CleanupOrphans(ctx context.Context, retention time.Duration) (*OrphanCleanupResult, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	if retention < 0 {
+		return nil, fmt.Errorf("%w: retention must be 0 or greater", domainErrors.ErrInvalidInput)
+	}
+
+	result := &OrphanCleanupResult{}
+	if u.dependentsRepo == nil {
+		return result, nil
+	}
+
+	deletedBefore := u.clock.Now().Add(-retention)
+	for {
+		itemIDs, err := u.dependentsRepo.FindOrphanedItemIDs(ctx, deletedBefore, orphanCleanupBatchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to find orphaned data: %w", err)
+		}
+
+		for _, id := range itemIDs {
+			var removedKeys []string
+			err := runInTransaction(ctx, u.transactor, func(ctx context.Context) error {
+				keys, err := u.dependentsRepo.DeleteByItemID(ctx, id)
+				removedKeys = keys
+				return err
+			})
+			if err != nil {
+				return result, fmt.Errorf("failed to delete data of item %d: %w", id, err)
+			}
+
+			u.deleteImageFiles(ctx, removedKeys)
+			result.Items++
+			result.Images += len(removedKeys)
+		}
+
+		if len(itemIDs) < orphanCleanupBatchSize {
+			return result, nil
+		}
+	}
+}
+
+func (u *itemUsecase) deleteImageFiles(ctx context.Context, keys []string) {
+	if u.imageStorage == nil {
+		return
+	}
+	for _, key := range keys {
+		_ = u.imageStorage.Delete(ctx, key)
+	}
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockItemDependentsRepository はアイテムに紐づくデータのモックリポジトリ
+type MockItemDependentsRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemDependentsRepository) CountByItemID(ctx context.Context, itemID int64) (*entity.ItemDependents, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemDependents), args.Error(1)
+}
+
+func (m *MockItemDependentsRepository) DeleteByItemID(ctx context.Context, itemID int64) ([]string, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockItemDependentsRepository) FindOrphanedItemIDs(ctx context.Context, deletedBefore time.Time, limit int) ([]int64, error) {
+	args := m.Called(ctx, deletedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+// fakeTransactor は関数をそのまま実行し、実行した回数とロールバックされたかを記録する
+type fakeTransactor struct {
+	calls      int
+	rolledBack bool
+}
+
+func (f *fakeTransactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.calls++
+	err := fn(ctx)
+	f.rolledBack = err != nil
+	return err
+}
+
+func TestItemUsecase_DeleteItem_DeletePolicy(t *testing.T) {
+	tests := []struct {
+		name               string
+		policy             DeletePolicy
+		setupMock          func(*MockItemRepository, *MockItemDependentsRepository, *MockImageStorage)
+		expectedErr        error
+		expectedRolledBack bool
+	}{
+		{
+			name:   "正常系: cascade は紐づくデータとファイルも削除",
+			policy: DeleteCascade,
+			setupMock: func(itemRepo *MockItemRepository, depsRepo *MockItemDependentsRepository, storage *MockImageStorage) {
+				depsRepo.On("DeleteByItemID", mock.Anything, int64(1)).Return([]string{"items/1/a.jpg", "items/1/b.jpg"}, nil)
+				itemRepo.On("Delete", mock.Anything, int64(1), mock.Anything).Return(nil)
+				storage.On("Delete", mock.Anything, "items/1/a.jpg").Return(nil)
+				storage.On("Delete", mock.Anything, "items/1/b.jpg").Return(errors.New("not found"))
+			},
+		},
+		{
+			name:   "異常系: cascade でアイテムの削除に失敗した場合はファイルを残す",
+			policy: DeleteCascade,
+			setupMock: func(itemRepo *MockItemRepository, depsRepo *MockItemDependentsRepository, _ *MockImageStorage) {
+				depsRepo.On("DeleteByItemID", mock.Anything, int64(1)).Return([]string{"items/1/a.jpg"}, nil)
+				itemRepo.On("Delete", mock.Anything, int64(1), mock.Anything).Return(domainErrors.ErrDatabaseError)
+			},
+			expectedErr:        domainErrors.ErrDatabaseError,
+			expectedRolledBack: true,
+		},
+		{
+			name:   "正常系: orphan はアイテムのみ削除",
+			policy: DeleteOrphan,
+			setupMock: func(itemRepo *MockItemRepository, _ *MockItemDependentsRepository, _ *MockImageStorage) {
+				itemRepo.On("Delete", mock.Anything, int64(1), mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:   "正常系: block は紐づくデータがなければ削除",
+			policy: DeleteBlock,
+			setupMock: func(itemRepo *MockItemRepository, depsRepo *MockItemDependentsRepository, _ *MockImageStorage) {
+				depsRepo.On("CountByItemID", mock.Anything, int64(1)).Return(&entity.ItemDependents{}, nil)
+				itemRepo.On("Delete", mock.Anything, int64(1), mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:   "異常系: block は紐づくデータがあれば拒否",
+			policy: DeleteBlock,
+			setupMock: func(_ *MockItemRepository, depsRepo *MockItemDependentsRepository, _ *MockImageStorage) {
+				depsRepo.On("CountByItemID", mock.Anything, int64(1)).Return(&entity.ItemDependents{Images: 2, Valuations: 1}, nil)
+			},
+			expectedErr:        domainErrors.ErrItemHasDependents,
+			expectedRolledBack: true,
+		},
+		{
+			name:   "異常系: block は保険の契約のみでも拒否",
+			policy: DeleteBlock,
+			setupMock: func(_ *MockItemRepository, depsRepo *MockItemDependentsRepository, _ *MockImageStorage) {
+				depsRepo.On("CountByItemID", mock.Anything, int64(1)).Return(&entity.ItemDependents{InsurancePolicies: 1}, nil)
+			},
+			expectedErr:        domainErrors.ErrItemHasDependents,
+			expectedRolledBack: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			depsRepo := new(MockItemDependentsRepository)
+			storage := new(MockImageStorage)
+			transactor := &fakeTransactor{}
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+			tt.setupMock(itemRepo, depsRepo, storage)
+
+			usecase := NewItemUsecase(itemRepo, WithDeletePolicy(tt.policy, depsRepo, transactor, storage))
+			err := usecase.DeleteItem(context.Background(), 1)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedRolledBack, transactor.rolledBack)
+			itemRepo.AssertExpectations(t)
+			depsRepo.AssertExpectations(t)
+			storage.AssertExpectations(t)
+			if tt.expectedErr != nil {
+				storage.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestItemUsecase_CleanupOrphans(t *testing.T) {
+	t.Run("正常系: 保持期間が経過したアイテムのデータを削除", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		depsRepo := new(MockItemDependentsRepository)
+		storage := new(MockImageStorage)
+
+		depsRepo.On("FindOrphanedItemIDs", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) > 29*24*time.Hour
+		}), orphanCleanupBatchSize).Return([]int64{3, 5}, nil)
+		depsRepo.On("DeleteByItemID", mock.Anything, int64(3)).Return([]string{"items/3/a.jpg"}, nil)
+		depsRepo.On("DeleteByItemID", mock.Anything, int64(5)).Return([]string{}, nil)
+		storage.On("Delete", mock.Anything, "items/3/a.jpg").Return(nil)
+
+		usecase := NewItemUsecase(itemRepo, WithDeletePolicy(DeleteOrphan, depsRepo, &fakeTransactor{}, storage))
+		result, err := usecase.CleanupOrphans(context.Background(), 30*24*time.Hour)
+
+		require.NoError(t, err)
+		assert.Equal(t, &OrphanCleanupResult{Items: 2, Images: 1}, result)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 負の保持期間", func(t *testing.T) {
+		usecase := NewItemUsecase(new(MockItemRepository))
+		result, err := usecase.CleanupOrphans(context.Background(), -time.Hour)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Nil(t, result)
+	})
+
+	t.Run("異常系: 読み取り専用モード", func(t *testing.T) {
+		depsRepo := new(MockItemDependentsRepository)
+		usecase := NewItemUsecase(new(MockItemRepository),
+			WithReadOnlySwitch(NewReadOnlySwitch(true)),
+			WithDeletePolicy(DeleteOrphan, depsRepo, &fakeTransactor{}, nil),
+		)
+		_, err := usecase.CleanupOrphans(context.Background(), time.Hour)
+
+		assert.ErrorIs(t, err, domainErrors.ErrReadOnly)
+		depsRepo.AssertNotCalled(t, "FindOrphanedItemIDs", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type DepreciationInput struct {
+	Method string // 未指定の場合は定額法
+	Years  int    // 耐用年数（custom の場合は不要）
+	Rates  []int  // custom の場合の年ごとの償却率（%）
+	AsOf   string // 帳簿価額の基準日（未指定の場合は当日）
+}
+
+// アイテムの減価償却の予定と基準日時点の帳簿価額
+type Depreciation struct {
+	ItemID int64 `json:"item_id"`
+	*entity.DepreciationSchedule
+	AsOf      string       `json:"as_of"`
+	BookValue entity.Money `json:"book_value"`
+}
+
+// 購入価格と購入日から減価償却の予定を求める（会計用の出力など）
+func (u *itemUsecase) GetDepreciation(ctx context.Context, id int64, input DepreciationInput) (*Depreciation, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	method := entity.DepreciationMethod(strings.ToLower(strings.TrimSpace(input.Method)))
+	if method == "" {
+		method = entity.DepreciationStraight
+	}
+
+	asOf := input.AsOf
+	if asOf == "" {
+		asOf = entity.Today(u.clock)
+	} else if _, err := time.Parse("2006-01-02", asOf); err != nil {
+		return nil, fmt.Errorf("%w: as_of must be in YYYY-MM-DD format", domainErrors.ErrInvalidInput)
+	}
+
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	schedule, err := entity.NewDepreciationSchedule(item.PurchasePrice, item.PurchaseDate, method, input.Years, input.Rates)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	return &Depreciation{
+		ItemID:               item.ID,
+		DepreciationSchedule: schedule,
+		AsOf:                 asOf,
+		BookValue:            schedule.BookValueAt(asOf),
+	}, nil
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_GetDepreciation(t *testing.T) {
+	item, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), entity.MustParseDate("2023-01-15"), time.Now())
+	item.ID = 1
+
+	tests := []struct {
+		name              string
+		id                int64
+		input             DepreciationInput
+		setupMock         func(*MockItemRepository)
+		expectedBookValue entity.Money
+		expectedPeriods   int
+		expectedErr       error
+	}{
+		{
+			name:  "正常系: 定額法（方法未指定）",
+			id:    1,
+			input: DepreciationInput{Years: 5, AsOf: "2025-01-14"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			expectedBookValue: entity.JPY(900000),
+			expectedPeriods:   5,
+		},
+		{
+			name:  "正常系: 償却率を指定",
+			id:    1,
+			input: DepreciationInput{Method: "custom", Rates: []int{50, 50}, AsOf: "2024-01-14"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			expectedBookValue: entity.JPY(750000),
+			expectedPeriods:   2,
+		},
+		{
+			name:  "異常系: 耐用年数が未指定",
+			id:    1,
+			input: DepreciationInput{Method: "straight"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 基準日の形式が不正",
+			id:          1,
+			input:       DepreciationInput{Years: 5, AsOf: "2025/01/14"},
+			setupMock:   func(*MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 存在しないアイテム",
+			id:    999,
+			input: DepreciationInput{Years: 5},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			depreciation, err := usecase.GetDepreciation(context.Background(), tt.id, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, depreciation)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), depreciation.ItemID)
+			assert.Equal(t, tt.input.AsOf, depreciation.AsOf)
+			assert.Equal(t, tt.expectedBookValue, depreciation.BookValue)
+			assert.Len(t, depreciation.Periods, tt.expectedPeriods)
+		})
+	}
+}
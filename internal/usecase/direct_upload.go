@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 直接アップロードの署名付きURLのデフォルトの有効期限
+const DefaultDirectUploadURLExpiry = 15 * time.Minute
+
+// クライアントが署名付きURLで直接アップロードできる写真の保存先（S3 など）
+type DirectUploadStorage interface {
+	// PresignPut returns a URL that accepts a PUT of the content with the content type and size under the key until it expires
+	PresignPut(ctx context.Context, key, contentType string, size int64, expires time.Duration) (string, error)
+
+	// Size returns the size of the content stored under the key, returning ErrUploadNotFound if it does not exist
+	Size(ctx context.Context, key string) (int64, error)
+}
+
+type CreateDirectUploadInput struct {
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+// 直接アップロードの送信先（url に method で、headers を付けて写真を送る）
+type DirectUpload struct {
+	Key       string            `json:"key"`
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+type CompleteDirectUploadInput struct {
+	Key      string `json:"key"`
+	FileName string `json:"file_name"`
+}
+
+// 直接アップロードで発行する保存先の形式（imageKey で生成したキー）
+var directUploadKeyPattern = regexp.MustCompile(`^items/(\d+)/[0-9a-f]{32}\.[a-z]+$`)
+
+// 写真のバイト列をAPIサーバーを経由せず、署名付きURLで保存先に直接アップロードできるようにする
+func WithDirectUploads(storage DirectUploadStorage, expiry time.Duration) ImageUsecaseOption {
+	return func(u *imageUsecase) {
+		u.directStorage = storage
+		u.directUploadURLExpiry = expiry
+	}
+}
+
+// 直接アップロードの署名付きURLを発行する（MIMEタイプ・サイズ・利用上限は発行時にも確認する）
+func (u *imageUsecase) CreateDirectUpload(ctx context.Context, itemID int64, input CreateDirectUploadInput) (*DirectUpload, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+	if u.directStorage == nil {
+		return nil, fmt.Errorf("%w: direct uploads are not supported by the image storage", domainErrors.ErrInvalidInput)
+	}
+
+	if _, err := u.findItem(ctx, itemID); err != nil {
+		return nil, err
+	}
+	if !entity.IsAllowedImageContentType(input.ContentType) {
+		return nil, fmt.Errorf("%w: content_type must be one of: %s", domainErrors.ErrInvalidInput, strings.Join(entity.AllowedImageContentTypes, ", "))
+	}
+	if input.Size <= 0 {
+		return nil, fmt.Errorf("%w: size must be greater than 0", domainErrors.ErrInvalidInput)
+	}
+	if input.Size > u.maxSize {
+		return nil, fmt.Errorf("%w: image must be %d bytes or smaller", domainErrors.ErrInvalidInput, u.maxSize)
+	}
+	if err := u.quota.CheckStorage(ctx, input.Size); err != nil {
+		return nil, err
+	}
+
+	key, err := imageKey(itemID, input.ContentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate image key: %w", err)
+	}
+	url, err := u.directStorage.PresignPut(ctx, key, input.ContentType, input.Size, u.directUploadURLExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign upload: %w", err)
+	}
+
+	return &DirectUpload{
+		Key:       key,
+		URL:       url,
+		Method:    http.MethodPut,
+		Headers:   map[string]string{"Content-Type": input.ContentType},
+		ExpiresAt: u.clock.Now().Add(u.directUploadURLExpiry),
+	}, nil
+}
+
+// 直接アップロードされたファイルを確認し、写真として登録する
+// 内容が写真として受け付けられない場合はファイルを削除する（署名付きURLの発行からやり直す）
+func (u *imageUsecase) CompleteDirectUpload(ctx context.Context, itemID int64, input CompleteDirectUploadInput) (*entity.ItemImage, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+	if u.directStorage == nil {
+		return nil, fmt.Errorf("%w: direct uploads are not supported by the image storage", domainErrors.ErrInvalidInput)
+	}
+
+	item, err := u.findItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	fileName := strings.TrimSpace(input.FileName)
+	if utf8.RuneCountInString(fileName) > entity.MaxUploadFileNameLength {
+		return nil, fmt.Errorf("%w: file_name must be %d characters or less", domainErrors.ErrInvalidInput, entity.MaxUploadFileNameLength)
+	}
+	// 他のアイテムに発行したキーは存在しないものとして扱う
+	match := directUploadKeyPattern.FindStringSubmatch(input.Key)
+	if match == nil || match[1] != strconv.FormatInt(itemID, 10) {
+		return nil, domainErrors.ErrUploadNotFound
+	}
+
+	size, err := u.directStorage.Size(ctx, input.Key)
+	if err != nil {
+		if domainErrors.IsUploadNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve upload: %w", err)
+	}
+
+	contentType, err := u.verifyDirectUpload(ctx, input.Key, size)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			_ = u.storage.Delete(ctx, input.Key)
+		}
+		return nil, err
+	}
+	// 発行後に他の写真が登録されている場合があるため、登録時にも利用上限を確認する
+	if err := u.quota.CheckStorage(ctx, size); err != nil {
+		return nil, err
+	}
+
+	image, err := u.imageRepo.Create(ctx, &entity.ItemImage{
+		ItemID:      itemID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  input.Key,
+	})
+	if err != nil {
+		// 登録済みの写真のファイルは削除しない
+		if errors.Is(err, domainErrors.ErrDuplicateEntry) {
+			return nil, fmt.Errorf("%w: upload is already attached", domainErrors.ErrInvalidInput)
+		}
+		return nil, fmt.Errorf("failed to create image: %w", err)
+	}
+	image.URL = u.storage.URL(image.StorageKey)
+	if u.suggestsFor(item) {
+		image.Suggestions = u.storedPhotoSuggestions(ctx, image.StorageKey)
+	}
+
+	return image, nil
+}
+
+// アップロードされたファイルのサイズと、内容から判定したMIMEタイプを確認する
+// MIMEタイプは発行時に指定されたもの（キーの拡張子）と一致する必要がある
+func (u *imageUsecase) verifyDirectUpload(ctx context.Context, key string, size int64) (string, error) {
+	if size <= 0 {
+		return "", fmt.Errorf("%w: image is empty", domainErrors.ErrInvalidInput)
+	}
+	if size > u.maxSize {
+		return "", fmt.Errorf("%w: image must be %d bytes or smaller", domainErrors.ErrInvalidInput, u.maxSize)
+	}
+
+	content, err := u.storage.Open(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to read upload: %w", err)
+	}
+	defer content.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("failed to read upload: %w", err)
+	}
+	contentType := http.DetectContentType(head[:n])
+	if !entity.IsAllowedImageContentType(contentType) || entity.ImageExtension(contentType) != path.Ext(key) {
+		return "", fmt.Errorf("%w: uploaded content does not match the requested content type", domainErrors.ErrInvalidInput)
+	}
+
+	return contentType, nil
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockDirectUploadStorage は署名付きURLを発行できるモックストレージ
+type MockDirectUploadStorage struct {
+	mock.Mock
+}
+
+func (m *MockDirectUploadStorage) PresignPut(ctx context.Context, key, contentType string, size int64, expires time.Duration) (string, error) {
+	args := m.Called(ctx, key, contentType, size, expires)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockDirectUploadStorage) Size(ctx context.Context, key string) (int64, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestImageUsecase_CreateDirectUpload(t *testing.T) {
+	t.Run("正常系: 発行したキーへの署名付きURLを返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		direct := new(MockDirectUploadStorage)
+
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		direct.On("PresignPut", mock.Anything, mock.MatchedBy(func(key string) bool {
+			return directUploadKeyPattern.MatchString(key) && strings.HasPrefix(key, "items/1/") && strings.HasSuffix(key, ".png")
+		}), "image/png", int64(2048), 10*time.Minute).Return("https://bucket.s3.example.com/items/1/x.png?X-Amz-Signature=abc", nil)
+
+		usecase := NewImageUsecase(itemRepo, new(MockItemImageRepository), new(MockImageStorage), WithDirectUploads(direct, 10*time.Minute))
+		upload, err := usecase.CreateDirectUpload(context.Background(), 1, CreateDirectUploadInput{ContentType: "image/png", Size: 2048})
+
+		require.NoError(t, err)
+		assert.Equal(t, "PUT", upload.Method)
+		assert.Equal(t, map[string]string{"Content-Type": "image/png"}, upload.Headers)
+		assert.Contains(t, upload.URL, "X-Amz-Signature")
+		assert.WithinDuration(t, time.Now().Add(10*time.Minute), upload.ExpiresAt, time.Minute)
+	})
+
+	tests := []struct {
+		name  string
+		input CreateDirectUploadInput
+	}{
+		{name: "異常系: 画像以外のMIMEタイプ", input: CreateDirectUploadInput{ContentType: "application/pdf", Size: 2048}},
+		{name: "異常系: 空のファイル", input: CreateDirectUploadInput{ContentType: "image/png", Size: 0}},
+		{name: "異常系: 最大サイズを超える", input: CreateDirectUploadInput{ContentType: "image/png", Size: DefaultMaxImageSize + 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			direct := new(MockDirectUploadStorage)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+
+			usecase := NewImageUsecase(itemRepo, new(MockItemImageRepository), new(MockImageStorage), WithDirectUploads(direct, time.Minute))
+			_, err := usecase.CreateDirectUpload(context.Background(), 1, tt.input)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			direct.AssertNotCalled(t, "PresignPut", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("異常系: 署名付きURLを発行できない保存先", func(t *testing.T) {
+		usecase := NewImageUsecase(new(MockItemRepository), new(MockItemImageRepository), new(MockImageStorage))
+		_, err := usecase.CreateDirectUpload(context.Background(), 1, CreateDirectUploadInput{ContentType: "image/png", Size: 2048})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestImageUsecase_CompleteDirectUpload(t *testing.T) {
+	const key = "items/1/0123456789abcdef0123456789abcdef.png"
+
+	t.Run("正常系: アップロードされた内容を確認して写真を登録する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		imageRepo := new(MockItemImageRepository)
+		storage := new(MockImageStorage)
+		direct := new(MockDirectUploadStorage)
+
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		direct.On("Size", mock.Anything, key).Return(int64(len(testPNG)), nil)
+		storage.On("Open", mock.Anything, key).Return(string(testPNG), nil)
+		imageRepo.On("Create", mock.Anything, &entity.ItemImage{
+			ItemID:      1,
+			FileName:    "photo.png",
+			ContentType: "image/png",
+			Size:        int64(len(testPNG)),
+			StorageKey:  key,
+		}).Return(&entity.ItemImage{ID: 10, ItemID: 1, ContentType: "image/png", StorageKey: key}, nil)
+
+		usecase := NewImageUsecase(itemRepo, imageRepo, storage, WithDirectUploads(direct, time.Minute))
+		image, err := usecase.CompleteDirectUpload(context.Background(), 1, CompleteDirectUploadInput{Key: key, FileName: " photo.png "})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), image.ID)
+		assert.Equal(t, "/images/"+key, image.URL)
+		storage.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 他のアイテム・発行していない形式のキー", func(t *testing.T) {
+		for _, other := range []string{"items/2/0123456789abcdef0123456789abcdef.png", "exports/abc.zip", "items/1/../2/x.png"} {
+			itemRepo := new(MockItemRepository)
+			direct := new(MockDirectUploadStorage)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+
+			usecase := NewImageUsecase(itemRepo, new(MockItemImageRepository), new(MockImageStorage), WithDirectUploads(direct, time.Minute))
+			_, err := usecase.CompleteDirectUpload(context.Background(), 1, CompleteDirectUploadInput{Key: other})
+
+			assert.ErrorIs(t, err, domainErrors.ErrUploadNotFound, other)
+			direct.AssertNotCalled(t, "Size", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("異常系: まだアップロードされていない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		direct := new(MockDirectUploadStorage)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		direct.On("Size", mock.Anything, key).Return(int64(0), domainErrors.ErrUploadNotFound)
+
+		usecase := NewImageUsecase(itemRepo, new(MockItemImageRepository), new(MockImageStorage), WithDirectUploads(direct, time.Minute))
+		_, err := usecase.CompleteDirectUpload(context.Background(), 1, CompleteDirectUploadInput{Key: key})
+
+		assert.ErrorIs(t, err, domainErrors.ErrUploadNotFound)
+	})
+
+	t.Run("異常系: 発行時と異なる内容のファイルは削除する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		storage := new(MockImageStorage)
+		direct := new(MockDirectUploadStorage)
+
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		direct.On("Size", mock.Anything, key).Return(int64(12), nil)
+		storage.On("Open", mock.Anything, key).Return("not an image", nil)
+		storage.On("Delete", mock.Anything, key).Return(nil)
+
+		usecase := NewImageUsecase(itemRepo, new(MockItemImageRepository), storage, WithDirectUploads(direct, time.Minute))
+		_, err := usecase.CompleteDirectUpload(context.Background(), 1, CompleteDirectUploadInput{Key: key})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 最大サイズを超えるファイルは削除する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		storage := new(MockImageStorage)
+		direct := new(MockDirectUploadStorage)
+
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		direct.On("Size", mock.Anything, key).Return(int64(DefaultMaxImageSize+1), nil)
+		storage.On("Delete", mock.Anything, key).Return(nil)
+
+		usecase := NewImageUsecase(itemRepo, new(MockItemImageRepository), storage, WithDirectUploads(direct, time.Minute))
+		_, err := usecase.CompleteDirectUpload(context.Background(), 1, CompleteDirectUploadInput{Key: key})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		storage.AssertExpectations(t)
+		storage.AssertNotCalled(t, "Open", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 登録済みの写真のファイルは削除しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		imageRepo := new(MockItemImageRepository)
+		storage := new(MockImageStorage)
+		direct := new(MockDirectUploadStorage)
+
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		direct.On("Size", mock.Anything, key).Return(int64(len(testPNG)), nil)
+		storage.On("Open", mock.Anything, key).Return(string(testPNG), nil)
+		imageRepo.On("Create", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, domainErrors.ErrDuplicateEntry))
+
+		usecase := NewImageUsecase(itemRepo, imageRepo, storage, WithDirectUploads(direct, time.Minute))
+		_, err := usecase.CompleteDirectUpload(context.Background(), 1, CompleteDirectUploadInput{Key: key})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		storage.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 下書きを公開する（登録時と同じバリデーション・予算チェックを行い、集計の対象にする）
+func (u *itemUsecase) PublishItem(ctx context.Context, id int64) (*ItemResult, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	if err := ensureNotOnHold(item); err != nil {
+		return nil, err
+	}
+	before := entity.ItemAuditFields(item)
+
+	if err := item.Publish(u.clock.Now()); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	// 下書きの間は購入日が未入力で為替レートを取得できなかった場合がある
+	warnings := u.applyExchangeRates(ctx, item)
+
+	budgetWarnings, err := u.checkBudget(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, budgetWarnings...)
+
+	var publishedItem *entity.Item
+	err = u.mutate(ctx, func(ctx context.Context) error {
+		publishedItem, err = u.itemRepo.Update(ctx, item)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to publish item: %w", err)
+		}
+		return u.audit(ctx, id, entity.AuditPublish, before, entity.ItemAuditFields(publishedItem))
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			u.evictItem(ctx, id)
+		}
+		return nil, err
+	}
+	u.cacheItem(ctx, publishedItem)
+	u.snapshot(ctx, publishedItem)
+	purgeItems(ctx, u.cachePurger, publishedItem)
+	_ = u.attachValuations(ctx, publishedItem)
+	_ = u.attachTags(ctx, publishedItem)
+
+	return &ItemResult{Item: publishedItem, Warnings: warnings}, nil
+}
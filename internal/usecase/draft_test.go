@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_CreateItem_Draft(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	budgetRepo := new(MockBudgetRepository)
+	itemRepo.On("FindByDedupeKey", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	itemRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+		return item.Draft && item.PurchaseDate.IsZero() && item.Brand == ""
+	})).Return(&entity.Item{ID: 1, Draft: true}, nil)
+	usecase := NewItemUsecase(itemRepo, WithBudgetCheck(budgetRepo, BudgetBlock))
+
+	result, err := usecase.CreateItem(context.Background(), CreateItemInput{
+		Name:     "ロレックス デイトナ",
+		Category: "時計",
+		Draft:    true,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Draft)
+	itemRepo.AssertExpectations(t)
+	// 下書きは予算の対象外
+	budgetRepo.AssertNotCalled(t, "FindByCategory", mock.Anything, mock.Anything)
+}
+
+func TestItemUsecase_PublishItem(t *testing.T) {
+	newDraft := func(brand, purchaseDate string) *entity.Item {
+		item, _ := entity.NewDraftItem("ロレックス デイトナ", "時計", brand, entity.JPY(1500000), entity.MustParseDate(purchaseDate), time.Now())
+		item.ID = 1
+		return item
+	}
+	budget := &entity.CategoryBudget{Category: "時計", Amount: entity.JPY(2000000)}
+
+	tests := []struct {
+		name        string
+		item        *entity.Item
+		setupMock   func(*MockItemRepository, *MockBudgetRepository)
+		expectedErr error
+	}{
+		{
+			name: "正常系: 下書きを公開",
+			item: newDraft("ROLEX", "2023-01-15"),
+			setupMock: func(itemRepo *MockItemRepository, budgetRepo *MockBudgetRepository) {
+				budgetRepo.On("FindByCategory", mock.Anything, "時計").Return(budget, nil)
+				itemRepo.On("SumPurchasePrice", mock.Anything, "時計", "JPY", 2023, int64(1)).Return(int64(0), nil)
+				itemRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return !item.Draft
+				})).Return(&entity.Item{ID: 1}, nil)
+			},
+		},
+		{
+			name:        "異常系: 未入力の項目がある",
+			item:        newDraft("", ""),
+			setupMock:   func(*MockItemRepository, *MockBudgetRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: 公開すると予算を超える",
+			item: newDraft("ROLEX", "2023-01-15"),
+			setupMock: func(itemRepo *MockItemRepository, budgetRepo *MockBudgetRepository) {
+				budgetRepo.On("FindByCategory", mock.Anything, "時計").Return(budget, nil)
+				itemRepo.On("SumPurchasePrice", mock.Anything, "時計", "JPY", 2023, int64(1)).Return(int64(1000000), nil)
+			},
+			expectedErr: domainErrors.ErrBudgetExceeded,
+		},
+		{
+			name: "異常系: 公開済みのアイテム",
+			item: &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX",
+				PurchasePrice: entity.JPY(1500000), PurchaseDate: entity.MustParseDate("2023-01-15")},
+			setupMock:   func(*MockItemRepository, *MockBudgetRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			budgetRepo := new(MockBudgetRepository)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(tt.item, nil)
+			tt.setupMock(itemRepo, budgetRepo)
+			usecase := NewItemUsecase(itemRepo, WithBudgetCheck(budgetRepo, BudgetBlock))
+
+			result, err := usecase.PublishItem(context.Background(), 1)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.False(t, result.Draft)
+			}
+			itemRepo.AssertExpectations(t)
+			budgetRepo.AssertExpectations(t)
+		})
+	}
+}
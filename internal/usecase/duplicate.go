@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 警告に含める重複候補の上限
+const maxDuplicateWarnings = 3
+
+// 名前とブランドを正規化すると一致し、購入日も同じアイテムが登録済みであれば重複として拒否する
+func (u *itemUsecase) ensureNotDuplicate(ctx context.Context, item *entity.Item) error {
+	exists, err := u.itemRepo.ExistsSimilar(ctx, item.DedupeKey, item.PurchaseDate)
+	if err != nil {
+		return fmt.Errorf("failed to check duplicates: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("%w: an item with the same name, brand and purchase_date already exists", domainErrors.ErrDuplicateItem)
+	}
+	return nil
+}
+
+// 名前とブランドを正規化すると一致する登録済みのアイテムがあれば、二重登録の可能性を警告する
+// 登録は妨げないため、確認に失敗した場合も警告なしで登録を続ける
+func (u *itemUsecase) duplicateWarnings(ctx context.Context, item *entity.Item) []string {
+	duplicates, err := u.itemRepo.FindByDedupeKey(ctx, item.DedupeKey)
+	if err != nil {
+		return nil
+	}
+
+	var warnings []string
+	for i, duplicate := range duplicates {
+		if i == maxDuplicateWarnings {
+			warnings = append(warnings, fmt.Sprintf("%d more items with the same name and brand already exist", len(duplicates)-i))
+			break
+		}
+		warnings = append(warnings, fmt.Sprintf("possible duplicate of item %d: %s (%s)", duplicate.ID, duplicate.Name, duplicate.Brand))
+	}
+	return warnings
+}
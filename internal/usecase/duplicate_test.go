@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_CreateItem_DuplicateWarning(t *testing.T) {
+	input := CreateItemInput{
+		Name:          "ﾛﾚｯｸｽ　ﾃﾞｲﾄﾅ",
+		Category:      "時計",
+		Brand:         "Rolex",
+		PurchasePrice: entity.JPY(1500000),
+		PurchaseDate:  "2023-01-15",
+	}
+	existing, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), entity.MustParseDate("2023-01-15"), time.Now())
+	existing.ID = 1
+
+	tests := []struct {
+		name             string
+		duplicates       []*entity.Item
+		findErr          error
+		expectedWarnings []string
+	}{
+		{
+			name:             "正常系: 正規化した名前とブランドが一致するアイテムがあれば警告",
+			duplicates:       []*entity.Item{existing},
+			expectedWarnings: []string{"possible duplicate of item 1: ロレックス デイトナ (ROLEX)"},
+		},
+		{
+			name:       "正常系: 一致するアイテムがなければ警告なし",
+			duplicates: []*entity.Item{},
+		},
+		{
+			name:    "正常系: 確認に失敗しても登録する",
+			findErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			mockRepo.On("FindByDedupeKey", mock.Anything, existing.DedupeKey).Return(tt.duplicates, tt.findErr)
+			mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 2}, nil)
+			usecase := NewItemUsecase(mockRepo)
+
+			result, err := usecase.CreateItem(context.Background(), input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedWarnings, result.Warnings)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_CreateItem_Strict(t *testing.T) {
+	input := CreateItemInput{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: entity.JPY(1500000),
+		PurchaseDate:  "2023-01-15",
+		Strict:        true,
+	}
+	item, _ := entity.NewItem(input.Name, input.Category, input.Brand, input.PurchasePrice, entity.MustParseDate(input.PurchaseDate), time.Now())
+
+	tests := []struct {
+		name        string
+		exists      bool
+		existsErr   error
+		expectedErr error
+	}{
+		{
+			name: "正常系: 同じアイテムがなければ登録",
+		},
+		{
+			name:        "異常系: 名前・ブランド・購入日が同じアイテムがあれば拒否",
+			exists:      true,
+			expectedErr: domainErrors.ErrDuplicateItem,
+		},
+		{
+			name:        "異常系: 確認に失敗した場合は登録しない",
+			existsErr:   domainErrors.ErrDatabaseError,
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			mockRepo.On("ExistsSimilar", mock.Anything, item.DedupeKey, entity.MustParseDate("2023-01-15")).Return(tt.exists, tt.existsErr)
+			mockRepo.On("FindByDedupeKey", mock.Anything, mock.Anything).Return([]*entity.Item{}, nil)
+			mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 2}, nil)
+			usecase := NewItemUsecase(mockRepo)
+
+			result, err := usecase.CreateItem(context.Background(), input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), result.ID)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 為替レートの取得先（外部の為替APIなど）
+type ExchangeRateProvider interface {
+	// Rate returns the JPY amount per unit of the currency on the date as a decimal string
+	Rate(ctx context.Context, currency string, date entity.Date) (string, error)
+}
+
+// 外貨建てのアイテムに購入日時点の為替レートを設定し、円換算額を求める
+// レートを取得できなかったアイテムは円換算額なしのまま登録できるよう、エラーではなく警告を返す
+func (u *itemUsecase) applyExchangeRates(ctx context.Context, items ...*entity.Item) []string {
+	if u.fxProvider == nil {
+		return nil
+	}
+
+	var warnings []string
+	// 同じ通貨・購入日のレートは一度だけ取得する（取得に失敗した場合は空文字）
+	rates := make(map[string]string)
+	for _, item := range items {
+		if !item.NeedsExchangeRate() {
+			continue
+		}
+
+		key := item.PurchasePrice.Currency + "/" + item.PurchaseDate.String()
+		rate, fetched := rates[key]
+		if !fetched {
+			var err error
+			rate, err = u.fxProvider.Rate(ctx, item.PurchasePrice.Currency, item.PurchaseDate)
+			if err != nil {
+				rate = ""
+				warnings = append(warnings, fmt.Sprintf("exchange rate for %s on %s is unavailable: %s", item.PurchasePrice.Currency, item.PurchaseDate, err.Error()))
+			}
+			rates[key] = rate
+		}
+		if rate == "" {
+			continue
+		}
+
+		if err := item.SetExchangeRate(rate); err != nil {
+			rates[key] = ""
+			warnings = append(warnings, fmt.Sprintf("exchange rate for %s on %s is unavailable: %s", item.PurchasePrice.Currency, item.PurchaseDate, err.Error()))
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// MockExchangeRateProvider は為替レートのモック
+type MockExchangeRateProvider struct {
+	mock.Mock
+}
+
+func (m *MockExchangeRateProvider) Rate(ctx context.Context, currency string, date entity.Date) (string, error) {
+	args := m.Called(ctx, currency, date)
+	return args.String(0), args.Error(1)
+}
+
+func TestItemUsecase_CreateItem_ExchangeRate(t *testing.T) {
+	usdInput := CreateItemInput{
+		Name:          "オメガ スピードマスター",
+		Category:      "時計",
+		Brand:         "OMEGA",
+		PurchasePrice: entity.Money{Amount: 750000, Currency: "USD"},
+		PurchaseDate:  "2023-01-15",
+	}
+
+	tests := []struct {
+		name             string
+		input            CreateItemInput
+		setupProvider    func(*MockExchangeRateProvider)
+		expectedRate     string
+		expectedJPY      *entity.Money
+		expectedWarnings int
+	}{
+		{
+			name:  "正常系: 購入日のレートで円換算する",
+			input: usdInput,
+			setupProvider: func(p *MockExchangeRateProvider) {
+				p.On("Rate", mock.Anything, "USD", entity.MustParseDate("2023-01-15")).Return("130.5", nil)
+			},
+			expectedRate: "130.5",
+			expectedJPY:  &entity.Money{Amount: 978750, Currency: "JPY"},
+		},
+		{
+			name: "正常系: 円建てはレートを取得しない",
+			input: CreateItemInput{
+				Name:          "ロレックス デイトナ",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: entity.JPY(1500000),
+				PurchaseDate:  "2023-01-15",
+			},
+			setupProvider: func(p *MockExchangeRateProvider) {},
+			expectedJPY:   &entity.Money{Amount: 1500000, Currency: "JPY"},
+		},
+		{
+			name:  "正常系: レートを取得できない場合は警告を返して円換算額なしで登録",
+			input: usdInput,
+			setupProvider: func(p *MockExchangeRateProvider) {
+				p.On("Rate", mock.Anything, "USD", entity.MustParseDate("2023-01-15")).Return("", errors.New("timeout"))
+			},
+			expectedWarnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			provider := new(MockExchangeRateProvider)
+			tt.setupProvider(provider)
+			mockRepo.On("FindByDedupeKey", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+			// 登録されるアイテムを検証する
+			var created *entity.Item
+			mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+				created = item
+				return true
+			})).Return(&entity.Item{ID: 1}, nil)
+
+			usecase := NewItemUsecase(mockRepo, WithExchangeRateProvider(provider))
+			result, err := usecase.CreateItem(context.Background(), tt.input)
+
+			require.NoError(t, err)
+			require.NotNil(t, created)
+			assert.Equal(t, tt.expectedRate, created.ExchangeRate)
+			assert.Equal(t, tt.expectedJPY, created.PurchasePriceJPY)
+			assert.Len(t, result.Warnings, tt.expectedWarnings)
+			provider.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_applyExchangeRates_FetchesOncePerCurrencyAndDate(t *testing.T) {
+	provider := new(MockExchangeRateProvider)
+	provider.On("Rate", mock.Anything, "USD", entity.MustParseDate("2023-01-15")).Return("130", nil).Once()
+	provider.On("Rate", mock.Anything, "EUR", entity.MustParseDate("2023-01-15")).Return("", errors.New("not found")).Once()
+	u := &itemUsecase{fxProvider: provider}
+
+	var items []*entity.Item
+	for _, price := range []entity.Money{
+		{Amount: 100, Currency: "USD"},
+		{Amount: 200, Currency: "USD"},
+		{Amount: 300, Currency: "EUR"},
+		{Amount: 400, Currency: "EUR"},
+	} {
+		item, err := entity.NewItem("アイテム", "時計", "ブランド", price, entity.MustParseDate("2023-01-15"), time.Now())
+		require.NoError(t, err)
+		items = append(items, item)
+	}
+
+	warnings := u.applyExchangeRates(context.Background(), items...)
+
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, entity.JPY(130), *items[0].PurchasePriceJPY)
+	assert.Equal(t, entity.JPY(260), *items[1].PurchasePriceJPY)
+	assert.Nil(t, items[2].PurchasePriceJPY)
+	assert.Nil(t, items[3].PurchasePriceJPY)
+	provider.AssertExpectations(t)
+}
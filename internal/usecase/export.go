@@ -0,0 +1,323 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// エクスポート形式
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+	ExportFormatJSON = "json"
+)
+
+// エクスポート形式ごとのファイルの種類
+type ExportFileType struct {
+	ContentType string
+	Extension   string
+}
+
+var exportFileTypes = map[string]ExportFileType{
+	ExportFormatCSV:  {ContentType: "text/csv; charset=utf-8", Extension: ".csv"},
+	ExportFormatXLSX: {ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Extension: ".xlsx"},
+	ExportFormatJSON: {ContentType: "application/json", Extension: ".json"},
+}
+
+// 形式のファイルの種類（未対応の形式の場合は false）。空の場合は CSV
+func ExportFileTypeOf(format string) (ExportFileType, bool) {
+	if format == "" {
+		format = ExportFormatCSV
+	}
+	fileType, ok := exportFileTypes[format]
+	return fileType, ok
+}
+
+// エクスポート時に1回のクエリで読み込む件数（メモリ使用量を一定に保つため）
+const ExportBatchSize = 500
+
+// Excel で文字化けしないよう UTF-8 の先頭に付ける BOM
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// CSV・XLSXのヘッダー行
+var exportCSVHeader = []string{
+	"id", "name", "category", "brand", "purchase_price", "currency",
+	"purchase_date", "attributes", "created_at", "updated_at", "purchase_country",
+	"tax_amount", "tax_included",
+}
+
+type ExportItemsInput struct {
+	Format string
+	BOM    bool // CSV のみ
+
+	// 金額・日付の表記（未指定の場合は再インポートできる形式で出力する。JSON には適用しない）
+	Formatter ExportFormatter
+}
+
+// エクスポートする金額・日付をロケールに応じて表記する
+type ExportFormatter interface {
+	FormatMoney(m entity.Money) string
+	FormatDate(date entity.Date) string
+	FormatTime(t time.Time) string
+}
+
+func (u *itemUsecase) ExportItems(ctx context.Context, w io.Writer, input ExportItemsInput) error {
+	format := input.Format
+	if format == "" {
+		format = ExportFormatCSV
+	}
+	if _, ok := exportFileTypes[format]; !ok {
+		return fmt.Errorf("%w: format must be one of: %s, %s, %s", domainErrors.ErrInvalidInput, ExportFormatCSV, ExportFormatXLSX, ExportFormatJSON)
+	}
+
+	// 最初のバッチを読み込んでから書き込みを始める（DBエラー時にレスポンスを書き始めていないようにするため）
+	query := entity.ItemQuery{
+		Limit: ExportBatchSize,
+		Sort:  entity.SortByID,
+		Order: entity.SortAsc,
+	}
+	items, err := u.itemRepo.FindAll(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	writer, err := newExportWriter(w, format, input)
+	if err != nil {
+		return err
+	}
+
+	// IDをカーソルにして最後まで読み進める
+	for len(items) > 0 {
+		for _, item := range items {
+			if err := writer.write(item); err != nil {
+				return err
+			}
+		}
+		if err := writer.flush(); err != nil {
+			return err
+		}
+
+		if len(items) < ExportBatchSize {
+			break
+		}
+
+		query.AfterID = items[len(items)-1].ID
+		items, err = u.itemRepo.FindAll(ctx, query)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve items: %w", err)
+		}
+	}
+
+	return writer.close()
+}
+
+// 形式ごとの書き込み（バッチごとに flush し、最後に close する）
+type exportWriter interface {
+	write(item *entity.Item) error
+	flush() error
+	close() error
+}
+
+func newExportWriter(w io.Writer, format string, input ExportItemsInput) (exportWriter, error) {
+	switch format {
+	case ExportFormatXLSX:
+		return newXLSXExportWriter(w, input.Formatter)
+	case ExportFormatJSON:
+		return &jsonExportWriter{w: w}, nil
+	default:
+		return newCSVExportWriter(w, input.BOM, input.Formatter)
+	}
+}
+
+type csvExportWriter struct {
+	writer    *csv.Writer
+	formatter ExportFormatter
+}
+
+func newCSVExportWriter(w io.Writer, bom bool, formatter ExportFormatter) (*csvExportWriter, error) {
+	if bom {
+		if _, err := w.Write(utf8BOM); err != nil {
+			return nil, err
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportCSVHeader); err != nil {
+		return nil, err
+	}
+	return &csvExportWriter{writer: writer, formatter: formatter}, nil
+}
+
+func (e *csvExportWriter) write(item *entity.Item) error {
+	record, err := itemCSVRecord(item, e.formatter)
+	if err != nil {
+		return err
+	}
+	return e.writer.Write(record)
+}
+
+func (e *csvExportWriter) flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvExportWriter) close() error {
+	return e.flush()
+}
+
+// CSV と同じ列を1シートに書き込む（行はストリーミングで一時ファイルに書き出し、close でまとめて書き込む）
+type xlsxExportWriter struct {
+	w         io.Writer
+	file      *excelize.File
+	stream    *excelize.StreamWriter
+	formatter ExportFormatter
+	row       int
+}
+
+func newXLSXExportWriter(w io.Writer, formatter ExportFormatter) (*xlsxExportWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to create sheet: %w", err)
+	}
+
+	e := &xlsxExportWriter{w: w, file: file, stream: stream, formatter: formatter}
+	if err := e.writeRow(exportCSVHeader); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *xlsxExportWriter) writeRow(values []string) error {
+	e.row++
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		cells[i] = value
+	}
+	if err := e.stream.SetRow(cell, cells); err != nil {
+		return fmt.Errorf("failed to write sheet: %w", err)
+	}
+	return nil
+}
+
+func (e *xlsxExportWriter) write(item *entity.Item) error {
+	record, err := itemCSVRecord(item, e.formatter)
+	if err != nil {
+		return err
+	}
+	return e.writeRow(record)
+}
+
+func (e *xlsxExportWriter) flush() error {
+	return nil
+}
+
+func (e *xlsxExportWriter) close() error {
+	defer e.file.Close()
+
+	if err := e.stream.Flush(); err != nil {
+		return fmt.Errorf("failed to write sheet: %w", err)
+	}
+	if err := e.file.Write(e.w); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	return nil
+}
+
+// APIのレスポンスと同じ形式のアイテムの配列
+type jsonExportWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonExportWriter) write(item *entity.Item) error {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n"
+	if e.count == 0 {
+		separator = "[\n"
+	}
+	e.count++
+	if _, err := io.WriteString(e.w, separator); err != nil {
+		return err
+	}
+	_, err = e.w.Write(b)
+	return err
+}
+
+func (e *jsonExportWriter) flush() error {
+	return nil
+}
+
+func (e *jsonExportWriter) close() error {
+	closing := "\n]\n"
+	if e.count == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(e.w, closing)
+	return err
+}
+
+func itemCSVRecord(item *entity.Item, formatter ExportFormatter) ([]string, error) {
+	attributes := ""
+	if len(item.Attributes) > 0 {
+		b, err := json.Marshal(item.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		attributes = string(b)
+	}
+
+	purchasePrice := strconv.FormatInt(item.PurchasePrice.Amount, 10)
+	purchaseDate := item.PurchaseDate.String()
+	createdAt := item.CreatedAt.Format(time.RFC3339)
+	updatedAt := item.UpdatedAt.Format(time.RFC3339)
+	var taxAmount string
+	if item.TaxAmount != nil {
+		taxAmount = strconv.FormatInt(item.TaxAmount.Amount, 10)
+	}
+	if formatter != nil {
+		purchasePrice = formatter.FormatMoney(item.PurchasePrice)
+		purchaseDate = formatter.FormatDate(item.PurchaseDate)
+		createdAt = formatter.FormatTime(item.CreatedAt)
+		updatedAt = formatter.FormatTime(item.UpdatedAt)
+		if item.TaxAmount != nil {
+			taxAmount = formatter.FormatMoney(*item.TaxAmount)
+		}
+	}
+
+	return []string{
+		strconv.FormatInt(item.ID, 10),
+		item.Name,
+		item.Category,
+		item.Brand,
+		purchasePrice,
+		item.PurchasePrice.Currency,
+		purchaseDate,
+		attributes,
+		createdAt,
+		updatedAt,
+		item.PurchaseCountry,
+		taxAmount,
+		strconv.FormatBool(item.TaxIncluded),
+	}, nil
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 障害を注入する依存先
+type FaultTarget string
+
+const (
+	FaultRepository FaultTarget = "repository" // データベース（すべてのリポジトリ）
+	FaultStorage    FaultTarget = "storage"    // 写真の保存先
+	FaultNotifier   FaultTarget = "notifier"   // Webhook の送信
+)
+
+var FaultTargets = []FaultTarget{FaultRepository, FaultStorage, FaultNotifier}
+
+func (t FaultTarget) IsValid() bool {
+	for _, target := range FaultTargets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+// 注入できるエラー（名前と、依存先が返すドメインエラー）
+var FaultErrors = map[string]error{
+	"not_found":            domainErrors.ErrItemNotFound,
+	"conflict":             domainErrors.ErrDuplicateItem,
+	"read_only":            domainErrors.ErrReadOnly,
+	"budget_exceeded":      domainErrors.ErrBudgetExceeded,
+	"quota_exceeded":       domainErrors.ErrQuotaExceeded,
+	"on_hold":              domainErrors.ErrItemOnHold,
+	"upstream_unavailable": domainErrors.ErrMarketPriceUnavailable,
+	"db_unavailable":       fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, domainErrors.ErrTransient),
+	"database":             domainErrors.ErrDatabaseError,
+	"transient":            domainErrors.ErrTransient,
+}
+
+// 注入中の障害
+type Fault struct {
+	Target FaultTarget `json:"target"`
+	Error  string      `json:"error"`
+
+	// 残りの回数（0 の場合は解除するまで毎回エラーにする）
+	Remaining int `json:"remaining,omitempty"`
+}
+
+type SetFaultInput struct {
+	Error string `json:"error"`
+	Count int    `json:"count"` // エラーにする回数（省略・0 の場合は解除するまで）
+}
+
+// 依存先に指定したエラーを返させるスイッチ（クライアントのエラー処理を結合環境で確かめるため。本番環境では使わない）
+type FaultInjector struct {
+	mu     sync.Mutex
+	faults map[FaultTarget]*Fault
+}
+
+func NewFaultInjector() *FaultInjector {
+	return &FaultInjector{faults: make(map[FaultTarget]*Fault)}
+}
+
+// 障害を注入する（同じ依存先の障害は置き換える）
+func (f *FaultInjector) Set(target FaultTarget, input SetFaultInput) (*Fault, error) {
+	var errs entity.ValidationErrors
+	if !target.IsValid() {
+		errs.Add("target", "must be one of: repository, storage, notifier")
+	}
+	if _, ok := FaultErrors[input.Error]; !ok {
+		errs.Add("error", "must be one of: "+faultErrorNames())
+	}
+	if input.Count < 0 {
+		errs.Add("count", "must not be negative")
+	}
+	if err := errs.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+
+	fault := &Fault{Target: target, Error: input.Error, Remaining: input.Count}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults[target] = fault
+	copied := *fault
+	return &copied, nil
+}
+
+// 障害を解除する（注入していない場合は何もしない）
+func (f *FaultInjector) Clear(target FaultTarget) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.faults, target)
+}
+
+// 注入中の障害（依存先の順）
+func (f *FaultInjector) Faults() []Fault {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	faults := make([]Fault, 0, len(f.faults))
+	for _, target := range FaultTargets {
+		if fault, ok := f.faults[target]; ok {
+			faults = append(faults, *fault)
+		}
+	}
+	return faults
+}
+
+// 依存先の呼び出しの前に確認し、障害を注入中の場合はそのエラーを返す
+// 回数を指定した障害は、その回数だけエラーを返すと解除する
+func (f *FaultInjector) Check(target FaultTarget) error {
+	if f == nil {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	fault, ok := f.faults[target]
+	if !ok {
+		return nil
+	}
+	if fault.Remaining > 0 {
+		fault.Remaining--
+		if fault.Remaining == 0 {
+			delete(f.faults, target)
+		}
+	}
+	return fmt.Errorf("injected %s fault: %w", target, FaultErrors[fault.Error])
+}
+
+func faultErrorNames() string {
+	names := make([]string, 0, len(FaultErrors))
+	for name := range FaultErrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// 障害を注入できる写真の保存先（URL の組み立ては保存先を呼び出さないため対象外）
+type faultImageStorage struct {
+	ImageStorage
+	faults *FaultInjector
+}
+
+func NewFaultImageStorage(storage ImageStorage, faults *FaultInjector) ImageStorage {
+	return &faultImageStorage{ImageStorage: storage, faults: faults}
+}
+
+func (s *faultImageStorage) Save(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if err := s.faults.Check(FaultStorage); err != nil {
+		return err
+	}
+	return s.ImageStorage.Save(ctx, key, body, size, contentType)
+}
+
+func (s *faultImageStorage) Delete(ctx context.Context, key string) error {
+	if err := s.faults.Check(FaultStorage); err != nil {
+		return err
+	}
+	return s.ImageStorage.Delete(ctx, key)
+}
+
+func (s *faultImageStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := s.faults.Check(FaultStorage); err != nil {
+		return nil, err
+	}
+	return s.ImageStorage.Open(ctx, key)
+}
+
+func (s *faultImageStorage) SignedURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	if err := s.faults.Check(FaultStorage); err != nil {
+		return "", err
+	}
+	return s.ImageStorage.SignedURL(ctx, key, expires)
+}
+
+// 障害を注入できる Webhook の送信（送信の失敗として再送の対象になる）
+type faultWebhookSender struct {
+	WebhookSender
+	faults *FaultInjector
+}
+
+func NewFaultWebhookSender(sender WebhookSender, faults *FaultInjector) WebhookSender {
+	return &faultWebhookSender{WebhookSender: sender, faults: faults}
+}
+
+func (s *faultWebhookSender) Send(ctx context.Context, req WebhookRequest) (int, error) {
+	if err := s.faults.Check(FaultNotifier); err != nil {
+		return 0, err
+	}
+	return s.WebhookSender.Send(ctx, req)
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestFaultInjector(t *testing.T) {
+	t.Run("正常系: 解除するまで指定したエラーを返す", func(t *testing.T) {
+		faults := NewFaultInjector()
+		_, err := faults.Set(FaultRepository, SetFaultInput{Error: "db_unavailable"})
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			err := faults.Check(FaultRepository)
+			assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+			assert.ErrorIs(t, err, domainErrors.ErrTransient)
+		}
+		assert.NoError(t, faults.Check(FaultStorage))
+
+		faults.Clear(FaultRepository)
+		assert.NoError(t, faults.Check(FaultRepository))
+		assert.Empty(t, faults.Faults())
+	})
+
+	t.Run("正常系: 回数を指定した場合はその回数だけエラーを返す", func(t *testing.T) {
+		faults := NewFaultInjector()
+		_, err := faults.Set(FaultStorage, SetFaultInput{Error: "transient", Count: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []Fault{{Target: FaultStorage, Error: "transient", Remaining: 2}}, faults.Faults())
+
+		assert.ErrorIs(t, faults.Check(FaultStorage), domainErrors.ErrTransient)
+		assert.ErrorIs(t, faults.Check(FaultStorage), domainErrors.ErrTransient)
+		assert.NoError(t, faults.Check(FaultStorage))
+		assert.Empty(t, faults.Faults())
+	})
+
+	t.Run("正常系: 有効にしていない場合（nil）は何もしない", func(t *testing.T) {
+		var faults *FaultInjector
+		assert.NoError(t, faults.Check(FaultRepository))
+	})
+
+	tests := []struct {
+		name   string
+		target FaultTarget
+		input  SetFaultInput
+	}{
+		{name: "異常系: 未定義の依存先", target: "cache", input: SetFaultInput{Error: "not_found"}},
+		{name: "異常系: 未定義のエラー", target: FaultRepository, input: SetFaultInput{Error: "teapot"}},
+		{name: "異常系: 負の回数", target: FaultRepository, input: SetFaultInput{Error: "not_found", Count: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			faults := NewFaultInjector()
+			_, err := faults.Set(tt.target, tt.input)
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			assert.True(t, domainErrors.IsValidationError(err))
+			assert.Empty(t, faults.Faults())
+		})
+	}
+}
+
+func TestFaultDecorators(t *testing.T) {
+	faults := NewFaultInjector()
+	_, err := faults.Set(FaultStorage, SetFaultInput{Error: "quota_exceeded", Count: 1})
+	require.NoError(t, err)
+	_, err = faults.Set(FaultNotifier, SetFaultInput{Error: "transient", Count: 1})
+	require.NoError(t, err)
+
+	storage := new(MockImageStorage)
+	storage.On("Save", mock.Anything, "items/1/a.jpg", mock.Anything, int64(3), "image/jpeg").Return(nil).Once()
+	faulty := NewFaultImageStorage(storage, faults)
+
+	err = faulty.Save(context.Background(), "items/1/a.jpg", bytes.NewReader([]byte("abc")), 3, "image/jpeg")
+	assert.ErrorIs(t, err, domainErrors.ErrQuotaExceeded)
+	assert.NoError(t, faulty.Save(context.Background(), "items/1/a.jpg", bytes.NewReader([]byte("abc")), 3, "image/jpeg"))
+	storage.AssertExpectations(t)
+
+	sender := &fakeWebhookSender{}
+	faultySender := NewFaultWebhookSender(sender, faults)
+	status, err := faultySender.Send(context.Background(), WebhookRequest{URL: "https://example.com"})
+	assert.ErrorIs(t, err, domainErrors.ErrTransient)
+	assert.Zero(t, status)
+	assert.Empty(t, sender.requests)
+
+	status, err = faultySender.Send(context.Background(), WebhookRequest{URL: "https://example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, 200, status)
+}
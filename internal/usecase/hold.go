@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type SetItemHoldInput struct {
+	OnHold bool   `json:"on_hold"`
+	Reason string `json:"reason"`
+}
+
+// 保全状態の設定（管理者用）
+func (u *itemUsecase) SetItemHold(ctx context.Context, id int64, input SetItemHoldInput) (*entity.Item, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	before := entity.ItemAuditFields(item)
+	if err := item.SetHold(input.OnHold, input.Reason, u.clock.Now()); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	err = u.mutate(ctx, func(ctx context.Context) error {
+		if err := u.itemRepo.SetHold(ctx, id, item.OnHold, item.HoldReason, item.UpdatedAt); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to set item hold: %w", err)
+		}
+		return u.audit(ctx, id, entity.AuditHold, before, entity.ItemAuditFields(item))
+	})
+	u.evictItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	purgeItems(ctx, u.cachePurger, item)
+
+	return item, nil
+}
+
+// 保全中のアイテムは削除・変更できない
+func ensureNotOnHold(item *entity.Item) error {
+	if !item.OnHold {
+		return nil
+	}
+	if item.HoldReason == "" {
+		return domainErrors.ErrItemOnHold
+	}
+	return fmt.Errorf("%w: %s", domainErrors.ErrItemOnHold, item.HoldReason)
+}
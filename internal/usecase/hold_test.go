@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_SetItemHold(t *testing.T) {
+	newItem := func(onHold bool) *entity.Item {
+		item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+		item.ID = 1
+		if onHold {
+			item.OnHold = true
+			item.HoldReason = "係争中"
+		}
+		return item
+	}
+
+	tests := []struct {
+		name           string
+		id             int64
+		input          SetItemHoldInput
+		setupMock      func(*MockItemRepository)
+		expectedReason string
+		expectedErr    error
+	}{
+		{
+			name:  "正常系: 保全を設定",
+			id:    1,
+			input: SetItemHoldInput{OnHold: true, Reason: " 保険請求中 "},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(false), nil)
+				mockRepo.On("SetHold", mock.Anything, int64(1), true, "保険請求中", mock.Anything).Return(nil)
+			},
+			expectedReason: "保険請求中",
+		},
+		{
+			name:  "正常系: 保全を解除すると理由も消去",
+			id:    1,
+			input: SetItemHoldInput{OnHold: false, Reason: "解除"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(true), nil)
+				mockRepo.On("SetHold", mock.Anything, int64(1), false, "", mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:  "異常系: 存在しないアイテム",
+			id:    999,
+			input: SetItemHoldInput{OnHold: true},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:  "異常系: 理由が長すぎる",
+			id:    1,
+			input: SetItemHoldInput{OnHold: true, Reason: strings.Repeat("あ", entity.MaxHoldReasonLength+1)},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(false), nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			item, err := usecase.SetItemHold(context.Background(), tt.id, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, item)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.input.OnHold, item.OnHold)
+			assert.Equal(t, tt.expectedReason, item.HoldReason)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_UpdateItem_OnHold(t *testing.T) {
+	item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+	item.ID = 1
+	item.OnHold = true
+	item.HoldReason = "係争中"
+
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+	usecase := NewItemUsecase(mockRepo)
+
+	name := "新しい名前"
+	result, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Name: &name})
+
+	assert.ErrorIs(t, err, domainErrors.ErrItemOnHold)
+	assert.Contains(t, err.Error(), "係争中")
+	assert.Nil(t, result)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 写真の最大サイズのデフォルト（5MB）
+const DefaultMaxImageSize = 5 << 20
+
+// 写真ファイルの保存先（ローカルディスク、S3 など）
+type ImageStorage interface {
+	// Save stores the content under the key
+	Save(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+
+	// Delete removes the content stored under the key
+	Delete(ctx context.Context, key string) error
+
+	// Open returns a reader of the content stored under the key
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// URL returns the URL clients can fetch the content from
+	URL(key string) string
+
+	// SignedURL returns a URL that grants access to the content until it expires
+	SignedURL(ctx context.Context, key string, expires time.Duration) (string, error)
+}
+
+type ImageUsecase interface {
+	UploadImage(ctx context.Context, itemID int64, input UploadImageInput) (*entity.ItemImage, error)
+	GetImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error)
+	DeleteImage(ctx context.Context, itemID, imageID int64) error
+	ExportImages(ctx context.Context, input ExportImagesInput) (*ImageExport, error)
+	CreateUpload(ctx context.Context, itemID int64, input CreateUploadInput) (*entity.UploadSession, error)
+	GetUpload(ctx context.Context, itemID int64, uploadID string) (*entity.UploadSession, error)
+	AppendUpload(ctx context.Context, itemID int64, uploadID string, offset int64, chunk io.Reader) (*UploadChunkResult, error)
+	CancelUpload(ctx context.Context, itemID int64, uploadID string) error
+	CleanupUploads(ctx context.Context) (int, error)
+	CreateDirectUpload(ctx context.Context, itemID int64, input CreateDirectUploadInput) (*DirectUpload, error)
+	CompleteDirectUpload(ctx context.Context, itemID int64, input CompleteDirectUploadInput) (*entity.ItemImage, error)
+}
+
+type UploadImageInput struct {
+	FileName string
+	Size     int64
+	Body     io.Reader
+}
+
+type imageUsecase struct {
+	itemRepo  ItemRepository
+	imageRepo ItemImageRepository
+	storage   ImageStorage
+	readOnly  *ReadOnlySwitch
+	maxSize   int64
+
+	// 利用上限（未指定の場合は確認しない）
+	quota *QuotaChecker
+
+	// 写真の一括エクスポートのダウンロードURLの有効期限
+	exportURLExpiry time.Duration
+
+	// 分割アップロード（未指定の場合は無効）
+	uploadStore   UploadStore
+	maxUploadSize int64
+	uploadTTL     time.Duration
+
+	// 署名付きURLでの直接アップロード（未指定の場合は無効）
+	directStorage         DirectUploadStorage
+	directUploadURLExpiry time.Duration
+
+	// 写真のメタデータの読み取り（未指定の場合は購入日・購入場所の候補を返さない）
+	metadataReader ImageMetadataReader
+
+	// URL・アップロードの有効期限の基準にする現在時刻
+	clock entity.Clock
+}
+
+// ImageUsecaseの任意の依存を指定するオプション
+type ImageUsecaseOption func(*imageUsecase)
+
+// 読み取り専用モードのスイッチを指定
+func WithImageReadOnlySwitch(readOnly *ReadOnlySwitch) ImageUsecaseOption {
+	return func(u *imageUsecase) {
+		u.readOnly = readOnly
+	}
+}
+
+// 写真の最大サイズ（バイト）を指定
+func WithMaxImageSize(size int64) ImageUsecaseOption {
+	return func(u *imageUsecase) {
+		u.maxSize = size
+	}
+}
+
+// 現在時刻の取得元を指定（デフォルトはシステムの時刻）
+func WithImageClock(clock entity.Clock) ImageUsecaseOption {
+	return func(u *imageUsecase) {
+		u.clock = clock
+	}
+}
+
+// 一括エクスポートのダウンロードURLの有効期限を指定
+func WithExportURLExpiry(expiry time.Duration) ImageUsecaseOption {
+	return func(u *imageUsecase) {
+		u.exportURLExpiry = expiry
+	}
+}
+
+func NewImageUsecase(itemRepo ItemRepository, imageRepo ItemImageRepository, storage ImageStorage, opts ...ImageUsecaseOption) ImageUsecase {
+	u := &imageUsecase{
+		itemRepo:  itemRepo,
+		imageRepo: imageRepo,
+		storage:   storage,
+		readOnly:  NewReadOnlySwitch(false),
+		maxSize:   DefaultMaxImageSize,
+
+		exportURLExpiry: DefaultExportURLExpiry,
+		maxUploadSize:   DefaultMaxUploadSize,
+		uploadTTL:       DefaultUploadTTL,
+
+		directUploadURLExpiry: DefaultDirectUploadURLExpiry,
+
+		clock: entity.SystemClock,
+	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
+}
+
+func (u *imageUsecase) UploadImage(ctx context.Context, itemID int64, input UploadImageInput) (*entity.ItemImage, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+
+	item, err := u.findItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Size <= 0 {
+		return nil, fmt.Errorf("%w: image is empty", domainErrors.ErrInvalidInput)
+	}
+	if input.Size > u.maxSize {
+		return nil, fmt.Errorf("%w: image must be %d bytes or smaller", domainErrors.ErrInvalidInput, u.maxSize)
+	}
+	if err := u.quota.CheckStorage(ctx, input.Size); err != nil {
+		return nil, err
+	}
+
+	return u.storeImage(ctx, item, input)
+}
+
+// 写真の内容を確認して保存し、メタデータを登録する
+func (u *imageUsecase) storeImage(ctx context.Context, item *entity.Item, input UploadImageInput) (*entity.ItemImage, error) {
+	itemID := item.ID
+	// クライアントが申告した Content-Type ではなく、内容からMIMEタイプを判定する
+	head := make([]byte, 512)
+	n, err := io.ReadFull(input.Body, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("%w: failed to read image: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	if !entity.IsAllowedImageContentType(contentType) {
+		return nil, fmt.Errorf("%w: image must be one of: %s", domainErrors.ErrInvalidInput, strings.Join(entity.AllowedImageContentTypes, ", "))
+	}
+
+	key, err := imageKey(itemID, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate image key: %w", err)
+	}
+
+	var body io.Reader = io.MultiReader(bytes.NewReader(head), input.Body)
+	var metadataHead *headBuffer
+	if u.suggestsFor(item) {
+		metadataHead = &headBuffer{limit: imageMetadataScanSize}
+		body = io.TeeReader(body, metadataHead)
+	}
+	if err := u.storage.Save(ctx, key, body, input.Size, contentType); err != nil {
+		return nil, fmt.Errorf("failed to save image: %w", err)
+	}
+
+	image, err := u.imageRepo.Create(ctx, &entity.ItemImage{
+		ItemID:      itemID,
+		FileName:    input.FileName,
+		ContentType: contentType,
+		Size:        input.Size,
+		StorageKey:  key,
+	})
+	if err != nil {
+		// メタデータを保存できなかったファイルは残さない
+		_ = u.storage.Delete(ctx, key)
+		return nil, fmt.Errorf("failed to create image: %w", err)
+	}
+	image.URL = u.storage.URL(image.StorageKey)
+	if metadataHead != nil {
+		image.Suggestions = u.photoSuggestions(metadataHead.data)
+	}
+
+	return image, nil
+}
+
+func (u *imageUsecase) GetImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	if _, err := u.findItem(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	images, err := u.imageRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve images: %w", err)
+	}
+
+	if images == nil {
+		images = []*entity.ItemImage{}
+	}
+	for _, image := range images {
+		image.URL = u.storage.URL(image.StorageKey)
+	}
+
+	return images, nil
+}
+
+func (u *imageUsecase) DeleteImage(ctx context.Context, itemID, imageID int64) error {
+	if u.readOnly.Enabled() {
+		return domainErrors.ErrReadOnly
+	}
+
+	if imageID <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+	item, err := u.findItem(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	// 保全中のアイテムの写真は削除できない
+	if err := ensureNotOnHold(item); err != nil {
+		return err
+	}
+
+	image, err := u.imageRepo.FindByID(ctx, itemID, imageID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemNotFound
+		}
+		return fmt.Errorf("failed to retrieve image: %w", err)
+	}
+
+	if err := u.imageRepo.Delete(ctx, itemID, imageID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemNotFound
+		}
+		return fmt.Errorf("failed to delete image: %w", err)
+	}
+
+	// メタデータは削除済みのため、ファイルの削除に失敗しても参照されることはない
+	_ = u.storage.Delete(ctx, image.StorageKey)
+
+	return nil
+}
+
+func (u *imageUsecase) findItem(ctx context.Context, itemID int64) (*entity.Item, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return item, nil
+}
+
+// 推測されにくいランダムなファイル名で保存先を決める
+func imageKey(itemID int64, contentType string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("items/%d/%s%s", itemID, hex.EncodeToString(b), entity.ImageExtension(contentType)), nil
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 一括エクスポートで指定できるアイテム数の上限
+const MaxExportImageItems = 100
+
+// ダウンロードURLの有効期限のデフォルト
+const DefaultExportURLExpiry = time.Hour
+
+type ExportImagesInput struct {
+	ItemIDs []int64 `json:"item_ids"`
+}
+
+// 写真の一括エクスポート結果（zipのダウンロードURL）
+type ImageExport struct {
+	URL        string    `json:"url"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	ItemCount  int       `json:"item_count"`
+	ImageCount int       `json:"image_count"`
+}
+
+// 指定したアイテムの写真をzipにまとめて保存し、ダウンロードURLを返す（保険請求の提出用など）
+// zip内は {アイテムID}/{写真ID}_{ファイル名} で格納する
+func (u *imageUsecase) ExportImages(ctx context.Context, input ExportImagesInput) (*ImageExport, error) {
+	itemIDs, err := normalizeItemIDs(input.ItemIDs, MaxExportImageItems)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []*entity.ItemImage
+	for _, itemID := range itemIDs {
+		if _, err := u.findItem(ctx, itemID); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return nil, fmt.Errorf("%w: item %d", domainErrors.ErrItemNotFound, itemID)
+			}
+			return nil, err
+		}
+
+		itemImages, err := u.imageRepo.FindByItemID(ctx, itemID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve images: %w", err)
+		}
+		images = append(images, itemImages...)
+	}
+
+	if len(images) == 0 {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, "selected items have no images")
+	}
+
+	// 写真の合計サイズが大きくなりうるため、メモリではなく一時ファイルに書き出す
+	file, err := os.CreateTemp("", "item-images-*.zip")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if err := u.writeImagesZip(ctx, file, images); err != nil {
+		return nil, err
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write export file: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	key, err := exportKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.storage.Save(ctx, key, file, size, "application/zip"); err != nil {
+		return nil, fmt.Errorf("failed to store export: %w", err)
+	}
+
+	url, err := u.storage.SignedURL(ctx, key, u.exportURLExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign export URL: %w", err)
+	}
+
+	return &ImageExport{
+		URL:        url,
+		ExpiresAt:  u.clock.Now().Add(u.exportURLExpiry),
+		ItemCount:  len(itemIDs),
+		ImageCount: len(images),
+	}, nil
+}
+
+func (u *imageUsecase) writeImagesZip(ctx context.Context, w io.Writer, images []*entity.ItemImage) error {
+	zw := zip.NewWriter(w)
+
+	for _, image := range images {
+		// 写真は圧縮済みの形式のため、再圧縮せずに格納する
+		entry, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     exportEntryName(image),
+			Method:   zip.Store,
+			Modified: image.CreatedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write export file: %w", err)
+		}
+
+		body, err := u.storage.Open(ctx, image.StorageKey)
+		if err != nil {
+			return fmt.Errorf("failed to read image %d: %w", image.ID, err)
+		}
+		_, err = io.Copy(entry, body)
+		body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read image %d: %w", image.ID, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	return nil
+}
+
+// 重複を除き、件数（max 件まで）と値を検証する
+func normalizeItemIDs(ids []int64, max int) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, "item_ids is required")
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	var itemIDs []int64
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, "item_ids must be positive integers")
+		}
+		if !seen[id] {
+			seen[id] = true
+			itemIDs = append(itemIDs, id)
+		}
+	}
+
+	if len(itemIDs) > max {
+		return nil, fmt.Errorf("%w: item_ids must contain %d items or less", domainErrors.ErrInvalidInput, max)
+	}
+
+	return itemIDs, nil
+}
+
+// アップロード時のファイル名からディレクトリ部分を取り除いて使う
+func exportEntryName(image *entity.ItemImage) string {
+	name := path.Base(strings.ReplaceAll(image.FileName, "\\", "/"))
+	if name == "." || name == "/" {
+		name = "image" + entity.ImageExtension(image.ContentType)
+	}
+	return fmt.Sprintf("%d/%d_%s", image.ItemID, image.ID, name)
+}
+
+// URLを知っている人だけが取得できるよう、推測されにくいファイル名にする
+func exportKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("exports/%s.zip", hex.EncodeToString(b)), nil
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// zipの内容をファイル名→内容のマップとして読み取る
+func readZipEntries(t *testing.T, content []byte) map[string]string {
+	t.Helper()
+
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+
+	entries := make(map[string]string)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+		entries[f.Name] = string(b)
+	}
+	return entries
+}
+
+func TestImageUsecase_ExportImages(t *testing.T) {
+	item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+
+	t.Run("正常系: 複数アイテムの写真をzipにまとめる", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		imageRepo := new(MockItemImageRepository)
+		storage := new(MockImageStorage)
+
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		itemRepo.On("FindByID", mock.Anything, int64(2)).Return(item, nil)
+		imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{
+			{ID: 10, ItemID: 1, FileName: "front.jpg", ContentType: "image/jpeg", StorageKey: "items/1/a.jpg"},
+			{ID: 11, ItemID: 1, FileName: `C:\photos\back.png`, ContentType: "image/png", StorageKey: "items/1/b.png"},
+		}, nil)
+		imageRepo.On("FindByItemID", mock.Anything, int64(2)).Return([]*entity.ItemImage{
+			{ID: 20, ItemID: 2, FileName: "box.webp", ContentType: "image/webp", StorageKey: "items/2/c.webp"},
+		}, nil)
+		storage.On("Open", mock.Anything, "items/1/a.jpg").Return("jpeg-data", nil)
+		storage.On("Open", mock.Anything, "items/1/b.png").Return("png-data", nil)
+		storage.On("Open", mock.Anything, "items/2/c.webp").Return("webp-data", nil)
+
+		var saved []byte
+		storage.On("Save", mock.Anything, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "exports/") && strings.HasSuffix(key, ".zip")
+		}), mock.Anything, mock.Anything, "application/zip").Run(func(args mock.Arguments) {
+			saved = args.Get(2).([]byte)
+		}).Return(nil)
+		storage.On("SignedURL", mock.Anything, mock.Anything, 30*time.Minute).Return("https://example.com/export.zip?sig=x", nil)
+
+		usecase := NewImageUsecase(itemRepo, imageRepo, storage, WithExportURLExpiry(30*time.Minute))
+		export, err := usecase.ExportImages(context.Background(), ExportImagesInput{ItemIDs: []int64{1, 2, 1}})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/export.zip?sig=x", export.URL)
+		assert.Equal(t, 2, export.ItemCount)
+		assert.Equal(t, 3, export.ImageCount)
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), export.ExpiresAt, time.Minute)
+		assert.Equal(t, map[string]string{
+			"1/10_front.jpg": "jpeg-data",
+			"1/11_back.png":  "png-data",
+			"2/20_box.webp":  "webp-data",
+		}, readZipEntries(t, saved))
+		storage.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name        string
+		input       ExportImagesInput
+		setupMock   func(*MockItemRepository, *MockItemImageRepository)
+		expectedErr error
+	}{
+		{
+			name:        "異常系: アイテム未指定",
+			input:       ExportImagesInput{},
+			setupMock:   func(*MockItemRepository, *MockItemImageRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 無効なID",
+			input:       ExportImagesInput{ItemIDs: []int64{1, 0}},
+			setupMock:   func(*MockItemRepository, *MockItemImageRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 存在しないアイテム",
+			input: ExportImagesInput{ItemIDs: []int64{999}},
+			setupMock: func(itemRepo *MockItemRepository, _ *MockItemImageRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:  "異常系: 写真がない",
+			input: ExportImagesInput{ItemIDs: []int64{1}},
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{}, nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			imageRepo := new(MockItemImageRepository)
+			storage := new(MockImageStorage)
+			tt.setupMock(itemRepo, imageRepo)
+
+			usecase := NewImageUsecase(itemRepo, imageRepo, storage)
+			export, err := usecase.ExportImages(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Nil(t, export)
+			storage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 写真のメタデータを読み取る範囲（EXIF はファイルの先頭 64KB 以内にある）
+const imageMetadataScanSize = 64 << 10
+
+// 写真のメタデータ（EXIF など）の読み取り
+type ImageMetadataReader interface {
+	// ReadMetadata reads the capture time and location from the head of the image
+	// Fields missing from the image are left nil
+	ReadMetadata(head []byte) (*entity.ImageMetadata, error)
+}
+
+// 下書きのアイテムに写真を添付したときに、撮影日時・撮影場所から購入日・購入場所の候補を返す
+func WithImageMetadataReader(reader ImageMetadataReader) ImageUsecaseOption {
+	return func(u *imageUsecase) {
+		u.metadataReader = reader
+	}
+}
+
+// 候補を返すか（メタデータの読み取りが指定されていて、アイテムが下書きの場合）
+func (u *imageUsecase) suggestsFor(item *entity.Item) bool {
+	return u.metadataReader != nil && item.Draft
+}
+
+// 写真の先頭部分から候補を作る。候補は補助的な情報のため、読み取れない場合は候補なしとする
+func (u *imageUsecase) photoSuggestions(head []byte) *entity.PhotoSuggestions {
+	metadata, err := u.metadataReader.ReadMetadata(head)
+	if err != nil {
+		return nil
+	}
+	return entity.NewPhotoSuggestions(metadata)
+}
+
+// 保存済みの写真の先頭部分から候補を作る
+func (u *imageUsecase) storedPhotoSuggestions(ctx context.Context, key string) *entity.PhotoSuggestions {
+	content, err := u.storage.Open(ctx, key)
+	if err != nil {
+		return nil
+	}
+	defer content.Close()
+
+	head, err := io.ReadAll(io.LimitReader(content, imageMetadataScanSize))
+	if err != nil {
+		return nil
+	}
+	return u.photoSuggestions(head)
+}
+
+// 書き込まれた内容の先頭 limit バイトを残す（保存する写真を読みながらメタデータの範囲を残すために使う）
+type headBuffer struct {
+	data  []byte
+	limit int
+}
+
+func (b *headBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - len(b.data); remaining > 0 {
+		if len(p) < remaining {
+			remaining = len(p)
+		}
+		b.data = append(b.data, p[:remaining]...)
+	}
+	return len(p), nil
+}
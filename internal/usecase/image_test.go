@@ -0,0 +1,329 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockItemImageRepository は写真メタデータのモックリポジトリ
+type MockItemImageRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemImageRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) FindByID(ctx context.Context, itemID, imageID int64) (*entity.ItemImage, error) {
+	args := m.Called(ctx, itemID, imageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) Create(ctx context.Context, image *entity.ItemImage) (*entity.ItemImage, error) {
+	args := m.Called(ctx, image)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) Delete(ctx context.Context, itemID, imageID int64) error {
+	args := m.Called(ctx, itemID, imageID)
+	return args.Error(0)
+}
+
+func (m *MockItemImageRepository) TotalSize(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockImageStorage は写真ファイルのモックストレージ
+type MockImageStorage struct {
+	mock.Mock
+}
+
+func (m *MockImageStorage) Save(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	// 保存された内容を検証できるよう読み込んでおく
+	content, _ := io.ReadAll(body)
+	args := m.Called(ctx, key, content, size, contentType)
+	return args.Error(0)
+}
+
+func (m *MockImageStorage) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockImageStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return io.NopCloser(strings.NewReader(args.String(0))), args.Error(1)
+}
+
+func (m *MockImageStorage) URL(key string) string {
+	return "/images/" + key
+}
+
+func (m *MockImageStorage) SignedURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	args := m.Called(ctx, key, expires)
+	return args.String(0), args.Error(1)
+}
+
+// PNGのシグネチャを先頭に持つデータ
+var testPNG = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 100)...)
+
+func TestImageUsecase_UploadImage(t *testing.T) {
+	tests := []struct {
+		name        string
+		itemID      int64
+		content     []byte
+		size        int64
+		setupMock   func(*MockItemRepository, *MockItemImageRepository, *MockImageStorage)
+		expectedErr error
+	}{
+		{
+			name:    "正常系: PNGをアップロード",
+			itemID:  1,
+			content: testPNG,
+			size:    int64(len(testPNG)),
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository, storage *MockImageStorage) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+				storage.On("Save", mock.Anything, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "items/1/") && strings.HasSuffix(key, ".png")
+				}), testPNG, int64(len(testPNG)), "image/png").Return(nil)
+				imageRepo.On("Create", mock.Anything, mock.MatchedBy(func(image *entity.ItemImage) bool {
+					return image.ItemID == 1 && image.ContentType == "image/png" && image.FileName == "photo.png"
+				})).Return(&entity.ItemImage{ID: 10, ItemID: 1, ContentType: "image/png", StorageKey: "items/1/abc.png"}, nil)
+			},
+		},
+		{
+			name:    "異常系: 画像以外のファイル",
+			itemID:  1,
+			content: []byte("not an image"),
+			size:    12,
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository, storage *MockImageStorage) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:    "異常系: 最大サイズ超過",
+			itemID:  1,
+			content: testPNG,
+			size:    DefaultMaxImageSize + 1,
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository, storage *MockImageStorage) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:    "異常系: 存在しないアイテム",
+			itemID:  999,
+			content: testPNG,
+			size:    int64(len(testPNG)),
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository, storage *MockImageStorage) {
+				itemRepo.On("FindByID", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:    "異常系: メタデータの保存に失敗した場合はファイルを削除",
+			itemID:  1,
+			content: testPNG,
+			size:    int64(len(testPNG)),
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository, storage *MockImageStorage) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+				storage.On("Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				imageRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+				storage.On("Delete", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			imageRepo := new(MockItemImageRepository)
+			storage := new(MockImageStorage)
+			tt.setupMock(itemRepo, imageRepo, storage)
+			usecase := NewImageUsecase(itemRepo, imageRepo, storage)
+
+			image, err := usecase.UploadImage(context.Background(), tt.itemID, UploadImageInput{
+				FileName: "photo.png",
+				Size:     tt.size,
+				Body:     bytes.NewReader(tt.content),
+			})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, image)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(10), image.ID)
+				assert.Equal(t, "/images/items/1/abc.png", image.URL)
+			}
+			itemRepo.AssertExpectations(t)
+			imageRepo.AssertExpectations(t)
+			storage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImageUsecase_GetImages(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	imageRepo := new(MockItemImageRepository)
+	itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+	imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{
+		{ID: 1, ItemID: 1, StorageKey: "items/1/a.jpg"},
+		{ID: 2, ItemID: 1, StorageKey: "items/1/b.png"},
+	}, nil)
+	usecase := NewImageUsecase(itemRepo, imageRepo, new(MockImageStorage))
+
+	images, err := usecase.GetImages(context.Background(), 1)
+
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+	assert.Equal(t, "/images/items/1/a.jpg", images[0].URL)
+	assert.Equal(t, "/images/items/1/b.png", images[1].URL)
+}
+
+func TestImageUsecase_DeleteImage(t *testing.T) {
+	tests := []struct {
+		name        string
+		imageID     int64
+		readOnly    bool
+		setupMock   func(*MockItemRepository, *MockItemImageRepository, *MockImageStorage)
+		expectedErr error
+	}{
+		{
+			name:    "正常系: 写真を削除",
+			imageID: 10,
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository, storage *MockImageStorage) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+				imageRepo.On("FindByID", mock.Anything, int64(1), int64(10)).Return(&entity.ItemImage{ID: 10, ItemID: 1, StorageKey: "items/1/abc.png"}, nil)
+				imageRepo.On("Delete", mock.Anything, int64(1), int64(10)).Return(nil)
+				storage.On("Delete", mock.Anything, "items/1/abc.png").Return(nil)
+			},
+		},
+		{
+			name:    "異常系: 存在しない写真",
+			imageID: 99,
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository, storage *MockImageStorage) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+				imageRepo.On("FindByID", mock.Anything, int64(1), int64(99)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:     "異常系: 読み取り専用モード",
+			imageID:  10,
+			readOnly: true,
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository, storage *MockImageStorage) {
+				// リポジトリは呼ばれない
+			},
+			expectedErr: domainErrors.ErrReadOnly,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			imageRepo := new(MockItemImageRepository)
+			storage := new(MockImageStorage)
+			tt.setupMock(itemRepo, imageRepo, storage)
+			usecase := NewImageUsecase(itemRepo, imageRepo, storage, WithImageReadOnlySwitch(NewReadOnlySwitch(tt.readOnly)))
+
+			err := usecase.DeleteImage(context.Background(), 1, tt.imageID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			itemRepo.AssertExpectations(t)
+			imageRepo.AssertExpectations(t)
+			storage.AssertExpectations(t)
+		})
+	}
+}
+
+// 決まったメタデータを返し、受け取った先頭部分を記録する
+type fakeImageMetadataReader struct {
+	metadata *entity.ImageMetadata
+	head     []byte
+}
+
+func (r *fakeImageMetadataReader) ReadMetadata(head []byte) (*entity.ImageMetadata, error) {
+	r.head = head
+	return r.metadata, nil
+}
+
+func TestImageUsecase_UploadImage_Suggestions(t *testing.T) {
+	takenAt := time.Date(2023, 1, 15, 14, 30, 0, 0, time.UTC)
+	location := &entity.GeoLocation{Latitude: 35.6717, Longitude: 139.765}
+
+	tests := []struct {
+		name     string
+		item     *entity.Item
+		expected *entity.PhotoSuggestions
+	}{
+		{
+			name:     "正常系: 下書きのアイテムには撮影日時・撮影場所から購入日・購入場所の候補を返す",
+			item:     &entity.Item{ID: 1, Draft: true},
+			expected: &entity.PhotoSuggestions{PurchaseDate: "2023-01-15", PurchaseLocation: location},
+		},
+		{
+			name: "正常系: 登録済みのアイテムには候補を返さない",
+			item: &entity.Item{ID: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			imageRepo := new(MockItemImageRepository)
+			storage := new(MockImageStorage)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(tt.item, nil)
+			storage.On("Save", mock.Anything, mock.Anything, testPNG, int64(len(testPNG)), "image/png").Return(nil)
+			imageRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.ItemImage{ID: 10, ItemID: 1, StorageKey: "items/1/abc.png"}, nil)
+			reader := &fakeImageMetadataReader{metadata: &entity.ImageMetadata{TakenAt: &takenAt, Location: location}}
+			usecase := NewImageUsecase(itemRepo, imageRepo, storage, WithImageMetadataReader(reader))
+
+			image, err := usecase.UploadImage(context.Background(), 1, UploadImageInput{
+				FileName: "photo.png",
+				Size:     int64(len(testPNG)),
+				Body:     bytes.NewReader(testPNG),
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, image.Suggestions)
+			if tt.expected != nil {
+				// 保存した内容の先頭部分から読み取る
+				assert.Equal(t, testPNG, reader.head)
+			} else {
+				assert.Nil(t, reader.head)
+			}
+		})
+	}
+}
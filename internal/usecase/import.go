@@ -0,0 +1,340 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// インポート形式
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatXLSX = "xlsx"
+)
+
+// インポート時にまとめて登録する件数
+const ImportBatchSize = 100
+
+// インポートで必須の列（エクスポートしたCSVの id, created_at などの列は無視する）
+var importRequiredColumns = []string{"name", "category", "brand", "purchase_price", "purchase_date"}
+
+type ImportItemsInput struct {
+	Format string
+	File   io.Reader
+}
+
+// 登録できた行
+type ImportedRow struct {
+	Row      int      `json:"row"`
+	ID       int64    `json:"id"`
+	Warnings []string `json:"warnings,omitempty"` // 予算の超過など
+}
+
+// 登録できなかった行
+type RejectedRow struct {
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
+
+type ImportResult struct {
+	Accepted []ImportedRow `json:"accepted"`
+	Rejected []RejectedRow `json:"rejected"`
+}
+
+// 1行ずつ読み込むリーダー（全体をメモリに載せないため）
+type rowReader interface {
+	// 次の行とその行番号（1始まり）を返す。最後まで読んだ場合は io.EOF を返す
+	Read() (int, []string, error)
+}
+
+func (u *itemUsecase) ImportItems(ctx context.Context, input ImportItemsInput) (*ImportResult, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	reader, closeReader, err := newRowReader(input.Format, input.File)
+	if err != nil {
+		return nil, err
+	}
+	defer closeReader()
+
+	_, header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: file is empty", domainErrors.ErrInvalidInput)
+		}
+		return nil, fmt.Errorf("%w: failed to read header: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	columns, err := parseImportHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		Accepted: []ImportedRow{},
+		Rejected: []RejectedRow{},
+	}
+
+	// 検証済みの行をまとめて登録する（pending は登録前の行の購入額で、予算の集計に加える）
+	var batch []*entity.Item
+	var batchRows []int
+	var batchWarnings [][]string
+	pending := batchSpend{}
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := u.quota.CheckItems(ctx, len(batch)); err != nil {
+			return err
+		}
+		// 為替レートを取得できなかった行は円換算額なしで登録する
+		u.applyExchangeRates(ctx, batch...)
+		var created []*entity.Item
+		err := u.mutate(ctx, func(ctx context.Context) error {
+			var err error
+			created, err = u.itemRepo.CreateMany(ctx, batch)
+			if err != nil {
+				return fmt.Errorf("failed to create items: %w", err)
+			}
+			for _, item := range created {
+				if err := u.audit(ctx, item.ID, entity.AuditCreate, nil, entity.ItemAuditFields(item)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for i, item := range created {
+			result.Accepted = append(result.Accepted, ImportedRow{Row: batchRows[i], ID: item.ID, Warnings: batchWarnings[i]})
+			u.snapshot(ctx, item)
+		}
+		purgeItems(ctx, u.cachePurger, created...)
+		batch, batchRows, batchWarnings = batch[:0], batchRows[:0], batchWarnings[:0]
+		// 登録した行の購入額はDBから集計される
+		pending = batchSpend{}
+		return nil
+	}
+
+	for {
+		row, record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// 行を特定できない読み込みエラーは続行できない
+			if row == 0 {
+				return nil, fmt.Errorf("%w: failed to read file: %s", domainErrors.ErrInvalidInput, err.Error())
+			}
+			result.Rejected = append(result.Rejected, RejectedRow{Row: row, Errors: []string{err.Error()}})
+			continue
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		item, errs := u.parseImportRecord(ctx, columns, record)
+		if len(errs) > 0 {
+			result.Rejected = append(result.Rejected, RejectedRow{Row: row, Errors: errs})
+			continue
+		}
+
+		budgetWarnings, err := u.checkBatchBudget(ctx, item, pending)
+		if err != nil {
+			if !errors.Is(err, domainErrors.ErrBudgetExceeded) {
+				return nil, err
+			}
+			result.Rejected = append(result.Rejected, RejectedRow{Row: row, Errors: []string{err.Error()}})
+			continue
+		}
+		pending.add(item)
+
+		batch = append(batch, item)
+		batchRows = append(batchRows, row)
+		batchWarnings = append(batchWarnings, budgetWarnings)
+		if len(batch) >= ImportBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func newRowReader(format string, file io.Reader) (rowReader, func(), error) {
+	switch strings.ToLower(format) {
+	case ImportFormatCSV:
+		reader := csv.NewReader(skipBOM(file))
+		// 列数のチェックは行ごとのバリデーションで行う
+		reader.FieldsPerRecord = -1
+		return &csvRowReader{reader: reader}, func() {}, nil
+	case ImportFormatXLSX:
+		f, err := excelize.OpenReader(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: failed to open xlsx: %s", domainErrors.ErrInvalidInput, err.Error())
+		}
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			f.Close()
+			return nil, nil, fmt.Errorf("%w: xlsx has no sheets", domainErrors.ErrInvalidInput)
+		}
+		// 最初のシートを読み込む
+		rows, err := f.Rows(sheets[0])
+		if err != nil {
+			f.Close()
+			return nil, nil, fmt.Errorf("%w: failed to read xlsx: %s", domainErrors.ErrInvalidInput, err.Error())
+		}
+		return &xlsxRowReader{rows: rows}, func() {
+			rows.Close()
+			f.Close()
+		}, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: format must be %s or %s", domainErrors.ErrInvalidInput, ImportFormatCSV, ImportFormatXLSX)
+	}
+}
+
+type csvRowReader struct {
+	reader *csv.Reader
+}
+
+func (r *csvRowReader) Read() (int, []string, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return parseErr.StartLine, nil, err
+		}
+		return 0, nil, err
+	}
+	// 空行は読み飛ばされるため、行番号はファイル上の位置から取得する
+	line, _ := r.reader.FieldPos(0)
+	return line, record, nil
+}
+
+type xlsxRowReader struct {
+	rows *excelize.Rows
+	row  int
+}
+
+func (r *xlsxRowReader) Read() (int, []string, error) {
+	if !r.rows.Next() {
+		if err := r.rows.Error(); err != nil {
+			return 0, nil, err
+		}
+		return 0, nil, io.EOF
+	}
+	r.row++
+	columns, err := r.rows.Columns()
+	return r.row, columns, err
+}
+
+// Excel で保存したCSVの先頭に付く BOM を読み飛ばす
+func skipBOM(r io.Reader) io.Reader {
+	buf := make([]byte, len(utf8BOM))
+	n, err := io.ReadFull(r, buf)
+	if err == nil && string(buf) == string(utf8BOM) {
+		return r
+	}
+	return io.MultiReader(strings.NewReader(string(buf[:n])), r)
+}
+
+// ヘッダー行から列名と位置の対応を作る
+func parseImportHeader(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	var missing []string
+	for _, name := range importRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing columns: %s", domainErrors.ErrInvalidInput, strings.Join(missing, ", "))
+	}
+
+	return columns, nil
+}
+
+// 1行分をバリデーションして、新しいエンティティを作成
+func (u *itemUsecase) parseImportRecord(ctx context.Context, columns map[string]int, record []string) (*entity.Item, []string) {
+	value := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var errs []string
+	input := CreateItemInput{
+		Name:         value("name"),
+		Category:     value("category"),
+		Brand:        value("brand"),
+		PurchaseDate: value("purchase_date"),
+
+		PurchaseCountry: value("purchase_country"),
+		TaxRate:         value("tax_rate"),
+	}
+
+	amount, err := strconv.ParseInt(value("purchase_price"), 10, 64)
+	if err != nil {
+		errs = append(errs, "purchase_price must be an integer")
+	}
+	input.PurchasePrice = entity.Money{Amount: amount, Currency: value("currency")}
+
+	if tax := value("tax_amount"); tax != "" {
+		amount, err := strconv.ParseInt(tax, 10, 64)
+		if err != nil {
+			errs = append(errs, "tax_amount must be an integer")
+		}
+		input.TaxAmount = &amount
+	}
+	if included := value("tax_included"); included != "" {
+		input.TaxIncluded, err = strconv.ParseBool(included)
+		if err != nil {
+			errs = append(errs, "tax_included must be true or false")
+		}
+	}
+
+	if attributes := value("attributes"); attributes != "" {
+		if err := json.Unmarshal([]byte(attributes), &input.Attributes); err != nil {
+			errs = append(errs, "attributes must be a JSON object of strings")
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	item, err := u.newItem(ctx, input)
+	if err != nil {
+		return nil, strings.Split(err.Error(), ", ")
+	}
+	return item, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 保険の明細の出力形式
+const (
+	InsuranceFormatJSON = "json"
+	InsuranceFormatXLSX = "xlsx"
+)
+
+type InsuranceUsecase interface {
+	// 利用できる書式（名前順）
+	GetTemplates() []entity.InsuranceTemplate
+
+	// 削除・下書きを除くすべてのアイテムを、書式の明細に分けて出力する
+	GenerateSchedules(ctx context.Context, template string) (*InsuranceBundle, error)
+}
+
+// 保険会社に提出する明細一式
+type InsuranceBundle struct {
+	Template    string                    `json:"template"`
+	GeneratedAt time.Time                 `json:"generated_at"`
+	Schedules   []InsuranceScheduleResult `json:"schedules"`
+	Totals      []CurrencyTotal           `json:"totals"` // すべての明細の保険金額の通貨ごとの合計
+}
+
+type InsuranceScheduleResult struct {
+	Name    string          `json:"name"`
+	Title   string          `json:"title"`
+	Headers []string        `json:"headers"`
+	Rows    [][]string      `json:"rows"`
+	Count   int             `json:"count"`
+	Totals  []CurrencyTotal `json:"totals"` // 保険金額の通貨ごとの合計
+
+	// 必須の項目が不足しているアイテム（行には空欄で出力する）
+	Missing []InsuranceMissingFields `json:"missing"`
+}
+
+type InsuranceMissingFields struct {
+	ItemID int64    `json:"item_id"`
+	Fields []string `json:"fields"`
+}
+
+type insuranceUsecase struct {
+	itemRepo      ItemRepository
+	valuationRepo ValuationRepository
+	templates     []entity.InsuranceTemplate
+	clock         entity.Clock
+}
+
+// templates が空の場合は既定の書式を使う
+func NewInsuranceUsecase(itemRepo ItemRepository, valuationRepo ValuationRepository, templates []entity.InsuranceTemplate, clock entity.Clock) InsuranceUsecase {
+	if len(templates) == 0 {
+		templates = entity.DefaultInsuranceTemplates()
+	}
+	// 出力は参照のみのため、一時的なエラーの場合は再試行する
+	return &insuranceUsecase{
+		itemRepo:      &retryingItemRepository{ItemRepository: itemRepo, policy: DefaultRetryPolicy},
+		valuationRepo: valuationRepo,
+		templates:     templates,
+		clock:         clock,
+	}
+}
+
+func (u *insuranceUsecase) GetTemplates() []entity.InsuranceTemplate {
+	return u.templates
+}
+
+func (u *insuranceUsecase) GenerateSchedules(ctx context.Context, name string) (*InsuranceBundle, error) {
+	if name == "" {
+		name = entity.DefaultInsuranceTemplate
+	}
+	template, ok := u.findTemplate(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown insurance template %q", domainErrors.ErrInvalidInput, name)
+	}
+
+	bundle := &InsuranceBundle{
+		Template:    template.Name,
+		GeneratedAt: u.clock.Now(),
+		Schedules:   make([]InsuranceScheduleResult, len(template.Schedules)),
+	}
+	totals := make([]currencyTotals, len(template.Schedules))
+	var allTotals currencyTotals
+	for i, schedule := range template.Schedules {
+		headers := make([]string, len(schedule.Columns))
+		for j, column := range schedule.Columns {
+			headers[j] = column.Header
+		}
+		bundle.Schedules[i] = InsuranceScheduleResult{
+			Name:    schedule.Name,
+			Title:   schedule.Title,
+			Headers: headers,
+			Rows:    [][]string{},
+			Missing: []InsuranceMissingFields{},
+		}
+	}
+
+	// IDをカーソルにして、エクスポートと同じ件数ずつ読み込む
+	query := entity.ItemQuery{Limit: ExportBatchSize, Sort: entity.SortByID, Order: entity.SortAsc}
+	for {
+		items, err := u.itemRepo.FindAll(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve items: %w", err)
+		}
+		if err := u.attachValuations(ctx, items); err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			if item.Draft {
+				continue
+			}
+			index := template.ScheduleIndex(item.Category)
+			if index < 0 {
+				continue
+			}
+			schedule := template.Schedules[index]
+			result := &bundle.Schedules[index]
+
+			row := make([]string, len(schedule.Columns))
+			for j, column := range schedule.Columns {
+				row[j] = entity.InsuranceFieldValue(item, column.Field)
+			}
+			result.Rows = append(result.Rows, row)
+			result.Count++
+
+			var missing []string
+			for _, field := range schedule.Required {
+				if entity.InsuranceFieldValue(item, field) == "" {
+					missing = append(missing, field)
+				}
+			}
+			if len(missing) > 0 {
+				result.Missing = append(result.Missing, InsuranceMissingFields{ItemID: item.ID, Fields: missing})
+			}
+
+			value := entity.InsuredValue(item)
+			totals[index].add(value)
+			allTotals.add(value)
+		}
+
+		if len(items) < ExportBatchSize {
+			break
+		}
+		query.AfterID = items[len(items)-1].ID
+	}
+
+	for i := range bundle.Schedules {
+		bundle.Schedules[i].Totals = totals[i].list()
+	}
+	bundle.Totals = allTotals.list()
+	return bundle, nil
+}
+
+func (u *insuranceUsecase) findTemplate(name string) (entity.InsuranceTemplate, bool) {
+	for _, template := range u.templates {
+		if template.Name == name {
+			return template, true
+		}
+	}
+	return entity.InsuranceTemplate{}, false
+}
+
+func (u *insuranceUsecase) attachValuations(ctx context.Context, items []*entity.Item) error {
+	if u.valuationRepo == nil || len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	latest, err := u.valuationRepo.FindLatestByItemIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve valuations: %w", err)
+	}
+	for _, item := range items {
+		item.SetLatestValuation(latest[item.ID])
+	}
+	return nil
+}
+
+// 通貨ごとの合計（最初に現れた通貨の順）
+type currencyTotals struct {
+	totals []CurrencyTotal
+}
+
+func (t *currencyTotals) add(m entity.Money) {
+	for i := range t.totals {
+		if t.totals[i].Currency == m.Currency {
+			t.totals[i].Total += m.Amount
+			return
+		}
+	}
+	t.totals = append(t.totals, CurrencyTotal{Currency: m.Currency, Total: m.Amount})
+}
+
+func (t *currencyTotals) list() []CurrencyTotal {
+	if t.totals == nil {
+		return []CurrencyTotal{}
+	}
+	return t.totals
+}
+
+// 明細ごとのシートに、見出し・アイテム・通貨ごとの合計の行を書き込む
+// 合計は通貨の単位の10進表記で、見出しの最後の列に書き込む
+func WriteInsuranceXLSX(w io.Writer, bundle *InsuranceBundle) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	for i, schedule := range bundle.Schedules {
+		sheet := schedule.Title
+		if sheet == "" {
+			sheet = schedule.Name
+		}
+		if i == 0 {
+			if err := f.SetSheetName("Sheet1", sheet); err != nil {
+				return fmt.Errorf("failed to create sheet %q: %w", sheet, err)
+			}
+		} else if _, err := f.NewSheet(sheet); err != nil {
+			return fmt.Errorf("failed to create sheet %q: %w", sheet, err)
+		}
+
+		rows := make([][]string, 0, len(schedule.Rows)+len(schedule.Totals)+2)
+		rows = append(rows, schedule.Headers)
+		rows = append(rows, schedule.Rows...)
+		if len(schedule.Totals) > 0 {
+			rows = append(rows, nil)
+		}
+		for _, total := range schedule.Totals {
+			row := make([]string, len(schedule.Headers))
+			row[0] = "合計"
+			if len(row) > 1 {
+				row[len(row)-2] = total.Currency
+			}
+			row[len(row)-1] = entity.Money{Amount: total.Total, Currency: total.Currency}.DecimalString()
+			rows = append(rows, row)
+		}
+
+		for r, values := range rows {
+			if values == nil {
+				continue
+			}
+			cells := make([]interface{}, len(values))
+			for c, value := range values {
+				cells[c] = value
+			}
+			cell, err := excelize.CoordinatesToCellName(1, r+1)
+			if err != nil {
+				return err
+			}
+			if err := f.SetSheetRow(sheet, cell, &cells); err != nil {
+				return fmt.Errorf("failed to write sheet %q: %w", sheet, err)
+			}
+		}
+	}
+
+	if err := f.Write(w); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	return nil
+}
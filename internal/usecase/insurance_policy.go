@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type InsurancePolicyUsecase interface {
+	GetPolicies(ctx context.Context, itemID int64) ([]*entity.InsurancePolicy, error)
+	AddPolicy(ctx context.Context, itemID int64, input InsurancePolicyInput) (*entity.InsurancePolicy, error)
+	UpdatePolicy(ctx context.Context, itemID, policyID int64, input UpdateInsurancePolicyInput) (*entity.InsurancePolicy, error)
+	DeletePolicy(ctx context.Context, itemID, policyID int64) error
+
+	// 満期日が今日から within（例: 30d）以内の保険の契約（満期日の順）
+	GetExpiringPolicies(ctx context.Context, within string) (*ExpiringInsuranceReport, error)
+}
+
+type InsurancePolicyInput struct {
+	PolicyNumber string       `json:"policy_number"`
+	Insurer      string       `json:"insurer"`
+	Coverage     entity.Money `json:"coverage"`
+	ExpiresOn    entity.Date  `json:"expires_on"`
+}
+
+// 指定した項目のみ更新する
+type UpdateInsurancePolicyInput struct {
+	PolicyNumber *string       `json:"policy_number,omitempty"`
+	Insurer      *string       `json:"insurer,omitempty"`
+	Coverage     *entity.Money `json:"coverage,omitempty"`
+	ExpiresOn    *entity.Date  `json:"expires_on,omitempty"`
+}
+
+// 更新する項目が指定されているか
+func (input UpdateInsurancePolicyInput) HasChanges() bool {
+	return input.PolicyNumber != nil || input.Insurer != nil || input.Coverage != nil || input.ExpiresOn != nil
+}
+
+// 満期が近い保険の契約（満期日が as_of から until まで、両端を含む）
+type ExpiringInsuranceReport struct {
+	AsOf       entity.Date                       `json:"as_of"`
+	Until      entity.Date                       `json:"until"`
+	WithinDays int                               `json:"within_days"`
+	Policies   []*entity.ExpiringInsurancePolicy `json:"policies"`
+	Totals     []CurrencyTotal                   `json:"totals"` // 満期が近い保険金額の通貨ごとの合計
+}
+
+type insurancePolicyUsecase struct {
+	itemRepo   ItemRepository
+	policyRepo InsurancePolicyRepository
+	readOnly   *ReadOnlySwitch
+
+	// 満期までの日数の基準にする「今日」の現在時刻
+	clock entity.Clock
+}
+
+func NewInsurancePolicyUsecase(itemRepo ItemRepository, policyRepo InsurancePolicyRepository, readOnly *ReadOnlySwitch, clock entity.Clock) InsurancePolicyUsecase {
+	if readOnly == nil {
+		readOnly = NewReadOnlySwitch(false)
+	}
+	if clock == nil {
+		clock = entity.SystemClock
+	}
+	return &insurancePolicyUsecase{
+		itemRepo:   itemRepo,
+		policyRepo: policyRepo,
+		readOnly:   readOnly,
+		clock:      clock,
+	}
+}
+
+func (u *insurancePolicyUsecase) GetPolicies(ctx context.Context, itemID int64) ([]*entity.InsurancePolicy, error) {
+	if err := u.ensureItem(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	policies, err := u.policyRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve insurance policies: %w", err)
+	}
+
+	if policies == nil {
+		policies = []*entity.InsurancePolicy{}
+	}
+
+	return policies, nil
+}
+
+func (u *insurancePolicyUsecase) AddPolicy(ctx context.Context, itemID int64, input InsurancePolicyInput) (*entity.InsurancePolicy, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+
+	if err := u.ensureItem(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	policy, err := entity.NewInsurancePolicy(itemID, input.PolicyNumber, input.Insurer, input.Coverage, input.ExpiresOn, u.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+
+	created, err := u.policyRepo.Create(ctx, policy)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrDuplicateEntry) {
+			return nil, fmt.Errorf("%w: %s %s", domainErrors.ErrDuplicateInsurancePolicy, policy.Insurer, policy.PolicyNumber)
+		}
+		return nil, fmt.Errorf("failed to create insurance policy: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *insurancePolicyUsecase) UpdatePolicy(ctx context.Context, itemID, policyID int64, input UpdateInsurancePolicyInput) (*entity.InsurancePolicy, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+
+	if !input.HasChanges() {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, "at least one field must be provided")
+	}
+
+	policy, err := u.findPolicy(ctx, itemID, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	policyNumber := policy.PolicyNumber
+	if input.PolicyNumber != nil {
+		policyNumber = *input.PolicyNumber
+	}
+	insurer := policy.Insurer
+	if input.Insurer != nil {
+		insurer = *input.Insurer
+	}
+	coverage := policy.Coverage
+	if input.Coverage != nil {
+		coverage = *input.Coverage
+	}
+	expiresOn := policy.ExpiresOn
+	if input.ExpiresOn != nil {
+		expiresOn = *input.ExpiresOn
+	}
+
+	if err := policy.Update(policyNumber, insurer, coverage, expiresOn, u.clock.Now()); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+
+	updated, err := u.policyRepo.Update(ctx, policy)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrDuplicateEntry) {
+			return nil, fmt.Errorf("%w: %s %s", domainErrors.ErrDuplicateInsurancePolicy, policy.Insurer, policy.PolicyNumber)
+		}
+		if domainErrors.IsInsurancePolicyNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update insurance policy: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (u *insurancePolicyUsecase) DeletePolicy(ctx context.Context, itemID, policyID int64) error {
+	if u.readOnly.Enabled() {
+		return domainErrors.ErrReadOnly
+	}
+
+	if _, err := u.findPolicy(ctx, itemID, policyID); err != nil {
+		return err
+	}
+
+	if err := u.policyRepo.Delete(ctx, policyID); err != nil {
+		if domainErrors.IsInsurancePolicyNotFoundError(err) {
+			return err
+		}
+		return fmt.Errorf("failed to delete insurance policy: %w", err)
+	}
+
+	return nil
+}
+
+func (u *insurancePolicyUsecase) GetExpiringPolicies(ctx context.Context, within string) (*ExpiringInsuranceReport, error) {
+	days, err := parseExpiryWindow(within)
+	if err != nil {
+		return nil, err
+	}
+
+	today := entity.DateOf(u.clock.Now())
+	until := entity.DateOf(today.Time().AddDate(0, 0, days))
+
+	// 集計は参照のみのため、一時的なエラーの場合は再試行する
+	var policies []*entity.ExpiringInsurancePolicy
+	err = DefaultRetryPolicy.do(ctx, func() error {
+		var err error
+		policies, err = u.policyRepo.FindExpiring(ctx, today, until)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve expiring insurance policies: %w", err)
+	}
+
+	report := &ExpiringInsuranceReport{
+		AsOf:       today,
+		Until:      until,
+		WithinDays: days,
+		Policies:   []*entity.ExpiringInsurancePolicy{},
+	}
+	var totals currencyTotals
+	for _, policy := range policies {
+		policy.DaysLeft = policy.DaysUntilExpiry(today)
+		report.Policies = append(report.Policies, policy)
+		totals.add(policy.Coverage)
+	}
+	report.Totals = totals.list()
+
+	return report, nil
+}
+
+// アイテムが存在するか確認する（削除済みのアイテムの保険は扱わない）
+func (u *insurancePolicyUsecase) ensureItem(ctx context.Context, itemID int64) error {
+	if itemID <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	if _, err := u.itemRepo.FindByID(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemNotFound
+		}
+		return fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return nil
+}
+
+// アイテムの保険の契約（他のアイテムの契約は ErrInsurancePolicyNotFound）
+func (u *insurancePolicyUsecase) findPolicy(ctx context.Context, itemID, policyID int64) (*entity.InsurancePolicy, error) {
+	if err := u.ensureItem(ctx, itemID); err != nil {
+		return nil, err
+	}
+	if policyID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	policy, err := u.policyRepo.FindByID(ctx, policyID)
+	if err != nil {
+		if domainErrors.IsInsurancePolicyNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve insurance policy: %w", err)
+	}
+	if policy.ItemID != itemID {
+		return nil, domainErrors.ErrInsurancePolicyNotFound
+	}
+
+	return policy, nil
+}
+
+// 満期までの日数（"30d" または "30"、未指定の場合は既定の日数）
+func parseExpiryWindow(within string) (int, error) {
+	within = strings.TrimSpace(within)
+	if within == "" {
+		return entity.DefaultInsuranceExpiryWindowDays, nil
+	}
+
+	days, err := strconv.Atoi(strings.TrimSuffix(within, "d"))
+	if err != nil || days < 0 || days > entity.MaxInsuranceExpiryWindowDays {
+		return 0, fmt.Errorf("%w: within must be a number of days between 0d and %dd (e.g. 30d)", domainErrors.ErrInvalidInput, entity.MaxInsuranceExpiryWindowDays)
+	}
+	return days, nil
+}
@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockInsurancePolicyRepository は保険の契約のモックリポジトリ
+type MockInsurancePolicyRepository struct {
+	mock.Mock
+}
+
+func (m *MockInsurancePolicyRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.InsurancePolicy, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.InsurancePolicy), args.Error(1)
+}
+
+func (m *MockInsurancePolicyRepository) FindByID(ctx context.Context, id int64) (*entity.InsurancePolicy, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InsurancePolicy), args.Error(1)
+}
+
+func (m *MockInsurancePolicyRepository) FindExpiring(ctx context.Context, from, to entity.Date) ([]*entity.ExpiringInsurancePolicy, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ExpiringInsurancePolicy), args.Error(1)
+}
+
+func (m *MockInsurancePolicyRepository) Create(ctx context.Context, policy *entity.InsurancePolicy) (*entity.InsurancePolicy, error) {
+	args := m.Called(ctx, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InsurancePolicy), args.Error(1)
+}
+
+func (m *MockInsurancePolicyRepository) Update(ctx context.Context, policy *entity.InsurancePolicy) (*entity.InsurancePolicy, error) {
+	args := m.Called(ctx, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InsurancePolicy), args.Error(1)
+}
+
+func (m *MockInsurancePolicyRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestInsurancePolicyUsecase_AddPolicy(t *testing.T) {
+	item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	input := InsurancePolicyInput{PolicyNumber: "AB-123", Insurer: "東京海上", Coverage: entity.JPY(1000000), ExpiresOn: entity.MustParseDate("2027-03-31")}
+
+	tests := []struct {
+		name        string
+		itemID      int64
+		input       InsurancePolicyInput
+		setupMock   func(*MockItemRepository, *MockInsurancePolicyRepository)
+		expectedErr error
+	}{
+		{
+			name:   "正常系: 保険の契約を登録",
+			itemID: 1,
+			input:  input,
+			setupMock: func(itemRepo *MockItemRepository, policyRepo *MockInsurancePolicyRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				policyRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *entity.InsurancePolicy) bool {
+					return p.ItemID == 1 && p.PolicyNumber == "AB-123" && p.CreatedAt.Equal(now)
+				})).Return(&entity.InsurancePolicy{ID: 1, ItemID: 1, PolicyNumber: "AB-123"}, nil)
+			},
+		},
+		{
+			name:   "異常系: 証券番号が空",
+			itemID: 1,
+			input:  InsurancePolicyInput{Insurer: "東京海上", Coverage: entity.JPY(1000000), ExpiresOn: entity.MustParseDate("2027-03-31")},
+			setupMock: func(itemRepo *MockItemRepository, policyRepo *MockInsurancePolicyRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:   "異常系: アイテムがない",
+			itemID: 999,
+			input:  input,
+			setupMock: func(itemRepo *MockItemRepository, policyRepo *MockInsurancePolicyRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:   "異常系: 登録済みの証券番号",
+			itemID: 1,
+			input:  input,
+			setupMock: func(itemRepo *MockItemRepository, policyRepo *MockInsurancePolicyRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				policyRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDuplicateEntry)
+			},
+			expectedErr: domainErrors.ErrDuplicateInsurancePolicy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			policyRepo := new(MockInsurancePolicyRepository)
+			tt.setupMock(itemRepo, policyRepo)
+			usecase := NewInsurancePolicyUsecase(itemRepo, policyRepo, nil, entity.FixedClock(now))
+
+			policy, err := usecase.AddPolicy(context.Background(), tt.itemID, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, policy)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), policy.ID)
+			}
+			itemRepo.AssertExpectations(t)
+			policyRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("異常系: 読み取り専用モード", func(t *testing.T) {
+		usecase := NewInsurancePolicyUsecase(new(MockItemRepository), new(MockInsurancePolicyRepository), NewReadOnlySwitch(true), nil)
+		_, err := usecase.AddPolicy(context.Background(), 1, input)
+		assert.ErrorIs(t, err, domainErrors.ErrReadOnly)
+	})
+}
+
+func TestInsurancePolicyUsecase_UpdatePolicy(t *testing.T) {
+	item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	newPolicy := func(itemID int64) *entity.InsurancePolicy {
+		return &entity.InsurancePolicy{ID: 5, ItemID: itemID, PolicyNumber: "AB-123", Insurer: "東京海上", Coverage: entity.JPY(1000000), ExpiresOn: entity.MustParseDate("2026-10-31")}
+	}
+	renewed := entity.MustParseDate("2027-10-31")
+
+	t.Run("正常系: 指定した項目のみ更新する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		policyRepo := new(MockInsurancePolicyRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		policyRepo.On("FindByID", mock.Anything, int64(5)).Return(newPolicy(1), nil)
+		policyRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *entity.InsurancePolicy) bool {
+			return p.ExpiresOn == renewed && p.PolicyNumber == "AB-123" && p.Coverage == entity.JPY(1000000) && p.UpdatedAt.Equal(now)
+		})).Return(&entity.InsurancePolicy{ID: 5, ItemID: 1, ExpiresOn: renewed}, nil)
+		usecase := NewInsurancePolicyUsecase(itemRepo, policyRepo, nil, entity.FixedClock(now))
+
+		policy, err := usecase.UpdatePolicy(context.Background(), 1, 5, UpdateInsurancePolicyInput{ExpiresOn: &renewed})
+
+		require.NoError(t, err)
+		assert.Equal(t, renewed, policy.ExpiresOn)
+		policyRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 更新する項目がない", func(t *testing.T) {
+		usecase := NewInsurancePolicyUsecase(new(MockItemRepository), new(MockInsurancePolicyRepository), nil, entity.FixedClock(now))
+		_, err := usecase.UpdatePolicy(context.Background(), 1, 5, UpdateInsurancePolicyInput{})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 他のアイテムの契約", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		policyRepo := new(MockInsurancePolicyRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		policyRepo.On("FindByID", mock.Anything, int64(5)).Return(newPolicy(2), nil)
+		usecase := NewInsurancePolicyUsecase(itemRepo, policyRepo, nil, entity.FixedClock(now))
+
+		_, err := usecase.UpdatePolicy(context.Background(), 1, 5, UpdateInsurancePolicyInput{ExpiresOn: &renewed})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInsurancePolicyNotFound)
+		policyRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestInsurancePolicyUsecase_DeletePolicy(t *testing.T) {
+	item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+
+	t.Run("正常系: 保険の契約を削除", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		policyRepo := new(MockInsurancePolicyRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		policyRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.InsurancePolicy{ID: 5, ItemID: 1}, nil)
+		policyRepo.On("Delete", mock.Anything, int64(5)).Return(nil)
+		usecase := NewInsurancePolicyUsecase(itemRepo, policyRepo, nil, nil)
+
+		require.NoError(t, usecase.DeletePolicy(context.Background(), 1, 5))
+		policyRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 契約がない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		policyRepo := new(MockInsurancePolicyRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		policyRepo.On("FindByID", mock.Anything, int64(5)).Return(nil, domainErrors.ErrInsurancePolicyNotFound)
+		usecase := NewInsurancePolicyUsecase(itemRepo, policyRepo, nil, nil)
+
+		assert.ErrorIs(t, usecase.DeletePolicy(context.Background(), 1, 5), domainErrors.ErrInsurancePolicyNotFound)
+		policyRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestInsurancePolicyUsecase_GetExpiringPolicies(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	today := entity.MustParseDate("2026-10-01")
+
+	t.Run("正常系: 満期までの日数と通貨ごとの合計", func(t *testing.T) {
+		policyRepo := new(MockInsurancePolicyRepository)
+		policyRepo.On("FindExpiring", mock.Anything, today, entity.MustParseDate("2026-10-31")).Return([]*entity.ExpiringInsurancePolicy{
+			{InsurancePolicy: &entity.InsurancePolicy{ID: 1, Coverage: entity.JPY(1000000), ExpiresOn: entity.MustParseDate("2026-10-01")}},
+			{InsurancePolicy: &entity.InsurancePolicy{ID: 2, Coverage: entity.JPY(500000), ExpiresOn: entity.MustParseDate("2026-10-15")}},
+		}, nil)
+		usecase := NewInsurancePolicyUsecase(new(MockItemRepository), policyRepo, nil, entity.FixedClock(now))
+
+		report, err := usecase.GetExpiringPolicies(context.Background(), "30d")
+
+		require.NoError(t, err)
+		assert.Equal(t, today, report.AsOf)
+		assert.Equal(t, 30, report.WithinDays)
+		require.Len(t, report.Policies, 2)
+		assert.Equal(t, 0, report.Policies[0].DaysLeft)
+		assert.Equal(t, 14, report.Policies[1].DaysLeft)
+		assert.Equal(t, []CurrencyTotal{{Currency: "JPY", Total: 1500000}}, report.Totals)
+	})
+
+	t.Run("正常系: 省略時は30日、該当なしは空", func(t *testing.T) {
+		policyRepo := new(MockInsurancePolicyRepository)
+		policyRepo.On("FindExpiring", mock.Anything, today, entity.MustParseDate("2026-10-31")).Return(nil, nil)
+		usecase := NewInsurancePolicyUsecase(new(MockItemRepository), policyRepo, nil, entity.FixedClock(now))
+
+		report, err := usecase.GetExpiringPolicies(context.Background(), "")
+
+		require.NoError(t, err)
+		assert.NotNil(t, report.Policies)
+		assert.Empty(t, report.Policies)
+	})
+
+	for _, within := range []string{"abc", "-1d", "367d", "30days"} {
+		t.Run("異常系: within が不正 "+within, func(t *testing.T) {
+			usecase := NewInsurancePolicyUsecase(new(MockItemRepository), new(MockInsurancePolicyRepository), nil, entity.FixedClock(now))
+			_, err := usecase.GetExpiringPolicies(context.Background(), within)
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		})
+	}
+}
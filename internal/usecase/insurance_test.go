@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestInsuranceUsecase_GenerateSchedules(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	ring := &entity.Item{ID: 1, Name: "リング", Brand: "Cartier", Category: "ジュエリー", PurchaseDate: entity.MustParseDate("2020-01-01"), PurchasePrice: entity.JPY(500000), Attributes: map[string]string{"material": "K18"}}
+	necklace := &entity.Item{ID: 2, Name: "ネックレス", Category: "ジュエリー", PurchaseDate: entity.MustParseDate("2021-01-01"), PurchasePrice: entity.JPY(200000)}
+	watch := &entity.Item{ID: 3, Name: "デイトナ", Brand: "ROLEX", Category: "時計", PurchaseDate: entity.MustParseDate("2019-01-01"), PurchasePrice: entity.Money{Amount: 1500000, Currency: "USD"}, Attributes: map[string]string{"reference_number": "116500LN"}}
+	bag := &entity.Item{ID: 4, Name: "バーキン", Brand: "HERMES", Category: "バッグ", PurchaseDate: entity.MustParseDate("2022-01-01"), PurchasePrice: entity.JPY(2000000)}
+	draft := &entity.Item{ID: 5, Name: "下書き", Category: "時計", Draft: true}
+
+	t.Run("正常系: カテゴリーごとの明細に分け、評価額を保険金額として合計し、不足している必須項目を報告する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, entity.ItemQuery{Limit: ExportBatchSize, Sort: entity.SortByID, Order: entity.SortAsc}).
+			Return([]*entity.Item{ring, necklace, watch, bag, draft}, nil)
+		valuationRepo := new(MockValuationRepository)
+		valuationRepo.On("FindLatestByItemIDs", mock.Anything, []int64{1, 2, 3, 4, 5}).Return(map[int64]*entity.Valuation{
+			1: {ItemID: 1, Value: entity.JPY(800000), ValuatedAt: entity.MustParseDate("2026-04-01"), Source: "鑑定書"},
+		}, nil)
+
+		usecase := NewInsuranceUsecase(itemRepo, valuationRepo, nil, entity.FixedClock(now))
+		bundle, err := usecase.GenerateSchedules(context.Background(), "")
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.DefaultInsuranceTemplate, bundle.Template)
+		assert.Equal(t, now, bundle.GeneratedAt)
+		require.Len(t, bundle.Schedules, 3)
+
+		jewelry := bundle.Schedules[0]
+		assert.Equal(t, "jewelry", jewelry.Name)
+		assert.Equal(t, 2, jewelry.Count)
+		assert.Equal(t, []string{"1", "リング", "Cartier", "K18", "2020-01-01", "500000", "JPY", "800000", "2026-04-01", "鑑定書"}, jewelry.Rows[0])
+		assert.Equal(t, []CurrencyTotal{{Currency: "JPY", Total: 1000000}}, jewelry.Totals)
+		assert.Equal(t, []InsuranceMissingFields{{ItemID: 2, Fields: []string{"brand", "attributes.material", "valuation_date"}}}, jewelry.Missing)
+
+		watches := bundle.Schedules[1]
+		assert.Equal(t, 1, watches.Count)
+		assert.Equal(t, []CurrencyTotal{{Currency: "USD", Total: 1500000}}, watches.Totals)
+		assert.Empty(t, watches.Missing)
+
+		general := bundle.Schedules[2]
+		assert.Equal(t, 1, general.Count)
+		assert.Equal(t, "バッグ", general.Rows[0][2])
+
+		assert.Equal(t, []CurrencyTotal{{Currency: "JPY", Total: 3000000}, {Currency: "USD", Total: 1500000}}, bundle.Totals)
+	})
+
+	t.Run("正常系: 書式で指定した列と明細で出力し、どの明細にも当てはまらないアイテムは含めない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{ring, watch, bag}, nil)
+		valuationRepo := new(MockValuationRepository)
+		valuationRepo.On("FindLatestByItemIDs", mock.Anything, mock.Anything).Return(map[int64]*entity.Valuation{}, nil)
+		templates := []entity.InsuranceTemplate{{
+			Name: "acme",
+			Schedules: []entity.InsuranceSchedule{{
+				Name:       "valuables",
+				Title:      "貴重品",
+				Categories: []string{"ジュエリー", "時計"},
+				Columns:    []entity.InsuranceColumn{{Header: "Item", Field: "name"}, {Header: "Value", Field: "insured_value"}, {Header: "Currency", Field: "insured_currency"}},
+			}},
+		}}
+
+		usecase := NewInsuranceUsecase(itemRepo, valuationRepo, templates, entity.FixedClock(now))
+		bundle, err := usecase.GenerateSchedules(context.Background(), "acme")
+
+		require.NoError(t, err)
+		require.Len(t, bundle.Schedules, 1)
+		assert.Equal(t, []string{"Item", "Value", "Currency"}, bundle.Schedules[0].Headers)
+		assert.Equal(t, [][]string{{"リング", "500000", "JPY"}, {"デイトナ", "15000.00", "USD"}}, bundle.Schedules[0].Rows)
+	})
+
+	t.Run("異常系: 存在しない書式", func(t *testing.T) {
+		usecase := NewInsuranceUsecase(new(MockItemRepository), new(MockValuationRepository), nil, entity.FixedClock(now))
+		_, err := usecase.GenerateSchedules(context.Background(), "unknown")
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: アイテムの取得に失敗", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item(nil), domainErrors.ErrDatabaseError)
+
+		usecase := NewInsuranceUsecase(itemRepo, new(MockValuationRepository), nil, entity.FixedClock(now))
+		_, err := usecase.GenerateSchedules(context.Background(), "")
+
+		assert.True(t, errors.Is(err, domainErrors.ErrDatabaseError))
+	})
+}
+
+func TestWriteInsuranceXLSX(t *testing.T) {
+	bundle := &InsuranceBundle{
+		Template: "standard",
+		Schedules: []InsuranceScheduleResult{
+			{Name: "jewelry", Title: "宝飾品明細", Headers: []string{"品名", "通貨", "評価額"}, Rows: [][]string{{"リング", "JPY", "800000"}}, Totals: []CurrencyTotal{{Currency: "JPY", Total: 800000}}},
+			{Name: "watches", Title: "時計明細", Headers: []string{"品名", "通貨", "評価額"}, Rows: [][]string{}, Totals: []CurrencyTotal{}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteInsuranceXLSX(&buf, bundle))
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer f.Close()
+
+	assert.Equal(t, []string{"宝飾品明細", "時計明細"}, f.GetSheetList())
+	rows, err := f.GetRows("宝飾品明細")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"品名", "通貨", "評価額"}, {"リング", "JPY", "800000"}, nil, {"合計", "JPY", "800000"}}, rows)
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+type itemCacheKey struct{}
+
+// リクエスト単位のアイテムキャッシュ
+// 同一リクエスト内で同じIDのアイテムを複数回取得する場合に、リポジトリへの問い合わせを1回にまとめる
+type itemCache struct {
+	mu    sync.Mutex
+	items map[int64]*entity.Item
+}
+
+// リクエスト単位のアイテムキャッシュを持つコンテキストを返す
+func WithItemCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, itemCacheKey{}, &itemCache{
+		items: make(map[int64]*entity.Item),
+	})
+}
+
+func itemCacheFrom(ctx context.Context) *itemCache {
+	cache, _ := ctx.Value(itemCacheKey{}).(*itemCache)
+	return cache
+}
+
+// キャッシュされたアイテムのコピーを返す（呼び出し側の変更がキャッシュに影響しないようにする）
+func (c *itemCache) get(id int64) (*entity.Item, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, ok := c.items[id]
+	if !ok {
+		return nil, false
+	}
+	copied := *item
+	return &copied, true
+}
+
+func (c *itemCache) set(item *entity.Item) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	copied := *item
+	c.items[item.ID] = &copied
+}
+
+func (c *itemCache) delete(id int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.items, id)
+}
+
+// コンテキストにキャッシュがあればそれを使い、なければリポジトリから取得する
+func (u *itemUsecase) findItem(ctx context.Context, id int64) (*entity.Item, error) {
+	cache := itemCacheFrom(ctx)
+	if cache == nil {
+		return u.itemRepo.FindByID(ctx, id)
+	}
+
+	if item, ok := cache.get(id); ok {
+		return item, nil
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	cache.set(item)
+
+	copied := *item
+	return &copied, nil
+}
+
+// 更新・作成後のアイテムをキャッシュに反映する
+func (u *itemUsecase) cacheItem(ctx context.Context, item *entity.Item) {
+	if cache := itemCacheFrom(ctx); cache != nil && item != nil {
+		cache.set(item)
+	}
+}
+
+// 削除されたアイテムをキャッシュから取り除く
+func (u *itemUsecase) evictItem(ctx context.Context, id int64) {
+	if cache := itemCacheFrom(ctx); cache != nil {
+		cache.delete(id)
+	}
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// アイテムの変更イベント（監査ログと同じ単位で、変更の完了後に発行する）
+type ItemEvent struct {
+	ItemID     int64
+	Action     entity.AuditAction
+	Actor      string
+	OccurredAt time.Time
+}
+
+// 変更イベントの受け取り手
+// アウトボックスを使う場合、エラーを返したイベントは間隔を空けて再送する（すべての受け取り手にもう一度渡すため、
+// 受け取り手は同じイベントを複数回受け取ってもよいようにする）。使わない場合はエラーを無視する
+type ItemEventHandler interface {
+	HandleItemEvent(ctx context.Context, event ItemEvent) error
+}
+
+// アイテムの変更イベントを受け取る（複数指定した場合は指定順に呼び出す）
+func WithItemEventHandler(handler ItemEventHandler) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.eventHandlers = append(u.eventHandlers, handler)
+	}
+}
+
+// 変更イベントを、変更と同じトランザクションでアウトボックスに記録する（受け取り手には OutboxRelay が渡す）
+// 変更の確定後に発行すると、発行の前にプロセスが終了した場合にイベントが失われるため
+func WithOutbox(outboxRepo OutboxRepository, transactor Transactor) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.outboxRepo = outboxRepo
+		u.outboxTransactor = transactor
+	}
+}
+
+// 変更と変更イベントの記録を行う
+// アウトボックスを使う場合は fn をトランザクションで実行し、fn の中の audit で記録したイベントも同じトランザクションで確定する
+// 一時的なエラー（デッドロックなど）で失敗した場合はロールバックされているため、トランザクションごと再試行する
+func (u *itemUsecase) mutate(ctx context.Context, fn func(ctx context.Context) error) error {
+	if u.outboxRepo == nil {
+		return fn(ctx)
+	}
+	return u.retryPolicy.do(ctx, func() error {
+		return runInTransaction(ctx, u.outboxTransactor, fn)
+	})
+}
+
+// 変更イベントをアウトボックスに記録する。アウトボックスを使わない場合は受け取り手にすぐに渡す
+func (u *itemUsecase) publish(ctx context.Context, event ItemEvent) error {
+	if u.outboxRepo != nil {
+		if err := u.outboxRepo.Create(ctx, entity.NewOutboxEvent(event.ItemID, event.Action, event.Actor, event.OccurredAt)); err != nil {
+			return fmt.Errorf("failed to record item event: %w", err)
+		}
+		return nil
+	}
+
+	for _, handler := range u.eventHandlers {
+		_ = handler.HandleItemEvent(ctx, event)
+	}
+	return nil
+}
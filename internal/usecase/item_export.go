@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 全アイテムをファイルに書き出して保存する（ペイロードは CreateExportInput、結果は ItemExportResult）
+const JobItemsExport = "items.export"
+
+// 非同期のエクスポート（アイテム数が多く、HTTPリクエスト内では書き出しが終わらない場合に使う）
+type ExportUsecase interface {
+	CreateExport(ctx context.Context, input CreateExportInput) (*ItemExport, error)
+	GetExport(ctx context.Context, id int64) (*ItemExport, error)
+}
+
+type CreateExportInput struct {
+	Format string `json:"format"` // csv（省略時）, xlsx, json
+	BOM    bool   `json:"bom"`    // CSV の先頭に BOM を付ける
+}
+
+// エクスポートの状況。完了した場合は署名付きのダウンロードURLを含む
+type ItemExport struct {
+	ID     int64            `json:"id"` // ジョブのID
+	Format string           `json:"format"`
+	Status entity.JobStatus `json:"status"`
+	Error  string           `json:"error,omitempty"` // 最後に失敗した理由
+
+	URL       string     `json:"url,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Size      int64      `json:"size,omitempty"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// エクスポートのジョブの結果
+type ItemExportResult struct {
+	StorageKey string `json:"storage_key"`
+	Size       int64  `json:"size"`
+}
+
+type exportUsecase struct {
+	jobUsecase JobUsecase
+	storage    ImageStorage
+	urlExpiry  time.Duration
+	clock      entity.Clock
+}
+
+// storage はファイルの保存先（写真と同じオブジェクトストレージ）。ダウンロードURLは取得のたびに urlExpiry の期限で発行する
+func NewExportUsecase(jobUsecase JobUsecase, storage ImageStorage, urlExpiry time.Duration, clock entity.Clock) ExportUsecase {
+	if clock == nil {
+		clock = entity.SystemClock
+	}
+	return &exportUsecase{
+		jobUsecase: jobUsecase,
+		storage:    storage,
+		urlExpiry:  urlExpiry,
+		clock:      clock,
+	}
+}
+
+func (u *exportUsecase) CreateExport(ctx context.Context, input CreateExportInput) (*ItemExport, error) {
+	if input.Format == "" {
+		input.Format = ExportFormatCSV
+	}
+	if _, ok := ExportFileTypeOf(input.Format); !ok {
+		return nil, fmt.Errorf("%w: format must be one of: %s, %s, %s", domainErrors.ErrInvalidInput, ExportFormatCSV, ExportFormatXLSX, ExportFormatJSON)
+	}
+
+	job, err := u.jobUsecase.Enqueue(ctx, JobItemsExport, input)
+	if err != nil {
+		return nil, err
+	}
+	return u.exportOf(ctx, job)
+}
+
+// 操作者が作成したエクスポート（他の種類のジョブは、ないものとして扱う）
+func (u *exportUsecase) GetExport(ctx context.Context, id int64) (*ItemExport, error) {
+	job, err := u.jobUsecase.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Type != JobItemsExport {
+		return nil, domainErrors.ErrJobNotFound
+	}
+	return u.exportOf(ctx, job)
+}
+
+func (u *exportUsecase) exportOf(ctx context.Context, job *entity.Job) (*ItemExport, error) {
+	var input CreateExportInput
+	if err := json.Unmarshal(job.Payload, &input); err != nil {
+		return nil, fmt.Errorf("failed to decode export job: %w", err)
+	}
+
+	export := &ItemExport{
+		ID:         job.ID,
+		Format:     input.Format,
+		Status:     job.Status,
+		Error:      job.LastError,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+	if job.Status != entity.JobSucceeded {
+		return export, nil
+	}
+
+	var result ItemExportResult
+	if err := json.Unmarshal(job.Result, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode export result: %w", err)
+	}
+	url, err := u.storage.SignedURL(ctx, result.StorageKey, u.urlExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign export URL: %w", err)
+	}
+	expiresAt := u.clock.Now().Add(u.urlExpiry)
+	export.URL = url
+	export.ExpiresAt = &expiresAt
+	export.Size = result.Size
+	return export, nil
+}
+
+// エクスポートのジョブの処理。全アイテムを一時ファイルに書き出してから保存する
+func NewItemExportJobHandler(itemUsecase ItemUsecase, storage ImageStorage) JobHandler {
+	return JobHandlerFunc(func(ctx context.Context, job *entity.Job) (interface{}, error) {
+		var input CreateExportInput
+		if err := json.Unmarshal(job.Payload, &input); err != nil {
+			return nil, fmt.Errorf("%w: invalid payload: %s", domainErrors.ErrInvalidInput, err.Error())
+		}
+		fileType, ok := ExportFileTypeOf(input.Format)
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported format: %s", domainErrors.ErrInvalidInput, input.Format)
+		}
+
+		file, err := os.CreateTemp("", "items-export-*"+fileType.Extension)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create export file: %w", err)
+		}
+		defer os.Remove(file.Name())
+		defer file.Close()
+
+		if err := itemUsecase.ExportItems(ctx, file, ExportItemsInput{Format: input.Format, BOM: input.BOM}); err != nil {
+			return nil, err
+		}
+
+		size, err := file.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write export file: %w", err)
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to write export file: %w", err)
+		}
+
+		key, err := itemExportKey(fileType.Extension)
+		if err != nil {
+			return nil, err
+		}
+		if err := storage.Save(ctx, key, file, size, fileType.ContentType); err != nil {
+			return nil, fmt.Errorf("failed to store export: %w", err)
+		}
+
+		return ItemExportResult{StorageKey: key, Size: size}, nil
+	})
+}
+
+// 写真の一括エクスポートと同じ exports/ 配下に保存する
+func itemExportKey(extension string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("exports/items-%s%s", hex.EncodeToString(b), extension), nil
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_ExportItems_Formats(t *testing.T) {
+	item, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), entity.MustParseDate("2023-01-15"), time.Now())
+	item.ID = 1
+
+	t.Run("正常系: JSON はAPIと同じ形式のアイテムの配列", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{item}, nil)
+
+		var buf bytes.Buffer
+		err := NewItemUsecase(mockRepo).ExportItems(context.Background(), &buf, ExportItemsInput{Format: ExportFormatJSON})
+
+		require.NoError(t, err)
+		var items []*entity.Item
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &items))
+		require.Len(t, items, 1)
+		assert.Equal(t, "ロレックス デイトナ", items[0].Name)
+	})
+
+	t.Run("正常系: アイテムが0件の場合は空の配列", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{}, nil)
+
+		var buf bytes.Buffer
+		err := NewItemUsecase(mockRepo).ExportItems(context.Background(), &buf, ExportItemsInput{Format: ExportFormatJSON})
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `[]`, buf.String())
+	})
+
+	t.Run("正常系: XLSX は CSV と同じ列", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{item}, nil)
+
+		var buf bytes.Buffer
+		err := NewItemUsecase(mockRepo).ExportItems(context.Background(), &buf, ExportItemsInput{Format: ExportFormatXLSX})
+
+		require.NoError(t, err)
+		f, err := excelize.OpenReader(&buf)
+		require.NoError(t, err)
+		defer f.Close()
+		rows, err := f.GetRows("Sheet1")
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, exportCSVHeader, rows[0])
+		assert.Equal(t, []string{"1", "ロレックス デイトナ", "時計", "ROLEX", "1500000", "JPY", "2023-01-15"}, rows[1][:7])
+	})
+}
+
+func TestExportUsecase(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	item, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), entity.MustParseDate("2023-01-15"), time.Now())
+	item.ID = 1
+
+	newUsecases := func(itemRepo *MockItemRepository, storage *MockImageStorage) (JobUsecase, ExportUsecase) {
+		jobUsecase := NewJobUsecase(&fakeJobRepository{},
+			WithJobClock(entity.FixedClock(now)),
+			WithJobHandler(JobItemsExport, NewItemExportJobHandler(NewItemUsecase(itemRepo), storage)),
+			WithJobHandler("other", JobHandlerFunc(func(ctx context.Context, job *entity.Job) (interface{}, error) { return nil, nil })),
+		)
+		return jobUsecase, NewExportUsecase(jobUsecase, storage, time.Hour, entity.FixedClock(now))
+	}
+
+	t.Run("正常系: ジョブで書き出して保存し、ダウンロードURLを返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{item}, nil)
+		storage := new(MockImageStorage)
+		var key string
+		storage.On("Save", mock.Anything, mock.MatchedBy(func(k string) bool {
+			key = k
+			return true
+		}), mock.Anything, mock.Anything, "application/json").Return(nil)
+		storage.On("SignedURL", mock.Anything, mock.Anything, time.Hour).Return("https://example.com/exports/items.json?sig=abc", nil)
+		jobUsecase, usecase := newUsecases(itemRepo, storage)
+		ctx := WithActor(context.Background(), "alice")
+
+		created, err := usecase.CreateExport(ctx, CreateExportInput{Format: ExportFormatJSON})
+		require.NoError(t, err)
+		assert.Equal(t, entity.JobQueued, created.Status)
+		assert.Empty(t, created.URL)
+
+		ran, err := jobUsecase.RunNext(context.Background())
+		require.NoError(t, err)
+		require.True(t, ran)
+
+		export, err := usecase.GetExport(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.JobSucceeded, export.Status)
+		assert.Equal(t, ExportFormatJSON, export.Format)
+		assert.Equal(t, "https://example.com/exports/items.json?sig=abc", export.URL)
+		assert.Equal(t, now.Add(time.Hour), *export.ExpiresAt)
+		assert.Positive(t, export.Size)
+		assert.Regexp(t, `^exports/items-[0-9a-f]{32}\.json$`, key)
+		storage.AssertCalled(t, "SignedURL", mock.Anything, key, time.Hour)
+	})
+
+	t.Run("異常系: 未対応の形式", func(t *testing.T) {
+		_, usecase := newUsecases(new(MockItemRepository), new(MockImageStorage))
+
+		_, err := usecase.CreateExport(context.Background(), CreateExportInput{Format: "pdf"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: エクスポート以外のジョブ", func(t *testing.T) {
+		jobUsecase, usecase := newUsecases(new(MockItemRepository), new(MockImageStorage))
+		job, err := jobUsecase.Enqueue(context.Background(), "other", nil)
+		require.NoError(t, err)
+
+		_, err = usecase.GetExport(context.Background(), job.ID)
+
+		assert.ErrorIs(t, err, domainErrors.ErrJobNotFound)
+	})
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ジョブの種類
+const (
+	// アイテムの評価額を市場価格で更新する（ペイロードは ValuationRefreshPayload）
+	JobValuationRefresh = "valuation.refresh"
+)
+
+// 失敗したジョブの再試行方針のデフォルト（MaxAttempts はジョブごとの最大実行回数）
+var DefaultJobRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	BaseDelay:   10 * time.Second,
+	MaxDelay:    10 * time.Minute,
+}
+
+// 実行中のジョブの期限のデフォルト。期限を過ぎたジョブは、ワーカーが応答しなくなったとみなして再度実行する
+const DefaultJobLease = 5 * time.Minute
+
+// 記録するエラーの最大文字数
+const maxJobErrorLength = 500
+
+type JobUsecase interface {
+	// ジョブを登録する（ワーカーが RunNext で実行する）
+	Enqueue(ctx context.Context, jobType string, payload interface{}) (*entity.Job, error)
+	// 操作者が登録したジョブ
+	GetJob(ctx context.Context, id int64) (*entity.Job, error)
+	// 実行できるジョブを1件実行する。実行できるジョブがなかった場合は false を返す
+	RunNext(ctx context.Context) (bool, error)
+	// ジョブが登録されたときに通知する（待機中のワーカーを起こす）
+	Pending() <-chan struct{}
+}
+
+// ジョブの種類ごとの処理
+// 戻り値はジョブの結果として記録する。入力の誤りや対象がないなど、再試行しても成功しないエラーの場合はすぐに失敗とする
+type JobHandler interface {
+	HandleJob(ctx context.Context, job *entity.Job) (interface{}, error)
+}
+
+type JobHandlerFunc func(ctx context.Context, job *entity.Job) (interface{}, error)
+
+func (f JobHandlerFunc) HandleJob(ctx context.Context, job *entity.Job) (interface{}, error) {
+	return f(ctx, job)
+}
+
+type jobUsecase struct {
+	jobRepo     JobRepository
+	handlers    map[string]JobHandler
+	readOnly    *ReadOnlySwitch
+	retryPolicy RetryPolicy
+	lease       time.Duration
+	clock       entity.Clock
+
+	pending chan struct{}
+}
+
+// JobUsecaseの任意の設定を指定するオプション
+type JobUsecaseOption func(*jobUsecase)
+
+// ジョブの種類の処理を登録する
+func WithJobHandler(jobType string, handler JobHandler) JobUsecaseOption {
+	return func(u *jobUsecase) {
+		u.handlers[jobType] = handler
+	}
+}
+
+// 読み取り専用モードのスイッチを指定
+func WithJobReadOnlySwitch(readOnly *ReadOnlySwitch) JobUsecaseOption {
+	return func(u *jobUsecase) {
+		u.readOnly = readOnly
+	}
+}
+
+// 再試行方針を指定
+func WithJobRetryPolicy(policy RetryPolicy) JobUsecaseOption {
+	return func(u *jobUsecase) {
+		u.retryPolicy = policy
+	}
+}
+
+// 実行中のジョブの期限を指定（1回の実行はこの時間でタイムアウトする）
+func WithJobLease(lease time.Duration) JobUsecaseOption {
+	return func(u *jobUsecase) {
+		u.lease = lease
+	}
+}
+
+// 現在時刻の取得元を指定（デフォルトはシステムの時刻）
+func WithJobClock(clock entity.Clock) JobUsecaseOption {
+	return func(u *jobUsecase) {
+		u.clock = clock
+	}
+}
+
+func NewJobUsecase(jobRepo JobRepository, opts ...JobUsecaseOption) JobUsecase {
+	u := &jobUsecase{
+		jobRepo:     jobRepo,
+		handlers:    make(map[string]JobHandler),
+		readOnly:    NewReadOnlySwitch(false),
+		retryPolicy: DefaultJobRetryPolicy,
+		lease:       DefaultJobLease,
+		clock:       entity.SystemClock,
+		pending:     make(chan struct{}, 1),
+	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
+}
+
+func (u *jobUsecase) Enqueue(ctx context.Context, jobType string, payload interface{}) (*entity.Job, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+
+	if _, ok := u.handlers[jobType]; !ok {
+		return nil, fmt.Errorf("%w: unknown job type: %s", domainErrors.ErrInvalidInput, jobType)
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid job payload: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	job := entity.NewJob(jobType, encoded, ActorFromContext(ctx), u.retryPolicy.MaxAttempts, u.clock.Now())
+	created, err := u.jobRepo.Create(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+
+	select {
+	case u.pending <- struct{}{}:
+	default:
+	}
+	return created, nil
+}
+
+// 他の操作者が登録したジョブは、ないものとして扱う
+func (u *jobUsecase) GetJob(ctx context.Context, id int64) (*entity.Job, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	job, err := u.jobRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsJobNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve job: %w", err)
+	}
+	if job.Owner != ActorFromContext(ctx) {
+		return nil, domainErrors.ErrJobNotFound
+	}
+	return job, nil
+}
+
+func (u *jobUsecase) Pending() <-chan struct{} {
+	return u.pending
+}
+
+// 実行待ちのジョブを1件取得して実行し、結果を記録する
+// 失敗した場合は再試行方針に従って次の実行日時を予定し、上限に達した場合は失敗とする
+func (u *jobUsecase) RunNext(ctx context.Context) (bool, error) {
+	now := u.clock.Now()
+	job, err := u.jobRepo.Claim(ctx, now, now.Add(u.lease))
+	if err != nil {
+		return false, fmt.Errorf("failed to claim job: %w", err)
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	result, err := u.run(ctx, job)
+	now = u.clock.Now()
+	if err != nil {
+		message := truncateJobError(err.Error())
+		var retryAt *time.Time
+		if isRetryableJobError(err) {
+			next := now.Add(u.retryPolicy.backoff(job.Attempts))
+			retryAt = &next
+		}
+		job.Fail(message, retryAt, now)
+	} else {
+		job.Succeed(result, now)
+	}
+
+	// ワーカーの停止で中断した場合も結果を記録する
+	if err := u.jobRepo.Update(context.WithoutCancel(ctx), job); err != nil {
+		return true, fmt.Errorf("failed to update job: %w", err)
+	}
+	return true, nil
+}
+
+// 期限内に処理を実行し、結果を JSON にする
+func (u *jobUsecase) run(ctx context.Context, job *entity.Job) (json.RawMessage, error) {
+	handler, ok := u.handlers[job.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown job type: %s", domainErrors.ErrInvalidInput, job.Type)
+	}
+
+	ctx, cancel := context.WithTimeout(WithActor(ctx, job.Owner), u.lease)
+	defer cancel()
+
+	result, err := handler.HandleJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid job result: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	return encoded, nil
+}
+
+// 入力の誤り・対象がない・保留中のアイテムなど、再試行しても結果が変わらないエラー以外は再試行する
+func isRetryableJobError(err error) bool {
+	switch {
+	case domainErrors.IsValidationError(err),
+		domainErrors.IsNotFoundError(err),
+		domainErrors.IsOnHoldError(err):
+		return false
+	}
+	return true
+}
+
+// 評価額の更新ジョブのペイロード
+type ValuationRefreshPayload struct {
+	ItemID int64 `json:"item_id"`
+}
+
+// 評価額の更新ジョブの処理（市場価格の取得に時間がかかるため、HTTPリクエストとは別に実行できるようにする）
+func NewValuationRefreshJobHandler(valuationUsecase ValuationUsecase) JobHandler {
+	return JobHandlerFunc(func(ctx context.Context, job *entity.Job) (interface{}, error) {
+		var payload ValuationRefreshPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("%w: invalid payload: %s", domainErrors.ErrInvalidInput, err.Error())
+		}
+		return valuationUsecase.RefreshItemValuation(ctx, payload.ItemID)
+	})
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// メモリ上のジョブの保存先
+type fakeJobRepository struct {
+	jobs []*entity.Job
+}
+
+func (r *fakeJobRepository) Create(ctx context.Context, job *entity.Job) (*entity.Job, error) {
+	created := *job
+	created.ID = int64(len(r.jobs) + 1)
+	r.jobs = append(r.jobs, &created)
+	copied := created
+	return &copied, nil
+}
+
+func (r *fakeJobRepository) FindByID(ctx context.Context, id int64) (*entity.Job, error) {
+	for _, job := range r.jobs {
+		if job.ID == id {
+			copied := *job
+			return &copied, nil
+		}
+	}
+	return nil, domainErrors.ErrJobNotFound
+}
+
+func (r *fakeJobRepository) Claim(ctx context.Context, now, leaseUntil time.Time) (*entity.Job, error) {
+	for _, job := range r.jobs {
+		due := job.Status == entity.JobQueued && !job.RunAt.After(now)
+		expired := job.Status == entity.JobRunning && job.LockedUntil != nil && !job.LockedUntil.After(now)
+		if due || expired {
+			job.Status = entity.JobRunning
+			job.Attempts++
+			job.LockedUntil = &leaseUntil
+			copied := *job
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeJobRepository) Update(ctx context.Context, job *entity.Job) error {
+	for i, j := range r.jobs {
+		if j.ID == job.ID {
+			copied := *job
+			r.jobs[i] = &copied
+		}
+	}
+	return nil
+}
+
+func TestJobUsecase_Enqueue(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	handler := JobHandlerFunc(func(ctx context.Context, job *entity.Job) (interface{}, error) { return nil, nil })
+
+	t.Run("正常系: 操作者のジョブとして登録し、待機中のワーカーに通知する", func(t *testing.T) {
+		repo := &fakeJobRepository{}
+		usecase := NewJobUsecase(repo, WithJobHandler("test", handler), WithJobClock(entity.FixedClock(now)))
+		ctx := WithActor(context.Background(), "alice")
+
+		job, err := usecase.Enqueue(ctx, "test", map[string]int{"item_id": 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.JobQueued, job.Status)
+		assert.Equal(t, "alice", job.Owner)
+		assert.Equal(t, DefaultJobRetryPolicy.MaxAttempts, job.MaxAttempts)
+		assert.JSONEq(t, `{"item_id":1}`, string(job.Payload))
+		assert.Len(t, usecase.Pending(), 1)
+
+		found, err := usecase.GetJob(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, found.ID)
+
+		// 他の操作者のジョブは見えない
+		_, err = usecase.GetJob(WithActor(context.Background(), "bob"), job.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrJobNotFound)
+	})
+
+	t.Run("異常系: 処理が登録されていない種類", func(t *testing.T) {
+		usecase := NewJobUsecase(&fakeJobRepository{})
+
+		_, err := usecase.Enqueue(context.Background(), "unknown", nil)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 読み取り専用モード", func(t *testing.T) {
+		usecase := NewJobUsecase(&fakeJobRepository{}, WithJobHandler("test", handler), WithJobReadOnlySwitch(NewReadOnlySwitch(true)))
+
+		_, err := usecase.Enqueue(context.Background(), "test", nil)
+
+		assert.ErrorIs(t, err, domainErrors.ErrReadOnly)
+	})
+}
+
+func TestJobUsecase_RunNext(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	policy := RetryPolicy{MaxAttempts: 2, BaseDelay: time.Minute, MaxDelay: time.Hour}
+
+	t.Run("正常系: 成功した場合は結果を記録する", func(t *testing.T) {
+		repo := &fakeJobRepository{}
+		var actor string
+		usecase := NewJobUsecase(repo,
+			WithJobClock(entity.FixedClock(now)),
+			WithJobHandler("test", JobHandlerFunc(func(ctx context.Context, job *entity.Job) (interface{}, error) {
+				actor = ActorFromContext(ctx)
+				return map[string]int{"value": 100}, nil
+			})),
+		)
+		_, err := usecase.Enqueue(WithActor(context.Background(), "alice"), "test", nil)
+		require.NoError(t, err)
+
+		ran, err := usecase.RunNext(context.Background())
+
+		require.NoError(t, err)
+		assert.True(t, ran)
+		assert.Equal(t, "alice", actor)
+		job := repo.jobs[0]
+		assert.Equal(t, entity.JobSucceeded, job.Status)
+		assert.Equal(t, 1, job.Attempts)
+		assert.JSONEq(t, `{"value":100}`, string(job.Result))
+		assert.Nil(t, job.LockedUntil)
+		assert.Equal(t, &now, job.FinishedAt)
+
+		ran, err = usecase.RunNext(context.Background())
+		require.NoError(t, err)
+		assert.False(t, ran)
+	})
+
+	t.Run("正常系: 失敗した場合は間隔を空けて再試行し、上限に達したら失敗とする", func(t *testing.T) {
+		repo := &fakeJobRepository{}
+		current := now
+		usecase := NewJobUsecase(repo,
+			WithJobClock(entity.ClockFunc(func() time.Time { return current })),
+			WithJobRetryPolicy(policy),
+			WithJobHandler("test", JobHandlerFunc(func(ctx context.Context, job *entity.Job) (interface{}, error) {
+				return nil, errors.New("connection refused")
+			})),
+		)
+		_, err := usecase.Enqueue(context.Background(), "test", nil)
+		require.NoError(t, err)
+
+		ran, err := usecase.RunNext(context.Background())
+		require.NoError(t, err)
+		assert.True(t, ran)
+		job := repo.jobs[0]
+		assert.Equal(t, entity.JobQueued, job.Status)
+		assert.Equal(t, "connection refused", job.LastError)
+		assert.True(t, job.RunAt.After(now))
+
+		// 次の実行日時までは実行しない
+		ran, err = usecase.RunNext(context.Background())
+		require.NoError(t, err)
+		assert.False(t, ran)
+
+		current = now.Add(time.Hour)
+		ran, err = usecase.RunNext(context.Background())
+		require.NoError(t, err)
+		assert.True(t, ran)
+		job = repo.jobs[0]
+		assert.Equal(t, entity.JobFailed, job.Status)
+		assert.Equal(t, 2, job.Attempts)
+		assert.NotNil(t, job.FinishedAt)
+	})
+
+	t.Run("異常系: 再試行しても成功しないエラーはすぐに失敗とする", func(t *testing.T) {
+		repo := &fakeJobRepository{}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(99)).Return(nil, domainErrors.ErrItemNotFound)
+		valuationUsecase := NewValuationUsecase(mockRepo, new(MockValuationRepository), nil)
+		usecase := NewJobUsecase(repo,
+			WithJobClock(entity.FixedClock(now)),
+			WithJobRetryPolicy(policy),
+			WithJobHandler(JobValuationRefresh, NewValuationRefreshJobHandler(valuationUsecase)),
+		)
+		_, err := usecase.Enqueue(context.Background(), JobValuationRefresh, ValuationRefreshPayload{ItemID: 99})
+		require.NoError(t, err)
+
+		ran, err := usecase.RunNext(context.Background())
+
+		require.NoError(t, err)
+		assert.True(t, ran)
+		job := repo.jobs[0]
+		assert.Equal(t, entity.JobFailed, job.Status)
+		assert.Equal(t, 1, job.Attempts)
+		assert.Contains(t, job.LastError, "item not found")
+	})
+}
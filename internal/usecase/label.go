@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ラベルを作成できるアイテム数の上限
+const MaxLabelItems = 300
+
+type LabelUsecase interface {
+	GetLabelLayouts() []entity.LabelLayout
+	GenerateLabels(ctx context.Context, w io.Writer, input GenerateLabelsInput) error
+}
+
+type GenerateLabelsInput struct {
+	ItemIDs []int64 `json:"item_ids"`
+	Layout  string  `json:"layout"` // 未指定の場合は entity.DefaultLabelLayout
+
+	// 1枚目の先頭から読み飛ばすラベル数（使いかけの用紙に印刷する場合）
+	Skip int `json:"skip"`
+}
+
+// ラベルシートを描画する（PDFなど）
+type LabelRenderer interface {
+	RenderLabels(w io.Writer, layout entity.LabelLayout, skip int, labels []entity.ItemLabel) error
+}
+
+type labelUsecase struct {
+	itemRepo ItemRepository
+	renderer LabelRenderer
+
+	// QRコードにするアイテムのURLのベース（未指定の場合は "items/{id}"）
+	qrBaseURL string
+}
+
+func NewLabelUsecase(itemRepo ItemRepository, renderer LabelRenderer, qrBaseURL string) LabelUsecase {
+	return &labelUsecase{
+		itemRepo:  itemRepo,
+		renderer:  renderer,
+		qrBaseURL: strings.TrimRight(qrBaseURL, "/"),
+	}
+}
+
+func (u *labelUsecase) GetLabelLayouts() []entity.LabelLayout {
+	return entity.LabelLayouts()
+}
+
+// 指定したアイテムの名前・ID・QRコードを並べたラベルシートを描画する（保管箱に貼る用）
+// ラベルは指定した順に並べる
+func (u *labelUsecase) GenerateLabels(ctx context.Context, w io.Writer, input GenerateLabelsInput) error {
+	itemIDs, err := normalizeItemIDs(input.ItemIDs, MaxLabelItems)
+	if err != nil {
+		return err
+	}
+
+	name := input.Layout
+	if name == "" {
+		name = entity.DefaultLabelLayout
+	}
+	layout, ok := entity.FindLabelLayout(name)
+	if !ok {
+		var names []string
+		for _, layout := range entity.LabelLayouts() {
+			names = append(names, layout.Name)
+		}
+		return fmt.Errorf("%w: layout must be one of: %s", domainErrors.ErrInvalidInput, strings.Join(names, ", "))
+	}
+	if input.Skip < 0 || input.Skip >= layout.PerSheet() {
+		return fmt.Errorf("%w: skip must be between 0 and %d", domainErrors.ErrInvalidInput, layout.PerSheet()-1)
+	}
+
+	// 1回のクエリでまとめて取得する
+	items, err := u.itemRepo.FindAll(ctx, entity.ItemQuery{IDs: itemIDs, Sort: entity.SortByID, Limit: len(itemIDs)})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	byID := make(map[int64]*entity.Item, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	labels := make([]entity.ItemLabel, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		item, ok := byID[id]
+		if !ok {
+			return fmt.Errorf("%w: item %d", domainErrors.ErrItemNotFound, id)
+		}
+		labels = append(labels, entity.ItemLabel{
+			ItemID: item.ID,
+			Name:   item.Name,
+			Brand:  item.Brand,
+			QRCode: u.itemURL(item.ID),
+		})
+	}
+
+	if err := u.renderer.RenderLabels(w, layout, input.Skip, labels); err != nil {
+		return fmt.Errorf("failed to render labels: %w", err)
+	}
+	return nil
+}
+
+func (u *labelUsecase) itemURL(id int64) string {
+	path := "items/" + strconv.FormatInt(id, 10)
+	if u.qrBaseURL == "" {
+		return path
+	}
+	return u.qrBaseURL + "/" + path
+}
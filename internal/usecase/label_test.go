@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 描画を依頼された内容を記録する
+type fakeLabelRenderer struct {
+	layout entity.LabelLayout
+	skip   int
+	labels []entity.ItemLabel
+	err    error
+}
+
+func (r *fakeLabelRenderer) RenderLabels(w io.Writer, layout entity.LabelLayout, skip int, labels []entity.ItemLabel) error {
+	r.layout, r.skip, r.labels = layout, skip, labels
+	if r.err != nil {
+		return r.err
+	}
+	_, err := io.WriteString(w, "%PDF")
+	return err
+}
+
+func TestLabelUsecase_GenerateLabels(t *testing.T) {
+	watch := &entity.Item{ID: 1, Name: "デイトナ", Brand: "ROLEX"}
+	bag := &entity.Item{ID: 2, Name: "バーキン", Brand: "HERMES"}
+
+	t.Run("正常系: 指定した順にラベルを並べ、QRコードにアイテムのURLを使う", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, entity.ItemQuery{IDs: []int64{2, 1}, Sort: entity.SortByID, Limit: 2}).Return([]*entity.Item{watch, bag}, nil)
+		renderer := &fakeLabelRenderer{}
+
+		var buf bytes.Buffer
+		usecase := NewLabelUsecase(itemRepo, renderer, "https://example.com/")
+		err := usecase.GenerateLabels(context.Background(), &buf, GenerateLabelsInput{ItemIDs: []int64{2, 1, 2}, Layout: "a4-12", Skip: 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, "%PDF", buf.String())
+		assert.Equal(t, "a4-12", renderer.layout.Name)
+		assert.Equal(t, 3, renderer.skip)
+		assert.Equal(t, []entity.ItemLabel{
+			{ItemID: 2, Name: "バーキン", Brand: "HERMES", QRCode: "https://example.com/items/2"},
+			{ItemID: 1, Name: "デイトナ", Brand: "ROLEX", QRCode: "https://example.com/items/1"},
+		}, renderer.labels)
+	})
+
+	t.Run("正常系: レイアウトを指定しない場合は既定のレイアウトを使う", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{watch}, nil)
+		renderer := &fakeLabelRenderer{}
+
+		usecase := NewLabelUsecase(itemRepo, renderer, "")
+		err := usecase.GenerateLabels(context.Background(), io.Discard, GenerateLabelsInput{ItemIDs: []int64{1}})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.DefaultLabelLayout, renderer.layout.Name)
+		assert.Equal(t, "items/1", renderer.labels[0].QRCode)
+	})
+
+	tests := []struct {
+		name        string
+		input       GenerateLabelsInput
+		setupMock   func(*MockItemRepository)
+		renderErr   error
+		expectedErr error
+	}{
+		{
+			name:        "異常系: アイテムの指定がない",
+			input:       GenerateLabelsInput{},
+			setupMock:   func(m *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 未定義のレイアウト",
+			input:       GenerateLabelsInput{ItemIDs: []int64{1}, Layout: "b5-8"},
+			setupMock:   func(m *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 読み飛ばす数が1枚のラベル数以上",
+			input:       GenerateLabelsInput{ItemIDs: []int64{1}, Layout: "a4-12", Skip: 12},
+			setupMock:   func(m *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 存在しないアイテムを含む",
+			input: GenerateLabelsInput{ItemIDs: []int64{1, 999}},
+			setupMock: func(m *MockItemRepository) {
+				m.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{watch}, nil)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:  "異常系: 描画に失敗",
+			input: GenerateLabelsInput{ItemIDs: []int64{1}},
+			setupMock: func(m *MockItemRepository) {
+				m.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{watch}, nil)
+			},
+			renderErr:   errors.New("font error"),
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			tt.setupMock(itemRepo)
+
+			usecase := NewLabelUsecase(itemRepo, &fakeLabelRenderer{err: tt.renderErr}, "")
+			err := usecase.GenerateLabels(context.Background(), io.Discard, tt.input)
+
+			require.Error(t, err)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.False(t, domainErrors.IsValidationError(err))
+			}
+			itemRepo.AssertExpectations(t)
+		})
+	}
+}
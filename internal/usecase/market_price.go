@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 市場価格の取得1回あたりのタイムアウトのデフォルト
+const DefaultPriceTimeout = 10 * time.Second
+
+// 市場価格の取得の再試行方針のデフォルト（外部APIのため、データベースより間隔を空ける）
+var DefaultPriceRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+// 市場価格の取得先（外部の鑑定・相場APIなど）
+type PriceProvider interface {
+	// Quote returns the current market value of an item matching the query
+	// Errors wrapping ErrTransient are retried
+	Quote(ctx context.Context, query PriceQuery) (*MarketPrice, error)
+}
+
+// 市場価格の問い合わせ条件
+type PriceQuery struct {
+	Brand    string
+	Model    string // 型番（なければアイテム名）
+	Category string
+	Currency string // この通貨で価格を求める
+}
+
+type MarketPrice struct {
+	Value  entity.Money
+	Source string // 価格の出典（相場サイト名など）
+}
+
+// 評価額の取得元が不明な場合の既定値
+const defaultMarketPriceSource = "market"
+
+// アイテムのブランド・型番の現在の市場価格を取得し、当日の評価額として記録する
+func (u *valuationUsecase) RefreshItemValuation(ctx context.Context, itemID int64) (*entity.Valuation, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+
+	item, err := u.findItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.priceProvider == nil {
+		return nil, fmt.Errorf("%w: market price provider is not configured", domainErrors.ErrMarketPriceUnavailable)
+	}
+
+	price, err := u.quote(ctx, marketPriceQuery(item))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrMarketPriceUnavailable, err.Error())
+	}
+
+	source := strings.TrimSpace(price.Source)
+	if source == "" {
+		source = defaultMarketPriceSource
+	}
+
+	valuation, err := entity.NewValuation(item.ID, entity.DateOf(u.clock.Now()), price.Value, source, u.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid market price: %s", domainErrors.ErrMarketPriceUnavailable, err.Error())
+	}
+
+	created, err := u.valuationRepo.Create(ctx, valuation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create valuation: %w", err)
+	}
+	purgeItems(ctx, u.cachePurger, item)
+
+	return created, nil
+}
+
+// 1回ごとにタイムアウトを設け、一時的なエラーの場合は再試行する
+func (u *valuationUsecase) quote(ctx context.Context, query PriceQuery) (*MarketPrice, error) {
+	var price *MarketPrice
+	err := u.priceRetryPolicy.do(ctx, func() error {
+		attemptCtx, cancel := context.WithTimeout(ctx, u.priceTimeout)
+		defer cancel()
+
+		var err error
+		price, err = u.priceProvider.Quote(attemptCtx, query)
+		if err != nil && attemptCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+			// タイムアウトは次の試行で成功しうる
+			return fmt.Errorf("%w: %w", domainErrors.ErrTransient, err)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return price, nil
+}
+
+// 時計の型番など、より具体的な属性があれば名前より優先する
+func marketPriceQuery(item *entity.Item) PriceQuery {
+	model := item.Name
+	if ref := item.Attributes["reference_number"]; ref != "" {
+		model = ref
+	}
+
+	return PriceQuery{
+		Brand:    item.Brand,
+		Model:    model,
+		Category: item.Category,
+		Currency: item.PurchasePrice.Currency,
+	}
+}
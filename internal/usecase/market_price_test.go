@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockPriceProvider は相場APIのモック
+type MockPriceProvider struct {
+	mock.Mock
+}
+
+func (m *MockPriceProvider) Quote(ctx context.Context, query PriceQuery) (*MarketPrice, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*MarketPrice), args.Error(1)
+}
+
+// テストで待たないよう、待ち時間なしで再試行する
+var noDelayPriceRetryPolicy = RetryPolicy{MaxAttempts: 3}
+
+func TestValuationUsecase_RefreshItemValuation(t *testing.T) {
+	item, _ := entity.NewItem("デイトナ", "時計", "ROLEX", entity.JPY(1500000), entity.MustParseDate("2023-01-15"),
+		time.Now(),
+		entity.WithAttributes(map[string]string{"reference_number": "116500LN"}))
+	item.ID = 1
+	query := PriceQuery{Brand: "ROLEX", Model: "116500LN", Category: "時計", Currency: "JPY"}
+	transientErr := fmt.Errorf("%w: status 503", domainErrors.ErrTransient)
+
+	tests := []struct {
+		name          string
+		setupProvider func(*MockPriceProvider)
+		expectedErr   error
+		expectedCalls int
+	}{
+		{
+			name: "正常系: 市場価格を評価額として記録",
+			setupProvider: func(p *MockPriceProvider) {
+				p.On("Quote", mock.Anything, query).Return(&MarketPrice{Value: entity.JPY(2000000), Source: "相場サイト"}, nil)
+			},
+			expectedCalls: 1,
+		},
+		{
+			name: "正常系: 一時的なエラーは再試行する",
+			setupProvider: func(p *MockPriceProvider) {
+				p.On("Quote", mock.Anything, query).Return(nil, transientErr).Once()
+				p.On("Quote", mock.Anything, query).Return(&MarketPrice{Value: entity.JPY(2000000)}, nil).Once()
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "異常系: 再試行しても取得できない",
+			setupProvider: func(p *MockPriceProvider) {
+				p.On("Quote", mock.Anything, query).Return(nil, transientErr)
+			},
+			expectedErr:   domainErrors.ErrMarketPriceUnavailable,
+			expectedCalls: 3,
+		},
+		{
+			name: "異常系: 該当する価格がない場合は再試行しない",
+			setupProvider: func(p *MockPriceProvider) {
+				p.On("Quote", mock.Anything, query).Return(nil, errors.New("no market price"))
+			},
+			expectedErr:   domainErrors.ErrMarketPriceUnavailable,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			valuationRepo := new(MockValuationRepository)
+			provider := new(MockPriceProvider)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			tt.setupProvider(provider)
+			if tt.expectedErr == nil {
+				valuationRepo.On("Create", mock.Anything, mock.MatchedBy(func(v *entity.Valuation) bool {
+					return v.ItemID == 1 && v.Value == entity.JPY(2000000) && v.ValuatedAt.String() == time.Now().Format("2006-01-02")
+				})).Return(&entity.Valuation{ID: 1, ItemID: 1, Value: entity.JPY(2000000)}, nil)
+			}
+
+			usecase := NewValuationUsecase(itemRepo, valuationRepo, nil,
+				WithPriceProvider(provider),
+				WithPriceRetryPolicy(noDelayPriceRetryPolicy),
+			)
+			valuation, err := usecase.RefreshItemValuation(context.Background(), 1)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, valuation)
+				valuationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, valuation)
+			}
+			provider.AssertNumberOfCalls(t, "Quote", tt.expectedCalls)
+			valuationRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("異常系: タイムアウトは再試行する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		provider := new(MockPriceProvider)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		provider.On("Quote", mock.Anything, query).Run(func(args mock.Arguments) {
+			ctx := args.Get(0).(context.Context)
+			<-ctx.Done()
+		}).Return(nil, context.DeadlineExceeded)
+
+		usecase := NewValuationUsecase(itemRepo, new(MockValuationRepository), nil,
+			WithPriceProvider(provider),
+			WithPriceTimeout(10*time.Millisecond),
+			WithPriceRetryPolicy(RetryPolicy{MaxAttempts: 2}),
+		)
+		_, err := usecase.RefreshItemValuation(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrMarketPriceUnavailable)
+		provider.AssertNumberOfCalls(t, "Quote", 2)
+	})
+
+	t.Run("異常系: 相場APIが未設定", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+
+		usecase := NewValuationUsecase(itemRepo, new(MockValuationRepository), nil)
+		_, err := usecase.RefreshItemValuation(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrMarketPriceUnavailable)
+	})
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import "sync/atomic"
+
+// 読み取り専用モードの切り替えスイッチ
+// マイグレーションや障害対応中に、参照系を止めずに更新系だけを停止するために使用する
+type ReadOnlySwitch struct {
+	enabled atomic.Bool
+}
+
+func NewReadOnlySwitch(enabled bool) *ReadOnlySwitch {
+	s := &ReadOnlySwitch{}
+	s.enabled.Store(enabled)
+	return s
+}
+
+func (s *ReadOnlySwitch) Enabled() bool {
+	return s.enabled.Load()
+}
+
+func (s *ReadOnlySwitch) Set(enabled bool) {
+	s.enabled.Store(enabled)
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+const (
+	// 1回の実行で送信するイベントの件数
+	outboxRelayBatchSize = 100
+
+	// 記録するエラーの最大文字数
+	maxOutboxErrorLength = 500
+)
+
+// 送信に失敗したイベントの再送の間隔（アウトボックスのイベントは送信できるまで再送するため、MaxAttempts は使わない）
+var DefaultOutboxRetryPolicy = RetryPolicy{
+	BaseDelay: 5 * time.Second,
+	MaxDelay:  10 * time.Minute,
+}
+
+// 送信済みのイベントを残す期間の既定
+const DefaultOutboxRetention = 7 * 24 * time.Hour
+
+// 送信の実行結果
+type OutboxRelayResult struct {
+	Published int `json:"published"`
+	Retrying  int `json:"retrying"` // 失敗して再送を予定したもの
+	Deleted   int `json:"deleted"`  // 保持期間を過ぎて削除した送信済みのイベント
+}
+
+// アウトボックスに記録した変更イベントを受け取り手に渡し、すべての受け取り手が処理できたものを送信済みにする
+// 送信済みにする前にプロセスが終了した場合や、送信済みにできなかった場合は次の実行でもう一度渡す（少なくとも1回の送信）
+type OutboxRelay struct {
+	outboxRepo  OutboxRepository
+	handlers    []ItemEventHandler
+	retryPolicy RetryPolicy
+	retention   time.Duration
+	clock       entity.Clock
+}
+
+// OutboxRelayの任意の設定を指定するオプション
+type OutboxRelayOption func(*OutboxRelay)
+
+// 再送の間隔を指定
+func WithOutboxRetryPolicy(policy RetryPolicy) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		r.retryPolicy = policy
+	}
+}
+
+// 送信済みのイベントを残す期間を指定（0 の場合は削除しない）
+func WithOutboxRetention(retention time.Duration) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		r.retention = retention
+	}
+}
+
+// 現在時刻の取得元を指定（デフォルトはシステムの時刻）
+func WithOutboxClock(clock entity.Clock) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		r.clock = clock
+	}
+}
+
+func NewOutboxRelay(outboxRepo OutboxRepository, handlers []ItemEventHandler, opts ...OutboxRelayOption) *OutboxRelay {
+	r := &OutboxRelay{
+		outboxRepo:  outboxRepo,
+		handlers:    handlers,
+		retryPolicy: DefaultOutboxRetryPolicy,
+		retention:   DefaultOutboxRetention,
+		clock:       entity.SystemClock,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// 送信時刻を過ぎたイベントを記録順に受け取り手に渡す（定期実行用）
+// 失敗したイベントは間隔を空けて再送するため、後のイベントが先に送信されることがある
+func (r *OutboxRelay) Relay(ctx context.Context) (*OutboxRelayResult, error) {
+	result := &OutboxRelayResult{}
+
+	events, err := r.outboxRepo.FindDue(ctx, r.clock.Now(), outboxRelayBatchSize)
+	if err != nil {
+		return result, fmt.Errorf("failed to retrieve outbox events: %w", err)
+	}
+
+	for _, event := range events {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		err := r.dispatch(ctx, event)
+		now := r.clock.Now()
+		if err != nil {
+			event.Attempts++
+			event.LastError = err.Error()
+			if len(event.LastError) > maxOutboxErrorLength {
+				event.LastError = event.LastError[:maxOutboxErrorLength]
+			}
+			event.NextAttemptAt = now.Add(r.retryPolicy.backoff(event.Attempts))
+			result.Retrying++
+		} else {
+			event.PublishedAt = &now
+			result.Published++
+		}
+
+		if err := r.outboxRepo.Update(ctx, event); err != nil {
+			return result, fmt.Errorf("failed to update outbox event: %w", err)
+		}
+	}
+
+	if r.retention > 0 {
+		deleted, err := r.outboxRepo.DeletePublishedBefore(ctx, r.clock.Now().Add(-r.retention))
+		if err != nil {
+			return result, fmt.Errorf("failed to delete published outbox events: %w", err)
+		}
+		result.Deleted = int(deleted)
+	}
+
+	return result, nil
+}
+
+// すべての受け取り手に渡す（失敗した受け取り手があっても残りの受け取り手には渡す）
+func (r *OutboxRelay) dispatch(ctx context.Context, event *entity.OutboxEvent) error {
+	itemEvent := ItemEvent{
+		ItemID:     event.ItemID,
+		Action:     event.Action,
+		Actor:      event.Actor,
+		OccurredAt: event.CreatedAt,
+	}
+
+	var errs []error
+	for _, handler := range r.handlers {
+		if err := handler.HandleItemEvent(ctx, itemEvent); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
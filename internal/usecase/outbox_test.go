@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// メモリ上のアウトボックス
+type fakeOutboxRepository struct {
+	events    []*entity.OutboxEvent
+	createErr error
+}
+
+func (r *fakeOutboxRepository) Create(ctx context.Context, event *entity.OutboxEvent) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	event.ID = int64(len(r.events) + 1)
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *fakeOutboxRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.OutboxEvent, error) {
+	var due []*entity.OutboxEvent
+	for _, event := range r.events {
+		if event.PublishedAt == nil && !event.NextAttemptAt.After(now) && len(due) < limit {
+			copied := *event
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeOutboxRepository) Update(ctx context.Context, event *entity.OutboxEvent) error {
+	for i, e := range r.events {
+		if e.ID == event.ID {
+			copied := *event
+			r.events[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (r *fakeOutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	var kept []*entity.OutboxEvent
+	var deleted int64
+	for _, event := range r.events {
+		if event.PublishedAt != nil && event.PublishedAt.Before(before) {
+			deleted++
+			continue
+		}
+		kept = append(kept, event)
+	}
+	r.events = kept
+	return deleted, nil
+}
+
+// 受け取ったイベントを記録し、指定した回数だけ失敗する
+type fakeItemEventHandler struct {
+	events   []ItemEvent
+	failures int
+}
+
+func (h *fakeItemEventHandler) HandleItemEvent(ctx context.Context, event ItemEvent) error {
+	h.events = append(h.events, event)
+	if h.failures > 0 {
+		h.failures--
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestItemUsecase_Outbox(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	input := CreateItemInput{
+		Name:          "デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: entity.JPY(1500000),
+		PurchaseDate:  "2023-01-15",
+	}
+
+	t.Run("正常系: 変更と同じトランザクションでイベントを記録し、受け取り手にはすぐに渡さない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByDedupeKey", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 7}, nil)
+		outbox := &fakeOutboxRepository{}
+		transactor := &fakeTransactor{}
+		handler := &fakeItemEventHandler{}
+
+		usecase := NewItemUsecase(mockRepo,
+			WithClock(entity.FixedClock(now)),
+			WithItemEventHandler(handler),
+			WithOutbox(outbox, transactor),
+		)
+		_, err := usecase.CreateItem(WithActor(context.Background(), "alice"), input)
+
+		require.NoError(t, err)
+		require.Len(t, outbox.events, 1)
+		assert.Equal(t, int64(7), outbox.events[0].ItemID)
+		assert.Equal(t, entity.AuditCreate, outbox.events[0].Action)
+		assert.Equal(t, "alice", outbox.events[0].Actor)
+		assert.Equal(t, now, outbox.events[0].NextAttemptAt)
+		assert.False(t, transactor.rolledBack)
+		assert.Empty(t, handler.events)
+	})
+
+	t.Run("異常系: イベントを記録できない場合は変更を取り消す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByDedupeKey", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 7}, nil)
+		transactor := &fakeTransactor{}
+
+		usecase := NewItemUsecase(mockRepo, WithOutbox(&fakeOutboxRepository{createErr: domainErrors.ErrDatabaseError}, transactor))
+		_, err := usecase.CreateItem(context.Background(), input)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.True(t, transactor.rolledBack)
+	})
+}
+
+func TestOutboxRelay_Relay(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	current := now
+	clock := entity.ClockFunc(func() time.Time { return current })
+	policy := RetryPolicy{BaseDelay: time.Minute, MaxDelay: time.Hour}
+
+	outbox := &fakeOutboxRepository{}
+	require.NoError(t, outbox.Create(context.Background(), entity.NewOutboxEvent(1, entity.AuditCreate, "alice", now)))
+	require.NoError(t, outbox.Create(context.Background(), entity.NewOutboxEvent(2, entity.AuditDelete, "bob", now)))
+	search := &fakeItemEventHandler{}
+	webhooks := &fakeItemEventHandler{failures: 1}
+
+	relay := NewOutboxRelay(outbox, []ItemEventHandler{search, webhooks},
+		WithOutboxClock(clock),
+		WithOutboxRetryPolicy(policy),
+		WithOutboxRetention(24*time.Hour),
+	)
+
+	// 失敗した受け取り手があっても他の受け取り手には渡し、そのイベントは再送を予定する
+	result, err := relay.Relay(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &OutboxRelayResult{Published: 1, Retrying: 1}, result)
+	assert.Equal(t, []ItemEvent{
+		{ItemID: 1, Action: entity.AuditCreate, Actor: "alice", OccurredAt: now},
+		{ItemID: 2, Action: entity.AuditDelete, Actor: "bob", OccurredAt: now},
+	}, search.events)
+
+	failed := outbox.events[0]
+	assert.Nil(t, failed.PublishedAt)
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, "connection refused", failed.LastError)
+	assert.True(t, failed.NextAttemptAt.After(now))
+	require.NotNil(t, outbox.events[1].PublishedAt)
+
+	// 次の送信時刻までは送信しない
+	result, err = relay.Relay(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &OutboxRelayResult{}, result)
+
+	// 再送ではすべての受け取り手にもう一度渡す
+	current = now.Add(time.Hour)
+	result, err = relay.Relay(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &OutboxRelayResult{Published: 1}, result)
+	assert.Len(t, search.events, 3)
+	assert.Len(t, webhooks.events, 3)
+
+	// 保持期間を過ぎた送信済みのイベントは削除する
+	current = now.Add(26 * time.Hour)
+	result, err = relay.Relay(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Deleted)
+	assert.Empty(t, outbox.events)
+}
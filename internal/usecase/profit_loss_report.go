@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 集計期間の単位（month / year、未指定の場合は month）と、売却日の期間（month は YYYY-MM、year は YYYY 形式、両端を含む）
+// 期間が未指定の場合はすべて
+type ProfitAndLossInput struct {
+	Period string
+	From   string
+	To     string
+}
+
+// 売却日の期間・カテゴリーごとの実現損益（円換算額）
+// 期間は売却のある期間のみを含む
+type ProfitAndLossReport struct {
+	Period  entity.ProfitLossPeriod `json:"period"`
+	Periods []PeriodProfitAndLoss   `json:"periods"`
+	Total   ProfitAndLoss           `json:"total"`
+}
+
+type PeriodProfitAndLoss struct {
+	Period string `json:"period"` // YYYY-MM または YYYY
+	ProfitAndLoss
+	Categories []CategoryProfitAndLoss `json:"categories"`
+}
+
+type CategoryProfitAndLoss struct {
+	Category string `json:"category"`
+	ProfitAndLoss
+}
+
+// 円換算額は実現損益を円換算できた売却のみの合計（円換算できない売却は unconverted に数える）
+type ProfitAndLoss struct {
+	Count           int   `json:"count"`
+	Unconverted     int   `json:"unconverted"`
+	ProceedsJPY     int64 `json:"proceeds_jpy"`      // 手取り額（売却価格 - 手数料）
+	CostBasisJPY    int64 `json:"cost_basis_jpy"`    // 取得費（購入価格）
+	RealizedGainJPY int64 `json:"realized_gain_jpy"` // 実現損益
+}
+
+func (p *ProfitAndLoss) add(t entity.ProfitLossTotal) {
+	p.Count += t.Count
+	p.Unconverted += t.Unconverted
+	p.ProceedsJPY += t.ProceedsJPY
+	p.CostBasisJPY += t.CostBasisJPY
+	p.RealizedGainJPY += t.RealizedGainJPY
+}
+
+func (u *reportUsecase) GetProfitAndLoss(ctx context.Context, input ProfitAndLossInput) (*ProfitAndLossReport, error) {
+	period, fromDate, toDate, err := parseProfitAndLossInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// 集計は参照のみのため、一時的なエラーの場合は再試行する
+	var totals []entity.ProfitLossTotal
+	err = DefaultRetryPolicy.do(ctx, func() error {
+		var err error
+		totals, err = u.saleRepo.GetProfitLossTotals(ctx, period, fromDate, toDate)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profit and loss totals: %w", err)
+	}
+
+	// 集計結果は期間の順に並んでいる
+	report := &ProfitAndLossReport{Period: period, Periods: []PeriodProfitAndLoss{}}
+	for _, t := range totals {
+		if n := len(report.Periods); n == 0 || report.Periods[n-1].Period != t.Period {
+			report.Periods = append(report.Periods, PeriodProfitAndLoss{Period: t.Period, Categories: []CategoryProfitAndLoss{}})
+		}
+		p := &report.Periods[len(report.Periods)-1]
+
+		category := CategoryProfitAndLoss{Category: t.Category}
+		category.add(t)
+		p.Categories = append(p.Categories, category)
+		p.add(t)
+		report.Total.add(t)
+	}
+
+	return report, nil
+}
+
+// 集計期間の単位と期間を検証し、売却日の検索条件（終端は翌月・翌年の1日で、含まない）にする
+func parseProfitAndLossInput(input ProfitAndLossInput) (entity.ProfitLossPeriod, string, string, error) {
+	period := entity.ProfitLossPeriod(input.Period)
+	if period == "" {
+		period = entity.ProfitLossMonthly
+	}
+
+	switch period {
+	case entity.ProfitLossMonthly:
+		from, to, err := parseReportRange(input.From, input.To)
+		if err != nil {
+			return "", "", "", err
+		}
+		var fromDate, toDate string
+		if !from.IsZero() {
+			fromDate = from.Format(time.DateOnly)
+		}
+		if !to.IsZero() {
+			toDate = to.AddDate(0, 1, 0).Format(time.DateOnly)
+		}
+		return period, fromDate, toDate, nil
+	case entity.ProfitLossYearly:
+		from, to, errs := parseYearRange(input.From, input.To)
+		if len(errs) > 0 {
+			return "", "", "", fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, strings.Join(errs, ", "))
+		}
+		fromDate, toDate := yearRangeDates(from, to)
+		return period, fromDate, toDate, nil
+	}
+
+	return "", "", "", fmt.Errorf("%w: period must be one of: month, year", domainErrors.ErrInvalidInput)
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestReportUsecase_GetProfitAndLoss(t *testing.T) {
+	totals := []entity.ProfitLossTotal{
+		{Period: "2026-08", Category: "バッグ", Count: 1, ProceedsJPY: 2500000, CostBasisJPY: 2000000, RealizedGainJPY: 500000},
+		{Period: "2026-08", Category: "時計", Count: 2, Unconverted: 1, ProceedsJPY: 900000, CostBasisJPY: 1000000, RealizedGainJPY: -100000},
+		{Period: "2026-09", Category: "時計", Count: 1, ProceedsJPY: 1900000, CostBasisJPY: 1500000, RealizedGainJPY: 400000},
+	}
+
+	tests := []struct {
+		name        string
+		input       ProfitAndLossInput
+		setupMock   func(*MockSaleRepository)
+		expected    *ProfitAndLossReport
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 月・カテゴリーごとに集計する",
+			input: ProfitAndLossInput{},
+			setupMock: func(saleRepo *MockSaleRepository) {
+				saleRepo.On("GetProfitLossTotals", mock.Anything, entity.ProfitLossMonthly, "", "").Return(totals, nil)
+			},
+			expected: &ProfitAndLossReport{
+				Period: entity.ProfitLossMonthly,
+				Periods: []PeriodProfitAndLoss{
+					{
+						Period:        "2026-08",
+						ProfitAndLoss: ProfitAndLoss{Count: 3, Unconverted: 1, ProceedsJPY: 3400000, CostBasisJPY: 3000000, RealizedGainJPY: 400000},
+						Categories: []CategoryProfitAndLoss{
+							{Category: "バッグ", ProfitAndLoss: ProfitAndLoss{Count: 1, ProceedsJPY: 2500000, CostBasisJPY: 2000000, RealizedGainJPY: 500000}},
+							{Category: "時計", ProfitAndLoss: ProfitAndLoss{Count: 2, Unconverted: 1, ProceedsJPY: 900000, CostBasisJPY: 1000000, RealizedGainJPY: -100000}},
+						},
+					},
+					{
+						Period:        "2026-09",
+						ProfitAndLoss: ProfitAndLoss{Count: 1, ProceedsJPY: 1900000, CostBasisJPY: 1500000, RealizedGainJPY: 400000},
+						Categories: []CategoryProfitAndLoss{
+							{Category: "時計", ProfitAndLoss: ProfitAndLoss{Count: 1, ProceedsJPY: 1900000, CostBasisJPY: 1500000, RealizedGainJPY: 400000}},
+						},
+					},
+				},
+				Total: ProfitAndLoss{Count: 4, Unconverted: 1, ProceedsJPY: 5300000, CostBasisJPY: 4500000, RealizedGainJPY: 800000},
+			},
+		},
+		{
+			name:  "正常系: 月の期間を指定（終端は翌月1日の前まで）",
+			input: ProfitAndLossInput{From: "2026-01", To: "2026-12"},
+			setupMock: func(saleRepo *MockSaleRepository) {
+				saleRepo.On("GetProfitLossTotals", mock.Anything, entity.ProfitLossMonthly, "2026-01-01", "2027-01-01").Return(nil, nil)
+			},
+			expected: &ProfitAndLossReport{Period: entity.ProfitLossMonthly, Periods: []PeriodProfitAndLoss{}},
+		},
+		{
+			name:  "正常系: 年の期間を指定",
+			input: ProfitAndLossInput{Period: "year", From: "2025", To: "2026"},
+			setupMock: func(saleRepo *MockSaleRepository) {
+				saleRepo.On("GetProfitLossTotals", mock.Anything, entity.ProfitLossYearly, "2025-01-01", "2027-01-01").Return(nil, nil)
+			},
+			expected: &ProfitAndLossReport{Period: entity.ProfitLossYearly, Periods: []PeriodProfitAndLoss{}},
+		},
+		{
+			name:        "異常系: 集計期間の単位が不正",
+			input:       ProfitAndLossInput{Period: "week"},
+			setupMock:   func(saleRepo *MockSaleRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 年の集計に年月を指定",
+			input:       ProfitAndLossInput{Period: "year", From: "2026-01"},
+			setupMock:   func(saleRepo *MockSaleRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			input: ProfitAndLossInput{},
+			setupMock: func(saleRepo *MockSaleRepository) {
+				saleRepo.On("GetProfitLossTotals", mock.Anything, entity.ProfitLossMonthly, "", "").Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saleRepo := new(MockSaleRepository)
+			tt.setupMock(saleRepo)
+			usecase := NewReportUsecase(new(MockItemRepository), saleRepo)
+
+			report, err := usecase.GetProfitAndLoss(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, report)
+			}
+			saleRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 予定日時を過ぎたアイテムを1回に取得する件数
+const purgeBatchSize = 100
+
+// 予定された完全削除の実行結果
+type PurgeResult struct {
+	Items  int `json:"items"`  // 完全削除したアイテム数
+	Images int `json:"images"` // 削除した写真のファイル数
+}
+
+// アイテムの完全削除を予定する（売却の決済期間中など、予定日時まではそのまま扱える）
+// 予定日時を過ぎると、定期実行の PurgeDueItems で紐づくデータとあわせて削除する
+func (u *itemUsecase) SchedulePurge(ctx context.Context, id int64, purgeAt time.Time) (*entity.Item, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	if err := ensureNotOnHold(item); err != nil {
+		return nil, err
+	}
+
+	before := entity.ItemAuditFields(item)
+	if err := item.SchedulePurge(purgeAt, u.clock.Now()); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	return u.savePurgeSchedule(ctx, item, entity.AuditSchedulePurge, before)
+}
+
+// 完全削除の予定を取り消す（予定がない場合は何もしない）
+func (u *itemUsecase) CancelPurge(ctx context.Context, id int64) (*entity.Item, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	if !item.IsPurgeScheduled() {
+		return item, nil
+	}
+
+	before := entity.ItemAuditFields(item)
+	item.CancelPurge(u.clock.Now())
+
+	return u.savePurgeSchedule(ctx, item, entity.AuditCancelPurge, before)
+}
+
+func (u *itemUsecase) savePurgeSchedule(ctx context.Context, item *entity.Item, action entity.AuditAction, before map[string]interface{}) (*entity.Item, error) {
+	err := u.mutate(ctx, func(ctx context.Context) error {
+		if err := u.itemRepo.SchedulePurge(ctx, item.ID, item.PurgeAt, item.UpdatedAt); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to schedule item purge: %w", err)
+		}
+		return u.audit(ctx, item.ID, action, before, entity.ItemAuditFields(item))
+	})
+	u.evictItem(ctx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	purgeItems(ctx, u.cachePurger, item)
+
+	return item, nil
+}
+
+// 完全削除の予定日時を過ぎたアイテムを、紐づくデータ（写真・評価額・タグ・保険の契約）とあわせて削除する
+// 保全中のアイテムは削除せず、保全の解除後に削除する
+func (u *itemUsecase) PurgeDueItems(ctx context.Context) (*PurgeResult, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	result := &PurgeResult{}
+	for {
+		items, err := u.itemRepo.FindDuePurges(ctx, u.clock.Now(), purgeBatchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to find items to purge: %w", err)
+		}
+
+		for _, item := range items {
+			var removedKeys []string
+			err := u.mutate(ctx, func(ctx context.Context) error {
+				keys, err := u.purgeItem(ctx, item.ID)
+				if err != nil {
+					return err
+				}
+				removedKeys = keys
+				return u.audit(ctx, item.ID, entity.AuditPurge, entity.ItemAuditFields(item), nil)
+			})
+			u.evictItem(ctx, item.ID)
+			if err != nil {
+				// 他の処理で削除済みの場合は次のアイテムに進む
+				if domainErrors.IsNotFoundError(err) {
+					continue
+				}
+				return result, fmt.Errorf("failed to purge item %d: %w", item.ID, err)
+			}
+
+			u.deleteImageFiles(ctx, removedKeys)
+			purgeItems(ctx, u.cachePurger, item)
+			result.Items++
+			result.Images += len(removedKeys)
+		}
+
+		if len(items) < purgeBatchSize {
+			return result, nil
+		}
+	}
+}
+
+// アイテムを完全削除し、削除した写真のファイルのキーを返す
+// 削除ポリシーが未設定の場合、写真などのメタデータは外部キーで削除され、ファイルは残る
+func (u *itemUsecase) purgeItem(ctx context.Context, id int64) ([]string, error) {
+	if u.dependentsRepo == nil {
+		return nil, u.itemRepo.Purge(ctx, id)
+	}
+
+	var removedKeys []string
+	err := runInTransaction(ctx, u.transactor, func(ctx context.Context) error {
+		keys, err := u.dependentsRepo.DeleteByItemID(ctx, id)
+		if err != nil {
+			return err
+		}
+		removedKeys = keys
+		return u.itemRepo.Purge(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return removedKeys, nil
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_SchedulePurge(t *testing.T) {
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	purgeAt := now.Add(30 * 24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		purgeAt     time.Time
+		setupMock   func(*MockItemRepository)
+		expectedErr error
+	}{
+		{
+			name:    "正常系: 完全削除を予定",
+			purgeAt: purgeAt,
+			setupMock: func(itemRepo *MockItemRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+				itemRepo.On("SchedulePurge", mock.Anything, int64(1), mock.MatchedBy(func(at *time.Time) bool {
+					return at != nil && at.Equal(purgeAt)
+				}), now).Return(nil)
+			},
+		},
+		{
+			name:    "異常系: 過去の日時",
+			purgeAt: now.Add(-time.Hour),
+			setupMock: func(itemRepo *MockItemRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:    "異常系: 保全中のアイテム",
+			purgeAt: purgeAt,
+			setupMock: func(itemRepo *MockItemRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, OnHold: true}, nil)
+			},
+			expectedErr: domainErrors.ErrItemOnHold,
+		},
+		{
+			name:    "異常系: 存在しないアイテム",
+			purgeAt: purgeAt,
+			setupMock: func(itemRepo *MockItemRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			tt.setupMock(itemRepo)
+
+			usecase := NewItemUsecase(itemRepo, WithClock(entity.FixedClock(now)))
+			item, err := usecase.SchedulePurge(context.Background(), 1, tt.purgeAt)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, item)
+				itemRepo.AssertNotCalled(t, "SchedulePurge", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, item.IsPurgeScheduled())
+			itemRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_CancelPurge(t *testing.T) {
+	t.Run("正常系: 予定を取り消す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		purgeAt := time.Now().Add(time.Hour)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, PurgeAt: &purgeAt}, nil)
+		itemRepo.On("SchedulePurge", mock.Anything, int64(1), (*time.Time)(nil), mock.Anything).Return(nil)
+
+		usecase := NewItemUsecase(itemRepo)
+		item, err := usecase.CancelPurge(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.False(t, item.IsPurgeScheduled())
+		itemRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 予定がない場合は何もしない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+
+		usecase := NewItemUsecase(itemRepo)
+		_, err := usecase.CancelPurge(context.Background(), 1)
+
+		require.NoError(t, err)
+		itemRepo.AssertNotCalled(t, "SchedulePurge", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_PurgeDueItems(t *testing.T) {
+	t.Run("正常系: 予定日時を過ぎたアイテムを紐づくデータとあわせて削除", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		depsRepo := new(MockItemDependentsRepository)
+		storage := new(MockImageStorage)
+		auditLogger := new(MockAuditLogger)
+
+		itemRepo.On("FindDuePurges", mock.Anything, mock.Anything, purgeBatchSize).
+			Return([]*entity.Item{{ID: 3, Category: "時計"}, {ID: 5}, {ID: 7}}, nil)
+		depsRepo.On("DeleteByItemID", mock.Anything, int64(3)).Return([]string{"items/3/a.jpg"}, nil)
+		depsRepo.On("DeleteByItemID", mock.Anything, int64(5)).Return([]string{}, nil)
+		depsRepo.On("DeleteByItemID", mock.Anything, int64(7)).Return([]string{}, nil)
+		itemRepo.On("Purge", mock.Anything, int64(3)).Return(nil)
+		itemRepo.On("Purge", mock.Anything, int64(5)).Return(nil)
+		// 他の処理で削除済み
+		itemRepo.On("Purge", mock.Anything, int64(7)).Return(domainErrors.ErrItemNotFound)
+		storage.On("Delete", mock.Anything, "items/3/a.jpg").Return(nil)
+		auditLogger.On("Log", mock.Anything, mock.MatchedBy(func(entry *entity.AuditLog) bool {
+			return entry.Action == entity.AuditPurge && entry.Actor == SystemActor
+		})).Return(nil).Twice()
+
+		usecase := NewItemUsecase(itemRepo,
+			WithDeletePolicy(DeleteOrphan, depsRepo, &fakeTransactor{}, storage),
+			WithAuditLogger(auditLogger),
+		)
+		result, err := usecase.PurgeDueItems(WithActor(context.Background(), SystemActor))
+
+		require.NoError(t, err)
+		assert.Equal(t, &PurgeResult{Items: 2, Images: 1}, result)
+		storage.AssertExpectations(t)
+		auditLogger.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 削除に失敗した場合は中断", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindDuePurges", mock.Anything, mock.Anything, purgeBatchSize).
+			Return([]*entity.Item{{ID: 3}, {ID: 5}}, nil)
+		itemRepo.On("Purge", mock.Anything, int64(3)).Return(domainErrors.ErrDatabaseError)
+
+		usecase := NewItemUsecase(itemRepo)
+		result, err := usecase.PurgeDueItems(context.Background())
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Equal(t, 0, result.Items)
+		itemRepo.AssertNotCalled(t, "Purge", mock.Anything, int64(5))
+	})
+
+	t.Run("異常系: 読み取り専用モード", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(itemRepo, WithReadOnlySwitch(NewReadOnlySwitch(true)))
+		_, err := usecase.PurgeDueItems(context.Background())
+
+		assert.ErrorIs(t, err, domainErrors.ErrReadOnly)
+		itemRepo.AssertNotCalled(t, "FindDuePurges", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
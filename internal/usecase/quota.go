@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 操作者に適用する利用上限を決める（料金プランごとの上限などに差し替えられるようにする）
+type QuotaPolicy interface {
+	// QuotaFor returns the quota applied to the actor
+	QuotaFor(ctx context.Context, actor string) (entity.Quota, error)
+}
+
+// 設定による利用上限（操作者ごとに上書きでき、上書きがない場合は既定の上限）
+type StaticQuotaPolicy struct {
+	Default   entity.Quota
+	Overrides map[string]entity.Quota
+}
+
+func (p *StaticQuotaPolicy) QuotaFor(_ context.Context, actor string) (entity.Quota, error) {
+	if quota, ok := p.Overrides[actor]; ok {
+		return quota, nil
+	}
+	return p.Default, nil
+}
+
+// アイテムの登録・写真のアップロード時に利用上限を確認する
+// アイテムは所有者を持たないため、利用量はシステム全体で数える
+type QuotaChecker struct {
+	policy    QuotaPolicy
+	itemRepo  ItemRepository
+	imageRepo ItemImageRepository
+}
+
+func NewQuotaChecker(policy QuotaPolicy, itemRepo ItemRepository, imageRepo ItemImageRepository) *QuotaChecker {
+	return &QuotaChecker{
+		policy:    policy,
+		itemRepo:  itemRepo,
+		imageRepo: imageRepo,
+	}
+}
+
+// adding 件のアイテムを登録できるか確認する（nil の場合は確認しない）
+func (q *QuotaChecker) CheckItems(ctx context.Context, adding int) error {
+	if q == nil || adding <= 0 {
+		return nil
+	}
+
+	quota, err := q.policy.QuotaFor(ctx, ActorFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to get quota: %w", err)
+	}
+	if quota.MaxItems <= 0 {
+		return nil
+	}
+
+	current, err := q.itemRepo.Count(ctx, entity.ItemQuery{})
+	if err != nil {
+		return fmt.Errorf("failed to count items: %w", err)
+	}
+	if quota.ExceedsItems(current, adding) {
+		return fmt.Errorf("%w: items limit is %d (current: %d, adding: %d)", domainErrors.ErrQuotaExceeded, quota.MaxItems, current, adding)
+	}
+
+	return nil
+}
+
+// adding バイトの写真を保存できるか確認する（nil の場合は確認しない）
+func (q *QuotaChecker) CheckStorage(ctx context.Context, adding int64) error {
+	if q == nil || adding <= 0 {
+		return nil
+	}
+
+	quota, err := q.policy.QuotaFor(ctx, ActorFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to get quota: %w", err)
+	}
+	if quota.MaxStorageBytes <= 0 {
+		return nil
+	}
+
+	current, err := q.imageRepo.TotalSize(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get storage usage: %w", err)
+	}
+	if quota.ExceedsStorage(current, adding) {
+		return fmt.Errorf("%w: storage limit is %d bytes (current: %d, adding: %d)", domainErrors.ErrQuotaExceeded, quota.MaxStorageBytes, current, adding)
+	}
+
+	return nil
+}
+
+// アイテムの登録時に利用上限を確認する
+func WithQuota(checker *QuotaChecker) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.quota = checker
+	}
+}
+
+// 写真のアップロード時に利用上限を確認する
+func WithImageQuota(checker *QuotaChecker) ImageUsecaseOption {
+	return func(u *imageUsecase) {
+		u.quota = checker
+	}
+}
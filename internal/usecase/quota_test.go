@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_CreateItem_Quota(t *testing.T) {
+	policy := &StaticQuotaPolicy{
+		Default: entity.Quota{MaxItems: 10},
+		Overrides: map[string]entity.Quota{
+			"premium": {MaxItems: 0},
+		},
+	}
+	input := CreateItemInput{
+		Name:          "時計1",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: entity.JPY(1000000),
+		PurchaseDate:  "2023-01-01",
+	}
+
+	t.Run("異常系: アイテム数の上限に達している", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Count", mock.Anything, entity.ItemQuery{}).Return(10, nil)
+
+		usecase := NewItemUsecase(mockRepo, WithQuota(NewQuotaChecker(policy, mockRepo, nil)))
+		result, err := usecase.CreateItem(context.Background(), input)
+
+		assert.ErrorIs(t, err, domainErrors.ErrQuotaExceeded)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 上限が上書きされた操作者は無制限", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByDedupeKey", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 11}, nil)
+
+		usecase := NewItemUsecase(mockRepo, WithQuota(NewQuotaChecker(policy, mockRepo, nil)))
+		result, err := usecase.CreateItem(WithActor(context.Background(), "premium"), input)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(11), result.ID)
+		mockRepo.AssertNotCalled(t, "Count", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 一括登録で上限を超える", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Count", mock.Anything, entity.ItemQuery{}).Return(9, nil)
+
+		usecase := NewItemUsecase(mockRepo, WithQuota(NewQuotaChecker(policy, mockRepo, nil)))
+		result, err := usecase.CreateItems(context.Background(), BulkCreateItemsInput{
+			Items: []CreateItemInput{input, input},
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrQuotaExceeded)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+	})
+}
+
+func TestImageUsecase_UploadImage_Quota(t *testing.T) {
+	policy := &StaticQuotaPolicy{Default: entity.Quota{MaxStorageBytes: 1000}}
+
+	itemRepo := new(MockItemRepository)
+	imageRepo := new(MockItemImageRepository)
+	storage := new(MockImageStorage)
+	itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+	imageRepo.On("TotalSize", mock.Anything).Return(int64(1000)-int64(len(testPNG))+1, nil)
+
+	usecase := NewImageUsecase(itemRepo, imageRepo, storage, WithImageQuota(NewQuotaChecker(policy, itemRepo, imageRepo)))
+	image, err := usecase.UploadImage(context.Background(), 1, UploadImageInput{
+		FileName: "photo.png",
+		Size:     int64(len(testPNG)),
+		Body:     bytes.NewReader(testPNG),
+	})
+
+	assert.ErrorIs(t, err, domainErrors.ErrQuotaExceeded)
+	assert.Nil(t, image)
+	storage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
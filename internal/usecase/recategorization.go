@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 「その他」のアイテムのカテゴリーの変更を提案するジョブ（ペイロードなし、結果は RecategorizationResult）
+const JobItemsRecategorize = "items.recategorize"
+
+// カテゴリーの推定1回あたりのタイムアウトのデフォルト
+const DefaultClassifierTimeout = 30 * time.Second
+
+// カテゴリーの推定の再試行方針のデフォルト（外部APIのため、データベースより間隔を空ける）
+var DefaultClassifierRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Second,
+	MaxDelay:    10 * time.Second,
+}
+
+// 提案として登録する推定の確からしさの下限のデフォルト
+const DefaultMinSuggestionConfidence = 0.5
+
+// 提案の一覧のページング上限
+const (
+	DefaultSuggestionsLimit = 50
+	MaxSuggestionsLimit     = 100
+)
+
+// 提案の作成で1回に読み込むアイテム数
+const recategorizationBatchSize = 100
+
+// アイテムのカテゴリーの推定（LLM など）
+type CategoryClassifier interface {
+	// SuggestCategory guesses which of the candidate categories fits the item best.
+	// Returns a guess with an empty category if none of them fits. Errors wrapping ErrTransient are retried
+	SuggestCategory(ctx context.Context, item *entity.Item, candidates []string) (*CategoryGuess, error)
+}
+
+type CategoryGuess struct {
+	Category   string
+	Confidence float64 // 0〜1
+	Reason     string
+}
+
+// 「その他」に分類されたアイテムのカテゴリーを推定し、人が承認・却下する提案として登録する
+type RecategorizationUsecase interface {
+	// 「その他」のアイテムを確認し、提案を登録する（ジョブとして実行する）
+	Scan(ctx context.Context) (*RecategorizationResult, error)
+	// 確認状況の提案（古い順）
+	ListSuggestions(ctx context.Context, input ListSuggestionsInput) ([]*entity.CategorySuggestion, error)
+	// 提案のカテゴリーにアイテムを変更する
+	ApproveSuggestion(ctx context.Context, id int64) (*entity.CategorySuggestion, error)
+	RejectSuggestion(ctx context.Context, id int64) (*entity.CategorySuggestion, error)
+}
+
+// 提案の作成の集計
+type RecategorizationResult struct {
+	Scanned   int `json:"scanned"`   // 確認した「その他」のアイテム数
+	Suggested int `json:"suggested"` // 登録した提案の数
+	// 保全中・確認待ちの提案がある・当てはまるカテゴリーがない・確からしさが下限未満のアイテム数
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+
+	// 失敗したアイテムと理由（先頭の MaxJobRunErrors 件）
+	Errors []entity.JobRunError `json:"errors"`
+}
+
+type ListSuggestionsInput struct {
+	Status string // 空の場合は確認待ち
+	Limit  int
+	Offset int
+}
+
+type recategorizationUsecase struct {
+	itemRepo       ItemRepository
+	suggestionRepo CategorySuggestionRepository
+	itemUsecase    ItemUsecase
+	classifier     CategoryClassifier
+
+	minConfidence float64
+	timeout       time.Duration
+	retryPolicy   RetryPolicy
+	readOnly      *ReadOnlySwitch
+	clock         entity.Clock
+}
+
+// RecategorizationUsecaseの任意の設定を指定するオプション
+type RecategorizationUsecaseOption func(*recategorizationUsecase)
+
+// 提案として登録する確からしさの下限を指定
+func WithMinSuggestionConfidence(min float64) RecategorizationUsecaseOption {
+	return func(u *recategorizationUsecase) {
+		u.minConfidence = min
+	}
+}
+
+// カテゴリーの推定1回あたりのタイムアウトを指定
+func WithClassifierTimeout(timeout time.Duration) RecategorizationUsecaseOption {
+	return func(u *recategorizationUsecase) {
+		u.timeout = timeout
+	}
+}
+
+// カテゴリーの推定の再試行方針を指定
+func WithClassifierRetryPolicy(policy RetryPolicy) RecategorizationUsecaseOption {
+	return func(u *recategorizationUsecase) {
+		u.retryPolicy = policy
+	}
+}
+
+// 読み取り専用モードのスイッチを指定
+func WithRecategorizationReadOnlySwitch(readOnly *ReadOnlySwitch) RecategorizationUsecaseOption {
+	return func(u *recategorizationUsecase) {
+		u.readOnly = readOnly
+	}
+}
+
+// 現在時刻の取得元を指定（デフォルトはシステムの時刻）
+func WithRecategorizationClock(clock entity.Clock) RecategorizationUsecaseOption {
+	return func(u *recategorizationUsecase) {
+		u.clock = clock
+	}
+}
+
+// 承認したアイテムの変更は itemUsecase で行う（監査ログ・変更イベント・キャッシュの削除を共通にする）
+func NewRecategorizationUsecase(itemRepo ItemRepository, suggestionRepo CategorySuggestionRepository, itemUsecase ItemUsecase, classifier CategoryClassifier, opts ...RecategorizationUsecaseOption) RecategorizationUsecase {
+	u := &recategorizationUsecase{
+		itemRepo:       itemRepo,
+		suggestionRepo: suggestionRepo,
+		itemUsecase:    itemUsecase,
+		classifier:     classifier,
+		minConfidence:  DefaultMinSuggestionConfidence,
+		timeout:        DefaultClassifierTimeout,
+		retryPolicy:    DefaultClassifierRetryPolicy,
+		readOnly:       NewReadOnlySwitch(false),
+		clock:          entity.SystemClock,
+	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
+}
+
+// 個別のアイテムの失敗は集計に記録して続ける
+func (u *recategorizationUsecase) Scan(ctx context.Context) (*RecategorizationResult, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+
+	result := &RecategorizationResult{Errors: []entity.JobRunError{}}
+	candidates := entity.SuggestibleCategories()
+	query := entity.ItemQuery{
+		Limit:    recategorizationBatchSize,
+		Sort:     entity.SortByID,
+		Order:    entity.SortAsc,
+		Category: entity.OtherCategory,
+	}
+	for {
+		items, err := u.itemRepo.FindAll(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve items: %w", err)
+		}
+
+		ids := make([]int64, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+		pending, err := u.suggestionRepo.FindPendingItemIDs(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve pending suggestions: %w", err)
+		}
+
+		for _, item := range items {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			result.Scanned++
+			if item.OnHold || pending[item.ID] {
+				result.Skipped++
+				continue
+			}
+
+			suggested, err := u.suggest(ctx, item, candidates)
+			if err != nil {
+				result.Failed++
+				if len(result.Errors) < entity.MaxJobRunErrors {
+					result.Errors = append(result.Errors, entity.JobRunError{ItemID: item.ID, Error: truncateJobError(err.Error())})
+				}
+				continue
+			}
+			if suggested {
+				result.Suggested++
+			} else {
+				result.Skipped++
+			}
+		}
+
+		if len(items) < recategorizationBatchSize {
+			return result, nil
+		}
+		query.AfterID = items[len(items)-1].ID
+	}
+}
+
+// 当てはまるカテゴリーがない・確からしさが下限未満の場合は登録せずに false を返す
+func (u *recategorizationUsecase) suggest(ctx context.Context, item *entity.Item, candidates []string) (bool, error) {
+	guess, err := u.classify(ctx, item, candidates)
+	if err != nil {
+		return false, err
+	}
+
+	category := strings.TrimSpace(guess.Category)
+	if category == "" || category == item.Category || guess.Confidence < u.minConfidence {
+		return false, nil
+	}
+
+	suggestion, err := entity.NewCategorySuggestion(item, category, guess.Confidence, strings.TrimSpace(guess.Reason), u.clock.Now())
+	if err != nil {
+		return false, fmt.Errorf("invalid suggestion: %w", err)
+	}
+	if _, err := u.suggestionRepo.Create(ctx, suggestion); err != nil {
+		return false, fmt.Errorf("failed to create suggestion: %w", err)
+	}
+	return true, nil
+}
+
+// 1回ごとにタイムアウトを設け、一時的なエラーの場合は再試行する
+func (u *recategorizationUsecase) classify(ctx context.Context, item *entity.Item, candidates []string) (*CategoryGuess, error) {
+	var guess *CategoryGuess
+	err := u.retryPolicy.do(ctx, func() error {
+		attemptCtx, cancel := context.WithTimeout(ctx, u.timeout)
+		defer cancel()
+
+		var err error
+		guess, err = u.classifier.SuggestCategory(attemptCtx, item, candidates)
+		if err != nil && attemptCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+			// タイムアウトは次の試行で成功しうる
+			return fmt.Errorf("%w: %w", domainErrors.ErrTransient, err)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to classify item: %w", err)
+	}
+	return guess, nil
+}
+
+func (u *recategorizationUsecase) ListSuggestions(ctx context.Context, input ListSuggestionsInput) ([]*entity.CategorySuggestion, error) {
+	status := entity.SuggestionPending
+	if input.Status != "" {
+		status = entity.SuggestionStatus(input.Status)
+		if !status.IsValid() {
+			return nil, fmt.Errorf("%w: status must be one of: %s, %s, %s", domainErrors.ErrInvalidInput, entity.SuggestionPending, entity.SuggestionApproved, entity.SuggestionRejected)
+		}
+	}
+
+	limit := input.Limit
+	if limit == 0 {
+		limit = DefaultSuggestionsLimit
+	}
+	if limit < 0 || limit > MaxSuggestionsLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domainErrors.ErrInvalidInput, MaxSuggestionsLimit)
+	}
+	if input.Offset < 0 {
+		return nil, fmt.Errorf("%w: offset must be 0 or greater", domainErrors.ErrInvalidInput)
+	}
+
+	suggestions, err := u.suggestionRepo.FindByStatus(ctx, status, limit, input.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve suggestions: %w", err)
+	}
+	return suggestions, nil
+}
+
+// 提案後にアイテムのカテゴリーが変更された・アイテムが削除された場合は ErrSuggestionOutdated を返す
+func (u *recategorizationUsecase) ApproveSuggestion(ctx context.Context, id int64) (*entity.CategorySuggestion, error) {
+	suggestion, err := u.findPending(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := u.itemUsecase.GetItemByID(ctx, suggestion.ItemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, fmt.Errorf("%w: item %d no longer exists", domainErrors.ErrSuggestionOutdated, suggestion.ItemID)
+		}
+		return nil, err
+	}
+	if item.Category != suggestion.FromCategory {
+		return nil, fmt.Errorf("%w: item category has changed to %s", domainErrors.ErrSuggestionOutdated, item.Category)
+	}
+
+	category := suggestion.Category
+	if _, err := u.itemUsecase.UpdateItem(ctx, item.ID, UpdateItemInput{Category: &category}); err != nil {
+		return nil, err
+	}
+
+	return u.review(ctx, suggestion, entity.SuggestionApproved)
+}
+
+func (u *recategorizationUsecase) RejectSuggestion(ctx context.Context, id int64) (*entity.CategorySuggestion, error) {
+	suggestion, err := u.findPending(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return u.review(ctx, suggestion, entity.SuggestionRejected)
+}
+
+func (u *recategorizationUsecase) findPending(ctx context.Context, id int64) (*entity.CategorySuggestion, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	suggestion, err := u.suggestionRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsSuggestionNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve suggestion: %w", err)
+	}
+	if suggestion.Status != entity.SuggestionPending {
+		return nil, fmt.Errorf("%w: suggestion is already %s", domainErrors.ErrSuggestionOutdated, suggestion.Status)
+	}
+	return suggestion, nil
+}
+
+func (u *recategorizationUsecase) review(ctx context.Context, suggestion *entity.CategorySuggestion, status entity.SuggestionStatus) (*entity.CategorySuggestion, error) {
+	suggestion.Review(status, ActorFromContext(ctx), u.clock.Now())
+	if err := u.suggestionRepo.Update(ctx, suggestion); err != nil {
+		if domainErrors.IsSuggestionOutdatedError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update suggestion: %w", err)
+	}
+	return suggestion, nil
+}
+
+// 提案の作成ジョブの処理（アイテムごとに LLM に問い合わせるため、HTTPリクエストとは別に実行する）
+func NewRecategorizationJobHandler(recategorizationUsecase RecategorizationUsecase) JobHandler {
+	return JobHandlerFunc(func(ctx context.Context, job *entity.Job) (interface{}, error) {
+		return recategorizationUsecase.Scan(ctx)
+	})
+}
@@ -0,0 +1,308 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// メモリ上のカテゴリーの変更の提案
+type fakeSuggestionRepository struct {
+	suggestions []*entity.CategorySuggestion
+}
+
+func (r *fakeSuggestionRepository) Create(ctx context.Context, suggestion *entity.CategorySuggestion) (*entity.CategorySuggestion, error) {
+	created := *suggestion
+	created.ID = int64(len(r.suggestions) + 1)
+	r.suggestions = append(r.suggestions, &created)
+	copied := created
+	return &copied, nil
+}
+
+func (r *fakeSuggestionRepository) FindByID(ctx context.Context, id int64) (*entity.CategorySuggestion, error) {
+	for _, suggestion := range r.suggestions {
+		if suggestion.ID == id {
+			copied := *suggestion
+			return &copied, nil
+		}
+	}
+	return nil, domainErrors.ErrSuggestionNotFound
+}
+
+func (r *fakeSuggestionRepository) FindByStatus(ctx context.Context, status entity.SuggestionStatus, limit, offset int) ([]*entity.CategorySuggestion, error) {
+	suggestions := []*entity.CategorySuggestion{}
+	for _, suggestion := range r.suggestions {
+		if suggestion.Status == status {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	return suggestions, nil
+}
+
+func (r *fakeSuggestionRepository) FindPendingItemIDs(ctx context.Context, itemIDs []int64) (map[int64]bool, error) {
+	pending := make(map[int64]bool)
+	for _, suggestion := range r.suggestions {
+		if suggestion.Status == entity.SuggestionPending {
+			pending[suggestion.ItemID] = true
+		}
+	}
+	return pending, nil
+}
+
+func (r *fakeSuggestionRepository) Update(ctx context.Context, suggestion *entity.CategorySuggestion) error {
+	for i, existing := range r.suggestions {
+		if existing.ID == suggestion.ID {
+			if existing.Status != entity.SuggestionPending {
+				return domainErrors.ErrSuggestionOutdated
+			}
+			copied := *suggestion
+			r.suggestions[i] = &copied
+		}
+	}
+	return nil
+}
+
+// アイテムごとに推定結果・エラーを指定できる
+type fakeClassifier struct {
+	guesses map[int64]*CategoryGuess
+	errs    map[int64]error
+	calls   map[int64]int
+}
+
+func (c *fakeClassifier) SuggestCategory(ctx context.Context, item *entity.Item, candidates []string) (*CategoryGuess, error) {
+	if c.calls == nil {
+		c.calls = make(map[int64]int)
+	}
+	c.calls[item.ID]++
+	if err := c.errs[item.ID]; err != nil {
+		return nil, err
+	}
+	if guess := c.guesses[item.ID]; guess != nil {
+		return guess, nil
+	}
+	return &CategoryGuess{}, nil
+}
+
+// アイテムの取得とカテゴリーの変更のみを行う
+type fakeRecategorizedItems struct {
+	ItemUsecase
+	items   map[int64]*entity.Item
+	updates map[int64]string
+}
+
+func (f *fakeRecategorizedItems) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	item, ok := f.items[id]
+	if !ok {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	return item, nil
+}
+
+func (f *fakeRecategorizedItems) UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*ItemResult, error) {
+	item := f.items[id]
+	if err := ensureNotOnHold(item); err != nil {
+		return nil, err
+	}
+	if f.updates == nil {
+		f.updates = make(map[int64]string)
+	}
+	f.updates[id] = *input.Category
+	item.Category = *input.Category
+	return &ItemResult{Item: item}, nil
+}
+
+func TestRecategorizationUsecase_Scan(t *testing.T) {
+	now := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+	noRetry := WithClassifierRetryPolicy(RetryPolicy{MaxAttempts: 1})
+
+	t.Run("正常系: 「その他」のアイテムを推定し、提案を登録する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, mock.MatchedBy(func(q entity.ItemQuery) bool {
+			return q.Category == entity.OtherCategory && q.Sort == entity.SortByID && q.Order == entity.SortAsc
+		})).Return([]*entity.Item{
+			{ID: 1, Name: "サブマリーナー", Category: "その他"},
+			{ID: 2, Name: "ノート", Category: "その他"},
+			{ID: 3, Name: "ネックレス", Category: "その他"},
+			{ID: 4, Name: "スニーカー", Category: "その他", OnHold: true},
+			{ID: 5, Name: "トート", Category: "その他"},
+			{ID: 6, Name: "指輪", Category: "その他"},
+		}, nil)
+
+		suggestionRepo := &fakeSuggestionRepository{}
+		_, err := suggestionRepo.Create(context.Background(), &entity.CategorySuggestion{ItemID: 5, FromCategory: "その他", Category: "バッグ", Status: entity.SuggestionPending})
+		require.NoError(t, err)
+
+		classifier := &fakeClassifier{
+			guesses: map[int64]*CategoryGuess{
+				1: {Category: "時計", Confidence: 0.95, Reason: "ロレックスの腕時計のモデル名"},
+				3: {Category: "ジュエリー", Confidence: 0.3},
+			},
+			errs: map[int64]error{6: fmt.Errorf("%w: status 503", domainErrors.ErrTransient)},
+		}
+		usecase := NewRecategorizationUsecase(itemRepo, suggestionRepo, &fakeRecategorizedItems{}, classifier,
+			WithRecategorizationClock(entity.FixedClock(now)), noRetry)
+
+		result, err := usecase.Scan(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 6, result.Scanned)
+		assert.Equal(t, 1, result.Suggested)
+		// 当てはまらない・確からしさが下限未満・保全中・確認待ちの提案がある
+		assert.Equal(t, 4, result.Skipped)
+		assert.Equal(t, 1, result.Failed)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, int64(6), result.Errors[0].ItemID)
+		assert.NotContains(t, classifier.calls, int64(4))
+		assert.NotContains(t, classifier.calls, int64(5))
+
+		require.Len(t, suggestionRepo.suggestions, 2)
+		created := suggestionRepo.suggestions[1]
+		assert.Equal(t, int64(1), created.ItemID)
+		assert.Equal(t, "その他", created.FromCategory)
+		assert.Equal(t, "時計", created.Category)
+		assert.Equal(t, entity.SuggestionPending, created.Status)
+		assert.Equal(t, now, created.CreatedAt)
+	})
+
+	t.Run("正常系: 一時的なエラーは再試行する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{{ID: 1, Category: "その他"}}, nil)
+
+		classifier := &fakeClassifier{errs: map[int64]error{1: fmt.Errorf("%w: status 429", domainErrors.ErrTransient)}}
+		usecase := NewRecategorizationUsecase(itemRepo, &fakeSuggestionRepository{}, &fakeRecategorizedItems{}, classifier,
+			WithClassifierRetryPolicy(RetryPolicy{MaxAttempts: 2}))
+
+		result, err := usecase.Scan(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, 2, classifier.calls[1])
+	})
+
+	t.Run("異常系: 読み取り専用モードでは実行しない", func(t *testing.T) {
+		usecase := NewRecategorizationUsecase(new(MockItemRepository), &fakeSuggestionRepository{}, &fakeRecategorizedItems{}, &fakeClassifier{},
+			WithRecategorizationReadOnlySwitch(NewReadOnlySwitch(true)))
+
+		_, err := usecase.Scan(context.Background())
+
+		assert.ErrorIs(t, err, domainErrors.ErrReadOnly)
+	})
+}
+
+func TestRecategorizationUsecase_ReviewSuggestion(t *testing.T) {
+	now := time.Date(2026, 10, 2, 9, 0, 0, 0, time.UTC)
+	ctx := WithActor(context.Background(), "reviewer-1")
+
+	setup := func(item *entity.Item) (RecategorizationUsecase, *fakeSuggestionRepository, *fakeRecategorizedItems) {
+		suggestionRepo := &fakeSuggestionRepository{}
+		_, err := suggestionRepo.Create(context.Background(), &entity.CategorySuggestion{ItemID: 1, FromCategory: "その他", Category: "時計", Status: entity.SuggestionPending})
+		require.NoError(t, err)
+
+		items := &fakeRecategorizedItems{items: map[int64]*entity.Item{}}
+		if item != nil {
+			items.items[item.ID] = item
+		}
+		usecase := NewRecategorizationUsecase(new(MockItemRepository), suggestionRepo, items, &fakeClassifier{},
+			WithRecategorizationClock(entity.FixedClock(now)))
+		return usecase, suggestionRepo, items
+	}
+
+	t.Run("正常系: 承認するとアイテムのカテゴリーを変更し、確認者を記録する", func(t *testing.T) {
+		usecase, suggestionRepo, items := setup(&entity.Item{ID: 1, Category: "その他"})
+
+		suggestion, err := usecase.ApproveSuggestion(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.SuggestionApproved, suggestion.Status)
+		assert.Equal(t, "reviewer-1", suggestion.ReviewedBy)
+		assert.Equal(t, now, *suggestion.ReviewedAt)
+		assert.Equal(t, "時計", items.updates[1])
+		assert.Equal(t, entity.SuggestionApproved, suggestionRepo.suggestions[0].Status)
+
+		// 確認済みの提案は再度承認・却下できない
+		_, err = usecase.RejectSuggestion(ctx, 1)
+		assert.ErrorIs(t, err, domainErrors.ErrSuggestionOutdated)
+	})
+
+	t.Run("正常系: 却下してもアイテムは変更しない", func(t *testing.T) {
+		usecase, _, items := setup(&entity.Item{ID: 1, Category: "その他"})
+
+		suggestion, err := usecase.RejectSuggestion(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.SuggestionRejected, suggestion.Status)
+		assert.Empty(t, items.updates)
+	})
+
+	t.Run("異常系: 提案後にカテゴリーが変更されたアイテムは承認できない", func(t *testing.T) {
+		usecase, suggestionRepo, items := setup(&entity.Item{ID: 1, Category: "バッグ"})
+
+		_, err := usecase.ApproveSuggestion(ctx, 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrSuggestionOutdated)
+		assert.Empty(t, items.updates)
+		assert.Equal(t, entity.SuggestionPending, suggestionRepo.suggestions[0].Status)
+	})
+
+	t.Run("異常系: 削除されたアイテムの提案は承認できない", func(t *testing.T) {
+		usecase, _, _ := setup(nil)
+
+		_, err := usecase.ApproveSuggestion(ctx, 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrSuggestionOutdated)
+	})
+
+	t.Run("異常系: 保全中のアイテムは変更せず、提案は確認待ちのまま", func(t *testing.T) {
+		usecase, suggestionRepo, _ := setup(&entity.Item{ID: 1, Category: "その他", OnHold: true})
+
+		_, err := usecase.ApproveSuggestion(ctx, 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemOnHold)
+		assert.Equal(t, entity.SuggestionPending, suggestionRepo.suggestions[0].Status)
+	})
+
+	t.Run("異常系: 存在しない提案", func(t *testing.T) {
+		usecase, _, _ := setup(nil)
+
+		_, err := usecase.RejectSuggestion(ctx, 99)
+
+		assert.ErrorIs(t, err, domainErrors.ErrSuggestionNotFound)
+	})
+}
+
+func TestRecategorizationUsecase_ListSuggestions(t *testing.T) {
+	usecase := NewRecategorizationUsecase(new(MockItemRepository), &fakeSuggestionRepository{}, &fakeRecategorizedItems{}, &fakeClassifier{})
+
+	tests := []struct {
+		name        string
+		input       ListSuggestionsInput
+		expectedErr string
+	}{
+		{name: "正常系: 省略時は確認待ち", input: ListSuggestionsInput{}},
+		{name: "正常系: 確認済み", input: ListSuggestionsInput{Status: "approved", Limit: 100}},
+		{name: "異常系: 不正な確認状況", input: ListSuggestionsInput{Status: "done"}, expectedErr: "status must be one of: pending, approved, rejected"},
+		{name: "異常系: 上限を超える件数", input: ListSuggestionsInput{Limit: 101}, expectedErr: "limit must be between 1 and 100"},
+		{name: "異常系: 負のオフセット", input: ListSuggestionsInput{Offset: -1}, expectedErr: "offset must be 0 or greater"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions, err := usecase.ListSuggestions(context.Background(), tt.input)
+			if tt.expectedErr != "" {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, suggestions)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 文字の読み取り1回あたりのタイムアウトのデフォルト
+const DefaultOCRTimeout = 30 * time.Second
+
+// 文字の読み取りの再試行方針のデフォルト（外部APIのため、データベースより間隔を空ける）
+var DefaultOCRRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Second,
+	MaxDelay:    10 * time.Second,
+}
+
+// 読み取る写真の最大サイズ（OCR サービスに送る上限。分割アップロードした大きな写真は読み取らない）
+const MaxReceiptImageSize = 10 << 20
+
+// 写真の文字の読み取り（OCR）
+type ReceiptReader interface {
+	// ReadText returns the text recognized in the image, one line per line of the receipt.
+	// Errors wrapping ErrTransient are retried
+	ReadText(ctx context.Context, image []byte, contentType string) (string, error)
+}
+
+// アイテムに添付した領収書の写真から、購入価格・購入日・店名の候補を読み取る
+type ReceiptUsecase interface {
+	// 写真の文字を読み取り、候補を写真の読み取り結果として保存する（読み取り済みの場合は置き換える）
+	ScanReceipt(ctx context.Context, itemID, imageID int64) (*entity.ReceiptScan, error)
+	// 保存した読み取り結果
+	GetReceipt(ctx context.Context, itemID, imageID int64) (*entity.ReceiptScan, error)
+}
+
+type receiptUsecase struct {
+	itemRepo  ItemRepository
+	imageRepo ItemImageRepository
+	storage   ImageStorage
+	scanRepo  ReceiptScanRepository
+	reader    ReceiptReader
+
+	timeout     time.Duration
+	retryPolicy RetryPolicy
+	readOnly    *ReadOnlySwitch
+	clock       entity.Clock
+}
+
+// ReceiptUsecaseの任意の設定を指定するオプション
+type ReceiptUsecaseOption func(*receiptUsecase)
+
+// 文字の読み取り1回あたりのタイムアウトを指定
+func WithOCRTimeout(timeout time.Duration) ReceiptUsecaseOption {
+	return func(u *receiptUsecase) {
+		u.timeout = timeout
+	}
+}
+
+// 文字の読み取りの再試行方針を指定
+func WithOCRRetryPolicy(policy RetryPolicy) ReceiptUsecaseOption {
+	return func(u *receiptUsecase) {
+		u.retryPolicy = policy
+	}
+}
+
+// 読み取り専用モードのスイッチを指定
+func WithReceiptReadOnlySwitch(readOnly *ReadOnlySwitch) ReceiptUsecaseOption {
+	return func(u *receiptUsecase) {
+		u.readOnly = readOnly
+	}
+}
+
+// 現在時刻の取得元を指定（デフォルトはシステムの時刻）
+func WithReceiptClock(clock entity.Clock) ReceiptUsecaseOption {
+	return func(u *receiptUsecase) {
+		u.clock = clock
+	}
+}
+
+func NewReceiptUsecase(itemRepo ItemRepository, imageRepo ItemImageRepository, storage ImageStorage, scanRepo ReceiptScanRepository, reader ReceiptReader, opts ...ReceiptUsecaseOption) ReceiptUsecase {
+	u := &receiptUsecase{
+		itemRepo:    itemRepo,
+		imageRepo:   imageRepo,
+		storage:     storage,
+		scanRepo:    scanRepo,
+		reader:      reader,
+		timeout:     DefaultOCRTimeout,
+		retryPolicy: DefaultOCRRetryPolicy,
+		readOnly:    NewReadOnlySwitch(false),
+		clock:       entity.SystemClock,
+	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
+}
+
+func (u *receiptUsecase) ScanReceipt(ctx context.Context, itemID, imageID int64) (*entity.ReceiptScan, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+
+	item, err := u.findItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if imageID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	image, err := u.imageRepo.FindByID(ctx, itemID, imageID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve image: %w", err)
+	}
+	if image.Size > MaxReceiptImageSize {
+		return nil, fmt.Errorf("%w: image must be %d bytes or smaller to scan", domainErrors.ErrInvalidInput, MaxReceiptImageSize)
+	}
+
+	content, err := u.readImage(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+	text, err := u.readText(ctx, content, image.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	// 金額に通貨の記号がない場合はアイテムの購入価格の通貨とみなす
+	scan := entity.NewReceiptScan(image, text, item.PurchasePrice.Currency, u.clock.Now())
+	if err := u.scanRepo.Save(ctx, scan); err != nil {
+		return nil, fmt.Errorf("failed to save receipt scan: %w", err)
+	}
+
+	return scan, nil
+}
+
+func (u *receiptUsecase) GetReceipt(ctx context.Context, itemID, imageID int64) (*entity.ReceiptScan, error) {
+	if _, err := u.findItem(ctx, itemID); err != nil {
+		return nil, err
+	}
+	if imageID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	scan, err := u.scanRepo.FindByImageID(ctx, itemID, imageID)
+	if err != nil {
+		if domainErrors.IsReceiptNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve receipt scan: %w", err)
+	}
+
+	return scan, nil
+}
+
+func (u *receiptUsecase) readImage(ctx context.Context, image *entity.ItemImage) ([]byte, error) {
+	body, err := u.storage.Open(ctx, image.StorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(body, MaxReceiptImageSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	return content, nil
+}
+
+// 1回ごとにタイムアウトを設け、一時的なエラーの場合は再試行する
+func (u *receiptUsecase) readText(ctx context.Context, content []byte, contentType string) (string, error) {
+	var text string
+	err := u.retryPolicy.do(ctx, func() error {
+		attemptCtx, cancel := context.WithTimeout(ctx, u.timeout)
+		defer cancel()
+
+		var err error
+		text, err = u.reader.ReadText(attemptCtx, content, contentType)
+		if err != nil && attemptCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+			// タイムアウトは次の試行で成功しうる
+			return fmt.Errorf("%w: %w", domainErrors.ErrTransient, err)
+		}
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", domainErrors.ErrOCRUnavailable, err)
+	}
+	return text, nil
+}
+
+func (u *receiptUsecase) findItem(ctx context.Context, itemID int64) (*entity.Item, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return item, nil
+}
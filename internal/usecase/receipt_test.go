@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 決まった全文を返し、受け取った写真を記録する
+type fakeReceiptReader struct {
+	text  string
+	err   error
+	image []byte
+}
+
+func (r *fakeReceiptReader) ReadText(ctx context.Context, image []byte, contentType string) (string, error) {
+	r.image = image
+	return r.text, r.err
+}
+
+// 読み取り結果をメモリに保存する
+type fakeReceiptScanRepository struct {
+	scans map[int64]*entity.ReceiptScan
+}
+
+func (r *fakeReceiptScanRepository) Save(ctx context.Context, scan *entity.ReceiptScan) error {
+	if r.scans == nil {
+		r.scans = map[int64]*entity.ReceiptScan{}
+	}
+	r.scans[scan.ImageID] = scan
+	return nil
+}
+
+func (r *fakeReceiptScanRepository) FindByImageID(ctx context.Context, itemID, imageID int64) (*entity.ReceiptScan, error) {
+	scan, ok := r.scans[imageID]
+	if !ok || scan.ItemID != itemID {
+		return nil, domainErrors.ErrReceiptNotFound
+	}
+	return scan, nil
+}
+
+func TestReceiptUsecase_ScanReceipt(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	item := &entity.Item{ID: 1, PurchasePrice: entity.Money{Amount: 100000, Currency: "JPY"}}
+	image := &entity.ItemImage{ID: 10, ItemID: 1, StorageKey: "items/1/receipt.jpg", ContentType: "image/jpeg", Size: 7}
+
+	tests := []struct {
+		name        string
+		readOnly    bool
+		readerErr   error
+		setupMock   func(*MockItemRepository, *MockItemImageRepository, *MockImageStorage)
+		expectedErr error
+	}{
+		{
+			name: "正常系: 候補を読み取って保存",
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository, storage *MockImageStorage) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				imageRepo.On("FindByID", mock.Anything, int64(1), int64(10)).Return(image, nil)
+				storage.On("Open", mock.Anything, "items/1/receipt.jpg").Return("receipt", nil)
+			},
+		},
+		{
+			name: "異常系: 存在しない写真",
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository, storage *MockImageStorage) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				imageRepo.On("FindByID", mock.Anything, int64(1), int64(10)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:      "異常系: OCR のエラー",
+			readerErr: errors.New("quota exceeded"),
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository, storage *MockImageStorage) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				imageRepo.On("FindByID", mock.Anything, int64(1), int64(10)).Return(image, nil)
+				storage.On("Open", mock.Anything, "items/1/receipt.jpg").Return("receipt", nil)
+			},
+			expectedErr: domainErrors.ErrOCRUnavailable,
+		},
+		{
+			name:     "異常系: 読み取り専用モード",
+			readOnly: true,
+			setupMock: func(itemRepo *MockItemRepository, imageRepo *MockItemImageRepository, storage *MockImageStorage) {
+				// リポジトリは呼ばれない
+			},
+			expectedErr: domainErrors.ErrReadOnly,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			imageRepo := new(MockItemImageRepository)
+			storage := new(MockImageStorage)
+			tt.setupMock(itemRepo, imageRepo, storage)
+			reader := &fakeReceiptReader{text: "ブティック銀座\n2023/01/15\n合計 12,000", err: tt.readerErr}
+			scanRepo := &fakeReceiptScanRepository{}
+			usecase := NewReceiptUsecase(itemRepo, imageRepo, storage, scanRepo, reader,
+				WithReceiptReadOnlySwitch(NewReadOnlySwitch(tt.readOnly)),
+				WithOCRRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+				WithReceiptClock(entity.ClockFunc(func() time.Time { return now })),
+			)
+
+			scan, err := usecase.ScanReceipt(context.Background(), 1, 10)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, scanRepo.scans)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, []byte("receipt"), reader.image)
+				assert.Equal(t, &entity.Money{Amount: 12000, Currency: "JPY"}, scan.PurchasePrice)
+				assert.Equal(t, "2023-01-15", scan.PurchaseDate)
+				assert.Equal(t, "ブティック銀座", scan.StoreName)
+				assert.Equal(t, now, scan.ScannedAt)
+				assert.Same(t, scan, scanRepo.scans[10])
+			}
+			itemRepo.AssertExpectations(t)
+			imageRepo.AssertExpectations(t)
+			storage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReceiptUsecase_GetReceipt(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+	scanRepo := &fakeReceiptScanRepository{scans: map[int64]*entity.ReceiptScan{10: {ImageID: 10, ItemID: 1, StoreName: "ブティック銀座"}}}
+	usecase := NewReceiptUsecase(itemRepo, new(MockItemImageRepository), new(MockImageStorage), scanRepo, &fakeReceiptReader{})
+
+	scan, err := usecase.GetReceipt(context.Background(), 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, "ブティック銀座", scan.StoreName)
+
+	_, err = usecase.GetReceipt(context.Background(), 1, 11)
+	assert.ErrorIs(t, err, domainErrors.ErrReceiptNotFound)
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 月次レポートで指定できる期間の上限（月数）
+const MaxReportMonths = 120
+
+const monthLayout = "2006-01"
+
+type ReportUsecase interface {
+	GetMonthlyPurchases(ctx context.Context, input MonthlyPurchasesInput) (*MonthlyPurchaseReport, error)
+	GetCustomsValues(ctx context.Context, input CustomsValuesInput) (*CustomsValueReport, error)
+	GetTaxPaid(ctx context.Context, input TaxPaidInput) (*TaxPaidReport, error)
+	GetProfitAndLoss(ctx context.Context, input ProfitAndLossInput) (*ProfitAndLossReport, error)
+}
+
+// 集計期間（YYYY-MM 形式、両端を含む）。未指定の場合は購入日の最も古い月・新しい月まで
+type MonthlyPurchasesInput struct {
+	From string
+	To   string
+}
+
+// 購入日に基づく月ごとの購入件数と金額の推移
+type MonthlyPurchaseReport struct {
+	From   string            `json:"from,omitempty"`
+	To     string            `json:"to,omitempty"`
+	Months []MonthlyPurchase `json:"months"`
+}
+
+// 購入のない月も0件として含める
+type MonthlyPurchase struct {
+	Month    string          `json:"month"`
+	Count    int             `json:"count"`
+	TotalJPY int64           `json:"total_jpy"` // 円換算額の合計
+	Totals   []CurrencyTotal `json:"totals"`    // 通貨ごとの購入価格の合計
+}
+
+type CurrencyTotal struct {
+	Currency string `json:"currency"`
+	Total    int64  `json:"total"`
+}
+
+type reportUsecase struct {
+	itemRepo ItemRepository
+	saleRepo SaleRepository
+}
+
+func NewReportUsecase(itemRepo ItemRepository, saleRepo SaleRepository) ReportUsecase {
+	// 集計は参照のみのため、一時的なエラーの場合は再試行する
+	return &reportUsecase{
+		itemRepo: &retryingItemRepository{ItemRepository: itemRepo, policy: DefaultRetryPolicy},
+		saleRepo: saleRepo,
+	}
+}
+
+func (u *reportUsecase) GetMonthlyPurchases(ctx context.Context, input MonthlyPurchasesInput) (*MonthlyPurchaseReport, error) {
+	from, to, err := parseReportRange(input.From, input.To)
+	if err != nil {
+		return nil, err
+	}
+
+	// 期間の終端は翌月の1日（含まない）で検索する
+	var fromDate, toDate string
+	if !from.IsZero() {
+		fromDate = from.Format(time.DateOnly)
+	}
+	if !to.IsZero() {
+		toDate = to.AddDate(0, 1, 0).Format(time.DateOnly)
+	}
+
+	totals, err := u.itemRepo.GetMonthlyPurchaseTotals(ctx, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly purchases: %w", err)
+	}
+
+	byMonth := make(map[string]*MonthlyPurchase)
+	for _, t := range totals {
+		month, ok := byMonth[t.Month]
+		if !ok {
+			month = &MonthlyPurchase{Month: t.Month, Totals: []CurrencyTotal{}}
+			byMonth[t.Month] = month
+		}
+		month.Count += t.Count
+		month.TotalJPY += t.TotalJPY
+		month.Totals = append(month.Totals, CurrencyTotal{Currency: t.Currency, Total: t.Total})
+	}
+
+	// 期間が未指定の場合は集計結果の最初と最後の月まで
+	if len(totals) > 0 {
+		if from.IsZero() {
+			from, _ = time.Parse(monthLayout, totals[0].Month)
+		}
+		if to.IsZero() {
+			to, _ = time.Parse(monthLayout, totals[len(totals)-1].Month)
+		}
+	}
+
+	report := &MonthlyPurchaseReport{Months: []MonthlyPurchase{}}
+	if from.IsZero() || to.IsZero() {
+		return report, nil
+	}
+	// 期間の片側が未指定の場合は集計結果から決まるため、ここでも上限を確認する
+	if monthsBetween(from, to) > MaxReportMonths {
+		return nil, fmt.Errorf("%w: period must be %d months or less", domainErrors.ErrInvalidInput, MaxReportMonths)
+	}
+
+	report.From = from.Format(monthLayout)
+	report.To = to.Format(monthLayout)
+	for m := from; !m.After(to); m = m.AddDate(0, 1, 0) {
+		key := m.Format(monthLayout)
+		if month, ok := byMonth[key]; ok {
+			report.Months = append(report.Months, *month)
+			continue
+		}
+		report.Months = append(report.Months, MonthlyPurchase{Month: key, Totals: []CurrencyTotal{}})
+	}
+
+	return report, nil
+}
+
+// YYYY-MM 形式の期間を検証する（未指定の端はゼロ値）
+func parseReportRange(fromStr, toStr string) (time.Time, time.Time, error) {
+	var from, to time.Time
+	var errs []string
+
+	if fromStr != "" {
+		t, err := time.Parse(monthLayout, fromStr)
+		if err != nil {
+			errs = append(errs, "from must be in YYYY-MM format")
+		}
+		from = t
+	}
+	if toStr != "" {
+		t, err := time.Parse(monthLayout, toStr)
+		if err != nil {
+			errs = append(errs, "to must be in YYYY-MM format")
+		}
+		to = t
+	}
+
+	if len(errs) == 0 && !from.IsZero() && !to.IsZero() {
+		if from.After(to) {
+			errs = append(errs, "from must be before or equal to to")
+		} else if monthsBetween(from, to) > MaxReportMonths {
+			errs = append(errs, fmt.Sprintf("period must be %d months or less", MaxReportMonths))
+		}
+	}
+
+	if len(errs) > 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, strings.Join(errs, ", "))
+	}
+	return from, to, nil
+}
+
+// 両端を含む月数
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestReportUsecase_GetMonthlyPurchases(t *testing.T) {
+	totals := []entity.MonthlyPurchaseTotal{
+		{Month: "2023-01", Currency: "JPY", Count: 2, Total: 1800000, TotalJPY: 1800000},
+		{Month: "2023-01", Currency: "USD", Count: 1, Total: 750000, TotalJPY: 978750},
+		{Month: "2023-03", Currency: "JPY", Count: 1, Total: 300000, TotalJPY: 300000},
+	}
+
+	tests := []struct {
+		name           string
+		input          MonthlyPurchasesInput
+		setupMock      func(*MockItemRepository)
+		expectedFrom   string
+		expectedTo     string
+		expectedMonths []MonthlyPurchase
+		expectedErr    error
+	}{
+		{
+			name:  "正常系: 期間指定なしは最初と最後の月まで（購入のない月は0件）",
+			input: MonthlyPurchasesInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetMonthlyPurchaseTotals", mock.Anything, "", "").Return(totals, nil)
+			},
+			expectedFrom: "2023-01",
+			expectedTo:   "2023-03",
+			expectedMonths: []MonthlyPurchase{
+				{Month: "2023-01", Count: 3, TotalJPY: 2778750, Totals: []CurrencyTotal{{Currency: "JPY", Total: 1800000}, {Currency: "USD", Total: 750000}}},
+				{Month: "2023-02", Totals: []CurrencyTotal{}},
+				{Month: "2023-03", Count: 1, TotalJPY: 300000, Totals: []CurrencyTotal{{Currency: "JPY", Total: 300000}}},
+			},
+		},
+		{
+			name:  "正常系: 期間指定（終端は翌月1日の前まで）",
+			input: MonthlyPurchasesInput{From: "2022-12", To: "2023-01"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetMonthlyPurchaseTotals", mock.Anything, "2022-12-01", "2023-02-01").Return(totals[:2], nil)
+			},
+			expectedFrom: "2022-12",
+			expectedTo:   "2023-01",
+			expectedMonths: []MonthlyPurchase{
+				{Month: "2022-12", Totals: []CurrencyTotal{}},
+				{Month: "2023-01", Count: 3, TotalJPY: 2778750, Totals: []CurrencyTotal{{Currency: "JPY", Total: 1800000}, {Currency: "USD", Total: 750000}}},
+			},
+		},
+		{
+			name:  "正常系: データなし",
+			input: MonthlyPurchasesInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetMonthlyPurchaseTotals", mock.Anything, "", "").Return(nil, nil)
+			},
+			expectedMonths: []MonthlyPurchase{},
+		},
+		{
+			name:        "異常系: 無効な形式",
+			input:       MonthlyPurchasesInput{From: "2023-1", To: "2023/02"},
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: from が to より後",
+			input:       MonthlyPurchasesInput{From: "2023-05", To: "2023-01"},
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 期間が長すぎる",
+			input:       MonthlyPurchasesInput{From: "2000-01", To: "2023-12"},
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			input: MonthlyPurchasesInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetMonthlyPurchaseTotals", mock.Anything, "", "").Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewReportUsecase(mockRepo, nil)
+
+			report, err := usecase.GetMonthlyPurchases(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, report)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedFrom, report.From)
+			assert.Equal(t, tt.expectedTo, report.To)
+			assert.Equal(t, tt.expectedMonths, report.Months)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -2,27 +2,361 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"Aicon-assignment/internal/domain/entity"
 )
 
 // ItemRepository defines the interface for item data access
 type ItemRepository interface {
-	// FindAll retrieves all items
-	FindAll(ctx context.Context) ([]*entity.Item, error)
+	// FindAll retrieves items matching the query, limited to one page
+	FindAll(ctx context.Context, query entity.ItemQuery) ([]*entity.Item, error)
 
-	// FindByID retrieves an item by ID
+	// Count returns the total number of items matching the query, ignoring paging
+	Count(ctx context.Context, query entity.ItemQuery) (int, error)
+
+	// FindByID retrieves an item by ID, excluding soft-deleted items
 	FindByID(ctx context.Context, id int64) (*entity.Item, error)
 
+	// FindByDedupeKey retrieves items with the same normalized name and brand, excluding soft-deleted items
+	FindByDedupeKey(ctx context.Context, key string) ([]*entity.Item, error)
+
+	// ExistsSimilar reports whether an item with the same normalized name and brand and the same purchase date exists,
+	// excluding soft-deleted items
+	ExistsSimilar(ctx context.Context, dedupeKey string, purchaseDate entity.Date) (bool, error)
+
 	// Create creates a new item and returns it with the generated ID
 	Create(ctx context.Context, item *entity.Item) (*entity.Item, error)
 
+	// CreateMany creates all items in a single transaction and returns them with the generated IDs
+	CreateMany(ctx context.Context, items []*entity.Item) ([]*entity.Item, error)
+
 	// Update updates an existing item
 	Update(ctx context.Context, item *entity.Item) (*entity.Item, error)
 
-	// Delete deletes an item by ID
-	Delete(ctx context.Context, id int64) error
+	// Delete soft-deletes an item by ID, setting deleted_at and updated_at to now
+	Delete(ctx context.Context, id int64, now time.Time) error
+
+	// Restore clears the soft-delete flag of a deleted item and sets updated_at to now
+	Restore(ctx context.Context, id int64, now time.Time) error
+
+	// SetHold sets or clears the legal hold flag of a non-deleted item and sets updated_at to now
+	SetHold(ctx context.Context, id int64, onHold bool, reason string, now time.Time) error
 
-	// GetSummaryByCategory returns item counts grouped by category (bonus feature)
+	// SetSold marks a non-deleted item as sold and sets updated_at to now
+	SetSold(ctx context.Context, id int64, now time.Time) error
+
+	// Split soft-deletes a non-deleted item (as of now) and creates its components in a single transaction,
+	// returning the components with the generated IDs
+	Split(ctx context.Context, id int64, components []*entity.Item, now time.Time) ([]*entity.Item, error)
+
+	// SchedulePurge sets the scheduled hard-delete time of a non-deleted item (nil cancels the schedule)
+	// and sets updated_at to now
+	SchedulePurge(ctx context.Context, id int64, purgeAt *time.Time, now time.Time) error
+
+	// FindDuePurges retrieves up to limit items whose scheduled hard-delete time is not after now,
+	// including soft-deleted items and excluding items on legal hold
+	FindDuePurges(ctx context.Context, now time.Time, limit int) ([]*entity.Item, error)
+
+	// Purge permanently deletes an item and, through foreign keys, its photos, valuations and tags
+	Purge(ctx context.Context, id int64) error
+
+	// SumPurchasePrice returns the total purchase price of non-deleted items in the category and currency
+	// purchased in the given year, excluding the item with excludeID (0 excludes nothing)
+	SumPurchasePrice(ctx context.Context, category, currency string, year int, excludeID int64) (int64, error)
+
+	// GetSummaryByCategory returns item counts grouped by category, excluding soft-deleted items (bonus feature)
 	GetSummaryByCategory(ctx context.Context) (map[string]int, error)
+
+	// GetValueSummaryByCategory returns purchase price totals grouped by category and currency, excluding soft-deleted items
+	GetValueSummaryByCategory(ctx context.Context) ([]entity.CategoryValueTotal, error)
+
+	// GetSummaryByBrand returns item counts and purchase price totals grouped by brand and currency,
+	// ordered by JPY-equivalent total descending, excluding soft-deleted items
+	GetSummaryByBrand(ctx context.Context) ([]entity.BrandValueTotal, error)
+
+	// GetSummaryByYearAndCategory returns item counts and purchase price totals grouped by purchase year,
+	// category and currency, ordered by year, excluding soft-deleted items
+	GetSummaryByYearAndCategory(ctx context.Context) ([]entity.YearCategoryValueTotal, error)
+
+	// GetMonthlyPurchaseTotals returns purchase counts and totals grouped by purchase month and currency,
+	// ordered by month, for purchase dates in [from, to) (an empty bound is open), excluding soft-deleted items
+	GetMonthlyPurchaseTotals(ctx context.Context, from, to string) ([]entity.MonthlyPurchaseTotal, error)
+
+	// GetCustomsValueTotals returns purchase counts and totals grouped by country of purchase, purchase year
+	// and currency, ordered by country and year, for purchase dates in [from, to) (an empty bound is open),
+	// excluding soft-deleted items, drafts and items without a country of purchase
+	GetCustomsValueTotals(ctx context.Context, from, to string) ([]entity.CustomsValueTotal, error)
+
+	// GetTaxTotals returns tax counts and totals grouped by purchase year and currency, ordered by year,
+	// for purchase dates in [from, to) (an empty bound is open), excluding soft-deleted items, drafts
+	// and items without a recorded tax amount
+	GetTaxTotals(ctx context.Context, from, to string) ([]entity.TaxTotal, error)
+}
+
+// BudgetRepository defines the interface for category budget data access
+type BudgetRepository interface {
+	// FindAll retrieves all category budgets
+	FindAll(ctx context.Context) ([]*entity.CategoryBudget, error)
+
+	// FindByCategory retrieves the budget of a category, or nil if none is set
+	FindByCategory(ctx context.Context, category string) (*entity.CategoryBudget, error)
+
+	// Save creates or replaces the budget of a category
+	Save(ctx context.Context, budget *entity.CategoryBudget) (*entity.CategoryBudget, error)
+
+	// Delete removes the budget of a category
+	Delete(ctx context.Context, category string) error
+}
+
+// BrandAliasRepository defines the interface for admin-managed brand alias access
+type BrandAliasRepository interface {
+	// FindAll retrieves all brand aliases ordered by alias
+	FindAll(ctx context.Context) ([]*entity.BrandAlias, error)
+
+	// Save creates or replaces the alias with the same normalized key
+	Save(ctx context.Context, alias *entity.BrandAlias) (*entity.BrandAlias, error)
+
+	// Delete removes the alias with the normalized key
+	Delete(ctx context.Context, key string) error
+}
+
+// ItemImageRepository defines the interface for item photo metadata access
+type ItemImageRepository interface {
+	// FindByItemID retrieves all photos of an item in upload order
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemImage, error)
+
+	// FindByID retrieves a photo of an item by ID
+	FindByID(ctx context.Context, itemID, imageID int64) (*entity.ItemImage, error)
+
+	// Create stores the metadata of a new photo and returns it with the generated ID
+	Create(ctx context.Context, image *entity.ItemImage) (*entity.ItemImage, error)
+
+	// Delete removes the metadata of a photo
+	Delete(ctx context.Context, itemID, imageID int64) error
+
+	// TotalSize returns the total size in bytes of all stored photos
+	TotalSize(ctx context.Context) (int64, error)
+}
+
+// ValuationRepository defines the interface for item valuation history access
+type ValuationRepository interface {
+	// FindByItemID retrieves all valuations of an item ordered by valuation date
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.Valuation, error)
+
+	// FindLatestByItemIDs retrieves the latest valuation of each item keyed by item ID
+	// Items without valuations are not included
+	FindLatestByItemIDs(ctx context.Context, itemIDs []int64) (map[int64]*entity.Valuation, error)
+
+	// Create stores a new valuation and returns it with the generated ID
+	Create(ctx context.Context, valuation *entity.Valuation) (*entity.Valuation, error)
+}
+
+// TagRepository defines the interface for item tag data access
+type TagRepository interface {
+	// FindNamesByItemIDs retrieves the tag names of each item ordered by name, keyed by item ID
+	// Items without tags are not included
+	FindNamesByItemIDs(ctx context.Context, itemIDs []int64) (map[int64][]string, error)
+
+	// AddToItem attaches the tag to an item, creating the tag if it does not exist yet
+	// Attaching a tag the item already has is a no-op
+	AddToItem(ctx context.Context, itemID int64, tag *entity.Tag) error
+
+	// RemoveFromItem detaches the tag from an item
+	RemoveFromItem(ctx context.Context, itemID int64, name string) error
+}
+
+// ItemDependentsRepository defines the interface for the data attached to an item
+// (photos, valuations and tags)
+type ItemDependentsRepository interface {
+	// CountByItemID counts the data attached to an item
+	CountByItemID(ctx context.Context, itemID int64) (*entity.ItemDependents, error)
+
+	// DeleteByItemID removes the data attached to an item and returns the storage keys of the removed photos
+	DeleteByItemID(ctx context.Context, itemID int64) ([]string, error)
+
+	// FindOrphanedItemIDs retrieves up to limit IDs of items deleted before the given time that still have attached data
+	FindOrphanedItemIDs(ctx context.Context, deletedBefore time.Time, limit int) ([]int64, error)
+}
+
+// Transactor runs operations across repositories in a single transaction
+type Transactor interface {
+	// Transaction runs fn in a transaction. Repository calls made with the context passed to fn
+	// join the transaction, which is rolled back if fn returns an error
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// AuditLogger defines the port for recording and reading the audit log of item mutations
+type AuditLogger interface {
+	// Log records a mutation of an item
+	Log(ctx context.Context, entry *entity.AuditLog) error
+
+	// FindByItemID retrieves the audit log of an item in the order it was recorded
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.AuditLog, error)
+}
+
+// ItemRevisionRepository defines the interface for the versioned snapshots of items
+type ItemRevisionRepository interface {
+	// Create stores a snapshot as the next version of the item and returns it with the assigned version
+	Create(ctx context.Context, revision *entity.ItemRevision) (*entity.ItemRevision, error)
+
+	// FindByItemID retrieves all snapshots of an item ordered by version
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemRevision, error)
+
+	// FindByVersion retrieves a single snapshot of an item, returning ErrRevisionNotFound if it does not exist
+	FindByVersion(ctx context.Context, itemID int64, version int) (*entity.ItemRevision, error)
+}
+
+// WebhookRepository defines the interface for the registered webhook endpoints
+type WebhookRepository interface {
+	// FindAll retrieves all webhooks ordered by ID
+	FindAll(ctx context.Context) ([]*entity.Webhook, error)
+
+	// FindByOwner retrieves the webhooks registered by an actor ordered by ID
+	FindByOwner(ctx context.Context, owner string) ([]*entity.Webhook, error)
+
+	// FindByID retrieves a webhook, returning ErrWebhookNotFound if it does not exist
+	FindByID(ctx context.Context, id int64) (*entity.Webhook, error)
+
+	// Create stores a webhook and returns it with the assigned ID
+	Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error)
+
+	// Delete removes a webhook and its delivery log, returning ErrWebhookNotFound if it does not exist
+	Delete(ctx context.Context, id int64) error
+}
+
+// WebhookDeliveryRepository defines the interface for the webhook delivery log
+type WebhookDeliveryRepository interface {
+	// Create stores a delivery and returns it with the assigned ID
+	Create(ctx context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error)
+
+	// Update stores the result of a delivery attempt (status, attempts, response and next attempt)
+	Update(ctx context.Context, delivery *entity.WebhookDelivery) error
+
+	// FindDue retrieves pending deliveries whose next attempt is at or before now, oldest first
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error)
+
+	// FindByWebhookID retrieves the most recent deliveries of a webhook, newest first
+	FindByWebhookID(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error)
+}
+
+// OutboxRepository defines the interface for the transactional outbox of item events
+type OutboxRepository interface {
+	// Create records an event. Called with the context of the mutation's transaction so that
+	// the event is stored if and only if the mutation commits
+	Create(ctx context.Context, event *entity.OutboxEvent) error
+
+	// FindDue retrieves unpublished events whose next attempt is at or before now, oldest first
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.OutboxEvent, error)
+
+	// Update stores the result of a publish attempt (attempts, last error, next attempt and published time)
+	Update(ctx context.Context, event *entity.OutboxEvent) error
+
+	// DeletePublishedBefore removes events published before the given time and returns how many were removed
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// JobRepository defines the interface for the persistent queue of background jobs
+type JobRepository interface {
+	// Create stores a job and returns it with the assigned ID
+	Create(ctx context.Context, job *entity.Job) (*entity.Job, error)
+
+	// FindByID retrieves a job, returning ErrJobNotFound if it does not exist
+	FindByID(ctx context.Context, id int64) (*entity.Job, error)
+
+	// Claim atomically takes the oldest runnable job (queued and due, or running with an expired lease),
+	// marks it as running until leaseUntil and counts the attempt. Returns nil if there is no runnable job
+	Claim(ctx context.Context, now, leaseUntil time.Time) (*entity.Job, error)
+
+	// Update stores the result of a run (status, attempts, error, result and next run)
+	Update(ctx context.Context, job *entity.Job) error
+}
+
+// JobRunRepository defines the interface for the history of scheduled job runs
+type JobRunRepository interface {
+	// Create stores a started run and returns it with the assigned ID.
+	// Returns ErrDuplicateEntry if a run of the same job and scheduled time already exists
+	Create(ctx context.Context, run *entity.JobRun) (*entity.JobRun, error)
+
+	// Update stores the summary of a run (status, counts, errors and finished time)
+	Update(ctx context.Context, run *entity.JobRun) error
+
+	// FindRecent retrieves the latest runs of all jobs, newest first
+	FindRecent(ctx context.Context, limit int) ([]*entity.JobRun, error)
+}
+
+// CategorySuggestionRepository defines the interface for suggested categories awaiting review
+type CategorySuggestionRepository interface {
+	// Create stores a suggestion and returns it with the assigned ID
+	Create(ctx context.Context, suggestion *entity.CategorySuggestion) (*entity.CategorySuggestion, error)
+
+	// FindByID retrieves a suggestion, returning ErrSuggestionNotFound if it does not exist
+	FindByID(ctx context.Context, id int64) (*entity.CategorySuggestion, error)
+
+	// FindByStatus retrieves suggestions with the given status, oldest first
+	FindByStatus(ctx context.Context, status entity.SuggestionStatus, limit, offset int) ([]*entity.CategorySuggestion, error)
+
+	// FindPendingItemIDs returns which of the given items already have a pending suggestion
+	FindPendingItemIDs(ctx context.Context, itemIDs []int64) (map[int64]bool, error)
+
+	// Update stores the review of a pending suggestion (status, reviewer and reviewed time).
+	// Returns ErrSuggestionOutdated if the suggestion has already been reviewed
+	Update(ctx context.Context, suggestion *entity.CategorySuggestion) error
+}
+
+// SaleRepository defines the interface for recording sales of items
+type SaleRepository interface {
+	// Create records a sale and returns it with the generated ID.
+	// Returns ErrDuplicateEntry if a sale of the item is already recorded
+	Create(ctx context.Context, sale *entity.Sale) (*entity.Sale, error)
+
+	// GetProfitLossTotals returns realized profit/loss totals grouped by period of the sale date and category,
+	// ordered by period and category, for sale dates in [from, to) (an empty bound is open)
+	GetProfitLossTotals(ctx context.Context, period entity.ProfitLossPeriod, from, to string) ([]entity.ProfitLossTotal, error)
+}
+
+// InsurancePolicyRepository defines the interface for the insurance policies of items
+type InsurancePolicyRepository interface {
+	// FindByItemID retrieves the policies of an item ordered by expiry date
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.InsurancePolicy, error)
+
+	// FindByID retrieves a policy, returning ErrInsurancePolicyNotFound if it does not exist
+	FindByID(ctx context.Context, id int64) (*entity.InsurancePolicy, error)
+
+	// FindExpiring retrieves the policies of non-deleted items expiring between from and to (inclusive),
+	// ordered by expiry date, with the name and category of the item
+	FindExpiring(ctx context.Context, from, to entity.Date) ([]*entity.ExpiringInsurancePolicy, error)
+
+	// Create creates a policy and returns it with the generated ID (ErrDuplicateEntry if the item already has the policy)
+	Create(ctx context.Context, policy *entity.InsurancePolicy) (*entity.InsurancePolicy, error)
+
+	// Update updates a policy, returning ErrInsurancePolicyNotFound if it does not exist
+	Update(ctx context.Context, policy *entity.InsurancePolicy) (*entity.InsurancePolicy, error)
+
+	// Delete removes a policy, returning ErrInsurancePolicyNotFound if it does not exist
+	Delete(ctx context.Context, id int64) error
+}
+
+// ReceiptScanRepository defines the interface for receipt fields read from item photos
+type ReceiptScanRepository interface {
+	// Save stores the scan of a photo, replacing the previous scan of the same photo
+	Save(ctx context.Context, scan *entity.ReceiptScan) error
+
+	// FindByImageID retrieves the scan of a photo, returning ErrReceiptNotFound if it has not been scanned
+	FindByImageID(ctx context.Context, itemID, imageID int64) (*entity.ReceiptScan, error)
+}
+
+// DashboardRepository defines the aggregate queries of the admin dashboard
+type DashboardRepository interface {
+	// SummarizeActivity counts the actors, changes and created items in the audit logs since query.Since
+	SummarizeActivity(ctx context.Context, query entity.ActivityQuery) (*entity.ActivitySummary, error)
+
+	// FindActorActivity aggregates the audit logs since query.Since per actor, most active first, limited to query.Limit
+	FindActorActivity(ctx context.Context, query entity.ActivityQuery) ([]*entity.ActorActivity, error)
+
+	// FindStorageByCategory aggregates the number and size of stored photos per category of their items
+	FindStorageByCategory(ctx context.Context) ([]*entity.CategoryStorage, error)
+
+	// FindWebhookHealth aggregates the deliveries created since the given time per webhook, including webhooks without deliveries
+	FindWebhookHealth(ctx context.Context, since time.Time) ([]*entity.WebhookHealth, error)
 }
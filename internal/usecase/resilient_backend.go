@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 読み取りキャッシュの呼び出しにタイムアウトとサーキットブレーカーを設けるデコレーター
+// 障害中はすぐにエラーを返すため、CachedItemRepository・ItemResponseCache は待たずにリポジトリから読む
+// 障害中の変更ではキャッシュを削除できないため、復旧後も有効期間までは古い内容を返すことがある
+type ResilientKeyValueCache struct {
+	cache   KeyValueCache
+	breaker *CircuitBreaker
+}
+
+func NewResilientKeyValueCache(cache KeyValueCache, breaker *CircuitBreaker) *ResilientKeyValueCache {
+	return &ResilientKeyValueCache{cache: cache, breaker: breaker}
+}
+
+func (c *ResilientKeyValueCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	var value []byte
+	var ok bool
+	err := c.breaker.Do(ctx, func(ctx context.Context) error {
+		var err error
+		value, ok, err = c.cache.Get(ctx, key)
+		return err
+	})
+	return value, ok, err
+}
+
+func (c *ResilientKeyValueCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.breaker.Do(ctx, func(ctx context.Context) error {
+		return c.cache.Set(ctx, key, value, ttl)
+	})
+}
+
+func (c *ResilientKeyValueCache) Delete(ctx context.Context, keys ...string) error {
+	return c.breaker.Do(ctx, func(ctx context.Context) error {
+		return c.cache.Delete(ctx, keys...)
+	})
+}
+
+func (c *ResilientKeyValueCache) Counter(ctx context.Context, key string) (int64, error) {
+	var value int64
+	err := c.breaker.Do(ctx, func(ctx context.Context) error {
+		var err error
+		value, err = c.cache.Counter(ctx, key)
+		return err
+	})
+	return value, err
+}
+
+func (c *ResilientKeyValueCache) Incr(ctx context.Context, key string) (int64, error) {
+	var value int64
+	err := c.breaker.Do(ctx, func(ctx context.Context) error {
+		var err error
+		value, err = c.cache.Incr(ctx, key)
+		return err
+	})
+	return value, err
+}
+
+// 検索エンジンの呼び出しにサーキットブレーカーを設けるデコレーター
+// 障害中の検索はすぐにエラーを返し、キーワード検索はSQLで検索する（インデックスの更新はアウトボックスを使う場合は再送する）
+type ResilientSearchIndex struct {
+	index   SearchIndex
+	breaker *CircuitBreaker
+}
+
+func NewResilientSearchIndex(index SearchIndex, breaker *CircuitBreaker) *ResilientSearchIndex {
+	return &ResilientSearchIndex{index: index, breaker: breaker}
+}
+
+func (s *ResilientSearchIndex) Search(ctx context.Context, query SearchIndexQuery) (*SearchIndexResult, error) {
+	var result *SearchIndexResult
+	err := s.breaker.Do(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.index.Search(ctx, query)
+		return err
+	})
+	return result, err
+}
+
+func (s *ResilientSearchIndex) Upsert(ctx context.Context, items []*entity.Item) error {
+	return s.breaker.Do(ctx, func(ctx context.Context) error {
+		return s.index.Upsert(ctx, items)
+	})
+}
+
+func (s *ResilientSearchIndex) Delete(ctx context.Context, ids []int64) error {
+	return s.breaker.Do(ctx, func(ctx context.Context) error {
+		return s.index.Delete(ctx, ids)
+	})
+}
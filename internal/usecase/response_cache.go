@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// レスポンスのキャッシュのデフォルトの有効期間
+const DefaultItemResponseCacheTTL = 5 * time.Minute
+
+// キャッシュのキー（保存形式を変えた場合は版を上げる）
+const itemResponseCachePrefix = "responses:v1:"
+
+// アイテムの取得（GET /items/{id}）のレスポンスのキャッシュ
+// CDNのキャッシュと同じ契機（アイテム・タグ・評価額の変更）でアイテムの版を進め、版ごとにレスポンスを保存するため、
+// 変更後に古いレスポンスを返すことはない。変更時の削除先（CachePurger）として指定する
+type ItemResponseCache struct {
+	cache KeyValueCache
+	ttl   time.Duration
+}
+
+// キャッシュしたレスポンス
+type CachedResponse struct {
+	ETag   string
+	Header map[string]string
+	Body   []byte
+}
+
+func NewItemResponseCache(cache KeyValueCache, ttl time.Duration) *ItemResponseCache {
+	return &ItemResponseCache{cache: cache, ttl: ttl}
+}
+
+// アイテムの現在の版と、その版のレスポンス（キャッシュされていない場合は nil）
+func (c *ItemResponseCache) Get(ctx context.Context, id int64) (int64, *CachedResponse, error) {
+	version, err := c.cache.Counter(ctx, itemResponseVersionKey(ItemSurrogateKey(id)))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	cached, ok, err := c.cache.Get(ctx, itemResponseKey(id, version))
+	if err != nil || !ok {
+		return version, nil, err
+	}
+	var response CachedResponse
+	if err := gob.NewDecoder(bytes.NewReader(cached)).Decode(&response); err != nil {
+		return version, nil, nil
+	}
+	return version, &response, nil
+}
+
+// Get で取得した版のレスポンスとして保存する（保存までに版が進んだ場合、そのレスポンスは使われない）
+func (c *ItemResponseCache) Set(ctx context.Context, id, version int64, response *CachedResponse) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	return c.cache.Set(ctx, itemResponseKey(id, version), buf.Bytes(), c.ttl)
+}
+
+// アイテム単位のサロゲートキーのアイテムの版を進める（他のキーは対象外）
+func (c *ItemResponseCache) Purge(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		if !strings.HasPrefix(key, itemSurrogateKeyPrefix) {
+			continue
+		}
+		if _, err := c.cache.Incr(ctx, itemResponseVersionKey(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func itemResponseVersionKey(surrogateKey string) string {
+	return itemResponseCachePrefix + "version:" + surrogateKey
+}
+
+func itemResponseKey(id, version int64) string {
+	return itemResponseCachePrefix + ItemSurrogateKey(id) + ":" + strconv.FormatInt(version, 10)
+}
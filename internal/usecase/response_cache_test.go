@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemResponseCache(t *testing.T) {
+	ctx := context.Background()
+	response := &CachedResponse{ETag: `"abc"`, Header: map[string]string{"Content-Type": "application/json"}, Body: []byte(`{"id":1}`)}
+
+	t.Run("正常系: 保存した版のレスポンスを返す", func(t *testing.T) {
+		cache := NewItemResponseCache(newFakeKeyValueCache(), time.Minute)
+		version, cached, err := cache.Get(ctx, 1)
+		require.NoError(t, err)
+		assert.Nil(t, cached)
+		require.NoError(t, cache.Set(ctx, 1, version, response))
+
+		_, cached, err = cache.Get(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, response, cached)
+	})
+
+	t.Run("正常系: アイテムのサロゲートキーの削除で版を進め、保存済みのレスポンスを使わない", func(t *testing.T) {
+		cache := NewItemResponseCache(newFakeKeyValueCache(), time.Minute)
+		version, _, _ := cache.Get(ctx, 1)
+		require.NoError(t, cache.Set(ctx, 1, version, response))
+		require.NoError(t, cache.Set(ctx, 2, version, response))
+
+		require.NoError(t, cache.Purge(ctx, []string{ItemsSurrogateKey, ItemSurrogateKey(1)}))
+
+		next, cached, err := cache.Get(ctx, 1)
+		require.NoError(t, err)
+		assert.Nil(t, cached)
+		assert.Greater(t, next, version)
+		_, cached, _ = cache.Get(ctx, 2)
+		assert.NotNil(t, cached)
+	})
+
+	t.Run("正常系: 版が進んだ後に保存した古い版のレスポンスは使わない", func(t *testing.T) {
+		cache := NewItemResponseCache(newFakeKeyValueCache(), time.Minute)
+		version, _, _ := cache.Get(ctx, 1)
+		require.NoError(t, cache.Purge(ctx, []string{ItemSurrogateKey(1)}))
+		require.NoError(t, cache.Set(ctx, 1, version, response))
+
+		_, cached, err := cache.Get(ctx, 1)
+		require.NoError(t, err)
+		assert.Nil(t, cached)
+	})
+
+	t.Run("異常系: キャッシュの障害はエラーを返す", func(t *testing.T) {
+		kv := newFakeKeyValueCache()
+		kv.err = errors.New("connection refused")
+		_, _, err := NewItemResponseCache(kv, time.Minute).Get(ctx, 1)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 一時的なエラーの再試行方針
+type RetryPolicy struct {
+	// 最初の試行を含む最大試行回数（1以下の場合は再試行しない）
+	MaxAttempts int
+	// 1回目の再試行までの待ち時間（以降は2倍ずつ増やす）
+	BaseDelay time.Duration
+	// 待ち時間の上限
+	MaxDelay time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   50 * time.Millisecond,
+	MaxDelay:    time.Second,
+}
+
+// 一時的なエラーの場合のみ、バックオフしながら fn を再試行する
+// トランザクションの中では再試行しない（デッドロックなどでロールバックされた後に文だけを再試行すると、
+// 一部の変更だけがトランザクションの外で確定するため。再試行はトランザクションを開始した側でトランザクションごと行う）
+func (p RetryPolicy) do(ctx context.Context, fn func() error) error {
+	if inTransaction(ctx) {
+		return fn()
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil || !domainErrors.IsTransientError(err) || attempt >= p.MaxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(p.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+type transactionKey struct{}
+
+// fn をトランザクションで実行する（fn に渡すコンテキストでは、リポジトリの操作を個別に再試行しない）
+func runInTransaction(ctx context.Context, transactor Transactor, fn func(ctx context.Context) error) error {
+	return transactor.Transaction(ctx, func(ctx context.Context) error {
+		return fn(context.WithValue(ctx, transactionKey{}, true))
+	})
+}
+
+// runInTransaction で開始したトランザクションの中か
+func inTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(transactionKey{}).(bool)
+	return ok
+}
+
+// 指数バックオフ（同時に失敗したリクエストが同時に再試行しないようジッターを加える）
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// 冪等な操作のみを再試行するリポジトリのデコレーター
+// 登録（Create, CreateMany）と分割（Split）は再試行すると重複登録になりうるため再試行しない。
+// 削除・復元は1回目が成功していた場合に再試行が not found になるため再試行しない
+type retryingItemRepository struct {
+	ItemRepository
+	policy RetryPolicy
+}
+
+func (r *retryingItemRepository) FindAll(ctx context.Context, query entity.ItemQuery) ([]*entity.Item, error) {
+	var items []*entity.Item
+	err := r.policy.do(ctx, func() error {
+		var err error
+		items, err = r.ItemRepository.FindAll(ctx, query)
+		return err
+	})
+	return items, err
+}
+
+func (r *retryingItemRepository) Count(ctx context.Context, query entity.ItemQuery) (int, error) {
+	var count int
+	err := r.policy.do(ctx, func() error {
+		var err error
+		count, err = r.ItemRepository.Count(ctx, query)
+		return err
+	})
+	return count, err
+}
+
+func (r *retryingItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	var item *entity.Item
+	err := r.policy.do(ctx, func() error {
+		var err error
+		item, err = r.ItemRepository.FindByID(ctx, id)
+		return err
+	})
+	return item, err
+}
+
+// 更新は同じ値を書き込むだけなので再試行しても結果は変わらない
+func (r *retryingItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	var updated *entity.Item
+	err := r.policy.do(ctx, func() error {
+		var err error
+		updated, err = r.ItemRepository.Update(ctx, item)
+		return err
+	})
+	return updated, err
+}
+
+func (r *retryingItemRepository) SetHold(ctx context.Context, id int64, onHold bool, reason string, now time.Time) error {
+	return r.policy.do(ctx, func() error {
+		return r.ItemRepository.SetHold(ctx, id, onHold, reason, now)
+	})
+}
+
+func (r *retryingItemRepository) SetSold(ctx context.Context, id int64, now time.Time) error {
+	return r.policy.do(ctx, func() error {
+		return r.ItemRepository.SetSold(ctx, id, now)
+	})
+}
+
+func (r *retryingItemRepository) SumPurchasePrice(ctx context.Context, category, currency string, year int, excludeID int64) (int64, error) {
+	var total int64
+	err := r.policy.do(ctx, func() error {
+		var err error
+		total, err = r.ItemRepository.SumPurchasePrice(ctx, category, currency, year, excludeID)
+		return err
+	})
+	return total, err
+}
+
+func (r *retryingItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
+	var summary map[string]int
+	err := r.policy.do(ctx, func() error {
+		var err error
+		summary, err = r.ItemRepository.GetSummaryByCategory(ctx)
+		return err
+	})
+	return summary, err
+}
+
+func (r *retryingItemRepository) GetValueSummaryByCategory(ctx context.Context) ([]entity.CategoryValueTotal, error) {
+	var totals []entity.CategoryValueTotal
+	err := r.policy.do(ctx, func() error {
+		var err error
+		totals, err = r.ItemRepository.GetValueSummaryByCategory(ctx)
+		return err
+	})
+	return totals, err
+}
+
+func (r *retryingItemRepository) FindByDedupeKey(ctx context.Context, key string) ([]*entity.Item, error) {
+	var items []*entity.Item
+	err := r.policy.do(ctx, func() error {
+		var err error
+		items, err = r.ItemRepository.FindByDedupeKey(ctx, key)
+		return err
+	})
+	return items, err
+}
+
+func (r *retryingItemRepository) ExistsSimilar(ctx context.Context, dedupeKey string, purchaseDate entity.Date) (bool, error) {
+	var exists bool
+	err := r.policy.do(ctx, func() error {
+		var err error
+		exists, err = r.ItemRepository.ExistsSimilar(ctx, dedupeKey, purchaseDate)
+		return err
+	})
+	return exists, err
+}
+
+func (r *retryingItemRepository) GetSummaryByBrand(ctx context.Context) ([]entity.BrandValueTotal, error) {
+	var totals []entity.BrandValueTotal
+	err := r.policy.do(ctx, func() error {
+		var err error
+		totals, err = r.ItemRepository.GetSummaryByBrand(ctx)
+		return err
+	})
+	return totals, err
+}
+
+func (r *retryingItemRepository) GetSummaryByYearAndCategory(ctx context.Context) ([]entity.YearCategoryValueTotal, error) {
+	var totals []entity.YearCategoryValueTotal
+	err := r.policy.do(ctx, func() error {
+		var err error
+		totals, err = r.ItemRepository.GetSummaryByYearAndCategory(ctx)
+		return err
+	})
+	return totals, err
+}
+
+func (r *retryingItemRepository) GetMonthlyPurchaseTotals(ctx context.Context, from, to string) ([]entity.MonthlyPurchaseTotal, error) {
+	var totals []entity.MonthlyPurchaseTotal
+	err := r.policy.do(ctx, func() error {
+		var err error
+		totals, err = r.ItemRepository.GetMonthlyPurchaseTotals(ctx, from, to)
+		return err
+	})
+	return totals, err
+}
+
+func (r *retryingItemRepository) GetCustomsValueTotals(ctx context.Context, from, to string) ([]entity.CustomsValueTotal, error) {
+	var totals []entity.CustomsValueTotal
+	err := r.policy.do(ctx, func() error {
+		var err error
+		totals, err = r.ItemRepository.GetCustomsValueTotals(ctx, from, to)
+		return err
+	})
+	return totals, err
+}
+
+func (r *retryingItemRepository) GetTaxTotals(ctx context.Context, from, to string) ([]entity.TaxTotal, error) {
+	var totals []entity.TaxTotal
+	err := r.policy.do(ctx, func() error {
+		var err error
+		totals, err = r.ItemRepository.GetTaxTotals(ctx, from, to)
+		return err
+	})
+	return totals, err
+}
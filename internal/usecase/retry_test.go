@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// テストでは待たずに再試行する
+var noDelayRetryPolicy = RetryPolicy{MaxAttempts: 3}
+
+var errTransient = fmt.Errorf("%w: %w: deadlock", domainErrors.ErrDatabaseError, domainErrors.ErrTransient)
+
+func TestItemUsecase_RetryOnTransientError(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "ロレックス デイトナ"}
+
+	tests := []struct {
+		name          string
+		setupMock     func(*MockItemRepository)
+		expectedCalls int
+		expectedErr   error
+	}{
+		{
+			name: "正常系: 一時的なエラーは再試行して成功",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, errTransient).Twice()
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil).Once()
+			},
+			expectedCalls: 3,
+		},
+		{
+			name: "異常系: 最大試行回数を超えた場合はエラー",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, errTransient)
+			},
+			expectedCalls: 3,
+			expectedErr:   domainErrors.ErrTransient,
+		},
+		{
+			name: "異常系: 一時的でないエラーは再試行しない",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedCalls: 1,
+			expectedErr:   domainErrors.ErrDatabaseError,
+		},
+		{
+			name: "異常系: not found は再試行しない",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedCalls: 1,
+			expectedErr:   domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo, WithRetryPolicy(noDelayRetryPolicy))
+
+			found, err := usecase.GetItemByID(context.Background(), 1)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, found)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, item.ID, found.ID)
+			}
+			mockRepo.AssertNumberOfCalls(t, "FindByID", tt.expectedCalls)
+		})
+	}
+}
+
+func TestItemUsecase_NoRetryOnCreate(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("FindByDedupeKey", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil, errTransient)
+	usecase := NewItemUsecase(mockRepo, WithRetryPolicy(noDelayRetryPolicy))
+
+	_, err := usecase.CreateItem(context.Background(), CreateItemInput{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: entity.JPY(1500000),
+		PurchaseDate:  "2023-01-15",
+	})
+
+	assert.ErrorIs(t, err, domainErrors.ErrTransient)
+	mockRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestRetryPolicy_StopsWhenContextDone(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	err := policy.do(ctx, func() error {
+		calls++
+		return errTransient
+	})
+
+	assert.ErrorIs(t, err, domainErrors.ErrTransient)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+
+	tests := []struct {
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{attempt: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{attempt: 2, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{attempt: 3, min: 150 * time.Millisecond, max: 300 * time.Millisecond},
+		{attempt: 10, min: 150 * time.Millisecond, max: 300 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d回目", tt.attempt), func(t *testing.T) {
+			delay := policy.backoff(tt.attempt)
+			assert.GreaterOrEqual(t, delay, tt.min)
+			assert.LessOrEqual(t, delay, tt.max)
+		})
+	}
+}
+
+func TestItemUsecase_RetryTransaction(t *testing.T) {
+	newItem := func() *entity.Item {
+		item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+		item.ID = 1
+		return item
+	}
+
+	t.Run("正常系: トランザクションの中の文は再試行せず、トランザクションごと再試行する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+		mockRepo.On("SetHold", mock.Anything, int64(1), true, "係争中", mock.Anything).Return(errTransient).Once()
+		mockRepo.On("SetHold", mock.Anything, int64(1), true, "係争中", mock.Anything).Return(nil).Once()
+		outbox := &fakeOutboxRepository{}
+		transactor := &fakeTransactor{}
+		usecase := NewItemUsecase(mockRepo, WithRetryPolicy(noDelayRetryPolicy), WithOutbox(outbox, transactor))
+
+		_, err := usecase.SetItemHold(context.Background(), 1, SetItemHoldInput{OnHold: true, Reason: "係争中"})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, transactor.calls)
+		assert.Len(t, outbox.events, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: トランザクションの中で呼ばれた場合は外側に任せて再試行しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+		mockRepo.On("SetHold", mock.Anything, int64(1), true, "係争中", mock.Anything).Return(errTransient)
+		transactor := &fakeTransactor{}
+		usecase := NewItemUsecase(mockRepo, WithRetryPolicy(noDelayRetryPolicy), WithOutbox(&fakeOutboxRepository{}, transactor))
+
+		err := runInTransaction(context.Background(), &fakeTransactor{}, func(ctx context.Context) error {
+			_, err := usecase.SetItemHold(ctx, 1, SetItemHoldInput{OnHold: true, Reason: "係争中"})
+			return err
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrTransient)
+		assert.Equal(t, 1, transactor.calls)
+		mockRepo.AssertNumberOfCalls(t, "SetHold", 1)
+	})
+}
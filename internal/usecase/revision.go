@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// アイテムの登録・更新ごとに状態を版として保存し、以前の版に戻せるようにする
+func WithRevisions(repo ItemRevisionRepository) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.revisionRepo = repo
+	}
+}
+
+// アイテムの現在の状態を次の版として保存する
+// 変更は完了しているため、保存に失敗しても操作自体はエラーにしない（その版には戻せなくなる）
+func (u *itemUsecase) snapshot(ctx context.Context, item *entity.Item) {
+	if u.revisionRepo == nil {
+		return
+	}
+
+	_, _ = u.revisionRepo.Create(ctx, entity.NewItemRevision(item, ActorFromContext(ctx), u.clock.Now()))
+}
+
+// アイテムの版の一覧（古い順）
+func (u *itemUsecase) GetItemRevisions(ctx context.Context, id int64) ([]*entity.ItemRevision, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if u.revisionRepo == nil {
+		return nil, fmt.Errorf("revisions are not configured")
+	}
+
+	if _, err := u.findItem(ctx, id); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	revisions, err := u.revisionRepo.FindByItemID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve revisions: %w", err)
+	}
+	if revisions == nil {
+		revisions = []*entity.ItemRevision{}
+	}
+
+	return revisions, nil
+}
+
+// 指定した日（YYYY-MM-DD、UTC のその日の終わり時点）のアイテムの状態
+// その日までに保存された最後の版を返す（保険請求・確定申告などで当時の状態を確認するため）
+// 評価額はその日までに評価された最新のものを含める
+func (u *itemUsecase) GetItemAsOf(ctx context.Context, id int64, asOf string) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	date, err := entity.ParseDate(asOf)
+	if err != nil || date.IsZero() {
+		return nil, fmt.Errorf("%w: as_of must be in YYYY-MM-DD format", domainErrors.ErrInvalidInput)
+	}
+	if u.revisionRepo == nil {
+		return nil, fmt.Errorf("revisions are not configured")
+	}
+
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	revisions, err := u.revisionRepo.FindByItemID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve revisions: %w", err)
+	}
+
+	// 版は古い順のため、翌日より前に保存された最後の版が指定日の状態
+	// 日付の区切りは UTC とし、サーバーのタイムゾーンによって返す版が変わらないようにする
+	end := date.Time().AddDate(0, 0, 1)
+	var revision *entity.ItemRevision
+	for _, r := range revisions {
+		if !r.CreatedAt.Before(end) {
+			break
+		}
+		revision = r
+	}
+	if revision == nil {
+		return nil, fmt.Errorf("%w: no revision recorded on or before %s", domainErrors.ErrRevisionNotFound, date)
+	}
+
+	itemAsOf := revision.ItemAt(item)
+	if u.valuationRepo != nil {
+		valuations, err := u.valuationRepo.FindByItemID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve valuations: %w", err)
+		}
+		// 評価日の古い順のため、指定日以前の最後の評価額を使う
+		var latest *entity.Valuation
+		for _, valuation := range valuations {
+			if valuation.ValuatedAt.After(date) {
+				break
+			}
+			latest = valuation
+		}
+		itemAsOf.SetLatestValuation(latest)
+	}
+
+	return itemAsOf, nil
+}
+
+// アイテムを指定した版の状態に戻す
+// 戻した状態も新しい版として保存するため、戻す操作自体も取り消せる
+func (u *itemUsecase) RevertItem(ctx context.Context, id int64, version int) (*ItemResult, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if version <= 0 {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, "version must be a positive integer")
+	}
+	if u.revisionRepo == nil {
+		return nil, fmt.Errorf("revisions are not configured")
+	}
+
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	if err := ensureNotOnHold(item); err != nil {
+		return nil, err
+	}
+
+	revision, err := u.revisionRepo.FindByVersion(ctx, id, version)
+	if err != nil {
+		if domainErrors.IsRevisionNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve revision: %w", err)
+	}
+
+	before := entity.ItemAuditFields(item)
+	category, purchasePrice, purchaseDate := item.Category, item.PurchasePrice, item.PurchaseDate
+	if err := revision.Snapshot.ApplyTo(item, u.clock.Now()); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	// 更新と同じく、購入価格・カテゴリー・購入日が戻る場合のみ予算をチェックする
+	var warnings []string
+	if item.Category != category || item.PurchasePrice != purchasePrice || item.PurchaseDate != purchaseDate {
+		warnings, err = u.checkBudget(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var revertedItem *entity.Item
+	err = u.mutate(ctx, func(ctx context.Context) error {
+		revertedItem, err = u.itemRepo.Update(ctx, item)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to revert item: %w", err)
+		}
+		return u.audit(ctx, id, entity.AuditRevert, before, entity.ItemAuditFields(revertedItem))
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			u.evictItem(ctx, id)
+		}
+		return nil, err
+	}
+	u.cacheItem(ctx, revertedItem)
+	u.snapshot(ctx, revertedItem)
+	purgeItems(ctx, u.cachePurger, revertedItem)
+	// 変更は完了しているため、評価額やタグを取得できなくてもエラーにしない
+	_ = u.attachValuations(ctx, revertedItem)
+	_ = u.attachTags(ctx, revertedItem)
+
+	return &ItemResult{Item: revertedItem, Warnings: warnings}, nil
+}
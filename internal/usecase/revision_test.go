@@ -0,0 +1,379 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockItemRevisionRepository はアイテムの版のリポジトリのモック
+type MockItemRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemRevisionRepository) Create(ctx context.Context, revision *entity.ItemRevision) (*entity.ItemRevision, error) {
+	args := m.Called(ctx, revision)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemRevision), args.Error(1)
+}
+
+func (m *MockItemRevisionRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemRevision, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemRevision), args.Error(1)
+}
+
+func (m *MockItemRevisionRepository) FindByVersion(ctx context.Context, itemID int64, version int) (*entity.ItemRevision, error) {
+	args := m.Called(ctx, itemID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemRevision), args.Error(1)
+}
+
+func TestItemUsecase_Snapshot(t *testing.T) {
+	t.Run("正常系: 更新後の状態を版として保存", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		revisionRepo := new(MockItemRevisionRepository)
+
+		existing, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+		existing.ID = 1
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+		mockRepo.On("Update", mock.Anything, existing).Return(existing, nil)
+		revisionRepo.On("Create", mock.Anything, mock.MatchedBy(func(revision *entity.ItemRevision) bool {
+			return revision.ItemID == 1 && revision.Actor == "user-1" &&
+				revision.Snapshot.PurchasePrice == entity.JPY(1200000)
+		})).Return(&entity.ItemRevision{}, nil)
+
+		usecase := NewItemUsecase(mockRepo, WithRevisions(revisionRepo))
+		price := entity.JPY(1200000)
+		_, err := usecase.UpdateItem(WithActor(context.Background(), "user-1"), 1, UpdateItemInput{PurchasePrice: &price})
+
+		require.NoError(t, err)
+		revisionRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 保存に失敗しても操作は成功", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		revisionRepo := new(MockItemRevisionRepository)
+
+		existing, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+		existing.ID = 1
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+		mockRepo.On("Update", mock.Anything, existing).Return(existing, nil)
+		revisionRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+
+		usecase := NewItemUsecase(mockRepo, WithRevisions(revisionRepo))
+		name := "時計2"
+		_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Name: &name})
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestItemUsecase_RevertItem(t *testing.T) {
+	original := entity.ItemSnapshot{
+		Name:          "時計1",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: entity.JPY(1000000),
+		PurchaseDate:  entity.MustParseDate("2023-01-01"),
+	}
+
+	newItem := func() *entity.Item {
+		item, _ := entity.NewItem("時計2", "時計", "ROLEX", entity.JPY(1500000), entity.MustParseDate("2023-01-01"), time.Now())
+		item.ID = 1
+		return item
+	}
+
+	tests := []struct {
+		name        string
+		version     int
+		setupMock   func(*MockItemRepository, *MockItemRevisionRepository)
+		expected    *entity.ItemSnapshot
+		expectedErr error
+	}{
+		{
+			name:    "正常系: 指定した版の状態に戻し、新しい版として保存",
+			version: 1,
+			setupMock: func(itemRepo *MockItemRepository, revisionRepo *MockItemRevisionRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+				revisionRepo.On("FindByVersion", mock.Anything, int64(1), 1).
+					Return(&entity.ItemRevision{ItemID: 1, Version: 1, Snapshot: original}, nil)
+				itemRepo.On("Update", mock.Anything, mock.Anything).Return(func() *entity.Item {
+					item := newItem()
+					_ = original.ApplyTo(item, time.Now())
+					return item
+				}(), nil)
+				revisionRepo.On("Create", mock.Anything, mock.MatchedBy(func(revision *entity.ItemRevision) bool {
+					return revision.Snapshot.Name == "時計1"
+				})).Return(&entity.ItemRevision{Version: 3}, nil)
+			},
+			expected: &original,
+		},
+		{
+			name:    "異常系: 存在しない版",
+			version: 9,
+			setupMock: func(itemRepo *MockItemRepository, revisionRepo *MockItemRevisionRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+				revisionRepo.On("FindByVersion", mock.Anything, int64(1), 9).Return(nil, domainErrors.ErrRevisionNotFound)
+			},
+			expectedErr: domainErrors.ErrRevisionNotFound,
+		},
+		{
+			name:        "異常系: バージョンが0以下",
+			version:     0,
+			setupMock:   func(*MockItemRepository, *MockItemRevisionRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:    "異常系: 保全中のアイテム",
+			version: 1,
+			setupMock: func(itemRepo *MockItemRepository, _ *MockItemRevisionRepository) {
+				item := newItem()
+				item.OnHold = true
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			expectedErr: domainErrors.ErrItemOnHold,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			revisionRepo := new(MockItemRevisionRepository)
+			tt.setupMock(mockRepo, revisionRepo)
+
+			usecase := NewItemUsecase(mockRepo, WithRevisions(revisionRepo))
+			result, err := usecase.RevertItem(context.Background(), 1, tt.version)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, *tt.expected, entity.NewItemSnapshot(result.Item))
+			revisionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_RevertItem_Budget(t *testing.T) {
+	// 購入価格を 1,000,000 円に下げたアイテムを、1,500,000 円だった版に戻す
+	expensive := entity.ItemSnapshot{
+		Name:          "時計1",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: entity.JPY(1500000),
+		PurchaseDate:  entity.MustParseDate("2023-01-01"),
+	}
+	newItem := func() *entity.Item {
+		item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+		item.ID = 1
+		return item
+	}
+	budget := &entity.CategoryBudget{Category: "時計", Amount: entity.JPY(2000000)}
+
+	tests := []struct {
+		name             string
+		enforcement      BudgetEnforcement
+		expectedWarnings int
+		expectedErr      error
+	}{
+		{
+			name:             "正常系: warn の場合は警告を付けて戻す",
+			enforcement:      BudgetWarn,
+			expectedWarnings: 1,
+		},
+		{
+			name:        "異常系: block の場合は予算を超える版には戻さない",
+			enforcement: BudgetBlock,
+			expectedErr: domainErrors.ErrBudgetExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			revisionRepo := new(MockItemRevisionRepository)
+			budgetRepo := new(MockBudgetRepository)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+			revisionRepo.On("FindByVersion", mock.Anything, int64(1), 1).
+				Return(&entity.ItemRevision{ItemID: 1, Version: 1, Snapshot: expensive}, nil)
+			budgetRepo.On("FindByCategory", mock.Anything, "時計").Return(budget, nil)
+			itemRepo.On("SumPurchasePrice", mock.Anything, "時計", "JPY", 2023, int64(1)).Return(int64(1000000), nil)
+			if tt.expectedErr == nil {
+				itemRepo.On("Update", mock.Anything, mock.Anything).Return(newItem(), nil)
+				revisionRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.ItemRevision{Version: 3}, nil)
+			}
+
+			usecase := NewItemUsecase(itemRepo, WithRevisions(revisionRepo), WithBudgetCheck(budgetRepo, tt.enforcement))
+			result, err := usecase.RevertItem(context.Background(), 1, 1)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, result.Warnings, tt.expectedWarnings)
+		})
+	}
+}
+
+func TestItemUsecase_GetItemAsOf(t *testing.T) {
+	revisionAt := func(version int, name string, price int64, createdAt string) *entity.ItemRevision {
+		at, _ := time.Parse("2006-01-02 15:04", createdAt)
+		return &entity.ItemRevision{
+			ItemID:  1,
+			Version: version,
+			Snapshot: entity.ItemSnapshot{
+				Name:          name,
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: entity.JPY(price),
+				PurchaseDate:  entity.MustParseDate("2023-01-01"),
+			},
+			CreatedAt: at,
+		}
+	}
+	revisions := []*entity.ItemRevision{
+		revisionAt(1, "時計1", 1000000, "2023-01-01 10:00"),
+		revisionAt(2, "時計2", 1200000, "2023-06-01 23:30"),
+		revisionAt(3, "時計3", 1500000, "2023-09-01 09:00"),
+	}
+	valuations := []*entity.Valuation{
+		{ItemID: 1, ValuatedAt: entity.MustParseDate("2023-03-01"), Value: entity.JPY(1100000)},
+		{ItemID: 1, ValuatedAt: entity.MustParseDate("2023-08-01"), Value: entity.JPY(1300000)},
+	}
+
+	tests := []struct {
+		name              string
+		asOf              string
+		expectedName      string
+		expectedValuation *entity.Money
+		expectedErr       error
+	}{
+		{
+			name:              "正常系: その日の終わりまでに保存された最後の版",
+			asOf:              "2023-06-01",
+			expectedName:      "時計2",
+			expectedValuation: &valuations[0].Value,
+		},
+		{
+			name:              "正常系: 指定日に評価した評価額を含める",
+			asOf:              "2023-03-01",
+			expectedName:      "時計1",
+			expectedValuation: &valuations[0].Value,
+		},
+		{
+			name:              "正常系: 最新の版より後の日付",
+			asOf:              "2024-01-01",
+			expectedName:      "時計3",
+			expectedValuation: &valuations[1].Value,
+		},
+		{
+			name:        "異常系: 最初の版より前の日付",
+			asOf:        "2022-12-31",
+			expectedErr: domainErrors.ErrRevisionNotFound,
+		},
+		{
+			name:        "異常系: 日付の形式が不正",
+			asOf:        "2023/06/01",
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			revisionRepo := new(MockItemRevisionRepository)
+			valuationRepo := new(MockValuationRepository)
+
+			current, _ := entity.NewItem("時計3", "時計", "ROLEX", entity.JPY(1500000), entity.MustParseDate("2023-01-01"), time.Now())
+			current.ID = 1
+			mockRepo.On("FindByID", mock.Anything, int64(1)).Return(current, nil)
+			revisionRepo.On("FindByItemID", mock.Anything, int64(1)).Return(revisions, nil)
+			valuationRepo.On("FindByItemID", mock.Anything, int64(1)).Return(valuations, nil)
+
+			usecase := NewItemUsecase(mockRepo, WithRevisions(revisionRepo), WithValuations(valuationRepo))
+			item, err := usecase.GetItemAsOf(context.Background(), 1, tt.asOf)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, item)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, item.Name)
+			require.NotNil(t, item.LatestValuation)
+			assert.Equal(t, *tt.expectedValuation, item.LatestValuation.Value)
+			// 現在のアイテムは変更しない
+			assert.Equal(t, "時計3", current.Name)
+		})
+	}
+}
+
+func TestItemUsecase_GetItemAsOf_DayBoundary(t *testing.T) {
+	// データベースのドライバーがサーバーのタイムゾーン（JST）で返した保存時刻
+	jst := time.FixedZone("JST", 9*60*60)
+	revisionAt := func(version int, name string, createdAt time.Time) *entity.ItemRevision {
+		return &entity.ItemRevision{
+			ItemID:  1,
+			Version: version,
+			Snapshot: entity.ItemSnapshot{
+				Name:          name,
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: entity.JPY(1000000),
+				PurchaseDate:  entity.MustParseDate("2023-01-01"),
+			},
+			CreatedAt: createdAt,
+		}
+	}
+	revisions := []*entity.ItemRevision{
+		revisionAt(1, "時計1", time.Date(2023, 6, 1, 8, 0, 0, 0, jst)), // 2023-05-31 23:00 UTC
+		revisionAt(2, "時計2", time.Date(2023, 6, 1, 23, 59, 0, 0, time.UTC)),
+		revisionAt(3, "時計3", time.Date(2023, 6, 2, 9, 1, 0, 0, jst)), // 2023-06-02 00:01 UTC
+	}
+
+	tests := []struct {
+		name         string
+		asOf         string
+		expectedName string
+	}{
+		{name: "正常系: UTC の前日の終わりまでに保存された版", asOf: "2023-05-31", expectedName: "時計1"},
+		{name: "正常系: UTC の0時の直前に保存された版はその日に含める", asOf: "2023-06-01", expectedName: "時計2"},
+		{name: "正常系: UTC の0時の直後に保存された版は翌日に含める", asOf: "2023-06-02", expectedName: "時計3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			revisionRepo := new(MockItemRevisionRepository)
+
+			current, _ := entity.NewItem("時計3", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+			current.ID = 1
+			mockRepo.On("FindByID", mock.Anything, int64(1)).Return(current, nil)
+			revisionRepo.On("FindByItemID", mock.Anything, int64(1)).Return(revisions, nil)
+
+			usecase := NewItemUsecase(mockRepo, WithRevisions(revisionRepo))
+			item, err := usecase.GetItemAsOf(context.Background(), 1, tt.asOf)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, item.Name)
+		})
+	}
+}
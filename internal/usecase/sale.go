@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type SellItemInput struct {
+	SalePrice entity.Money  `json:"sale_price"`
+	SaleDate  entity.Date   `json:"sale_date"`      // 未指定の場合は当日
+	Fees      *entity.Money `json:"fees,omitempty"` // 未指定の場合は手数料なし
+}
+
+type SaleResult struct {
+	*entity.Sale
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// アイテムの売却の記録を有効にする（売却の記録とアイテムの売却済みの設定は transactor の1つのトランザクションで行う）
+func WithSales(saleRepo SaleRepository, transactor Transactor) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.saleRepo = saleRepo
+		u.saleTransactor = transactor
+	}
+}
+
+// アイテムの売却を記録し、アイテムを売却済みにする
+// 外貨建ての売却で売却日時点の為替レートを取得できない場合は、円換算額なしで記録して警告を返す
+func (u *itemUsecase) SellItem(ctx context.Context, id int64, input SellItemInput) (*SaleResult, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	if u.saleRepo == nil {
+		return nil, fmt.Errorf("sales are not configured")
+	}
+
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	if err := ensureNotOnHold(item); err != nil {
+		return nil, err
+	}
+	if item.Sold {
+		return nil, domainErrors.ErrItemSold
+	}
+
+	saleDate := input.SaleDate
+	if saleDate.IsZero() {
+		saleDate = entity.DateOf(u.clock.Now())
+	}
+	sale, err := entity.NewSale(item, input.SalePrice, saleDate, input.Fees, u.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	warnings := u.applySaleExchangeRate(ctx, sale)
+
+	before := entity.ItemAuditFields(item)
+	item.MarkSold(u.clock.Now())
+
+	var created *entity.Sale
+	err = u.mutate(ctx, func(ctx context.Context) error {
+		// 売却の記録とアイテムの売却済みは一緒に確定する（一時的なエラーの場合はトランザクションごと再試行する）
+		err := u.retryPolicy.do(ctx, func() error {
+			return runInTransaction(ctx, u.saleTransactor, func(ctx context.Context) error {
+				var err error
+				created, err = u.saleRepo.Create(ctx, sale)
+				if err != nil {
+					// 同時に売却を記録した場合
+					if errors.Is(err, domainErrors.ErrDuplicateEntry) {
+						return domainErrors.ErrItemSold
+					}
+					return fmt.Errorf("failed to create sale: %w", err)
+				}
+				if err := u.itemRepo.SetSold(ctx, id, item.UpdatedAt); err != nil {
+					if domainErrors.IsNotFoundError(err) {
+						return domainErrors.ErrItemNotFound
+					}
+					return fmt.Errorf("failed to mark item as sold: %w", err)
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+		return u.audit(ctx, id, entity.AuditSell, before, entity.ItemAuditFields(item))
+	})
+	u.evictItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	purgeItems(ctx, u.cachePurger, item)
+
+	return &SaleResult{Sale: created, Warnings: warnings}, nil
+}
+
+// 外貨建ての売却に売却日時点の為替レートを設定する（取得できない場合は警告を返す）
+func (u *itemUsecase) applySaleExchangeRate(ctx context.Context, sale *entity.Sale) []string {
+	if u.fxProvider == nil || !sale.NeedsExchangeRate() {
+		return nil
+	}
+
+	rate, err := u.fxProvider.Rate(ctx, sale.SalePrice.Currency, sale.SaleDate)
+	if err == nil {
+		err = sale.SetExchangeRate(rate)
+	}
+	if err != nil {
+		return []string{fmt.Sprintf("exchange rate for %s on %s is unavailable: %s", sale.SalePrice.Currency, sale.SaleDate, err.Error())}
+	}
+	return nil
+}
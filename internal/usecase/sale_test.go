@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockSaleRepository は売却の記録のモックリポジトリ
+type MockSaleRepository struct {
+	mock.Mock
+}
+
+func (m *MockSaleRepository) Create(ctx context.Context, sale *entity.Sale) (*entity.Sale, error) {
+	args := m.Called(ctx, sale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	// 受け取った売却から戻り値を作る場合
+	if fn, ok := args.Get(0).(func(*entity.Sale) *entity.Sale); ok {
+		return fn(sale), args.Error(1)
+	}
+	return args.Get(0).(*entity.Sale), args.Error(1)
+}
+
+func (m *MockSaleRepository) GetProfitLossTotals(ctx context.Context, period entity.ProfitLossPeriod, from, to string) ([]entity.ProfitLossTotal, error) {
+	args := m.Called(ctx, period, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ProfitLossTotal), args.Error(1)
+}
+
+func TestItemUsecase_SellItem(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	newItem := func() *entity.Item {
+		item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+		item.ID = 1
+		return item
+	}
+	created := func(sale *entity.Sale) *entity.Sale {
+		copied := *sale
+		copied.ID = 5
+		return &copied
+	}
+	fees := entity.JPY(50000)
+
+	tests := []struct {
+		name         string
+		input        SellItemInput
+		item         func() *entity.Item
+		setupMock    func(*MockItemRepository, *MockSaleRepository)
+		expectedErr  error
+		expectedGain int64
+	}{
+		{
+			name:  "正常系: 売却を記録してアイテムを売却済みにする",
+			input: SellItemInput{SalePrice: entity.JPY(1200000), SaleDate: entity.MustParseDate("2026-09-01"), Fees: &fees},
+			item:  newItem,
+			setupMock: func(itemRepo *MockItemRepository, saleRepo *MockSaleRepository) {
+				saleRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Sale")).Return(created, nil)
+				itemRepo.On("SetSold", mock.Anything, int64(1), mock.Anything).Return(nil)
+			},
+			expectedGain: 150000,
+		},
+		{
+			name:  "異常系: 売却済み",
+			input: SellItemInput{SalePrice: entity.JPY(1200000)},
+			item: func() *entity.Item {
+				item := newItem()
+				item.Sold = true
+				return item
+			},
+			setupMock:   func(itemRepo *MockItemRepository, saleRepo *MockSaleRepository) {},
+			expectedErr: domainErrors.ErrItemSold,
+		},
+		{
+			name:  "異常系: 同時に売却を記録した",
+			input: SellItemInput{SalePrice: entity.JPY(1200000)},
+			item:  newItem,
+			setupMock: func(itemRepo *MockItemRepository, saleRepo *MockSaleRepository) {
+				saleRepo.On("Create", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, domainErrors.ErrDuplicateEntry))
+			},
+			expectedErr: domainErrors.ErrItemSold,
+		},
+		{
+			name:  "異常系: 保全中",
+			input: SellItemInput{SalePrice: entity.JPY(1200000)},
+			item: func() *entity.Item {
+				item := newItem()
+				item.OnHold = true
+				return item
+			},
+			setupMock:   func(itemRepo *MockItemRepository, saleRepo *MockSaleRepository) {},
+			expectedErr: domainErrors.ErrItemOnHold,
+		},
+		{
+			name:        "異常系: 売却日が未来",
+			input:       SellItemInput{SalePrice: entity.JPY(1200000), SaleDate: entity.MustParseDate("2026-10-02")},
+			item:        newItem,
+			setupMock:   func(itemRepo *MockItemRepository, saleRepo *MockSaleRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			saleRepo := new(MockSaleRepository)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(tt.item(), nil)
+			tt.setupMock(itemRepo, saleRepo)
+			transactor := &fakeTransactor{}
+			usecase := NewItemUsecase(itemRepo, WithSales(saleRepo, transactor), WithClock(entity.FixedClock(now)))
+
+			result, err := usecase.SellItem(context.Background(), 1, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(5), result.ID)
+				assert.Equal(t, entity.JPY(tt.expectedGain), *result.RealizedGainJPY)
+				assert.False(t, transactor.rolledBack)
+			}
+			itemRepo.AssertExpectations(t)
+			saleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_SellItem_RetryTransaction(t *testing.T) {
+	item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+	item.ID = 1
+
+	// デッドロックでロールバックされた場合、売却済みにする文だけでなく売却の記録からやり直す
+	itemRepo := new(MockItemRepository)
+	saleRepo := new(MockSaleRepository)
+	itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+	saleRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Sale")).Return(func(sale *entity.Sale) *entity.Sale {
+		copied := *sale
+		copied.ID = 5
+		return &copied
+	}, nil).Twice()
+	itemRepo.On("SetSold", mock.Anything, int64(1), mock.Anything).Return(errTransient).Once()
+	itemRepo.On("SetSold", mock.Anything, int64(1), mock.Anything).Return(nil).Once()
+	transactor := &fakeTransactor{}
+	usecase := NewItemUsecase(itemRepo, WithSales(saleRepo, transactor), WithRetryPolicy(noDelayRetryPolicy))
+
+	result, err := usecase.SellItem(context.Background(), 1, SellItemInput{SalePrice: entity.JPY(1200000)})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), result.ID)
+	assert.Equal(t, 2, transactor.calls)
+	itemRepo.AssertExpectations(t)
+	saleRepo.AssertExpectations(t)
+}
+
+func TestItemUsecase_SellItem_ExchangeRate(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	input := SellItemInput{SalePrice: entity.Money{Amount: 800000, Currency: "USD"}}
+
+	tests := []struct {
+		name             string
+		rate             string
+		rateErr          error
+		expectedGain     *entity.Money
+		expectedWarnings int
+	}{
+		{name: "正常系: 売却日の為替レートで円換算する", rate: "150", expectedGain: &entity.Money{Amount: 200000, Currency: "JPY"}},
+		{name: "正常系: 為替レートを取得できない場合は円換算せずに警告", rateErr: errors.New("timeout"), expectedWarnings: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			saleRepo := new(MockSaleRepository)
+			fx := new(MockExchangeRateProvider)
+			item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+			item.ID = 1
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			itemRepo.On("SetSold", mock.Anything, int64(1), mock.Anything).Return(nil)
+			saleRepo.On("Create", mock.Anything, mock.Anything).Return(func(sale *entity.Sale) *entity.Sale { return sale }, nil)
+			fx.On("Rate", mock.Anything, "USD", entity.DateOf(now)).Return(tt.rate, tt.rateErr)
+			usecase := NewItemUsecase(itemRepo, WithSales(saleRepo, &fakeTransactor{}), WithExchangeRateProvider(fx), WithClock(entity.FixedClock(now)))
+
+			result, err := usecase.SellItem(context.Background(), 1, input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedGain, result.RealizedGainJPY)
+			assert.Len(t, result.Warnings, tt.expectedWarnings)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 定期実行の処理の名前（実行の履歴に記録する）
+const ScheduledValuationRefresh = JobValuationRefresh
+
+// 実行の履歴で返す件数のデフォルトと上限
+const (
+	DefaultJobRunLimit = 20
+	MaxJobRunLimit     = 100
+)
+
+// 定期実行で1回に読み込むアイテム数
+const scheduledJobBatchSize = 100
+
+// 設定した実行予定（cron 形式）に従って実行する処理
+type ScheduledJobUsecase interface {
+	// 全アイテムの評価額を市場価格で更新し、集計を記録する
+	// 同じ実行予定の日時の実行がすでに記録されている場合（他のサーバーが実行した場合）は実行せずに nil を返す
+	RunValuationRefresh(ctx context.Context, schedule string, scheduledAt time.Time) (*entity.JobRun, error)
+	// 実行の履歴（新しい順）
+	ListRuns(ctx context.Context, limit int) ([]*entity.JobRun, error)
+}
+
+type scheduledJobUsecase struct {
+	itemRepo         ItemRepository
+	jobRunRepo       JobRunRepository
+	valuationUsecase ValuationUsecase
+	readOnly         *ReadOnlySwitch
+	clock            entity.Clock
+}
+
+// ScheduledJobUsecaseの任意の設定を指定するオプション
+type ScheduledJobUsecaseOption func(*scheduledJobUsecase)
+
+// 読み取り専用モードのスイッチを指定
+func WithScheduledJobReadOnlySwitch(readOnly *ReadOnlySwitch) ScheduledJobUsecaseOption {
+	return func(u *scheduledJobUsecase) {
+		u.readOnly = readOnly
+	}
+}
+
+// 現在時刻の取得元を指定（デフォルトはシステムの時刻）
+func WithScheduledJobClock(clock entity.Clock) ScheduledJobUsecaseOption {
+	return func(u *scheduledJobUsecase) {
+		u.clock = clock
+	}
+}
+
+func NewScheduledJobUsecase(itemRepo ItemRepository, jobRunRepo JobRunRepository, valuationUsecase ValuationUsecase, opts ...ScheduledJobUsecaseOption) ScheduledJobUsecase {
+	u := &scheduledJobUsecase{
+		itemRepo:         itemRepo,
+		jobRunRepo:       jobRunRepo,
+		valuationUsecase: valuationUsecase,
+		readOnly:         NewReadOnlySwitch(false),
+		clock:            entity.SystemClock,
+	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
+}
+
+// 下書きのアイテムは対象外とする。個別のアイテムの失敗は集計に記録して続ける
+func (u *scheduledJobUsecase) RunValuationRefresh(ctx context.Context, schedule string, scheduledAt time.Time) (*entity.JobRun, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+
+	run, err := u.jobRunRepo.Create(ctx, entity.NewJobRun(ScheduledValuationRefresh, schedule, scheduledAt, u.clock.Now()))
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrDuplicateEntry) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to create job run: %w", err)
+	}
+
+	runErr := u.refreshAll(ctx, run)
+	message := ""
+	if runErr != nil {
+		message = truncateJobError(runErr.Error())
+	}
+	run.Finish(message, u.clock.Now())
+
+	// サーバーの停止で中断した場合も集計を記録する
+	if err := u.jobRunRepo.Update(context.WithoutCancel(ctx), run); err != nil {
+		return run, fmt.Errorf("failed to update job run: %w", err)
+	}
+	return run, runErr
+}
+
+func (u *scheduledJobUsecase) refreshAll(ctx context.Context, run *entity.JobRun) error {
+	query := entity.ItemQuery{
+		Limit: scheduledJobBatchSize,
+		Sort:  entity.SortByID,
+		Order: entity.SortAsc,
+	}
+	for {
+		items, err := u.itemRepo.FindAll(ctx, query)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve items: %w", err)
+		}
+
+		for _, item := range items {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if item.Draft {
+				run.RecordSkip()
+				continue
+			}
+			if _, err := u.valuationUsecase.RefreshItemValuation(ctx, item.ID); err != nil {
+				run.RecordFailure(item.ID, truncateJobError(err.Error()))
+				continue
+			}
+			run.RecordSuccess()
+		}
+
+		if len(items) < scheduledJobBatchSize {
+			return nil
+		}
+		query.AfterID = items[len(items)-1].ID
+	}
+}
+
+func (u *scheduledJobUsecase) ListRuns(ctx context.Context, limit int) ([]*entity.JobRun, error) {
+	if limit == 0 {
+		limit = DefaultJobRunLimit
+	}
+	if limit < 0 || limit > MaxJobRunLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domainErrors.ErrInvalidInput, MaxJobRunLimit)
+	}
+
+	runs, err := u.jobRunRepo.FindRecent(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve job runs: %w", err)
+	}
+	return runs, nil
+}
+
+// 記録するエラーを最大文字数で切り詰める
+func truncateJobError(message string) string {
+	if len(message) > maxJobErrorLength {
+		return message[:maxJobErrorLength]
+	}
+	return message
+}
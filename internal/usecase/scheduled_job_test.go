@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// メモリ上の定期実行の履歴（同じ処理・同じ実行予定の日時は重複エラーにする）
+type fakeJobRunRepository struct {
+	runs []*entity.JobRun
+}
+
+func (r *fakeJobRunRepository) Create(ctx context.Context, run *entity.JobRun) (*entity.JobRun, error) {
+	for _, existing := range r.runs {
+		if existing.Name == run.Name && existing.ScheduledAt.Equal(run.ScheduledAt) {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, domainErrors.ErrDuplicateEntry)
+		}
+	}
+	created := *run
+	created.ID = int64(len(r.runs) + 1)
+	r.runs = append(r.runs, &created)
+	copied := created
+	return &copied, nil
+}
+
+func (r *fakeJobRunRepository) Update(ctx context.Context, run *entity.JobRun) error {
+	for i, existing := range r.runs {
+		if existing.ID == run.ID {
+			copied := *run
+			r.runs[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (r *fakeJobRunRepository) FindRecent(ctx context.Context, limit int) ([]*entity.JobRun, error) {
+	var runs []*entity.JobRun
+	for i := len(r.runs) - 1; i >= 0 && len(runs) < limit; i-- {
+		runs = append(runs, r.runs[i])
+	}
+	return runs, nil
+}
+
+// 評価額の更新のみを行う（アイテムごとのエラーを指定できる）
+type fakeValuationRefresher struct {
+	ValuationUsecase
+	errs      map[int64]error
+	refreshed []int64
+}
+
+func (f *fakeValuationRefresher) RefreshItemValuation(ctx context.Context, itemID int64) (*entity.Valuation, error) {
+	if err := f.errs[itemID]; err != nil {
+		return nil, err
+	}
+	f.refreshed = append(f.refreshed, itemID)
+	return &entity.Valuation{ItemID: itemID}, nil
+}
+
+func TestScheduledJobUsecase_RunValuationRefresh(t *testing.T) {
+	now := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: 全アイテムを順に更新し、集計を記録する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		firstPage := make([]*entity.Item, scheduledJobBatchSize)
+		for i := range firstPage {
+			firstPage[i] = &entity.Item{ID: int64(i + 1)}
+		}
+		firstPage[1].Draft = true
+		itemRepo.On("FindAll", mock.Anything, mock.MatchedBy(func(q entity.ItemQuery) bool {
+			return q.Sort == entity.SortByID && q.Order == entity.SortAsc && q.AfterID == 0
+		})).Return(firstPage, nil)
+		itemRepo.On("FindAll", mock.Anything, mock.MatchedBy(func(q entity.ItemQuery) bool {
+			return q.AfterID == int64(scheduledJobBatchSize)
+		})).Return([]*entity.Item{{ID: 101}}, nil)
+
+		runRepo := &fakeJobRunRepository{}
+		refresher := &fakeValuationRefresher{errs: map[int64]error{
+			3: fmt.Errorf("%w: timeout", domainErrors.ErrMarketPriceUnavailable),
+		}}
+		usecase := NewScheduledJobUsecase(itemRepo, runRepo, refresher, WithScheduledJobClock(entity.FixedClock(now)))
+
+		run, err := usecase.RunValuationRefresh(context.Background(), "0 3 * * *", now)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.JobRunSucceeded, run.Status)
+		assert.Equal(t, 101, run.Total)
+		assert.Equal(t, 99, run.Succeeded)
+		assert.Equal(t, 1, run.Skipped)
+		assert.Equal(t, 1, run.Failed)
+		require.Len(t, run.Errors, 1)
+		assert.Equal(t, int64(3), run.Errors[0].ItemID)
+		assert.Contains(t, run.Errors[0].Error, "timeout")
+		assert.Len(t, refresher.refreshed, 99)
+
+		// 記録した集計
+		require.Len(t, runRepo.runs, 1)
+		assert.Equal(t, ScheduledValuationRefresh, runRepo.runs[0].Name)
+		assert.Equal(t, "0 3 * * *", runRepo.runs[0].Schedule)
+		assert.Equal(t, 99, runRepo.runs[0].Succeeded)
+		assert.NotNil(t, runRepo.runs[0].FinishedAt)
+	})
+
+	t.Run("正常系: 同じ実行予定の日時の実行がすでにある場合は実行しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		runRepo := &fakeJobRunRepository{}
+		_, err := runRepo.Create(context.Background(), entity.NewJobRun(ScheduledValuationRefresh, "0 3 * * *", now, now))
+		require.NoError(t, err)
+
+		usecase := NewScheduledJobUsecase(itemRepo, runRepo, &fakeValuationRefresher{})
+		run, err := usecase.RunValuationRefresh(context.Background(), "0 3 * * *", now)
+
+		require.NoError(t, err)
+		assert.Nil(t, run)
+		itemRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: アイテムを取得できない場合は中断として記録する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item(nil), domainErrors.ErrDatabaseError)
+		runRepo := &fakeJobRunRepository{}
+
+		usecase := NewScheduledJobUsecase(itemRepo, runRepo, &fakeValuationRefresher{})
+		run, err := usecase.RunValuationRefresh(context.Background(), "0 3 * * *", now)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		require.NotNil(t, run)
+		assert.Equal(t, entity.JobRunFailed, run.Status)
+		require.Len(t, runRepo.runs, 1)
+		assert.Equal(t, entity.JobRunFailed, runRepo.runs[0].Status)
+		assert.NotEmpty(t, runRepo.runs[0].Error)
+	})
+
+	t.Run("異常系: 読み取り専用モード", func(t *testing.T) {
+		runRepo := &fakeJobRunRepository{}
+		usecase := NewScheduledJobUsecase(new(MockItemRepository), runRepo, &fakeValuationRefresher{}, WithScheduledJobReadOnlySwitch(NewReadOnlySwitch(true)))
+
+		_, err := usecase.RunValuationRefresh(context.Background(), "0 3 * * *", now)
+
+		assert.ErrorIs(t, err, domainErrors.ErrReadOnly)
+		assert.Empty(t, runRepo.runs)
+	})
+}
+
+func TestScheduledJobUsecase_ListRuns(t *testing.T) {
+	runRepo := &fakeJobRunRepository{}
+	start := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		scheduledAt := start.AddDate(0, 0, i)
+		_, err := runRepo.Create(context.Background(), entity.NewJobRun(ScheduledValuationRefresh, "0 3 * * *", scheduledAt, scheduledAt))
+		require.NoError(t, err)
+	}
+	usecase := NewScheduledJobUsecase(new(MockItemRepository), runRepo, &fakeValuationRefresher{})
+
+	tests := []struct {
+		name    string
+		limit   int
+		wantLen int
+		wantErr bool
+	}{
+		{name: "正常系: 省略時はデフォルトの件数まで", limit: 0, wantLen: 3},
+		{name: "正常系: 件数を指定", limit: 2, wantLen: 2},
+		{name: "異常系: 上限を超える件数", limit: MaxJobRunLimit + 1, wantErr: true},
+		{name: "異常系: 負の件数", limit: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs, err := usecase.ListRuns(context.Background(), tt.limit)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, runs, tt.wantLen)
+			// 新しい順
+			assert.Equal(t, start.AddDate(0, 0, 2), runs[0].ScheduledAt)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 外部の検索エンジンで件数を返す項目
+var SearchFacets = []string{"category", "brand", "currency"}
+
+// 検索インデックスを作り直すときに一度に登録するアイテム数
+const SearchReindexBatchSize = 500
+
+// 外部の全文検索エンジン（Meilisearch など）
+// 表記の揺れ・誤字を許容し、関連度の順に並べる検索に使う
+type SearchIndex interface {
+	// Search returns the IDs of matching items ranked by relevance (or by the requested sort),
+	// the number of matches and the number of matches per facet value
+	Search(ctx context.Context, query SearchIndexQuery) (*SearchIndexResult, error)
+
+	// Upsert adds or replaces the documents of the items
+	Upsert(ctx context.Context, items []*entity.Item) error
+
+	// Delete removes the documents of the items
+	Delete(ctx context.Context, ids []int64) error
+}
+
+// 検索エンジンへの検索条件（Sort が空の場合は関連度の順）
+type SearchIndexQuery struct {
+	Keyword  string
+	Category string
+	Limit    int
+	Offset   int
+	Sort     entity.ItemSortKey
+	Order    entity.SortOrder
+	Facets   []string
+}
+
+type SearchIndexResult struct {
+	IDs    []int64
+	Total  int
+	Facets map[string]map[string]int
+}
+
+// キーワード検索に外部の検索エンジンを使う
+// 検索エンジンに障害がある場合や、検索エンジンで扱えない条件（タグ・削除済みを含む・変更の通し番号）の場合はSQLで検索する
+func WithSearchIndex(index SearchIndex) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.searchIndex = index
+	}
+}
+
+// 検索エンジンで検索する。検索エンジンを使えない場合は false を返す
+func (u *itemUsecase) searchWithIndex(ctx context.Context, keyword string, input ListItemsInput) (*ItemList, bool) {
+	if input.Tag != "" || input.IncludeDeleted || input.ChangedAfter > 0 || input.Sort == string(entity.SortByChangeSeq) {
+		return nil, false
+	}
+	query, err := newItemQuery(keyword, input)
+	if err != nil {
+		// 条件の誤りはSQLでの検索で返す
+		return nil, false
+	}
+
+	indexQuery := SearchIndexQuery{
+		Keyword:  keyword,
+		Category: query.Category,
+		Limit:    query.Limit,
+		Offset:   query.Offset,
+		Facets:   SearchFacets,
+	}
+	if input.Sort != "" {
+		indexQuery.Sort = query.Sort
+		indexQuery.Order = query.Order
+	}
+
+	result, err := u.searchIndex.Search(ctx, indexQuery)
+	if err != nil {
+		return nil, false
+	}
+
+	items := []*entity.Item{}
+	if len(result.IDs) > 0 {
+		found, err := u.itemRepo.FindAll(ctx, entity.ItemQuery{IDs: result.IDs, Limit: len(result.IDs), Sort: entity.SortByID})
+		if err != nil {
+			return nil, false
+		}
+		// 検索エンジンの順に並べる（インデックスの更新前に削除されたアイテムは除く）
+		byID := make(map[int64]*entity.Item, len(found))
+		for _, item := range found {
+			byID[item.ID] = item
+		}
+		for _, id := range result.IDs {
+			if item, ok := byID[id]; ok {
+				items = append(items, item)
+			}
+		}
+	}
+	if err := u.attachValuations(ctx, items...); err != nil {
+		return nil, false
+	}
+	if err := u.attachTags(ctx, items...); err != nil {
+		return nil, false
+	}
+
+	return &ItemList{
+		Items:  items,
+		Total:  result.Total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+		Facets: result.Facets,
+	}, true
+}
+
+// アイテムの変更イベントを受けて検索インデックスを更新する
+// 最新の状態を読み直して登録するため、イベントの順序が前後してもインデックスは最新の状態になる
+type SearchIndexer struct {
+	index    SearchIndex
+	itemRepo ItemRepository
+}
+
+func NewSearchIndexer(index SearchIndex, itemRepo ItemRepository) *SearchIndexer {
+	return &SearchIndexer{index: index, itemRepo: itemRepo}
+}
+
+// 更新に失敗した場合、インデックスは次の変更か作り直しまで古いままになる（検索結果は取得時にDBの最新の状態になる）
+// アウトボックスを使う場合は、エラーを返したイベントを再送する
+func (s *SearchIndexer) HandleItemEvent(ctx context.Context, event ItemEvent) error {
+	item, err := s.itemRepo.FindByID(ctx, event.ItemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return s.index.Delete(ctx, []int64{event.ItemID})
+		}
+		return fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	return s.index.Upsert(ctx, []*entity.Item{item})
+}
+
+// 検索インデックスの作り直しの結果
+type SearchReindexResult struct {
+	Indexed int `json:"indexed"`
+}
+
+// 削除されていないすべてのアイテムを検索インデックスに登録する（検索エンジンの導入時・障害からの復旧時用）
+func (s *SearchIndexer) Reindex(ctx context.Context) (*SearchReindexResult, error) {
+	result := &SearchReindexResult{}
+	query := entity.ItemQuery{
+		Limit: SearchReindexBatchSize,
+		Sort:  entity.SortByID,
+		Order: entity.SortAsc,
+	}
+	for {
+		items, err := s.itemRepo.FindAll(ctx, query)
+		if err != nil {
+			return result, fmt.Errorf("failed to retrieve items: %w", err)
+		}
+		if len(items) > 0 {
+			if err := s.index.Upsert(ctx, items); err != nil {
+				return result, fmt.Errorf("failed to index items: %w", err)
+			}
+			result.Indexed += len(items)
+		}
+
+		if len(items) < SearchReindexBatchSize {
+			break
+		}
+		query.AfterID = items[len(items)-1].ID
+	}
+
+	return result, nil
+}
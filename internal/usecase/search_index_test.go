@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockSearchIndex は検索エンジンのモック
+type MockSearchIndex struct {
+	mock.Mock
+}
+
+func (m *MockSearchIndex) Search(ctx context.Context, query SearchIndexQuery) (*SearchIndexResult, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*SearchIndexResult), args.Error(1)
+}
+
+func (m *MockSearchIndex) Upsert(ctx context.Context, items []*entity.Item) error {
+	args := m.Called(ctx, items)
+	return args.Error(0)
+}
+
+func (m *MockSearchIndex) Delete(ctx context.Context, ids []int64) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func TestItemUsecase_SearchItems_WithSearchIndex(t *testing.T) {
+	daytona := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX"}
+	submariner := &entity.Item{ID: 2, Name: "ロレックス サブマリーナ", Category: "時計", Brand: "ROLEX"}
+
+	t.Run("正常系: 検索エンジンの関連度の順に返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		index := new(MockSearchIndex)
+
+		index.On("Search", mock.Anything, SearchIndexQuery{Keyword: "ロレクス", Category: "時計", Limit: DefaultItemsLimit, Facets: SearchFacets}).
+			Return(&SearchIndexResult{IDs: []int64{2, 1, 3}, Total: 3, Facets: map[string]map[string]int{"brand": {"ROLEX": 3}}}, nil)
+		// 3 はインデックスの更新前に削除されたアイテム
+		mockRepo.On("FindAll", mock.Anything, entity.ItemQuery{IDs: []int64{2, 1, 3}, Limit: 3, Sort: entity.SortByID}).
+			Return([]*entity.Item{daytona, submariner}, nil)
+
+		usecase := NewItemUsecase(mockRepo, WithSearchIndex(index))
+		list, err := usecase.SearchItems(context.Background(), "ロレクス", ListItemsInput{Category: "時計"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.Item{submariner, daytona}, list.Items)
+		assert.Equal(t, 3, list.Total)
+		assert.Equal(t, map[string]map[string]int{"brand": {"ROLEX": 3}}, list.Facets)
+		mockRepo.AssertNotCalled(t, "Count", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 並び順を指定した場合は検索エンジンに渡す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		index := new(MockSearchIndex)
+
+		index.On("Search", mock.Anything, SearchIndexQuery{Keyword: "ROLEX", Limit: 10, Offset: 10, Sort: entity.SortByPurchasePrice, Order: entity.SortAsc, Facets: SearchFacets}).
+			Return(&SearchIndexResult{Total: 10}, nil)
+
+		usecase := NewItemUsecase(mockRepo, WithSearchIndex(index))
+		list, err := usecase.SearchItems(context.Background(), "ROLEX", ListItemsInput{Limit: 10, Offset: 10, Sort: "purchase_price", Order: "asc"})
+
+		require.NoError(t, err)
+		assert.Empty(t, list.Items)
+		assert.Equal(t, 10, list.Total)
+		mockRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 検索エンジンの障害時はSQLで検索する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		index := new(MockSearchIndex)
+
+		index.On("Search", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+		query := entity.ItemQuery{Limit: DefaultItemsLimit, Sort: entity.SortByCreatedAt, Order: entity.SortDesc, Keyword: "デイトナ"}
+		mockRepo.On("FindAll", mock.Anything, query).Return([]*entity.Item{daytona}, nil)
+		mockRepo.On("Count", mock.Anything, query).Return(1, nil)
+
+		usecase := NewItemUsecase(mockRepo, WithSearchIndex(index))
+		list, err := usecase.SearchItems(context.Background(), "デイトナ", ListItemsInput{})
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.Item{daytona}, list.Items)
+		assert.Nil(t, list.Facets)
+	})
+
+	t.Run("正常系: タグで絞り込む場合は検索エンジンを使わない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		index := new(MockSearchIndex)
+
+		mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{}, nil)
+		mockRepo.On("Count", mock.Anything, mock.Anything).Return(0, nil)
+
+		usecase := NewItemUsecase(mockRepo, WithSearchIndex(index))
+		_, err := usecase.SearchItems(context.Background(), "ROLEX", ListItemsInput{Tag: "gift"})
+
+		require.NoError(t, err)
+		index.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 不正な条件は検索エンジンの有無にかかわらずエラー", func(t *testing.T) {
+		usecase := NewItemUsecase(new(MockItemRepository), WithSearchIndex(new(MockSearchIndex)))
+		_, err := usecase.SearchItems(context.Background(), "ROLEX", ListItemsInput{Category: "車"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestSearchIndexer_HandleItemEvent(t *testing.T) {
+	t.Run("正常系: 変更されたアイテムの最新の状態を登録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		index := new(MockSearchIndex)
+
+		existing, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+		existing.ID = 1
+		updated := *existing
+		updated.Name = "時計2"
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil).Once()
+		mockRepo.On("Update", mock.Anything, existing).Return(&updated, nil)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&updated, nil)
+		index.On("Upsert", mock.Anything, []*entity.Item{&updated}).Return(nil)
+
+		usecase := NewItemUsecase(mockRepo, WithItemEventHandler(NewSearchIndexer(index, mockRepo)))
+		name := "時計2"
+		_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Name: &name})
+
+		require.NoError(t, err)
+		index.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 削除されたアイテムはインデックスから削除する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		index := new(MockSearchIndex)
+
+		mockRepo.On("FindByID", mock.Anything, int64(2)).Return(nil, domainErrors.ErrItemNotFound)
+		index.On("Delete", mock.Anything, []int64{2}).Return(nil)
+
+		NewSearchIndexer(index, mockRepo).HandleItemEvent(context.Background(), ItemEvent{ItemID: 2, Action: entity.AuditDelete})
+
+		index.AssertExpectations(t)
+		index.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: インデックスの更新に失敗しても操作は成功", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		index := new(MockSearchIndex)
+
+		mockRepo.On("FindByID", mock.Anything, int64(3)).Return(&entity.Item{ID: 3}, nil)
+		mockRepo.On("Delete", mock.Anything, int64(3), mock.Anything).Return(nil)
+		index.On("Upsert", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+		usecase := NewItemUsecase(mockRepo, WithItemEventHandler(NewSearchIndexer(index, mockRepo)))
+		assert.NoError(t, usecase.DeleteItem(context.Background(), 3))
+	})
+}
+
+func TestSearchIndexer_Reindex(t *testing.T) {
+	t.Run("正常系: IDの順に分割して登録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		index := new(MockSearchIndex)
+
+		first := make([]*entity.Item, SearchReindexBatchSize)
+		for i := range first {
+			first[i] = &entity.Item{ID: int64(i + 1)}
+		}
+		second := []*entity.Item{{ID: SearchReindexBatchSize + 1}}
+		query := entity.ItemQuery{Limit: SearchReindexBatchSize, Sort: entity.SortByID, Order: entity.SortAsc}
+		mockRepo.On("FindAll", mock.Anything, query).Return(first, nil)
+		query.AfterID = SearchReindexBatchSize
+		mockRepo.On("FindAll", mock.Anything, query).Return(second, nil)
+		index.On("Upsert", mock.Anything, first).Return(nil)
+		index.On("Upsert", mock.Anything, second).Return(nil)
+
+		result, err := NewSearchIndexer(index, mockRepo).Reindex(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, SearchReindexBatchSize+1, result.Indexed)
+		index.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 検索エンジンへの登録に失敗", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		index := new(MockSearchIndex)
+
+		mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{{ID: 1}}, nil)
+		index.On("Upsert", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+		result, err := NewSearchIndexer(index, mockRepo).Reindex(context.Background())
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, result.Indexed)
+	})
+}
@@ -2,57 +2,540 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
 )
 
 type ItemUsecase interface {
-	GetAllItems(ctx context.Context) ([]*entity.Item, error)
+	GetAllItems(ctx context.Context, input ListItemsInput) (*ItemList, error)
+	SearchItems(ctx context.Context, keyword string, input ListItemsInput) (*ItemList, error)
 	GetItemByID(ctx context.Context, id int64) (*entity.Item, error)
-	CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error)
-	UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error)
+	CreateItem(ctx context.Context, input CreateItemInput) (*ItemResult, error)
+	CreateItems(ctx context.Context, input BulkCreateItemsInput) (*BulkCreateResult, error)
+	ExportItems(ctx context.Context, w io.Writer, input ExportItemsInput) error
+	ImportItems(ctx context.Context, input ImportItemsInput) (*ImportResult, error)
+	UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*ItemResult, error)
 	DeleteItem(ctx context.Context, id int64) error
+	RestoreItem(ctx context.Context, id int64) (*entity.Item, error)
+	SetItemHold(ctx context.Context, id int64, input SetItemHoldInput) (*entity.Item, error)
+	SellItem(ctx context.Context, id int64, input SellItemInput) (*SaleResult, error)
+	SplitItem(ctx context.Context, id int64, input SplitItemInput) (*SplitResult, error)
 	GetCategorySummary(ctx context.Context) (*CategorySummary, error)
+	GetBrandSummary(ctx context.Context) (*BrandSummary, error)
+	GetYearCategorySummary(ctx context.Context) (*YearCategorySummary, error)
+	GetDepreciation(ctx context.Context, id int64, input DepreciationInput) (*Depreciation, error)
+	AddItemTag(ctx context.Context, id int64, name string) (*entity.Item, error)
+	RemoveItemTag(ctx context.Context, id int64, name string) error
+	CleanupOrphans(ctx context.Context, retention time.Duration) (*OrphanCleanupResult, error)
+	NormalizeBrands(ctx context.Context) (*BrandNormalizationResult, error)
+	GetItemHistory(ctx context.Context, id int64) ([]*entity.AuditLog, error)
+	GetItemRevisions(ctx context.Context, id int64) ([]*entity.ItemRevision, error)
+	GetItemAsOf(ctx context.Context, id int64, asOf string) (*entity.Item, error)
+	RevertItem(ctx context.Context, id int64, version int) (*ItemResult, error)
+	PublishItem(ctx context.Context, id int64) (*ItemResult, error)
+	SchedulePurge(ctx context.Context, id int64, purgeAt time.Time) (*entity.Item, error)
+	CancelPurge(ctx context.Context, id int64) (*entity.Item, error)
+	PurgeDueItems(ctx context.Context) (*PurgeResult, error)
+}
+
+// 一覧取得のページング上限
+const (
+	DefaultItemsLimit = 20
+	MaxItemsLimit     = 100
+)
+
+// 検索キーワードの最大文字数
+const MaxSearchKeywordLength = 100
+
+type ListItemsInput struct {
+	Limit  int
+	Offset int
+	Sort   string
+	Order  string
+
+	Category       string
+	Tag            string
+	Uncategorized  bool  // 未分類のアイテムのみに絞り込む
+	IncludeDeleted bool  // 論理削除されたアイテムも含める（管理者用）
+	ChangedAfter   int64 // 変更の通し番号がこの値より大きいアイテムのみ（同期用）
+}
+
+type ItemList struct {
+	Items  []*entity.Item `json:"items"`
+	Total  int            `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+
+	// 外部の検索エンジンで検索した場合の、項目の値ごとの件数（例: {"category": {"時計": 3}}）
+	Facets map[string]map[string]int `json:"facets,omitempty"`
+}
+
+// 登録・更新結果（予算超過などの警告がある場合は warnings に含める）
+type ItemResult struct {
+	*entity.Item
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 type CreateItemInput struct {
-	Name          string `json:"name"`
-	Category      string `json:"category"`
-	Brand         string `json:"brand"`
-	PurchasePrice int    `json:"purchase_price"`
-	PurchaseDate  string `json:"purchase_date"`
+	Name          string            `json:"name"`
+	Category      string            `json:"category"`
+	Brand         string            `json:"brand"`
+	PurchasePrice entity.Money      `json:"purchase_price"`
+	PurchaseDate  string            `json:"purchase_date"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+
+	// 購入した国・地域（ISO 3166-1 alpha-2）
+	PurchaseCountry string `json:"purchase_country,omitempty"`
+
+	// 購入時に支払った税額（購入価格の通貨の最小単位）と、購入価格が税込かどうか
+	// 税額の代わりに税率（%、例: "10"）を指定した場合は購入価格から税額を求める
+	TaxAmount   *int64 `json:"tax_amount,omitempty"`
+	TaxIncluded bool   `json:"tax_included"`
+	TaxRate     string `json:"tax_rate,omitempty"`
+
+	// 下書きとして保存する（名前以外の未入力を許し、集計・予算には含めない）
+	Draft bool `json:"draft"`
+
+	// 名前・ブランド・購入日が同じアイテムが登録済みの場合に、警告ではなくエラーにする
+	Strict bool `json:"-"`
 }
 
+// 部分更新の入力（nil の項目は変更しない）
 type UpdateItemInput struct {
-	Name          *string `json:"name,omitempty"`
-	Brand         *string `json:"brand,omitempty"`
-	PurchasePrice *int    `json:"purchase_price,omitempty"`
+	Name          *string           `json:"name,omitempty"`
+	Brand         NullableString    `json:"brand"` // null を指定するとブランドを空にする
+	Category      *string           `json:"category,omitempty"`
+	PurchasePrice *entity.Money     `json:"purchase_price,omitempty"`
+	PurchaseDate  *string           `json:"purchase_date,omitempty"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+
+	PurchaseCountry NullableString `json:"purchase_country"` // null を指定すると未設定にする
+
+	TaxAmount   NullableInt64 `json:"tax_amount"` // null を指定すると未記録にする
+	TaxIncluded *bool         `json:"tax_included,omitempty"`
+	TaxRate     *string       `json:"tax_rate,omitempty"` // 変更後の購入価格から税額を求める
+}
+
+// 未指定と null を区別する文字列（部分更新で値を空にするため）
+type NullableString struct {
+	Set   bool    // 指定された（null を含む）
+	Value *string // null の場合は nil
+}
+
+// 値を指定した NullableString
+func NewNullableString(value string) NullableString {
+	return NullableString{Set: true, Value: &value}
+}
+
+func (n *NullableString) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if string(data) == "null" {
+		n.Value = nil
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	n.Value = &value
+	return nil
+}
+
+// 更新後の値（null の場合は空文字、未指定の場合は current）
+func (n NullableString) Or(current string) string {
+	if !n.Set {
+		return current
+	}
+	if n.Value == nil {
+		return ""
+	}
+	return *n.Value
+}
+
+// 未指定と null を区別する整数（部分更新で値を未設定にするため）
+type NullableInt64 struct {
+	Set   bool   // 指定された（null を含む）
+	Value *int64 // null の場合は nil
+}
+
+// 値を指定した NullableInt64
+func NewNullableInt64(value int64) NullableInt64 {
+	return NullableInt64{Set: true, Value: &value}
+}
+
+func (n *NullableInt64) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if string(data) == "null" {
+		n.Value = nil
+		return nil
+	}
+
+	var value int64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	n.Value = &value
+	return nil
+}
+
+// 更新後の値（null の場合は nil、未指定の場合は current）
+func (n NullableInt64) Or(current *int64) *int64 {
+	if !n.Set {
+		return current
+	}
+	return n.Value
 }
 
 type CategorySummary struct {
-	Categories map[string]int `json:"categories"`
-	Total      int            `json:"total"`
+	Categories    map[string]int             `json:"categories"`
+	Uncategorized int                        `json:"uncategorized"`
+	Total         int                        `json:"total"`
+	Values        map[string][]CategoryValue `json:"values"`
+	ValueJPY      map[string]int64           `json:"value_jpy"` // カテゴリーごとの円換算額の合計
+}
+
+// カテゴリー内の通貨ごとの購入価格の合計と平均
+// 通貨の異なる金額は合算せず、金額は通貨の最小単位で表す
+type CategoryValue struct {
+	Currency string `json:"currency"`
+	Count    int    `json:"count"`
+	Total    int64  `json:"total"`
+	Average  int64  `json:"average"`
+	TotalJPY int64  `json:"total_jpy"`
+}
+
+// ブランド別の集計（円換算額の合計の降順）
+type BrandSummary struct {
+	Brands []BrandValue `json:"brands"`
+}
+
+// ブランド・通貨ごとのアイテム数と購入価格の合計
+// 通貨の異なる金額は合算せず、別の行として返す
+type BrandValue struct {
+	Brand    string `json:"brand"`
+	Currency string `json:"currency"`
+	Count    int    `json:"count"`
+	Total    int64  `json:"total"`
+	TotalJPY int64  `json:"total_jpy"`
+}
+
+// 購入年×カテゴリーの集計表
+// 最初と最後の購入年の間の年、すべてのカテゴリーを0件でも含める
+type YearCategorySummary struct {
+	Categories []string          `json:"categories"` // 列の並び（有効なカテゴリーと未分類）
+	Years      []YearCategoryRow `json:"years"`
+}
+
+type YearCategoryRow struct {
+	Year       int                         `json:"year"`
+	Count      int                         `json:"count"`
+	TotalJPY   int64                       `json:"total_jpy"`
+	Categories map[string]YearCategoryCell `json:"categories"`
+}
+
+// 通貨の異なる金額は合算せず、通貨ごとの合計と円換算額の合計を返す
+type YearCategoryCell struct {
+	Count    int             `json:"count"`
+	TotalJPY int64           `json:"total_jpy"`
+	Totals   []CurrencyTotal `json:"totals"`
 }
 
 type itemUsecase struct {
-	itemRepo ItemRepository
+	itemRepo        ItemRepository
+	readOnly        *ReadOnlySwitch
+	defaultCategory string
+
+	// カテゴリー予算のチェック（未指定の場合はチェックしない）
+	budgetRepo        BudgetRepository
+	budgetEnforcement BudgetEnforcement
+
+	retryPolicy RetryPolicy
+
+	// 外貨建ての購入価格の円換算（未指定の場合は円換算しない）
+	fxProvider ExchangeRateProvider
+
+	// 最新の評価額と含み損益（未指定の場合は設定しない）
+	valuationRepo ValuationRepository
+
+	// アイテムのタグ（未指定の場合はタグを扱わない）
+	tagRepo TagRepository
+
+	// 売却の記録（未指定の場合は売却を扱わない）
+	saleRepo       SaleRepository
+	saleTransactor Transactor
+
+	// 削除時の紐づくデータの扱い（未指定の場合はアイテムのみ削除する）
+	deletePolicy   DeletePolicy
+	dependentsRepo ItemDependentsRepository
+	transactor     Transactor
+	imageStorage   ImageStorage
+
+	// 監査ログ（未指定の場合は記録しない）
+	auditLogger AuditLogger
+
+	// 利用上限（未指定の場合は確認しない）
+	quota *QuotaChecker
+
+	// CDNのキャッシュの削除（未指定の場合は削除しない）
+	cachePurger CachePurger
+
+	// アイテムの版（未指定の場合は保存しない）
+	revisionRepo ItemRevisionRepository
+
+	// ブランドの表記の統一（未指定の場合は入力のまま保存する）
+	brandNormalizer *BrandNormalizer
+
+	// 税率から税額を求める場合の1円（最小単位）未満の端数処理
+	taxRounding entity.RoundingMode
+
+	// 変更イベントの受け取り手（検索インデックスの更新など）
+	eventHandlers []ItemEventHandler
+
+	// 変更イベントを変更と同じトランザクションで記録するアウトボックス（未指定の場合は受け取り手にすぐに渡す）
+	outboxRepo       OutboxRepository
+	outboxTransactor Transactor
+
+	// 外部の検索エンジン（未指定の場合はSQLで検索する）
+	searchIndex SearchIndex
+
+	// 作成日時・更新日時や削除の予定の基準にする現在時刻
+	clock entity.Clock
+}
+
+// ItemUsecaseの任意の依存を指定するオプション
+type ItemUsecaseOption func(*itemUsecase)
+
+// 読み取り専用モードのスイッチを指定
+func WithReadOnlySwitch(readOnly *ReadOnlySwitch) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.readOnly = readOnly
+	}
+}
+
+// カテゴリー未指定で作成されたアイテムの分類先を指定（"その他" や "未分類"）
+func WithDefaultCategory(category string) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.defaultCategory = category
+	}
+}
+
+// 登録・更新時にカテゴリーの年間予算をチェックする
+func WithBudgetCheck(budgetRepo BudgetRepository, enforcement BudgetEnforcement) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.budgetRepo = budgetRepo
+		u.budgetEnforcement = enforcement
+	}
+}
+
+// 一時的なデータベースエラーの再試行方針を指定
+func WithRetryPolicy(policy RetryPolicy) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.retryPolicy = policy
+	}
+}
+
+// 外貨建ての購入価格を登録時に円換算する為替レートの取得先を指定
+func WithExchangeRateProvider(provider ExchangeRateProvider) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.fxProvider = provider
+	}
+}
+
+// アイテムの取得時に最新の評価額と含み損益を設定する
+func WithValuations(valuationRepo ValuationRepository) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.valuationRepo = valuationRepo
+	}
+}
+
+// アイテムのタグの追加・削除とタグでの絞り込みを有効にする
+func WithTags(tagRepo TagRepository) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.tagRepo = tagRepo
+	}
+}
+
+// 現在時刻の取得元を指定（デフォルトはシステムの時刻）
+func WithClock(clock entity.Clock) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.clock = clock
+	}
+}
+
+// 税率から税額を求める場合の端数処理を指定（デフォルトは切り捨て）
+func WithTaxRounding(mode entity.RoundingMode) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.taxRounding = mode
+	}
+}
+
+func NewItemUsecase(itemRepo ItemRepository, opts ...ItemUsecaseOption) ItemUsecase {
+	u := &itemUsecase{
+		itemRepo:        itemRepo,
+		readOnly:        NewReadOnlySwitch(false),
+		defaultCategory: entity.UncategorizedCategory,
+		retryPolicy:     DefaultRetryPolicy,
+		deletePolicy:    DeleteOrphan,
+		taxRounding:     entity.RoundDown,
+		clock:           entity.SystemClock,
+	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	// 冪等な操作は一時的なエラーの場合に自動で再試行する
+	u.itemRepo = &retryingItemRepository{ItemRepository: u.itemRepo, policy: u.retryPolicy}
+
+	return u
+}
+
+// 読み取り専用モード中は更新系の操作を拒否する
+func (u *itemUsecase) ensureWritable() error {
+	if u.readOnly.Enabled() {
+		return domainErrors.ErrReadOnly
+	}
+	return nil
+}
+
+func (u *itemUsecase) GetAllItems(ctx context.Context, input ListItemsInput) (*ItemList, error) {
+	return u.listItems(ctx, "", input)
 }
 
-func NewItemUsecase(itemRepo ItemRepository) ItemUsecase {
-	return &itemUsecase{
-		itemRepo: itemRepo,
+func (u *itemUsecase) SearchItems(ctx context.Context, keyword string, input ListItemsInput) (*ItemList, error) {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return nil, fmt.Errorf("%w: q is required", domainErrors.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(keyword) > MaxSearchKeywordLength {
+		return nil, fmt.Errorf("%w: q must be %d characters or less", domainErrors.ErrInvalidInput, MaxSearchKeywordLength)
+	}
+
+	if u.searchIndex != nil {
+		if list, ok := u.searchWithIndex(ctx, keyword, input); ok {
+			return list, nil
+		}
 	}
+	return u.listItems(ctx, keyword, input)
 }
 
-func (u *itemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
-	items, err := u.itemRepo.FindAll(ctx)
+func (u *itemUsecase) listItems(ctx context.Context, keyword string, input ListItemsInput) (*ItemList, error) {
+	query, err := newItemQuery(keyword, input)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.itemRepo.FindAll(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve items: %w", err)
 	}
 
-	return items, nil
+	total, err := u.itemRepo.Count(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count items: %w", err)
+	}
+
+	if items == nil {
+		items = []*entity.Item{}
+	}
+	if err := u.attachValuations(ctx, items...); err != nil {
+		return nil, err
+	}
+	if err := u.attachTags(ctx, items...); err != nil {
+		return nil, err
+	}
+
+	return &ItemList{
+		Items:  items,
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}, nil
+}
+
+// 一覧・検索の条件を検証して取得条件にする
+func newItemQuery(keyword string, input ListItemsInput) (entity.ItemQuery, error) {
+	if input.Limit == 0 {
+		input.Limit = DefaultItemsLimit
+	}
+	if input.Limit < 0 || input.Limit > MaxItemsLimit {
+		return entity.ItemQuery{}, fmt.Errorf("%w: limit must be between 1 and %d", domainErrors.ErrInvalidInput, MaxItemsLimit)
+	}
+	if input.Offset < 0 {
+		return entity.ItemQuery{}, fmt.Errorf("%w: offset must be 0 or greater", domainErrors.ErrInvalidInput)
+	}
+	if input.ChangedAfter < 0 {
+		return entity.ItemQuery{}, fmt.Errorf("%w: changed_after must be 0 or greater", domainErrors.ErrInvalidInput)
+	}
+
+	sortKey, order, err := parseSort(input.Sort, input.Order)
+	if err != nil {
+		return entity.ItemQuery{}, err
+	}
+
+	category := strings.TrimSpace(input.Category)
+	if input.Uncategorized {
+		if category != "" && category != entity.UncategorizedCategory {
+			return entity.ItemQuery{}, fmt.Errorf("%w: category and uncategorized cannot be combined", domainErrors.ErrInvalidInput)
+		}
+		category = entity.UncategorizedCategory
+	}
+	if category != "" && !entity.IsValidCategory(category) {
+		invalid := entity.ValidationErrors{{Field: "category", Reason: "is invalid: " + category}}
+		return entity.ItemQuery{}, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, invalid)
+	}
+
+	var tag string
+	if input.Tag != "" {
+		if tag, err = entity.NormalizeTagName(input.Tag); err != nil {
+			return entity.ItemQuery{}, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+		}
+	}
+
+	return entity.ItemQuery{
+		Limit:          input.Limit,
+		Offset:         input.Offset,
+		Sort:           sortKey,
+		Order:          order,
+		Keyword:        keyword,
+		Category:       category,
+		Tag:            tag,
+		IncludeDeleted: input.IncludeDeleted,
+		ChangedAfter:   input.ChangedAfter,
+	}, nil
+}
+
+// 並び替え条件をホワイトリストで検証する（未指定の場合は登録日の新しい順）
+func parseSort(sort, order string) (entity.ItemSortKey, entity.SortOrder, error) {
+	sortKey := entity.SortByCreatedAt
+	if sort != "" {
+		sortKey = entity.ItemSortKey(sort)
+		if !sortKey.IsValid() {
+			keys := make([]string, 0, len(entity.ValidItemSortKeys))
+			for _, key := range entity.ValidItemSortKeys {
+				keys = append(keys, string(key))
+			}
+			return "", "", fmt.Errorf("%w: sort must be one of: %s", domainErrors.ErrInvalidInput, strings.Join(keys, ", "))
+		}
+	}
+
+	sortOrder := entity.SortDesc
+	if order != "" {
+		sortOrder = entity.SortOrder(strings.ToLower(order))
+		if !sortOrder.IsValid() {
+			return "", "", fmt.Errorf("%w: order must be asc or desc", domainErrors.ErrInvalidInput)
+		}
+	}
+
+	return sortKey, sortOrder, nil
 }
 
 func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
@@ -60,63 +543,178 @@ func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item,
 		return nil, domainErrors.ErrInvalidInput
 	}
 
-	item, err := u.itemRepo.FindByID(ctx, id)
+	item, err := u.findItem(ctx, id)
 	if err != nil {
 		if domainErrors.IsNotFoundError(err) {
 			return nil, domainErrors.ErrItemNotFound
 		}
 		return nil, fmt.Errorf("failed to retrieve item: %w", err)
 	}
+	if err := u.attachValuations(ctx, item); err != nil {
+		return nil, err
+	}
+	if err := u.attachTags(ctx, item); err != nil {
+		return nil, err
+	}
 
 	return item, nil
 }
 
-func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
-	// バリデーションして、新しいエンティティを作成
-	item, err := entity.NewItem(
+func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*ItemResult, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	item, err := u.newItem(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+
+	if err := u.quota.CheckItems(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	if input.Strict {
+		if err := u.ensureNotDuplicate(ctx, item); err != nil {
+			return nil, err
+		}
+	}
+
+	warnings := u.duplicateWarnings(ctx, item)
+	warnings = append(warnings, u.applyExchangeRates(ctx, item)...)
+
+	budgetWarnings, err := u.checkBudget(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, budgetWarnings...)
+
+	var createdItem *entity.Item
+	err = u.mutate(ctx, func(ctx context.Context) error {
+		createdItem, err = u.itemRepo.Create(ctx, item)
+		if err != nil {
+			return fmt.Errorf("failed to create item: %w", err)
+		}
+		return u.audit(ctx, createdItem.ID, entity.AuditCreate, nil, entity.ItemAuditFields(createdItem))
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.cacheItem(ctx, createdItem)
+	u.snapshot(ctx, createdItem)
+	purgeItems(ctx, u.cachePurger, createdItem)
+
+	return &ItemResult{Item: createdItem, Warnings: warnings}, nil
+}
+
+// 入力をバリデーションして、新しいエンティティを作成
+func (u *itemUsecase) newItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
+	// カテゴリー未指定の場合は既定のカテゴリーに分類する
+	category := input.Category
+	if strings.TrimSpace(category) == "" {
+		category = u.defaultCategory
+	}
+
+	if input.TaxAmount != nil && input.TaxRate != "" {
+		return nil, taxAmountAndRateError
+	}
+	purchaseDate, err := parsePurchaseDate(input.PurchaseDate)
+	if err != nil {
+		return nil, err
+	}
+
+	newItem := entity.NewItem
+	if input.Draft {
+		newItem = entity.NewDraftItem
+	}
+	item, err := newItem(
 		input.Name,
-		input.Category,
-		input.Brand,
+		category,
+		u.normalizeBrand(ctx, input.Brand),
 		input.PurchasePrice,
-		input.PurchaseDate,
+		purchaseDate,
+		u.clock.Now(),
+		entity.WithAttributes(input.Attributes),
+		entity.WithPurchaseCountry(input.PurchaseCountry),
+		entity.WithTax(input.TaxAmount, input.TaxIncluded),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+		return nil, err
 	}
 
-	createdItem, err := u.itemRepo.Create(ctx, item)
+	if input.TaxRate != "" {
+		if err := item.SetTaxRate(input.TaxRate, input.TaxIncluded, u.taxRounding); err != nil {
+			return nil, taxRateError
+		}
+	}
+	return item, nil
+}
+
+var (
+	taxAmountAndRateError   = entity.ValidationErrors{{Field: "tax_amount", Reason: "and tax_rate cannot be specified together"}}
+	taxRateError            = entity.ValidationErrors{{Field: "tax_rate", Reason: "must be a percentage between 0 and 100"}}
+	purchaseDateFormatError = entity.ValidationErrors{{Field: "purchase_date", Reason: "must be in YYYY-MM-DD format"}}
+)
+
+// 入力の購入日（YYYY-MM-DD 形式、空文字は未入力）
+func parsePurchaseDate(value string) (entity.Date, error) {
+	date, err := entity.ParseDate(value)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create item: %w", err)
+		return entity.Date{}, purchaseDateFormatError
 	}
+	return date, nil
+}
 
-	return createdItem, nil
+// 更新する項目が指定されているか
+func (input UpdateItemInput) HasChanges() bool {
+	return input.Name != nil || input.Brand.Set || input.Category != nil ||
+		input.PurchasePrice != nil || input.PurchaseDate != nil || input.Attributes != nil ||
+		input.PurchaseCountry.Set || input.TaxAmount.Set || input.TaxIncluded != nil || input.TaxRate != nil
 }
 
-func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error) {
+func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*ItemResult, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
 	if id <= 0 {
 		return nil, domainErrors.ErrInvalidInput
 	}
 
-	if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil {
+	if !input.HasChanges() {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, "at least one field must be provided")
 	}
+	if input.TaxAmount.Set && input.TaxRate != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, taxAmountAndRateError)
+	}
 
-	item, err := u.itemRepo.FindByID(ctx, id)
+	item, err := u.findItem(ctx, id)
 	if err != nil {
 		if domainErrors.IsNotFoundError(err) {
 			return nil, domainErrors.ErrItemNotFound
 		}
 		return nil, fmt.Errorf("failed to retrieve item: %w", err)
 	}
+	if err := ensureNotOnHold(item); err != nil {
+		return nil, err
+	}
+	before := entity.ItemAuditFields(item)
 
 	name := item.Name
 	if input.Name != nil {
 		name = *input.Name
 	}
 
-	brand := item.Brand
-	if input.Brand != nil {
-		brand = *input.Brand
+	brand := input.Brand.Or(item.Brand)
+	if input.Brand.Set {
+		brand = u.normalizeBrand(ctx, brand)
+	}
+
+	// 変更前のカテゴリーのキャッシュも削除する
+	previous := &entity.Item{ID: item.ID, Category: item.Category}
+	category := item.Category
+	if input.Category != nil {
+		category = *input.Category
 	}
 
 	purchasePrice := item.PurchasePrice
@@ -124,42 +722,159 @@ func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItem
 		purchasePrice = *input.PurchasePrice
 	}
 
-	if err := item.Update(name, item.Category, brand, purchasePrice, item.PurchaseDate); err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	purchaseDate := item.PurchaseDate
+	if input.PurchaseDate != nil {
+		purchaseDate, err = parsePurchaseDate(*input.PurchaseDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+		}
 	}
 
-	updatedItem, err := u.itemRepo.Update(ctx, item)
+	if input.Attributes != nil {
+		item.SetAttributes(input.Attributes)
+	}
+	if input.PurchaseCountry.Set {
+		item.SetPurchaseCountry(input.PurchaseCountry.Or(""))
+	}
+
+	// 税率を指定した場合は変更後の購入価格から税額を求める
+	taxIncluded := item.TaxIncluded
+	if input.TaxIncluded != nil {
+		taxIncluded = *input.TaxIncluded
+	}
+	taxAmount := input.TaxAmount.Or(item.TaxAmountValue())
+	if input.TaxRate != nil {
+		tax, err := purchasePrice.Tax(*input.TaxRate, taxIncluded, u.taxRounding)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, taxRateError)
+		}
+		taxAmount = &tax.Amount
+	}
+	item.SetTax(taxAmount, taxIncluded)
+
+	if err := item.Update(name, category, brand, purchasePrice, purchaseDate, u.clock.Now()); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+
+	// 通貨を変更した場合は為替レートを取得し直す
+	warnings := u.applyExchangeRates(ctx, item)
+
+	// 購入価格・カテゴリー・購入日（年）を変更した場合のみ予算をチェックする
+	if input.PurchasePrice != nil || input.Category != nil || input.PurchaseDate != nil {
+		budgetWarnings, err := u.checkBudget(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, budgetWarnings...)
+	}
+
+	var updatedItem *entity.Item
+	err = u.mutate(ctx, func(ctx context.Context) error {
+		updatedItem, err = u.itemRepo.Update(ctx, item)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to update item: %w", err)
+		}
+		return u.audit(ctx, id, entity.AuditUpdate, before, entity.ItemAuditFields(updatedItem))
+	})
 	if err != nil {
 		if domainErrors.IsNotFoundError(err) {
-			return nil, domainErrors.ErrItemNotFound
+			u.evictItem(ctx, id)
 		}
-		return nil, fmt.Errorf("failed to update item: %w", err)
+		return nil, err
 	}
-
-	return updatedItem, nil
+	u.cacheItem(ctx, updatedItem)
+	u.snapshot(ctx, updatedItem)
+	purgeItems(ctx, u.cachePurger, previous, updatedItem)
+	// 更新は完了しているため、評価額やタグを取得できなくてもエラーにしない
+	_ = u.attachValuations(ctx, updatedItem)
+	_ = u.attachTags(ctx, updatedItem)
+
+	return &ItemResult{Item: updatedItem, Warnings: warnings}, nil
 }
 
 func (u *itemUsecase) DeleteItem(ctx context.Context, id int64) error {
+	if err := u.ensureWritable(); err != nil {
+		return err
+	}
+
 	if id <= 0 {
 		return domainErrors.ErrInvalidInput
 	}
 
-	_, err := u.itemRepo.FindByID(ctx, id)
+	item, err := u.findItem(ctx, id)
 	if err != nil {
 		if domainErrors.IsNotFoundError(err) {
 			return domainErrors.ErrItemNotFound
 		}
 		return fmt.Errorf("failed to check item existence: %w", err)
 	}
+	if err := ensureNotOnHold(item); err != nil {
+		return err
+	}
 
-	err = u.itemRepo.Delete(ctx, id)
+	err = u.mutate(ctx, func(ctx context.Context) error {
+		if err := u.deleteWithDependents(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete item: %w", err)
+		}
+		return u.audit(ctx, id, entity.AuditDelete, entity.ItemAuditFields(item), nil)
+	})
+	u.evictItem(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to delete item: %w", err)
+		return err
 	}
+	purgeItems(ctx, u.cachePurger, item)
 
 	return nil
 }
 
+func (u *itemUsecase) RestoreItem(ctx context.Context, id int64) (*entity.Item, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	// 削除されていないアイテムの復元は何もしない（冪等）
+	var item *entity.Item
+	var restoreErr error
+	err := u.mutate(ctx, func(ctx context.Context) error {
+		restoreErr = u.itemRepo.Restore(ctx, id, u.clock.Now())
+		if restoreErr != nil && !domainErrors.IsNotFoundError(restoreErr) {
+			return fmt.Errorf("failed to restore item: %w", restoreErr)
+		}
+
+		var err error
+		item, err = u.itemRepo.FindByID(ctx, id)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		if restoreErr == nil {
+			return u.audit(ctx, id, entity.AuditRestore, nil, entity.ItemAuditFields(item))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.cacheItem(ctx, item)
+	if restoreErr == nil {
+		purgeItems(ctx, u.cachePurger, item)
+	}
+	// 復元は完了しているため、評価額やタグを取得できなくてもエラーにしない
+	_ = u.attachValuations(ctx, item)
+	_ = u.attachTags(ctx, item)
+
+	return item, nil
+}
+
 func (u *itemUsecase) GetCategorySummary(ctx context.Context) (*CategorySummary, error) {
 	categoryCounts, err := u.itemRepo.GetSummaryByCategory(ctx)
 	if err != nil {
@@ -181,8 +896,96 @@ func (u *itemUsecase) GetCategorySummary(ctx context.Context) (*CategorySummary,
 		}
 	}
 
+	valueTotals, err := u.itemRepo.GetValueSummaryByCategory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category value summary: %w", err)
+	}
+
+	values := make(map[string][]CategoryValue)
+	valueJPY := make(map[string]int64)
+	for _, category := range entity.GetValidCategories() {
+		values[category] = []CategoryValue{}
+		valueJPY[category] = 0
+	}
+	for _, v := range valueTotals {
+		if v.Count == 0 {
+			continue
+		}
+		values[v.Category] = append(values[v.Category], CategoryValue{
+			Currency: v.Currency,
+			Count:    v.Count,
+			Total:    v.Total,
+			// 最小単位未満は四捨五入
+			Average:  (v.Total + int64(v.Count)/2) / int64(v.Count),
+			TotalJPY: v.TotalJPY,
+		})
+		valueJPY[v.Category] += v.TotalJPY
+	}
+
 	return &CategorySummary{
-		Categories: summary,
-		Total:      total,
+		Categories:    summary,
+		Uncategorized: categoryCounts[entity.UncategorizedCategory],
+		Total:         total,
+		Values:        values,
+		ValueJPY:      valueJPY,
 	}, nil
 }
+
+func (u *itemUsecase) GetBrandSummary(ctx context.Context) (*BrandSummary, error) {
+	totals, err := u.itemRepo.GetSummaryByBrand(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get brand summary: %w", err)
+	}
+
+	brands := make([]BrandValue, 0, len(totals))
+	for _, t := range totals {
+		brands = append(brands, BrandValue{
+			Brand:    t.Brand,
+			Currency: t.Currency,
+			Count:    t.Count,
+			Total:    t.Total,
+			TotalJPY: t.TotalJPY,
+		})
+	}
+
+	return &BrandSummary{Brands: brands}, nil
+}
+
+func (u *itemUsecase) GetYearCategorySummary(ctx context.Context) (*YearCategorySummary, error) {
+	totals, err := u.itemRepo.GetSummaryByYearAndCategory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get year category summary: %w", err)
+	}
+
+	categories := append(append([]string{}, entity.GetValidCategories()...), entity.UncategorizedCategory)
+	summary := &YearCategorySummary{
+		Categories: categories,
+		Years:      []YearCategoryRow{},
+	}
+	if len(totals) == 0 {
+		return summary, nil
+	}
+
+	// 集計結果は年の昇順
+	firstYear, lastYear := totals[0].Year, totals[len(totals)-1].Year
+	for year := firstYear; year <= lastYear; year++ {
+		row := YearCategoryRow{Year: year, Categories: make(map[string]YearCategoryCell, len(categories))}
+		for _, category := range categories {
+			row.Categories[category] = YearCategoryCell{Totals: []CurrencyTotal{}}
+		}
+		summary.Years = append(summary.Years, row)
+	}
+
+	for _, t := range totals {
+		row := &summary.Years[t.Year-firstYear]
+		cell := row.Categories[t.Category]
+		cell.Count += t.Count
+		cell.TotalJPY += t.TotalJPY
+		cell.Totals = append(cell.Totals, CurrencyTotal{Currency: t.Currency, Total: t.Total})
+		row.Categories[t.Category] = cell
+		row.Count += t.Count
+		row.TotalJPY += t.TotalJPY
+	}
+
+	return summary, nil
+}
@@ -1,13 +1,17 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
@@ -18,11 +22,16 @@ type MockItemRepository struct {
 	mock.Mock
 }
 
-func (m *MockItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
-	args := m.Called(ctx)
+func (m *MockItemRepository) FindAll(ctx context.Context, query entity.ItemQuery) ([]*entity.Item, error) {
+	args := m.Called(ctx, query)
 	return args.Get(0).([]*entity.Item), args.Error(1)
 }
 
+func (m *MockItemRepository) Count(ctx context.Context, query entity.ItemQuery) (int, error) {
+	args := m.Called(ctx, query)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -39,6 +48,14 @@ func (m *MockItemRepository) Create(ctx context.Context, item *entity.Item) (*en
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
+func (m *MockItemRepository) CreateMany(ctx context.Context, items []*entity.Item) ([]*entity.Item, error) {
+	args := m.Called(ctx, items)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
 func (m *MockItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
 	args := m.Called(ctx, item)
 	if args.Get(0) == nil {
@@ -47,11 +64,61 @@ func (m *MockItemRepository) Update(ctx context.Context, item *entity.Item) (*en
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
-func (m *MockItemRepository) Delete(ctx context.Context, id int64) error {
+func (m *MockItemRepository) Delete(ctx context.Context, id int64, now time.Time) error {
+	args := m.Called(ctx, id, now)
+	return args.Error(0)
+}
+
+func (m *MockItemRepository) Restore(ctx context.Context, id int64, now time.Time) error {
+	args := m.Called(ctx, id, now)
+	return args.Error(0)
+}
+
+func (m *MockItemRepository) SetHold(ctx context.Context, id int64, onHold bool, reason string, now time.Time) error {
+	args := m.Called(ctx, id, onHold, reason, now)
+	return args.Error(0)
+}
+
+func (m *MockItemRepository) SetSold(ctx context.Context, id int64, now time.Time) error {
+	args := m.Called(ctx, id, now)
+	return args.Error(0)
+}
+
+func (m *MockItemRepository) Split(ctx context.Context, id int64, components []*entity.Item, now time.Time) ([]*entity.Item, error) {
+	args := m.Called(ctx, id, components, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	// 受け取った構成品から戻り値を作る場合
+	if fn, ok := args.Get(0).(func(context.Context, int64, []*entity.Item) []*entity.Item); ok {
+		return fn(ctx, id, components), args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) SchedulePurge(ctx context.Context, id int64, purgeAt *time.Time, now time.Time) error {
+	args := m.Called(ctx, id, purgeAt, now)
+	return args.Error(0)
+}
+
+func (m *MockItemRepository) FindDuePurges(ctx context.Context, now time.Time, limit int) ([]*entity.Item, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) Purge(ctx context.Context, id int64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockItemRepository) SumPurchasePrice(ctx context.Context, category, currency string, year int, excludeID int64) (int64, error) {
+	args := m.Called(ctx, category, currency, year, excludeID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -60,6 +127,101 @@ func (m *MockItemRepository) GetSummaryByCategory(ctx context.Context) (map[stri
 	return args.Get(0).(map[string]int), args.Error(1)
 }
 
+func (m *MockItemRepository) GetValueSummaryByCategory(ctx context.Context) ([]entity.CategoryValueTotal, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.CategoryValueTotal), args.Error(1)
+}
+
+func (m *MockItemRepository) FindByDedupeKey(ctx context.Context, key string) ([]*entity.Item, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) ExistsSimilar(ctx context.Context, dedupeKey string, purchaseDate entity.Date) (bool, error) {
+	args := m.Called(ctx, dedupeKey, purchaseDate)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockItemRepository) GetSummaryByBrand(ctx context.Context) ([]entity.BrandValueTotal, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.BrandValueTotal), args.Error(1)
+}
+
+func (m *MockItemRepository) GetSummaryByYearAndCategory(ctx context.Context) ([]entity.YearCategoryValueTotal, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.YearCategoryValueTotal), args.Error(1)
+}
+
+func (m *MockItemRepository) GetMonthlyPurchaseTotals(ctx context.Context, from, to string) ([]entity.MonthlyPurchaseTotal, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.MonthlyPurchaseTotal), args.Error(1)
+}
+
+func (m *MockItemRepository) GetCustomsValueTotals(ctx context.Context, from, to string) ([]entity.CustomsValueTotal, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.CustomsValueTotal), args.Error(1)
+}
+
+func (m *MockItemRepository) GetTaxTotals(ctx context.Context, from, to string) ([]entity.TaxTotal, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.TaxTotal), args.Error(1)
+}
+
+// MockBudgetRepository はカテゴリー予算のモックリポジトリ
+type MockBudgetRepository struct {
+	mock.Mock
+}
+
+func (m *MockBudgetRepository) FindAll(ctx context.Context) ([]*entity.CategoryBudget, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.CategoryBudget), args.Error(1)
+}
+
+func (m *MockBudgetRepository) FindByCategory(ctx context.Context, category string) (*entity.CategoryBudget, error) {
+	args := m.Called(ctx, category)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CategoryBudget), args.Error(1)
+}
+
+func (m *MockBudgetRepository) Save(ctx context.Context, budget *entity.CategoryBudget) (*entity.CategoryBudget, error) {
+	args := m.Called(ctx, budget)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CategoryBudget), args.Error(1)
+}
+
+func (m *MockBudgetRepository) Delete(ctx context.Context, category string) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+
 func TestNewItemUsecase(t *testing.T) {
 	mockRepo := new(MockItemRepository)
 	usecase := NewItemUsecase(mockRepo)
@@ -69,38 +231,145 @@ func TestNewItemUsecase(t *testing.T) {
 
 func TestItemUsecase_GetAllItems(t *testing.T) {
 	tests := []struct {
-		name          string
-		setupMock     func(*MockItemRepository)
-		expectedCount int
-		expectedErr   error
+		name           string
+		input          ListItemsInput
+		setupMock      func(*MockItemRepository)
+		expectedCount  int
+		expectedTotal  int
+		expectedLimit  int
+		expectedOffset int
+		expectedErr    error
 	}{
 		{
-			name: "正常系: 複数のアイテムを取得",
+			name:  "正常系: 複数のアイテムを取得",
+			input: ListItemsInput{},
 			setupMock: func(mockRepo *MockItemRepository) {
-				item1, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
-				item2, _ := entity.NewItem("バッグ1", "バッグ", "HERMÈS", 500000, "2023-01-02")
+				item1, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+				item2, _ := entity.NewItem("バッグ1", "バッグ", "HERMÈS", entity.JPY(500000), entity.MustParseDate("2023-01-02"), time.Now())
 				items := []*entity.Item{item1, item2}
-				mockRepo.On("FindAll", mock.Anything).Return(items, nil)
+				query := entity.ItemQuery{Limit: DefaultItemsLimit, Offset: 0, Sort: entity.SortByCreatedAt, Order: entity.SortDesc}
+				mockRepo.On("FindAll", mock.Anything, query).Return(items, nil)
+				mockRepo.On("Count", mock.Anything, query).Return(2, nil)
 			},
-			expectedCount: 2,
-			expectedErr:   nil,
+			expectedCount:  2,
+			expectedTotal:  2,
+			expectedLimit:  DefaultItemsLimit,
+			expectedOffset: 0,
+			expectedErr:    nil,
 		},
 		{
-			name: "正常系: アイテムが0件",
+			name:  "正常系: limitとoffsetを指定",
+			input: ListItemsInput{Limit: 1, Offset: 1},
 			setupMock: func(mockRepo *MockItemRepository) {
-				items := []*entity.Item{}
-				mockRepo.On("FindAll", mock.Anything).Return(items, nil)
+				item, _ := entity.NewItem("バッグ1", "バッグ", "HERMÈS", entity.JPY(500000), entity.MustParseDate("2023-01-02"), time.Now())
+				query := entity.ItemQuery{Limit: 1, Offset: 1, Sort: entity.SortByCreatedAt, Order: entity.SortDesc}
+				mockRepo.On("FindAll", mock.Anything, query).Return([]*entity.Item{item}, nil)
+				mockRepo.On("Count", mock.Anything, query).Return(5, nil)
 			},
-			expectedCount: 0,
-			expectedErr:   nil,
+			expectedCount:  1,
+			expectedTotal:  5,
+			expectedLimit:  1,
+			expectedOffset: 1,
+			expectedErr:    nil,
 		},
 		{
-			name: "異常系: データベースエラー",
+			name:  "正常系: 購入価格の降順で並び替え",
+			input: ListItemsInput{Sort: "purchase_price", Order: "DESC"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+				query := entity.ItemQuery{Limit: DefaultItemsLimit, Offset: 0, Sort: entity.SortByPurchasePrice, Order: entity.SortDesc}
+				mockRepo.On("FindAll", mock.Anything, query).Return([]*entity.Item{item}, nil)
+				mockRepo.On("Count", mock.Anything, query).Return(1, nil)
+			},
+			expectedCount:  1,
+			expectedTotal:  1,
+			expectedLimit:  DefaultItemsLimit,
+			expectedOffset: 0,
+			expectedErr:    nil,
+		},
+		{
+			name:  "正常系: 名前の昇順で並び替え",
+			input: ListItemsInput{Sort: "name", Order: "asc"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				query := entity.ItemQuery{Limit: DefaultItemsLimit, Offset: 0, Sort: entity.SortByName, Order: entity.SortAsc}
+				mockRepo.On("FindAll", mock.Anything, query).Return([]*entity.Item{}, nil)
+				mockRepo.On("Count", mock.Anything, query).Return(0, nil)
+			},
+			expectedCount:  0,
+			expectedTotal:  0,
+			expectedLimit:  DefaultItemsLimit,
+			expectedOffset: 0,
+			expectedErr:    nil,
+		},
+		{
+			name:  "異常系: 許可されていない並び替えキー",
+			input: ListItemsInput{Sort: "id; DROP TABLE items"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// FindAllは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 不正な並び順",
+			input: ListItemsInput{Sort: "name", Order: "random"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// FindAllは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "正常系: アイテムが0件",
+			input: ListItemsInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindAll", mock.Anything, mock.Anything).Return(([]*entity.Item)(nil), nil)
+				mockRepo.On("Count", mock.Anything, mock.Anything).Return(0, nil)
+			},
+			expectedCount:  0,
+			expectedTotal:  0,
+			expectedLimit:  DefaultItemsLimit,
+			expectedOffset: 0,
+			expectedErr:    nil,
+		},
+		{
+			name:  "異常系: limitが上限超過",
+			input: ListItemsInput{Limit: MaxItemsLimit + 1},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// FindAllは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: offsetが負の値",
+			input: ListItemsInput{Offset: -1},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// FindAllは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: changed_afterが負の値",
+			input: ListItemsInput{ChangedAfter: -1},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// FindAllは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			input: ListItemsInput{},
 			setupMock: func(mockRepo *MockItemRepository) {
-				mockRepo.On("FindAll", mock.Anything).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
+				mockRepo.On("FindAll", mock.Anything, mock.Anything).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
 			},
-			expectedCount: 0,
-			expectedErr:   domainErrors.ErrDatabaseError,
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+		{
+			name:  "異常系: 件数取得でデータベースエラー",
+			input: ListItemsInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{}, nil)
+				mockRepo.On("Count", mock.Anything, mock.Anything).Return(0, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
 		},
 	}
 
@@ -111,17 +380,23 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 			usecase := NewItemUsecase(mockRepo)
 
 			ctx := context.Background()
-			items, err := usecase.GetAllItems(ctx)
+			list, err := usecase.GetAllItems(ctx, tt.input)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
 				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, list)
 				mockRepo.AssertExpectations(t)
 				return
 			}
 
-			assert.NoError(t, err)
-			assert.Len(t, items, tt.expectedCount)
+			require.NoError(t, err)
+			require.NotNil(t, list)
+			assert.NotNil(t, list.Items)
+			assert.Len(t, list.Items, tt.expectedCount)
+			assert.Equal(t, tt.expectedTotal, list.Total)
+			assert.Equal(t, tt.expectedLimit, list.Limit)
+			assert.Equal(t, tt.expectedOffset, list.Offset)
 			mockRepo.AssertExpectations(t)
 		})
 	}
@@ -139,7 +414,7 @@ func TestItemUsecase_GetItemByID(t *testing.T) {
 			name: "正常系: 存在するアイテムを取得",
 			id:   1,
 			setupMock: func(mockRepo *MockItemRepository) {
-				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
 				item.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
 			},
@@ -213,23 +488,43 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				Name:          "ロレックス デイトナ",
 				Category:      "時計",
 				Brand:         "ROLEX",
-				PurchasePrice: 1500000,
+				PurchasePrice: entity.JPY(1500000),
 				PurchaseDate:  "2023-01-15",
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				createdItem, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+				createdItem, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), entity.MustParseDate("2023-01-15"), time.Now())
 				createdItem.ID = 1
 				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(createdItem, nil)
 			},
 			expectError: false,
 		},
+		{
+			name: "正常系: カテゴリー固有の属性を指定して作成",
+			input: CreateItemInput{
+				Name:          "ロレックス デイトナ",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: entity.JPY(1500000),
+				PurchaseDate:  "2023-01-15",
+				Attributes:    map[string]string{entity.AttrReferenceNumber: "116500LN"},
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				createdItem, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), entity.MustParseDate("2023-01-15"), time.Now(),
+					entity.WithAttributes(map[string]string{entity.AttrReferenceNumber: "116500LN"}))
+				createdItem.ID = 1
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.Attributes[entity.AttrReferenceNumber] == "116500LN"
+				})).Return(createdItem, nil)
+			},
+			expectError: false,
+		},
 		{
 			name: "異常系: 無効な入力（名前が空）",
 			input: CreateItemInput{
 				Name:          "",
 				Category:      "時計",
 				Brand:         "ROLEX",
-				PurchasePrice: 1500000,
+				PurchasePrice: entity.JPY(1500000),
 				PurchaseDate:  "2023-01-15",
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
@@ -244,7 +539,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				Name:          "アイテム",
 				Category:      "無効なカテゴリー",
 				Brand:         "ブランド",
-				PurchasePrice: 100000,
+				PurchasePrice: entity.JPY(100000),
 				PurchaseDate:  "2023-01-15",
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
@@ -259,7 +554,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				Name:          "アイテム",
 				Category:      "時計",
 				Brand:         "ブランド",
-				PurchasePrice: 100000,
+				PurchasePrice: entity.JPY(100000),
 				PurchaseDate:  "2023-01-15",
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
@@ -272,6 +567,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockItemRepository)
+			mockRepo.On("FindByDedupeKey", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 			tt.setupMock(mockRepo)
 			usecase := NewItemUsecase(mockRepo)
 
@@ -291,7 +587,8 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				assert.Equal(t, tt.input.Category, item.Category)
 				assert.Equal(t, tt.input.Brand, item.Brand)
 				assert.Equal(t, tt.input.PurchasePrice, item.PurchasePrice)
-				assert.Equal(t, tt.input.PurchaseDate, item.PurchaseDate)
+				assert.Equal(t, tt.input.PurchaseDate, item.PurchaseDate.String())
+				assert.Equal(t, tt.input.Attributes, item.Attributes)
 			}
 
 			mockRepo.AssertExpectations(t)
@@ -301,21 +598,21 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 
 func TestItemUsecase_UpdateItem(t *testing.T) {
 	strPtr := func(s string) *string { return &s }
-	intPtr := func(i int) *int { return &i }
+	moneyPtr := func(m entity.Money) *entity.Money { return &m }
 
 	tests := []struct {
 		name      string
 		id        int64
 		input     UpdateItemInput
 		setupMock func(*MockItemRepository)
-		check     func(t *testing.T, item *entity.Item, err error)
+		check     func(t *testing.T, item *ItemResult, err error)
 	}{
 		{
 			name: "正常系: nameとpurchase_priceを更新",
 			id:   1,
 			input: UpdateItemInput{
 				Name:          strPtr("ロレックス デイトナ（整備済み）"),
-				PurchasePrice: intPtr(1600000),
+				PurchasePrice: moneyPtr(entity.JPY(1600000)),
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
 				existingItem := &entity.Item{
@@ -323,8 +620,8 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 					Name:          "ロレックス デイトナ",
 					Category:      "時計",
 					Brand:         "ROLEX",
-					PurchasePrice: 1500000,
-					PurchaseDate:  "2023-01-15",
+					PurchasePrice: entity.JPY(1500000),
+					PurchaseDate:  entity.MustParseDate("2023-01-15"),
 					CreatedAt:     time.Date(2025, 10, 24, 7, 24, 45, 0, time.UTC),
 					UpdatedAt:     time.Date(2025, 10, 24, 7, 24, 45, 0, time.UTC),
 				}
@@ -333,8 +630,8 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 					Name:          "ロレックス デイトナ（整備済み）",
 					Category:      "時計",
 					Brand:         "ROLEX",
-					PurchasePrice: 1600000,
-					PurchaseDate:  "2023-01-15",
+					PurchasePrice: entity.JPY(1600000),
+					PurchaseDate:  entity.MustParseDate("2023-01-15"),
 					CreatedAt:     existingItem.CreatedAt,
 					UpdatedAt:     time.Date(2025, 10, 24, 8, 6, 52, 0, time.UTC),
 				}
@@ -345,16 +642,69 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 						item.Name == "ロレックス デイトナ（整備済み）" &&
 						item.Brand == "ROLEX" &&
 						item.Category == "時計" &&
-						item.PurchasePrice == 1600000 &&
-						item.PurchaseDate == "2023-01-15"
+						item.PurchasePrice == entity.JPY(1600000) &&
+						item.PurchaseDate.String() == "2023-01-15"
 				})).Return(updatedItem, nil)
 			},
-			check: func(t *testing.T, item *entity.Item, err error) {
+			check: func(t *testing.T, item *ItemResult, err error) {
 				require.NoError(t, err)
 				require.NotNil(t, item)
 				assert.Equal(t, int64(1), item.ID)
 				assert.Equal(t, "ロレックス デイトナ（整備済み）", item.Name)
-				assert.Equal(t, 1600000, item.PurchasePrice)
+				assert.Equal(t, entity.JPY(1600000), item.PurchasePrice)
+			},
+		},
+		{
+			name: "正常系: category・purchase_dateを更新し、brandをnullで空にする",
+			id:   1,
+			input: UpdateItemInput{
+				Brand:        NullableString{Set: true},
+				Category:     strPtr("その他"),
+				PurchaseDate: strPtr("2024-03-01"),
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				existingItem := &entity.Item{
+					ID:            1,
+					Name:          "ロレックス デイトナ",
+					Category:      "時計",
+					Brand:         "ROLEX",
+					PurchasePrice: entity.JPY(1500000),
+					PurchaseDate:  entity.MustParseDate("2023-01-15"),
+				}
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.Name == "ロレックス デイトナ" &&
+						item.Brand == "" &&
+						item.Category == "その他" &&
+						item.PurchaseDate.String() == "2024-03-01"
+				})).Return(&entity.Item{ID: 1, Category: "その他", PurchaseDate: entity.MustParseDate("2024-03-01")}, nil)
+			},
+			check: func(t *testing.T, item *ItemResult, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "その他", item.Category)
+				assert.Equal(t, "2024-03-01", item.PurchaseDate.String())
+			},
+		},
+		{
+			name: "異常系: 無効なpurchase_date",
+			id:   1,
+			input: UpdateItemInput{
+				PurchaseDate: strPtr("2024/03/01"),
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				existingItem := &entity.Item{
+					ID:            1,
+					Name:          "ロレックス デイトナ",
+					Category:      "時計",
+					Brand:         "ROLEX",
+					PurchasePrice: entity.JPY(1500000),
+					PurchaseDate:  entity.MustParseDate("2023-01-15"),
+				}
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+			},
+			check: func(t *testing.T, item *ItemResult, err error) {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				assert.Nil(t, item)
 			},
 		},
 		{
@@ -364,7 +714,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				Name: strPtr("any"),
 			},
 			setupMock: nil,
-			check: func(t *testing.T, item *entity.Item, err error) {
+			check: func(t *testing.T, item *ItemResult, err error) {
 				require.Error(t, err)
 				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
 				assert.Nil(t, item)
@@ -375,7 +725,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			id:        1,
 			input:     UpdateItemInput{},
 			setupMock: nil,
-			check: func(t *testing.T, item *entity.Item, err error) {
+			check: func(t *testing.T, item *ItemResult, err error) {
 				require.Error(t, err)
 				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
 				assert.Nil(t, item)
@@ -390,7 +740,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			setupMock: func(mockRepo *MockItemRepository) {
 				mockRepo.On("FindByID", mock.Anything, int64(99)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
 			},
-			check: func(t *testing.T, item *entity.Item, err error) {
+			check: func(t *testing.T, item *ItemResult, err error) {
 				require.Error(t, err)
 				assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
 				assert.Nil(t, item)
@@ -405,7 +755,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			setupMock: func(mockRepo *MockItemRepository) {
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return((*entity.Item)(nil), domainErrors.ErrDatabaseError)
 			},
-			check: func(t *testing.T, item *entity.Item, err error) {
+			check: func(t *testing.T, item *ItemResult, err error) {
 				require.Error(t, err)
 				assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
 				assert.Nil(t, item)
@@ -423,12 +773,12 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 					Name:          "ロレックス デイトナ",
 					Category:      "時計",
 					Brand:         "ROLEX",
-					PurchasePrice: 1500000,
-					PurchaseDate:  "2023-01-15",
+					PurchasePrice: entity.JPY(1500000),
+					PurchaseDate:  entity.MustParseDate("2023-01-15"),
 				}
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
 			},
-			check: func(t *testing.T, item *entity.Item, err error) {
+			check: func(t *testing.T, item *ItemResult, err error) {
 				require.Error(t, err)
 				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
 				assert.Nil(t, item)
@@ -446,13 +796,13 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 					Name:          "ロレックス デイトナ",
 					Category:      "時計",
 					Brand:         "ROLEX",
-					PurchasePrice: 1500000,
-					PurchaseDate:  "2023-01-15",
+					PurchasePrice: entity.JPY(1500000),
+					PurchaseDate:  entity.MustParseDate("2023-01-15"),
 				}
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
 				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Item")).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
 			},
-			check: func(t *testing.T, item *entity.Item, err error) {
+			check: func(t *testing.T, item *ItemResult, err error) {
 				require.Error(t, err)
 				assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
 				assert.Nil(t, item)
@@ -470,13 +820,13 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 					Name:          "ロレックス デイトナ",
 					Category:      "時計",
 					Brand:         "ROLEX",
-					PurchasePrice: 1500000,
-					PurchaseDate:  "2023-01-15",
+					PurchasePrice: entity.JPY(1500000),
+					PurchaseDate:  entity.MustParseDate("2023-01-15"),
 				}
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
 				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Item")).Return((*entity.Item)(nil), domainErrors.ErrDatabaseError)
 			},
-			check: func(t *testing.T, item *entity.Item, err error) {
+			check: func(t *testing.T, item *ItemResult, err error) {
 				require.Error(t, err)
 				assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
 				assert.Nil(t, item)
@@ -514,10 +864,10 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 			name: "正常系: 存在するアイテムを削除",
 			id:   1,
 			setupMock: func(mockRepo *MockItemRepository) {
-				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
 				item.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
-				mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+				mockRepo.On("Delete", mock.Anything, int64(1), mock.Anything).Return(nil)
 			},
 			expectError: false,
 		},
@@ -547,14 +897,28 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "異常系: 保全中のアイテム",
+			id:   1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+				item.ID = 1
+				item.OnHold = true
+				item.HoldReason = "保険請求中"
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				// Deleteは呼ばれない
+			},
+			expectError: true,
+			expectedErr: domainErrors.ErrItemOnHold,
+		},
 		{
 			name: "異常系: Deleteでデータベースエラー",
 			id:   1,
 			setupMock: func(mockRepo *MockItemRepository) {
-				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
 				item.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
-				mockRepo.On("Delete", mock.Anything, int64(1)).Return(domainErrors.ErrDatabaseError)
+				mockRepo.On("Delete", mock.Anything, int64(1), mock.Anything).Return(domainErrors.ErrDatabaseError)
 			},
 			expectError: true,
 		},
@@ -585,12 +949,15 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 
 func TestItemUsecase_GetCategorySummary(t *testing.T) {
 	tests := []struct {
-		name               string
-		setupMock          func(*MockItemRepository)
-		expectedTotal      int
-		expectedWatchCount int
-		expectedBagCount   int
-		expectError        bool
+		name                  string
+		setupMock             func(*MockItemRepository)
+		expectedTotal         int
+		expectedWatchCount    int
+		expectedBagCount      int
+		expectedUncategorized int
+		expectedWatchValues   []CategoryValue
+		expectedWatchValueJPY int64
+		expectError           bool
 	}{
 		{
 			name: "正常系: 複数カテゴリーのアイテムがある場合",
@@ -600,22 +967,57 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 					"バッグ": 1,
 				}
 				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
+				mockRepo.On("GetValueSummaryByCategory", mock.Anything).Return([]entity.CategoryValueTotal{
+					{Category: "バッグ", Currency: "JPY", Count: 1, Total: 300000, TotalJPY: 300000},
+					{Category: "時計", Currency: "JPY", Count: 1, Total: 1500000, TotalJPY: 1500000},
+					{Category: "時計", Currency: "USD", Count: 1, Total: 750001, TotalJPY: 1030000},
+				}, nil)
 			},
 			expectedTotal:      3,
 			expectedWatchCount: 2,
 			expectedBagCount:   1,
-			expectError:        false,
+			expectedWatchValues: []CategoryValue{
+				{Currency: "JPY", Count: 1, Total: 1500000, Average: 1500000, TotalJPY: 1500000},
+				{Currency: "USD", Count: 1, Total: 750001, Average: 750001, TotalJPY: 1030000},
+			},
+			expectedWatchValueJPY: 2530000,
+			expectError:           false,
+		},
+		{
+			name: "正常系: 未分類のアイテムがある場合",
+			setupMock: func(mockRepo *MockItemRepository) {
+				summary := map[string]int{
+					"時計":  1,
+					"未分類": 2,
+				}
+				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
+				mockRepo.On("GetValueSummaryByCategory", mock.Anything).Return([]entity.CategoryValueTotal{
+					{Category: "時計", Currency: "JPY", Count: 3, Total: 1000000, TotalJPY: 1000000},
+					{Category: "未分類", Currency: "JPY", Count: 2, Total: 50000, TotalJPY: 50000},
+				}, nil)
+			},
+			expectedTotal:         3,
+			expectedWatchCount:    1,
+			expectedBagCount:      0,
+			expectedUncategorized: 2,
+			expectedWatchValues: []CategoryValue{
+				{Currency: "JPY", Count: 3, Total: 1000000, Average: 333333, TotalJPY: 1000000},
+			},
+			expectedWatchValueJPY: 1000000,
+			expectError:           false,
 		},
 		{
 			name: "正常系: アイテムが0件の場合",
 			setupMock: func(mockRepo *MockItemRepository) {
 				summary := map[string]int{}
 				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
+				mockRepo.On("GetValueSummaryByCategory", mock.Anything).Return([]entity.CategoryValueTotal{}, nil)
 			},
-			expectedTotal:      0,
-			expectedWatchCount: 0,
-			expectedBagCount:   0,
-			expectError:        false,
+			expectedTotal:       0,
+			expectedWatchCount:  0,
+			expectedBagCount:    0,
+			expectedWatchValues: []CategoryValue{},
+			expectError:         false,
 		},
 		{
 			name: "異常系: データベースエラー",
@@ -624,6 +1026,14 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "異常系: 金額集計でデータベースエラー",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 1}, nil)
+				mockRepo.On("GetValueSummaryByCategory", mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -648,14 +1058,1432 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 			assert.Equal(t, tt.expectedTotal, summary.Total)
 			assert.Equal(t, tt.expectedWatchCount, summary.Categories["時計"])
 			assert.Equal(t, tt.expectedBagCount, summary.Categories["バッグ"])
+			assert.Equal(t, tt.expectedUncategorized, summary.Uncategorized)
+			assert.Equal(t, tt.expectedWatchValues, summary.Values["時計"])
+			assert.Equal(t, tt.expectedWatchValueJPY, summary.ValueJPY["時計"])
 
 			// すべてのカテゴリーがレスポンスに含まれているかチェック
 			expectedCategories := []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
 			for _, category := range expectedCategories {
 				assert.Contains(t, summary.Categories, category)
+				assert.Contains(t, summary.Values, category)
 			}
 
 			mockRepo.AssertExpectations(t)
 		})
 	}
 }
+
+func TestItemUsecase_ReadOnly(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	readOnly := NewReadOnlySwitch(true)
+	usecase := NewItemUsecase(mockRepo, WithReadOnlySwitch(readOnly))
+	ctx := context.Background()
+	name := "updated"
+
+	// 更新系はリポジトリを呼ばずにエラーを返す
+	_, err := usecase.CreateItem(ctx, CreateItemInput{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: entity.JPY(1500000),
+		PurchaseDate:  "2023-01-15",
+	})
+	assert.ErrorIs(t, err, domainErrors.ErrReadOnly)
+
+	_, err = usecase.UpdateItem(ctx, 1, UpdateItemInput{Name: &name})
+	assert.ErrorIs(t, err, domainErrors.ErrReadOnly)
+
+	err = usecase.DeleteItem(ctx, 1)
+	assert.ErrorIs(t, err, domainErrors.ErrReadOnly)
+
+	// 参照系は引き続き利用できる
+	item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+	item.ID = 1
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+
+	found, err := usecase.GetItemByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), found.ID)
+
+	// 読み取り専用モードを解除すると更新できる
+	readOnly.Set(false)
+	mockRepo.On("Delete", mock.Anything, int64(1), mock.Anything).Return(nil)
+	assert.NoError(t, usecase.DeleteItem(ctx, 1))
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemUsecase_SearchItems(t *testing.T) {
+	tests := []struct {
+		name          string
+		keyword       string
+		setupMock     func(*MockItemRepository)
+		expectedCount int
+		expectedErr   error
+	}{
+		{
+			name:    "正常系: キーワードで検索",
+			keyword: " デイトナ ",
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), entity.MustParseDate("2023-01-15"), time.Now())
+				query := entity.ItemQuery{Limit: DefaultItemsLimit, Sort: entity.SortByCreatedAt, Order: entity.SortDesc, Keyword: "デイトナ"}
+				mockRepo.On("FindAll", mock.Anything, query).Return([]*entity.Item{item}, nil)
+				mockRepo.On("Count", mock.Anything, query).Return(1, nil)
+			},
+			expectedCount: 1,
+		},
+		{
+			name:    "異常系: キーワードが空",
+			keyword: "   ",
+			setupMock: func(mockRepo *MockItemRepository) {
+				// FindAllは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:    "異常系: キーワードが長すぎる",
+			keyword: strings.Repeat("あ", MaxSearchKeywordLength+1),
+			setupMock: func(mockRepo *MockItemRepository) {
+				// FindAllは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:    "異常系: データベースエラー",
+			keyword: "ROLEX",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindAll", mock.Anything, mock.Anything).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			list, err := usecase.SearchItems(context.Background(), tt.keyword, ListItemsInput{})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, list)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, list.Items, tt.expectedCount)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_RequestScopedItemCache(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	usecase := NewItemUsecase(mockRepo)
+	ctx := WithItemCache(context.Background())
+
+	existingItem := &entity.Item{
+		ID:            1,
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: entity.JPY(1500000),
+		PurchaseDate:  entity.MustParseDate("2023-01-15"),
+	}
+	// 同一リクエスト内では FindByID は1回だけ呼ばれる
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil).Once()
+
+	first, err := usecase.GetItemByID(ctx, 1)
+	require.NoError(t, err)
+	second, err := usecase.GetItemByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	// 呼び出し側の変更はキャッシュに影響しない
+	second.Name = "changed"
+	third, err := usecase.GetItemByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "ロレックス デイトナ", third.Name)
+
+	// 更新後はキャッシュが更新結果に置き換わる
+	name := "ロレックス デイトナ（整備済み）"
+	updatedItem := *existingItem
+	updatedItem.Name = name
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(&updatedItem, nil).Once()
+
+	_, err = usecase.UpdateItem(ctx, 1, UpdateItemInput{Name: &name})
+	require.NoError(t, err)
+
+	afterUpdate, err := usecase.GetItemByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, name, afterUpdate.Name)
+
+	// 削除後はキャッシュから取り除かれ、再度リポジトリに問い合わせる
+	mockRepo.On("Delete", mock.Anything, int64(1), mock.Anything).Return(nil).Once()
+	require.NoError(t, usecase.DeleteItem(ctx, 1))
+
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound).Once()
+	_, err = usecase.GetItemByID(ctx, 1)
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemUsecase_CreateItem_DefaultCategory(t *testing.T) {
+	tests := []struct {
+		name             string
+		opts             []ItemUsecaseOption
+		category         string
+		expectedCategory string
+	}{
+		{
+			name:             "正常系: カテゴリー未指定は未分類になる",
+			category:         "",
+			expectedCategory: entity.UncategorizedCategory,
+		},
+		{
+			name:             "正常系: 既定のカテゴリーを「その他」に設定",
+			opts:             []ItemUsecaseOption{WithDefaultCategory("その他")},
+			category:         "  ",
+			expectedCategory: "その他",
+		},
+		{
+			name:             "正常系: カテゴリー指定時は既定のカテゴリーを使わない",
+			category:         "時計",
+			expectedCategory: "時計",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			mockRepo.On("FindByDedupeKey", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+			mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+				return item.Category == tt.expectedCategory
+			})).Return(&entity.Item{ID: 1, Category: tt.expectedCategory}, nil)
+			usecase := NewItemUsecase(mockRepo, tt.opts...)
+
+			item, err := usecase.CreateItem(context.Background(), CreateItemInput{
+				Name:          "クイック登録",
+				Category:      tt.category,
+				Brand:         "不明",
+				PurchasePrice: entity.JPY(10000),
+				PurchaseDate:  "2023-01-15",
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCategory, item.Category)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_Tax(t *testing.T) {
+	t.Run("正常系: 税率から税額を求める（既定は切り捨て）", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByDedupeKey", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.TaxAmount != nil && *item.TaxAmount == entity.JPY(1363) && item.TaxIncluded
+		})).Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(context.Background(), CreateItemInput{
+			Name:          "モンブラン マイスターシュテュック 149",
+			Category:      "その他",
+			Brand:         "Montblanc",
+			PurchasePrice: entity.JPY(15000),
+			PurchaseDate:  "2023-01-15",
+			TaxIncluded:   true,
+			TaxRate:       "10",
+		})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 端数処理を指定し、変更後の購入価格から税額を求める", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{
+			ID: 1, Name: "ナイキ ダンク ロー", Category: "靴", Brand: "NIKE", PurchasePrice: entity.JPY(15000), PurchaseDate: entity.MustParseDate("2023-01-15"),
+		}, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return *item.TaxAmount == entity.JPY(1501) && !item.TaxIncluded
+		})).Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo, WithTaxRounding(entity.RoundUp))
+
+		price := entity.JPY(15001)
+		rate := "10"
+		_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{PurchasePrice: &price, TaxRate: &rate})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 税額と税率の両方を指定", func(t *testing.T) {
+		usecase := NewItemUsecase(new(MockItemRepository))
+		rate := "10"
+
+		_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{TaxAmount: NewNullableInt64(100), TaxRate: &rate})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestItemUsecase_FieldErrors(t *testing.T) {
+	t.Run("異常系: 登録時の項目ごとのエラー", func(t *testing.T) {
+		usecase := NewItemUsecase(new(MockItemRepository))
+
+		_, err := usecase.CreateItem(context.Background(), CreateItemInput{
+			Name:          "ロレックス デイトナ",
+			Category:      "時計",
+			PurchasePrice: entity.JPY(-1),
+			PurchaseDate:  "2023-01-15",
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		var fieldErrs entity.ValidationErrors
+		require.ErrorAs(t, err, &fieldErrs)
+		assert.Equal(t, entity.ValidationErrors{
+			{Field: "brand", Reason: "is required"},
+			{Field: "purchase_price", Reason: "must be 0 or greater"},
+		}, fieldErrs)
+	})
+
+	t.Run("異常系: 更新時の項目ごとのエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{
+			ID: 1, Name: "ナイキ ダンク ロー", Category: "靴", Brand: "NIKE", PurchasePrice: entity.JPY(15000), PurchaseDate: entity.MustParseDate("2023-01-15"),
+		}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		date := "2023/01/15"
+		_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{PurchaseDate: &date})
+
+		var fieldErrs entity.ValidationErrors
+		require.ErrorAs(t, err, &fieldErrs)
+		assert.Equal(t, entity.ValidationErrors{{Field: "purchase_date", Reason: "must be in YYYY-MM-DD format"}}, fieldErrs)
+	})
+
+	t.Run("異常系: 不正な税率", func(t *testing.T) {
+		usecase := NewItemUsecase(new(MockItemRepository))
+
+		_, err := usecase.CreateItem(context.Background(), CreateItemInput{
+			Name:          "モンブラン マイスターシュテュック 149",
+			Category:      "その他",
+			Brand:         "Montblanc",
+			PurchasePrice: entity.JPY(15000),
+			PurchaseDate:  "2023-01-15",
+			TaxRate:       "abc",
+		})
+
+		var fieldErrs entity.ValidationErrors
+		require.ErrorAs(t, err, &fieldErrs)
+		assert.Equal(t, "tax_rate", fieldErrs[0].Field)
+	})
+}
+
+func TestItemUsecase_GetAllItems_CategoryFilter(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            ListItemsInput
+		expectedCategory string
+		expectedErr      error
+	}{
+		{
+			name:             "正常系: カテゴリーで絞り込み",
+			input:            ListItemsInput{Category: "時計"},
+			expectedCategory: "時計",
+		},
+		{
+			name:             "正常系: 未分類のみに絞り込み",
+			input:            ListItemsInput{Uncategorized: true},
+			expectedCategory: entity.UncategorizedCategory,
+		},
+		{
+			name:        "異常系: 無効なカテゴリー",
+			input:       ListItemsInput{Category: "衣服"},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: カテゴリーと未分類の同時指定",
+			input:       ListItemsInput{Category: "時計", Uncategorized: true},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			if tt.expectedErr == nil {
+				matcher := mock.MatchedBy(func(query entity.ItemQuery) bool {
+					return query.Category == tt.expectedCategory
+				})
+				mockRepo.On("FindAll", mock.Anything, matcher).Return([]*entity.Item{}, nil)
+				mockRepo.On("Count", mock.Anything, matcher).Return(0, nil)
+			}
+			usecase := NewItemUsecase(mockRepo)
+
+			list, err := usecase.GetAllItems(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, list)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, list)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_RestoreItem(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          int64
+		setupMock   func(*MockItemRepository)
+		expectedErr error
+	}{
+		{
+			name: "正常系: 論理削除されたアイテムを復元",
+			id:   1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+				item.ID = 1
+				mockRepo.On("Restore", mock.Anything, int64(1), mock.Anything).Return(nil)
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+		},
+		{
+			name: "正常系: 削除されていないアイテムの復元は冪等",
+			id:   1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+				item.ID = 1
+				mockRepo.On("Restore", mock.Anything, int64(1), mock.Anything).Return(domainErrors.ErrItemNotFound)
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+		},
+		{
+			name: "異常系: 存在しないアイテム",
+			id:   999,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Restore", mock.Anything, int64(999), mock.Anything).Return(domainErrors.ErrItemNotFound)
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name: "異常系: 無効なID（0以下）",
+			id:   0,
+			setupMock: func(mockRepo *MockItemRepository) {
+				// Restoreは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: データベースエラー",
+			id:   1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Restore", mock.Anything, int64(1), mock.Anything).Return(domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			item, err := usecase.RestoreItem(context.Background(), tt.id)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, item)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.id, item.ID)
+				assert.False(t, item.IsDeleted())
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_GetAllItems_IncludeDeleted(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	matcher := mock.MatchedBy(func(query entity.ItemQuery) bool {
+		return query.IncludeDeleted
+	})
+	mockRepo.On("FindAll", mock.Anything, matcher).Return([]*entity.Item{}, nil)
+	mockRepo.On("Count", mock.Anything, matcher).Return(0, nil)
+	usecase := NewItemUsecase(mockRepo)
+
+	_, err := usecase.GetAllItems(context.Background(), ListItemsInput{IncludeDeleted: true})
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemUsecase_CreateItems(t *testing.T) {
+	validInput := CreateItemInput{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: entity.JPY(1500000),
+		PurchaseDate:  "2023-01-15",
+	}
+	invalidInput := CreateItemInput{
+		Name:          "",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: entity.JPY(1500000),
+		PurchaseDate:  "2023-01-15",
+	}
+	tooMany := make([]CreateItemInput, MaxBulkItems+1)
+	for i := range tooMany {
+		tooMany[i] = validInput
+	}
+
+	tests := []struct {
+		name            string
+		input           BulkCreateItemsInput
+		setupMock       func(*MockItemRepository)
+		expectedCreated int
+		expectedFailed  []int
+		expectedErr     error
+	}{
+		{
+			name:  "正常系: 全件登録",
+			input: BulkCreateItemsInput{Items: []CreateItemInput{validInput, validInput}},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+					return len(items) == 2
+				})).Return([]*entity.Item{{ID: 1}, {ID: 2}}, nil)
+			},
+			expectedCreated: 2,
+		},
+		{
+			name:  "正常系: partial モードではエラーのない項目だけ登録",
+			input: BulkCreateItemsInput{Mode: BulkModePartial, Items: []CreateItemInput{validInput, invalidInput, validInput}},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+					return len(items) == 2
+				})).Return([]*entity.Item{{ID: 1}, {ID: 2}}, nil)
+			},
+			expectedCreated: 2,
+			expectedFailed:  []int{1},
+		},
+		{
+			name:  "正常系: partial モードで全件エラーの場合は登録しない",
+			input: BulkCreateItemsInput{Mode: BulkModePartial, Items: []CreateItemInput{invalidInput}},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// CreateManyは呼ばれない
+			},
+			expectedFailed: []int{0},
+		},
+		{
+			name:  "異常系: all_or_nothing モードでは1件でもエラーがあれば登録しない",
+			input: BulkCreateItemsInput{Mode: BulkModeAllOrNothing, Items: []CreateItemInput{validInput, invalidInput}},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// CreateManyは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 無効なモード",
+			input: BulkCreateItemsInput{Mode: "unknown", Items: []CreateItemInput{validInput}},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// CreateManyは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 項目が空",
+			input: BulkCreateItemsInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// CreateManyは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 上限件数を超過",
+			input: BulkCreateItemsInput{Items: tooMany},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// CreateManyは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			input: BulkCreateItemsInput{Items: []CreateItemInput{validInput}},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			result, err := usecase.CreateItems(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, result.Created, tt.expectedCreated)
+				require.Len(t, result.Failed, len(tt.expectedFailed))
+				for i, index := range tt.expectedFailed {
+					assert.Equal(t, index, result.Failed[i].Index)
+					assert.Contains(t, result.Failed[i].Errors, "name is required")
+				}
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_CreateItems_BulkValidationError(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	usecase := NewItemUsecase(mockRepo)
+
+	_, err := usecase.CreateItems(context.Background(), BulkCreateItemsInput{
+		Items: []CreateItemInput{
+			{Name: "", Category: "時計", Brand: "", PurchasePrice: entity.JPY(1000), PurchaseDate: "2023-01-15"},
+		},
+	})
+
+	var bulkErr *BulkValidationError
+	require.ErrorAs(t, err, &bulkErr)
+	require.Len(t, bulkErr.Failed, 1)
+	assert.Equal(t, 0, bulkErr.Failed[0].Index)
+	assert.Equal(t, []string{"name is required", "brand is required"}, bulkErr.Failed[0].Errors)
+	mockRepo.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+}
+
+// fakeExportFormatter は表記を区別できるよう値に印を付けるだけの ExportFormatter
+type fakeExportFormatter struct{}
+
+func (fakeExportFormatter) FormatMoney(m entity.Money) string  { return "money:" + m.String() }
+func (fakeExportFormatter) FormatDate(date entity.Date) string { return "date:" + date.String() }
+func (fakeExportFormatter) FormatTime(t time.Time) string      { return "time:" + t.Format(time.DateOnly) }
+
+func TestItemUsecase_ExportItems_Formatter(t *testing.T) {
+	item, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), entity.MustParseDate("2023-01-15"), time.Now())
+	item.ID = 1
+	item.CreatedAt = time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC)
+	item.UpdatedAt = item.CreatedAt
+
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{item}, nil)
+	usecase := NewItemUsecase(mockRepo)
+
+	var buf bytes.Buffer
+	err := usecase.ExportItems(context.Background(), &buf, ExportItemsInput{Formatter: fakeExportFormatter{}})
+
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	// 通貨コードの列は表記によらずそのまま出力する
+	assert.Equal(t, "1,ロレックス デイトナ,時計,ROLEX,money:1500000 JPY,JPY,date:2023-01-15,,time:2023-01-15,time:2023-01-15,,,false", lines[1])
+}
+
+func TestItemUsecase_ExportItems(t *testing.T) {
+	newItem := func(id int64) *entity.Item {
+		item, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), entity.MustParseDate("2023-01-15"), time.Now())
+		item.ID = id
+		item.CreatedAt = time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC)
+		item.UpdatedAt = item.CreatedAt
+		return item
+	}
+	firstBatch := make([]*entity.Item, ExportBatchSize)
+	for i := range firstBatch {
+		firstBatch[i] = newItem(int64(i + 1))
+	}
+
+	tests := []struct {
+		name          string
+		input         ExportItemsInput
+		setupMock     func(*MockItemRepository)
+		expectedLines int
+		expectedBOM   bool
+		expectedErr   error
+	}{
+		{
+			name:  "正常系: CSVを出力",
+			input: ExportItemsInput{Format: "csv"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindAll", mock.Anything, entity.ItemQuery{Limit: ExportBatchSize, Sort: entity.SortByID, Order: entity.SortAsc}).
+					Return([]*entity.Item{newItem(1), newItem(2)}, nil)
+			},
+			expectedLines: 3,
+		},
+		{
+			name:  "正常系: BOM付きで出力",
+			input: ExportItemsInput{BOM: true},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{newItem(1)}, nil)
+			},
+			expectedLines: 2,
+			expectedBOM:   true,
+		},
+		{
+			name:  "正常系: カーソルで次のバッチを読み込む",
+			input: ExportItemsInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindAll", mock.Anything, entity.ItemQuery{Limit: ExportBatchSize, Sort: entity.SortByID, Order: entity.SortAsc}).
+					Return(firstBatch, nil)
+				mockRepo.On("FindAll", mock.Anything, entity.ItemQuery{Limit: ExportBatchSize, Sort: entity.SortByID, Order: entity.SortAsc, AfterID: ExportBatchSize}).
+					Return([]*entity.Item{newItem(ExportBatchSize + 1)}, nil)
+			},
+			expectedLines: ExportBatchSize + 2,
+		},
+		{
+			name:  "正常系: アイテムが0件の場合はヘッダーのみ",
+			input: ExportItemsInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{}, nil)
+			},
+			expectedLines: 1,
+		},
+		{
+			name:  "異常系: 未対応の形式",
+			input: ExportItemsInput{Format: "pdf"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// FindAllは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			input: ExportItemsInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{}, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			var buf bytes.Buffer
+			err := usecase.ExportItems(context.Background(), &buf, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Zero(t, buf.Len())
+			} else {
+				require.NoError(t, err)
+				out := buf.String()
+				assert.Equal(t, tt.expectedBOM, strings.HasPrefix(out, "\xEF\xBB\xBF"))
+				lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+				assert.Len(t, lines, tt.expectedLines)
+				assert.Equal(t, "id,name,category,brand,purchase_price,currency,purchase_date,attributes,created_at,updated_at,purchase_country,tax_amount,tax_included", strings.TrimPrefix(lines[0], "\xEF\xBB\xBF"))
+				if len(lines) > 1 {
+					assert.Equal(t, "1,ロレックス デイトナ,時計,ROLEX,1500000,JPY,2023-01-15,,2023-01-15T10:00:00Z,2023-01-15T10:00:00Z,,,false", lines[1])
+				}
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_ImportItems(t *testing.T) {
+	createdItems := func(items []*entity.Item) []*entity.Item {
+		created := make([]*entity.Item, len(items))
+		for i, item := range items {
+			copied := *item
+			copied.ID = int64(i + 1)
+			created[i] = &copied
+		}
+		return created
+	}
+
+	tests := []struct {
+		name             string
+		input            ImportItemsInput
+		setupMock        func(*MockItemRepository)
+		expectedAccepted []ImportedRow
+		expectedRejected []RejectedRow
+		expectedErr      error
+	}{
+		{
+			name: "正常系: CSVの全行を登録",
+			input: ImportItemsInput{
+				Format: "csv",
+				File: strings.NewReader("name,category,brand,purchase_price,purchase_date\n" +
+					"ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n" +
+					"エルメス バーキン,バッグ,HERMÈS,2000000,2023-02-20\n"),
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+					return len(items) == 2
+				})).Return(createdItems([]*entity.Item{{}, {}}), nil)
+			},
+			expectedAccepted: []ImportedRow{{Row: 2, ID: 1}, {Row: 3, ID: 2}},
+			expectedRejected: []RejectedRow{},
+		},
+		{
+			name: "正常系: エラーのある行は理由とともに返す",
+			input: ImportItemsInput{
+				Format: "csv",
+				File: strings.NewReader("\xEF\xBB\xBFname,category,brand,purchase_price,currency,purchase_date,attributes\n" +
+					",時計,ROLEX,1500000,,2023-01-15,\n" +
+					"オメガ スピードマスター,時計,OMEGA,abc,,2023-01-15,\n" +
+					"\n" +
+					"カルティエ ラブ,ジュエリー,Cartier,750000,USD,2023-03-01,\"{\"\"material\"\":\"\"18K\"\"}\"\n"),
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+					return len(items) == 1 &&
+						items[0].PurchasePrice == entity.Money{Amount: 750000, Currency: "USD"} &&
+						items[0].Attributes["material"] == "18K"
+				})).Return([]*entity.Item{{ID: 10}}, nil)
+			},
+			expectedAccepted: []ImportedRow{{Row: 5, ID: 10}},
+			expectedRejected: []RejectedRow{
+				{Row: 2, Errors: []string{"name is required"}},
+				{Row: 3, Errors: []string{"purchase_price must be an integer"}},
+			},
+		},
+		{
+			name: "正常系: XLSXを登録",
+			input: ImportItemsInput{
+				Format: "xlsx",
+				File:   newTestXLSX(t, [][]string{{"name", "category", "brand", "purchase_price", "purchase_date"}, {"ロレックス デイトナ", "時計", "ROLEX", "1500000", "2023-01-15"}}),
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+					return len(items) == 1 && items[0].Name == "ロレックス デイトナ"
+				})).Return([]*entity.Item{{ID: 1}}, nil)
+			},
+			expectedAccepted: []ImportedRow{{Row: 2, ID: 1}},
+			expectedRejected: []RejectedRow{},
+		},
+		{
+			name: "異常系: 必須の列がない",
+			input: ImportItemsInput{
+				Format: "csv",
+				File:   strings.NewReader("name,category\nロレックス デイトナ,時計\n"),
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// CreateManyは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: 空のファイル",
+			input: ImportItemsInput{
+				Format: "csv",
+				File:   strings.NewReader(""),
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// CreateManyは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: 未対応の形式",
+			input: ImportItemsInput{
+				Format: "json",
+				File:   strings.NewReader("[]"),
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// CreateManyは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: データベースエラー",
+			input: ImportItemsInput{
+				Format: "csv",
+				File:   strings.NewReader("name,category,brand,purchase_price,purchase_date\nロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n"),
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			result, err := usecase.ImportItems(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedAccepted, result.Accepted)
+				assert.Equal(t, tt.expectedRejected, result.Rejected)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func newTestXLSX(t *testing.T, rows [][]string) *bytes.Buffer {
+	t.Helper()
+
+	f := excelize.NewFile()
+	defer f.Close()
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		require.NoError(t, err)
+		require.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+
+	buf, err := f.WriteToBuffer()
+	require.NoError(t, err)
+	return buf
+}
+
+func TestItemUsecase_CreateItem_BudgetCheck(t *testing.T) {
+	input := CreateItemInput{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: entity.JPY(1500000),
+		PurchaseDate:  "2023-01-15",
+	}
+	budget := &entity.CategoryBudget{Category: "時計", Amount: entity.JPY(2000000)}
+
+	tests := []struct {
+		name             string
+		enforcement      BudgetEnforcement
+		input            CreateItemInput
+		setupMock        func(*MockItemRepository, *MockBudgetRepository)
+		expectedWarnings []string
+		expectedErr      error
+	}{
+		{
+			name:        "正常系: 予算内",
+			enforcement: BudgetWarn,
+			input:       input,
+			setupMock: func(itemRepo *MockItemRepository, budgetRepo *MockBudgetRepository) {
+				budgetRepo.On("FindByCategory", mock.Anything, "時計").Return(budget, nil)
+				itemRepo.On("SumPurchasePrice", mock.Anything, "時計", "JPY", 2023, int64(0)).Return(int64(500000), nil)
+				itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil)
+			},
+		},
+		{
+			name:        "正常系: 予算超過は警告を返す",
+			enforcement: BudgetWarn,
+			input:       input,
+			setupMock: func(itemRepo *MockItemRepository, budgetRepo *MockBudgetRepository) {
+				budgetRepo.On("FindByCategory", mock.Anything, "時計").Return(budget, nil)
+				itemRepo.On("SumPurchasePrice", mock.Anything, "時計", "JPY", 2023, int64(0)).Return(int64(1000000), nil)
+				itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil)
+			},
+			expectedWarnings: []string{"2023 budget for 時計 exceeded: 2500000 JPY of 2000000 JPY"},
+		},
+		{
+			name:        "正常系: 予算未設定",
+			enforcement: BudgetBlock,
+			input:       input,
+			setupMock: func(itemRepo *MockItemRepository, budgetRepo *MockBudgetRepository) {
+				budgetRepo.On("FindByCategory", mock.Anything, "時計").Return(nil, nil)
+				itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil)
+			},
+		},
+		{
+			name:        "正常系: 予算と異なる通貨はチェックしない",
+			enforcement: BudgetBlock,
+			input: CreateItemInput{
+				Name:          "オメガ スピードマスター",
+				Category:      "時計",
+				Brand:         "OMEGA",
+				PurchasePrice: entity.Money{Amount: 750000, Currency: "USD"},
+				PurchaseDate:  "2023-01-15",
+			},
+			setupMock: func(itemRepo *MockItemRepository, budgetRepo *MockBudgetRepository) {
+				budgetRepo.On("FindByCategory", mock.Anything, "時計").Return(budget, nil)
+				itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil)
+			},
+		},
+		{
+			name:        "異常系: block の場合は予算超過で拒否",
+			enforcement: BudgetBlock,
+			input:       input,
+			setupMock: func(itemRepo *MockItemRepository, budgetRepo *MockBudgetRepository) {
+				budgetRepo.On("FindByCategory", mock.Anything, "時計").Return(budget, nil)
+				itemRepo.On("SumPurchasePrice", mock.Anything, "時計", "JPY", 2023, int64(0)).Return(int64(1000000), nil)
+			},
+			expectedErr: domainErrors.ErrBudgetExceeded,
+		},
+		{
+			name:        "異常系: データベースエラー",
+			enforcement: BudgetWarn,
+			input:       input,
+			setupMock: func(itemRepo *MockItemRepository, budgetRepo *MockBudgetRepository) {
+				budgetRepo.On("FindByCategory", mock.Anything, "時計").Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			budgetRepo := new(MockBudgetRepository)
+			itemRepo.On("FindByDedupeKey", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+			tt.setupMock(itemRepo, budgetRepo)
+			usecase := NewItemUsecase(itemRepo, WithBudgetCheck(budgetRepo, tt.enforcement))
+
+			result, err := usecase.CreateItem(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedWarnings, result.Warnings)
+			}
+			itemRepo.AssertExpectations(t)
+			budgetRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_UpdateItem_BudgetCheck(t *testing.T) {
+	newExistingItem := func() *entity.Item {
+		return &entity.Item{
+			ID:            1,
+			Name:          "ロレックス デイトナ",
+			Category:      "時計",
+			Brand:         "ROLEX",
+			PurchasePrice: entity.JPY(1500000),
+			PurchaseDate:  entity.MustParseDate("2023-01-15"),
+		}
+	}
+	budget := &entity.CategoryBudget{Category: "時計", Amount: entity.JPY(2000000)}
+
+	t.Run("正常系: 購入価格の変更時は自身を除いた支出でチェック", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		budgetRepo := new(MockBudgetRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newExistingItem(), nil)
+		budgetRepo.On("FindByCategory", mock.Anything, "時計").Return(budget, nil)
+		itemRepo.On("SumPurchasePrice", mock.Anything, "時計", "JPY", 2023, int64(1)).Return(int64(1000000), nil)
+		itemRepo.On("Update", mock.Anything, mock.Anything).Return(newExistingItem(), nil)
+		usecase := NewItemUsecase(itemRepo, WithBudgetCheck(budgetRepo, BudgetWarn))
+
+		price := entity.JPY(1200000)
+		result, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{PurchasePrice: &price})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2023 budget for 時計 exceeded: 2200000 JPY of 2000000 JPY"}, result.Warnings)
+		itemRepo.AssertExpectations(t)
+		budgetRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 購入価格を変更しない場合はチェックしない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		budgetRepo := new(MockBudgetRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newExistingItem(), nil)
+		itemRepo.On("Update", mock.Anything, mock.Anything).Return(newExistingItem(), nil)
+		usecase := NewItemUsecase(itemRepo, WithBudgetCheck(budgetRepo, BudgetBlock))
+
+		name := "ロレックス デイトナ（整備済み）"
+		result, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Name: &name})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.Warnings)
+		budgetRepo.AssertNotCalled(t, "FindByCategory", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_CreateItems_BudgetCheck(t *testing.T) {
+	// 予算 2,000,000 円に対して 800,000 円を3件（3件目で超過）
+	input := CreateItemInput{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: entity.JPY(800000),
+		PurchaseDate:  "2023-01-15",
+	}
+	items := []CreateItemInput{input, input, input}
+	budget := &entity.CategoryBudget{Category: "時計", Amount: entity.JPY(2000000)}
+
+	tests := []struct {
+		name             string
+		enforcement      BudgetEnforcement
+		mode             BulkMode
+		expectedCreated  int
+		expectedFailed   []int
+		expectedWarnings []int
+		expectedErr      error
+	}{
+		{
+			name:             "正常系: warn の場合は同じバッチの購入額を含めて警告する",
+			enforcement:      BudgetWarn,
+			mode:             BulkModePartial,
+			expectedCreated:  3,
+			expectedWarnings: []int{2},
+		},
+		{
+			name:            "正常系: block の partial モードでは予算を超える項目のみエラー",
+			enforcement:     BudgetBlock,
+			mode:            BulkModePartial,
+			expectedCreated: 2,
+			expectedFailed:  []int{2},
+		},
+		{
+			name:           "異常系: block の all_or_nothing モードでは何も登録しない",
+			enforcement:    BudgetBlock,
+			mode:           BulkModeAllOrNothing,
+			expectedFailed: []int{2},
+			expectedErr:    domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			budgetRepo := new(MockBudgetRepository)
+			budgetRepo.On("FindByCategory", mock.Anything, "時計").Return(budget, nil)
+			itemRepo.On("SumPurchasePrice", mock.Anything, "時計", "JPY", 2023, int64(0)).Return(int64(0), nil)
+			if tt.expectedErr == nil {
+				created := make([]*entity.Item, tt.expectedCreated)
+				for i := range created {
+					created[i] = &entity.Item{ID: int64(i + 1)}
+				}
+				itemRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+					return len(items) == tt.expectedCreated
+				})).Return(created, nil)
+			}
+			usecase := NewItemUsecase(itemRepo, WithBudgetCheck(budgetRepo, tt.enforcement))
+
+			result, err := usecase.CreateItems(context.Background(), BulkCreateItemsInput{Mode: tt.mode, Items: items})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				var bulkErr *BulkValidationError
+				require.ErrorAs(t, err, &bulkErr)
+				require.Len(t, bulkErr.Failed, len(tt.expectedFailed))
+				assert.Equal(t, tt.expectedFailed[0], bulkErr.Failed[0].Index)
+				itemRepo.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, result.Created, tt.expectedCreated)
+			require.Len(t, result.Failed, len(tt.expectedFailed))
+			for i, index := range tt.expectedFailed {
+				assert.Equal(t, index, result.Failed[i].Index)
+				assert.Equal(t, []string{"category budget exceeded: 2023 budget for 時計 exceeded: 2400000 JPY of 2000000 JPY"}, result.Failed[i].Errors)
+			}
+			require.Len(t, result.Warnings, len(tt.expectedWarnings))
+			for i, index := range tt.expectedWarnings {
+				assert.Equal(t, index, result.Warnings[i].Index)
+			}
+			itemRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_ImportItems_BudgetCheck(t *testing.T) {
+	file := "name,category,brand,purchase_price,purchase_date\n" +
+		"ロレックス デイトナ,時計,ROLEX,800000,2023-01-15\n" +
+		"オメガ スピードマスター,時計,OMEGA,800000,2023-02-20\n" +
+		"カルティエ タンク,時計,Cartier,800000,2023-03-10\n"
+	budget := &entity.CategoryBudget{Category: "時計", Amount: entity.JPY(2000000)}
+
+	itemRepo := new(MockItemRepository)
+	budgetRepo := new(MockBudgetRepository)
+	budgetRepo.On("FindByCategory", mock.Anything, "時計").Return(budget, nil)
+	itemRepo.On("SumPurchasePrice", mock.Anything, "時計", "JPY", 2023, int64(0)).Return(int64(0), nil)
+	itemRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+		return len(items) == 2
+	})).Return([]*entity.Item{{ID: 1}, {ID: 2}}, nil)
+	usecase := NewItemUsecase(itemRepo, WithBudgetCheck(budgetRepo, BudgetBlock))
+
+	result, err := usecase.ImportItems(context.Background(), ImportItemsInput{Format: "csv", File: strings.NewReader(file)})
+
+	require.NoError(t, err)
+	assert.Equal(t, []ImportedRow{{Row: 2, ID: 1}, {Row: 3, ID: 2}}, result.Accepted)
+	assert.Equal(t, []RejectedRow{{Row: 4, Errors: []string{"category budget exceeded: 2023 budget for 時計 exceeded: 2400000 JPY of 2000000 JPY"}}}, result.Rejected)
+	itemRepo.AssertExpectations(t)
+}
+
+func TestBudgetUsecase_SetBudget(t *testing.T) {
+	tests := []struct {
+		name        string
+		category    string
+		amount      entity.Money
+		readOnly    bool
+		setupMock   func(*MockBudgetRepository)
+		expectedErr error
+	}{
+		{
+			name:     "正常系: 予算を設定",
+			category: "時計",
+			amount:   entity.JPY(3000000),
+			setupMock: func(budgetRepo *MockBudgetRepository) {
+				budgetRepo.On("Save", mock.Anything, mock.MatchedBy(func(budget *entity.CategoryBudget) bool {
+					return budget.Category == "時計" && budget.Amount == entity.JPY(3000000)
+				})).Return(&entity.CategoryBudget{Category: "時計", Amount: entity.JPY(3000000)}, nil)
+			},
+		},
+		{
+			name:     "異常系: 無効なカテゴリー",
+			category: "衣服",
+			amount:   entity.JPY(3000000),
+			setupMock: func(budgetRepo *MockBudgetRepository) {
+				// Saveは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:     "異常系: 読み取り専用モード",
+			category: "時計",
+			amount:   entity.JPY(3000000),
+			readOnly: true,
+			setupMock: func(budgetRepo *MockBudgetRepository) {
+				// Saveは呼ばれない
+			},
+			expectedErr: domainErrors.ErrReadOnly,
+		},
+		{
+			name:     "異常系: データベースエラー",
+			category: "時計",
+			amount:   entity.JPY(3000000),
+			setupMock: func(budgetRepo *MockBudgetRepository) {
+				budgetRepo.On("Save", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budgetRepo := new(MockBudgetRepository)
+			tt.setupMock(budgetRepo)
+			usecase := NewBudgetUsecase(budgetRepo, NewReadOnlySwitch(tt.readOnly))
+
+			budget, err := usecase.SetBudget(context.Background(), tt.category, tt.amount)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, budget)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.category, budget.Category)
+			}
+			budgetRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_GetBrandSummary(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(*MockItemRepository)
+		expected    []BrandValue
+		expectError bool
+	}{
+		{
+			name: "正常系: ブランド別の集計",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSummaryByBrand", mock.Anything).Return([]entity.BrandValueTotal{
+					{Brand: "ROLEX", Currency: "JPY", Count: 2, Total: 3000000, TotalJPY: 3000000},
+					{Brand: "HERMÈS", Currency: "JPY", Count: 1, Total: 2000000, TotalJPY: 2000000},
+					{Brand: "OMEGA", Currency: "USD", Count: 1, Total: 750000, TotalJPY: 1030000},
+				}, nil)
+			},
+			expected: []BrandValue{
+				{Brand: "ROLEX", Currency: "JPY", Count: 2, Total: 3000000, TotalJPY: 3000000},
+				{Brand: "HERMÈS", Currency: "JPY", Count: 1, Total: 2000000, TotalJPY: 2000000},
+				{Brand: "OMEGA", Currency: "USD", Count: 1, Total: 750000, TotalJPY: 1030000},
+			},
+		},
+		{
+			name: "正常系: アイテムが0件の場合は空配列",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSummaryByBrand", mock.Anything).Return(nil, nil)
+			},
+			expected: []BrandValue{},
+		},
+		{
+			name: "異常系: データベースエラー",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSummaryByBrand", mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			summary, err := usecase.GetBrandSummary(context.Background())
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+				assert.Nil(t, summary)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, summary.Brands)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_GetYearCategorySummary(t *testing.T) {
+	t.Run("正常系: 購入年×カテゴリーの集計表", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByYearAndCategory", mock.Anything).Return([]entity.YearCategoryValueTotal{
+			{Year: 2021, Category: "時計", Currency: "JPY", Count: 1, Total: 1500000, TotalJPY: 1500000},
+			{Year: 2023, Category: "時計", Currency: "JPY", Count: 2, Total: 2000000, TotalJPY: 2000000},
+			{Year: 2023, Category: "時計", Currency: "USD", Count: 1, Total: 750000, TotalJPY: 1030000},
+			{Year: 2023, Category: "バッグ", Currency: "JPY", Count: 1, Total: 2000000, TotalJPY: 2000000},
+		}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		summary, err := usecase.GetYearCategorySummary(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"時計", "バッグ", "ジュエリー", "靴", "その他", entity.UncategorizedCategory}, summary.Categories)
+		require.Len(t, summary.Years, 3)
+
+		// 購入のない年も0件で含める
+		assert.Equal(t, 2022, summary.Years[1].Year)
+		assert.Equal(t, 0, summary.Years[1].Count)
+		assert.Equal(t, YearCategoryCell{Totals: []CurrencyTotal{}}, summary.Years[1].Categories["時計"])
+
+		row := summary.Years[2]
+		assert.Equal(t, 4, row.Count)
+		assert.Equal(t, int64(5030000), row.TotalJPY)
+		assert.Equal(t, YearCategoryCell{
+			Count:    3,
+			TotalJPY: 3030000,
+			Totals:   []CurrencyTotal{{Currency: "JPY", Total: 2000000}, {Currency: "USD", Total: 750000}},
+		}, row.Categories["時計"])
+		assert.Equal(t, 0, row.Categories["靴"].Count)
+		assert.Len(t, row.Categories, 6)
+
+		// 有効なカテゴリーの定義は変更しない
+		assert.Len(t, entity.GetValidCategories(), 5)
+	})
+
+	t.Run("正常系: アイテムが0件の場合は空配列", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByYearAndCategory", mock.Anything).Return(nil, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		summary, err := usecase.GetYearCategorySummary(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []YearCategoryRow{}, summary.Years)
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByYearAndCategory", mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+		usecase := NewItemUsecase(mockRepo)
+
+		summary, err := usecase.GetYearCategorySummary(context.Background())
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Nil(t, summary)
+	})
+}
+
+func TestNullableString_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected NullableString
+	}{
+		{
+			name:     "正常系: 未指定",
+			body:     `{}`,
+			expected: NullableString{},
+		},
+		{
+			name:     "正常系: null",
+			body:     `{"brand": null}`,
+			expected: NullableString{Set: true},
+		},
+		{
+			name:     "正常系: 値を指定",
+			body:     `{"brand": "ROLEX"}`,
+			expected: NewNullableString("ROLEX"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var input UpdateItemInput
+			require.NoError(t, json.Unmarshal([]byte(tt.body), &input))
+			assert.Equal(t, tt.expected, input.Brand)
+		})
+	}
+}
+
+func TestItemUsecase_Clock(t *testing.T) {
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	clock := entity.FixedClock(now)
+
+	t.Run("正常系: 作成日時・更新日時に指定した時刻を使う", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.CreatedAt.Equal(now) && item.UpdatedAt.Equal(now)
+		})).Return(&entity.Item{ID: 1}, nil)
+		itemRepo.On("FindByDedupeKey", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+
+		usecase := NewItemUsecase(itemRepo, WithClock(clock))
+		_, err := usecase.CreateItem(context.Background(), CreateItemInput{
+			Name:          "ロレックス デイトナ",
+			Category:      "時計",
+			Brand:         "ROLEX",
+			PurchasePrice: entity.JPY(1500000),
+			PurchaseDate:  "2023-01-15",
+		})
+
+		require.NoError(t, err)
+		itemRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 更新日時に指定した時刻を使う", func(t *testing.T) {
+		existing, err := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", entity.JPY(1500000), entity.MustParseDate("2023-01-15"), now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		existing.ID = 1
+
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+		itemRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.UpdatedAt.Equal(now) && item.CreatedAt.Equal(now.Add(-24*time.Hour))
+		})).Return(existing, nil)
+		itemRepo.On("FindByDedupeKey", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+
+		name := "デイトナ"
+		usecase := NewItemUsecase(itemRepo, WithClock(clock))
+		_, err = usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Name: &name})
+
+		require.NoError(t, err)
+		itemRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type SplitItemInput struct {
+	Allocation entity.SplitAllocation  `json:"allocation"` // 未指定の場合は equal
+	Components []entity.SplitComponent `json:"components"`
+}
+
+type SplitResult struct {
+	Original   *entity.Item   `json:"original"` // 分割元（削除済み）
+	Components []*entity.Item `json:"components"`
+}
+
+// アイテムを構成品に分割する（セットで登録したジュエリーを1点ずつに分けるなど）
+// 分割元は論理削除し、構成品には分割元のIDを記録する。監査ログには分割元と構成品の両方に分割を記録する
+func (u *itemUsecase) SplitItem(ctx context.Context, id int64, input SplitItemInput) (*SplitResult, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	if err := ensureNotOnHold(item); err != nil {
+		return nil, err
+	}
+	if item.Sold {
+		return nil, domainErrors.ErrItemSold
+	}
+
+	allocation := input.Allocation
+	if allocation == "" {
+		allocation = entity.SplitEqual
+	}
+	specs := make([]entity.SplitComponent, len(input.Components))
+	for n, spec := range input.Components {
+		if spec.Brand != "" {
+			spec.Brand = u.normalizeBrand(ctx, spec.Brand)
+		}
+		specs[n] = spec
+	}
+
+	now := u.clock.Now()
+	components, err := item.Split(specs, allocation, now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+
+	// 分割元を削除するため、増えるのは構成品の数 - 1 件
+	if err := u.quota.CheckItems(ctx, len(components)-1); err != nil {
+		return nil, err
+	}
+
+	var created []*entity.Item
+	err = u.mutate(ctx, func(ctx context.Context) error {
+		created, err = u.itemRepo.Split(ctx, id, components, now)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to split item: %w", err)
+		}
+
+		componentIDs := make([]int64, len(created))
+		for n, component := range created {
+			componentIDs[n] = component.ID
+			if err := u.audit(ctx, component.ID, entity.AuditSplit, nil, entity.ItemAuditFields(component)); err != nil {
+				return err
+			}
+		}
+		return u.audit(ctx, id, entity.AuditSplit, entity.ItemAuditFields(item), map[string]interface{}{"split_into": componentIDs})
+	})
+	u.evictItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, component := range created {
+		u.cacheItem(ctx, component)
+		u.snapshot(ctx, component)
+	}
+	purgeItems(ctx, u.cachePurger, append([]*entity.Item{item}, created...)...)
+
+	deletedAt := u.clock.Now()
+	item.DeletedAt = &deletedAt
+	return &SplitResult{Original: item, Components: created}, nil
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_SplitItem(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	newSet := func() *entity.Item {
+		item, _ := entity.NewItem("ジュエリーセット", "ジュエリー", "Tiffany & Co.", entity.JPY(100000), entity.MustParseDate("2023-05-01"), time.Now())
+		item.ID = 1
+		return item
+	}
+	// 受け取った構成品に 2, 3, ... のIDを付けて返す
+	created := func(components []*entity.Item) []*entity.Item {
+		result := make([]*entity.Item, len(components))
+		for n, component := range components {
+			copied := *component
+			copied.ID = int64(n + 2)
+			result[n] = &copied
+		}
+		return result
+	}
+	pieces := []entity.SplitComponent{{Name: "ネックレス", Ratio: 3}, {Name: "ピアス", Ratio: 1}}
+
+	t.Run("正常系: 分割元を削除して構成品を登録し、両方に分割を記録する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		logger := new(MockAuditLogger)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newSet(), nil)
+		itemRepo.On("Split", mock.Anything, int64(1), mock.AnythingOfType("[]*entity.Item"), mock.Anything).Return(func(ctx context.Context, id int64, components []*entity.Item) []*entity.Item {
+			return created(components)
+		}, nil).Once()
+
+		var logged []*entity.AuditLog
+		logger.On("Log", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			logged = append(logged, args.Get(1).(*entity.AuditLog))
+		}).Return(nil)
+
+		usecase := NewItemUsecase(itemRepo, WithAuditLogger(logger), WithClock(entity.FixedClock(now)))
+		result, err := usecase.SplitItem(context.Background(), 1, SplitItemInput{Allocation: entity.SplitByRatio, Components: pieces})
+
+		require.NoError(t, err)
+		require.Len(t, result.Components, 2)
+		assert.Equal(t, entity.JPY(75000), result.Components[0].PurchasePrice)
+		assert.Equal(t, entity.JPY(25000), result.Components[1].PurchasePrice)
+		assert.Equal(t, int64(1), *result.Components[0].SplitFrom)
+		assert.Equal(t, int64(1), result.Original.ID)
+		assert.Equal(t, now, *result.Original.DeletedAt)
+
+		require.Len(t, logged, 3)
+		assert.Equal(t, int64(2), logged[0].ItemID)
+		assert.Equal(t, entity.AuditSplit, logged[0].Action)
+		assert.Equal(t, entity.FieldChange{Old: nil, New: result.Components[0].SplitFrom}, logged[0].Changes["split_from"])
+		assert.Equal(t, int64(1), logged[2].ItemID)
+		assert.Equal(t, entity.AuditSplit, logged[2].Action)
+		assert.Equal(t, entity.FieldChange{Old: nil, New: []int64{2, 3}}, logged[2].Changes["split_into"])
+		itemRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name        string
+		input       SplitItemInput
+		item        func() *entity.Item
+		setupMock   func(*MockItemRepository)
+		expectedErr error
+	}{
+		{
+			name:  "異常系: 売却済み",
+			input: SplitItemInput{Components: pieces},
+			item: func() *entity.Item {
+				item := newSet()
+				item.Sold = true
+				return item
+			},
+			setupMock:   func(itemRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrItemSold,
+		},
+		{
+			name:  "異常系: 保全中",
+			input: SplitItemInput{Components: pieces},
+			item: func() *entity.Item {
+				item := newSet()
+				item.OnHold = true
+				return item
+			},
+			setupMock:   func(itemRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrItemOnHold,
+		},
+		{
+			name:        "異常系: 構成品が1つ",
+			input:       SplitItemInput{Components: pieces[:1]},
+			item:        newSet,
+			setupMock:   func(itemRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 同時に削除された",
+			input: SplitItemInput{Components: pieces},
+			item:  newSet,
+			setupMock: func(itemRepo *MockItemRepository) {
+				itemRepo.On("Split", mock.Anything, int64(1), mock.Anything, mock.Anything).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(tt.item(), nil)
+			tt.setupMock(itemRepo)
+			usecase := NewItemUsecase(itemRepo, WithClock(entity.FixedClock(now)))
+
+			result, err := usecase.SplitItem(context.Background(), 1, tt.input)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Nil(t, result)
+			itemRepo.AssertExpectations(t)
+		})
+	}
+}
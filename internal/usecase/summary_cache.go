@@ -0,0 +1,345 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// カテゴリー別集計のキャッシュ
+// 公開統計など、認証なしで多数のリクエストを受けるエンドポイントで集計クエリを毎回実行しないために使用する
+type SummaryCache struct {
+	itemUsecase ItemUsecase
+	ttl         time.Duration
+	clock       entity.Clock
+
+	// 再集計の前に世代を進める読み取りキャッシュ（未設定の場合は nil）
+	itemCache *CachedItemRepository
+	// 複数のサーバーで再集計を順に実行するためのロック（読み取りキャッシュを共有しない場合は nil）
+	locker Locker
+	// 複数のサーバーで集計と再集計の進み具合を共有する保存先（未設定の場合はプロセスごとに持つ）
+	store KeyValueCache
+
+	mu        sync.Mutex
+	summary   *CategorySummary
+	expiresAt time.Time
+
+	// 再集計中も参照できるよう、集計とは別のロックで保護する
+	progressMu sync.Mutex
+	progress   SummaryRecomputeProgress
+}
+
+// 複数のサーバーで共有する排他ロック（Redis など）
+type Locker interface {
+	// key のロックを取得するまで待ち、解放する関数を返す（保持したまま停止した場合は ttl の経過で解放される）
+	Lock(ctx context.Context, key string, ttl time.Duration) (unlock func(), err error)
+}
+
+// 再集計のロック（集計のクエリより十分に長く保持できる期間）
+const (
+	summaryRecomputeLockKey = "items:summary:recompute"
+	summaryRecomputeLockTTL = time.Minute
+)
+
+// 共有する保存先のキー（進み具合は完了後も確認できるよう1日保持する）
+const (
+	summaryStoreKey             = "items:summary:public"
+	summaryProgressStoreKey     = "items:summary:recompute:progress"
+	summaryRecomputeProgressTTL = 24 * time.Hour
+)
+
+// 再集計の段階
+const (
+	SummaryRecomputeStepLock       = "lock"             // 他のサーバーの再集計の完了を待つ
+	SummaryRecomputeStepInvalidate = "invalidate_cache" // 読み取りキャッシュを古いものとして扱う
+	SummaryRecomputeStepAggregate  = "aggregate"        // DBから集計する
+	SummaryRecomputeStepStore      = "store"            // 共有する保存先に書き込む
+)
+
+type SummaryCacheOption func(*SummaryCache)
+
+// アイテムの読み取りキャッシュを使っている場合に指定する（再集計でキャッシュした集計を返さないようにする）
+func WithSummaryItemCache(itemCache *CachedItemRepository) SummaryCacheOption {
+	return func(c *SummaryCache) {
+		c.itemCache = itemCache
+	}
+}
+
+// 読み取りキャッシュを複数のサーバーで共有している場合に指定する
+func WithSummaryLocker(locker Locker) SummaryCacheOption {
+	return func(c *SummaryCache) {
+		c.locker = locker
+	}
+}
+
+// 集計を複数のサーバーで共有する場合に指定する（再集計の結果と進み具合がすべてのサーバーに反映される）
+func WithSummaryStore(store KeyValueCache) SummaryCacheOption {
+	return func(c *SummaryCache) {
+		c.store = store
+	}
+}
+
+// 公開用の統計情報
+type PublicStats struct {
+	TotalItems int `json:"total_items"`
+	// アイテムが登録されているカテゴリーの数
+	Categories int `json:"categories"`
+}
+
+func NewSummaryCache(itemUsecase ItemUsecase, ttl time.Duration, opts ...SummaryCacheOption) *SummaryCache {
+	c := &SummaryCache{
+		itemUsecase: itemUsecase,
+		ttl:         ttl,
+		clock:       entity.SystemClock,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// 共有する保存先に書き込む集計
+type storedSummary struct {
+	Summary   *CategorySummary `json:"summary"`
+	ExpiresAt time.Time        `json:"expires_at"`
+}
+
+// キャッシュされた集計を返す。期限切れの場合は再集計し、失敗した場合は古い集計を返す
+// 保存先を共有している場合は、他のサーバーの再集計を反映するため先に保存先を読む
+func (c *SummaryCache) Get(ctx context.Context) (*CategorySummary, error) {
+	// 同時に期限切れを検知したリクエストが一斉に集計しないよう、集計中もロックを保持する
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stored, err := c.loadStored(ctx)
+	if stored != nil {
+		c.summary = stored.Summary
+		c.expiresAt = stored.ExpiresAt
+		return c.summary, nil
+	}
+	// 保存先が未設定または障害中の場合は、このサーバーのキャッシュを期限まで返す
+	if (c.store == nil || err != nil) && c.summary != nil && c.clock.Now().Before(c.expiresAt) {
+		return c.summary, nil
+	}
+
+	summary, err := c.itemUsecase.GetCategorySummary(ctx)
+	if err != nil {
+		if c.summary != nil {
+			return c.summary, nil
+		}
+		return nil, err
+	}
+
+	c.summary = summary
+	c.expiresAt = c.clock.Now().Add(c.ttl)
+	// 保存先に書き込めない場合もこのサーバーでは集計を返す（次のリクエストで再度集計する）
+	_ = c.saveStored(ctx)
+	return summary, nil
+}
+
+// 保存先の集計を読む（未設定・期限切れの場合は nil）
+func (c *SummaryCache) loadStored(ctx context.Context) (*storedSummary, error) {
+	if c.store == nil {
+		return nil, nil
+	}
+	data, ok, err := c.store.Get(ctx, summaryStoreKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var stored storedSummary
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Summary == nil || !c.clock.Now().Before(stored.ExpiresAt) {
+		return nil, nil
+	}
+	return &stored, nil
+}
+
+func (c *SummaryCache) saveStored(ctx context.Context) error {
+	if c.store == nil {
+		return nil
+	}
+	data, err := json.Marshal(storedSummary{Summary: c.summary, ExpiresAt: c.expiresAt})
+	if err != nil {
+		return err
+	}
+	return c.store.Set(ctx, summaryStoreKey, data, c.expiresAt.Sub(c.clock.Now()))
+}
+
+// 再集計の結果
+type SummaryRecomputeResult struct {
+	Summary      *CategorySummary `json:"summary"`
+	RecomputedAt time.Time        `json:"recomputed_at"`
+	ExpiresAt    time.Time        `json:"expires_at"`
+	// 集計を共有する保存先に書き込んだか（false の場合はリクエストを受けたサーバーのキャッシュのみ更新した）
+	Shared bool `json:"shared"`
+}
+
+// 再集計の進み具合
+type SummaryRecomputeProgress struct {
+	Running        bool       `json:"running"`
+	Step           string     `json:"step,omitempty"` // 実行中、または失敗した段階
+	CompletedSteps int        `json:"completed_steps"`
+	TotalSteps     int        `json:"total_steps"`
+	StartedAt      *time.Time `json:"started_at,omitempty"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty"`
+	Error          string     `json:"error,omitempty"`
+}
+
+// 期限によらず再集計してキャッシュを置き換える（一括インポートやマイグレーションの後に使う）
+// 同時に呼ばれた場合は順に実行し（ロックを指定した場合は他のサーバーとも）、失敗した場合はキャッシュを変更しない
+// 保存先を共有している場合はロックを保持したまま書き込み、他のサーバーも次のリクエストから再集計した結果を返す
+func (c *SummaryCache) Recompute(ctx context.Context) (*SummaryRecomputeResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var steps []string
+	if c.locker != nil {
+		steps = append(steps, SummaryRecomputeStepLock)
+	}
+	if c.itemCache != nil {
+		steps = append(steps, SummaryRecomputeStepInvalidate)
+	}
+	steps = append(steps, SummaryRecomputeStepAggregate)
+	if c.store != nil {
+		steps = append(steps, SummaryRecomputeStepStore)
+	}
+	startedAt := c.clock.Now()
+	progress := SummaryRecomputeProgress{Running: true, TotalSteps: len(steps), StartedAt: &startedAt}
+	// 各段階の開始時に進み具合を記録し、失敗した場合はその段階とエラーを残す
+	run := func(step string, fn func() error) error {
+		progress.Step = step
+		c.reportProgress(ctx, progress)
+		if err := fn(); err != nil {
+			finishedAt := c.clock.Now()
+			progress.Running = false
+			progress.FinishedAt = &finishedAt
+			progress.Error = err.Error()
+			c.reportProgress(ctx, progress)
+			return err
+		}
+		progress.CompletedSteps++
+		return nil
+	}
+
+	if c.locker != nil {
+		var unlock func()
+		err := run(SummaryRecomputeStepLock, func() error {
+			var err error
+			unlock, err = c.locker.Lock(ctx, summaryRecomputeLockKey, summaryRecomputeLockTTL)
+			if err != nil {
+				return fmt.Errorf("failed to acquire summary recompute lock: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+	// リポジトリを通らない変更（マイグレーションなど）も反映するよう、読み取りキャッシュの集計ではなくDBから集計する
+	if c.itemCache != nil {
+		err := run(SummaryRecomputeStepInvalidate, func() error {
+			if err := c.itemCache.InvalidateAll(ctx); err != nil {
+				return fmt.Errorf("failed to invalidate item cache: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var summary *CategorySummary
+	err := run(SummaryRecomputeStepAggregate, func() error {
+		var err error
+		summary, err = c.itemUsecase.GetCategorySummary(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := c.clock.Now()
+	previous, previousExpiresAt := c.summary, c.expiresAt
+	c.summary = summary
+	c.expiresAt = now.Add(c.ttl)
+	if c.store != nil {
+		err := run(SummaryRecomputeStepStore, func() error {
+			if err := c.saveStored(ctx); err != nil {
+				return fmt.Errorf("failed to store summary: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			c.summary, c.expiresAt = previous, previousExpiresAt
+			return nil, err
+		}
+	}
+
+	progress.Running = false
+	progress.Step = ""
+	progress.FinishedAt = &now
+	c.reportProgress(ctx, progress)
+	return &SummaryRecomputeResult{
+		Summary:      summary,
+		RecomputedAt: now,
+		ExpiresAt:    c.expiresAt,
+		Shared:       c.store != nil,
+	}, nil
+}
+
+// 進み具合を記録する（保存先に書き込めない場合もこのサーバーでは参照できる）
+func (c *SummaryCache) reportProgress(ctx context.Context, progress SummaryRecomputeProgress) {
+	c.progressMu.Lock()
+	c.progress = progress
+	c.progressMu.Unlock()
+
+	if c.store == nil {
+		return
+	}
+	if data, err := json.Marshal(progress); err == nil {
+		_ = c.store.Set(ctx, summaryProgressStoreKey, data, summaryRecomputeProgressTTL)
+	}
+}
+
+// 実行中または直前の再集計の進み具合を返す（保存先を共有している場合は他のサーバーの再集計も含む）
+func (c *SummaryCache) RecomputeProgress(ctx context.Context) *SummaryRecomputeProgress {
+	if c.store != nil {
+		if data, ok, err := c.store.Get(ctx, summaryProgressStoreKey); err == nil && ok {
+			var progress SummaryRecomputeProgress
+			if err := json.Unmarshal(data, &progress); err == nil {
+				return &progress
+			}
+		}
+	}
+
+	c.progressMu.Lock()
+	defer c.progressMu.Unlock()
+	progress := c.progress
+	return &progress
+}
+
+func (c *SummaryCache) GetPublicStats(ctx context.Context) (*PublicStats, error) {
+	summary, err := c.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := 0
+	for _, count := range summary.Categories {
+		if count > 0 {
+			categories++
+		}
+	}
+	if summary.Uncategorized > 0 {
+		categories++
+	}
+
+	return &PublicStats{
+		TotalItems: summary.Total,
+		Categories: categories,
+	}, nil
+}
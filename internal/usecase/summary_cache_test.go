@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestSummaryCache_GetPublicStats(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 2, "バッグ": 1, "未分類": 1}, nil).Once()
+	mockRepo.On("GetValueSummaryByCategory", mock.Anything).Return([]entity.CategoryValueTotal{}, nil)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewSummaryCache(NewItemUsecase(mockRepo), 5*time.Minute)
+	cache.clock = entity.ClockFunc(func() time.Time { return now })
+	ctx := context.Background()
+
+	stats, err := cache.GetPublicStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &PublicStats{TotalItems: 4, Categories: 3}, stats)
+
+	// 期限内はキャッシュから返す
+	now = now.Add(4 * time.Minute)
+	_, err = cache.GetPublicStats(ctx)
+	require.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 1)
+
+	// 期限切れ後は再集計する
+	now = now.Add(2 * time.Minute)
+	mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 3}, nil).Once()
+	stats, err = cache.GetPublicStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &PublicStats{TotalItems: 3, Categories: 1}, stats)
+
+	// 再集計に失敗した場合は古い集計を返す
+	now = now.Add(6 * time.Minute)
+	mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int(nil), domainErrors.ErrDatabaseError).Once()
+	stats, err = cache.GetPublicStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.TotalItems)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSummaryCache_Get_Error(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int(nil), domainErrors.ErrDatabaseError)
+	cache := NewSummaryCache(NewItemUsecase(mockRepo), time.Minute)
+
+	summary, err := cache.Get(context.Background())
+
+	assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	assert.Nil(t, summary)
+}
+
+func TestSummaryCache_Recompute(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 2}, nil).Once()
+	mockRepo.On("GetValueSummaryByCategory", mock.Anything).Return([]entity.CategoryValueTotal{}, nil)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewSummaryCache(NewItemUsecase(mockRepo), 5*time.Minute)
+	cache.clock = entity.ClockFunc(func() time.Time { return now })
+	ctx := context.Background()
+
+	_, err := cache.Get(ctx)
+	require.NoError(t, err)
+
+	// 期限内でも再集計する
+	mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 5}, nil).Once()
+	result, err := cache.Recompute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Summary.Total)
+	assert.Equal(t, now.Add(5*time.Minute), result.ExpiresAt)
+
+	stats, err := cache.GetPublicStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.TotalItems)
+
+	// 失敗した場合はキャッシュを変更しない
+	mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int(nil), domainErrors.ErrDatabaseError).Once()
+	_, err = cache.Recompute(ctx)
+	assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+
+	stats, err = cache.GetPublicStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.TotalItems)
+}
+
+// 取得・解放した回数を数えるロック
+type countingLocker struct {
+	locked, unlocked int
+}
+
+func (l *countingLocker) Lock(ctx context.Context, key string, ttl time.Duration) (func(), error) {
+	l.locked++
+	return func() { l.unlocked++ }, nil
+}
+
+func TestSummaryCache_Recompute_ItemCache(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 2}, nil).Once()
+	mockRepo.On("GetValueSummaryByCategory", mock.Anything).Return([]entity.CategoryValueTotal{}, nil)
+
+	itemCache := NewCachedItemRepository(mockRepo, newFakeKeyValueCache(), ItemCacheTTL{Summary: time.Hour})
+	locker := &countingLocker{}
+	cache := NewSummaryCache(NewItemUsecase(itemCache), 5*time.Minute, WithSummaryItemCache(itemCache), WithSummaryLocker(locker))
+	ctx := context.Background()
+
+	_, err := cache.Get(ctx)
+	require.NoError(t, err)
+
+	// 読み取りキャッシュの集計ではなく、DBから集計し直す
+	mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 5}, nil).Once()
+	result, err := cache.Recompute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Summary.Total)
+	assert.Equal(t, 1, locker.locked)
+	assert.Equal(t, 1, locker.unlocked)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSummaryCache_Store(t *testing.T) {
+	t.Run("正常系: 再集計した結果は保存先を共有する他のサーバーも返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 2}, nil).Once()
+		mockRepo.On("GetValueSummaryByCategory", mock.Anything).Return([]entity.CategoryValueTotal{}, nil)
+
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := entity.ClockFunc(func() time.Time { return now })
+		store := newFakeKeyValueCache()
+		server1 := NewSummaryCache(NewItemUsecase(mockRepo), 5*time.Minute, WithSummaryStore(store))
+		server1.clock = clock
+		server2 := NewSummaryCache(NewItemUsecase(mockRepo), 5*time.Minute, WithSummaryStore(store))
+		server2.clock = clock
+		ctx := context.Background()
+
+		// 1台目の集計を2台目も返す
+		summary, err := server1.Get(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, summary.Total)
+		summary, err = server2.Get(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, summary.Total)
+
+		// 1台目の再集計は期限内でも2台目に反映される
+		mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 5}, nil).Once()
+		result, err := server1.Recompute(ctx)
+		require.NoError(t, err)
+		assert.True(t, result.Shared)
+		summary, err = server2.Get(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 5, summary.Total)
+		mockRepo.AssertExpectations(t)
+
+		// 進み具合も2台目から参照できる
+		progress := server2.RecomputeProgress(ctx)
+		assert.False(t, progress.Running)
+		assert.Equal(t, 2, progress.CompletedSteps)
+		assert.Equal(t, 2, progress.TotalSteps)
+		assert.Equal(t, now, *progress.FinishedAt)
+	})
+
+	t.Run("正常系: 保存先の障害中はサーバーのキャッシュを期限まで返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 2}, nil).Once()
+		mockRepo.On("GetValueSummaryByCategory", mock.Anything).Return([]entity.CategoryValueTotal{}, nil)
+
+		store := newFakeKeyValueCache()
+		cache := NewSummaryCache(NewItemUsecase(mockRepo), 5*time.Minute, WithSummaryStore(store))
+		ctx := context.Background()
+
+		_, err := cache.Get(ctx)
+		require.NoError(t, err)
+		store.err = domainErrors.ErrDatabaseError
+		summary, err := cache.Get(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, summary.Total)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 1)
+	})
+
+	t.Run("異常系: 保存先に書き込めない場合は再集計を失敗にしてキャッシュを変更しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 2}, nil).Once()
+		mockRepo.On("GetValueSummaryByCategory", mock.Anything).Return([]entity.CategoryValueTotal{}, nil)
+
+		store := newFakeKeyValueCache()
+		cache := NewSummaryCache(NewItemUsecase(mockRepo), 5*time.Minute, WithSummaryStore(store))
+		ctx := context.Background()
+
+		_, err := cache.Get(ctx)
+		require.NoError(t, err)
+		store.err = domainErrors.ErrDatabaseError
+		mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 5}, nil).Once()
+		_, err = cache.Recompute(ctx)
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+
+		summary, err := cache.Get(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, summary.Total)
+
+		// 保存先に書き込めなかった段階をこのサーバーで参照できる
+		progress := cache.RecomputeProgress(ctx)
+		assert.False(t, progress.Running)
+		assert.Equal(t, SummaryRecomputeStepStore, progress.Step)
+		assert.Equal(t, 1, progress.CompletedSteps)
+		assert.NotEmpty(t, progress.Error)
+	})
+}
+
+// ロックの取得中に進み具合を読むロック
+type progressLocker struct {
+	cache    *SummaryCache
+	progress *SummaryRecomputeProgress
+}
+
+func (l *progressLocker) Lock(ctx context.Context, key string, ttl time.Duration) (func(), error) {
+	l.progress = l.cache.RecomputeProgress(ctx)
+	return func() {}, nil
+}
+
+func TestSummaryCache_RecomputeProgress(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("GetSummaryByCategory", mock.Anything).Return(map[string]int{"時計": 2}, nil)
+	mockRepo.On("GetValueSummaryByCategory", mock.Anything).Return([]entity.CategoryValueTotal{}, nil)
+
+	locker := &progressLocker{}
+	cache := NewSummaryCache(NewItemUsecase(mockRepo), 5*time.Minute, WithSummaryLocker(locker))
+	locker.cache = cache
+	ctx := context.Background()
+
+	// 再集計の前は何も実行していない
+	assert.Equal(t, &SummaryRecomputeProgress{}, cache.RecomputeProgress(ctx))
+
+	_, err := cache.Recompute(ctx)
+	require.NoError(t, err)
+
+	// 実行中は段階を返す
+	require.NotNil(t, locker.progress)
+	assert.True(t, locker.progress.Running)
+	assert.Equal(t, SummaryRecomputeStepLock, locker.progress.Step)
+	assert.Equal(t, 0, locker.progress.CompletedSteps)
+	assert.Equal(t, 2, locker.progress.TotalSteps)
+
+	progress := cache.RecomputeProgress(ctx)
+	assert.False(t, progress.Running)
+	assert.Empty(t, progress.Step)
+	assert.Equal(t, 2, progress.CompletedSteps)
+	assert.NotNil(t, progress.FinishedAt)
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// アイテムにタグを付け、タグを含むアイテムを返す（付け済みのタグの場合は何もしない）
+func (u *itemUsecase) AddItemTag(ctx context.Context, id int64, name string) (*entity.Item, error) {
+	if err := u.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	tag, err := entity.NewTag(name, u.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	if u.tagRepo == nil {
+		return nil, fmt.Errorf("tags are not configured")
+	}
+
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	if err := u.tagRepo.AddToItem(ctx, id, tag); err != nil {
+		return nil, fmt.Errorf("failed to add tag: %w", err)
+	}
+
+	purgeItems(ctx, u.cachePurger, item)
+
+	// タグの追加で変更の通し番号が進むため読み直す（読み直せない場合は追加前の内容を返す）
+	if updated, err := u.itemRepo.FindByID(ctx, id); err == nil {
+		u.cacheItem(ctx, updated)
+		item = updated
+	}
+
+	if err := u.attachTags(ctx, item); err != nil {
+		return nil, err
+	}
+	// タグの追加は完了しているため、評価額を取得できなくてもエラーにしない
+	_ = u.attachValuations(ctx, item)
+
+	return item, nil
+}
+
+// アイテムからタグを外す
+func (u *itemUsecase) RemoveItemTag(ctx context.Context, id int64, name string) error {
+	if err := u.ensureWritable(); err != nil {
+		return err
+	}
+
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	normalized, err := entity.NormalizeTagName(name)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	if u.tagRepo == nil {
+		return fmt.Errorf("tags are not configured")
+	}
+
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemNotFound
+		}
+		return fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	if err := u.tagRepo.RemoveFromItem(ctx, id, normalized); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return fmt.Errorf("%w: tag %s is not attached to item %d", domainErrors.ErrItemNotFound, normalized, id)
+		}
+		return fmt.Errorf("failed to remove tag: %w", err)
+	}
+	purgeItems(ctx, u.cachePurger, item)
+
+	return nil
+}
+
+// アイテムに付けられたタグ名を設定する
+func (u *itemUsecase) attachTags(ctx context.Context, items ...*entity.Item) error {
+	if u.tagRepo == nil || len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+
+	names, err := u.tagRepo.FindNamesByItemIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve tags: %w", err)
+	}
+
+	for _, item := range items {
+		item.Tags = names[item.ID]
+	}
+
+	return nil
+}
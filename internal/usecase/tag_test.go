@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockTagRepository はタグのモックリポジトリ
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) FindNamesByItemIDs(ctx context.Context, itemIDs []int64) (map[int64][]string, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64][]string), args.Error(1)
+}
+
+func (m *MockTagRepository) AddToItem(ctx context.Context, itemID int64, tag *entity.Tag) error {
+	args := m.Called(ctx, itemID, tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) RemoveFromItem(ctx context.Context, itemID int64, name string) error {
+	args := m.Called(ctx, itemID, name)
+	return args.Error(0)
+}
+
+func TestItemUsecase_AddItemTag(t *testing.T) {
+	tests := []struct {
+		name         string
+		id           int64
+		tag          string
+		readOnly     bool
+		setupMock    func(*MockItemRepository, *MockTagRepository)
+		expectedTags []string
+		expectedErr  error
+	}{
+		{
+			name: "正常系: 正規化したタグを追加",
+			id:   1,
+			tag:  " Limited-Edition ",
+			setupMock: func(itemRepo *MockItemRepository, tagRepo *MockTagRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+				tagRepo.On("AddToItem", mock.Anything, int64(1), mock.MatchedBy(func(tag *entity.Tag) bool {
+					return tag.Name == "limited-edition"
+				})).Return(nil)
+				tagRepo.On("FindNamesByItemIDs", mock.Anything, []int64{1}).Return(map[int64][]string{
+					1: {"limited-edition", "vintage"},
+				}, nil)
+			},
+			expectedTags: []string{"limited-edition", "vintage"},
+		},
+		{
+			name:        "異常系: 不正なタグ",
+			id:          1,
+			tag:         "limited edition",
+			setupMock:   func(*MockItemRepository, *MockTagRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: 存在しないアイテム",
+			id:   999,
+			tag:  "vintage",
+			setupMock: func(itemRepo *MockItemRepository, _ *MockTagRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:        "異常系: 読み取り専用モード",
+			id:          1,
+			tag:         "vintage",
+			readOnly:    true,
+			setupMock:   func(*MockItemRepository, *MockTagRepository) {},
+			expectedErr: domainErrors.ErrReadOnly,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			tagRepo := new(MockTagRepository)
+			tt.setupMock(itemRepo, tagRepo)
+
+			usecase := NewItemUsecase(itemRepo, WithTags(tagRepo), WithReadOnlySwitch(NewReadOnlySwitch(tt.readOnly)))
+			item, err := usecase.AddItemTag(context.Background(), tt.id, tt.tag)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, item)
+				tagRepo.AssertNotCalled(t, "AddToItem", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTags, item.Tags)
+			tagRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_RemoveItemTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		tag         string
+		setupMock   func(*MockItemRepository, *MockTagRepository)
+		expectedErr error
+	}{
+		{
+			name: "正常系: タグを外す",
+			tag:  "Vintage",
+			setupMock: func(itemRepo *MockItemRepository, tagRepo *MockTagRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+				tagRepo.On("RemoveFromItem", mock.Anything, int64(1), "vintage").Return(nil)
+			},
+		},
+		{
+			name: "異常系: 付いていないタグ",
+			tag:  "vintage",
+			setupMock: func(itemRepo *MockItemRepository, tagRepo *MockTagRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+				tagRepo.On("RemoveFromItem", mock.Anything, int64(1), "vintage").Return(domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			tagRepo := new(MockTagRepository)
+			tt.setupMock(itemRepo, tagRepo)
+
+			usecase := NewItemUsecase(itemRepo, WithTags(tagRepo))
+			err := usecase.RemoveItemTag(context.Background(), 1, tt.tag)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			tagRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_GetAllItems_TagFilter(t *testing.T) {
+	t.Run("正常系: 正規化したタグで絞り込み、タグを設定する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		tagRepo := new(MockTagRepository)
+		itemRepo.On("FindAll", mock.Anything, mock.MatchedBy(func(q entity.ItemQuery) bool {
+			return q.Tag == "limited-edition"
+		})).Return([]*entity.Item{{ID: 3}}, nil)
+		itemRepo.On("Count", mock.Anything, mock.Anything).Return(1, nil)
+		tagRepo.On("FindNamesByItemIDs", mock.Anything, []int64{3}).Return(map[int64][]string{3: {"limited-edition"}}, nil)
+
+		usecase := NewItemUsecase(itemRepo, WithTags(tagRepo))
+		list, err := usecase.GetAllItems(context.Background(), ListItemsInput{Tag: "Limited-Edition"})
+
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, []string{"limited-edition"}, list.Items[0].Tags)
+	})
+
+	t.Run("異常系: 不正なタグ", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+
+		usecase := NewItemUsecase(itemRepo)
+		list, err := usecase.GetAllItems(context.Background(), ListItemsInput{Tag: "a b"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Nil(t, list)
+		itemRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 集計期間（購入年、両端を含む）。未指定の場合はすべて
+type TaxPaidInput struct {
+	From string
+	To   string
+}
+
+// 購入年ごとの支払った税額の集計（確定申告などの確認に使う）
+// 税額を記録していないアイテムは含めない
+type TaxPaidReport struct {
+	Years []YearTaxPaid `json:"years"`
+}
+
+type YearTaxPaid struct {
+	Year     int             `json:"year"`
+	Count    int             `json:"count"`
+	TotalJPY int64           `json:"total_jpy"` // 円換算額の合計
+	Totals   []CurrencyTotal `json:"totals"`    // 通貨ごとの税額の合計
+}
+
+func (u *reportUsecase) GetTaxPaid(ctx context.Context, input TaxPaidInput) (*TaxPaidReport, error) {
+	from, to, errs := parseYearRange(input.From, input.To)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, strings.Join(errs, ", "))
+	}
+
+	fromDate, toDate := yearRangeDates(from, to)
+	totals, err := u.itemRepo.GetTaxTotals(ctx, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax totals: %w", err)
+	}
+
+	// 集計結果は年の順に並んでいる
+	report := &TaxPaidReport{Years: []YearTaxPaid{}}
+	for _, t := range totals {
+		if n := len(report.Years); n == 0 || report.Years[n-1].Year != t.Year {
+			report.Years = append(report.Years, YearTaxPaid{Year: t.Year, Totals: []CurrencyTotal{}})
+		}
+		y := &report.Years[len(report.Years)-1]
+
+		y.Count += t.Count
+		y.TotalJPY += t.TotalJPY
+		y.Totals = append(y.Totals, CurrencyTotal{Currency: t.Currency, Total: t.Total})
+	}
+
+	return report, nil
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestReportUsecase_GetTaxPaid(t *testing.T) {
+	totals := []entity.TaxTotal{
+		{Year: 2023, Currency: "JPY", Count: 2, Total: 150000, TotalJPY: 150000},
+		{Year: 2023, Currency: "USD", Count: 1, Total: 6656, TotalJPY: 9984},
+		{Year: 2024, Currency: "JPY", Count: 1, Total: 30000, TotalJPY: 30000},
+	}
+
+	tests := []struct {
+		name          string
+		input         TaxPaidInput
+		setupMock     func(*MockItemRepository)
+		expectedYears []YearTaxPaid
+		expectedErr   error
+	}{
+		{
+			name:  "正常系: 年ごとに集計する",
+			input: TaxPaidInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetTaxTotals", mock.Anything, "", "").Return(totals, nil)
+			},
+			expectedYears: []YearTaxPaid{
+				{Year: 2023, Count: 3, TotalJPY: 159984, Totals: []CurrencyTotal{{Currency: "JPY", Total: 150000}, {Currency: "USD", Total: 6656}}},
+				{Year: 2024, Count: 1, TotalJPY: 30000, Totals: []CurrencyTotal{{Currency: "JPY", Total: 30000}}},
+			},
+		},
+		{
+			name:  "正常系: 期間を指定（終端は翌年1月1日の前まで）",
+			input: TaxPaidInput{From: "2024", To: "2024"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetTaxTotals", mock.Anything, "2024-01-01", "2025-01-01").Return(totals[2:], nil)
+			},
+			expectedYears: []YearTaxPaid{
+				{Year: 2024, Count: 1, TotalJPY: 30000, Totals: []CurrencyTotal{{Currency: "JPY", Total: 30000}}},
+			},
+		},
+		{
+			name:  "正常系: データなし",
+			input: TaxPaidInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetTaxTotals", mock.Anything, "", "").Return(nil, nil)
+			},
+			expectedYears: []YearTaxPaid{},
+		},
+		{
+			name:        "異常系: from が to より後",
+			input:       TaxPaidInput{From: "2024", To: "2023"},
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			input: TaxPaidInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetTaxTotals", mock.Anything, "", "").Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewReportUsecase(mockRepo, nil)
+
+			report, err := usecase.GetTaxPaid(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, report)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedYears, report.Years)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
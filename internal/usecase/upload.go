@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 分割アップロードのデフォルトの上限サイズ（50MB）とセッションの有効期間
+const (
+	DefaultMaxUploadSize = 50 << 20
+	DefaultUploadTTL     = 24 * time.Hour
+)
+
+// 分割アップロードの受信途中のデータの保存先
+type UploadStore interface {
+	// Create stores a new empty upload session
+	Create(ctx context.Context, session *entity.UploadSession) error
+
+	// Find retrieves an upload session with its current offset, returning ErrUploadNotFound if it does not exist
+	Find(ctx context.Context, id string) (*entity.UploadSession, error)
+
+	// Append writes the chunk at offset and returns the new offset. It returns ErrUploadOffsetMismatch
+	// if offset is not the current offset, and ErrInvalidInput without writing if the chunk exceeds limit bytes
+	Append(ctx context.Context, id string, offset int64, chunk io.Reader, limit int64) (int64, error)
+
+	// Open returns a reader of the received content
+	Open(ctx context.Context, id string) (io.ReadCloser, error)
+
+	// Delete removes an upload session and its content
+	Delete(ctx context.Context, id string) error
+
+	// DeleteExpired removes the sessions that expired before now and returns how many were removed
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+type CreateUploadInput struct {
+	FileName string `json:"file_name"`
+	Size     int64  `json:"size"`
+}
+
+// 分割アップロードの送信結果（全体を受信した場合は登録した写真を含む）
+type UploadChunkResult struct {
+	Upload *entity.UploadSession `json:"upload"`
+	Image  *entity.ItemImage     `json:"image,omitempty"`
+}
+
+// 大きな写真・レシートの分割アップロード（中断しても受信済みの位置から再開できる）を有効にする
+func WithUploadSessions(store UploadStore, maxSize int64, ttl time.Duration) ImageUsecaseOption {
+	return func(u *imageUsecase) {
+		u.uploadStore = store
+		u.maxUploadSize = maxSize
+		u.uploadTTL = ttl
+	}
+}
+
+// 分割アップロードを開始する（サイズの確認と利用上限の確認は開始時に行う）
+func (u *imageUsecase) CreateUpload(ctx context.Context, itemID int64, input CreateUploadInput) (*entity.UploadSession, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+	if u.uploadStore == nil {
+		return nil, fmt.Errorf("resumable uploads are not configured")
+	}
+
+	if _, err := u.findItem(ctx, itemID); err != nil {
+		return nil, err
+	}
+	if input.Size > u.maxUploadSize {
+		return nil, fmt.Errorf("%w: size must be %d bytes or smaller", domainErrors.ErrInvalidInput, u.maxUploadSize)
+	}
+	if err := u.quota.CheckStorage(ctx, input.Size); err != nil {
+		return nil, err
+	}
+
+	id, err := uploadID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate upload ID: %w", err)
+	}
+	session, err := entity.NewUploadSession(id, itemID, input.FileName, input.Size, u.clock.Now(), u.uploadTTL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	if err := u.uploadStore.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create upload: %w", err)
+	}
+
+	return session, nil
+}
+
+// 分割アップロードの受信状況（再開する位置の確認に使う）
+func (u *imageUsecase) GetUpload(ctx context.Context, itemID int64, uploadID string) (*entity.UploadSession, error) {
+	if u.uploadStore == nil {
+		return nil, fmt.Errorf("resumable uploads are not configured")
+	}
+
+	return u.findUpload(ctx, itemID, uploadID)
+}
+
+// offset の位置から続きを受信する。全体を受信した場合は写真として登録し、セッションを削除する
+// 登録に失敗した場合はセッションを残すため、同じ offset で空の送信をすると登録を再試行できる
+func (u *imageUsecase) AppendUpload(ctx context.Context, itemID int64, uploadID string, offset int64, chunk io.Reader) (*UploadChunkResult, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+	if u.uploadStore == nil {
+		return nil, fmt.Errorf("resumable uploads are not configured")
+	}
+
+	session, err := u.findUpload(ctx, itemID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if offset != session.Offset {
+		return nil, fmt.Errorf("%w: expected offset %d", domainErrors.ErrUploadOffsetMismatch, session.Offset)
+	}
+
+	newOffset, err := u.uploadStore.Append(ctx, session.ID, offset, chunk, session.Size-offset)
+	if err != nil {
+		if domainErrors.IsUploadOffsetMismatchError(err) || domainErrors.IsValidationError(err) || domainErrors.IsUploadNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to write upload: %w", err)
+	}
+	session.Offset = newOffset
+
+	result := &UploadChunkResult{Upload: session}
+	if !session.IsComplete() {
+		return result, nil
+	}
+
+	image, err := u.completeUpload(ctx, session)
+	if err != nil {
+		// 内容が写真でない場合は再試行しても登録できないため、セッションを削除する
+		if domainErrors.IsValidationError(err) {
+			_ = u.uploadStore.Delete(ctx, session.ID)
+		}
+		return nil, err
+	}
+	result.Image = image
+
+	return result, nil
+}
+
+func (u *imageUsecase) completeUpload(ctx context.Context, session *entity.UploadSession) (*entity.ItemImage, error) {
+	// 受信中に他の写真が登録されている場合があるため、登録時にも利用上限を確認する
+	if err := u.quota.CheckStorage(ctx, session.Size); err != nil {
+		return nil, err
+	}
+
+	item, err := u.findItem(ctx, session.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := u.uploadStore.Open(ctx, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	defer content.Close()
+
+	image, err := u.storeImage(ctx, item, UploadImageInput{
+		FileName: session.FileName,
+		Size:     session.Size,
+		Body:     content,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 写真は登録済みのため、セッションの削除に失敗しても期限切れのクリーンアップで削除される
+	_ = u.uploadStore.Delete(ctx, session.ID)
+
+	return image, nil
+}
+
+// 分割アップロードを中止し、受信済みのデータを削除する
+func (u *imageUsecase) CancelUpload(ctx context.Context, itemID int64, uploadID string) error {
+	if u.uploadStore == nil {
+		return fmt.Errorf("resumable uploads are not configured")
+	}
+
+	session, err := u.findUpload(ctx, itemID, uploadID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.uploadStore.Delete(ctx, session.ID); err != nil {
+		return fmt.Errorf("failed to delete upload: %w", err)
+	}
+
+	return nil
+}
+
+// 期限切れの分割アップロードを削除し、削除した件数を返す
+func (u *imageUsecase) CleanupUploads(ctx context.Context) (int, error) {
+	if u.uploadStore == nil {
+		return 0, nil
+	}
+
+	removed, err := u.uploadStore.DeleteExpired(ctx, u.clock.Now())
+	if err != nil {
+		return removed, fmt.Errorf("failed to delete expired uploads: %w", err)
+	}
+
+	return removed, nil
+}
+
+// アイテムの期限内の分割アップロード（他のアイテムのセッションは存在しないものとして扱う）
+func (u *imageUsecase) findUpload(ctx context.Context, itemID int64, uploadID string) (*entity.UploadSession, error) {
+	if itemID <= 0 || uploadID == "" {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	session, err := u.uploadStore.Find(ctx, uploadID)
+	if err != nil {
+		if domainErrors.IsUploadNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve upload: %w", err)
+	}
+	if session.ItemID != itemID || session.IsExpired(u.clock.Now()) {
+		return nil, domainErrors.ErrUploadNotFound
+	}
+
+	return session, nil
+}
+
+// 推測されにくいランダムなID（セッションIDを知っている場合のみ続きを送れる）
+func uploadID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeUploadStore は分割アップロードをメモリに保存する
+type fakeUploadStore struct {
+	sessions map[string]*entity.UploadSession
+	data     map[string][]byte
+}
+
+func newFakeUploadStore() *fakeUploadStore {
+	return &fakeUploadStore{sessions: map[string]*entity.UploadSession{}, data: map[string][]byte{}}
+}
+
+func (s *fakeUploadStore) Create(ctx context.Context, session *entity.UploadSession) error {
+	copied := *session
+	s.sessions[session.ID] = &copied
+	s.data[session.ID] = nil
+	return nil
+}
+
+func (s *fakeUploadStore) Find(ctx context.Context, id string) (*entity.UploadSession, error) {
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil, domainErrors.ErrUploadNotFound
+	}
+	copied := *session
+	copied.Offset = int64(len(s.data[id]))
+	return &copied, nil
+}
+
+func (s *fakeUploadStore) Append(ctx context.Context, id string, offset int64, chunk io.Reader, limit int64) (int64, error) {
+	if offset != int64(len(s.data[id])) {
+		return 0, domainErrors.ErrUploadOffsetMismatch
+	}
+	content, err := io.ReadAll(chunk)
+	if err != nil {
+		return offset, err
+	}
+	if int64(len(content)) > limit {
+		return offset, fmt.Errorf("%w: chunk exceeds the declared size", domainErrors.ErrInvalidInput)
+	}
+	s.data[id] = append(s.data[id], content...)
+	return int64(len(s.data[id])), nil
+}
+
+func (s *fakeUploadStore) Open(ctx context.Context, id string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(s.data[id])), nil
+}
+
+func (s *fakeUploadStore) Delete(ctx context.Context, id string) error {
+	delete(s.sessions, id)
+	delete(s.data, id)
+	return nil
+}
+
+func (s *fakeUploadStore) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	removed := 0
+	for id, session := range s.sessions {
+		if session.IsExpired(now) {
+			_ = s.Delete(ctx, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func TestImageUsecase_Upload(t *testing.T) {
+	newUsecase := func(store *fakeUploadStore) (ImageUsecase, *MockItemImageRepository, *MockImageStorage) {
+		itemRepo := new(MockItemRepository)
+		imageRepo := new(MockItemImageRepository)
+		storage := new(MockImageStorage)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		return NewImageUsecase(itemRepo, imageRepo, storage, WithUploadSessions(store, 1<<20, time.Hour)), imageRepo, storage
+	}
+
+	t.Run("正常系: 分割して送り、全体を受信したら写真を登録", func(t *testing.T) {
+		store := newFakeUploadStore()
+		usecase, imageRepo, storage := newUsecase(store)
+		storage.On("Save", mock.Anything, mock.Anything, testPNG, int64(len(testPNG)), "image/png").Return(nil)
+		imageRepo.On("Create", mock.Anything, mock.MatchedBy(func(image *entity.ItemImage) bool {
+			return image.FileName == "receipt.png" && image.Size == int64(len(testPNG))
+		})).Return(&entity.ItemImage{ID: 10, ItemID: 1, StorageKey: "items/1/abc.png"}, nil)
+
+		session, err := usecase.CreateUpload(context.Background(), 1, CreateUploadInput{FileName: "receipt.png", Size: int64(len(testPNG))})
+		require.NoError(t, err)
+
+		result, err := usecase.AppendUpload(context.Background(), 1, session.ID, 0, bytes.NewReader(testPNG[:50]))
+		require.NoError(t, err)
+		assert.Equal(t, int64(50), result.Upload.Offset)
+		assert.Nil(t, result.Image)
+
+		// 中断後は受信状況の offset から再開する
+		resumed, err := usecase.GetUpload(context.Background(), 1, session.ID)
+		require.NoError(t, err)
+		result, err = usecase.AppendUpload(context.Background(), 1, session.ID, resumed.Offset, bytes.NewReader(testPNG[50:]))
+		require.NoError(t, err)
+		require.NotNil(t, result.Image)
+		assert.Equal(t, int64(10), result.Image.ID)
+		assert.Empty(t, store.sessions)
+	})
+
+	t.Run("異常系: 受信済みと異なる位置からの送信", func(t *testing.T) {
+		store := newFakeUploadStore()
+		usecase, _, _ := newUsecase(store)
+		session, err := usecase.CreateUpload(context.Background(), 1, CreateUploadInput{FileName: "receipt.png", Size: 100})
+		require.NoError(t, err)
+
+		_, err = usecase.AppendUpload(context.Background(), 1, session.ID, 10, strings.NewReader("x"))
+		assert.ErrorIs(t, err, domainErrors.ErrUploadOffsetMismatch)
+	})
+
+	t.Run("異常系: 画像以外の内容はセッションも削除", func(t *testing.T) {
+		store := newFakeUploadStore()
+		usecase, _, _ := newUsecase(store)
+		session, err := usecase.CreateUpload(context.Background(), 1, CreateUploadInput{FileName: "notes.txt", Size: 5})
+		require.NoError(t, err)
+
+		_, err = usecase.AppendUpload(context.Background(), 1, session.ID, 0, strings.NewReader("hello"))
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Empty(t, store.sessions)
+	})
+
+	t.Run("異常系: 上限サイズ超過", func(t *testing.T) {
+		usecase, _, _ := newUsecase(newFakeUploadStore())
+		_, err := usecase.CreateUpload(context.Background(), 1, CreateUploadInput{FileName: "scan.png", Size: 1<<20 + 1})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 他のアイテムのセッション", func(t *testing.T) {
+		store := newFakeUploadStore()
+		usecase, _, _ := newUsecase(store)
+		session, err := usecase.CreateUpload(context.Background(), 1, CreateUploadInput{FileName: "scan.png", Size: 10})
+		require.NoError(t, err)
+
+		_, err = usecase.GetUpload(context.Background(), 2, session.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrUploadNotFound)
+	})
+
+	t.Run("正常系: 期限切れのセッションを削除", func(t *testing.T) {
+		store := newFakeUploadStore()
+		usecase, _, _ := newUsecase(store)
+		expired, _ := entity.NewUploadSession("expired", 1, "scan.png", 10, time.Now().Add(-2*time.Hour), time.Hour)
+		_ = store.Create(context.Background(), expired)
+		_, err := usecase.CreateUpload(context.Background(), 1, CreateUploadInput{FileName: "scan.png", Size: 10})
+		require.NoError(t, err)
+
+		removed, err := usecase.CleanupUploads(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		assert.Len(t, store.sessions, 1)
+	})
+}
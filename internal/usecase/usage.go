@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 利用状況を記録する操作者の上限（X-User-ID は自由に指定できるため、メモリ使用量を抑える）
+const DefaultMaxUsageActors = 10000
+
+// 操作者ごとのAPIの利用状況
+type APIUsage struct {
+	Actor        string     `json:"actor,omitempty"`
+	Requests     int64      `json:"requests"`
+	ClientErrors int64      `json:"client_errors"` // 4xx
+	ServerErrors int64      `json:"server_errors"` // 5xx
+	ErrorRate    float64    `json:"error_rate"`    // エラー（4xx・5xx）の割合
+	LastUsedAt   *time.Time `json:"last_used_at"`  // 最後にリクエストした日時（未使用の場合は null）
+}
+
+// 全操作者の利用状況の集計（管理者用）
+type APIUsageRollup struct {
+	Since  time.Time   `json:"since"` // 集計の開始日時（サーバーの起動時）
+	Total  APIUsage    `json:"total"`
+	Actors []*APIUsage `json:"actors"` // リクエスト数の多い順
+}
+
+// 操作者ごとのリクエスト数・エラー数・最終利用日時を集計する
+// 集計はプロセス内のみで持ち、再起動するとリセットされる
+type UsageTracker struct {
+	maxActors int
+	clock     entity.Clock
+	since     time.Time
+
+	mu    sync.Mutex
+	usage map[string]*APIUsage
+}
+
+func NewUsageTracker(maxActors int) *UsageTracker {
+	if maxActors <= 0 {
+		maxActors = DefaultMaxUsageActors
+	}
+	return &UsageTracker{
+		maxActors: maxActors,
+		clock:     entity.SystemClock,
+		since:     entity.SystemClock.Now(),
+		usage:     make(map[string]*APIUsage),
+	}
+}
+
+// レスポンスのステータスを操作者の利用状況に加算する
+func (t *UsageTracker) Record(actor string, status int) {
+	now := t.clock.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	usage, ok := t.usage[actor]
+	if !ok {
+		if len(t.usage) >= t.maxActors {
+			t.evictLeastRecentlyUsed()
+		}
+		usage = &APIUsage{Actor: actor}
+		t.usage[actor] = usage
+	}
+
+	usage.Requests++
+	switch {
+	case status >= http.StatusInternalServerError:
+		usage.ServerErrors++
+	case status >= http.StatusBadRequest:
+		usage.ClientErrors++
+	}
+	usage.LastUsedAt = &now
+}
+
+// 上限を超える場合は、最も長く使われていない操作者の集計を破棄する
+func (t *UsageTracker) evictLeastRecentlyUsed() {
+	var oldest *APIUsage
+	for _, usage := range t.usage {
+		if oldest == nil || usage.LastUsedAt.Before(*oldest.LastUsedAt) {
+			oldest = usage
+		}
+	}
+	if oldest != nil {
+		delete(t.usage, oldest.Actor)
+	}
+}
+
+// 操作者の利用状況（リクエストがない場合は0件）
+func (t *UsageTracker) Get(actor string) *APIUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	usage, ok := t.usage[actor]
+	if !ok {
+		return &APIUsage{Actor: actor}
+	}
+	return usage.snapshot()
+}
+
+// 全操作者の利用状況
+func (t *UsageTracker) Rollup() *APIUsageRollup {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rollup := &APIUsageRollup{Since: t.since, Actors: make([]*APIUsage, 0, len(t.usage))}
+	for _, usage := range t.usage {
+		rollup.Actors = append(rollup.Actors, usage.snapshot())
+
+		rollup.Total.Requests += usage.Requests
+		rollup.Total.ClientErrors += usage.ClientErrors
+		rollup.Total.ServerErrors += usage.ServerErrors
+		if rollup.Total.LastUsedAt == nil || usage.LastUsedAt.After(*rollup.Total.LastUsedAt) {
+			rollup.Total.LastUsedAt = usage.LastUsedAt
+		}
+	}
+	rollup.Total.ErrorRate = errorRate(&rollup.Total)
+
+	sort.Slice(rollup.Actors, func(i, j int) bool {
+		if rollup.Actors[i].Requests != rollup.Actors[j].Requests {
+			return rollup.Actors[i].Requests > rollup.Actors[j].Requests
+		}
+		return rollup.Actors[i].Actor < rollup.Actors[j].Actor
+	})
+	return rollup
+}
+
+// ロックの外で参照できるようコピーを返す
+func (u *APIUsage) snapshot() *APIUsage {
+	copied := *u
+	copied.ErrorRate = errorRate(u)
+	return &copied
+}
+
+func errorRate(u *APIUsage) float64 {
+	if u.Requests == 0 {
+		return 0
+	}
+	return float64(u.ClientErrors+u.ServerErrors) / float64(u.Requests)
+}
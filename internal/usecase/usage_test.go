@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func TestUsageTracker(t *testing.T) {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	now := start
+	newTracker := func(maxActors int) *UsageTracker {
+		tracker := NewUsageTracker(maxActors)
+		tracker.clock = entity.ClockFunc(func() time.Time { return now })
+		tracker.since = start
+		return tracker
+	}
+
+	t.Run("正常系: 操作者ごとにリクエスト数・エラー数を集計", func(t *testing.T) {
+		tracker := newTracker(0)
+		tracker.Record("user-1", http.StatusOK)
+		tracker.Record("user-1", http.StatusNotFound)
+		tracker.Record("user-1", http.StatusInternalServerError)
+		now = start.Add(time.Minute)
+		tracker.Record("user-1", http.StatusCreated)
+		tracker.Record("user-2", http.StatusOK)
+
+		usage := tracker.Get("user-1")
+		assert.Equal(t, int64(4), usage.Requests)
+		assert.Equal(t, int64(1), usage.ClientErrors)
+		assert.Equal(t, int64(1), usage.ServerErrors)
+		assert.Equal(t, 0.5, usage.ErrorRate)
+		require.NotNil(t, usage.LastUsedAt)
+		assert.Equal(t, start.Add(time.Minute), *usage.LastUsedAt)
+
+		rollup := tracker.Rollup()
+		assert.Equal(t, start, rollup.Since)
+		assert.Equal(t, int64(5), rollup.Total.Requests)
+		assert.Equal(t, 0.4, rollup.Total.ErrorRate)
+		require.Len(t, rollup.Actors, 2)
+		assert.Equal(t, "user-1", rollup.Actors[0].Actor)
+		assert.Equal(t, "user-2", rollup.Actors[1].Actor)
+	})
+
+	t.Run("正常系: リクエストのない操作者は0件", func(t *testing.T) {
+		usage := newTracker(0).Get("user-1")
+		assert.Equal(t, &APIUsage{Actor: "user-1"}, usage)
+	})
+
+	t.Run("正常系: 上限を超えると最も長く使われていない操作者を破棄", func(t *testing.T) {
+		tracker := newTracker(2)
+		now = start
+		tracker.Record("user-1", http.StatusOK)
+		now = start.Add(time.Second)
+		tracker.Record("user-2", http.StatusOK)
+		now = start.Add(2 * time.Second)
+		tracker.Record("user-1", http.StatusOK)
+		tracker.Record("user-3", http.StatusOK)
+
+		assert.Equal(t, int64(0), tracker.Get("user-2").Requests)
+		assert.Equal(t, int64(2), tracker.Get("user-1").Requests)
+		assert.Equal(t, int64(1), tracker.Get("user-3").Requests)
+	})
+}
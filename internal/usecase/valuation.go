@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ValuationUsecase interface {
+	RecordValuation(ctx context.Context, itemID int64, input RecordValuationInput) (*entity.Valuation, error)
+	GetValuations(ctx context.Context, itemID int64) ([]*entity.Valuation, error)
+	RefreshItemValuation(ctx context.Context, itemID int64) (*entity.Valuation, error)
+	AdjustValuations(ctx context.Context, input AdjustValuationsInput) (*ValuationAdjustment, error)
+}
+
+type RecordValuationInput struct {
+	ValuatedAt string       `json:"valuated_at"` // 未指定の場合は当日
+	Value      entity.Money `json:"value"`
+	Source     string       `json:"source"`
+	Reason     string       `json:"reason,omitempty"`
+}
+
+type valuationUsecase struct {
+	itemRepo      ItemRepository
+	valuationRepo ValuationRepository
+	readOnly      *ReadOnlySwitch
+
+	// 市場価格の取得先（未指定の場合は市場価格による評価額の更新はできない）
+	priceProvider    PriceProvider
+	priceTimeout     time.Duration
+	priceRetryPolicy RetryPolicy
+
+	// 一括調整のトランザクション管理（未指定の場合はアイテムごとに記録する）
+	transactor Transactor
+
+	// CDNのキャッシュの削除（未指定の場合は削除しない）
+	cachePurger CachePurger
+
+	// 評価日を省略した場合の「今日」の基準にする現在時刻
+	clock entity.Clock
+}
+
+// ValuationUsecaseの任意の依存を指定するオプション
+type ValuationUsecaseOption func(*valuationUsecase)
+
+// 市場価格の取得先を指定
+func WithPriceProvider(provider PriceProvider) ValuationUsecaseOption {
+	return func(u *valuationUsecase) {
+		u.priceProvider = provider
+	}
+}
+
+// 市場価格の取得1回あたりのタイムアウトを指定
+func WithPriceTimeout(timeout time.Duration) ValuationUsecaseOption {
+	return func(u *valuationUsecase) {
+		u.priceTimeout = timeout
+	}
+}
+
+// 市場価格の取得の再試行方針を指定
+func WithPriceRetryPolicy(policy RetryPolicy) ValuationUsecaseOption {
+	return func(u *valuationUsecase) {
+		u.priceRetryPolicy = policy
+	}
+}
+
+// 現在時刻の取得元を指定（デフォルトはシステムの時刻）
+func WithValuationClock(clock entity.Clock) ValuationUsecaseOption {
+	return func(u *valuationUsecase) {
+		u.clock = clock
+	}
+}
+
+func NewValuationUsecase(itemRepo ItemRepository, valuationRepo ValuationRepository, readOnly *ReadOnlySwitch, opts ...ValuationUsecaseOption) ValuationUsecase {
+	if readOnly == nil {
+		readOnly = NewReadOnlySwitch(false)
+	}
+	u := &valuationUsecase{
+		itemRepo:         itemRepo,
+		valuationRepo:    valuationRepo,
+		readOnly:         readOnly,
+		priceTimeout:     DefaultPriceTimeout,
+		priceRetryPolicy: DefaultPriceRetryPolicy,
+		clock:            entity.SystemClock,
+	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
+}
+
+func (u *valuationUsecase) RecordValuation(ctx context.Context, itemID int64, input RecordValuationInput) (*entity.Valuation, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+
+	item, err := u.findItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	valuatedAt, err := entity.ParseDate(input.ValuatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: valuated_at must be in YYYY-MM-DD format", domainErrors.ErrInvalidInput)
+	}
+	if valuatedAt.IsZero() {
+		valuatedAt = entity.DateOf(u.clock.Now())
+	}
+
+	valuation, err := entity.NewValuation(itemID, valuatedAt, input.Value, input.Source, u.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	valuation.Reason = strings.TrimSpace(input.Reason)
+	if err := valuation.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	created, err := u.valuationRepo.Create(ctx, valuation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create valuation: %w", err)
+	}
+	purgeItems(ctx, u.cachePurger, item)
+
+	return created, nil
+}
+
+func (u *valuationUsecase) GetValuations(ctx context.Context, itemID int64) ([]*entity.Valuation, error) {
+	if _, err := u.findItem(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	valuations, err := u.valuationRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve valuations: %w", err)
+	}
+
+	if valuations == nil {
+		valuations = []*entity.Valuation{}
+	}
+
+	return valuations, nil
+}
+
+func (u *valuationUsecase) findItem(ctx context.Context, itemID int64) (*entity.Item, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return item, nil
+}
+
+// 最新の評価額をアイテムに設定し、含み損益を求める
+func (u *itemUsecase) attachValuations(ctx context.Context, items ...*entity.Item) error {
+	if u.valuationRepo == nil || len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+
+	latest, err := u.valuationRepo.FindLatestByItemIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve valuations: %w", err)
+	}
+
+	for _, item := range items {
+		item.SetLatestValuation(latest[item.ID])
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 一括調整で指定できる割合（%）の範囲
+const (
+	MinAdjustmentPercent = -100
+	MaxAdjustmentPercent = 1000
+)
+
+// 一括調整で記録する評価額の取得元
+const AdjustmentValuationSource = "bulk-adjustment"
+
+// 一括調整で1回に読み込むアイテム数
+const adjustmentBatchSize = 100
+
+// 絞り込んだアイテムの評価額を一律の割合で調整する（例: バッグの評価額を10%下げる）
+type AdjustValuationsInput struct {
+	Category string  `json:"category"`
+	Tag      string  `json:"tag"`
+	Percent  float64 `json:"percent"` // 増減の割合（-10 で10%減）
+	Reason   string  `json:"reason"`
+}
+
+type ValuationAdjustment struct {
+	ValuatedAt entity.Date         `json:"valuated_at"`
+	Percent    float64             `json:"percent"`
+	Reason     string              `json:"reason"`
+	Count      int                 `json:"count"`
+	Items      []AdjustedValuation `json:"items"`
+}
+
+type AdjustedValuation struct {
+	ItemID int64        `json:"item_id"`
+	Before entity.Money `json:"before"` // 調整前の評価額（評価額がない場合は購入価格）
+	After  entity.Money `json:"after"`
+}
+
+// 一括調整を1つのトランザクションで行うためのトランザクション管理を指定
+func WithValuationTransactor(transactor Transactor) ValuationUsecaseOption {
+	return func(u *valuationUsecase) {
+		u.transactor = transactor
+	}
+}
+
+// 絞り込んだアイテムの現在の評価額（評価額がない場合は購入価格）を割合で増減し、当日の評価額として記録する
+// 途中で失敗した場合は、トランザクション管理が指定されていればすべて取り消す
+func (u *valuationUsecase) AdjustValuations(ctx context.Context, input AdjustValuationsInput) (*ValuationAdjustment, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+
+	query, err := adjustmentQuery(input)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustment := &ValuationAdjustment{
+		ValuatedAt: entity.DateOf(u.clock.Now()),
+		Percent:    input.Percent,
+		Reason:     strings.TrimSpace(input.Reason),
+		Items:      []AdjustedValuation{},
+	}
+
+	var adjusted []*entity.Item
+	adjust := func(ctx context.Context) error {
+		for {
+			items, err := u.itemRepo.FindAll(ctx, query)
+			if err != nil {
+				return fmt.Errorf("failed to retrieve items: %w", err)
+			}
+			if err := u.adjustBatch(ctx, items, adjustment); err != nil {
+				return err
+			}
+			adjusted = append(adjusted, items...)
+			if len(items) < adjustmentBatchSize {
+				return nil
+			}
+			query.AfterID = items[len(items)-1].ID
+		}
+	}
+
+	if u.transactor != nil {
+		err = runInTransaction(ctx, u.transactor, adjust)
+	} else {
+		err = adjust(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	purgeItems(ctx, u.cachePurger, adjusted...)
+
+	adjustment.Count = len(adjustment.Items)
+	return adjustment, nil
+}
+
+func (u *valuationUsecase) adjustBatch(ctx context.Context, items []*entity.Item, adjustment *ValuationAdjustment) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+
+	latest, err := u.valuationRepo.FindLatestByItemIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve valuations: %w", err)
+	}
+
+	for _, item := range items {
+		before := item.PurchasePrice
+		if v, ok := latest[item.ID]; ok {
+			before = v.Value
+		}
+		after, err := before.MulPercent(new(big.Rat).SetFloat64(100+adjustment.Percent), entity.RoundHalfUp)
+		if err != nil {
+			return fmt.Errorf("%w: item %d: %s", domainErrors.ErrInvalidInput, item.ID, err.Error())
+		}
+
+		valuation, err := entity.NewValuation(item.ID, adjustment.ValuatedAt, after, AdjustmentValuationSource, u.clock.Now())
+		if err != nil {
+			return fmt.Errorf("%w: item %d: %s", domainErrors.ErrInvalidInput, item.ID, err.Error())
+		}
+		valuation.Reason = adjustment.Reason
+
+		if _, err := u.valuationRepo.Create(ctx, valuation); err != nil {
+			return fmt.Errorf("failed to create valuation of item %d: %w", item.ID, err)
+		}
+
+		adjustment.Items = append(adjustment.Items, AdjustedValuation{
+			ItemID: item.ID,
+			Before: before,
+			After:  after,
+		})
+	}
+
+	return nil
+}
+
+// 入力を検証し、対象のアイテムを絞り込む条件を作る（全アイテムの誤調整を防ぐため、絞り込みは必須）
+func adjustmentQuery(input AdjustValuationsInput) (entity.ItemQuery, error) {
+	var errs []string
+
+	category := strings.TrimSpace(input.Category)
+	if category != "" && !entity.IsValidCategory(category) {
+		errs = append(errs, "invalid category: "+category)
+	}
+
+	var tag string
+	if input.Tag != "" {
+		normalized, err := entity.NormalizeTagName(input.Tag)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		tag = normalized
+	}
+
+	if category == "" && input.Tag == "" {
+		errs = append(errs, "category or tag is required")
+	}
+
+	if input.Percent == 0 || input.Percent <= MinAdjustmentPercent || input.Percent > MaxAdjustmentPercent {
+		errs = append(errs, fmt.Sprintf("percent must be non-zero, greater than %d and at most %d", MinAdjustmentPercent, MaxAdjustmentPercent))
+	}
+
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		errs = append(errs, "reason is required")
+	} else if utf8.RuneCountInString(reason) > entity.MaxValuationReasonLength {
+		errs = append(errs, fmt.Sprintf("reason must be %d characters or less", entity.MaxValuationReasonLength))
+	}
+
+	if len(errs) > 0 {
+		return entity.ItemQuery{}, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, strings.Join(errs, ", "))
+	}
+
+	return entity.ItemQuery{
+		Limit:    adjustmentBatchSize,
+		Sort:     entity.SortByID,
+		Order:    entity.SortAsc,
+		Category: category,
+		Tag:      tag,
+	}, nil
+}
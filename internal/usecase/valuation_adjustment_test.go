@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestValuationUsecase_AdjustValuations(t *testing.T) {
+	t.Run("正常系: 最新の評価額（なければ購入価格）を割合で調整して記録", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		valuationRepo := new(MockValuationRepository)
+		transactor := &fakeTransactor{}
+
+		itemRepo.On("FindAll", mock.Anything, mock.MatchedBy(func(q entity.ItemQuery) bool {
+			return q.Category == "バッグ" && q.Sort == entity.SortByID && q.AfterID == 0
+		})).Return([]*entity.Item{
+			{ID: 1, PurchasePrice: entity.JPY(1000000)},
+			{ID: 2, PurchasePrice: entity.Money{Amount: 250000, Currency: "USD"}},
+		}, nil)
+		valuationRepo.On("FindLatestByItemIDs", mock.Anything, []int64{1, 2}).Return(map[int64]*entity.Valuation{
+			1: {ItemID: 1, Value: entity.JPY(1200005)},
+		}, nil)
+
+		var created []*entity.Valuation
+		valuationRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			created = append(created, args.Get(1).(*entity.Valuation))
+		}).Return(&entity.Valuation{}, nil)
+
+		usecase := NewValuationUsecase(itemRepo, valuationRepo, nil, WithValuationTransactor(transactor))
+		adjustment, err := usecase.AdjustValuations(context.Background(), AdjustValuationsInput{
+			Category: "バッグ",
+			Percent:  -10,
+			Reason:   " 市場の調整 ",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, adjustment.Count)
+		assert.Equal(t, []AdjustedValuation{
+			{ItemID: 1, Before: entity.JPY(1200005), After: entity.JPY(1080005)},
+			{ItemID: 2, Before: entity.Money{Amount: 250000, Currency: "USD"}, After: entity.Money{Amount: 225000, Currency: "USD"}},
+		}, adjustment.Items)
+
+		require.Len(t, created, 2)
+		for _, v := range created {
+			assert.Equal(t, AdjustmentValuationSource, v.Source)
+			assert.Equal(t, "市場の調整", v.Reason)
+			assert.Equal(t, adjustment.ValuatedAt, v.ValuatedAt)
+		}
+		assert.False(t, transactor.rolledBack)
+	})
+
+	t.Run("異常系: 記録に失敗した場合はロールバック", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		valuationRepo := new(MockValuationRepository)
+		transactor := &fakeTransactor{}
+
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{{ID: 1, PurchasePrice: entity.JPY(1000)}}, nil)
+		valuationRepo.On("FindLatestByItemIDs", mock.Anything, []int64{1}).Return(map[int64]*entity.Valuation{}, nil)
+		valuationRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+
+		usecase := NewValuationUsecase(itemRepo, valuationRepo, nil, WithValuationTransactor(transactor))
+		adjustment, err := usecase.AdjustValuations(context.Background(), AdjustValuationsInput{Tag: "vintage", Percent: 5, Reason: "相場上昇"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Nil(t, adjustment)
+		assert.True(t, transactor.rolledBack)
+	})
+
+	tests := []struct {
+		name     string
+		input    AdjustValuationsInput
+		readOnly bool
+		expected error
+	}{
+		{
+			name:     "異常系: 絞り込みなし",
+			input:    AdjustValuationsInput{Percent: -10, Reason: "調整"},
+			expected: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:     "異常系: 無効なカテゴリー",
+			input:    AdjustValuationsInput{Category: "車", Percent: -10, Reason: "調整"},
+			expected: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:     "異常系: 割合が0",
+			input:    AdjustValuationsInput{Category: "バッグ", Reason: "調整"},
+			expected: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:     "異常系: -100%以下",
+			input:    AdjustValuationsInput{Category: "バッグ", Percent: -100, Reason: "調整"},
+			expected: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:     "異常系: 理由なし",
+			input:    AdjustValuationsInput{Category: "バッグ", Percent: -10},
+			expected: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:     "異常系: 読み取り専用モード",
+			input:    AdjustValuationsInput{Category: "バッグ", Percent: -10, Reason: "調整"},
+			readOnly: true,
+			expected: domainErrors.ErrReadOnly,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			valuationRepo := new(MockValuationRepository)
+
+			usecase := NewValuationUsecase(itemRepo, valuationRepo, NewReadOnlySwitch(tt.readOnly))
+			adjustment, err := usecase.AdjustValuations(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Nil(t, adjustment)
+			itemRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+		})
+	}
+}
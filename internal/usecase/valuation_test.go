@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockValuationRepository は評価額履歴のモックリポジトリ
+type MockValuationRepository struct {
+	mock.Mock
+}
+
+func (m *MockValuationRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.Valuation, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Valuation), args.Error(1)
+}
+
+func (m *MockValuationRepository) FindLatestByItemIDs(ctx context.Context, itemIDs []int64) (map[int64]*entity.Valuation, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]*entity.Valuation), args.Error(1)
+}
+
+func (m *MockValuationRepository) Create(ctx context.Context, valuation *entity.Valuation) (*entity.Valuation, error) {
+	args := m.Called(ctx, valuation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Valuation), args.Error(1)
+}
+
+func TestValuationUsecase_RecordValuation(t *testing.T) {
+	item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+	now := time.Date(2024, 6, 15, 23, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		itemID      int64
+		input       RecordValuationInput
+		setupMock   func(*MockItemRepository, *MockValuationRepository)
+		expectedErr error
+	}{
+		{
+			name:   "正常系: 評価額を記録",
+			itemID: 1,
+			input:  RecordValuationInput{ValuatedAt: "2024-06-01", Value: entity.JPY(1200000), Source: "鑑定"},
+			setupMock: func(itemRepo *MockItemRepository, valuationRepo *MockValuationRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				valuationRepo.On("Create", mock.Anything, mock.MatchedBy(func(v *entity.Valuation) bool {
+					return v.ItemID == 1 && v.ValuatedAt.String() == "2024-06-01" && v.Value == entity.JPY(1200000)
+				})).Return(&entity.Valuation{ID: 1, ItemID: 1, ValuatedAt: entity.MustParseDate("2024-06-01"), Value: entity.JPY(1200000), Source: "鑑定"}, nil)
+			},
+		},
+		{
+			name:   "正常系: 評価日未指定は当日",
+			itemID: 1,
+			input:  RecordValuationInput{Value: entity.JPY(1200000), Source: "鑑定"},
+			setupMock: func(itemRepo *MockItemRepository, valuationRepo *MockValuationRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				valuationRepo.On("Create", mock.Anything, mock.MatchedBy(func(v *entity.Valuation) bool {
+					return v.ValuatedAt.String() == "2024-06-15" && v.CreatedAt.Equal(now)
+				})).Return(&entity.Valuation{ID: 1, ItemID: 1}, nil)
+			},
+		},
+		{
+			name:   "異常系: 取得元が空",
+			itemID: 1,
+			input:  RecordValuationInput{ValuatedAt: "2024-06-01", Value: entity.JPY(1200000)},
+			setupMock: func(itemRepo *MockItemRepository, _ *MockValuationRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:   "異常系: 評価日の形式が不正",
+			itemID: 1,
+			input:  RecordValuationInput{ValuatedAt: "2024/06/01", Value: entity.JPY(1200000), Source: "鑑定"},
+			setupMock: func(itemRepo *MockItemRepository, _ *MockValuationRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:   "異常系: 存在しないアイテム",
+			itemID: 999,
+			input:  RecordValuationInput{ValuatedAt: "2024-06-01", Value: entity.JPY(1200000), Source: "鑑定"},
+			setupMock: func(itemRepo *MockItemRepository, _ *MockValuationRepository) {
+				itemRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			valuationRepo := new(MockValuationRepository)
+			tt.setupMock(itemRepo, valuationRepo)
+
+			usecase := NewValuationUsecase(itemRepo, valuationRepo, nil, WithValuationClock(entity.FixedClock(now)))
+			valuation, err := usecase.RecordValuation(context.Background(), tt.itemID, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, valuation)
+				valuationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, valuation)
+			}
+			valuationRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("異常系: 読み取り専用モード", func(t *testing.T) {
+		usecase := NewValuationUsecase(new(MockItemRepository), new(MockValuationRepository), NewReadOnlySwitch(true))
+		_, err := usecase.RecordValuation(context.Background(), 1, RecordValuationInput{Value: entity.JPY(1), Source: "鑑定"})
+		assert.ErrorIs(t, err, domainErrors.ErrReadOnly)
+	})
+}
+
+func TestValuationUsecase_GetValuations(t *testing.T) {
+	item, _ := entity.NewItem("時計1", "時計", "ROLEX", entity.JPY(1000000), entity.MustParseDate("2023-01-01"), time.Now())
+	itemRepo := new(MockItemRepository)
+	valuationRepo := new(MockValuationRepository)
+	itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+	valuationRepo.On("FindByItemID", mock.Anything, int64(1)).Return(nil, nil)
+
+	usecase := NewValuationUsecase(itemRepo, valuationRepo, nil)
+	valuations, err := usecase.GetValuations(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.Valuation{}, valuations)
+}
+
+func TestItemUsecase_WithValuations(t *testing.T) {
+	item1 := &entity.Item{ID: 1, PurchasePrice: entity.JPY(1000000)}
+	item2 := &entity.Item{ID: 2, PurchasePrice: entity.JPY(500000)}
+
+	mockRepo := new(MockItemRepository)
+	valuationRepo := new(MockValuationRepository)
+	mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{item1, item2}, nil)
+	mockRepo.On("Count", mock.Anything, mock.Anything).Return(2, nil)
+	valuationRepo.On("FindLatestByItemIDs", mock.Anything, []int64{1, 2}).Return(map[int64]*entity.Valuation{
+		1: {ItemID: 1, ValuatedAt: entity.MustParseDate("2024-06-01"), Value: entity.JPY(1300000), Source: "鑑定"},
+	}, nil)
+
+	usecase := NewItemUsecase(mockRepo, WithValuations(valuationRepo))
+	list, err := usecase.GetAllItems(context.Background(), ListItemsInput{})
+
+	require.NoError(t, err)
+	require.Len(t, list.Items, 2)
+	assert.Equal(t, entity.JPY(300000), *list.Items[0].UnrealizedGain)
+	// 評価額のないアイテムは含み損益なし
+	assert.Nil(t, list.Items[1].LatestValuation)
+	assert.Nil(t, list.Items[1].UnrealizedGain)
+}
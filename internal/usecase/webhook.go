@@ -0,0 +1,408 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// Webhook の再送方針（送信の失敗から次の送信までの待ち時間は BaseDelay から2倍ずつ増やす）
+var DefaultWebhookRetryPolicy = RetryPolicy{
+	MaxAttempts: 8,
+	BaseDelay:   30 * time.Second,
+	MaxDelay:    time.Hour,
+}
+
+const (
+	// 1回の配信の実行で送信する最大件数
+	webhookDeliveryBatchSize = 100
+
+	// 送信ログで返す最大件数（新しい順）
+	WebhookDeliveryLogLimit = 100
+
+	// 送信ログに残すエラーの最大文字数
+	maxWebhookErrorLength = 500
+)
+
+type WebhookUsecase interface {
+	// アイテムの変更イベントから送信を登録する
+	ItemEventHandler
+
+	CreateWebhook(ctx context.Context, input CreateWebhookInput) (*entity.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]*entity.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	GetDeliveries(ctx context.Context, webhookID int64) ([]*entity.WebhookDelivery, error)
+
+	// 送信時刻を過ぎた送信を実行する（定期実行用）
+	DeliverDue(ctx context.Context) (*WebhookDeliveryResult, error)
+
+	// 新しい送信を登録したときに通知する（定期実行の間隔を待たずに送信するため）
+	Pending() <-chan struct{}
+}
+
+type CreateWebhookInput struct {
+	URL    string                `json:"url"`
+	Events []entity.WebhookEvent `json:"events"` // 未指定の場合はすべてのイベント
+}
+
+// 送信先へのリクエスト
+type WebhookRequest struct {
+	URL    string
+	Header map[string]string
+	Body   []byte
+}
+
+// 送信先に POST する
+// 送信先に接続できた場合は、ステータスが 2xx 以外でもエラーにせずステータスを返す
+type WebhookSender interface {
+	Send(ctx context.Context, req WebhookRequest) (status int, err error)
+}
+
+// 送信する本文
+type WebhookPayload struct {
+	Event      entity.WebhookEvent `json:"event"`
+	OccurredAt time.Time           `json:"occurred_at"`
+	Data       WebhookItemData     `json:"data"`
+}
+
+type WebhookItemData struct {
+	ItemID int64              `json:"item_id"`
+	Action entity.AuditAction `json:"action"` // 監査ログと同じ操作（update / hold / revert など）
+	Actor  string             `json:"actor"`
+
+	// 変更後のアイテム（削除の場合は含めない）
+	Item *entity.Item `json:"item,omitempty"`
+}
+
+// 配信の実行結果
+type WebhookDeliveryResult struct {
+	Succeeded int `json:"succeeded"`
+	Retrying  int `json:"retrying"` // 失敗して再送を予定したもの
+	Failed    int `json:"failed"`   // 再送の上限に達したもの
+}
+
+type webhookUsecase struct {
+	webhookRepo  WebhookRepository
+	deliveryRepo WebhookDeliveryRepository
+	itemRepo     ItemRepository
+	sender       WebhookSender
+	readOnly     *ReadOnlySwitch
+	retryPolicy  RetryPolicy
+	clock        entity.Clock
+
+	pending chan struct{}
+}
+
+// WebhookUsecaseの任意の依存を指定するオプション
+type WebhookUsecaseOption func(*webhookUsecase)
+
+// 読み取り専用モードのスイッチを指定
+func WithWebhookReadOnlySwitch(readOnly *ReadOnlySwitch) WebhookUsecaseOption {
+	return func(u *webhookUsecase) {
+		u.readOnly = readOnly
+	}
+}
+
+// 再送方針を指定
+func WithWebhookRetryPolicy(policy RetryPolicy) WebhookUsecaseOption {
+	return func(u *webhookUsecase) {
+		u.retryPolicy = policy
+	}
+}
+
+// 現在時刻の取得元を指定（デフォルトはシステムの時刻）
+func WithWebhookClock(clock entity.Clock) WebhookUsecaseOption {
+	return func(u *webhookUsecase) {
+		u.clock = clock
+	}
+}
+
+func NewWebhookUsecase(webhookRepo WebhookRepository, deliveryRepo WebhookDeliveryRepository, itemRepo ItemRepository, sender WebhookSender, opts ...WebhookUsecaseOption) WebhookUsecase {
+	u := &webhookUsecase{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		itemRepo:     itemRepo,
+		sender:       sender,
+		readOnly:     NewReadOnlySwitch(false),
+		retryPolicy:  DefaultWebhookRetryPolicy,
+		clock:        entity.SystemClock,
+		pending:      make(chan struct{}, 1),
+	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
+}
+
+// 送信先を登録し、署名の鍵を含めて返す（鍵を返すのはこのときのみ）
+func (u *webhookUsecase) CreateWebhook(ctx context.Context, input CreateWebhookInput) (*entity.Webhook, error) {
+	if u.readOnly.Enabled() {
+		return nil, domainErrors.ErrReadOnly
+	}
+
+	secret, err := webhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	webhook, err := entity.NewWebhook(input.URL, input.Events, ActorFromContext(ctx), secret, u.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+
+	created, err := u.webhookRepo.Create(ctx, webhook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return created, nil
+}
+
+// 操作者が登録した送信先
+func (u *webhookUsecase) GetWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	webhooks, err := u.webhookRepo.FindByOwner(ctx, ActorFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhooks: %w", err)
+	}
+
+	redacted := make([]*entity.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		redacted[i] = webhook.Redacted()
+	}
+	return redacted, nil
+}
+
+func (u *webhookUsecase) DeleteWebhook(ctx context.Context, id int64) error {
+	if u.readOnly.Enabled() {
+		return domainErrors.ErrReadOnly
+	}
+
+	if _, err := u.findOwnWebhook(ctx, id); err != nil {
+		return err
+	}
+
+	if err := u.webhookRepo.Delete(ctx, id); err != nil {
+		if domainErrors.IsWebhookNotFoundError(err) {
+			return err
+		}
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// 送信先の送信ログ（新しい順、最大 WebhookDeliveryLogLimit 件）
+func (u *webhookUsecase) GetDeliveries(ctx context.Context, webhookID int64) ([]*entity.WebhookDelivery, error) {
+	if _, err := u.findOwnWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := u.deliveryRepo.FindByWebhookID(ctx, webhookID, WebhookDeliveryLogLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook deliveries: %w", err)
+	}
+	if deliveries == nil {
+		deliveries = []*entity.WebhookDelivery{}
+	}
+	return deliveries, nil
+}
+
+// 他の操作者が登録した送信先は、ないものとして扱う
+func (u *webhookUsecase) findOwnWebhook(ctx context.Context, id int64) (*entity.Webhook, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	webhook, err := u.webhookRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsWebhookNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+	if webhook.Owner != ActorFromContext(ctx) {
+		return nil, domainErrors.ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+// イベントを通知する送信先ごとに送信を登録する（送信は DeliverDue で行う）
+// 登録に失敗した場合はエラーを返す（アウトボックスから再送した場合、登録済みの送信先には同じイベントをもう一度送る）
+func (u *webhookUsecase) HandleItemEvent(ctx context.Context, event ItemEvent) error {
+	webhooks, err := u.webhookRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve webhooks: %w", err)
+	}
+
+	webhookEvent := entity.WebhookEventOf(event.Action)
+	var targets []*entity.Webhook
+	for _, webhook := range webhooks {
+		if webhook.Subscribes(webhookEvent) {
+			targets = append(targets, webhook)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	now := u.clock.Now()
+	occurredAt := event.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = now
+	}
+	payload := WebhookPayload{
+		Event:      webhookEvent,
+		OccurredAt: occurredAt,
+		Data: WebhookItemData{
+			ItemID: event.ItemID,
+			Action: event.Action,
+			Actor:  event.Actor,
+		},
+	}
+	if webhookEvent != entity.WebhookItemDeleted {
+		if item, err := u.itemRepo.FindByID(ctx, event.ItemID); err == nil {
+			payload.Data.Item = item
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	for _, webhook := range targets {
+		_, err := u.deliveryRepo.Create(ctx, &entity.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			Event:         webhookEvent,
+			Payload:       body,
+			Status:        entity.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create webhook delivery: %w", err)
+		}
+	}
+
+	// 配信中の場合は、その配信が終わった後にもう一度実行する
+	select {
+	case u.pending <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (u *webhookUsecase) Pending() <-chan struct{} {
+	return u.pending
+}
+
+// 送信時刻を過ぎた送信を古い順に実行する
+// 失敗した送信は再送方針に従って次の送信時刻を予定し、上限に達した場合は失敗とする
+func (u *webhookUsecase) DeliverDue(ctx context.Context) (*WebhookDeliveryResult, error) {
+	result := &WebhookDeliveryResult{}
+
+	deliveries, err := u.deliveryRepo.FindDue(ctx, u.clock.Now(), webhookDeliveryBatchSize)
+	if err != nil {
+		return result, fmt.Errorf("failed to retrieve webhook deliveries: %w", err)
+	}
+
+	webhooks := make(map[int64]*entity.Webhook)
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = u.webhookRepo.FindByID(ctx, delivery.WebhookID)
+			if err != nil && !domainErrors.IsWebhookNotFoundError(err) {
+				return result, fmt.Errorf("failed to retrieve webhook: %w", err)
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		u.deliver(ctx, webhook, delivery)
+		if err := u.deliveryRepo.Update(ctx, delivery); err != nil {
+			return result, fmt.Errorf("failed to update webhook delivery: %w", err)
+		}
+
+		switch delivery.Status {
+		case entity.WebhookDeliverySucceeded:
+			result.Succeeded++
+		case entity.WebhookDeliveryFailed:
+			result.Failed++
+		default:
+			result.Retrying++
+		}
+	}
+
+	return result, nil
+}
+
+// 1回送信し、結果を delivery に記録する（webhook が nil の場合は送信先が削除されている）
+func (u *webhookUsecase) deliver(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) {
+	now := u.clock.Now()
+	delivery.UpdatedAt = now
+	if webhook == nil {
+		delivery.Status = entity.WebhookDeliveryFailed
+		delivery.LastError = "webhook was deleted"
+		delivery.NextAttemptAt = nil
+		return
+	}
+
+	timestamp := now.Unix()
+	status, err := u.sender.Send(ctx, WebhookRequest{
+		URL: webhook.URL,
+		Header: map[string]string{
+			"Content-Type":        "application/json",
+			"User-Agent":          "Aicon-Webhook/1.0",
+			"X-Webhook-Event":     string(delivery.Event),
+			"X-Webhook-Delivery":  strconv.FormatInt(delivery.ID, 10),
+			"X-Webhook-Timestamp": strconv.FormatInt(timestamp, 10),
+			"X-Webhook-Signature": webhook.Sign(timestamp, delivery.Payload),
+		},
+		Body: delivery.Payload,
+	})
+
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	if err == nil && status >= 200 && status < 300 {
+		delivery.Status = entity.WebhookDeliverySucceeded
+		delivery.LastError = ""
+		delivery.NextAttemptAt = nil
+		return
+	}
+
+	if err != nil {
+		delivery.LastError = err.Error()
+	} else {
+		delivery.LastError = fmt.Sprintf("unexpected status %d", status)
+	}
+	if len(delivery.LastError) > maxWebhookErrorLength {
+		delivery.LastError = delivery.LastError[:maxWebhookErrorLength]
+	}
+
+	if delivery.Attempts >= u.retryPolicy.MaxAttempts {
+		delivery.Status = entity.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		return
+	}
+	next := now.Add(u.retryPolicy.backoff(delivery.Attempts))
+	delivery.NextAttemptAt = &next
+}
+
+// 署名の鍵（推測されにくいランダムな文字列）
+func webhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
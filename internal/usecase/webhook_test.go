@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 登録された送信先をメモリに保持する
+type fakeWebhookRepository struct {
+	webhooks []*entity.Webhook
+}
+
+func (r *fakeWebhookRepository) FindAll(ctx context.Context) ([]*entity.Webhook, error) {
+	return r.webhooks, nil
+}
+
+func (r *fakeWebhookRepository) FindByOwner(ctx context.Context, owner string) ([]*entity.Webhook, error) {
+	var owned []*entity.Webhook
+	for _, webhook := range r.webhooks {
+		if webhook.Owner == owner {
+			owned = append(owned, webhook)
+		}
+	}
+	return owned, nil
+}
+
+func (r *fakeWebhookRepository) FindByID(ctx context.Context, id int64) (*entity.Webhook, error) {
+	for _, webhook := range r.webhooks {
+		if webhook.ID == id {
+			return webhook, nil
+		}
+	}
+	return nil, domainErrors.ErrWebhookNotFound
+}
+
+func (r *fakeWebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	created := *webhook
+	created.ID = int64(len(r.webhooks) + 1)
+	r.webhooks = append(r.webhooks, &created)
+	return &created, nil
+}
+
+func (r *fakeWebhookRepository) Delete(ctx context.Context, id int64) error {
+	for i, webhook := range r.webhooks {
+		if webhook.ID == id {
+			r.webhooks = append(r.webhooks[:i], r.webhooks[i+1:]...)
+			return nil
+		}
+	}
+	return domainErrors.ErrWebhookNotFound
+}
+
+// 送信をメモリに保持する
+type fakeWebhookDeliveryRepository struct {
+	deliveries []*entity.WebhookDelivery
+}
+
+func (r *fakeWebhookDeliveryRepository) Create(ctx context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	created := *delivery
+	created.ID = int64(len(r.deliveries) + 1)
+	r.deliveries = append(r.deliveries, &created)
+	return &created, nil
+}
+
+func (r *fakeWebhookDeliveryRepository) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	updated := *delivery
+	r.deliveries[delivery.ID-1] = &updated
+	return nil
+}
+
+func (r *fakeWebhookDeliveryRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	var due []*entity.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status == entity.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) {
+			copied := *delivery
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeWebhookDeliveryRepository) FindByWebhookID(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error) {
+	var found []*entity.WebhookDelivery
+	for i := len(r.deliveries) - 1; i >= 0; i-- {
+		if r.deliveries[i].WebhookID == webhookID {
+			found = append(found, r.deliveries[i])
+		}
+	}
+	return found, nil
+}
+
+// 送信したリクエストを記録し、指定した応答を順に返す
+type fakeWebhookSender struct {
+	requests  []WebhookRequest
+	responses []fakeWebhookResponse
+}
+
+type fakeWebhookResponse struct {
+	status int
+	err    error
+}
+
+func (s *fakeWebhookSender) Send(ctx context.Context, req WebhookRequest) (int, error) {
+	s.requests = append(s.requests, req)
+	resp := fakeWebhookResponse{status: 200}
+	if len(s.responses) > 0 {
+		resp, s.responses = s.responses[0], s.responses[1:]
+	}
+	return resp.status, resp.err
+}
+
+func TestWebhookUsecase_CreateWebhook(t *testing.T) {
+	ctx := WithActor(context.Background(), "alice")
+
+	t.Run("正常系: 署名の鍵を発行し、一覧では返さない", func(t *testing.T) {
+		webhookRepo := &fakeWebhookRepository{}
+		usecase := NewWebhookUsecase(webhookRepo, &fakeWebhookDeliveryRepository{}, new(MockItemRepository), &fakeWebhookSender{})
+
+		created, err := usecase.CreateWebhook(ctx, CreateWebhookInput{URL: "https://example.com/hooks"})
+		require.NoError(t, err)
+		assert.Regexp(t, `^whsec_[0-9a-f]{64}$`, created.Secret)
+		assert.Equal(t, "alice", created.Owner)
+		assert.Equal(t, entity.WebhookEvents, created.Events)
+
+		webhooks, err := usecase.GetWebhooks(ctx)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 1)
+		assert.Empty(t, webhooks[0].Secret)
+
+		// 他の操作者の一覧には含めない
+		others, err := usecase.GetWebhooks(WithActor(context.Background(), "bob"))
+		require.NoError(t, err)
+		assert.Empty(t, others)
+	})
+
+	t.Run("異常系: URLの誤り", func(t *testing.T) {
+		usecase := NewWebhookUsecase(&fakeWebhookRepository{}, &fakeWebhookDeliveryRepository{}, new(MockItemRepository), &fakeWebhookSender{})
+
+		_, err := usecase.CreateWebhook(ctx, CreateWebhookInput{URL: "not a url"})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 読み取り専用モード", func(t *testing.T) {
+		usecase := NewWebhookUsecase(&fakeWebhookRepository{}, &fakeWebhookDeliveryRepository{}, new(MockItemRepository), &fakeWebhookSender{},
+			WithWebhookReadOnlySwitch(NewReadOnlySwitch(true)))
+
+		_, err := usecase.CreateWebhook(ctx, CreateWebhookInput{URL: "https://example.com/hooks"})
+		assert.ErrorIs(t, err, domainErrors.ErrReadOnly)
+	})
+}
+
+func TestWebhookUsecase_OtherOwner(t *testing.T) {
+	webhookRepo := &fakeWebhookRepository{webhooks: []*entity.Webhook{
+		{ID: 1, URL: "https://example.com/hooks", Events: entity.WebhookEvents, Owner: "alice"},
+	}}
+	usecase := NewWebhookUsecase(webhookRepo, &fakeWebhookDeliveryRepository{}, new(MockItemRepository), &fakeWebhookSender{})
+	bob := WithActor(context.Background(), "bob")
+
+	// 他の操作者の送信先は存在しないものとして扱う
+	_, err := usecase.GetDeliveries(bob, 1)
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookNotFound)
+	assert.ErrorIs(t, usecase.DeleteWebhook(bob, 1), domainErrors.ErrWebhookNotFound)
+	assert.Len(t, webhookRepo.webhooks, 1)
+
+	require.NoError(t, usecase.DeleteWebhook(WithActor(context.Background(), "alice"), 1))
+	assert.Empty(t, webhookRepo.webhooks)
+}
+
+func TestWebhookUsecase_Deliver(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	item := &entity.Item{ID: 7, Name: "デイトナ", Brand: "ROLEX"}
+
+	newUsecase := func(sender *fakeWebhookSender, webhooks ...*entity.Webhook) (WebhookUsecase, *fakeWebhookDeliveryRepository, *time.Time) {
+		current := now
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(7)).Return(item, nil).Maybe()
+		deliveryRepo := &fakeWebhookDeliveryRepository{}
+		usecase := NewWebhookUsecase(&fakeWebhookRepository{webhooks: webhooks}, deliveryRepo, itemRepo, sender,
+			WithWebhookClock(entity.ClockFunc(func() time.Time { return current })),
+			WithWebhookRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}),
+		)
+		return usecase, deliveryRepo, &current
+	}
+
+	t.Run("正常系: 通知するイベントの送信先にのみ、署名付きで送信する", func(t *testing.T) {
+		all := &entity.Webhook{ID: 1, URL: "https://a.example.com", Events: entity.WebhookEvents, Secret: "whsec_a"}
+		deletesOnly := &entity.Webhook{ID: 2, URL: "https://b.example.com", Events: []entity.WebhookEvent{entity.WebhookItemDeleted}, Secret: "whsec_b"}
+		sender := &fakeWebhookSender{}
+		usecase, deliveryRepo, _ := newUsecase(sender, all, deletesOnly)
+
+		usecase.HandleItemEvent(context.Background(), ItemEvent{ItemID: 7, Action: entity.AuditHold, Actor: "alice"})
+		require.Len(t, deliveryRepo.deliveries, 1)
+		select {
+		case <-usecase.Pending():
+		default:
+			t.Fatal("pending notification was not sent")
+		}
+
+		result, err := usecase.DeliverDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &WebhookDeliveryResult{Succeeded: 1}, result)
+
+		require.Len(t, sender.requests, 1)
+		req := sender.requests[0]
+		assert.Equal(t, "https://a.example.com", req.URL)
+		assert.Equal(t, "item.updated", req.Header["X-Webhook-Event"])
+		assert.Equal(t, "1", req.Header["X-Webhook-Delivery"])
+		assert.Equal(t, strconv.FormatInt(now.Unix(), 10), req.Header["X-Webhook-Timestamp"])
+		assert.Equal(t, all.Sign(now.Unix(), req.Body), req.Header["X-Webhook-Signature"])
+
+		var payload WebhookPayload
+		require.NoError(t, json.Unmarshal(req.Body, &payload))
+		assert.Equal(t, entity.WebhookItemUpdated, payload.Event)
+		assert.Equal(t, entity.AuditHold, payload.Data.Action)
+		assert.Equal(t, "alice", payload.Data.Actor)
+		require.NotNil(t, payload.Data.Item)
+		assert.Equal(t, "デイトナ", payload.Data.Item.Name)
+
+		delivery := deliveryRepo.deliveries[0]
+		assert.Equal(t, entity.WebhookDeliverySucceeded, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Nil(t, delivery.NextAttemptAt)
+	})
+
+	t.Run("正常系: 削除のイベントにはアイテムを含めない", func(t *testing.T) {
+		sender := &fakeWebhookSender{}
+		usecase, _, _ := newUsecase(sender, &entity.Webhook{ID: 1, URL: "https://a.example.com", Events: entity.WebhookEvents})
+
+		usecase.HandleItemEvent(context.Background(), ItemEvent{ItemID: 7, Action: entity.AuditDelete, Actor: "alice"})
+		_, err := usecase.DeliverDue(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, sender.requests, 1)
+		var payload WebhookPayload
+		require.NoError(t, json.Unmarshal(sender.requests[0].Body, &payload))
+		assert.Equal(t, entity.WebhookItemDeleted, payload.Event)
+		assert.Nil(t, payload.Data.Item)
+	})
+
+	t.Run("正常系: 失敗したら間隔を空けて再送し、上限に達したら失敗とする", func(t *testing.T) {
+		sender := &fakeWebhookSender{responses: []fakeWebhookResponse{
+			{status: 500},
+			{err: errors.New("connection refused")},
+			{status: 404},
+		}}
+		usecase, deliveryRepo, current := newUsecase(sender, &entity.Webhook{ID: 1, URL: "https://a.example.com", Events: entity.WebhookEvents})
+		usecase.HandleItemEvent(context.Background(), ItemEvent{ItemID: 7, Action: entity.AuditCreate, Actor: "alice"})
+
+		result, err := usecase.DeliverDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &WebhookDeliveryResult{Retrying: 1}, result)
+		delivery := deliveryRepo.deliveries[0]
+		assert.Equal(t, entity.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, 500, delivery.ResponseStatus)
+		assert.Equal(t, "unexpected status 500", delivery.LastError)
+		require.NotNil(t, delivery.NextAttemptAt)
+		assert.True(t, delivery.NextAttemptAt.After(now))
+
+		// 次の送信時刻までは送信しない
+		result, err = usecase.DeliverDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &WebhookDeliveryResult{}, result)
+
+		*current = now.Add(time.Hour)
+		_, err = usecase.DeliverDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "connection refused", deliveryRepo.deliveries[0].LastError)
+		assert.Equal(t, 0, deliveryRepo.deliveries[0].ResponseStatus)
+
+		*current = now.Add(3 * time.Hour)
+		result, err = usecase.DeliverDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &WebhookDeliveryResult{Failed: 1}, result)
+		delivery = deliveryRepo.deliveries[0]
+		assert.Equal(t, entity.WebhookDeliveryFailed, delivery.Status)
+		assert.Equal(t, 3, delivery.Attempts)
+		assert.Nil(t, delivery.NextAttemptAt)
+
+		// 再送しても同じ本文を送る
+		require.Len(t, sender.requests, 3)
+		assert.Equal(t, sender.requests[0].Body, sender.requests[2].Body)
+	})
+
+	t.Run("正常系: 送信先がない場合は送信を登録しない", func(t *testing.T) {
+		usecase, deliveryRepo, _ := newUsecase(&fakeWebhookSender{})
+
+		usecase.HandleItemEvent(context.Background(), ItemEvent{ItemID: 7, Action: entity.AuditCreate, Actor: "alice"})
+		assert.Empty(t, deliveryRepo.deliveries)
+		select {
+		case <-usecase.Pending():
+			t.Fatal("unexpected pending notification")
+		default:
+		}
+	})
+}
@@ -0,0 +1,130 @@
+-- データベースの文字セットを明示的に設定
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+SET CHARACTER SET utf8mb4;
+
+-- Create items table for managing valuable items and collections
+CREATE TABLE IF NOT EXISTS items (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL COMMENT 'Item name',
+    category VARCHAR(50) NOT NULL COMMENT 'Item category: 時計, バッグ, ジュエリー, 靴, その他',
+    brand VARCHAR(100) NOT NULL COMMENT 'Brand name',
+    purchase_price BIGINT NOT NULL DEFAULT 0 COMMENT 'Purchase price in minor units of the currency',
+    currency CHAR(3) NOT NULL DEFAULT 'JPY' COMMENT 'ISO 4217 currency code of purchase_price',
+    purchase_date DATE NULL COMMENT 'Purchase date in YYYY-MM-DD format (NULL only for drafts)',
+    attributes JSON NULL COMMENT 'Category-specific attributes (e.g. reference_number, material)',
+    purchase_country CHAR(2) NULL COMMENT 'ISO 3166-1 alpha-2 code of the country/region of purchase',
+    exchange_rate DECIMAL(18, 6) NULL COMMENT 'JPY per unit of currency on purchase_date, frozen at creation (NULL for JPY)',
+    purchase_price_jpy BIGINT NULL COMMENT 'JPY equivalent of purchase_price (NULL if the rate is unknown)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+    deleted_at TIMESTAMP NULL DEFAULT NULL COMMENT 'Soft-delete timestamp (NULL if not deleted)',
+    on_hold BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Legal hold flag (blocks deletion and updates)',
+    hold_reason VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Reason for the legal hold',
+    dedupe_key CHAR(64) NOT NULL DEFAULT '' COMMENT 'SHA-256 of the normalized name and brand for duplicate detection',
+    draft BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Draft flag (partially filled, excluded from summaries and budgets)',
+    purge_at TIMESTAMP NULL DEFAULT NULL COMMENT 'Scheduled hard-delete time (NULL if not scheduled)',
+    
+    INDEX idx_category (category),
+    INDEX idx_brand (brand),
+    INDEX idx_purchase_date (purchase_date),
+    INDEX idx_created_at (created_at),
+    INDEX idx_deleted_at (deleted_at),
+    INDEX idx_dedupe_key (dedupe_key),
+    INDEX idx_purge_at (purge_at),
+    INDEX idx_purchase_country (purchase_country, purchase_date),
+    FULLTEXT INDEX ft_name_brand (name, brand) WITH PARSER ngram
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for managing valuable items and collections';
+
+-- Create item_images table for photos attached to items
+CREATE TABLE IF NOT EXISTS item_images (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Item the photo belongs to',
+    file_name VARCHAR(255) NOT NULL COMMENT 'Original file name',
+    content_type VARCHAR(50) NOT NULL COMMENT 'MIME type detected from the content',
+    size BIGINT NOT NULL COMMENT 'File size in bytes',
+    storage_key VARCHAR(255) NOT NULL COMMENT 'Key of the file in the image storage',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_id (item_id),
+    CONSTRAINT fk_item_images_item FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Photos attached to items';
+
+-- Create item_valuations table for the value history of items
+CREATE TABLE IF NOT EXISTS item_valuations (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Item the valuation belongs to',
+    valuated_at DATE NOT NULL COMMENT 'Date of the valuation',
+    value BIGINT NOT NULL COMMENT 'Valuation in minor units of the currency',
+    currency CHAR(3) NOT NULL DEFAULT 'JPY' COMMENT 'ISO 4217 currency code of value',
+    source VARCHAR(100) NOT NULL COMMENT 'Where the valuation came from (appraiser, market site, etc.)',
+    reason VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Why the valuation was recorded (e.g. bulk adjustment reason)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_valuated_at (item_id, valuated_at),
+    CONSTRAINT fk_item_valuations_item FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Value history of items';
+
+-- Create tags table for labels shared across items
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(50) NOT NULL COMMENT 'Lower-cased tag name',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE INDEX uq_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Tags attached to items';
+
+-- Create item_tags table linking items and tags (many-to-many)
+CREATE TABLE IF NOT EXISTS item_tags (
+    item_id BIGINT NOT NULL COMMENT 'Tagged item',
+    tag_id BIGINT NOT NULL COMMENT 'Attached tag',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    PRIMARY KEY (item_id, tag_id),
+    INDEX idx_tag_id (tag_id),
+    CONSTRAINT fk_item_tags_item FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE,
+    CONSTRAINT fk_item_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Tags attached to each item';
+
+-- Create item_audit_logs table recording every mutation of items
+-- Kept without a foreign key so that the history outlives the item
+CREATE TABLE IF NOT EXISTS item_audit_logs (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Mutated item',
+    action VARCHAR(20) NOT NULL COMMENT 'create, update, delete, restore, hold, revert, publish, schedule_purge, cancel_purge or purge',
+    actor VARCHAR(100) NOT NULL COMMENT 'Who made the change (X-User-ID header)',
+    changes JSON NOT NULL COMMENT 'Changed fields with old and new values',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'When the change was made',
+
+    INDEX idx_item_id (item_id, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Audit log of item mutations';
+
+-- Create item_revisions table storing a full snapshot of the item on every change
+-- Kept without a foreign key so that the snapshots outlive the item, like the audit log
+CREATE TABLE IF NOT EXISTS item_revisions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Snapshotted item',
+    version INT NOT NULL COMMENT 'Version number per item, starting at 1',
+    snapshot JSON NOT NULL COMMENT 'Item fields at this version',
+    actor VARCHAR(100) NOT NULL COMMENT 'Who made the change (X-User-ID header)',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'When the version was saved',
+
+    UNIQUE KEY uk_item_version (item_id, version)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Versioned snapshots of items';
+
+-- Create category_budgets table for annual spending budgets per category
+CREATE TABLE IF NOT EXISTS category_budgets (
+    category VARCHAR(50) NOT NULL PRIMARY KEY COMMENT 'Item category',
+    amount BIGINT NOT NULL COMMENT 'Annual budget in minor units of the currency',
+    currency CHAR(3) NOT NULL DEFAULT 'JPY' COMMENT 'ISO 4217 currency code of amount',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Annual spending budgets per category';
+
+-- Create brand_aliases table for admin-managed brand spellings (override the built-in dictionary)
+CREATE TABLE IF NOT EXISTS brand_aliases (
+    alias_key VARCHAR(100) NOT NULL PRIMARY KEY COMMENT 'Alias normalized for comparison (NFKC, lower case, letters and digits only)',
+    alias VARCHAR(100) NOT NULL COMMENT 'Alias as entered',
+    brand VARCHAR(100) NOT NULL COMMENT 'Canonical brand name',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Brand aliases normalized at create/update time';
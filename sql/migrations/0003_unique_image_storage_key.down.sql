@@ -0,0 +1 @@
+ALTER TABLE item_images DROP INDEX uq_storage_key;
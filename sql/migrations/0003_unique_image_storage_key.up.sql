@@ -0,0 +1,2 @@
+-- A stored file belongs to at most one photo (direct uploads are attached by key)
+ALTER TABLE item_images ADD UNIQUE INDEX uq_storage_key (storage_key);
@@ -0,0 +1,4 @@
+ALTER TABLE items
+    DROP COLUMN tax_amount_jpy,
+    DROP COLUMN tax_included,
+    DROP COLUMN tax_amount;
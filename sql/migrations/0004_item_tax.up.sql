@@ -0,0 +1,5 @@
+-- Tax paid on purchase (in the purchase currency) and whether purchase_price includes it
+ALTER TABLE items
+    ADD COLUMN tax_amount BIGINT NULL COMMENT 'Tax paid on purchase in the smallest unit of currency (NULL if not recorded)' AFTER purchase_price_jpy,
+    ADD COLUMN tax_included BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Whether purchase_price includes tax_amount' AFTER tax_amount,
+    ADD COLUMN tax_amount_jpy BIGINT NULL COMMENT 'JPY equivalent of tax_amount at exchange_rate (NULL if the rate is unknown)' AFTER tax_included;
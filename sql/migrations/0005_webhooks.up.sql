@@ -0,0 +1,30 @@
+-- Create webhooks table holding callback URLs notified of item lifecycle events
+CREATE TABLE IF NOT EXISTS webhooks (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL COMMENT 'Callback URL receiving POST requests',
+    events VARCHAR(255) NOT NULL COMMENT 'Comma-separated events: item.created, item.updated, item.deleted',
+    secret VARCHAR(100) NOT NULL COMMENT 'HMAC-SHA256 key signing each delivery',
+    owner VARCHAR(100) NOT NULL COMMENT 'Who registered the webhook (X-User-ID header)',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_owner (owner)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Webhook endpoints';
+
+-- Create webhook_deliveries table queueing each event per webhook and logging its attempts
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    webhook_id BIGINT NOT NULL,
+    event VARCHAR(20) NOT NULL COMMENT 'item.created, item.updated or item.deleted',
+    payload JSON NOT NULL COMMENT 'Request body, identical across retries',
+    status VARCHAR(20) NOT NULL COMMENT 'pending, succeeded or failed',
+    attempts INT NOT NULL DEFAULT 0,
+    response_status INT NOT NULL DEFAULT 0 COMMENT 'HTTP status of the last attempt (0 if the request failed)',
+    last_error VARCHAR(500) NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP NULL DEFAULT NULL COMMENT 'When to attempt next (NULL unless pending)',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_status_next_attempt_at (status, next_attempt_at),
+    INDEX idx_webhook_id (webhook_id, id),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Webhook delivery queue and log';
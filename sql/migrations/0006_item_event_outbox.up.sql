@@ -0,0 +1,15 @@
+-- Create item_event_outbox table recording item events in the same transaction as the mutation,
+-- so that a relay can publish them to the event handlers (webhooks, search index) at least once
+CREATE TABLE IF NOT EXISTS item_event_outbox (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL,
+    action VARCHAR(20) NOT NULL COMMENT 'Audit action of the mutation (create, update, delete, ...)',
+    actor VARCHAR(100) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0 COMMENT 'Failed publish attempts',
+    last_error VARCHAR(500) NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP NULL DEFAULT NULL COMMENT 'When all handlers processed the event (NULL while unpublished)',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_published_at_next_attempt_at (published_at, next_attempt_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Transactional outbox of item events';
@@ -0,0 +1,20 @@
+-- Create jobs table queueing long-running work (valuation refresh, export generation, ...) for the worker pool
+CREATE TABLE IF NOT EXISTS jobs (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    type VARCHAR(50) NOT NULL COMMENT 'Job type selecting the handler, e.g. valuation.refresh',
+    payload JSON NOT NULL,
+    status VARCHAR(20) NOT NULL COMMENT 'queued, running, succeeded or failed',
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL,
+    last_error VARCHAR(500) NOT NULL DEFAULT '',
+    result JSON NULL,
+    owner VARCHAR(100) NOT NULL COMMENT 'Who enqueued the job (X-User-ID header)',
+    run_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'When to run next (while queued)',
+    locked_until TIMESTAMP NULL DEFAULT NULL COMMENT 'Lease of the running worker; the job is run again once it expires',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP NULL DEFAULT NULL,
+
+    INDEX idx_status_run_at (status, run_at),
+    INDEX idx_status_locked_until (status, locked_until)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Background job queue';
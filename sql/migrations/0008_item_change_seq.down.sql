@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS item_change_sequence;
+
+ALTER TABLE items
+    DROP INDEX idx_change_seq,
+    DROP COLUMN change_seq,
+    MODIFY COLUMN updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp';
@@ -0,0 +1,16 @@
+-- Change sequence number of items, taken from a single counter on every modification of the item row,
+-- so that sync clients can fetch modifications in a total order.
+-- updated_at is set by the application (clock of the usecase) instead of ON UPDATE CURRENT_TIMESTAMP
+ALTER TABLE items
+    MODIFY COLUMN updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Last update of the item fields (set by the application)',
+    ADD COLUMN change_seq BIGINT NOT NULL DEFAULT 0 COMMENT 'Sequence number of the last modification (item_change_sequence)' AFTER updated_at,
+    ADD INDEX idx_change_seq (change_seq);
+
+CREATE TABLE IF NOT EXISTS item_change_sequence (
+    id TINYINT PRIMARY KEY,
+    value BIGINT NOT NULL COMMENT 'Last assigned change sequence number'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Counter of item change sequence numbers (single row)';
+
+-- Existing items are numbered in ID order
+UPDATE items SET change_seq = id;
+INSERT INTO item_change_sequence (id, value) SELECT 1, COALESCE(MAX(id), 0) FROM items;
@@ -0,0 +1,19 @@
+-- Create job_runs table recording each run of the scheduled jobs (valuation refresh, ...) with its summary
+CREATE TABLE IF NOT EXISTS job_runs (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(50) NOT NULL COMMENT 'Scheduled job, e.g. valuation.refresh',
+    schedule VARCHAR(100) NOT NULL COMMENT 'Cron expression the run was scheduled by',
+    scheduled_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL COMMENT 'running, succeeded or failed',
+    total INT NOT NULL DEFAULT 0,
+    succeeded INT NOT NULL DEFAULT 0,
+    skipped INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    errors JSON NULL COMMENT 'Failed items and reasons (capped)',
+    last_error VARCHAR(500) NOT NULL DEFAULT '' COMMENT 'Why the run was aborted',
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP NULL DEFAULT NULL,
+
+    UNIQUE KEY uk_name_scheduled_at (name, scheduled_at) COMMENT 'Only one server runs each scheduled time',
+    INDEX idx_started_at (started_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Runs of the scheduled jobs';
@@ -0,0 +1,17 @@
+-- Create category_suggestions table queueing suggested categories of items in その他 for human review
+CREATE TABLE IF NOT EXISTS category_suggestions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL,
+    from_category VARCHAR(50) NOT NULL COMMENT 'Category of the item when suggested',
+    category VARCHAR(50) NOT NULL COMMENT 'Suggested category',
+    confidence DOUBLE NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL COMMENT 'pending, approved or rejected',
+    reviewed_by VARCHAR(100) NOT NULL DEFAULT '',
+    reviewed_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_status_id (status, id),
+    INDEX idx_item_id_status (item_id, status),
+    CONSTRAINT fk_category_suggestions_item FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Suggested categories awaiting review';
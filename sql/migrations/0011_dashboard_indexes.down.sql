@@ -0,0 +1,2 @@
+ALTER TABLE webhook_deliveries DROP INDEX idx_created_at_webhook_id;
+ALTER TABLE item_audit_logs DROP INDEX idx_created_at_actor;
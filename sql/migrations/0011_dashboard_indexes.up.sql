@@ -0,0 +1,3 @@
+-- Indexes for the admin dashboard, which aggregates audit logs and webhook deliveries over a recent window
+ALTER TABLE item_audit_logs ADD INDEX idx_created_at_actor (created_at, actor, action);
+ALTER TABLE webhook_deliveries ADD INDEX idx_created_at_webhook_id (created_at, webhook_id, status);
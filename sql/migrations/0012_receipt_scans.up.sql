@@ -0,0 +1,14 @@
+-- Create receipt_scans table for purchase fields read from receipt photos by OCR
+CREATE TABLE IF NOT EXISTS receipt_scans (
+    image_id BIGINT PRIMARY KEY COMMENT 'Photo the receipt was read from (latest scan only)',
+    item_id BIGINT NOT NULL,
+    purchase_price BIGINT NULL DEFAULT NULL COMMENT 'Total in minor units of the currency, NULL if not found',
+    currency CHAR(3) NOT NULL DEFAULT '',
+    purchase_date DATE NULL DEFAULT NULL,
+    store_name VARCHAR(100) NOT NULL DEFAULT '',
+    text TEXT NOT NULL COMMENT 'Full text recognized by OCR',
+    scanned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_item_id (item_id),
+    CONSTRAINT fk_receipt_scans_image FOREIGN KEY (image_id) REFERENCES item_images (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Purchase fields read from receipt photos';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS sales;
+
+ALTER TABLE items DROP COLUMN sold;
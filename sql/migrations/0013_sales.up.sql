@@ -0,0 +1,24 @@
+-- Mark sold items and record their sales for realized profit/loss
+ALTER TABLE items
+    ADD COLUMN sold BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Sold flag (the sale is recorded in sales)' AFTER draft;
+
+-- Sales are kept when the item is purged, so the item is not referenced by a foreign key
+CREATE TABLE IF NOT EXISTS sales (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL,
+    category VARCHAR(50) NOT NULL COMMENT 'Category of the item when sold',
+    sale_price BIGINT NOT NULL COMMENT 'Sale price in minor units of the currency',
+    currency CHAR(3) NOT NULL COMMENT 'ISO 4217 currency code of sale_price and fees',
+    fees BIGINT NOT NULL DEFAULT 0 COMMENT 'Fees paid on the sale in minor units of the currency',
+    sale_date DATE NOT NULL,
+    exchange_rate DECIMAL(18, 6) NULL COMMENT 'JPY per unit of currency on sale_date (NULL for JPY)',
+    cost_basis BIGINT NOT NULL COMMENT 'Purchase price of the item when sold',
+    cost_currency CHAR(3) NOT NULL,
+    cost_basis_jpy BIGINT NULL COMMENT 'JPY equivalent of cost_basis (NULL if unknown)',
+    proceeds_jpy BIGINT NULL COMMENT 'JPY equivalent of sale_price - fees (NULL if the rate is unknown)',
+    realized_gain_jpy BIGINT NULL COMMENT 'proceeds_jpy - cost_basis_jpy (NULL if either is unknown)',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_item_id (item_id),
+    INDEX idx_sale_date (sale_date)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Sales of items';
@@ -0,0 +1,3 @@
+ALTER TABLE items
+    DROP INDEX idx_split_from,
+    DROP COLUMN split_from;
@@ -0,0 +1,6 @@
+-- Link components to the item they were split from (e.g. a jewelry set split into individual pieces).
+-- The original item is soft-deleted on split and kept for audit, so the link is not a foreign key
+-- (purging the original keeps its components)
+ALTER TABLE items
+    ADD COLUMN split_from BIGINT NULL COMMENT 'ID of the item this item was split from' AFTER sold,
+    ADD INDEX idx_split_from (split_from);
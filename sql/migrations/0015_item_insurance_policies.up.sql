@@ -0,0 +1,16 @@
+-- Insurance policies covering items (an item can be covered by several policies)
+CREATE TABLE IF NOT EXISTS item_insurance_policies (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Insured item',
+    policy_number VARCHAR(100) NOT NULL COMMENT 'Policy number issued by the insurer',
+    insurer VARCHAR(100) NOT NULL COMMENT 'Name of the insurance company',
+    coverage BIGINT NOT NULL COMMENT 'Coverage amount in minor units of the currency',
+    currency CHAR(3) NOT NULL DEFAULT 'JPY' COMMENT 'ISO 4217 currency code of coverage',
+    expires_on DATE NOT NULL COMMENT 'Last day the policy is in force',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_item_policy (item_id, insurer, policy_number),
+    INDEX idx_expires_on (expires_on),
+    CONSTRAINT fk_item_insurance_policies_item FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Insurance policies of items';
@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// バイナリに埋め込むマイグレーション（{バージョン}_{名前}.up.sql / .down.sql）
+// 適用済みのファイルは変更せず、スキーマの変更は新しいバージョンのファイルとして追加する
+//
+//go:embed *.sql
+var FS embed.FS
+
+//go:embed sqlite/*.sql
+var sqliteFS embed.FS
+
+// SQLite 用のマイグレーション（ローカル開発・テスト用）
+// MySQL のマイグレーションを追加する場合は、同じバージョンで同じスキーマを作るファイルをこちらにも追加する
+var SQLiteFS, _ = fs.Sub(sqliteFS, "sqlite")
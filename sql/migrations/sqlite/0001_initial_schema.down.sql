@@ -0,0 +1,10 @@
+-- Drop all tables (child tables first)
+DROP TABLE IF EXISTS brand_aliases;
+DROP TABLE IF EXISTS category_budgets;
+DROP TABLE IF EXISTS item_revisions;
+DROP TABLE IF EXISTS item_audit_logs;
+DROP TABLE IF EXISTS item_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS item_valuations;
+DROP TABLE IF EXISTS item_images;
+DROP TABLE IF EXISTS items;
@@ -0,0 +1,105 @@
+-- SQLite 版の初期スキーマ（MySQL 版の 0001_initial_schema と同じテーブル・インデックス）
+-- 日時は CURRENT_TIMESTAMP と同じ UTC の文字列、真偽値は 0 / 1、JSON は文字列で保存する
+CREATE TABLE IF NOT EXISTS items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    category VARCHAR(50) NOT NULL,
+    brand VARCHAR(100) NOT NULL,
+    purchase_price BIGINT NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL DEFAULT 'JPY',
+    purchase_date DATE NULL,
+    attributes JSON NULL,
+    purchase_country CHAR(2) NULL,
+    exchange_rate DECIMAL(18, 6) NULL,
+    purchase_price_jpy BIGINT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+    on_hold BOOLEAN NOT NULL DEFAULT FALSE,
+    hold_reason VARCHAR(255) NOT NULL DEFAULT '',
+    dedupe_key CHAR(64) NOT NULL DEFAULT '',
+    draft BOOLEAN NOT NULL DEFAULT FALSE,
+    purge_at TIMESTAMP NULL DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_items_category ON items (category);
+CREATE INDEX IF NOT EXISTS idx_items_brand ON items (brand);
+CREATE INDEX IF NOT EXISTS idx_items_purchase_date ON items (purchase_date);
+CREATE INDEX IF NOT EXISTS idx_items_created_at ON items (created_at);
+CREATE INDEX IF NOT EXISTS idx_items_deleted_at ON items (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_items_dedupe_key ON items (dedupe_key);
+CREATE INDEX IF NOT EXISTS idx_items_purge_at ON items (purge_at);
+CREATE INDEX IF NOT EXISTS idx_items_purchase_country ON items (purchase_country, purchase_date);
+
+CREATE TABLE IF NOT EXISTS item_images (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id BIGINT NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_item_images_item_id ON item_images (item_id);
+
+CREATE TABLE IF NOT EXISTS item_valuations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id BIGINT NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+    valuated_at DATE NOT NULL,
+    value BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'JPY',
+    source VARCHAR(100) NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_item_valuations_item_valuated_at ON item_valuations (item_id, valuated_at);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS item_tags (
+    item_id BIGINT NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (item_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS idx_item_tags_tag_id ON item_tags (tag_id);
+
+-- 変更履歴と版はアイテムより長く残すため、外部キーを付けない
+CREATE TABLE IF NOT EXISTS item_audit_logs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id BIGINT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    changes JSON NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_item_audit_logs_item_id ON item_audit_logs (item_id, id);
+
+CREATE TABLE IF NOT EXISTS item_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id BIGINT NOT NULL,
+    version INT NOT NULL,
+    snapshot JSON NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (item_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS category_budgets (
+    category VARCHAR(50) NOT NULL PRIMARY KEY,
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'JPY',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS brand_aliases (
+    alias_key VARCHAR(100) NOT NULL PRIMARY KEY,
+    alias VARCHAR(100) NOT NULL,
+    brand VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
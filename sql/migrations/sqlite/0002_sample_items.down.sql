@@ -0,0 +1,8 @@
+-- Remove the sample data
+DELETE FROM items WHERE dedupe_key IN (
+    'b2b88afa9aeb0c430e969de6c97ffc9e0ecf932deac3ea3c4c30ff6eec884f8c',
+    'bd3e1312d26a14d094fa5f8dbb02b616b88d1aa48efcccf390fd5c803eb0270f',
+    '9f1cdae5b020e571a18bb45b20b2b38c835be22f83186066388f0fe04a19d30c',
+    'bd81bb5bad74535ea1930b01c0c32ab95b9f7e3dbd16080f59950a6f4fafdb1f',
+    'df24f9c3838ccd3c0219c1eb7b81d78ffed29844ca9fd8a315b940348b6082ed'
+);
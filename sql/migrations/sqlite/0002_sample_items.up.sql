@@ -0,0 +1,10 @@
+-- Insert sample data for testing (skipped if items already exist)
+INSERT INTO items (name, category, brand, purchase_price, purchase_price_jpy, purchase_date, dedupe_key)
+SELECT name, category, brand, purchase_price, purchase_price, purchase_date, dedupe_key FROM (
+    SELECT 'ロレックス デイトナ' AS name, '時計' AS category, 'ROLEX' AS brand, 1500000 AS purchase_price, '2023-01-15' AS purchase_date, 'b2b88afa9aeb0c430e969de6c97ffc9e0ecf932deac3ea3c4c30ff6eec884f8c' AS dedupe_key
+    UNION ALL SELECT 'エルメス バーキン', 'バッグ', 'HERMÈS', 2000000, '2023-02-20', 'bd3e1312d26a14d094fa5f8dbb02b616b88d1aa48efcccf390fd5c803eb0270f'
+    UNION ALL SELECT 'ティファニー ネックレス', 'ジュエリー', 'Tiffany & Co.', 300000, '2023-03-10', '9f1cdae5b020e571a18bb45b20b2b38c835be22f83186066388f0fe04a19d30c'
+    UNION ALL SELECT 'ルブタン パンプス', '靴', 'Christian Louboutin', 150000, '2023-04-05', 'bd81bb5bad74535ea1930b01c0c32ab95b9f7e3dbd16080f59950a6f4fafdb1f'
+    UNION ALL SELECT 'アップルウォッチ', 'その他', 'Apple', 50000, '2023-05-12', 'df24f9c3838ccd3c0219c1eb7b81d78ffed29844ca9fd8a315b940348b6082ed'
+) AS samples
+WHERE NOT EXISTS (SELECT 1 FROM items);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS uq_item_images_storage_key;
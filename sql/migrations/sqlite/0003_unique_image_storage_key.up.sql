@@ -0,0 +1,2 @@
+-- A stored file belongs to at most one photo (direct uploads are attached by key)
+CREATE UNIQUE INDEX IF NOT EXISTS uq_item_images_storage_key ON item_images (storage_key);
@@ -0,0 +1,3 @@
+ALTER TABLE items DROP COLUMN tax_amount_jpy;
+ALTER TABLE items DROP COLUMN tax_included;
+ALTER TABLE items DROP COLUMN tax_amount;
@@ -0,0 +1,4 @@
+-- Tax paid on purchase (in the purchase currency) and whether purchase_price includes it
+ALTER TABLE items ADD COLUMN tax_amount BIGINT NULL;
+ALTER TABLE items ADD COLUMN tax_included BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE items ADD COLUMN tax_amount_jpy BIGINT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS item_change_sequence;
+DROP INDEX IF EXISTS idx_items_change_seq;
+ALTER TABLE items DROP COLUMN change_seq;
@@ -0,0 +1,12 @@
+-- Change sequence number of items, taken from a single counter on every modification of the item row
+ALTER TABLE items ADD COLUMN change_seq BIGINT NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_items_change_seq ON items (change_seq);
+
+CREATE TABLE IF NOT EXISTS item_change_sequence (
+    id INTEGER PRIMARY KEY,
+    value BIGINT NOT NULL
+);
+
+-- Existing items are numbered in ID order
+UPDATE items SET change_seq = id;
+INSERT INTO item_change_sequence (id, value) SELECT 1, COALESCE(MAX(id), 0) FROM items;
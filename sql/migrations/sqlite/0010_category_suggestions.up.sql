@@ -0,0 +1,15 @@
+-- Suggested categories awaiting review
+CREATE TABLE IF NOT EXISTS category_suggestions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id BIGINT NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+    from_category VARCHAR(50) NOT NULL,
+    category VARCHAR(50) NOT NULL,
+    confidence DOUBLE NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    reviewed_by VARCHAR(100) NOT NULL DEFAULT '',
+    reviewed_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_category_suggestions_status_id ON category_suggestions (status, id);
+CREATE INDEX IF NOT EXISTS idx_category_suggestions_item_id_status ON category_suggestions (item_id, status);
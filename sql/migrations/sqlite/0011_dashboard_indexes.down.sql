@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_webhook_deliveries_created_at_webhook_id;
+DROP INDEX IF EXISTS idx_item_audit_logs_created_at_actor;
@@ -0,0 +1,3 @@
+-- Indexes for the admin dashboard, which aggregates audit logs and webhook deliveries over a recent window
+CREATE INDEX IF NOT EXISTS idx_item_audit_logs_created_at_actor ON item_audit_logs (created_at, actor, action);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created_at_webhook_id ON webhook_deliveries (created_at, webhook_id, status);
@@ -0,0 +1,12 @@
+-- Purchase fields read from receipt photos
+CREATE TABLE IF NOT EXISTS receipt_scans (
+    image_id BIGINT PRIMARY KEY REFERENCES item_images (id) ON DELETE CASCADE,
+    item_id BIGINT NOT NULL,
+    purchase_price BIGINT NULL DEFAULT NULL,
+    currency CHAR(3) NOT NULL DEFAULT '',
+    purchase_date DATE NULL DEFAULT NULL,
+    store_name VARCHAR(100) NOT NULL DEFAULT '',
+    text TEXT NOT NULL,
+    scanned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_receipt_scans_item_id ON receipt_scans (item_id);
@@ -0,0 +1,21 @@
+-- Mark sold items and record their sales for realized profit/loss
+ALTER TABLE items ADD COLUMN sold BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Sales are kept when the item is purged, so the item is not referenced by a foreign key
+CREATE TABLE IF NOT EXISTS sales (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id BIGINT NOT NULL UNIQUE,
+    category VARCHAR(50) NOT NULL,
+    sale_price BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    fees BIGINT NOT NULL DEFAULT 0,
+    sale_date DATE NOT NULL,
+    exchange_rate DECIMAL(18, 6) NULL,
+    cost_basis BIGINT NOT NULL,
+    cost_currency CHAR(3) NOT NULL,
+    cost_basis_jpy BIGINT NULL,
+    proceeds_jpy BIGINT NULL,
+    realized_gain_jpy BIGINT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_sales_sale_date ON sales (sale_date);
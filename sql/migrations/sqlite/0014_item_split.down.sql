@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_items_split_from;
+ALTER TABLE items DROP COLUMN split_from;
@@ -0,0 +1,3 @@
+-- Link components to the item they were split from (the original is soft-deleted and kept for audit)
+ALTER TABLE items ADD COLUMN split_from BIGINT NULL;
+CREATE INDEX IF NOT EXISTS idx_items_split_from ON items (split_from);
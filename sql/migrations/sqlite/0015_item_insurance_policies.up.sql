@@ -0,0 +1,14 @@
+-- Insurance policies covering items (an item can be covered by several policies)
+CREATE TABLE IF NOT EXISTS item_insurance_policies (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id BIGINT NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+    policy_number VARCHAR(100) NOT NULL,
+    insurer VARCHAR(100) NOT NULL,
+    coverage BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'JPY',
+    expires_on DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (item_id, insurer, policy_number)
+);
+CREATE INDEX IF NOT EXISTS idx_item_insurance_policies_expires_on ON item_insurance_policies (expires_on);